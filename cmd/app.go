@@ -0,0 +1,25 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var appCmd = &cobra.Command{
+	Use:     "app",
+	GroupID: "appGroup",
+	Short:   "Manage GitOps applications",
+	Long: `Register, inspect, sync, and delete GitOps applications managed by gitopsctl.
+
+Each application ties a path in a Git repository to a registered Kubernetes cluster.`,
+	Example: `  # Register an application
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s -c production
+
+  # List registered applications
+  gitopsctl app list
+
+  # Trigger an immediate sync
+  gitopsctl app sync myapp`,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(appCmd)
+}
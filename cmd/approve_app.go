@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	approveAppPlanID        string // ID of the plan or change to approve, the pending one if empty
+	approveAppControlSocket string // Path of the controller's local control socket
+)
+
+var approveAppCmd = &cobra.Command{
+	Use:   "approve <name>",
+	Short: "Approve an application's pending plan or change so it is applied",
+	Long: `Approves the plan a Terraform application is waiting on, or the change an application
+registered with --approval-required is waiting on, after showing its summary and diff.
+The plan is applied by the next sync, which starts immediately if the controller is running.
+
+An approval only covers the plan it names: if a newer commit is pushed before the plan is
+applied, the controller plans the new commit instead and that plan must be approved again.
+Pass --plan with the ID shown by 'gitopsctl app describe' to make sure the plan you reviewed
+is the one approved.
+
+If the controller is running, the approval is sent through its local control socket.
+Otherwise it is stored and the plan is applied when the controller starts.`,
+	Example: `  # Approve the pending plan of an application
+  gitopsctl app approve network
+
+  # Approve a specific plan, failing if it was replaced in the meantime
+  gitopsctl app approve network --plan 3f2a9c1b7d4e`,
+	Args: cobra.ExactArgs(1),
+	RunE: runApproveAppCommand,
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return appNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func runApproveAppCommand(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+
+	apps, targetApp, err := loadAndFindApplication(name)
+	if err != nil {
+		return err
+	}
+	if targetApp == nil {
+		return fmt.Errorf("application '%s' not found\nUse 'gitopsctl app list' to see registered applications", name)
+	}
+	if !targetApp.RequiresApproval() {
+		return fmt.Errorf("application '%s' does not require approval; its changes are applied as they are detected", name)
+	}
+
+	planID := strings.TrimSpace(approveAppPlanID)
+	if planID == "" {
+		if plan := targetApp.TerraformPlan; targetApp.IsTerraform() && plan.IsPending() {
+			planID = plan.ID
+		} else if change := targetApp.PendingChange; !targetApp.IsTerraform() && change != nil {
+			planID = change.ID
+		}
+	}
+	// Validate against a copy so the stored application is only changed once the approval is sent
+	approved := *targetApp
+	if err := approved.ApprovePlan(planID, time.Now()); err != nil {
+		return err
+	}
+
+	if plan := targetApp.TerraformPlan; targetApp.IsTerraform() {
+		fmt.Printf("📋 Plan %s of application '%s' at %s:\n", plan.ID, name, plan.GitHash)
+		fmt.Printf("   %s\n\n", plan.Summary)
+	} else {
+		change := targetApp.PendingChange
+		fmt.Printf("📋 Change %s of application '%s' at %s:\n", change.ID, name, change.GitHash)
+		fmt.Printf("   %s\n\n", change.Summary)
+		for _, line := range strings.Split(strings.TrimSuffix(change.Diff, "\n"), "\n") {
+			fmt.Printf("   %s\n", line)
+		}
+		fmt.Println()
+	}
+
+	remote, err := controller.NewRemoteClient(logger, approveAppControlSocket)
+	if err == nil && remote.IsDispatcherRunning() {
+		remote.ApprovePlan(name, planID)
+		logger.Info("Plan approval requested via CLI", zap.String("name", name), zap.String("plan", planID))
+		fmt.Printf("✅ Approved %s; the controller is applying it now.\n", planID)
+	} else {
+		logger.Debug("Controller not reachable, approving plan in configuration", zap.Error(err))
+		apps.Lock()
+		targetApp.TerraformPlan = approved.TerraformPlan
+		targetApp.PendingChange = approved.PendingChange
+		saveErr := app.SaveApplications(apps, app.DefaultAppConfigFile)
+		apps.Unlock()
+		if saveErr != nil {
+			logger.Error("Failed to save application configuration", zap.String("app", name), zap.Error(saveErr))
+			return fmt.Errorf("failed to save application configuration: %w", saveErr)
+		}
+		logger.Info("Plan approved in configuration", zap.String("name", name), zap.String("plan", planID))
+		fmt.Printf("✅ Approved %s.\n", planID)
+		fmt.Printf("   The controller is not running; it is applied when the controller starts, unless the branch has moved on.\n")
+	}
+
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • Follow the apply: gitopsctl app describe %s\n", name)
+	fmt.Printf("  • Monitor sync status: gitopsctl app status --watch\n")
+	return nil
+}
+
+func init() {
+	appCmd.AddCommand(approveAppCmd)
+
+	approveAppCmd.Flags().StringVar(&approveAppPlanID, "plan", "", "ID of the plan or change to approve (default: the pending one)")
+	approveAppCmd.Flags().StringVar(&approveAppControlSocket, "control-socket", controller.DefaultControlSocket, "Path of the controller's local control socket")
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	auditAppName     string // Only show entries for this application
+	auditClusterName string // Only show entries for this cluster
+	auditActor       string // Only show entries by this actor
+	auditOutput      string // Output format
+	auditNoHeader    bool   // Hide table headers
+	auditDetails     bool   // Show additional details
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show who made manual changes and why",
+	Long: `Displays the audit log: the manual syncs, rollbacks, suspensions and registrations of
+applications and clusters, with who requested them and the reason they gave with --reason.
+
+Changes made through the API are recorded with the authenticated user or API token, and
+changes made with the CLI with the local user. Entries are never pruned; the log is a JSON
+Lines file that can be shipped to a log pipeline as is.`,
+	Example: `  # Show all recorded changes
+  gitopsctl audit
+
+  # Show the changes to an application, with their details
+  gitopsctl audit --app myapp --details
+
+  # Show the changes made by a user as JSON
+  gitopsctl audit --actor alice@example.com -o json`,
+	Args: cobra.NoArgs,
+	RunE: runAuditCommand,
+}
+
+func runAuditCommand(cmd *cobra.Command, args []string) error {
+	if auditAppName != "" && auditClusterName != "" {
+		return fmt.Errorf("only one of --app or --cluster may be specified")
+	}
+
+	entries, err := audit.Load(audit.DefaultAuditFile)
+	if err != nil {
+		logger.Error("Failed to load audit log", zap.Error(err))
+		return err
+	}
+
+	kind, name := "", ""
+	switch {
+	case auditAppName != "":
+		kind, name = event.KindApplication, auditAppName
+	case auditClusterName != "":
+		kind, name = event.KindCluster, auditClusterName
+	}
+
+	var items []utils.Renderable
+	for i := range entries {
+		entry := &entries[i]
+		if kind != "" && (entry.Kind != kind || entry.Name != name) {
+			continue
+		}
+		if auditActor != "" && entry.Actor != auditActor {
+			continue
+		}
+		items = append(items, entry)
+	}
+
+	switch strings.ToLower(auditOutput) {
+	case "json":
+		return utils.RenderJSON(items)
+	case "yaml":
+		return utils.RenderYAML(items)
+	}
+	if len(items) == 0 {
+		fmt.Println("📋 No audit entries found")
+		return nil
+	}
+	return utils.RenderTable(items, auditNoHeader, auditDetails)
+}
+
+// recordAudit records a change made with the CLI in the audit log, as the local user.
+// A failure to record it is logged rather than failing the command, whose change has already been made.
+func recordAudit(action audit.Action, kind, name, reason, details string) {
+	entry := audit.Entry{
+		Actor:   audit.LocalActor(),
+		Action:  action,
+		Kind:    kind,
+		Name:    name,
+		Reason:  reason,
+		Details: details,
+	}
+	if err := audit.Record(audit.DefaultAuditFile, entry); err != nil {
+		logger.Warn("Failed to record audit entry", zap.String("name", name), zap.String("action", string(action)), zap.Error(err))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().StringVar(&auditAppName, "app", "", "Only show entries for this application")
+	auditCmd.Flags().StringVar(&auditClusterName, "cluster", "", "Only show entries for this cluster")
+	auditCmd.Flags().StringVar(&auditActor, "actor", "", "Only show entries by this actor (e.g., alice@example.com, token:ci, local:bob)")
+	auditCmd.Flags().StringVarP(&auditOutput, "output", "o", "table", "Output format: table, json, yaml")
+	auditCmd.Flags().BoolVar(&auditNoHeader, "no-header", false, "Hide table headers")
+	auditCmd.Flags().BoolVar(&auditDetails, "details", false, "Show additional details")
+
+	auditCmd.RegisterFlagCompletionFunc("app", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return appNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+	auditCmd.RegisterFlagCompletionFunc("cluster", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return clusterNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+	auditCmd.RegisterFlagCompletionFunc("output", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"table", "json", "yaml"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/core/auth"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	authConfigFile    string        // Path of the authentication configuration
+	authTokenFile     string        // Path of the API token store
+	authCanIToken     string        // API token to check
+	authCanIGroups    []string      // Identity provider groups to check
+	tokenPermissions  []string      // Permissions granted to a new token
+	tokenExpiresAfter time.Duration // Lifetime of a new token
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage API tokens and check permissions",
+	Long: `Manages the API tokens scripts and CI use to call the API, and checks which permissions
+tokens and identity provider groups are granted.
+
+The permissions are:
+  app:read       read applications, clusters and events
+  app:sync       sync, suspend, resume and approve applications, control their rollouts and health check clusters
+  app:write      register, rename and unregister applications
+  cluster:write  register, rename and unregister clusters
+  admin          everything
+
+The roles viewer (app:read), operator (app:read, app:sync) and admin can be used instead of listing permissions.`,
+	Args: cobra.NoArgs,
+}
+
+var authCanICmd = &cobra.Command{
+	Use:   "can-i <permission>",
+	Short: "Check whether a token or group is granted a permission",
+	Long: `Checks whether an API token, or a user in the given identity provider groups, is granted a
+permission. Prints "yes" or "no", and exits with a non-zero status for "no".
+
+The token can also be given in the GITOPSCTL_TOKEN environment variable.`,
+	Example: `  # Check whether a CI token may sync applications
+  gitopsctl auth can-i app:sync --token "$GITOPSCTL_TOKEN"
+
+  # Check whether members of a group may register clusters
+  gitopsctl auth can-i cluster:write --group sre`,
+	Args:      cobra.ExactArgs(1),
+	RunE:      runAuthCanICommand,
+	ValidArgs: permissionNames(),
+}
+
+var authTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Create, list and revoke API tokens",
+	Long: `Manages API tokens, which are sent in an "Authorization: Bearer <token>" header. Only a hash of
+each token is stored in --token-file, which the API server reloads when it changes. Creating the
+first token enables API authentication; restart the API server for it to take effect.`,
+	Args: cobra.NoArgs,
+}
+
+var createTokenCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an API token",
+	Long:  `Creates an API token with the given permissions and prints it. The token is shown only once.`,
+	Example: `  # Create a token for a CI pipeline that syncs applications
+  gitopsctl auth token create ci --permissions app:read,app:sync --expires-in 2160h
+
+  # Create a read-only token
+  gitopsctl auth token create grafana --permissions viewer`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCreateTokenCommand,
+}
+
+var listTokenCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API tokens",
+	Args:  cobra.NoArgs,
+	RunE:  runListTokenCommand,
+}
+
+var revokeTokenCmd = &cobra.Command{
+	Use:   "revoke <name>",
+	Short: "Revoke an API token",
+	Long:  `Revokes an API token. Requests made with it are rejected as soon as the API server notices the change.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRevokeTokenCommand,
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		tokens, _ := auth.LoadTokens(authTokenFile)
+		names := make([]string, len(tokens))
+		for i, token := range tokens {
+			names[i] = token.Name
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func runAuthCanICommand(cmd *cobra.Command, args []string) error {
+	permission := auth.Permission(strings.TrimSpace(args[0]))
+	if !slices.Contains(auth.Permissions, permission) {
+		return fmt.Errorf("invalid permission '%s'\nSupported permissions: %s", permission, strings.Join(permissionNames(), ", "))
+	}
+
+	token := authCanIToken
+	if token == "" {
+		token = os.Getenv("GITOPSCTL_TOKEN")
+	}
+	if token == "" && len(authCanIGroups) == 0 {
+		return fmt.Errorf("specify an API token with --token or GITOPSCTL_TOKEN, or groups with --group")
+	}
+
+	var grant auth.Grant
+	var subject string
+	if token != "" {
+		tokens, err := auth.LoadTokens(authTokenFile)
+		if err != nil {
+			return err
+		}
+		t, ok := tokens.Find(token)
+		if !ok {
+			return fmt.Errorf("unknown or revoked API token")
+		}
+		if t.Expired(time.Now()) {
+			return fmt.Errorf("API token '%s' expired at %s", t.Name, t.ExpiresAt.Format(time.RFC3339))
+		}
+		grant, subject = t.Permissions, fmt.Sprintf("token '%s'", t.Name)
+	} else {
+		config, err := auth.LoadConfig(authConfigFile)
+		if err != nil {
+			return err
+		}
+		if config.OIDC == nil {
+			return fmt.Errorf("no identity provider is configured in %s", authConfigFile)
+		}
+		grant, subject = config.PermissionsFor(authCanIGroups), fmt.Sprintf("groups %s", strings.Join(authCanIGroups, ", "))
+	}
+
+	logger.Debug("Checked permission", zap.String("subject", subject), zap.Stringer("granted", grant))
+	if !grant.Allows(permission) {
+		fmt.Println("no")
+		return fmt.Errorf("%s is not granted %s (granted: %s)", subject, permission, grantString(grant))
+	}
+	fmt.Println("yes")
+	return nil
+}
+
+func runCreateTokenCommand(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("token name must not be empty")
+	}
+	grant, err := auth.ParseGrant(tokenPermissions...)
+	if err != nil {
+		return err
+	}
+	if len(grant) == 0 {
+		return fmt.Errorf("specify the token's permissions with --permissions\nSupported permissions: %s", strings.Join(permissionNames(), ", "))
+	}
+
+	tokens, err := auth.LoadTokens(authTokenFile)
+	if err != nil {
+		return err
+	}
+	if _, exists := tokens.Get(name); exists {
+		return fmt.Errorf("a token named '%s' already exists\nRevoke it first with 'gitopsctl auth token revoke %s'", name, name)
+	}
+
+	token, secret, err := auth.NewToken(name, grant, tokenExpiresAfter)
+	if err != nil {
+		return err
+	}
+	if err := auth.SaveTokens(append(tokens, token), authTokenFile); err != nil {
+		return err
+	}
+	logger.Info("Created API token", zap.String("name", name), zap.Stringer("permissions", grant))
+
+	fmt.Printf("✅ Created API token '%s' with permissions %s.\n", name, grant)
+	if !token.ExpiresAt.IsZero() {
+		fmt.Printf("   It expires at %s.\n", token.ExpiresAt.Format(time.RFC3339))
+	}
+	fmt.Printf("\n%s\n\n", secret)
+	fmt.Printf("Store it now; it cannot be shown again. Send it in an \"Authorization: Bearer <token>\" header.\n")
+	if len(tokens) == 0 {
+		fmt.Printf("If API authentication was disabled, restart the API server to require it.\n")
+	}
+	return nil
+}
+
+func runListTokenCommand(cmd *cobra.Command, args []string) error {
+	tokens, err := auth.LoadTokens(authTokenFile)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		fmt.Println("📋 No API tokens. Create one with 'gitopsctl auth token create'.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "NAME\tPERMISSIONS\tCREATED\tEXPIRES")
+	now := time.Now()
+	for _, token := range tokens {
+		expires := "never"
+		if !token.ExpiresAt.IsZero() {
+			expires = token.ExpiresAt.Format("2006-01-02 15:04:05 MST")
+			if token.Expired(now) {
+				expires += " (expired)"
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			token.Name,
+			grantString(token.Permissions),
+			token.CreatedAt.Format("2006-01-02 15:04:05 MST"),
+			expires,
+		)
+	}
+	return w.Flush()
+}
+
+func runRevokeTokenCommand(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+
+	tokens, err := auth.LoadTokens(authTokenFile)
+	if err != nil {
+		return err
+	}
+	remaining := make(auth.Tokens, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Name != name {
+			remaining = append(remaining, token)
+		}
+	}
+	if len(remaining) == len(tokens) {
+		return fmt.Errorf("API token '%s' not found\nUse 'gitopsctl auth token list' to see the tokens", name)
+	}
+	if err := auth.SaveTokens(remaining, authTokenFile); err != nil {
+		return err
+	}
+	logger.Info("Revoked API token", zap.String("name", name))
+	fmt.Printf("✅ Revoked API token '%s'.\n", name)
+	return nil
+}
+
+// grantString returns the permissions of a grant, or "none".
+func grantString(grant auth.Grant) string {
+	if len(grant) == 0 {
+		return "none"
+	}
+	return grant.String()
+}
+
+// permissionNames returns the names of all permissions.
+func permissionNames() []string {
+	names := make([]string, len(auth.Permissions))
+	for i, permission := range auth.Permissions {
+		names[i] = string(permission)
+	}
+	return names
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authCanICmd, authTokenCmd)
+	authTokenCmd.AddCommand(createTokenCmd, listTokenCmd, revokeTokenCmd)
+
+	authCmd.PersistentFlags().StringVar(&authConfigFile, "auth-config", auth.DefaultAuthConfigFile, "Path of the authentication configuration file")
+	authCmd.PersistentFlags().StringVar(&authTokenFile, "token-file", auth.DefaultTokenFile, "Path of the API token store")
+
+	authCanICmd.Flags().StringVar(&authCanIToken, "token", "", "API token to check (defaults to $GITOPSCTL_TOKEN)")
+	authCanICmd.Flags().StringSliceVar(&authCanIGroups, "group", nil, "Identity provider group to check; repeat for a user in several groups")
+
+	createTokenCmd.Flags().StringSliceVarP(&tokenPermissions, "permissions", "p", nil, "Permissions or roles to grant, comma-separated (e.g., app:read,app:sync or viewer)")
+	createTokenCmd.Flags().DurationVar(&tokenExpiresAfter, "expires-in", 0, "How long the token is valid (e.g., 720h; 0 for a token that does not expire)")
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/backup"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	backupConfigFile string // Path of the scheduled backup configuration
+	backupOutput     string // Path the backup is written to
+	backupUpload     bool   // Upload the backup to the configured S3 target instead
+	backupNoSecrets  bool   // Leave secrets out of the backup, or do not restore them
+	backupForce      bool   // Restore without asking for confirmation
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up and restore the controller's state",
+	Long: `Snapshots the controller's state into a single archive and restores it: the application and
+cluster files, the sync, event and audit history, and the configuration files in configs/.
+
+Secrets, the API token store and the kubeconfig files of the registered clusters, are included
+encrypted with AES-256-GCM under the passphrase in ` + backup.DefaultPassphraseEnv + `, or the
+environment variable named by passphraseEnv in the backup configuration.
+
+The controller also creates backups on a schedule and uploads them to S3 or an S3-compatible
+object store when the backup configuration (` + backup.DefaultConfigFile + `) sets an interval:
+
+  {
+    "interval": "24h",
+    "s3": {"bucket": "my-backups", "region": "eu-west-1", "prefix": "gitopsctl/"}
+  }
+
+The S3 credentials are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, or the environment
+variables named by accessKeyIDEnv and secretAccessKeyEnv. Set endpoint to the URL of MinIO or
+another S3-compatible store. Expire old backups with a lifecycle rule on the bucket.`,
+	Args: cobra.NoArgs,
+}
+
+var createBackupCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a backup",
+	Long: `Creates a backup of the controller's state, written to a file named after the time it was
+created unless --output is given, or uploaded to the configured S3 target with --upload.
+Secrets are included, encrypted, when the passphrase is set, and left out with --no-secrets.`,
+	Example: `  # Create a backup, with secrets encrypted
+  GITOPSCTL_BACKUP_PASSPHRASE=... gitopsctl backup create
+
+  # Create a backup without secrets at a given path
+  gitopsctl backup create --no-secrets -o state.tar.gz
+
+  # Create a backup and upload it to the S3 target of the backup configuration
+  gitopsctl backup create --upload`,
+	Args: cobra.NoArgs,
+	RunE: runCreateBackupCommand,
+}
+
+var restoreBackupCmd = &cobra.Command{
+	Use:   "restore <archive>",
+	Short: "Restore a backup",
+	Long: `Restores a backup created with 'gitopsctl backup create', replacing the current state files.
+Secrets are decrypted with the passphrase and restored too, unless --no-secrets is given. Nothing
+is restored if the backed up clusters file points a cluster at a kubeconfig file the encrypted
+secrets do not hold, as the rest of the archive is not authenticated.
+
+The controller must be stopped first; start it again once the backup is restored. Files of an
+older format are migrated when it starts.`,
+	Example: `  # Restore a backup, with its secrets
+  GITOPSCTL_BACKUP_PASSPHRASE=... gitopsctl backup restore gitopsctl-backup-20250101T000000Z.tar.gz
+
+  # Restore only the state files, without asking for confirmation
+  gitopsctl backup restore state.tar.gz --no-secrets --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRestoreBackupCommand,
+}
+
+func runCreateBackupCommand(cmd *cobra.Command, args []string) error {
+	config, err := backup.LoadConfig(backupConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load backup config: %w", err)
+	}
+	passphrase := config.Passphrase()
+	if passphrase == "" && !backupNoSecrets {
+		return fmt.Errorf("set the passphrase to encrypt secrets with in %s, or leave them out with --no-secrets", config.PassphraseEnv)
+	}
+	if backupNoSecrets {
+		passphrase = ""
+	}
+
+	if backupUpload {
+		url, manifest, err := config.CreateAndUpload(context.Background(), passphrase)
+		if err != nil {
+			return err
+		}
+		logger.Info("Uploaded backup", zap.String("url", url))
+		printBackupSummary(manifest)
+		fmt.Printf("✅ Uploaded backup to %s\n", url)
+		return nil
+	}
+
+	path := backupOutput
+	if path == "" {
+		path = backup.Name(time.Now())
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	manifest, err := backup.Create(file, passphrase)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	logger.Info("Created backup", zap.String("file", path))
+	printBackupSummary(manifest)
+	fmt.Printf("✅ Created backup %s\n", path)
+	return nil
+}
+
+func runRestoreBackupCommand(cmd *cobra.Command, args []string) error {
+	if pid, err := controller.RunningPID(pidFile); err == nil {
+		return fmt.Errorf("a controller is running (PID %d); stop it with 'gitopsctl stop' before restoring a backup", pid)
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer file.Close()
+	archive, err := backup.Open(file)
+	if err != nil {
+		return err
+	}
+
+	passphrase := ""
+	if !backupNoSecrets && len(archive.Manifest.Secrets) > 0 {
+		config, err := backup.LoadConfig(backupConfigFile)
+		if err != nil {
+			return fmt.Errorf("failed to load backup config: %w", err)
+		}
+		if passphrase = config.Passphrase(); passphrase == "" {
+			return fmt.Errorf("set the passphrase the secrets were encrypted with in %s, or restore without them with --no-secrets", config.PassphraseEnv)
+		}
+	}
+
+	fmt.Printf("Backup created at %s\n", archive.Manifest.CreatedAt.Local().Format(time.RFC1123))
+	printBackupSummary(&archive.Manifest)
+	if !backupForce && !common.ConfirmAction("Restore the backup, replacing these files?") {
+		fmt.Println("Restore cancelled.")
+		return nil
+	}
+
+	restored, err := archive.Restore(passphrase)
+	if err != nil {
+		return err
+	}
+	logger.Info("Restored backup", zap.String("file", args[0]), zap.Int("files", len(restored)))
+	fmt.Printf("✅ Restored %d file(s). Start the controller with 'gitopsctl start'.\n", len(restored))
+	return nil
+}
+
+// printBackupSummary prints the files and secrets of a backup.
+func printBackupSummary(manifest *backup.Manifest) {
+	for _, path := range manifest.Files {
+		fmt.Printf("  • %s\n", path)
+	}
+	for _, path := range manifest.Secrets {
+		fmt.Printf("  • %s (secret, encrypted)\n", path)
+	}
+	if len(manifest.Secrets) == 0 {
+		fmt.Printf("  ⚠️  No secrets included\n")
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	backupCmd.AddCommand(createBackupCmd, restoreBackupCmd)
+
+	backupCmd.PersistentFlags().StringVar(&backupConfigFile, "backup-config", backup.DefaultConfigFile, "Path of the backup configuration, with the passphrase variable and the S3 target")
+	backupCmd.PersistentFlags().BoolVar(&backupNoSecrets, "no-secrets", false, "Leave the encrypted secrets out of the backup, or do not restore them")
+	createBackupCmd.Flags().StringVarP(&backupOutput, "output", "o", "", "Path to write the backup to (defaults to gitopsctl-backup-<time>.tar.gz)")
+	createBackupCmd.Flags().BoolVar(&backupUpload, "upload", false, "Upload the backup to the S3 target of the backup configuration instead of writing a file")
+	restoreBackupCmd.Flags().BoolVarP(&backupForce, "force", "f", false, "Restore without asking for confirmation")
+	restoreBackupCmd.Flags().StringVar(&pidFile, "pid-file", controller.DefaultPIDFile, "Path of the file recording the running controller's process ID")
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/git"
+	"aeswibon.com/github/gitopsctl/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds the remote lookups made while completing flags, so a slow or
+// unreachable repository does not hang the shell.
+const completionTimeout = 10 * time.Second
+
+var (
+	browseRepoBranch  string              // Branch to list directories of
+	browseRepoPath    string              // Directory to list, relative to the repository root
+	browseRepoTimeout time.Duration       // Timeout for the remote lookups
+	browseRepoOutput  utils.OutputOptions // Output options for the browse command
+)
+
+// RepoBrowseResult is the machine-readable output of 'repo browse'.
+type RepoBrowseResult struct {
+	URL           string   `json:"url"`
+	DefaultBranch string   `json:"defaultBranch"`
+	Branches      []string `json:"branches"`
+	Branch        string   `json:"branch"`
+	Path          string   `json:"path"`
+	Directories   []string `json:"directories"`
+}
+
+var browseRepoCmd = &cobra.Command{
+	Use:   "browse <url>",
+	Short: "List the branches and directories of a repository",
+	Long: `Lists the branches of a remote Git repository and the directories on one of them, to help
+choose the --branch and --path of 'gitopsctl app register'.
+
+Branches are listed without fetching anything, like git ls-remote. Directories are read from a
+shallow, in-memory fetch of the branch's latest commit; nothing is written to disk. Without
+--branch, the repository's default branch is used, and without --path, its top-level directories
+are listed.`,
+	Example: `  # List the branches and top-level directories of a repository
+  gitopsctl repo browse https://github.com/user/repo.git
+
+  # List the directories under k8s on the develop branch
+  gitopsctl repo browse https://github.com/user/repo.git --branch develop --path k8s`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBrowseRepoCommand,
+}
+
+func runBrowseRepoCommand(cmd *cobra.Command, args []string) error {
+	if err := browseRepoOutput.Validate(); err != nil {
+		return err
+	}
+	repoURL := strings.TrimSpace(args[0])
+	if err := common.ValidateGitURL(repoURL); err != nil {
+		return err
+	}
+	dir, err := common.NormalizeRepoPath(common.DefaultIfEmpty(browseRepoPath, "."))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), browseRepoTimeout)
+	defer cancel()
+
+	branches, err := git.RemoteBranches(ctx, repoURL)
+	if err != nil {
+		return err
+	}
+	defaultBranch, err := git.DefaultBranch(ctx, repoURL)
+	if err != nil {
+		defaultBranch = ""
+	}
+
+	result := RepoBrowseResult{
+		URL:           repoURL,
+		DefaultBranch: defaultBranch,
+		Branches:      branches,
+		Branch:        strings.TrimSpace(browseRepoBranch),
+		Path:          dir,
+	}
+	if result.Branch == "" {
+		result.Branch = defaultBranch
+	}
+	if result.Branch != "" {
+		if result.Directories, err = git.ListDirectories(ctx, repoURL, result.Branch, dir); err != nil {
+			return err
+		}
+	}
+
+	if browseRepoOutput.IsMachineOutput() {
+		return utils.RenderObject(browseRepoOutput, result)
+	}
+
+	fmt.Printf("Repository: %s\n", repoURL)
+	fmt.Printf("\nBranches:\n")
+	if len(branches) == 0 {
+		fmt.Printf("  (none)\n")
+	}
+	for _, b := range branches {
+		if b == defaultBranch {
+			fmt.Printf("  • %s (default)\n", b)
+		} else {
+			fmt.Printf("  • %s\n", b)
+		}
+	}
+	if result.Branch == "" {
+		fmt.Printf("\n⚠️  Could not determine the default branch; use --branch to list directories.\n")
+		return nil
+	}
+
+	fmt.Printf("\nDirectories in '%s' on %s:\n", dir, result.Branch)
+	if len(result.Directories) == 0 {
+		fmt.Printf("  (none)\n")
+	}
+	for _, d := range result.Directories {
+		fmt.Printf("  • %s\n", d)
+	}
+
+	fmt.Printf("\nNext steps:\n")
+	if len(result.Directories) > 0 {
+		fmt.Printf("  • Browse deeper: gitopsctl repo browse %s --branch %s --path %s\n", repoURL, result.Branch, result.Directories[0])
+	}
+	fmt.Printf("  • Register an application: gitopsctl app register -n <name> -r %s -b %s -p %s -c <cluster>\n",
+		repoURL, result.Branch, dir)
+	return nil
+}
+
+// completeRemoteBranches completes a branch of the repository given with the command's --repo flag.
+func completeRemoteBranches(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	repoURL, _ := cmd.Flags().GetString("repo")
+	if strings.TrimSpace(repoURL) == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	branches, err := git.RemoteBranches(ctx, strings.TrimSpace(repoURL))
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return branches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRepoPaths completes a directory of the repository given with the command's --repo flag,
+// on its --branch or default branch. The directories under the part of the path typed so far are
+// offered, so a path can be completed one directory at a time.
+func completeRepoPaths(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	repoURL, _ := cmd.Flags().GetString("repo")
+	repoURL = strings.TrimSpace(repoURL)
+	if repoURL == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	branch, _ := cmd.Flags().GetString("branch")
+	branch = strings.TrimSpace(branch)
+	if branch == "" {
+		var err error
+		if branch, err = git.DefaultBranch(ctx, repoURL); err != nil {
+			cobra.CompDebugln(err.Error(), false)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+
+	dirs, err := git.ListDirectories(ctx, repoURL, branch, path.Dir(toComplete))
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return dirs, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+func init() {
+	repoCmd.AddCommand(browseRepoCmd)
+
+	browseRepoCmd.Flags().StringVarP(&browseRepoBranch, "branch", "b", "",
+		"Branch to list directories of (defaults to the repository's default branch)")
+	browseRepoCmd.Flags().StringVarP(&browseRepoPath, "path", "p", "",
+		"Directory to list, relative to the repository root (defaults to the top level)")
+	browseRepoCmd.Flags().DurationVar(&browseRepoTimeout, "timeout", 30*time.Second,
+		"Timeout for the remote lookups")
+	utils.AddOutputFlags(browseRepoCmd, &browseRepoOutput)
+}
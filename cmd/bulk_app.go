@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// DefaultBulkParallelism is how many applications a bulk command acts on at the same time.
+const DefaultBulkParallelism = 5
+
+// appSelection selects the applications a bulk command acts on instead of a single named one.
+type appSelection struct {
+	all      bool              // Every registered application
+	selector map[string]string // Applications with all these labels
+	cluster  string            // Applications deployed to this cluster
+	parallel int               // How many applications are acted on at the same time
+}
+
+// addAppSelectionFlags adds the flags selecting the applications of a bulk command to cmd.
+func addAppSelectionFlags(cmd *cobra.Command, s *appSelection, verb string) {
+	cmd.Flags().BoolVar(&s.all, "all", false, fmt.Sprintf("%s every registered application", verb))
+	cmd.Flags().StringToStringVarP(&s.selector, "selector", "l", nil,
+		fmt.Sprintf("%s the applications with these labels, as KEY=VALUE (repeatable)", verb))
+	cmd.Flags().StringVar(&s.cluster, "cluster", "", fmt.Sprintf("%s the applications deployed to this cluster", verb))
+	cmd.Flags().IntVar(&s.parallel, "parallel", DefaultBulkParallelism, "Number of applications acted on at the same time with --all, --selector or --cluster")
+	cmd.MarkFlagsMutuallyExclusive("all", "selector")
+	cmd.MarkFlagsMutuallyExclusive("all", "cluster")
+	cmd.RegisterFlagCompletionFunc("cluster", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return clusterNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// isBulk checks that the command was given either an application name or a selection, and reports
+// whether it was a selection.
+func (s *appSelection) isBulk(args []string) (bool, error) {
+	selected := s.all || len(s.selector) > 0 || s.cluster != ""
+	switch {
+	case selected && len(args) > 0:
+		return false, fmt.Errorf("an application name cannot be combined with --all, --selector or --cluster")
+	case !selected && len(args) == 0:
+		return false, fmt.Errorf("an application name, --all, --selector or --cluster is required")
+	case selected && s.parallel < 1:
+		return false, fmt.Errorf("--parallel must be at least 1")
+	}
+	if err := common.ValidateLabels(s.selector); err != nil {
+		return false, fmt.Errorf("invalid --selector: %w", err)
+	}
+	return selected, nil
+}
+
+// String describes the selection for messages.
+func (s *appSelection) String() string {
+	var parts []string
+	if len(s.selector) > 0 {
+		parts = append(parts, "labels "+common.FormatLabels(s.selector))
+	}
+	if s.cluster != "" {
+		parts = append(parts, "cluster '"+s.cluster+"'")
+	}
+	if len(parts) == 0 {
+		return "registered applications"
+	}
+	return "applications with " + strings.Join(parts, " on ")
+}
+
+// flags returns the command-line flags making the same selection, for next-step hints.
+func (s *appSelection) flags() string {
+	if s.all {
+		return "--all"
+	}
+	var flags []string
+	if len(s.selector) > 0 {
+		flags = append(flags, "--selector "+common.FormatLabels(s.selector))
+	}
+	if s.cluster != "" {
+		flags = append(flags, "--cluster "+s.cluster)
+	}
+	return strings.Join(flags, " ")
+}
+
+// load returns the registered applications and those of them the selection matches, sorted by name.
+func (s *appSelection) load() (*app.Applications, []*app.Application, error) {
+	apps, err := app.LoadApplications(app.DefaultAppConfigFile)
+	if err != nil {
+		logger.Error("Failed to load applications", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to load applications: %w", err)
+	}
+
+	apps.RLock()
+	defer apps.RUnlock()
+	var selected []*app.Application
+	for _, a := range apps.List() {
+		if common.MatchLabels(a.Labels, s.selector) && (s.cluster == "" || a.ClusterName == s.cluster) {
+			selected = append(selected, a)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Name < selected[j].Name })
+	if len(selected) == 0 {
+		return nil, nil, fmt.Errorf("no %s found\nUse 'gitopsctl app list' to see registered applications", s)
+	}
+	return apps, selected, nil
+}
+
+// bulkResult is the outcome of a bulk command for one application.
+type bulkResult struct {
+	name    string
+	message string // What was done, or why the application was skipped
+	skipped bool   // The application did not need the operation, for example it was already suspended
+	err     error
+}
+
+// runBulk runs op for each application, up to s.parallel at a time, printing each result as it
+// completes and a summary at the end. It fails if op failed for any application.
+func runBulk(s *appSelection, operation string, selected []*app.Application, op func(a *app.Application) bulkResult) error {
+	fmt.Printf("%s %d %s, %d at a time:\n", operation, len(selected), s, min(s.parallel, len(selected)))
+
+	results := make(chan bulkResult)
+	sem := make(chan struct{}, s.parallel)
+	var wg sync.WaitGroup
+	for _, a := range selected {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results <- op(a)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	done, skipped, failed := 0, 0, 0
+	width := len(fmt.Sprint(len(selected)))
+	for r := range results {
+		progress := fmt.Sprintf("[%*d/%d]", width, done+skipped+failed+1, len(selected))
+		switch {
+		case r.err != nil:
+			failed++
+			logger.Debug("Bulk operation failed for application", zap.String("app", r.name), zap.Error(r.err))
+			fmt.Printf("  %s ❌ %s: %v\n", progress, r.name, r.err)
+		case r.skipped:
+			skipped++
+			fmt.Printf("  %s ⏭️  %s: %s\n", progress, r.name, r.message)
+		default:
+			done++
+			fmt.Printf("  %s ✅ %s: %s\n", progress, r.name, r.message)
+		}
+	}
+
+	fmt.Printf("\n%d succeeded, %d skipped, %d failed\n", done, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%s failed for %d of %d application(s)", strings.ToLower(operation), failed, len(selected))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+
+	"aeswibon.com/github/gitopsctl/internal/core/git"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	cleanupTempDir string // Directory to search for orphaned repository clones
+	dryRunCleanup  bool   // Preview cleanup without removing anything
+)
+
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Remove repository clones left behind by stopped controllers",
+	Long: `Removes temporary Git clone directories (gitopsctl-repo-*) that are no longer
+owned by a running controller, for example after the controller crashed or was killed.
+
+Each clone directory records the PID of the controller that created it. Directories
+whose controller is still running are left untouched. The controller also performs
+this cleanup automatically when it starts.`,
+	Example: `  # List orphaned clone directories without removing them
+  gitopsctl cleanup --dry-run
+
+  # Remove orphaned clone directories
+  gitopsctl cleanup`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dirs, err := git.CleanUpOrphanedRepoDirs(logger, cleanupTempDir, dryRunCleanup)
+		if err != nil {
+			logger.Error("Failed to clean up orphaned repository directories", zap.Error(err))
+		}
+
+		if len(dirs) == 0 && err == nil {
+			fmt.Println("✨ No orphaned repository directories found")
+			return nil
+		}
+
+		if dryRunCleanup {
+			fmt.Printf("\n🔍 DRY RUN - No changes will be applied\n\n")
+			fmt.Printf("The following %d director(ies) would be removed:\n", len(dirs))
+		} else if len(dirs) > 0 {
+			fmt.Printf("\n🧹 Removed %d orphaned repository director(ies):\n", len(dirs))
+		}
+		for _, dir := range dirs {
+			fmt.Printf("  • %s\n", dir)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to remove some directories: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cleanupCmd)
+
+	cleanupCmd.Flags().StringVar(&cleanupTempDir, "temp-dir", "",
+		"Directory to search for orphaned clones (defaults to the system temporary directory)")
+	cleanupCmd.Flags().BoolVar(&dryRunCleanup, "dry-run", false,
+		"List orphaned directories without removing them")
+}
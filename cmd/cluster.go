@@ -0,0 +1,20 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var clusterCmd = &cobra.Command{
+	Use:     "cluster",
+	GroupID: "clusterGroup",
+	Short:   "Manage Kubernetes clusters",
+	Long:    `Register, inspect, and delete the Kubernetes clusters that applications are deployed to.`,
+	Example: `  # Register a cluster
+  gitopsctl cluster register -n production -k ~/.kube/config
+
+  # Show cluster health
+  gitopsctl cluster status`,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(clusterCmd)
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/schema"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configAppsFile     string // Path of the applications file to validate
+	configClustersFile string // Path of the clusters file to validate
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration files and switch profiles",
+	Long: `Check the application and cluster files the controller loads, for example after editing them by hand,
+and switch between the profiles of the configuration file.`,
+	Example: `  # Validate the application and cluster files
+  gitopsctl config validate
+
+  # Switch to the staging profile
+  gitopsctl config use-profile staging`,
+	Args: cobra.NoArgs,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the application and cluster files",
+	Long: `Validates the application and cluster files against their schema, reporting every problem
+with its line, column and path in the file: invalid JSON, unknown fields, values of the wrong
+type, missing required fields and malformed durations.
+
+The same validation runs whenever the controller or a command loads the files, so a file that
+passes validation can be loaded. Files that do not exist are skipped. The command exits with
+an error if any file is invalid.`,
+	Example: `  # Validate the default application and cluster files
+  gitopsctl config validate
+
+  # Validate a hand-edited applications file before replacing the current one
+  gitopsctl config validate --apps-file apps.new.json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidateCommand,
+}
+
+func runConfigValidateCommand(cmd *cobra.Command, args []string) error {
+	files := []struct {
+		path     string
+		validate func(data []byte) error
+	}{
+		{configAppsFile, app.ValidateApplications},
+		{configClustersFile, cluster.ValidateClusters},
+	}
+
+	invalid := 0
+	for _, f := range files {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				fmt.Printf("  • %s does not exist, skipped\n", f.path)
+				continue
+			}
+			return fmt.Errorf("failed to read %s: %w", f.path, err)
+		}
+
+		err = f.validate(data)
+		if err == nil {
+			fmt.Printf("  ✓ %s is valid\n", f.path)
+			continue
+		}
+		invalid++
+		var errs schema.Errors
+		if !errors.As(err, &errs) {
+			fmt.Printf("  ✗ %s: %v\n", f.path, err)
+			continue
+		}
+		fmt.Printf("  ✗ %s has %d problem(s):\n", f.path, len(errs))
+		for _, e := range errs {
+			location := fmt.Sprintf("%s:%d:%d", f.path, e.Line, e.Column)
+			if e.Path != "" {
+				location += " " + e.Path
+			}
+			fmt.Printf("      %s: %s\n", location, e.Message)
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d file(s) failed validation", invalid)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+
+	configValidateCmd.Flags().StringVar(&configAppsFile, "apps-file", app.DefaultAppConfigFile, "Path of the applications file to validate")
+	configValidateCmd.Flags().StringVar(&configClustersFile, "clusters-file", cluster.DefaultClusterConfigFile, "Path of the clusters file to validate")
+}
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/core/alert"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// EnvPrefix starts the names of the environment variables setting flags, such as
+	// GITOPSCTL_API_ADDRESS for --api-address.
+	EnvPrefix = "GITOPSCTL_"
+	// ConfigFileEnv is the environment variable naming the configuration file when --config is not given.
+	ConfigFileEnv = EnvPrefix + "CONFIG"
+	// DefaultConfigFileName is the configuration file read from the home directory when neither
+	// --config nor ConfigFileEnv is set.
+	DefaultConfigFileName = ".gitopsctl.yaml"
+)
+
+// notificationSettings are the notification channels of the configuration file, which the
+// controller adds to those of its alerting configuration.
+type notificationSettings struct {
+	// Webhooks are the URLs notifications are posted to as they happen.
+	Webhooks []string `json:"webhooks,omitempty"`
+	// Channels are the webhooks and email recipients notified, as in the alerting configuration.
+	Channels []alert.Channel `json:"channels,omitempty"`
+}
+
+// configNotifications holds the notification channels of the configuration file once it is loaded.
+var configNotifications notificationSettings
+
+// configFilePath returns the configuration file to read, and whether it must exist: one named
+// with --config or ConfigFileEnv must, while the default one in the home directory is optional.
+func configFilePath() (string, bool) {
+	if cfgFile != "" {
+		return cfgFile, true
+	}
+	if path := os.Getenv(ConfigFileEnv); path != "" {
+		return path, true
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", false
+	}
+	return filepath.Join(home, DefaultConfigFileName), false
+}
+
+// loadConfigFile reads the configuration file and sets the flags of cmd it configures, unless they
+// were given on the command line or set by their environment variable.
+//
+// Top-level keys are flag names and apply to every command with that flag. A key naming a command,
+// such as start or app, holds the flags of that command and its subcommands, and may nest the
+// sections of its subcommands; settings of more specific commands win. The profiles key holds named
+// sets of such settings, and those of the selected profile override the others (see selectProfile).
+// The notifications key holds notification channels added to those of the alerting configuration.
+func loadConfigFile(cmd *cobra.Command) error {
+	path, required := configFilePath()
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !required {
+			return nil
+		}
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	settings, err := parseConfigFile(data)
+	if err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if raw, ok := settings["notifications"]; ok {
+		delete(settings, "notifications")
+		encoded, _ := json.Marshal(raw)
+		configNotifications = notificationSettings{}
+		decoder := json.NewDecoder(bytes.NewReader(encoded))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&configNotifications); err != nil {
+			return fmt.Errorf("invalid notifications in config file %s: %w", path, err)
+		}
+	}
+
+	profile, err := selectProfile(cmd, settings)
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	var commands []*cobra.Command
+	for c := cmd; c != nil; c = c.Parent() {
+		commands = append([]*cobra.Command{c}, commands...)
+	}
+	values := make(map[string]any)
+	if err := collectCommandSettings(commands, []configLayer{{settings: settings}, profile}, values); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	for name, value := range values {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || f.Changed {
+			continue
+		}
+		if err := f.Value.Set(flagValue(value)); err != nil {
+			return fmt.Errorf("invalid %s in config file %s: %w", name, path, err)
+		}
+	}
+	return nil
+}
+
+// flagEnvName returns the environment variable setting the flag with the given name.
+func flagEnvName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// applyEnvironment sets the flags of cmd that were not given on the command line from their
+// non-empty GITOPSCTL_ environment variables, which override the configuration file. The flags
+// are marked as changed, so commands treat them as given on the command line.
+func applyEnvironment(cmd *cobra.Command) error {
+	var err error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		value := os.Getenv(flagEnvName(f.Name))
+		if err != nil || f.Changed || value == "" {
+			return
+		}
+		if setErr := cmd.Flags().Set(f.Name, value); setErr != nil {
+			err = fmt.Errorf("invalid %s: %w", flagEnvName(f.Name), setErr)
+		}
+	})
+	return err
+}
+
+// configLayer is a set of settings of the configuration file: its top-level settings, or those of
+// a profile.
+type configLayer struct {
+	name     string // Empty for the top-level settings, or "profile <name>"
+	settings map[string]any
+}
+
+// collectCommandSettings adds to values the settings of commands, the commands on the path from the
+// root to the command being run, from each layer. At each command, the settings of later layers
+// override those of earlier ones, so that the settings of a more specific command in the file
+// still win over the top-level settings of a profile.
+func collectCommandSettings(commands []*cobra.Command, layers []configLayer, values map[string]any) error {
+	sections := make([]map[string]any, len(layers))
+	for i, layer := range layers {
+		sections[i] = layer.settings
+	}
+	for level, c := range commands {
+		for i, layer := range layers {
+			if level > 0 {
+				sections[i], _ = sections[i][c.Name()].(map[string]any)
+			}
+			if sections[i] == nil {
+				continue
+			}
+			if err := collectSettings(c, sections[i], values); err != nil {
+				var location []string
+				if layer.name != "" {
+					location = append(location, layer.name)
+				}
+				if level > 0 {
+					location = append(location, "section "+c.CommandPath())
+				}
+				if len(location) == 0 {
+					return err
+				}
+				return fmt.Errorf("%s: %w", strings.Join(location, ", "), err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseConfigFile parses a YAML configuration file into its settings, keeping numbers as written.
+func parseConfigFile(data []byte) (map[string]any, error) {
+	encoded, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	var settings map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	if err := decoder.Decode(&settings); err != nil {
+		return nil, fmt.Errorf("expected a mapping of settings: %w", err)
+	}
+	if settings == nil {
+		settings = make(map[string]any)
+	}
+	return settings, nil
+}
+
+// collectSettings adds the flag settings of the section of cmd to values, skipping the sections of
+// its subcommands. Every setting must be a flag of cmd or one of its subcommands.
+func collectSettings(cmd *cobra.Command, section map[string]any, values map[string]any) error {
+	for key, value := range section {
+		if _, ok := value.(map[string]any); ok && subcommand(cmd, key) != nil {
+			continue
+		}
+		if !hasFlag(cmd, key) {
+			return fmt.Errorf("unknown setting '%s'", key)
+		}
+		values[key] = value
+	}
+	return nil
+}
+
+// subcommand returns the subcommand of cmd with the given name, or nil.
+func subcommand(cmd *cobra.Command, name string) *cobra.Command {
+	for _, c := range cmd.Commands() {
+		if c.Name() == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// hasFlag reports whether cmd or any of its subcommands has a flag with the given name.
+func hasFlag(cmd *cobra.Command, name string) bool {
+	if cmd.Flags().Lookup(name) != nil || cmd.PersistentFlags().Lookup(name) != nil {
+		return true
+	}
+	for _, c := range cmd.Commands() {
+		if hasFlag(c, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// flagValue formats a setting as a command-line flag value: lists are comma-separated, and
+// mappings comma-separated KEY=VALUE pairs sorted by key.
+func flagValue(value any) string {
+	switch v := value.(type) {
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = flagValue(item)
+		}
+		return strings.Join(items, ",")
+	case map[string]any:
+		pairs := make([]string, 0, len(v))
+		for key, item := range v {
+			pairs = append(pairs, key+"="+flagValue(item))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
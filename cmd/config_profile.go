@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// profilesKey is the key of the configuration file holding the profiles, by name.
+	profilesKey = "profiles"
+	// currentProfileKey is the key of the configuration file naming the profile used when
+	// --use-profile is not given.
+	currentProfileKey = "current-profile"
+)
+
+var configUseProfileCmd = &cobra.Command{
+	Use:   "use-profile <name>",
+	Short: "Switch the profile commands use by default",
+	Long: `Sets the current profile of the configuration file, the profile whose settings commands use
+unless --use-profile names another one.
+
+A profile is a named set of settings in the profiles section of the configuration file, with the
+same keys as the rest of the file. It usually points the CLI at one gitopsctl installation, with
+its state-dir and control-socket, along with the token and output format used with it. The CLI
+works on an installation's state directory and control socket rather than its API URL.`,
+	Example: `  # Work with the staging installation from now on
+  gitopsctl config use-profile staging
+
+  # Run a single command against production instead
+  gitopsctl app list --use-profile production`,
+	Args: cobra.ExactArgs(1),
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return profileNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: runConfigUseProfileCommand,
+}
+
+var configProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "List the profiles of the configuration file",
+	Long:  `Lists the profiles of the configuration file with their settings, marking the current one.`,
+	Example: `  # List the profiles
+  gitopsctl config profiles`,
+	Args: cobra.NoArgs,
+	RunE: runConfigProfilesCommand,
+}
+
+// selectProfile removes the profiles and the current profile from the settings of the
+// configuration file, and returns the settings of the profile to use: the one named with
+// --use-profile, or else the current profile.
+//
+// A current profile that no longer exists is ignored by the config commands, so that another one
+// can still be chosen.
+func selectProfile(cmd *cobra.Command, settings map[string]any) (configLayer, error) {
+	profiles, current, err := extractProfiles(settings)
+	if err != nil {
+		return configLayer{}, err
+	}
+	name := useProfile
+	if name == "" {
+		if name = current; name == "" {
+			return configLayer{}, nil
+		}
+		if _, ok := profiles[name]; !ok && cmd.Parent() == configCmd {
+			return configLayer{}, nil
+		}
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		return configLayer{}, fmt.Errorf("profile '%s' not found\nUse 'gitopsctl config profiles' to see the profiles", name)
+	}
+	return configLayer{name: "profile " + name, settings: profile}, nil
+}
+
+// extractProfiles removes the profiles and the current profile from settings and returns them.
+func extractProfiles(settings map[string]any) (map[string]map[string]any, string, error) {
+	rawProfiles, rawCurrent := settings[profilesKey], settings[currentProfileKey]
+	delete(settings, profilesKey)
+	delete(settings, currentProfileKey)
+
+	current, ok := rawCurrent.(string)
+	if rawCurrent != nil && !ok {
+		return nil, "", fmt.Errorf("%s must be a profile name", currentProfileKey)
+	}
+	profiles := make(map[string]map[string]any)
+	if rawProfiles == nil {
+		return profiles, current, nil
+	}
+	entries, ok := rawProfiles.(map[string]any)
+	if !ok {
+		return nil, "", fmt.Errorf("%s must map profile names to their settings", profilesKey)
+	}
+	for name, raw := range entries {
+		profile, ok := raw.(map[string]any)
+		if !ok && raw != nil {
+			return nil, "", fmt.Errorf("profile '%s' must be a mapping of settings", name)
+		}
+		if profile == nil {
+			profile = make(map[string]any)
+		}
+		profiles[name] = profile
+	}
+	return profiles, current, nil
+}
+
+// readProfiles reads the profiles and the current profile of the configuration file, and returns
+// the file's path.
+func readProfiles() (map[string]map[string]any, string, string, error) {
+	path, _ := configFilePath()
+	if path == "" {
+		return nil, "", "", fmt.Errorf("no configuration file: the home directory is unknown, use --config")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", path, fmt.Errorf("configuration file %s does not exist; add profiles to its %s section", path, profilesKey)
+		}
+		return nil, "", path, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	settings, err := parseConfigFile(data)
+	if err != nil {
+		return nil, "", path, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	profiles, current, err := extractProfiles(settings)
+	if err != nil {
+		return nil, "", path, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return profiles, current, path, nil
+}
+
+// profileNames returns the names of the profiles for shell completion.
+func profileNames() []string {
+	profiles, _, _, err := readProfiles()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runConfigUseProfileCommand(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	profiles, current, path, err := readProfiles()
+	if err != nil {
+		return err
+	}
+	if _, ok := profiles[name]; !ok {
+		return fmt.Errorf("profile '%s' not found in %s\nUse 'gitopsctl config profiles' to see the profiles", name, path)
+	}
+	if name == current {
+		fmt.Printf("ℹ️  Profile '%s' is already the current profile\n", name)
+		return nil
+	}
+	if err := setCurrentProfile(path, name); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Switched to profile '%s'\n", name)
+	return nil
+}
+
+// setCurrentProfile sets the current profile of the configuration file at path, keeping the rest
+// of the file and its comments.
+func setCurrentProfile(path, name string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("invalid config file %s: expected a mapping of settings", path)
+	}
+
+	root := doc.Content[0]
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}
+	found := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == currentProfileKey {
+			root.Content[i+1] = value
+			found = true
+			break
+		}
+	}
+	if !found {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: currentProfileKey}
+		root.Content = append(root.Content, key, value)
+	}
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := os.WriteFile(path, out.Bytes(), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func runConfigProfilesCommand(cmd *cobra.Command, args []string) error {
+	profiles, current, path, err := readProfiles()
+	if err != nil {
+		return err
+	}
+	if len(profiles) == 0 {
+		fmt.Printf("📋 No profiles in %s\n", path)
+		fmt.Println("\n💡 Add them to its profiles section, e.g.:")
+		fmt.Println("   profiles:")
+		fmt.Println("     staging:")
+		fmt.Println("       state-dir: /srv/gitopsctl-staging")
+		return nil
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CURRENT\tNAME\tSETTINGS")
+	for _, name := range names {
+		marker := ""
+		if name == current {
+			marker = "*"
+		}
+		keys := make([]string, 0, len(profiles[name]))
+		for key := range profiles[name] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", marker, name, strings.Join(keys, ", "))
+	}
+	return w.Flush()
+}
+
+func init() {
+	configCmd.AddCommand(configUseProfileCmd, configProfilesCmd)
+}
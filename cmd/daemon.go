@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+)
+
+// daemonStartupTimeout is how long starting a background controller waits for it to record its
+// process ID before giving up.
+const daemonStartupTimeout = 10 * time.Second
+
+// startDaemon starts the controller in the background with the flags of startFlags and the global
+// flags that were set on the command line in flags, and waits until it has recorded its process ID.
+// The controller logs to --log-file, or to controller.DefaultLogFile if it is not set.
+func startDaemon(flags, startFlags *pflag.FlagSet) error {
+	if pid, err := controller.RunningPID(pidFile); err == nil {
+		return fmt.Errorf("a controller is already running (PID %d, recorded in %s)\nStop it with 'gitopsctl stop' or use 'gitopsctl restart'", pid, pidFile)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the gitopsctl executable: %w", err)
+	}
+	logFile := common.DefaultIfEmpty(logFile, controller.DefaultLogFile)
+	if err := os.MkdirAll(filepath.Dir(logFile), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for log file %s: %w", logFile, err)
+	}
+	log, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file %s: %w", logFile, err)
+	}
+	defer log.Close()
+
+	// Pass on every start and global flag given on the command line, so the background controller
+	// is configured the same way. Flags of the calling command that start lacks, such as restart's
+	// --timeout, are left out. The controller writes its logs to the log file itself, so that they
+	// are rotated; its output is only redirected there for what is not logged, such as panics.
+	args := []string{"start", "--log-file=" + logFile}
+	flags.Visit(func(f *pflag.Flag) {
+		if f.Name == "daemon" || f.Name == "log-file" {
+			return
+		}
+		if startFlags.Lookup(f.Name) != nil || rootCmd.PersistentFlags().Lookup(f.Name) != nil {
+			args = append(args, "--"+f.Name+"="+f.Value.String())
+		}
+	})
+
+	child := exec.Command(executable, args...)
+	// Start from the same directory, so relative --config and --state-dir paths resolve the same way
+	child.Dir = launchDir
+	child.Stdout = log
+	child.Stderr = log
+	child.SysProcAttr = controller.DetachedProcAttr()
+	if err := child.Start(); err != nil {
+		return fmt.Errorf("failed to start the controller in the background: %w", err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- child.Wait() }()
+
+	deadline := time.After(daemonStartupTimeout)
+	for {
+		select {
+		case err := <-exited:
+			if err == nil {
+				err = errors.New("exited")
+			}
+			return fmt.Errorf("the controller stopped right after starting (%v)\nSee %s for details", err, logFile)
+		case <-deadline:
+			return fmt.Errorf("the controller (PID %d) did not record its process ID in %s within %s\nSee %s for details",
+				child.Process.Pid, pidFile, daemonStartupTimeout, logFile)
+		case <-time.After(100 * time.Millisecond):
+		}
+		if pid, err := controller.RunningPID(pidFile); err == nil && pid == child.Process.Pid {
+			break
+		}
+	}
+
+	logger.Info("Controller started in the background",
+		zap.Int("pid", child.Process.Pid),
+		zap.String("log_file", logFile),
+		zap.String("pid_file", pidFile))
+	fmt.Printf("✓ Controller started in the background (PID %d)\n", child.Process.Pid)
+	fmt.Printf("  Log file: %s\n", logFile)
+	fmt.Printf("  PID file: %s\n", pidFile)
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • Follow the logs: tail -f %s\n", logFile)
+	fmt.Printf("  • Stop the controller: gitopsctl stop\n")
+	return nil
+}
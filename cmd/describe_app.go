@@ -0,0 +1,571 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
+	"aeswibon.com/github/gitopsctl/internal/core/terraform"
+	"aeswibon.com/github/gitopsctl/internal/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var describeAppOutput utils.OutputOptions // Output options for the describe command
+
+// appDescription is the machine-readable view of a described application.
+type appDescription struct {
+	*app.Application
+	Health        string `json:"health"`
+	ClusterStatus string `json:"clusterStatus"`
+}
+
+var describeAppCmd = &cobra.Command{
+	Use:   "describe <name>",
+	Short: "Show detailed information about a GitOps application",
+	Long: `Displays a detailed view of a single registered application.
+
+This includes its configuration, current status and health, consecutive failures,
+the next scheduled poll, the most recent sync events, and the Kubernetes resources
+it manages.`,
+	Example: `  # Describe an application
+  gitopsctl app describe myapp
+
+  # Print the full application as JSON
+  gitopsctl app describe myapp -o json
+
+  # Extract the last synced commit for scripting
+  gitopsctl app describe myapp -o jsonpath='{.lastSyncedGitHash}'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDescribeAppCommand,
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return appNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func runDescribeAppCommand(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+
+	if err := describeAppOutput.Validate(); err != nil {
+		return err
+	}
+
+	_, targetApp, err := loadAndFindApplication(name)
+	if err != nil {
+		return err
+	}
+	if targetApp == nil {
+		return fmt.Errorf("application '%s' not found\nUse 'gitopsctl app list' to see registered applications", name)
+	}
+
+	clusterStatus := "Unknown"
+	if targetApp.IsTerraform() {
+		clusterStatus = ""
+	} else if cl, exists, err := cluster.VerifyCluster(targetApp.ClusterName); err == nil && exists {
+		clusterStatus = string(cl.Status)
+	} else if err != nil {
+		logger.Debug("Failed to look up target cluster", zap.String("cluster", targetApp.ClusterName), zap.Error(err))
+	}
+
+	if describeAppOutput.IsMachineOutput() {
+		return utils.RenderObject(describeAppOutput, appDescription{
+			Application:   targetApp,
+			Health:        appHealth(targetApp),
+			ClusterStatus: clusterStatus,
+		})
+	}
+
+	printAppDescription(targetApp, clusterStatus)
+	return nil
+}
+
+// printAppDescription prints the detailed, human-readable view of an application.
+func printAppDescription(a *app.Application, clusterStatus string) {
+	fmt.Printf("Name:           %s\n", a.Name)
+	if len(a.Labels) > 0 {
+		fmt.Printf("Labels:         %s\n", common.FormatLabels(a.Labels))
+	}
+	fmt.Printf("Repository:     %s\n", a.RepoURL)
+	fmt.Printf("Branch:         %s\n", common.DefaultIfEmpty(a.Branch, "main"))
+	fmt.Printf("Path:           %s\n", strings.Join(a.SourcePaths(), ", "))
+	if fetch := describeFetchOptions(a); fetch != "" {
+		fmt.Printf("Fetch:          %s\n", fetch)
+	}
+	printSources("", a.Sources)
+	if a.IsTerraform() {
+		fmt.Printf("Type:           %s\n", a.Type)
+		fmt.Printf("Poll Interval:  %s\n", describePollInterval(a))
+		printTerraformSource("", a.Terraform)
+	} else {
+		fmt.Printf("Cluster:        %s (%s)\n", a.ClusterName, common.DefaultIfEmpty(clusterStatus, "Unknown"))
+		if a.Placement != nil {
+			fmt.Printf("Placement:      %s\n", describePlacement(a.Placement))
+		}
+		fmt.Printf("Poll Interval:  %s\n", describePollInterval(a))
+		fmt.Printf("Renderer:       %s\n", common.DefaultIfEmpty(a.Renderer, render.RendererYAML))
+		fmt.Printf("Apply Strategy: %s\n", common.DefaultIfEmpty(a.ApplyStrategy, k8s.ApplyStrategyBestEffort))
+		if a.ImpersonateUser != "" {
+			fmt.Printf("Impersonate:    %s\n", k8s.Impersonation{User: a.ImpersonateUser, Groups: a.ImpersonateGroups})
+		}
+		if a.ApprovalRequired {
+			fmt.Printf("Approval:       required\n")
+		}
+		if a.IsTwoPhase() {
+			fmt.Printf("Sync Mode:      %s (health timeout %s)\n", a.SyncMode, a.HealthTimeoutDuration())
+		}
+	}
+	if len(a.Exclude) > 0 {
+		fmt.Printf("Exclude:        %s\n", strings.Join(a.Exclude, ", "))
+	}
+	if a.SubstitutesVariables() {
+		fmt.Printf("Substitute:     %s\n", describeVariables(a.Variables))
+	}
+	if a.ConfigHash {
+		fmt.Printf("Config Hash:    enabled\n")
+	}
+	if namespacing := describeNamespacing(a); namespacing != "" {
+		fmt.Printf("Namespace:      %s\n", namespacing)
+	}
+	if a.Lint != nil {
+		fmt.Printf("Lint:           %s\n", describeLint(a.Lint))
+	}
+	if a.APIDeprecationPolicy == app.APIDeprecationBlock {
+		fmt.Printf("Deprecated APIs: blocked\n")
+	}
+	if len(a.PluginParams) > 0 {
+		keys := make([]string, 0, len(a.PluginParams))
+		for key := range a.PluginParams {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		params := make([]string, len(keys))
+		for i, key := range keys {
+			params[i] = key + "=" + a.PluginParams[key]
+		}
+		fmt.Printf("Plugin Params:  %s\n", strings.Join(params, ", "))
+	}
+	printHelmSource("", a.Helm)
+
+	fmt.Printf("\nStatus:\n")
+	fmt.Printf("  Status:               %s\n", common.DefaultIfEmpty(string(a.Status), "Unknown"))
+	fmt.Printf("  Health:               %s\n", appHealth(a))
+	fmt.Printf("  Message:              %s\n", common.DefaultIfEmpty(a.Message, "-"))
+	fmt.Printf("  Last Synced Hash:     %s\n", common.DefaultIfEmpty(a.LastSyncedGitHash, "-"))
+	for _, source := range a.Sources {
+		fmt.Printf("  %-22s%s\n", "Source "+source.Name+":", common.DefaultIfEmpty(a.SourceRevisions[source.Name], "-"))
+	}
+	fmt.Printf("  Consecutive Failures: %d\n", a.ConsecutiveFailures)
+	fmt.Printf("  Last Poll:            %s\n", common.GetRelativeTime(a.LastSyncAt))
+	if !a.LastSyncFinishedAt.IsZero() {
+		fmt.Printf("  Last Sync Duration:   %s\n", a.LastSyncDuration.Round(time.Millisecond))
+	}
+	fmt.Printf("  Next Poll:            %s\n", describeNextPoll(a))
+	printRepositoryStats(a.Repository)
+
+	if a.IsTerraform() {
+		printTerraformPlan(a.TerraformPlan)
+	} else if a.ApprovalRequired || a.PendingChange != nil {
+		printPendingChange(a.PendingChange)
+	}
+	if len(a.Rollouts) > 0 {
+		printRollouts(a.Rollouts)
+	}
+
+	printConditions(a.Conditions)
+
+	fmt.Printf("\nRecent Sync Events:\n")
+	if len(a.SyncHistory) == 0 {
+		fmt.Printf("  <none>\n")
+	} else {
+		for i := len(a.SyncHistory) - 1; i >= 0; i-- {
+			ev := a.SyncHistory[i]
+			hash := ev.GitHash
+			if len(hash) > 7 {
+				hash = hash[:7]
+			}
+			fmt.Printf("  %s  %-10s %-7s %s\n",
+				ev.Time.Format("2006-01-02 15:04:05 MST"),
+				ev.Status,
+				common.DefaultIfEmpty(hash, "-"),
+				common.TruncateString(ev.Message, 80))
+			if ev.Actor != "" {
+				fmt.Printf("%46sRequested by %s\n", "", app.Trigger{Actor: ev.Actor, Reason: ev.Reason})
+			}
+		}
+	}
+
+	if a.IsTerraform() {
+		return
+	}
+	fmt.Printf("\nManaged Resources:\n")
+	if len(a.ManagedResources) == 0 {
+		fmt.Printf("  <none>\n")
+	} else {
+		for _, r := range a.ManagedResources {
+			if r.Namespace != "" {
+				fmt.Printf("  %s %s/%s\n", r.Kind, r.Namespace, r.Name)
+			} else {
+				fmt.Printf("  %s %s\n", r.Kind, r.Name)
+			}
+		}
+		fmt.Printf("  Selector: %s\n", k8s.AppSelector(a.Name))
+	}
+	if len(a.LastSyncResources) > 0 {
+		printLastSyncResources(a)
+	}
+	if len(a.LintFindings) > 0 {
+		printLintFindings(a.LintFindings)
+	}
+	if len(a.DeprecatedAPIs) > 0 {
+		printDeprecatedAPIs(a.DeprecatedAPIs)
+	}
+}
+
+// printLintFindings prints the problems the linters found in the manifests of the last sync that linted them.
+func printLintFindings(findings []app.LintFinding) {
+	fmt.Printf("\nLint Findings:\n")
+	for _, f := range findings {
+		location := common.DefaultIfEmpty(f.Resource, f.File)
+		fmt.Printf("  %-7s %s: %s\n", f.Severity, f.Linter, location)
+		fmt.Printf("          %s\n", common.TruncateString(f.Message, 100))
+	}
+}
+
+// printDeprecatedAPIs prints the manifests of the last sync that used API versions deprecated in, or
+// removed from, the cluster's Kubernetes version.
+func printDeprecatedAPIs(deprecated []app.DeprecatedAPI) {
+	fmt.Printf("\nDeprecated APIs:\n")
+	for _, d := range deprecated {
+		state := "deprecated in " + d.DeprecatedIn + ", removed in " + d.RemovedIn
+		if d.Removed {
+			state = "removed in " + d.RemovedIn
+		}
+		fmt.Printf("  %s: %s (%s)\n", d.Resource, d.APIVersion, state)
+		if d.Replacement != "" {
+			fmt.Printf("    Use %s instead\n", d.Replacement)
+		}
+	}
+}
+
+// printLastSyncResources prints what the last sync that applied manifests did to each resource,
+// with the error of those it could not apply, and whether the next sync only applies the rest.
+func printLastSyncResources(a *app.Application) {
+	fmt.Printf("\nLast Sync Resources:\n")
+	if a.PartialGitHash != "" {
+		fmt.Printf("  Partially applied at %s; the next sync of it applies only the resources not applied\n", a.PartialGitHash)
+	}
+	for _, r := range a.LastSyncResources {
+		ref := k8s.ResourceRef{Kind: r.Kind, Namespace: r.Namespace, Name: r.Name}
+		fmt.Printf("  %-8s %s\n", r.Action, ref)
+		if r.Error != "" {
+			fmt.Printf("           Error: %s\n", common.TruncateString(r.Error, 100))
+		}
+	}
+}
+
+// describeLint describes the linters an application's manifests are checked with, e.g.
+// "kubeconform, kube-score (strict)".
+func describeLint(config *app.LintConfig) string {
+	linters := strings.Join(config.Linters, ", ")
+	if config.Strict {
+		return linters + " (strict)"
+	}
+	return linters
+}
+
+// describePlacement describes how an application's cluster is chosen.
+func describePlacement(placement *app.Placement) string {
+	if len(placement.ClusterSelector) == 0 {
+		return "any healthy cluster with the most free capacity"
+	}
+	return "healthy cluster with " + common.FormatLabels(placement.ClusterSelector) + " and the most free capacity"
+}
+
+// describeNamespacing describes where namespaced resources whose manifest does not set a namespace
+// are applied to, or returns an empty string when it is the "default" namespace.
+func describeNamespacing(a *app.Application) string {
+	switch {
+	case a.RequireNamespace:
+		return "required in manifests"
+	case a.DefaultNamespace != "":
+		return a.DefaultNamespace + " when not set in manifests"
+	}
+	return ""
+}
+
+// describeVariables lists the variables substituted into an application's manifests besides the
+// built-in and cluster ones, e.g. "built-in and cluster variables, REGION=eu-west-1".
+func describeVariables(vars map[string]string) string {
+	if len(vars) == 0 {
+		return "built-in and cluster variables"
+	}
+	return "built-in and cluster variables, " + formatVariables(vars)
+}
+
+// formatVariables lists variables as KEY=VALUE pairs sorted by name.
+func formatVariables(vars map[string]string) string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + vars[name]
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// printSources prints an application's additional sources, one per line, with each line starting
+// with indent.
+func printSources(indent string, sources []app.Source) {
+	for _, source := range sources {
+		location := source.RepoURL + "@" + source.Branch
+		if source.Path != "" {
+			location += ":" + source.Path
+		}
+		fmt.Printf("%sSource:         %s (%s)\n", indent, source.Name, location)
+	}
+}
+
+// printHelmSource prints an application's Helm settings, if it has any, with each line starting
+// with indent.
+func printHelmSource(indent string, helm *app.HelmSource) {
+	if helm == nil {
+		return
+	}
+	if helm.Namespace != "" {
+		fmt.Printf("%sHelm Namespace: %s\n", indent, helm.Namespace)
+	}
+	if helm.RecordRelease {
+		fmt.Printf("%sHelm Release:   recorded in namespace '%s'\n", indent, common.DefaultIfEmpty(helm.Namespace, render.DefaultHelmNamespace))
+	}
+	if len(helm.ValuesFiles) > 0 {
+		fmt.Printf("%sHelm Values:    %s\n", indent, strings.Join(helm.ValuesFiles, ", "))
+	}
+	if len(helm.Values) > 0 {
+		inline, err := json.Marshal(helm.Values)
+		if err != nil {
+			inline = []byte("<invalid>")
+		}
+		fmt.Printf("%sHelm Set:       %s\n", indent, inline)
+	}
+}
+
+// printTerraformSource prints a Terraform application's settings, with each line starting with indent.
+func printTerraformSource(indent string, source *app.TerraformSource) {
+	if source == nil {
+		source = &app.TerraformSource{}
+	}
+	fmt.Printf("%sBinary:         %s\n", indent, common.DefaultIfEmpty(source.Binary, terraform.BinaryTerraform))
+	if len(source.VarFiles) > 0 {
+		fmt.Printf("%sVar Files:      %s\n", indent, strings.Join(source.VarFiles, ", "))
+	}
+	if len(source.Vars) > 0 {
+		names := make([]string, 0, len(source.Vars))
+		for name := range source.Vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		vars := make([]string, len(names))
+		for i, name := range names {
+			vars[i] = name + "=" + source.Vars[name]
+		}
+		fmt.Printf("%sVars:           %s\n", indent, strings.Join(vars, ", "))
+	}
+}
+
+// printTerraformPlan prints the plan section of a Terraform application's describe view.
+func printTerraformPlan(plan *app.TerraformPlan) {
+	fmt.Printf("\nTerraform Plan:\n")
+	if plan == nil {
+		fmt.Printf("  <none>\n")
+		return
+	}
+	state := "Awaiting approval"
+	switch {
+	case !plan.AppliedAt.IsZero():
+		state = "Applied " + common.GetRelativeTime(plan.AppliedAt)
+	case !plan.ApprovedAt.IsZero():
+		state = "Approved " + common.GetRelativeTime(plan.ApprovedAt) + ", applying on the next sync"
+	}
+	fmt.Printf("  ID:       %s\n", plan.ID)
+	fmt.Printf("  Commit:   %s\n", plan.GitHash)
+	fmt.Printf("  Summary:  %s\n", plan.Summary)
+	fmt.Printf("  Planned:  %s\n", common.GetRelativeTime(plan.PlannedAt))
+	fmt.Printf("  State:    %s\n", state)
+}
+
+// printPendingChange prints the pending change section of the describe view of an application requiring approval.
+func printPendingChange(change *app.PendingChange) {
+	fmt.Printf("\nPending Change:\n")
+	if change == nil {
+		fmt.Printf("  <none>\n")
+		return
+	}
+	state := "Awaiting approval"
+	if !change.ApprovedAt.IsZero() {
+		state = "Approved " + common.GetRelativeTime(change.ApprovedAt) + ", applying on the next sync"
+	}
+	fmt.Printf("  ID:       %s\n", change.ID)
+	fmt.Printf("  Commit:   %s\n", change.GitHash)
+	fmt.Printf("  Summary:  %s\n", change.Summary)
+	fmt.Printf("  Detected: %s\n", common.GetRelativeTime(change.DetectedAt))
+	fmt.Printf("  State:    %s\n", state)
+	fmt.Printf("  Diff:\n")
+	for _, line := range strings.Split(strings.TrimSuffix(change.Diff, "\n"), "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+}
+
+// printRollouts prints the progress of an application's canary rollouts.
+func printRollouts(rollouts []app.Rollout) {
+	fmt.Printf("\nRollouts:\n")
+	for _, r := range rollouts {
+		fmt.Printf("  %s %s/%s (%s)\n", r.Kind, r.Namespace, r.Name, r.Provider)
+		fmt.Printf("    Phase:    %s\n", common.DefaultIfEmpty(r.Phase, "Unknown"))
+		fmt.Printf("    Progress: %s\n", r.Progress())
+		if r.Message != "" {
+			fmt.Printf("    Message:  %s\n", common.TruncateString(r.Message, 100))
+		}
+	}
+}
+
+// printRepositoryStats prints how long fetching an application's repository takes and how large its clone is.
+func printRepositoryStats(stats *app.RepositoryStats) {
+	if stats == nil {
+		return
+	}
+	fmt.Printf("\nRepository Clone:\n")
+	if stats.MeasuredRevision != "" {
+		fmt.Printf("  Size:       %s (%d objects)\n", formatSize(stats.SizeBytes), stats.Objects)
+	}
+	if !stats.LastClonedAt.IsZero() {
+		fmt.Printf("  Last Clone: %s, %s\n", stats.LastCloneDuration.Round(time.Millisecond), common.GetRelativeTime(stats.LastClonedAt))
+	}
+	if !stats.LastPulledAt.IsZero() {
+		fmt.Printf("  Last Pull:  %s, %s\n", stats.LastPullDuration.Round(time.Millisecond), common.GetRelativeTime(stats.LastPulledAt))
+	}
+	if !stats.LastCheckedAt.IsZero() {
+		fmt.Printf("  Last Check: %s, %s (ls-remote, no new commits)\n", stats.LastCheckDuration.Round(time.Millisecond), common.GetRelativeTime(stats.LastCheckedAt))
+	}
+	fmt.Printf("  Fetches:    %d clones, %d pulls, %d skipped (no new commits), %d failed\n", stats.Clones, stats.Pulls, stats.Checks, stats.FetchFailures)
+}
+
+// formatSize formats a size on disk in the largest binary unit that keeps it at least 1 (e.g., "12.5Mi").
+func formatSize(bytes int64) string {
+	size, units := float64(bytes), []string{"", "Ki", "Mi", "Gi"}
+	unit := 0
+	for size >= 1024 && unit < len(units)-1 {
+		size /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%d bytes", bytes)
+	}
+	return fmt.Sprintf("%.1f%s", size, units[unit])
+}
+
+// printConditions prints the conditions section of a describe view.
+func printConditions(conditions []common.Condition) {
+	fmt.Printf("\nConditions:\n")
+	if len(conditions) == 0 {
+		fmt.Printf("  <none>\n")
+		return
+	}
+	for _, cond := range conditions {
+		fmt.Printf("  %-10s %-6s %-24s %s\n",
+			cond.Type,
+			cond.Status,
+			common.DefaultIfEmpty(cond.Reason, "-"),
+			common.GetRelativeTime(cond.LastTransitionTime))
+		if cond.Message != "" {
+			fmt.Printf("    %s\n", common.TruncateString(cond.Message, 100))
+		}
+	}
+}
+
+// appHealth derives a coarse health summary from the application's status and failures.
+func appHealth(a *app.Application) string {
+	switch a.Status {
+	case app.StatusSynced:
+		return "Healthy"
+	case app.StatusError, app.StatusInvalidManifests, app.StatusRolledBack:
+		return "Degraded"
+	case app.StatusPending, app.StatusSyncRequested, app.StatusInterrupted, app.StatusAwaitingApproval:
+		return "Progressing"
+	case app.StatusStopped, app.StatusSuspended:
+		return "Suspended"
+	default:
+		return "Unknown"
+	}
+}
+
+// describeFetchOptions lists what is fetched with an application's repository besides the branch's
+// files, e.g. "submodules, Git LFS", or returns an empty string.
+func describeFetchOptions(a *app.Application) string {
+	var fetched []string
+	if a.Submodules {
+		fetched = append(fetched, "submodules")
+	}
+	if a.LFS {
+		fetched = append(fetched, "Git LFS")
+	}
+	return strings.Join(fetched, ", ")
+}
+
+// describePollInterval describes how often an application's repository is polled, e.g.
+// "30s, adaptive up to 1h (currently 4m0s)".
+func describePollInterval(a *app.Application) string {
+	if !a.IsAdaptive() {
+		return a.Interval
+	}
+	description := fmt.Sprintf("%s, adaptive up to %s", a.Interval, a.MaxIntervalDuration())
+	if a.AdaptiveInterval > 0 {
+		description += fmt.Sprintf(" (currently %s)", a.PollInterval())
+	}
+	return description
+}
+
+// describeNextPoll reports when the controller is scheduled to poll the application's repository next.
+func describeNextPoll(a *app.Application) string {
+	if a.NextSyncAt.IsZero() {
+		return "N/A"
+	}
+	return fmt.Sprintf("%s (%s, every %s)",
+		a.NextSyncAt.Format("2006-01-02 15:04:05 MST"),
+		common.GetRelativeFutureTime(a.NextSyncAt),
+		common.DefaultIfEmpty(a.EffectiveInterval, a.Interval))
+}
+
+// appNames returns the names of all registered applications for shell completion.
+func appNames() []string {
+	apps, err := app.LoadApplications(app.DefaultAppConfigFile)
+	if err != nil {
+		return nil
+	}
+	apps.RLock()
+	defer apps.RUnlock()
+
+	names := make([]string, 0, len(apps.Apps))
+	for name := range apps.Apps {
+		names = append(names, name)
+	}
+	return names
+}
+
+func init() {
+	appCmd.AddCommand(describeAppCmd)
+
+	utils.AddOutputFlags(describeAppCmd, &describeAppOutput)
+}
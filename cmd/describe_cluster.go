@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	describeClusterTimeout time.Duration       // Timeout for live cluster queries
+	describeClusterOutput  utils.OutputOptions // Output options for the describe command
+)
+
+var describeClusterCmd = &cobra.Command{
+	Use:   "describe <name>",
+	Short: "Show detailed information about a Kubernetes cluster",
+	Long: `Displays a detailed view of a single registered Kubernetes cluster.
+
+This includes the kubeconfig context and API server URL, the Kubernetes version and
+node count (queried live), recent health check history, and the applications that
+target the cluster.`,
+	Example: `  # Describe a cluster
+  gitopsctl cluster describe production
+
+  # Allow more time for live cluster queries
+  gitopsctl cluster describe production --timeout 30s
+
+  # Print the cluster as YAML
+  gitopsctl cluster describe production -o yaml
+
+  # Extract the cluster status for scripting
+  gitopsctl cluster describe production -o jsonpath='{.status}'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDescribeClusterCommand,
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return clusterNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+// clusterLiveInfo holds information queried directly from the cluster's API server.
+type clusterLiveInfo struct {
+	version   string
+	nodeCount string
+}
+
+// clusterDescription is the machine-readable view of a described cluster.
+type clusterDescription struct {
+	*cluster.Cluster
+	Context      string                   `json:"context,omitempty"`
+	Server       string                   `json:"server,omitempty"`
+	Version      string                   `json:"version"`
+	NodeCount    string                   `json:"nodeCount"`
+	Applications []clusterApplicationInfo `json:"applications"`
+}
+
+// clusterApplicationInfo summarizes an application that targets a described cluster.
+type clusterApplicationInfo struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+func runDescribeClusterCommand(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+
+	if err := describeClusterOutput.Validate(); err != nil {
+		return err
+	}
+
+	cl, _, err := cluster.VerifyCluster(name)
+	if err != nil {
+		return err
+	}
+
+	var contextName, server string
+	if !cl.InCluster {
+		contextName, server, err = k8s.KubeconfigContext(cl.KubeconfigPath)
+		if err != nil {
+			logger.Debug("Failed to read kubeconfig context", zap.String("cluster", cl.Name), zap.Error(err))
+		}
+	}
+
+	targetingApps, err := appsTargetingCluster(cl.Name)
+	if err != nil {
+		return err
+	}
+
+	live := queryClusterLiveInfo(cl)
+
+	if describeClusterOutput.IsMachineOutput() {
+		desc := clusterDescription{
+			Cluster:      cl,
+			Context:      contextName,
+			Server:       server,
+			Version:      live.version,
+			NodeCount:    live.nodeCount,
+			Applications: make([]clusterApplicationInfo, 0, len(targetingApps)),
+		}
+		for _, a := range targetingApps {
+			desc.Applications = append(desc.Applications, clusterApplicationInfo{
+				Name:   a.Name,
+				Status: common.DefaultIfEmpty(string(a.Status), "Unknown"),
+			})
+		}
+		return utils.RenderObject(describeClusterOutput, desc)
+	}
+
+	printClusterDescription(cl, contextName, server, live, targetingApps)
+	return nil
+}
+
+// queryClusterLiveInfo connects to the cluster to retrieve its version and node count.
+// Failures are reported inline rather than failing the whole command.
+func queryClusterLiveInfo(cl *cluster.Cluster) clusterLiveInfo {
+	info := clusterLiveInfo{version: "unavailable", nodeCount: "unavailable"}
+
+	client, err := k8s.NewClientSet(zap.NewNop(), cl.KubeconfigPath, k8s.ClientOptions{QPS: cl.QPS, Burst: cl.Burst, InCluster: cl.InCluster})
+	if err != nil {
+		logger.Debug("Failed to create Kubernetes client", zap.String("cluster", cl.Name), zap.Error(err))
+		return info
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), describeClusterTimeout)
+	defer cancel()
+
+	if version, err := client.ServerVersion(ctx); err == nil {
+		info.version = version
+	} else {
+		logger.Debug("Failed to get server version", zap.String("cluster", cl.Name), zap.Error(err))
+	}
+	if count, err := client.NodeCount(ctx); err == nil {
+		info.nodeCount = fmt.Sprintf("%d", count)
+	} else {
+		logger.Debug("Failed to count nodes", zap.String("cluster", cl.Name), zap.Error(err))
+	}
+	return info
+}
+
+// clusterRateLimit describes the client-side rate limit the controller applies to a cluster, and
+// its limit on concurrent applies if it has one.
+func clusterRateLimit(cl *cluster.Cluster) string {
+	qps, burst := float32(k8s.DefaultQPS), k8s.DefaultBurst
+	if cl.QPS > 0 {
+		qps = cl.QPS
+	}
+	if cl.Burst > 0 {
+		burst = cl.Burst
+	}
+	limit := fmt.Sprintf("%g QPS, burst %d", qps, burst)
+	if cl.MaxConcurrentApplies > 0 {
+		limit += fmt.Sprintf(", %d concurrent applies", cl.MaxConcurrentApplies)
+	}
+	return limit
+}
+
+// printClusterHealth prints the diagnostics gathered by the cluster's last successful health check.
+func printClusterHealth(health *cluster.ClusterHealth) {
+	fmt.Printf("\nHealth:\n")
+	if health == nil {
+		fmt.Printf("  <not checked yet>\n")
+		return
+	}
+
+	nodes := "unavailable"
+	if health.Nodes >= 0 {
+		nodes = fmt.Sprintf("%d/%d ready", health.ReadyNodes, health.Nodes)
+	}
+	certificate := "none"
+	if !health.CertificateExpiry.IsZero() {
+		certificate = fmt.Sprintf("expires %s (%s)",
+			health.CertificateExpiry.Format("2006-01-02 15:04:05 MST"),
+			common.GetRelativeFutureTime(health.CertificateExpiry))
+	}
+
+	fmt.Printf("  Server Version: %s\n", common.DefaultIfEmpty(health.ServerVersion, "unknown"))
+	fmt.Printf("  Nodes:          %s\n", nodes)
+	fmt.Printf("  API Latency:    %s (node list %s)\n",
+		health.APILatency.Round(time.Millisecond), health.NodeListLatency.Round(time.Millisecond))
+	fmt.Printf("  Client Cert:    %s\n", certificate)
+	if free, ok := health.FreeCapacity(); ok {
+		fmt.Printf("  Free Capacity:  %.0f%% (CPU %dm of %dm, memory %s of %s requested)\n", free*100,
+			health.CPURequested, health.CPUAllocatable, formatBytes(health.MemoryRequested), formatBytes(health.MemoryAllocatable))
+	}
+	fmt.Printf("  Checked:        %s\n", common.GetRelativeTime(health.CheckedAt))
+	for _, warning := range health.Warnings {
+		fmt.Printf("  ⚠️  %s\n", warning)
+	}
+}
+
+// appsTargetingCluster returns the applications deployed to the named cluster, sorted by name.
+func appsTargetingCluster(clusterName string) ([]*app.Application, error) {
+	apps, err := app.LoadApplications(app.DefaultAppConfigFile)
+	if err != nil {
+		logger.Error("Failed to load applications", zap.Error(err))
+		return nil, fmt.Errorf("failed to load applications: %w", err)
+	}
+
+	apps.RLock()
+	defer apps.RUnlock()
+
+	var targeting []*app.Application
+	for _, a := range apps.List() {
+		if a.ClusterName == clusterName {
+			targeting = append(targeting, a)
+		}
+	}
+	sort.Slice(targeting, func(i, j int) bool { return targeting[i].Name < targeting[j].Name })
+	return targeting, nil
+}
+
+// printClusterDescription prints the detailed, human-readable view of a cluster.
+func printClusterDescription(cl *cluster.Cluster, contextName, server string, live clusterLiveInfo, apps []*app.Application) {
+	lastChecked := "N/A"
+	if !cl.LastCheckedAt.IsZero() {
+		lastChecked = fmt.Sprintf("%s (%s)", cl.LastCheckedAt.Format("2006-01-02 15:04:05 MST"), common.GetRelativeTime(cl.LastCheckedAt))
+	}
+
+	fmt.Printf("Name:           %s\n", cl.Name)
+	fmt.Printf("Kubeconfig:     %s\n", cl.ConfigSource())
+	fmt.Printf("Context:        %s\n", common.DefaultIfEmpty(contextName, "unknown"))
+	fmt.Printf("API Server:     %s\n", common.DefaultIfEmpty(server, "unknown"))
+	if !cl.InCluster {
+		if auth, err := k8s.KubeconfigAuth(cl.KubeconfigPath); err == nil {
+			fmt.Printf("Auth:           %s\n", auth)
+		}
+	}
+	fmt.Printf("Version:        %s\n", live.version)
+	fmt.Printf("Nodes:          %s\n", live.nodeCount)
+	fmt.Printf("Rate Limit:     %s\n", clusterRateLimit(cl))
+	if cl.ImpersonateUser != "" {
+		fmt.Printf("Impersonate:    %s\n", k8s.Impersonation{User: cl.ImpersonateUser, Groups: cl.ImpersonateGroups})
+	}
+	if len(cl.Variables) > 0 {
+		fmt.Printf("Variables:      %s\n", formatVariables(cl.Variables))
+	}
+	if len(cl.Labels) > 0 {
+		fmt.Printf("Labels:         %s\n", common.FormatLabels(cl.Labels))
+	}
+	fmt.Printf("Registered:     %s\n", cl.RegisteredAt.Format("2006-01-02 15:04:05 MST"))
+
+	fmt.Printf("\nStatus:\n")
+	fmt.Printf("  Status:        %s\n", common.DefaultIfEmpty(string(cl.Status), "Unknown"))
+	fmt.Printf("  Message:       %s\n", common.DefaultIfEmpty(cl.Message, "-"))
+	fmt.Printf("  Last Checked:  %s\n", lastChecked)
+	fmt.Printf("  Check Every:   %s\n", cl.EffectiveHealthCheckInterval())
+
+	printClusterHealth(cl.Health)
+
+	printConditions(cl.Conditions)
+
+	fmt.Printf("\nHealth History:\n")
+	if len(cl.HealthHistory) == 0 {
+		fmt.Printf("  <none>\n")
+	} else {
+		for i := len(cl.HealthHistory) - 1; i >= 0; i-- {
+			ev := cl.HealthHistory[i]
+			fmt.Printf("  %s  %-12s %s\n",
+				ev.Time.Format("2006-01-02 15:04:05 MST"),
+				ev.Status,
+				common.TruncateString(ev.Message, 80))
+		}
+	}
+
+	fmt.Printf("\nApplications:\n")
+	if len(apps) == 0 {
+		fmt.Printf("  <none>\n")
+	} else {
+		for _, a := range apps {
+			fmt.Printf("  %-30s %s\n", a.Name, common.DefaultIfEmpty(string(a.Status), "Unknown"))
+		}
+	}
+}
+
+// clusterNames returns the names of all registered clusters for shell completion.
+func clusterNames() []string {
+	clusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
+	if err != nil {
+		return nil
+	}
+	clusters.RLock()
+	defer clusters.RUnlock()
+
+	names := make([]string, 0, len(clusters.Cs))
+	for name := range clusters.Cs {
+		names = append(names, name)
+	}
+	return names
+}
+
+func init() {
+	clusterCmd.AddCommand(describeClusterCmd)
+
+	describeClusterCmd.Flags().DurationVar(&describeClusterTimeout, "timeout", 10*time.Second, "Timeout for live cluster queries")
+	utils.AddOutputFlags(describeClusterCmd, &describeClusterOutput)
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	eventsAppName     string             // Only show events for this application
+	eventsClusterName string             // Only show events for this cluster
+	eventsType        string             // Only show events of this type
+	eventsOutput      string             // Output format
+	eventsNoHeader    bool               // Hide table headers
+	eventsDetails     bool               // Show additional details
+	eventsWatchOpts   utils.WatchOptions // Watch options
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show events recorded by the GitOps controller",
+	Long: `Displays the events recorded by the controller, such as syncs, sync failures,
+manual sync requests, and cluster health transitions.
+
+Repeated identical events are aggregated into a single entry with a count.
+Events are retained for 24 hours, up to a maximum of 500 entries.`,
+	Example: `  # Show all events
+  gitopsctl events
+
+  # Show events for a single application
+  gitopsctl events --app myapp
+
+  # Show only warnings for a cluster
+  gitopsctl events --cluster production --type warning
+
+  # Watch events as they happen
+  gitopsctl events --watch`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if eventsAppName != "" && eventsClusterName != "" {
+			return fmt.Errorf("only one of --app or --cluster may be specified")
+		}
+		switch strings.ToLower(eventsType) {
+		case "", "all", "normal", "warning":
+		default:
+			return fmt.Errorf("invalid event type '%s'\nSupported types: all, normal, warning", eventsType)
+		}
+		if err := eventsWatchOpts.Validate(eventsOutput); err != nil {
+			return err
+		}
+		return utils.RunWatch(eventsWatchOpts, renderEvents)
+	},
+}
+
+// renderEvents loads the recorded events, applies the filters and prints them.
+func renderEvents() error {
+	events, err := event.LoadEvents(event.DefaultEventFile)
+	if err != nil {
+		logger.Error("Failed to load events", zap.Error(err))
+		return fmt.Errorf("failed to load events: %w", err)
+	}
+
+	kind, name := "", ""
+	switch {
+	case eventsAppName != "":
+		kind, name = event.KindApplication, eventsAppName
+	case eventsClusterName != "":
+		kind, name = event.KindCluster, eventsClusterName
+	}
+
+	events.RLock()
+	defer events.RUnlock()
+
+	var items []utils.Renderable
+	for _, ev := range events.Filter(kind, name) {
+		if eventsType != "" && !strings.EqualFold(eventsType, "all") && !strings.EqualFold(string(ev.Type), eventsType) {
+			continue
+		}
+		items = append(items, ev)
+	}
+
+	if len(items) == 0 && strings.ToLower(eventsOutput) != "json" && strings.ToLower(eventsOutput) != "yaml" {
+		fmt.Println("📋 No events found")
+		return nil
+	}
+
+	switch strings.ToLower(eventsOutput) {
+	case "json":
+		return utils.RenderJSON(items)
+	case "yaml":
+		return utils.RenderYAML(items)
+	default:
+		return utils.RenderTable(items, eventsNoHeader, eventsDetails)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+
+	eventsCmd.Flags().StringVar(&eventsAppName, "app", "", "Only show events for this application")
+	eventsCmd.Flags().StringVar(&eventsClusterName, "cluster", "", "Only show events for this cluster")
+	eventsCmd.Flags().StringVar(&eventsType, "type", "all", "Filter by event type: all, normal, warning")
+	eventsCmd.Flags().StringVarP(&eventsOutput, "output", "o", "table", "Output format: table, json, yaml")
+	eventsCmd.Flags().BoolVar(&eventsNoHeader, "no-header", false, "Hide table headers")
+	eventsCmd.Flags().BoolVar(&eventsDetails, "details", false, "Show additional details")
+	utils.AddWatchFlags(eventsCmd, &eventsWatchOpts)
+
+	eventsCmd.RegisterFlagCompletionFunc("app", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return appNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+	eventsCmd.RegisterFlagCompletionFunc("cluster", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return clusterNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+	eventsCmd.RegisterFlagCompletionFunc("type", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"all", "normal", "warning"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	eventsCmd.RegisterFlagCompletionFunc("output", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"table", "json", "yaml"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
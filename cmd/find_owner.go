@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/owner"
+	"aeswibon.com/github/gitopsctl/internal/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	findOwnerCluster   string              // Cluster the resource lives in
+	findOwnerKind      string              // Kind of the resource
+	findOwnerName      string              // Name of the resource
+	findOwnerNamespace string              // Namespace of the resource
+	findOwnerTimeout   time.Duration       // Timeout for the live cluster query
+	findOwnerOutput    utils.OutputOptions // Output options for the find-owner command
+)
+
+var findOwnerCmd = &cobra.Command{
+	Use:   "find-owner",
+	Short: "Show which application manages a resource in a cluster",
+	Long: `Looks up a live resource in a registered cluster and shows the registered application
+that manages it, the Git commit it was last applied from, and the controller instance that applied it.
+
+The owner is read from the tracking labels and annotations the controller puts on every resource
+it applies ('` + k8s.ManagedByLabel + `', '` + k8s.AppLabel + `', '` + k8s.RevisionAnnotation + `'
+and '` + k8s.ControllerAnnotation + `'). Resources applied before tracking labels were introduced are
+matched against the managed resources recorded for each application instead.`,
+	Example: `  # Find the application that manages a deployment
+  gitopsctl find-owner --cluster prod --kind Deployment --name web --namespace shop
+
+  # Look up a cluster-scoped resource and print the result as JSON
+  gitopsctl find-owner --cluster prod --kind ClusterRole --name viewer -o json`,
+	Args: cobra.NoArgs,
+	RunE: runFindOwnerCommand,
+}
+
+func runFindOwnerCommand(cmd *cobra.Command, args []string) error {
+	if err := findOwnerOutput.Validate(); err != nil {
+		return err
+	}
+
+	cl, _, err := cluster.VerifyCluster(strings.TrimSpace(findOwnerCluster))
+	if err != nil {
+		return err
+	}
+
+	apps, err := app.LoadApplications(app.DefaultAppConfigFile)
+	if err != nil {
+		logger.Error("Failed to load applications", zap.Error(err))
+		return fmt.Errorf("failed to load applications: %w", err)
+	}
+
+	client, err := k8s.NewClientSet(zap.NewNop(), cl.KubeconfigPath, k8s.ClientOptions{QPS: cl.QPS, Burst: cl.Burst, InCluster: cl.InCluster})
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client for cluster '%s': %w", cl.Name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), findOwnerTimeout)
+	defer cancel()
+
+	apps.RLock()
+	result, err := owner.Find(ctx, client, apps, owner.Query{
+		Cluster:   cl.Name,
+		Kind:      findOwnerKind,
+		Namespace: findOwnerNamespace,
+		Name:      findOwnerName,
+	})
+	apps.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to find owner: %w", err)
+	}
+
+	if findOwnerOutput.IsMachineOutput() {
+		return utils.RenderObject(findOwnerOutput, result)
+	}
+
+	resource := result.Kind + " " + result.Name
+	if result.Namespace != "" {
+		resource = fmt.Sprintf("%s %s/%s", result.Kind, result.Namespace, result.Name)
+	}
+	if !result.Managed {
+		fmt.Printf("%s in cluster '%s' is not managed by any application.\n", resource, result.Cluster)
+		return nil
+	}
+
+	fmt.Printf("Resource:     %s\n", resource)
+	fmt.Printf("Cluster:      %s\n", result.Cluster)
+	fmt.Printf("Application:  %s\n", result.App)
+	fmt.Printf("Revision:     %s\n", common.DefaultIfEmpty(result.Revision, "-"))
+	fmt.Printf("Controller:   %s\n", common.DefaultIfEmpty(result.Controller, "-"))
+	fmt.Printf("Found In:     %s\n", result.Source)
+
+	fmt.Printf("\nNext steps:\n")
+	if result.Registered {
+		fmt.Printf("  • Describe the application: gitopsctl app describe %s\n", result.App)
+	} else {
+		fmt.Printf("  ⚠️  Application '%s' is no longer registered for this cluster; the resource was left behind.\n", result.App)
+		fmt.Printf("  • List registered applications: gitopsctl app list\n")
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(findOwnerCmd)
+
+	findOwnerCmd.Flags().StringVar(&findOwnerCluster, "cluster", "", "Name of the registered cluster the resource lives in (required)")
+	findOwnerCmd.Flags().StringVar(&findOwnerKind, "kind", "", "Kind of the resource, e.g. Deployment or deployments.apps (required)")
+	findOwnerCmd.Flags().StringVar(&findOwnerName, "name", "", "Name of the resource (required)")
+	findOwnerCmd.Flags().StringVarP(&findOwnerNamespace, "namespace", "n", "", "Namespace of the resource (defaults to 'default' for namespaced resources)")
+	findOwnerCmd.Flags().DurationVar(&findOwnerTimeout, "timeout", 30*time.Second, "Timeout for the live cluster query")
+	utils.AddOutputFlags(findOwnerCmd, &findOwnerOutput)
+
+	findOwnerCmd.MarkFlagRequired("cluster")
+	findOwnerCmd.MarkFlagRequired("kind")
+	findOwnerCmd.MarkFlagRequired("name")
+	findOwnerCmd.RegisterFlagCompletionFunc("cluster", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return clusterNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/importer"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
+	"aeswibon.com/github/gitopsctl/internal/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var (
+	importFiles    []string // Files holding the resources to import
+	importCluster  string   // Cluster every imported application deploys to, overriding the resources' destinations
+	importInterval string   // Polling interval of the imported applications, overriding the resources' intervals
+	dryRunImport   bool     // Preview the imported applications without saving them
+	forceImport    bool     // Overwrite existing applications with the same names
+	importSocket   string   // Path of the controller's local control socket
+
+	importOutput utils.OutputOptions // Output options for the imported applications
+)
+
+var importCmd = &cobra.Command{
+	Use:     "import",
+	GroupID: "appGroup",
+	Short:   "Import applications from Argo CD or Flux",
+	Long: `Translate the application resources of other GitOps tools into gitopsctl applications, to
+evaluate gitopsctl with existing deployments or migrate to it.
+
+Each imported application is registered as with 'gitopsctl app register'. Settings gitopsctl does
+not support are rejected, and settings it handles differently are reported as warnings, so review
+the output of --dry-run before importing.`,
+	Example: `  # Preview the applications translated from an Argo CD Application
+  gitopsctl import argocd -f application.yaml --dry-run
+
+  # Import a Flux Kustomization and its GitRepository
+  gitopsctl import flux -f kustomization.yaml -f gitrepository.yaml`,
+	Args: cobra.NoArgs,
+}
+
+var importArgoCDCmd = &cobra.Command{
+	Use:   "argocd",
+	Short: "Import Argo CD Applications",
+	Long: `Imports the Argo CD Applications in the given files, which may hold several YAML documents.
+Other documents, such as AppProjects, are skipped.
+
+Each Application's source is mapped to a repository, branch and path. A targetRevision of HEAD
+tracks the repository's default branch; tags and pinned commits are not supported. Sources with a
+helm section use the helm renderer with their values files, values and parameters, rendered in the
+destination namespace. Sources with Jsonnet options use the jsonnet renderer, and config management
+plugins the manifest generator plugin of the same name.
+
+Charts that Argo CD detects from their Chart.yaml without a helm section are imported as plain YAML;
+register them again with --renderer helm.
+
+The destination cluster is the registered cluster with the destination's name, the '` + cluster.InClusterName + `'
+cluster for ` + importer.InClusterServer + `, or the registered cluster whose kubeconfig points at the
+destination's server. Use --cluster to choose it explicitly.`,
+	Example: `  # Preview the applications translated from Argo CD Applications
+  gitopsctl import argocd -f application.yaml --dry-run
+
+  # Import every Application exported from Argo CD into the production cluster
+  kubectl get applications -n argocd -o yaml | gitopsctl import argocd -f - -c production`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plugins, err := render.LoadPlugins(render.DefaultPluginConfigFile)
+		if err != nil {
+			return fmt.Errorf("failed to load manifest generator plugins: %w", err)
+		}
+		results, err := importer.ArgoCD(importFiles, plugins)
+		if err != nil {
+			return err
+		}
+		return runImport(cmd, results)
+	},
+}
+
+var importFluxCmd = &cobra.Command{
+	Use:   "flux",
+	Short: "Import Flux Kustomizations",
+	Long: `Imports the Flux Kustomizations in the given files, which may hold several YAML documents.
+The GitRepository each Kustomization refers to must be in one of the files. Other documents are skipped.
+
+Each Kustomization's path is mapped to an application tracking its GitRepository's branch, polled at
+the GitRepository's interval. Tags, semver ranges and pinned commits, and OCI and bucket sources,
+are not supported.
+
+Flux builds the path with kustomize, while gitopsctl applies its YAML files as they are. Paths with
+a kustomization.yaml need a kustomize manifest generator plugin, selected with 'gitopsctl app
+register --renderer'.
+
+Kustomizations deploy to the cluster Flux runs in, which is the '` + cluster.InClusterName + `' cluster if it is
+registered. Use --cluster to choose the cluster explicitly.`,
+	Example: `  # Preview the applications translated from a Flux Kustomization
+  gitopsctl import flux -f kustomization.yaml -f gitrepository.yaml --dry-run
+
+  # Import every Kustomization exported from Flux into the staging cluster
+  flux export source git --all > sources.yaml
+  flux export kustomization --all > kustomizations.yaml
+  gitopsctl import flux -f sources.yaml -f kustomizations.yaml -c staging`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		results, err := importer.Flux(importFiles)
+		if err != nil {
+			return err
+		}
+		return runImport(cmd, results)
+	},
+}
+
+// runImport completes the translated applications with their cluster, branch and interval, and
+// registers them, or shows them with --dry-run. Nothing is saved unless every application is valid.
+func runImport(cmd *cobra.Command, results []importer.Result) error {
+	if err := importOutput.Validate(); err != nil {
+		return err
+	}
+	if importCluster != "" {
+		if err := verifyClusterExists(importCluster); err != nil {
+			return err
+		}
+	}
+	clusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
+	if err != nil {
+		logger.Error("Failed to load cluster configurations", zap.Error(err))
+		return fmt.Errorf("failed to load cluster configurations: %w", err)
+	}
+	apps, err := app.LoadApplications(app.DefaultAppConfigFile)
+	if err != nil {
+		logger.Error("Failed to load applications", zap.Error(err))
+		return fmt.Errorf("failed to load applications: %w", err)
+	}
+
+	seen := make(map[string]string)
+	existing := make(map[string]bool)
+	for _, result := range results {
+		newApp := result.App
+		if source, ok := seen[newApp.Name]; ok {
+			return fmt.Errorf("%s and %s both import application '%s'", source, result.Source, newApp.Name)
+		}
+		seen[newApp.Name] = result.Source
+
+		apps.RLock()
+		_, exists := apps.Get(newApp.Name)
+		apps.RUnlock()
+		if exists && !forceImport {
+			return fmt.Errorf("%s: application '%s' already exists\nUse --force to overwrite it", result.Source, newApp.Name)
+		}
+		existing[newApp.Name] = exists
+
+		if newApp.ClusterName, err = resolveImportCluster(clusters, result.Destination); err != nil {
+			return fmt.Errorf("%s: %w", result.Source, err)
+		}
+		if cmd.Flags().Changed("interval") || newApp.Interval == "" {
+			newApp.Interval = strings.TrimSpace(importInterval)
+		}
+		if newApp.PollingInterval, err = common.ParsePollingInterval(newApp.Interval); err != nil {
+			return fmt.Errorf("%s: %w", result.Source, err)
+		}
+		if newApp.Branch == "" {
+			newApp.Branch = detectDefaultBranch(newApp.RepoURL, importOutput)
+		}
+	}
+
+	imported := make([]*app.Application, 0, len(results))
+	for _, result := range results {
+		imported = append(imported, result.App)
+	}
+
+	if dryRunImport {
+		if importOutput.IsMachineOutput() {
+			return utils.RenderObject(importOutput, imported)
+		}
+		fmt.Printf("\n🔍 DRY RUN - No changes will be applied\n")
+		for _, result := range results {
+			action := "CREATE"
+			if existing[result.App.Name] {
+				action = "UPDATE"
+			}
+			fmt.Printf("\n%s application '%s' from %s\n", action, result.App.Name, result.Source)
+			printImportedApplication(result)
+		}
+		fmt.Printf("\nTo import these applications, run the command again without --dry-run\n")
+		return nil
+	}
+
+	// A running controller is the only writer of the applications file, so it registers them
+	remote, err := controller.NewRemoteClient(logger, importSocket)
+	if err == nil && remote.IsDispatcherRunning() {
+		for _, newApp := range imported {
+			if err := remote.RegisterApp(newApp); err != nil {
+				logger.Error("Failed to register application with the controller", zap.String("app", newApp.Name), zap.Error(err))
+				return fmt.Errorf("failed to register application '%s': %w", newApp.Name, err)
+			}
+		}
+	} else {
+		logger.Debug("Controller not reachable, importing applications in configuration", zap.Error(err))
+		apps.Lock()
+		for _, newApp := range imported {
+			apps.Add(newApp)
+		}
+		err := app.SaveApplications(apps, app.DefaultAppConfigFile)
+		apps.Unlock()
+		if err != nil {
+			logger.Error("Failed to save application configuration", zap.Error(err))
+			return fmt.Errorf("failed to save application configuration: %w", err)
+		}
+	}
+
+	for _, result := range results {
+		logger.Info("Application imported",
+			zap.String("name", result.App.Name),
+			zap.String("source", result.Source),
+			zap.String("repo", result.App.RepoURL),
+			zap.String("branch", result.App.Branch),
+			zap.String("path", result.App.Path),
+			zap.String("cluster", result.App.ClusterName),
+			zap.Bool("is_update", existing[result.App.Name]),
+			zap.Int("warnings", len(result.Warnings)))
+	}
+
+	if importOutput.IsMachineOutput() {
+		return utils.RenderObject(importOutput, imported)
+	}
+	for _, result := range results {
+		fmt.Printf("\n✅ Application '%s' imported from %s\n", result.App.Name, result.Source)
+		printImportedApplication(result)
+	}
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • Review the applications: gitopsctl app list\n")
+	fmt.Printf("  • Monitor sync status: gitopsctl app status <name>\n")
+	return nil
+}
+
+// resolveImportCluster returns the registered cluster an imported application deploys to: the
+// --cluster flag, the cluster named like the destination, the in-cluster cluster for the in-cluster
+// server, or the cluster whose kubeconfig points at the destination's server.
+func resolveImportCluster(clusters *cluster.Clusters, dest importer.Destination) (string, error) {
+	if importCluster != "" {
+		return importCluster, nil
+	}
+
+	clusters.RLock()
+	defer clusters.RUnlock()
+
+	if dest.Name != "" {
+		if _, ok := clusters.Get(dest.Name); ok {
+			return dest.Name, nil
+		}
+	}
+	if dest.Server == importer.InClusterServer {
+		if _, ok := clusters.Get(cluster.InClusterName); ok {
+			return cluster.InClusterName, nil
+		}
+	}
+	if dest.Server != "" {
+		for _, c := range clusters.List() {
+			if c.KubeconfigPath == "" {
+				continue
+			}
+			config, err := clientcmd.BuildConfigFromFlags("", c.KubeconfigPath)
+			if err != nil {
+				logger.Debug("Failed to load cluster kubeconfig", zap.String("cluster", c.Name), zap.Error(err))
+				continue
+			}
+			if strings.TrimSuffix(config.Host, "/") == dest.Server {
+				return c.Name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no registered cluster matches destination %s\nUse --cluster to choose one, or 'gitopsctl cluster register' to add it", dest)
+}
+
+// printImportedApplication prints an imported application's configuration and warnings.
+func printImportedApplication(result importer.Result) {
+	a := result.App
+	fmt.Printf("  Repository:     %s@%s\n", a.RepoURL, a.Branch)
+	fmt.Printf("  Path:           %s\n", a.Path)
+	fmt.Printf("  Target Cluster: %s\n", a.ClusterName)
+	fmt.Printf("  Poll Interval:  %s\n", a.Interval)
+	fmt.Printf("  Renderer:       %s\n", common.DefaultIfEmpty(a.Renderer, render.RendererYAML))
+	printHelmSource("  ", a.Helm)
+	if len(a.PluginParams) > 0 {
+		params := make([]string, 0, len(a.PluginParams))
+		for key, value := range a.PluginParams {
+			params = append(params, key+"="+value)
+		}
+		fmt.Printf("  Plugin Params:  %s\n", strings.Join(params, ", "))
+	}
+	if len(a.Exclude) > 0 {
+		fmt.Printf("  Exclude:        %s\n", strings.Join(a.Exclude, ", "))
+	}
+	for _, warning := range result.Warnings {
+		fmt.Printf("  ⚠️  %s\n", warning)
+	}
+}
+
+// addImportFlags adds the flags shared by the import commands to cmd.
+func addImportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(&importFiles, "file", "f", nil,
+		"File holding the resources to import, or - for standard input (repeatable)")
+	cmd.Flags().StringVarP(&importCluster, "cluster", "c", "",
+		"Registered cluster to deploy every imported application to (defaults to the resources' destinations)")
+	cmd.Flags().StringVarP(&importInterval, "interval", "i", "5m",
+		"Polling interval of applications whose resources do not set one, or of every application if given")
+	cmd.Flags().BoolVar(&dryRunImport, "dry-run", false,
+		"Preview the imported applications without saving them")
+	cmd.Flags().BoolVar(&forceImport, "force", false,
+		"Overwrite existing applications with the same names")
+	cmd.Flags().StringVar(&importSocket, "control-socket", controller.DefaultControlSocket,
+		"Path of the controller's local control socket")
+	utils.AddOutputFlags(cmd, &importOutput)
+	cmd.MarkFlagRequired("file")
+	cmd.RegisterFlagCompletionFunc("cluster", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return clusterNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importArgoCDCmd, importFluxCmd)
+	addImportFlags(importArgoCDCmd)
+	addImportFlags(importFluxCmd)
+}
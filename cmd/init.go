@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// sampleAppName is the name of the sample application registered by 'init'.
+	sampleAppName = "guestbook"
+	// sampleAppRepo is the public demo repository the sample application is deployed from.
+	sampleAppRepo = "https://github.com/argoproj/argocd-example-apps.git"
+	// sampleAppBranch is the branch of the demo repository the sample application tracks.
+	sampleAppBranch = "master"
+	// sampleAppPath is the directory of the demo repository holding the sample application's manifests.
+	sampleAppPath = "guestbook"
+	// sampleAppInterval is how often the sample application's repository is polled.
+	sampleAppInterval = "1m"
+)
+
+var (
+	initClusterName string // Name of the cluster registered from the current kubeconfig context
+	initKubeconfig  string // Path of the kubeconfig whose current context is registered
+	initNoCluster   bool   // Skip registering the current kubeconfig context
+	initNoSampleApp bool   // Skip registering the sample application
+	initNoStart     bool   // Skip starting the controller
+	initAPIAddress  string // Address the API server listens on when the controller is started
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Set up gitopsctl and start the controller for local development",
+	Long: `Sets up gitopsctl in the current directory to get started quickly:
+
+  1. Creates the configs directory and empty application, cluster and event files.
+  2. Registers the current context of your kubeconfig as a cluster, named after the context
+     unless --cluster-name is given.
+  3. Registers a sample application, '` + sampleAppName + `', deployed from the public demo repository
+     ` + sampleAppRepo + `.
+  4. Starts the controller in the foreground in development mode: debug logging, and the API
+     server listening on 127.0.0.1 only. Press Ctrl+C to stop it.
+
+Existing files, clusters and applications are never overwritten, so init is safe to run again.
+Each step after the first can be skipped with --no-cluster, --no-sample-app and --no-start.`,
+	Example: `  # Set up everything and start the controller
+  gitopsctl init
+
+  # Register the current kubeconfig context under a chosen name, without starting the controller
+  gitopsctl init --cluster-name dev --no-start
+
+  # Only create the configuration files
+  gitopsctl init --no-cluster --no-sample-app --no-start`,
+	Args: cobra.NoArgs,
+	RunE: runInitCommand,
+}
+
+func runInitCommand(cmd *cobra.Command, args []string) error {
+	fmt.Printf("🚀 Setting up gitopsctl\n\n")
+
+	if err := scaffoldConfigFiles(); err != nil {
+		return err
+	}
+
+	var clusterName string
+	if !initNoCluster {
+		name, err := registerCurrentContext()
+		if err != nil {
+			return err
+		}
+		clusterName = name
+	}
+
+	if !initNoSampleApp {
+		if clusterName == "" {
+			fmt.Printf("  ⚠️  Skipped sample application: no cluster was registered to deploy it to\n")
+		} else if err := registerSampleApp(clusterName); err != nil {
+			return err
+		}
+	}
+
+	if initNoStart {
+		fmt.Printf("\nNext steps:\n")
+		fmt.Printf("  • Start the controller: gitopsctl start\n")
+		fmt.Printf("  • Register an application: gitopsctl app register --interactive\n")
+		return nil
+	}
+
+	fmt.Printf("\n▶️  Starting the controller in development mode (press Ctrl+C to stop)\n")
+	fmt.Printf("  • API server: http://%s/api/v1\n", initAPIAddress)
+	fmt.Printf("  • Follow the sample application: gitopsctl app status %s\n\n", sampleAppName)
+	logLevel.SetLevel(zapcore.DebugLevel)
+	apiAddress = initAPIAddress
+	return runStartCommand(cmd, args)
+}
+
+// scaffoldConfigFiles creates the configs directory and empty application, cluster and event files,
+// leaving existing files untouched.
+func scaffoldConfigFiles() error {
+	files := []struct {
+		path string
+		save func(path string) error
+	}{
+		{app.DefaultAppConfigFile, func(path string) error { return app.SaveApplications(app.NewApplications(), path) }},
+		{cluster.DefaultClusterConfigFile, func(path string) error { return cluster.SaveClusters(cluster.NewClusters(), path) }},
+		{event.DefaultEventFile, func(path string) error { return event.SaveEvents(event.NewEvents(), path) }},
+	}
+
+	dir := filepath.Dir(app.DefaultAppConfigFile)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	for _, f := range files {
+		if _, err := os.Stat(f.path); err == nil {
+			fmt.Printf("  • %s already exists\n", f.path)
+			continue
+		}
+		if err := f.save(f.path); err != nil {
+			return err
+		}
+		logger.Debug("Created configuration file", zap.String("file", f.path))
+		fmt.Printf("  ✓ Created %s\n", f.path)
+	}
+	return nil
+}
+
+// registerCurrentContext registers the kubeconfig's current context as a cluster and returns the
+// cluster's name. A cluster already registered under that name is kept as it is.
+func registerCurrentContext() (string, error) {
+	kubeconfigPath := strings.TrimSpace(initKubeconfig)
+	if kubeconfigPath == "" {
+		path, err := defaultKubeconfigPath()
+		if err != nil {
+			return "", fmt.Errorf("%w\nUse --kubeconfig to choose one, or --no-cluster to skip registering a cluster", err)
+		}
+		kubeconfigPath = path
+	}
+	resolvedPath, err := filepath.Abs(kubeconfigPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve kubeconfig path: %w", err)
+	}
+	if err := common.ValidateKubeconfigFile(resolvedPath); err != nil {
+		return "", err
+	}
+
+	kubeconfig, err := clientcmd.LoadFromFile(resolvedPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load kubeconfig %s: %w", resolvedPath, err)
+	}
+	if kubeconfig.CurrentContext == "" {
+		return "", fmt.Errorf("kubeconfig %s has no current context\nSelect one with 'kubectl config use-context', or use --no-cluster", resolvedPath)
+	}
+
+	name := strings.TrimSpace(initClusterName)
+	if name == "" {
+		name = clusterNameFromContext(kubeconfig.CurrentContext)
+	}
+	if err := common.ValidateName(name); err != nil {
+		return "", fmt.Errorf("invalid cluster name: %w", err)
+	}
+
+	clusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to load cluster configurations: %w", err)
+	}
+	clusters.Lock()
+	defer clusters.Unlock()
+
+	if existing, exists := clusters.Get(name); exists {
+		fmt.Printf("  • Cluster '%s' is already registered (%s)\n", name, existing.ConfigSource())
+		return name, nil
+	}
+	clusters.Add(&cluster.Cluster{
+		Name:           name,
+		KubeconfigPath: resolvedPath,
+		RegisteredAt:   time.Now(),
+		Status:         cluster.StatusPending,
+		Message:        fmt.Sprintf("Registered by 'gitopsctl init' from context '%s', awaiting validation", kubeconfig.CurrentContext),
+	})
+	if err := cluster.SaveClusters(clusters, cluster.DefaultClusterConfigFile); err != nil {
+		return "", fmt.Errorf("failed to save cluster configuration: %w", err)
+	}
+
+	logger.Info("Cluster registered from kubeconfig context",
+		zap.String("name", name),
+		zap.String("context", kubeconfig.CurrentContext),
+		zap.String("kubeconfig", resolvedPath))
+	fmt.Printf("  ✓ Registered cluster '%s' from context '%s'\n", name, kubeconfig.CurrentContext)
+	fmt.Printf("    The cluster follows the current context of %s\n", resolvedPath)
+	return name, nil
+}
+
+// clusterNameFromContext turns a kubeconfig context name into a valid cluster name, replacing
+// unsupported characters with hyphens.
+func clusterNameFromContext(context string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, context)
+	if len(name) > common.MaxNameLength {
+		name = name[len(name)-common.MaxNameLength:] // Keep the end, which usually names the cluster
+	}
+	return common.DefaultIfEmpty(strings.Trim(name, "-"), "local")
+}
+
+// registerSampleApp registers the sample application against the given cluster, unless an
+// application with its name already exists.
+func registerSampleApp(clusterName string) error {
+	apps, exists, err := loadAndCheckApplications(sampleAppName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		fmt.Printf("  • Application '%s' is already registered\n", sampleAppName)
+		return nil
+	}
+	pollingInterval, err := common.ParsePollingInterval(sampleAppInterval)
+	if err != nil {
+		return err
+	}
+
+	apps.Lock()
+	defer apps.Unlock()
+	apps.Add(&app.Application{
+		Name:            sampleAppName,
+		RepoURL:         sampleAppRepo,
+		Branch:          sampleAppBranch,
+		Path:            sampleAppPath,
+		ClusterName:     clusterName,
+		Interval:        sampleAppInterval,
+		PollingInterval: pollingInterval,
+		Status:          app.StatusPending,
+		Message:         "Sample application registered by 'gitopsctl init', awaiting first sync.",
+	})
+	if err := app.SaveApplications(apps, app.DefaultAppConfigFile); err != nil {
+		return fmt.Errorf("failed to save application configuration: %w", err)
+	}
+
+	logger.Info("Sample application registered", zap.String("name", sampleAppName), zap.String("cluster", clusterName))
+	fmt.Printf("  ✓ Registered sample application '%s' (%s, path %s) on cluster '%s'\n",
+		sampleAppName, sampleAppRepo, sampleAppPath, clusterName)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().StringVar(&initClusterName, "cluster-name", "", "Name to register the current kubeconfig context under (defaults to the context's name)")
+	initCmd.Flags().StringVarP(&initKubeconfig, "kubeconfig", "k", "", "Path to the kubeconfig whose current context is registered (auto-detected if not specified)")
+	initCmd.Flags().BoolVar(&initNoCluster, "no-cluster", false, "Do not register the current kubeconfig context as a cluster")
+	initCmd.Flags().BoolVar(&initNoSampleApp, "no-sample-app", false, "Do not register the sample application")
+	initCmd.Flags().BoolVar(&initNoStart, "no-start", false, "Do not start the controller")
+	initCmd.Flags().StringVar(&initAPIAddress, "api-address", "127.0.0.1:8080", "Address for the API server to listen on when the controller is started")
+}
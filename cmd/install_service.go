@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	serviceUser       string // User the service runs as
+	serviceWorkingDir string // Directory the service runs in, holding its configs directory
+	serviceFile       string // File to write the unit to instead of standard output
+)
+
+// serviceUnitTemplate is the systemd unit generated by install-service.
+var serviceUnitTemplate = template.Must(template.New("unit").Parse(`# Generated by 'gitopsctl install-service'.
+# Install it with:
+#   sudo cp gitopsctl.service /etc/systemd/system/
+#   sudo systemctl daemon-reload
+#   sudo systemctl enable --now gitopsctl
+[Unit]
+Description=gitopsctl GitOps controller
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+Type=simple
+User={{ .User }}
+WorkingDirectory={{ .WorkingDir }}
+ExecStart={{ .ExecStart }}
+Restart=on-failure
+RestartSec=5
+KillSignal=SIGTERM
+TimeoutStopSec={{ .StopTimeout }}
+
+[Install]
+WantedBy=multi-user.target
+`))
+
+var installServiceCmd = &cobra.Command{
+	Use:   "install-service [-- start flags...]",
+	Short: "Generate a systemd unit for running the controller as a service",
+	Long: `Generates a systemd unit that runs 'gitopsctl start' as a service, restarting it if it fails.
+
+The unit is printed to standard output, or written to --file, with instructions for installing it
+in a comment. The service runs in --working-dir, which holds the configs directory, as --user.
+Flags for 'gitopsctl start' can be given after '--' and are added to the unit's command line.
+
+systemd tracks the service's process itself, so do not pass --daemon.`,
+	Example: `  # Print a unit for the current user and directory
+  gitopsctl install-service
+
+  # Write a unit that runs the controller without the API server
+  gitopsctl install-service --file gitopsctl.service -- --no-api`,
+	RunE: runInstallServiceCommand,
+}
+
+func runInstallServiceCommand(cmd *cobra.Command, args []string) error {
+	for _, arg := range args {
+		if arg == "--daemon" || arg == "-d" || strings.HasPrefix(arg, "--daemon=") {
+			return fmt.Errorf("--daemon cannot be used in a service; systemd runs the controller in the background")
+		}
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the gitopsctl executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	workingDir := serviceWorkingDir
+	if workingDir == "" {
+		if workingDir, err = os.Getwd(); err != nil {
+			return fmt.Errorf("failed to determine the working directory: %w", err)
+		}
+	}
+	if workingDir, err = filepath.Abs(workingDir); err != nil {
+		return fmt.Errorf("failed to resolve working directory: %w", err)
+	}
+
+	serviceUserName := serviceUser
+	if serviceUserName == "" {
+		current, err := user.Current()
+		if err != nil {
+			return fmt.Errorf("failed to determine the current user; set --user: %w", err)
+		}
+		serviceUserName = current.Username
+	}
+
+	execStart := append([]string{systemdQuote(executable), "start"}, quoteAll(args)...)
+	var unit strings.Builder
+	if err := serviceUnitTemplate.Execute(&unit, map[string]string{
+		"User":        serviceUserName,
+		"WorkingDir":  workingDir, // Taken verbatim by systemd, so not quoted
+		"ExecStart":   strings.Join(execStart, " "),
+		"StopTimeout": fmt.Sprintf("%d", int(controller.DefaultStopTimeout.Seconds())),
+	}); err != nil {
+		return fmt.Errorf("failed to generate systemd unit: %w", err)
+	}
+
+	if serviceFile == "" {
+		fmt.Print(unit.String())
+		return nil
+	}
+	if err := os.WriteFile(serviceFile, []byte(unit.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write systemd unit %s: %w", serviceFile, err)
+	}
+
+	logger.Info("Systemd unit written", zap.String("file", serviceFile))
+	fmt.Printf("✓ Systemd unit written to %s\n", serviceFile)
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • Install it: sudo cp %s /etc/systemd/system/gitopsctl.service && sudo systemctl daemon-reload\n", serviceFile)
+	fmt.Printf("  • Start it on boot and now: sudo systemctl enable --now gitopsctl\n")
+	fmt.Printf("  • Follow the logs: journalctl -u gitopsctl -f\n")
+	return nil
+}
+
+// systemdQuote quotes a command line word for a systemd unit if it contains characters systemd
+// would otherwise interpret.
+func systemdQuote(word string) string {
+	if word != "" && !strings.ContainsAny(word, " \t\"'\\$%;") {
+		return word
+	}
+	word = strings.ReplaceAll(word, `\`, `\\`)
+	word = strings.ReplaceAll(word, `"`, `\"`)
+	word = strings.ReplaceAll(word, `$`, `$$`)
+	word = strings.ReplaceAll(word, `%`, `%%`)
+	return `"` + word + `"`
+}
+
+// quoteAll quotes each word with systemdQuote.
+func quoteAll(words []string) []string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = systemdQuote(word)
+	}
+	return quoted
+}
+
+func init() {
+	rootCmd.AddCommand(installServiceCmd)
+
+	installServiceCmd.Flags().StringVar(&serviceUser, "user", "", "User the service runs as (defaults to the current user)")
+	installServiceCmd.Flags().StringVar(&serviceWorkingDir, "working-dir", "", "Directory the service runs in, holding its configs directory (defaults to the current directory)")
+	installServiceCmd.Flags().StringVar(&serviceFile, "file", "", "Write the unit to this file instead of standard output")
+}
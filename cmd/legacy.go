@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// legacyCommands maps the original flat command names to their noun-verb replacements.
+var legacyCommands = []struct {
+	use    string
+	path   string
+	target *cobra.Command
+}{
+	{"register-apps", "app register", registerCmd},
+	{"list-apps", "app list", listAppCmd},
+	{"status-apps", "app status", statusAppCmd},
+	{"unregister", "app delete", unregisterAppCmd},
+	{"describe-app", "app describe", describeAppCmd},
+	{"register-cluster", "cluster register", registerClusterCmd},
+	{"list-clusters", "cluster list", listClusterCmd},
+	{"status-clusters", "cluster status", statusClusterCmd},
+	{"unregister-cluster", "cluster delete", unregisterClusterCmd},
+	{"describe-cluster", "cluster describe", describeClusterCmd},
+}
+
+// addLegacyCommands registers hidden, deprecated aliases for the original flat commands.
+// Each alias shares its target's flag set and run function, so both forms behave identically.
+// It must run after all command flags have been defined.
+func addLegacyCommands() {
+	for _, legacy := range legacyCommands {
+		alias := &cobra.Command{
+			Use:               legacy.use,
+			Short:             legacy.target.Short,
+			Hidden:            true,
+			Deprecated:        fmt.Sprintf("use 'gitopsctl %s' instead", legacy.path),
+			Args:              legacy.target.Args,
+			RunE:              legacy.target.RunE,
+			ValidArgsFunction: legacy.target.ValidArgsFunction,
+		}
+		alias.Flags().AddFlagSet(legacy.target.Flags())
+		rootCmd.AddCommand(alias)
+	}
+}
@@ -5,53 +5,73 @@ import (
 	"sort"
 	"strings"
 
+	"aeswibon.com/github/gitopsctl/internal/common"
 	"aeswibon.com/github/gitopsctl/internal/core/app"
 	"aeswibon.com/github/gitopsctl/internal/utils"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
-var listAppOpts utils.ListOptions
+var (
+	listAppOpts      utils.ListOptions
+	listAppWatchOpts utils.WatchOptions
+)
 
 var listAppCmd = &cobra.Command{
-	Use:     "list-apps",
-	GroupID: "appGroup",
-	Short:   "List all registered GitOps applications",
+	Use:   "list",
+	Short: "List all registered GitOps applications",
 	Long: `Displays information about all registered GitOps applications registered with gitopsctl.
 	
 This command shows application names, repository URLs, branches, paths, clusters, and sync intervals.
 You can filter, sort, and format the output according to your needs.`,
 	Example: `
   # List all registered applications in table format
-  gitopsctl app list-apps
+  gitopsctl app list
+
+  # List only failing applications
+  gitopsctl app list --status error
+
+  # List every application that is not synced
+  gitopsctl app list --status '!synced'
 
-  # List only active applications
-  gitopsctl app list-apps --status active
+  # Find applications by part of their name, repository URL or message
+  gitopsctl app list --search payments
 
   # List applications sorted by name
-  gitopsctl app list-apps --sort-by name
+  gitopsctl app list --sort-by name
 
   # Show detailed information for each application
-  gitopsctl app list-apps --details
+  gitopsctl app list --details
 
   # Output as JSON for automation
-  gitopsctl app list-apps --output json
+  gitopsctl app list --output json
+
+  # Export selected columns as CSV for a spreadsheet
+  gitopsctl app list -o csv --columns name,status,cluster
 
   # Compact view without headers
-  gitopsctl app list-apps --no-header
+  gitopsctl app list --no-header
+
+  # Refresh the list every 2 seconds
+  gitopsctl app list --watch
 	`,
 	RunE: runListAppsCommand,
 }
 
 func runListAppsCommand(cmd *cobra.Command, args []string) error {
-	return utils.RunListCommand(
-		logger,
-		listAppOpts,
-		loadAppsForList,
-		filterAppsForList,
-		sortAppsForList,
-		handleEmptyAppsForList,
-	)
+	if err := listAppWatchOpts.Validate(listAppOpts.OutputFormat); err != nil {
+		return err
+	}
+	return utils.RunWatch(listAppWatchOpts, func() error {
+		return utils.RunListCommand(
+			logger,
+			listAppOpts,
+			loadAppsForList,
+			filterAppsForList,
+			sortAppsForList,
+			handleEmptyAppsForList,
+		)
+	})
 }
 
 // loadAppsForList loads applications and converts them to cliutils.Renderable.
@@ -74,18 +94,21 @@ func loadAppsForList() ([]utils.Renderable, error) {
 	return renderableApps, nil
 }
 
-// filterAppsForList filters a slice of Renderable (app.Application) by status.
+// filterAppsForList filters a slice of Renderable (app.Application) by a status filter such as "error,pending"
+// or "!synced".
 func filterAppsForList(items []utils.Renderable, statusFilter string) []utils.Renderable {
-	if statusFilter == "" || strings.ToLower(statusFilter) == "all" {
+	filter, err := common.ParseStatusFilter(statusFilter, app.Statuses)
+	if err != nil {
+		return nil
+	}
+	if filter.IsAll() {
 		return items
 	}
 
 	var filtered []utils.Renderable
-	targetStatus := strings.ToLower(statusFilter)
-
 	for _, item := range items {
 		if appItem, ok := item.(*app.Application); ok {
-			if strings.ToLower(appItem.Status) == targetStatus {
+			if filter.Matches(string(appItem.Status)) {
 				filtered = append(filtered, appItem)
 			}
 		}
@@ -133,8 +156,9 @@ func handleEmptyAppsForList(statusFilter string) error {
 }
 
 func init() {
-	rootCmd.AddCommand(listAppCmd)
-	utils.AddListFlags(listAppCmd, &listAppOpts, "name")
+	appCmd.AddCommand(listAppCmd)
+	utils.AddListFlags(listAppCmd, &listAppOpts, "name", app.Statuses)
+	utils.AddWatchFlags(listAppCmd, &listAppWatchOpts)
 	listAppCmd.RegisterFlagCompletionFunc("sort-by", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"name", "status", "branch"}, cobra.ShellCompDirectiveDefault
 	})
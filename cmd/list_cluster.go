@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 
+	"aeswibon.com/github/gitopsctl/internal/common"
 	"aeswibon.com/github/gitopsctl/internal/core/cluster"
 	"aeswibon.com/github/gitopsctl/internal/utils"
 	"github.com/spf13/cobra"
@@ -14,31 +15,39 @@ import (
 var listClusterOpts utils.ListOptions
 
 var listClusterCmd = &cobra.Command{
-	Use:     "list-clusters",
-	GroupID: "clusterGroup",
-	Short:   "List all registered Kubernetes clusters",
+	Use:   "list",
+	Short: "List all registered Kubernetes clusters",
 	Long: `Displays information about all Kubernetes clusters registered with gitopsctl.
 
 This command shows cluster names, kubeconfig paths, connection status, and registration details.
 You can filter, sort, and format the output according to your needs.`,
 	Example: `
   # List all clusters in table format
-  gitopsctl list-clusters
+  gitopsctl cluster list
 
   # List only active clusters
-  gitopsctl list-clusters --status active
+  gitopsctl cluster list --status active
+
+  # List clusters that are unreachable or degraded
+  gitopsctl cluster list --status unreachable,degraded
+
+  # Find clusters by part of their name, kubeconfig path or message
+  gitopsctl cluster list --search eu-west
 
   # List clusters sorted by registration date
-  gitopsctl list-clusters --sort-by registered
+  gitopsctl cluster list --sort-by registered
 
   # Show detailed information
-  gitopsctl list-clusters --details
+  gitopsctl cluster list --details
 
   # Output as JSON for automation
-  gitopsctl list-clusters --output json
+  gitopsctl cluster list --output json
+
+  # Export selected columns as CSV for a spreadsheet
+  gitopsctl cluster list -o csv --columns name,status,message
 
   # Compact view without headers
-  gitopsctl list-clusters --no-header
+  gitopsctl cluster list --no-header
 	`,
 	RunE: runListClustersCommand,
 }
@@ -74,18 +83,21 @@ func loadClustersForList() ([]utils.Renderable, error) {
 	return renderableClusters, nil
 }
 
-// filterClustersForList filters a slice of Renderable (cluster.Cluster) by status.
+// filterClustersForList filters a slice of Renderable (cluster.Cluster) by a status filter such as "error,pending"
+// or "!synced".
 func filterClustersForList(items []utils.Renderable, statusFilter string) []utils.Renderable {
-	if statusFilter == "" || strings.ToLower(statusFilter) == "all" {
+	filter, err := common.ParseStatusFilter(statusFilter, cluster.Statuses)
+	if err != nil {
+		return nil
+	}
+	if filter.IsAll() {
 		return items
 	}
 
 	var filtered []utils.Renderable
-	targetStatus := strings.ToLower(statusFilter)
-
 	for _, item := range items {
 		if clItem, ok := item.(*cluster.Cluster); ok {
-			if strings.ToLower(clItem.Status) == targetStatus {
+			if filter.Matches(string(clItem.Status)) {
 				filtered = append(filtered, clItem)
 			}
 		}
@@ -130,8 +142,9 @@ func handleEmptyClustersForList(statusFilter string) error {
 }
 
 func init() {
-	rootCmd.AddCommand(listClusterCmd)
-	utils.AddListFlags(listClusterCmd, &listClusterOpts, "name")
+	clusterCmd.AddCommand(listClusterCmd)
+	utils.AddListFlags(listClusterCmd, &listClusterOpts, "name", cluster.Statuses)
+	listClusterCmd.Flags().Lookup("search").Usage = "Only list clusters whose name, kubeconfig path or message contains this text, ignoring case"
 	listClusterCmd.RegisterFlagCompletionFunc("sort-by", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"name", "status", "registered"}, cobra.ShellCompDirectiveDefault
 	})
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/install"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	manifestsNamespace    string // Namespace the controller is deployed to
+	manifestsImage        string // Container image the controller runs from
+	manifestsClusterRole  string // Cluster role bound to the controller's service account
+	manifestsStorageSize  string // Size of the volume claim holding the controller's state
+	manifestsStorageClass string // Storage class of the volume claim
+	manifestsFile         string // File to write the manifests to instead of standard output
+)
+
+var manifestsCmd = &cobra.Command{
+	Use:   "manifests",
+	Short: "Generate manifests for running the controller in Kubernetes",
+	Long:  `Generate the Kubernetes manifests that deploy the controller into a cluster.`,
+	Example: `  # Deploy the controller into the current cluster
+  gitopsctl manifests generate | kubectl apply -f -`,
+	Args: cobra.NoArgs,
+}
+
+var generateManifestsCmd = &cobra.Command{
+	Use:   "generate [-- start flags...]",
+	Short: "Generate the Deployment, RBAC and storage manifests for the controller",
+	Long: `Generates the manifests that run the controller inside a Kubernetes cluster: a Namespace, a
+ServiceAccount bound to --cluster-role, a PersistentVolumeClaim, a Deployment and a Service for the
+API server on port ` + fmt.Sprint(install.APIPort) + `.
+
+The controller's configs directory, holding registered applications, clusters and events, is kept
+on the PersistentVolumeClaim so it survives restarts. A single replica runs at a time.
+
+The cluster the controller runs in is registered automatically as '` + cluster.InClusterName + `', using the
+pod's service account. Other clusters can be registered through the API with a kubeconfig mounted
+into the pod. Flags for 'gitopsctl start' can be given after '--' and are added to the container's
+arguments.
+
+The manifests are printed to standard output, or written to --file.`,
+	Example: `  # Deploy the controller into the current cluster
+  gitopsctl manifests generate | kubectl apply -f -
+
+  # Use a custom image and storage class, and run without the API server
+  gitopsctl manifests generate --image registry.example.com/gitopsctl:v1 --storage-class fast \
+    --file gitopsctl.yaml -- --no-api`,
+	RunE: runGenerateManifestsCommand,
+}
+
+func runGenerateManifestsCommand(cmd *cobra.Command, args []string) error {
+	if err := common.ValidateName(manifestsNamespace); err != nil {
+		return fmt.Errorf("invalid namespace: %w", err)
+	}
+	for _, arg := range args {
+		if arg == "--daemon" || arg == "-d" || strings.HasPrefix(arg, "--daemon=") {
+			return fmt.Errorf("--daemon cannot be used in a container; Kubernetes runs the controller")
+		}
+		if arg == "--api-address" || arg == "-a" || strings.HasPrefix(arg, "--api-address=") {
+			return fmt.Errorf("--api-address cannot be changed; the API server listens on port %d, exposed by the Service", install.APIPort)
+		}
+	}
+
+	manifests, err := install.Generate(install.Options{
+		Namespace:    manifestsNamespace,
+		Image:        manifestsImage,
+		ClusterRole:  manifestsClusterRole,
+		StorageSize:  manifestsStorageSize,
+		StorageClass: manifestsStorageClass,
+		Args:         args,
+	})
+	if err != nil {
+		return err
+	}
+
+	if manifestsFile == "" {
+		fmt.Print(string(manifests))
+		return nil
+	}
+	if err := os.WriteFile(manifestsFile, manifests, 0644); err != nil {
+		return fmt.Errorf("failed to write manifests %s: %w", manifestsFile, err)
+	}
+
+	logger.Info("Manifests written", zap.String("file", manifestsFile), zap.String("namespace", manifestsNamespace))
+	fmt.Printf("✓ Manifests written to %s\n", manifestsFile)
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • Deploy them: kubectl apply -f %s\n", manifestsFile)
+	fmt.Printf("  • Reach the API: kubectl -n %s port-forward svc/%s %d\n", manifestsNamespace, install.Name, install.APIPort)
+	fmt.Printf("  • Follow the logs: kubectl -n %s logs -f deploy/%s\n", manifestsNamespace, install.Name)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(manifestsCmd)
+	manifestsCmd.AddCommand(generateManifestsCmd)
+
+	generateManifestsCmd.Flags().StringVarP(&manifestsNamespace, "namespace", "n", install.DefaultNamespace, "Namespace to deploy the controller to")
+	generateManifestsCmd.Flags().StringVar(&manifestsImage, "image", install.DefaultImage, "Container image to run the controller from")
+	generateManifestsCmd.Flags().StringVar(&manifestsClusterRole, "cluster-role", install.DefaultClusterRole, "Cluster role to bind to the controller's service account")
+	generateManifestsCmd.Flags().StringVar(&manifestsStorageSize, "storage-size", install.DefaultStorageSize, "Size of the volume holding the controller's state")
+	generateManifestsCmd.Flags().StringVar(&manifestsStorageClass, "storage-class", "", "Storage class of the volume holding the controller's state (defaults to the cluster's default)")
+	generateManifestsCmd.Flags().StringVar(&manifestsFile, "file", "", "Write the manifests to this file instead of standard output")
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade the application and cluster files to the current format",
+	Long: `Upgrades the application and cluster files written by earlier versions of gitopsctl to the
+current format, after copying each file it rewrites to a backup next to it named
+<file>.v<version>.<timestamp>.bak.
+
+Applications that still name their kubeconfig with the per-application kubeconfigPath field are
+moved to a cluster registered for that kubeconfig, named kubeconfig-<hash of its path>; rename
+it with 'gitopsctl cluster rename'.
+
+Files of older versions are also read by every command as they are, and migrated automatically
+when the controller starts, so running migrate is only needed to upgrade the files explicitly,
+for example before committing them or to review the result. Files already in the current format
+are left untouched.`,
+	Example: `  # Upgrade the application and cluster files
+  gitopsctl migrate`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateCommand,
+}
+
+func runMigrateCommand(cmd *cobra.Command, args []string) error {
+	apps, clusters, err := migrateStateFiles()
+	if err != nil {
+		return err
+	}
+
+	if apps.Backup == "" && clusters.Backup == "" && len(clusters.Registered) == 0 {
+		fmt.Printf("✓ The application and cluster files are already in the current format (version %d)\n", app.FileVersion)
+		return nil
+	}
+	for _, migrated := range []struct {
+		path   string
+		from   int
+		to     int
+		backup string
+	}{
+		{app.DefaultAppConfigFile, apps.From, app.FileVersion, apps.Backup},
+		{cluster.DefaultClusterConfigFile, clusters.From, cluster.FileVersion, clusters.Backup},
+	} {
+		if migrated.backup != "" {
+			fmt.Printf("  ✓ Upgraded %s from version %d to %d (backup: %s)\n", migrated.path, migrated.from, migrated.to, migrated.backup)
+		}
+	}
+	for _, name := range clusters.Registered {
+		fmt.Printf("  ✓ Registered cluster '%s' for kubeconfig %s\n", name, apps.LegacyClusters[name])
+	}
+	if len(clusters.Registered) > 0 {
+		fmt.Printf("\n💡 Rename the registered clusters with 'gitopsctl cluster rename <old> <new>'\n")
+	}
+	return nil
+}
+
+// migrateStateFiles upgrades the application and cluster files to the current format, registering
+// a cluster for each kubeconfig applications were moved away from.
+//
+// Both files are decoded before either is written, and the clusters file is written first: the
+// upgraded applications file no longer records the kubeconfigs the clusters are registered for.
+func migrateStateFiles() (*app.Migration, *cluster.Migration, error) {
+	apps, err := app.PlanMigration(app.DefaultAppConfigFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to migrate applications: %w", err)
+	}
+	clusters, err := cluster.PlanMigration(cluster.DefaultClusterConfigFile, apps.LegacyClusters)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to migrate clusters: %w", err)
+	}
+
+	if err := clusters.Apply(); err != nil {
+		return nil, nil, fmt.Errorf("failed to migrate clusters: %w", err)
+	}
+	if clusters.Backup != "" || len(clusters.Registered) > 0 {
+		logger.Info("Migrated clusters file",
+			zap.String("file", cluster.DefaultClusterConfigFile),
+			zap.Int("from", clusters.From),
+			zap.Int("to", cluster.FileVersion),
+			zap.String("backup", clusters.Backup),
+			zap.Strings("registered", clusters.Registered))
+	}
+
+	if err := apps.Apply(); err != nil {
+		return nil, nil, fmt.Errorf("failed to migrate applications: %w", err)
+	}
+	if apps.Backup != "" {
+		logger.Info("Migrated applications file",
+			zap.String("file", app.DefaultAppConfigFile),
+			zap.Int("from", apps.From),
+			zap.Int("to", app.FileVersion),
+			zap.String("backup", apps.Backup))
+	}
+	return apps, clusters, nil
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+}
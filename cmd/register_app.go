@@ -1,27 +1,76 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/controller"
 	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
 	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/git"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/lint"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
+	"aeswibon.com/github/gitopsctl/internal/core/terraform"
+	"aeswibon.com/github/gitopsctl/internal/utils"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
 var (
 	// Flags for the register command
-	appName     string // Name of the application
-	repoURL     string // Git repository URL
-	branch      string // Branch in the repository (optional, default is "main")
-	pathInRepo  string // Path to Kubernetes manifests in the repository
-	clusterName string // Name of the Kubernetes cluster
-	interval    string // Polling interval for Git repository
-	dryRunApp   bool   // Preview changes without applying them
-	forceApp    bool   // Force overwrite existing application
+	appName             string            // Name of the application
+	repoURL             string            // Git repository URL
+	branch              string            // Branch in the repository (optional, defaults to the remote's default branch)
+	pathInRepo          string            // Path to Kubernetes manifests in the repository
+	extraPaths          []string          // Additional paths or glob patterns of manifests combined with pathInRepo
+	sourceSpecs         []string          // Additional repositories the application is composed from
+	substitute          bool              // Substitute variables into the rendered manifests
+	appVariables        map[string]string // Variables substituted into the manifests
+	clusterName         string            // Name of the Kubernetes cluster
+	interval            string            // Polling interval for Git repository
+	maxInterval         string            // Longest polling interval of adaptive polling, empty to poll at a fixed interval
+	renderer            string            // Renderer used to produce manifests (yaml, jsonnet, ytt, helm or a plugin)
+	pluginArgs          map[string]string // Parameters passed to a manifest generator plugin
+	helmValuesFiles     []string          // Helm values files, relative to the chart, applied in order
+	helmSets            []string          // Inline Helm values as key=value assignments
+	helmNamespace       string            // Release namespace the Helm chart is rendered with
+	helmRecordRelease   bool              // Record syncs as revisions of a Helm release
+	applyMode           string            // Strategy for handling manifest failures during apply
+	excludes            []string          // Glob patterns of manifest paths to skip
+	approvalRequired    bool              // Hold back detected changes until they are approved
+	configHash          bool              // Roll workloads when the ConfigMaps and Secrets they reference change
+	defaultNamespace    string            // Namespace of namespaced resources whose manifest does not set one
+	requireNamespace    bool              // Fail namespaced resources whose manifest does not set a namespace
+	clusterSelector     map[string]string // Labels of the clusters the application is placed on automatically
+	appLabels           map[string]string // Labels grouping the application for bulk operations
+	impersonateUser     string            // User the resources are applied as, instead of the cluster's
+	impersonateGroups   []string          // Groups the resources are applied as, with impersonateUser
+	linters             []string          // Linters the manifests are checked with before they are applied
+	lintStrict          bool              // Block the apply when a linter reports an error
+	deprecatedAPIs      string            // Whether manifests using deprecated API versions are applied (warn or block)
+	syncMode            string            // How revisions are synced (apply or two-phase)
+	submodules          bool              // Initialize and update the repository's submodules
+	lfs                 bool              // Fetch the repository's Git LFS objects
+	healthTimeout       string            // How long a two-phase sync waits for resources to become healthy
+	appType             string            // Kind of configuration under the path (kubernetes or terraform)
+	terraformBinary     string            // CLI a Terraform configuration is planned with (terraform or tofu)
+	terraformVarFiles   []string          // Terraform variable files, relative to the path, passed in order
+	terraformVars       map[string]string // Terraform input variables
+	dryRunApp           bool              // Preview changes without applying them
+	verifyRepo          bool              // Check the repository, branch and path before registering
+	registerInteractive bool              // Prompt for settings not given as flags
+	forceApp            bool              // Force overwrite existing application
+	registerAppReason   string            // Why the application is registered, recorded in the audit log
+	registerAppSocket   string            // Path of the controller's control socket
+
+	registerAppOutput utils.OutputOptions // Output options for the registered application
 )
 
 // registrationConfig holds validated configuration for app registration
@@ -30,45 +79,157 @@ type registrationConfig struct {
 	repoURL         string
 	branch          string
 	pathInRepo      string
+	extraPaths      []string
+	sources         []app.Source
+	substitute      bool
+	variables       map[string]string
 	clusterName     string
+	placement       *app.Placement
+	labels          map[string]string
+	appType         string
+	terraform       *app.TerraformSource
 	interval        string
+	maxInterval     string
+	renderer        string
+	pluginParams    map[string]string
+	helm            *app.HelmSource
+	applyStrategy   string
+	exclude         []string
+	approval        bool
+	configHash      bool
+	defaultNs       string
+	requireNs       bool
+	impersonate     k8s.Impersonation
+	lint            *app.LintConfig
+	deprecationPol  string
+	submodules      bool
+	lfs             bool
+	syncMode        string
+	healthTimeout   string
 	pollingInterval time.Duration
 }
 
 var registerCmd = &cobra.Command{
-	Use:     "register-apps",
-	GroupID: "appGroup",
-	Short:   "Register a new GitOps application",
+	Use:   "register",
+	Short: "Register a new GitOps application",
 	Long: `Registers a new application to be managed by the GitOps controller.
 
 This command defines where the Kubernetes manifests are located in Git
 and which Kubernetes cluster they should be applied to.
 
 The controller will periodically poll the Git repository and apply any
-changes to the specified Kubernetes cluster.`,
+changes to the specified Kubernetes cluster.
+
+With --type terraform, the path is a Terraform or OpenTofu configuration instead and no
+cluster is needed. Each new commit is planned with the terraform (or tofu) CLI found in the
+controller's PATH, and a plan with changes is only applied once it is approved with
+'gitopsctl app approve'. The configuration's backend and provider credentials come from the
+controller's environment.
+
+With --approval-required, changes to the manifests are not applied right away either: the
+controller diffs them against the cluster and the application waits in AwaitingApproval, with
+the diff shown by 'gitopsctl app describe', until the change is approved with 'gitopsctl app approve'.
+
+With --sync-mode two-phase, each sync waits for the applied Deployments, StatefulSets,
+DaemonSets, Pods, Jobs and PersistentVolumeClaims to become healthy. If they do not within
+--health-timeout, the manifests of the previous healthy revision are applied again and the
+application is marked RolledBack until a new commit is pushed or it is reset.
+
+With --interactive, a wizard prompts for each setting not given as a flag: branches and
+top-level directories are listed from the repository, and clusters from the registered ones.
+Every answer is validated before moving on to the next step.`,
 	Example: `  # Register a simple application
   gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s/prod -c production
 
   # Register with custom branch and interval
   gitopsctl app register -n myapp -r git@github.com:user/repo.git -b develop -p manifests -c staging -i 10m
 
+  # Register an application whose manifests are generated with Jsonnet
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p jsonnet/prod -c production --renderer jsonnet
+
+  # Register a Helm chart with production values, overriding the image tag from the environment
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p charts/myapp -c production --renderer helm \
+    --helm-values values.yaml --helm-values ../../envs/prod/values.yaml --helm-set 'image.tag=${IMAGE_TAG:-latest}'
+
+  # Register a Helm chart whose syncs show up in 'helm list', adopting the release if it was installed with Helm
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p charts/myapp -c production --renderer helm \
+    --helm-namespace myapp --helm-record-release
+
+  # Register an application rendered by a configured plugin
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p tanka -c production --renderer tanka --plugin-param env=prod
+
+  # Register an application that is only applied if every manifest passes a dry run
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s -c prod --apply-strategy atomic
+
+  # Register an application that skips test fixtures and a local-only overlay
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s -c prod --exclude '*_test.yaml' --exclude overlays/local
+
+  # Register an application whose manifests are split between a base and a production overlay
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s/base -c prod --extra-path 'k8s/overlays/prod/*.yaml'
+
+  # Register a Helm chart whose production values live in a separate repository
+  gitopsctl app register -n myapp -r https://github.com/user/charts.git -p myapp -c production --renderer helm \
+    --source name=env,repo=https://github.com/user/env.git,branch=main --helm-values '$env/prod/myapp.yaml'
+
+  # Register an application whose manifests refer to ${CLUSTER_NAME} and ${REGION}
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s -c prod --var REGION=eu-west-1
+
+  # Register a production application whose changes are only applied once approved
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s/prod -c production --approval-required
+
+  # Register an application that is rolled back if its workloads are not healthy within 10 minutes
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s/prod -c production --sync-mode two-phase --health-timeout 10m
+
+  # Register a Terraform configuration whose plans are applied once approved
+  gitopsctl app register -n network -r https://github.com/user/repo.git -p infra/network --type terraform \
+    --terraform-var-file prod.tfvars --terraform-var region=eu-west-1
+
+  # Register an OpenTofu configuration
+  gitopsctl app register -n dns -r https://github.com/user/repo.git -p infra/dns --type terraform --terraform-binary tofu
+
   # Preview registration without saving (dry run)
   gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s -c prod --dry-run
 
   # Force overwrite existing application
-  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s -c prod --force`,
-	Args: cobra.NoArgs,
-	RunE: runRegisterCommand,
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s -c prod --force
+
+  # Register step by step, choosing the branch, path and cluster from lists
+  gitopsctl app register --interactive
+
+  # Check the repository, branch and path before registering
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s -c prod --verify-repo
+
+  # Print the registered application as JSON
+  gitopsctl app register -n myapp -r https://github.com/user/repo.git -p k8s -c prod -o json`,
+	Args:    cobra.NoArgs,
+	PreRunE: registerWizardPreRun,
+	RunE:    runRegisterCommand,
 }
 
 func runRegisterCommand(cobraCmd *cobra.Command, args []string) error {
+	if err := registerAppOutput.Validate(); err != nil {
+		return err
+	}
+
 	config, err := validateAndNormalizeInput()
 	if err != nil {
 		return err
 	}
 
-	if err := verifyClusterExists(config.clusterName); err != nil {
-		return err
+	if config.placement != nil {
+		if err := placeApplication(config); err != nil {
+			return err
+		}
+	} else if config.appType != app.TypeTerraform {
+		if err := verifyClusterExists(config.clusterName); err != nil {
+			return err
+		}
+	}
+
+	if verifyRepo {
+		if err := verifyRepository(config); err != nil {
+			return err
+		}
 	}
 
 	apps, appExists, err := loadAndCheckApplications(config.appName)
@@ -89,14 +250,105 @@ func runRegisterCommand(cobraCmd *cobra.Command, args []string) error {
 	return saveAndConfirmApplication(apps, newApp, appExists)
 }
 
+// detectDefaultBranch returns the branch the repository's HEAD points to, falling back to
+// git.FallbackBranch when the remote cannot be reached. The fallback is reported unless output is
+// machine-readable.
+func detectDefaultBranch(repoURL string, output utils.OutputOptions) string {
+	ctx, cancel := context.WithTimeout(context.Background(), git.DefaultBranchTimeout)
+	defer cancel()
+
+	detected, err := git.DefaultBranch(ctx, repoURL)
+	if err != nil {
+		logger.Warn("Failed to detect the repository's default branch",
+			zap.String("repoURL", repoURL),
+			zap.Error(err))
+		if !output.IsMachineOutput() {
+			fmt.Printf("⚠️  Could not detect the default branch of %s, using '%s'\n", repoURL, git.FallbackBranch)
+		}
+		return git.FallbackBranch
+	}
+	logger.Debug("Detected default branch", zap.String("repoURL", repoURL), zap.String("branch", detected))
+	return detected
+}
+
+// verifyRepository checks that the repository is reachable and that the branch and manifests
+// path exist, so a misconfigured application is rejected before it is registered.
+func verifyRepository(config *registrationConfig) error {
+	if !registerAppOutput.IsMachineOutput() {
+		fmt.Printf("🔍 Verifying %s@%s:%s...\n", config.repoURL, config.branch, config.pathInRepo)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), git.VerifyTimeout)
+	defer cancel()
+
+	if err := git.VerifyRepository(ctx, config.repoURL, config.branch, config.pathInRepo); err != nil {
+		logger.Error("Repository verification failed",
+			zap.String("repoURL", config.repoURL),
+			zap.String("branch", config.branch),
+			zap.String("path", config.pathInRepo),
+			zap.Error(err))
+		return fmt.Errorf("repository verification failed: %w", err)
+	}
+	if !registerAppOutput.IsMachineOutput() {
+		fmt.Printf("✓ Repository, branch and path verified\n")
+	}
+	return nil
+}
+
+// parseSources parses --source values of comma-separated key=value pairs, e.g.
+// "name=values,repo=https://github.com/user/env.git,branch=main,path=prod", into additional sources.
+// Without a branch, the repository's default branch is used.
+func parseSources(specs []string) ([]app.Source, error) {
+	var sources []app.Source
+	for _, spec := range specs {
+		var source app.Source
+		for _, pair := range strings.Split(spec, ",") {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid --source '%s': expected key=value pairs separated by commas", spec)
+			}
+			switch strings.TrimSpace(key) {
+			case "name":
+				source.Name = value
+			case "repo":
+				source.RepoURL = value
+			case "branch":
+				source.Branch = value
+			case "path":
+				source.Path = value
+			default:
+				return nil, fmt.Errorf("invalid --source '%s': unknown key '%s'\nSupported keys: name, repo, branch, path", spec, key)
+			}
+		}
+		if strings.TrimSpace(source.Branch) == "" && strings.TrimSpace(source.RepoURL) != "" {
+			source.Branch = detectDefaultBranch(strings.TrimSpace(source.RepoURL), registerAppOutput)
+		}
+		sources = append(sources, source)
+	}
+	normalized, err := app.NormalizeSources(sources)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --source: %w", err)
+	}
+	return normalized, nil
+}
+
 func validateAndNormalizeInput() (*registrationConfig, error) {
 	config := &registrationConfig{}
 
+	config.appType = strings.ToLower(strings.TrimSpace(appType))
+	if config.appType == app.TypeKubernetes {
+		config.appType = ""
+	}
+	if config.appType != "" && config.appType != app.TypeTerraform {
+		return nil, fmt.Errorf("invalid application type '%s'\nSupported types: %s", appType, strings.Join(app.Types, ", "))
+	}
+
 	requiredFields := map[string]string{
 		"application name": appName,
 		"repository URL":   repoURL,
 		"path":             pathInRepo,
-		"cluster name":     clusterName,
+	}
+	if config.appType != app.TypeTerraform && len(clusterSelector) == 0 {
+		requiredFields["cluster name"] = clusterName
 	}
 
 	var missingFields []string
@@ -113,39 +365,221 @@ func validateAndNormalizeInput() (*registrationConfig, error) {
 	config.appName = strings.TrimSpace(appName)
 	config.repoURL = strings.TrimSpace(repoURL)
 	config.branch = strings.TrimSpace(branch)
-	if config.branch == "" {
-		config.branch = "main"
-	}
 	config.clusterName = strings.TrimSpace(clusterName)
+	if len(clusterSelector) > 0 {
+		if config.clusterName != "" {
+			return nil, fmt.Errorf("--cluster and --cluster-selector cannot be used together")
+		}
+		if err := common.ValidateLabels(clusterSelector); err != nil {
+			return nil, fmt.Errorf("invalid --cluster-selector: %w", err)
+		}
+		config.placement = &app.Placement{ClusterSelector: clusterSelector}
+	}
+	if err := common.ValidateLabels(appLabels); err != nil {
+		return nil, fmt.Errorf("invalid --label: %w", err)
+	}
+	config.labels = appLabels
 	config.interval = strings.TrimSpace(interval)
 	if config.interval == "" {
 		config.interval = "5m"
 	}
 
-	if !common.IsValidGitURL(config.repoURL) {
-		return nil, fmt.Errorf("invalid repository URL format: %s\nMust be a valid HTTPS or SSH Git URL", config.repoURL)
+	if err := common.ValidateGitURL(config.repoURL); err != nil {
+		return nil, err
+	}
+	if config.branch == "" {
+		config.branch = detectDefaultBranch(config.repoURL, registerAppOutput)
+	}
+	if err := common.ValidateBranch(config.branch); err != nil {
+		return nil, err
 	}
 
-	config.pathInRepo = strings.Trim(strings.TrimSpace(pathInRepo), "/")
-	if config.pathInRepo == "" {
-		return nil, fmt.Errorf("path cannot be empty or contain only slashes")
+	config.renderer = strings.ToLower(strings.TrimSpace(renderer))
+	if config.renderer == render.RendererYAML {
+		config.renderer = ""
+	}
+	plugins, err := render.LoadPlugins(render.DefaultPluginConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load manifest generator plugins: %w", err)
+	}
+	if !plugins.IsValidRenderer(config.renderer) {
+		return nil, fmt.Errorf("invalid renderer '%s'\nSupported renderers: %s", renderer,
+			strings.Join(append(append([]string{}, render.Renderers...), plugins.Names()...), ", "))
+	}
+	if len(pluginArgs) > 0 {
+		if _, ok := plugins.Get(config.renderer); !ok {
+			return nil, fmt.Errorf("--plugin-param can only be used when --renderer names a plugin")
+		}
+		config.pluginParams = pluginArgs
+	}
+	if len(helmValuesFiles) > 0 || len(helmSets) > 0 || helmNamespace != "" || helmRecordRelease {
+		if config.renderer != render.RendererHelm {
+			return nil, fmt.Errorf("--helm-values, --helm-set, --helm-namespace and --helm-record-release can only be used with --renderer helm")
+		}
+		if helmNamespace != "" {
+			if err := common.ValidateName(helmNamespace); err != nil {
+				return nil, fmt.Errorf("invalid --helm-namespace: %w", err)
+			}
+		}
+		if err := render.ValidateValuesFiles(helmValuesFiles); err != nil {
+			return nil, err
+		}
+		values, err := render.ParseHelmSet(helmSets)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --helm-set: %w", err)
+		}
+		config.helm = &app.HelmSource{ValuesFiles: helmValuesFiles, Namespace: helmNamespace, RecordRelease: helmRecordRelease}
+		if len(values) > 0 {
+			config.helm.Values = values
+		}
 	}
 
-	if err := common.ValidateName(config.appName); err != nil {
+	config.applyStrategy = strings.ToLower(strings.TrimSpace(applyMode))
+	if config.applyStrategy == k8s.ApplyStrategyBestEffort {
+		config.applyStrategy = ""
+	}
+	if !k8s.IsValidApplyStrategy(config.applyStrategy) {
+		return nil, fmt.Errorf("invalid apply strategy '%s'\nSupported strategies: %s", applyMode, strings.Join(k8s.ApplyStrategies, ", "))
+	}
+
+	if err := k8s.ValidateExcludePatterns(excludes); err != nil {
 		return nil, err
 	}
+	config.exclude = excludes
+	config.approval = approvalRequired
+	config.configHash = configHash
+	config.defaultNs = strings.TrimSpace(defaultNamespace)
+	if config.defaultNs != "" {
+		if err := common.ValidateName(config.defaultNs); err != nil {
+			return nil, fmt.Errorf("invalid --default-namespace: %w", err)
+		}
+		if requireNamespace {
+			return nil, fmt.Errorf("--default-namespace and --require-namespace cannot be used together")
+		}
+	}
+	config.requireNs = requireNamespace
+	if err := k8s.ValidateImpersonation(impersonateUser, impersonateGroups); err != nil {
+		return nil, fmt.Errorf("invalid --impersonate-user or --impersonate-group: %w", err)
+	}
+	config.impersonate = k8s.Impersonation{User: impersonateUser, Groups: impersonateGroups}
+	if len(linters) > 0 {
+		names := make([]string, len(linters))
+		for i, linter := range linters {
+			names[i] = strings.ToLower(strings.TrimSpace(linter))
+		}
+		if err := lint.ValidateLinters(names); err != nil {
+			return nil, fmt.Errorf("invalid --lint: %w", err)
+		}
+		config.lint = &app.LintConfig{Linters: names, Strict: lintStrict}
+	} else if lintStrict {
+		return nil, fmt.Errorf("--lint-strict requires --lint")
+	}
+	config.deprecationPol = strings.ToLower(strings.TrimSpace(deprecatedAPIs))
+	if config.deprecationPol == app.APIDeprecationWarn {
+		config.deprecationPol = ""
+	}
+	if config.deprecationPol != "" && config.deprecationPol != app.APIDeprecationBlock {
+		return nil, fmt.Errorf("invalid --deprecated-apis '%s'\nSupported policies: %s", deprecatedAPIs, strings.Join(app.APIDeprecationPolicies, ", "))
+	}
+	config.submodules, config.lfs = submodules, lfs
 
-	parsedInterval, err := time.ParseDuration(config.interval)
-	if err != nil {
-		return nil, fmt.Errorf("invalid interval format: %w\nExamples: 30s, 5m, 1h", err)
+	config.syncMode = strings.ToLower(strings.TrimSpace(syncMode))
+	if config.syncMode == app.SyncModeApply {
+		config.syncMode = ""
+	}
+	if config.syncMode != "" && config.syncMode != app.SyncModeTwoPhase {
+		return nil, fmt.Errorf("invalid sync mode '%s'\nSupported modes: %s", syncMode, strings.Join(app.SyncModes, ", "))
+	}
+	config.healthTimeout = strings.TrimSpace(healthTimeout)
+	if config.healthTimeout != "" {
+		if config.syncMode != app.SyncModeTwoPhase {
+			return nil, fmt.Errorf("--health-timeout can only be used with --sync-mode %s", app.SyncModeTwoPhase)
+		}
+		if timeout, err := time.ParseDuration(config.healthTimeout); err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid --health-timeout '%s': must be a positive duration such as 5m", healthTimeout)
+		}
+	}
+
+	if config.appType == app.TypeTerraform {
+		if config.clusterName != "" || config.placement != nil || config.renderer != "" || config.pluginParams != nil || config.helm != nil ||
+			config.applyStrategy != "" || len(config.exclude) > 0 || config.configHash || config.defaultNs != "" || config.requireNs || config.lint != nil ||
+			config.deprecationPol != "" || !config.impersonate.IsZero() {
+			return nil, fmt.Errorf("--cluster, --cluster-selector, --renderer, --plugin-param, --helm-*, --apply-strategy, --exclude, --config-hash, --default-namespace, --require-namespace, --lint, --deprecated-apis and --impersonate-* cannot be used with --type terraform")
+		}
+		if config.approval {
+			return nil, fmt.Errorf("--approval-required cannot be used with --type terraform, whose plans always require approval")
+		}
+		if config.syncMode != "" {
+			return nil, fmt.Errorf("--sync-mode cannot be used with --type terraform")
+		}
+		if len(extraPaths) > 0 || len(sourceSpecs) > 0 || substitute || len(appVariables) > 0 {
+			return nil, fmt.Errorf("--extra-path, --source, --substitute and --var cannot be used with --type terraform")
+		}
+		binary := strings.ToLower(strings.TrimSpace(terraformBinary))
+		if binary == terraform.BinaryTerraform {
+			binary = ""
+		}
+		if binary != "" && !slices.Contains(terraform.Binaries, binary) {
+			return nil, fmt.Errorf("invalid --terraform-binary '%s'\nSupported binaries: %s", terraformBinary, strings.Join(terraform.Binaries, ", "))
+		}
+		if err := terraform.ValidateVarFiles(terraformVarFiles); err != nil {
+			return nil, fmt.Errorf("invalid --terraform-var-file: %w", err)
+		}
+		if binary != "" || len(terraformVarFiles) > 0 || len(terraformVars) > 0 {
+			config.terraform = &app.TerraformSource{Binary: binary, VarFiles: terraformVarFiles, Vars: terraformVars}
+		}
+	} else if terraformBinary != terraform.BinaryTerraform || len(terraformVarFiles) > 0 || len(terraformVars) > 0 {
+		return nil, fmt.Errorf("--terraform-binary, --terraform-var-file and --terraform-var can only be used with --type terraform")
+	}
+
+	if config.pathInRepo, err = common.NormalizeRepoPath(pathInRepo); err != nil {
+		return nil, err
+	}
+	if err := render.ValidateVariables(appVariables); err != nil {
+		return nil, fmt.Errorf("invalid --var: %w", err)
+	}
+	config.substitute = substitute
+	if len(appVariables) > 0 {
+		config.variables = appVariables
+	}
+	if len(sourceSpecs) > 0 {
+		if config.sources, err = parseSources(sourceSpecs); err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(config.sources))
+		for _, source := range config.sources {
+			if source.Path != "" && config.renderer != "" {
+				return nil, fmt.Errorf("source '%s' has a path, which can only be used with plain YAML manifests, not --renderer %s", source.Name, config.renderer)
+			}
+			names = append(names, source.Name)
+		}
+		if config.helm != nil {
+			if err := render.ValidateValuesFileSources(config.helm.ValuesFiles, names); err != nil {
+				return nil, fmt.Errorf("invalid --helm-values: %w", err)
+			}
+		}
+	}
+	if len(extraPaths) > 0 {
+		if config.renderer != "" {
+			return nil, fmt.Errorf("--extra-path can only be used with plain YAML manifests, not --renderer %s", config.renderer)
+		}
+		if config.extraPaths, err = render.NormalizeSourcePaths(extraPaths); err != nil {
+			return nil, fmt.Errorf("invalid --extra-path: %w", err)
+		}
+	}
+
+	if err := common.ValidateName(config.appName); err != nil {
+		return nil, fmt.Errorf("invalid application name: %w", err)
 	}
-	if parsedInterval < 10*time.Second {
-		return nil, fmt.Errorf("interval must be at least 10 seconds to avoid excessive polling")
+
+	if config.pollingInterval, err = common.ParsePollingInterval(config.interval); err != nil {
+		return nil, err
 	}
-	if parsedInterval > 24*time.Hour {
-		return nil, fmt.Errorf("interval cannot exceed 24 hours")
+	if config.maxInterval = strings.TrimSpace(maxInterval); config.maxInterval != "" {
+		if _, err := common.ParseMaxPollingInterval(config.maxInterval, config.pollingInterval); err != nil {
+			return nil, fmt.Errorf("invalid --max-interval: %w", err)
+		}
 	}
-	config.pollingInterval = parsedInterval
 
 	return config, nil
 }
@@ -167,6 +601,44 @@ func verifyClusterExists(clusterName string) error {
 	return nil
 }
 
+// placeApplication chooses the cluster of an application with a placement, as the controller does:
+// the healthy cluster matching the selector with the most free capacity.
+func placeApplication(config *registrationConfig) error {
+	clusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configurations: %w", err)
+	}
+	apps, err := app.LoadApplications(app.DefaultAppConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load applications: %w", err)
+	}
+	selector := config.placement.ClusterSelector
+	placed := make(map[string]int)
+	current := ""
+	for _, a := range apps.List() {
+		if a.Name == config.appName {
+			current = a.ClusterName
+		} else if a.ClusterName != "" {
+			placed[a.ClusterName]++
+		}
+	}
+
+	clusters.RLock()
+	defer clusters.RUnlock()
+	// Re-registering keeps the application where it is while that cluster still qualifies
+	if cl, ok := clusters.Get(current); ok && cl.Matches(selector) && cl.Status.Placeable() {
+		config.clusterName = cl.Name
+		return nil
+	}
+	target, err := cluster.Place(clusters.List(), selector, placed)
+	if err != nil {
+		return err
+	}
+	config.clusterName = target.Name
+	logger.Info("Placed application", zap.String("app", config.appName), zap.String("cluster", target.Name))
+	return nil
+}
+
 func loadAndCheckApplications(appName string) (*app.Applications, bool, error) {
 	apps, err := app.LoadApplications(app.DefaultAppConfigFile)
 	if err != nil {
@@ -188,7 +660,9 @@ func handleExistingApp(exists bool, appName string) error {
 
 	if forceApp {
 		logger.Info("Forcing update of existing application", zap.String("name", appName))
-		fmt.Printf("⚠️  Overwriting existing application '%s' (--force flag used)\n", appName)
+		if !registerAppOutput.IsMachineOutput() {
+			fmt.Printf("⚠️  Overwriting existing application '%s' (--force flag used)\n", appName)
+		}
 		return nil
 	}
 
@@ -197,20 +671,50 @@ func handleExistingApp(exists bool, appName string) error {
 
 func createApplication(config *registrationConfig) *app.Application {
 	return &app.Application{
-		Name:                config.appName,
-		RepoURL:             config.repoURL,
-		Branch:              config.branch,
-		Path:                config.pathInRepo,
-		ClusterName:         config.clusterName,
-		Interval:            config.interval,
-		PollingInterval:     config.pollingInterval,
-		Status:              "Pending",
-		Message:             "Application registered, awaiting first sync",
-		ConsecutiveFailures: 0,
+		Name:                 config.appName,
+		Labels:               config.labels,
+		RepoURL:              config.repoURL,
+		Branch:               config.branch,
+		Path:                 config.pathInRepo,
+		Paths:                config.extraPaths,
+		Sources:              config.sources,
+		Substitute:           config.substitute,
+		ConfigHash:           config.configHash,
+		Variables:            config.variables,
+		Submodules:           config.submodules,
+		LFS:                  config.lfs,
+		ClusterName:          config.clusterName,
+		Placement:            config.placement,
+		Type:                 config.appType,
+		Terraform:            config.terraform,
+		Interval:             config.interval,
+		MaxInterval:          config.maxInterval,
+		Renderer:             config.renderer,
+		PluginParams:         config.pluginParams,
+		Helm:                 config.helm,
+		ApplyStrategy:        config.applyStrategy,
+		Exclude:              config.exclude,
+		DefaultNamespace:     config.defaultNs,
+		RequireNamespace:     config.requireNs,
+		ImpersonateUser:      config.impersonate.User,
+		ImpersonateGroups:    config.impersonate.Groups,
+		Lint:                 config.lint,
+		APIDeprecationPolicy: config.deprecationPol,
+		ApprovalRequired:     config.approval,
+		SyncMode:             config.syncMode,
+		HealthTimeout:        config.healthTimeout,
+		PollingInterval:      config.pollingInterval,
+		Status:               app.StatusPending,
+		Message:              "Application registered, awaiting first sync",
+		ConsecutiveFailures:  0,
 	}
 }
 
 func displayDryRunSummary(newApp *app.Application, isUpdate bool) error {
+	if registerAppOutput.IsMachineOutput() {
+		return utils.RenderObject(registerAppOutput, newApp)
+	}
+
 	action := "CREATE"
 	if isUpdate {
 		action = "UPDATE"
@@ -222,9 +726,52 @@ func displayDryRunSummary(newApp *app.Application, isUpdate bool) error {
 	fmt.Printf("  Name:           %s\n", newApp.Name)
 	fmt.Printf("  Repository:     %s\n", newApp.RepoURL)
 	fmt.Printf("  Branch:         %s\n", newApp.Branch)
-	fmt.Printf("  Path:           %s\n", newApp.Path)
-	fmt.Printf("  Cluster:        %s\n", newApp.ClusterName)
-	fmt.Printf("  Poll Interval:  %s\n", newApp.Interval)
+	fmt.Printf("  Path:           %s\n", strings.Join(newApp.SourcePaths(), ", "))
+	if fetch := describeFetchOptions(newApp); fetch != "" {
+		fmt.Printf("  Fetch:          %s\n", fetch)
+	}
+	if len(newApp.Labels) > 0 {
+		fmt.Printf("  Labels:         %s\n", common.FormatLabels(newApp.Labels))
+	}
+	printSources("  ", newApp.Sources)
+	if newApp.IsTerraform() {
+		fmt.Printf("  Type:           %s\n", newApp.Type)
+		fmt.Printf("  Poll Interval:  %s\n", describePollInterval(newApp))
+		printTerraformSource("  ", newApp.Terraform)
+	} else {
+		fmt.Printf("  Cluster:        %s\n", newApp.ClusterName)
+		if newApp.Placement != nil {
+			fmt.Printf("  Placement:      %s\n", describePlacement(newApp.Placement))
+		}
+		fmt.Printf("  Poll Interval:  %s\n", describePollInterval(newApp))
+		fmt.Printf("  Renderer:       %s\n", common.DefaultIfEmpty(newApp.Renderer, render.RendererYAML))
+		printHelmSource("  ", newApp.Helm)
+		fmt.Printf("  Apply Strategy: %s\n", common.DefaultIfEmpty(newApp.ApplyStrategy, k8s.ApplyStrategyBestEffort))
+		if len(newApp.Exclude) > 0 {
+			fmt.Printf("  Exclude:        %s\n", strings.Join(newApp.Exclude, ", "))
+		}
+		if newApp.SubstitutesVariables() {
+			fmt.Printf("  Substitute:     %s\n", describeVariables(newApp.Variables))
+		}
+		if newApp.ConfigHash {
+			fmt.Printf("  Config Hash:    enabled\n")
+		}
+		if namespacing := describeNamespacing(newApp); namespacing != "" {
+			fmt.Printf("  Namespace:      %s\n", namespacing)
+		}
+		if newApp.Lint != nil {
+			fmt.Printf("  Lint:           %s\n", describeLint(newApp.Lint))
+		}
+		if newApp.APIDeprecationPolicy == app.APIDeprecationBlock {
+			fmt.Printf("  Deprecated APIs: blocked\n")
+		}
+		if newApp.ApprovalRequired {
+			fmt.Printf("  Approval:       required\n")
+		}
+		if newApp.IsTwoPhase() {
+			fmt.Printf("  Sync Mode:      %s (health timeout %s)\n", newApp.SyncMode, newApp.HealthTimeoutDuration())
+		}
+	}
 	fmt.Printf("  Status:         %s\n", newApp.Status)
 
 	if isUpdate {
@@ -236,17 +783,46 @@ func displayDryRunSummary(newApp *app.Application, isUpdate bool) error {
 	return nil
 }
 
+// saveAndConfirmApplication registers the application through the controller if it is running, as
+// it would otherwise overwrite the registration with its own state, or else saves it in the
+// configuration.
 func saveAndConfirmApplication(apps *app.Applications, newApp *app.Application, isUpdate bool) error {
-	apps.Lock()
-	defer apps.Unlock()
+	remote, err := controller.NewRemoteClient(logger, registerAppSocket)
+	if err == nil && remote.IsDispatcherRunning() {
+		if err := remote.RegisterApp(newApp); err != nil {
+			logger.Error("Failed to register application with the controller", zap.String("app", newApp.Name), zap.Error(err))
+			return fmt.Errorf("failed to register application: %w", err)
+		}
+	} else {
+		logger.Debug("Controller not reachable, registering application in configuration", zap.Error(err))
+		apps.Lock()
+		apps.Add(newApp)
+		err := app.SaveApplications(apps, app.DefaultAppConfigFile)
+		apps.Unlock()
+		if err != nil {
+			logger.Error("Failed to save application configuration",
+				zap.String("app", newApp.Name),
+				zap.Error(err))
+			return fmt.Errorf("failed to save application configuration: %w", err)
+		}
+	}
+	recordAudit(audit.ActionRegister, event.KindApplication, newApp.Name, registerAppReason, "Repository "+newApp.RepoURL+", path "+newApp.Path)
 
-	apps.Add(newApp)
+	logger.Info("Application registered successfully",
+		zap.String("name", newApp.Name),
+		zap.String("repo", newApp.RepoURL),
+		zap.String("branch", newApp.Branch),
+		zap.String("path", newApp.Path),
+		zap.String("cluster", newApp.ClusterName),
+		zap.String("type", newApp.Type),
+		zap.String("interval", newApp.Interval),
+		zap.String("renderer", newApp.Renderer),
+		zap.String("apply_strategy", newApp.ApplyStrategy),
+		zap.Bool("is_update", isUpdate),
+	)
 
-	if err := app.SaveApplications(apps, app.DefaultAppConfigFile); err != nil {
-		logger.Error("Failed to save application configuration",
-			zap.String("app", newApp.Name),
-			zap.Error(err))
-		return fmt.Errorf("failed to save application configuration: %w", err)
+	if registerAppOutput.IsMachineOutput() {
+		return utils.RenderObject(registerAppOutput, newApp)
 	}
 
 	action := "registered"
@@ -259,53 +835,181 @@ func saveAndConfirmApplication(apps *app.Applications, newApp *app.Application,
 	fmt.Printf("\n%s Application '%s' %s successfully!\n\n", emoji, newApp.Name, action)
 	fmt.Printf("Configuration:\n")
 	fmt.Printf("  Repository:     %s@%s\n", newApp.RepoURL, newApp.Branch)
-	fmt.Printf("  Path:           %s\n", newApp.Path)
-	fmt.Printf("  Target Cluster: %s\n", newApp.ClusterName)
-	fmt.Printf("  Poll Interval:  %s\n", newApp.Interval)
+	fmt.Printf("  Path:           %s\n", strings.Join(newApp.SourcePaths(), ", "))
+	if fetch := describeFetchOptions(newApp); fetch != "" {
+		fmt.Printf("  Fetch:          %s\n", fetch)
+	}
+	if len(newApp.Labels) > 0 {
+		fmt.Printf("  Labels:         %s\n", common.FormatLabels(newApp.Labels))
+	}
+	printSources("  ", newApp.Sources)
+	if newApp.IsTerraform() {
+		fmt.Printf("  Type:           %s\n", newApp.Type)
+		fmt.Printf("  Poll Interval:  %s\n", describePollInterval(newApp))
+		printTerraformSource("  ", newApp.Terraform)
+	} else {
+		fmt.Printf("  Target Cluster: %s\n", newApp.ClusterName)
+		fmt.Printf("  Poll Interval:  %s\n", describePollInterval(newApp))
+		fmt.Printf("  Renderer:       %s\n", common.DefaultIfEmpty(newApp.Renderer, render.RendererYAML))
+		printHelmSource("  ", newApp.Helm)
+		fmt.Printf("  Apply Strategy: %s\n", common.DefaultIfEmpty(newApp.ApplyStrategy, k8s.ApplyStrategyBestEffort))
+		if len(newApp.Exclude) > 0 {
+			fmt.Printf("  Exclude:        %s\n", strings.Join(newApp.Exclude, ", "))
+		}
+		if newApp.SubstitutesVariables() {
+			fmt.Printf("  Substitute:     %s\n", describeVariables(newApp.Variables))
+		}
+		if newApp.ConfigHash {
+			fmt.Printf("  Config Hash:    enabled\n")
+		}
+		if namespacing := describeNamespacing(newApp); namespacing != "" {
+			fmt.Printf("  Namespace:      %s\n", namespacing)
+		}
+		if newApp.Lint != nil {
+			fmt.Printf("  Lint:           %s\n", describeLint(newApp.Lint))
+		}
+		if newApp.APIDeprecationPolicy == app.APIDeprecationBlock {
+			fmt.Printf("  Deprecated APIs: blocked\n")
+		}
+		if newApp.ApprovalRequired {
+			fmt.Printf("  Approval:       required\n")
+		}
+		if newApp.IsTwoPhase() {
+			fmt.Printf("  Sync Mode:      %s (health timeout %s)\n", newApp.SyncMode, newApp.HealthTimeoutDuration())
+		}
+	}
 	fmt.Printf("  Status:         %s\n", newApp.Status)
 
 	fmt.Printf("\nNext steps:\n")
 	fmt.Printf("  • Monitor sync status: gitopsctl app status %s\n", newApp.Name)
 	fmt.Printf("  • View application logs: gitopsctl app logs %s\n", newApp.Name)
 	fmt.Printf("  • Trigger manual sync: gitopsctl app sync %s\n", newApp.Name)
-
-	logger.Info("Application registered successfully",
-		zap.String("name", newApp.Name),
-		zap.String("repo", newApp.RepoURL),
-		zap.String("branch", newApp.Branch),
-		zap.String("path", newApp.Path),
-		zap.String("cluster", newApp.ClusterName),
-		zap.String("interval", newApp.Interval),
-		zap.Bool("is_update", isUpdate),
-	)
+	if newApp.IsTerraform() {
+		fmt.Printf("  • Approve a plan with changes: gitopsctl app approve %s\n", newApp.Name)
+	} else if newApp.ApprovalRequired {
+		fmt.Printf("  • Review and approve a change: gitopsctl app describe %s && gitopsctl app approve %s\n", newApp.Name, newApp.Name)
+	}
 
 	return nil
 }
 
 func init() {
-	rootCmd.AddCommand(registerCmd)
+	appCmd.AddCommand(registerCmd)
 
 	registerCmd.Flags().StringVarP(&appName, "name", "n", "",
 		"Unique name for the application (required)")
 	registerCmd.Flags().StringVarP(&repoURL, "repo", "r", "",
 		"Git repository URL (required)")
 	registerCmd.Flags().StringVarP(&pathInRepo, "path", "p", "",
-		"Path to Kubernetes manifests in the repository (required)")
+		"Path to Kubernetes manifests, or the Terraform configuration, in the repository (required)")
+	registerCmd.Flags().StringSliceVar(&extraPaths, "extra-path", nil,
+		"Additional path or glob pattern of manifests in the repository, applied together with --path (repeatable)")
+	registerCmd.Flags().StringArrayVar(&sourceSpecs, "source", nil,
+		"Additional repository the application is composed from, as name=NAME,repo=URL[,branch=BRANCH][,path=PATH] (repeatable)")
+	registerCmd.Flags().BoolVar(&configHash, "config-hash", false,
+		"Roll Deployments, StatefulSets and DaemonSets when only the ConfigMaps or Secrets they reference change")
+	registerCmd.Flags().BoolVar(&substitute, "substitute", false,
+		"Substitute ${CLUSTER_NAME}, ${APP_NAME} and the cluster's variables into the rendered manifests")
+	registerCmd.Flags().StringToStringVar(&appVariables, "var", nil,
+		"Variable substituted into the rendered manifests as ${KEY}, as KEY=VALUE (repeatable; implies --substitute)")
+	registerCmd.Flags().BoolVar(&submodules, "submodules", false,
+		"Initialize and update the repository's submodules, recursively, on every fetch")
+	registerCmd.Flags().BoolVar(&lfs, "lfs", false,
+		"Fetch the Git LFS objects of the synced commit (requires git and git-lfs in PATH)")
 	registerCmd.Flags().StringVarP(&clusterName, "cluster", "c", "",
-		"Name of the target Kubernetes cluster (required)")
-
-	registerCmd.Flags().StringVarP(&branch, "branch", "b", "main",
-		"Branch in the repository")
+		"Name of the target Kubernetes cluster (required unless --type is terraform or --cluster-selector is given)")
+	registerCmd.Flags().StringToStringVar(&clusterSelector, "cluster-selector", nil,
+		"Place the application automatically on the healthy cluster with these labels and the most free capacity, as KEY=VALUE (repeatable)")
+	registerCmd.Flags().StringToStringVar(&appLabels, "label", nil,
+		"Label grouping the application for bulk commands such as 'app sync --selector', as KEY=VALUE (repeatable)")
+	registerCmd.Flags().StringVar(&appType, "type", app.TypeKubernetes,
+		"Kind of configuration under --path: kubernetes manifests, or a terraform configuration whose plans are applied once approved")
+
+	registerCmd.Flags().StringVarP(&branch, "branch", "b", "",
+		"Branch in the repository (defaults to the remote's default branch)")
 	registerCmd.Flags().StringVarP(&interval, "interval", "i", "5m",
 		"Polling interval (min: 10s, max: 24h)")
+	registerCmd.Flags().StringVar(&maxInterval, "max-interval", "",
+		"Enable adaptive polling: poll less often, up to this interval (e.g., 1h), while the repository has no new commits")
+	registerCmd.Flags().StringVar(&renderer, "renderer", render.RendererYAML,
+		"Manifest renderer: yaml, jsonnet, ytt, helm, or a plugin from "+render.DefaultPluginConfigFile)
+	registerCmd.Flags().StringToStringVar(&pluginArgs, "plugin-param", nil,
+		"Parameter passed to the manifest generator plugin (key=value, repeatable)")
+	registerCmd.Flags().StringArrayVar(&helmValuesFiles, "helm-values", nil,
+		"Helm values file relative to --path, applied in order with later files taking precedence (repeatable)")
+	registerCmd.Flags().StringArrayVar(&helmSets, "helm-set", nil,
+		"Inline Helm value applied after the values files (key.subkey=value, repeatable)")
+	registerCmd.Flags().StringVar(&helmNamespace, "helm-namespace", "",
+		"Release namespace the Helm chart is rendered with and its release is recorded in (default \""+render.DefaultHelmNamespace+"\")")
+	registerCmd.Flags().BoolVar(&helmRecordRelease, "helm-record-release", false,
+		"Record each sync as a revision of a Helm release named after the application, adopting an existing release")
+	registerCmd.Flags().StringVar(&applyMode, "apply-strategy", k8s.ApplyStrategyBestEffort,
+		"How manifest failures are handled: best-effort, fail-fast, or atomic")
+	registerCmd.Flags().StringSliceVar(&excludes, "exclude", nil,
+		"Glob pattern of manifest files or directories to skip, relative to --path (repeatable)")
+	registerCmd.Flags().StringVar(&defaultNamespace, "default-namespace", "",
+		"Namespace namespaced resources whose manifest does not set one are applied to (default \""+k8s.DefaultNamespace+"\")")
+	registerCmd.Flags().BoolVar(&requireNamespace, "require-namespace", false,
+		"Fail namespaced resources whose manifest does not set a namespace instead of applying them to the default namespace")
+	registerCmd.Flags().StringVar(&impersonateUser, "impersonate-user", "",
+		"User the resources are applied as, impersonated with the cluster's credentials (default: the cluster's --impersonate-user)")
+	registerCmd.Flags().StringSliceVar(&impersonateGroups, "impersonate-group", nil,
+		"Group impersonated along with --impersonate-user (repeatable)")
+	registerCmd.Flags().StringSliceVar(&linters, "lint", nil,
+		"Linter the manifests are checked with before they are applied: kubeconform or kube-score (repeatable; must be in the controller's PATH)")
+	registerCmd.Flags().BoolVar(&lintStrict, "lint-strict", false,
+		"Apply nothing when a linter reports an error, instead of only reporting it")
+	registerCmd.Flags().StringVar(&deprecatedAPIs, "deprecated-apis", app.APIDeprecationWarn,
+		"What happens to manifests using API versions deprecated in the cluster's Kubernetes version: warn, or block to apply nothing")
+	registerCmd.Flags().BoolVar(&approvalRequired, "approval-required", false,
+		"Hold back detected changes, with their diff, until they are approved with 'gitopsctl app approve'")
+	registerCmd.Flags().StringVar(&syncMode, "sync-mode", app.SyncModeApply,
+		"How revisions are synced: apply, or two-phase to wait for health and roll back unhealthy revisions")
+	registerCmd.Flags().StringVar(&healthTimeout, "health-timeout", "",
+		"How long a two-phase sync waits for resources to become healthy before rolling back (default 5m)")
+	registerCmd.Flags().StringVar(&terraformBinary, "terraform-binary", terraform.BinaryTerraform,
+		"CLI a Terraform application is planned and applied with: terraform, or tofu for OpenTofu")
+	registerCmd.Flags().StringArrayVar(&terraformVarFiles, "terraform-var-file", nil,
+		"Terraform variable file relative to --path, passed to plan in order (repeatable)")
+	registerCmd.Flags().StringToStringVar(&terraformVars, "terraform-var", nil,
+		"Terraform input variable passed to plan, taking precedence over variable files (name=value, repeatable)")
 
 	registerCmd.Flags().BoolVar(&dryRunApp, "dry-run", false,
 		"Preview the registration without applying changes")
+	registerCmd.Flags().StringVar(&registerAppReason, "reason", "",
+		"Why the application is registered or updated, recorded in the audit log")
+	registerCmd.Flags().StringVar(&registerAppSocket, "control-socket", controller.DefaultControlSocket,
+		"Path of the controller's local control socket")
 	registerCmd.Flags().BoolVar(&forceApp, "force", false,
 		"Force overwrite existing application")
+	registerCmd.Flags().BoolVar(&registerInteractive, "interactive", false,
+		"Prompt step by step for the settings not given as flags")
+	registerCmd.Flags().BoolVar(&verifyRepo, "verify-repo", false,
+		"Check that the repository is reachable and the branch and path exist before registering")
+	utils.AddOutputFlags(registerCmd, &registerAppOutput)
 
 	registerCmd.MarkFlagRequired("name")
 	registerCmd.MarkFlagRequired("repo")
 	registerCmd.MarkFlagRequired("path")
-	registerCmd.MarkFlagRequired("cluster")
+	registerCmd.RegisterFlagCompletionFunc("branch", completeRemoteBranches)
+	registerCmd.RegisterFlagCompletionFunc("path", completeRepoPaths)
+	registerCmd.RegisterFlagCompletionFunc("renderer", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		names := append([]string{}, render.Renderers...)
+		if plugins, err := render.LoadPlugins(render.DefaultPluginConfigFile); err == nil {
+			names = append(names, plugins.Names()...)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+	registerCmd.RegisterFlagCompletionFunc("type", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return app.Types, cobra.ShellCompDirectiveNoFileComp
+	})
+	registerCmd.RegisterFlagCompletionFunc("terraform-binary", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return terraform.Binaries, cobra.ShellCompDirectiveNoFileComp
+	})
+	registerCmd.RegisterFlagCompletionFunc("apply-strategy", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return k8s.ApplyStrategies, cobra.ShellCompDirectiveNoFileComp
+	})
+	registerCmd.RegisterFlagCompletionFunc("sync-mode", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return app.SyncModes, cobra.ShellCompDirectiveNoFileComp
+	})
 }
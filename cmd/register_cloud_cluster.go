@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/core/cloud"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// cloudCLITimeout bounds the cloud CLI commands run to describe a cluster.
+const cloudCLITimeout = 2 * time.Minute
+
+var (
+	cloudClusterName string // Name of the cluster in its cloud, defaulting to --name
+	eksRegion        string // AWS region of the EKS cluster
+	eksProfile       string // AWS CLI profile used for the EKS cluster
+	eksRoleARN       string // IAM role assumed to get EKS tokens
+	gkeLocation      string // Region or zone of the GKE cluster
+	gkeProject       string // Google Cloud project of the GKE cluster
+	gkeInternalIP    bool   // Connect to the private endpoint of the GKE cluster
+	aksResourceGroup string // Resource group of the AKS cluster
+	aksSubscription  string // Azure subscription of the AKS cluster
+	aksAdmin         bool   // Use the admin credentials of the AKS cluster
+)
+
+var registerEKSClusterCmd = &cobra.Command{
+	Use:   "register-eks",
+	Short: "Register an Amazon EKS cluster",
+	Long: `Registers an Amazon EKS cluster, building its kubeconfig with the AWS CLI instead of a
+hand-crafted file.
+
+The cluster's endpoint and certificate authority are read with 'aws eks describe-cluster', and the
+kubeconfig written to ` + cloud.DefaultKubeconfigDir + `/<name>.yaml gets short-lived tokens with
+'aws eks get-token', so the AWS CLI and its credentials must also be available to the controller.`,
+	Example: `  # Register the EKS cluster 'prod' in eu-west-1
+  gitopsctl cluster register-eks -n prod --region eu-west-1
+
+  # Register it under another name, with a named AWS profile and an assumed role
+  gitopsctl cluster register-eks -n eks-prod --cluster prod --region eu-west-1 --profile ops --role-arn arn:aws:iam::123456789012:role/deployer`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return registerCloudCluster(func(ctx context.Context, name string) (*clientcmdapi.Config, error) {
+			return cloud.EKSKubeconfig(ctx, cloud.EKSOptions{Cluster: name, Region: eksRegion, Profile: eksProfile, RoleARN: eksRoleARN})
+		})
+	},
+}
+
+var registerGKEClusterCmd = &cobra.Command{
+	Use:   "register-gke",
+	Short: "Register a Google Kubernetes Engine cluster",
+	Long: `Registers a GKE cluster, building its kubeconfig with the gcloud CLI instead of a hand-crafted
+file.
+
+The cluster's endpoint and certificate authority are read with 'gcloud container clusters describe',
+and the kubeconfig written to ` + cloud.DefaultKubeconfigDir + `/<name>.yaml gets short-lived tokens
+with gke-gcloud-auth-plugin, so the plugin and the gcloud credentials must also be available to the
+controller.`,
+	Example: `  # Register the GKE cluster 'prod' in europe-west1 of project my-project
+  gitopsctl cluster register-gke -n prod --location europe-west1 --project my-project
+
+  # Connect through the private endpoint of the cluster
+  gitopsctl cluster register-gke -n prod --location europe-west1-b --internal-ip`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return registerCloudCluster(func(ctx context.Context, name string) (*clientcmdapi.Config, error) {
+			return cloud.GKEKubeconfig(ctx, cloud.GKEOptions{Cluster: name, Location: gkeLocation, Project: gkeProject, InternalIP: gkeInternalIP})
+		})
+	},
+}
+
+var registerAKSClusterCmd = &cobra.Command{
+	Use:   "register-aks",
+	Short: "Register an Azure Kubernetes Service cluster",
+	Long: `Registers an AKS cluster, building its kubeconfig with the az CLI instead of a hand-crafted file.
+
+The credentials are read with 'az aks get-credentials' and written to
+` + cloud.DefaultKubeconfigDir + `/<name>.yaml. Clusters with Microsoft Entra ID integration
+authenticate with kubelogin using the az CLI's login, so kubelogin and the az credentials must also
+be available to the controller.`,
+	Example: `  # Register the AKS cluster 'prod' in resource group platform
+  gitopsctl cluster register-aks -n prod --resource-group platform
+
+  # Register it with its admin credentials from another subscription
+  gitopsctl cluster register-aks -n prod --resource-group platform --subscription 00000000-0000-0000-0000-000000000000 --admin`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return registerCloudCluster(func(ctx context.Context, name string) (*clientcmdapi.Config, error) {
+			return cloud.AKSKubeconfig(ctx, cloud.AKSOptions{Cluster: name, ResourceGroup: aksResourceGroup, Subscription: aksSubscription, Admin: aksAdmin})
+		})
+	},
+}
+
+// registerCloudCluster registers a cluster whose kubeconfig is built from its cloud by build, which
+// is given the cluster's name in the cloud.
+func registerCloudCluster(build func(ctx context.Context, name string) (*clientcmdapi.Config, error)) error {
+	if err := registerClusterOutput.Validate(); err != nil {
+		return err
+	}
+	config, err := validateClusterSettings()
+	if err != nil {
+		return err
+	}
+	if config.resolvedPath, err = filepath.Abs(cloud.KubeconfigPath(config.name)); err != nil {
+		return fmt.Errorf("failed to resolve kubeconfig path: %w", err)
+	}
+	config.kubeconfigPath = config.resolvedPath
+
+	clusterExists, err := clusterRegistered(config.name)
+	if err != nil {
+		return err
+	}
+	if err := handleExistingCluster(clusterExists, config.name); err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(cloudClusterName)
+	if name == "" {
+		name = config.name
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cloudCLITimeout)
+	defer cancel()
+	logger.Info("Building kubeconfig from the cloud", zap.String("cluster", name))
+	kubeconfig, err := build(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to build kubeconfig: %w", err)
+	}
+
+	newCluster := createClusterConfig(config)
+	if dryRunCluster {
+		return displayDryRunClusterSummary(newCluster, clusterExists)
+	}
+
+	// The kubeconfig is written next to the current one and tested before it replaces it, so a
+	// failed registration leaves a registered cluster's kubeconfig untouched
+	previous, err := os.ReadFile(config.resolvedPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read kubeconfig %s: %w", config.resolvedPath, err)
+	}
+	staged := *config
+	staged.resolvedPath = config.resolvedPath + ".new"
+	if err := cloud.WriteKubeconfig(staged.resolvedPath, kubeconfig); err != nil {
+		return err
+	}
+	if testConnection {
+		if err := testClusterConnectivity(&staged); err != nil {
+			os.Remove(staged.resolvedPath)
+			return fmt.Errorf("cluster connectivity test failed: %w", err)
+		}
+	}
+	if err := os.Rename(staged.resolvedPath, config.resolvedPath); err != nil {
+		os.Remove(staged.resolvedPath)
+		return fmt.Errorf("failed to replace kubeconfig %s: %w", config.resolvedPath, err)
+	}
+	logger.Info("Wrote kubeconfig", zap.String("path", config.resolvedPath))
+
+	if err := saveAndConfirmCluster(newCluster, clusterExists); err != nil {
+		restoreKubeconfig(config.resolvedPath, previous)
+		return err
+	}
+	return nil
+}
+
+// restoreKubeconfig puts back the kubeconfig at path as it was before a failed registration
+// replaced it: previous is its former contents, nil if it did not exist.
+func restoreKubeconfig(path string, previous []byte) {
+	var err error
+	if previous == nil {
+		err = os.Remove(path)
+	} else {
+		err = os.WriteFile(path, previous, 0600)
+	}
+	if err != nil {
+		logger.Warn("Failed to restore kubeconfig", zap.String("path", path), zap.Error(err))
+	}
+}
+
+func init() {
+	clusterCmd.AddCommand(registerEKSClusterCmd, registerGKEClusterCmd, registerAKSClusterCmd)
+
+	for _, cmd := range []*cobra.Command{registerEKSClusterCmd, registerGKEClusterCmd, registerAKSClusterCmd} {
+		cmd.Flags().StringVarP(&clusterRegName, "name", "n", "", "Unique name for the Kubernetes cluster (required)")
+		cmd.Flags().StringVar(&cloudClusterName, "cluster", "", "Name of the cluster in its cloud (defaults to --name)")
+		addClusterSettingsFlags(cmd)
+		cmd.MarkFlagRequired("name")
+	}
+
+	registerEKSClusterCmd.Flags().StringVar(&eksRegion, "region", "", "AWS region of the cluster (required)")
+	registerEKSClusterCmd.Flags().StringVar(&eksProfile, "profile", "", "AWS CLI profile to describe the cluster and get tokens with")
+	registerEKSClusterCmd.Flags().StringVar(&eksRoleARN, "role-arn", "", "IAM role assumed to get tokens")
+	registerEKSClusterCmd.MarkFlagRequired("region")
+
+	registerGKEClusterCmd.Flags().StringVar(&gkeLocation, "location", "", "Region or zone of the cluster (required)")
+	registerGKEClusterCmd.Flags().StringVar(&gkeProject, "project", "", "Google Cloud project of the cluster (defaults to the gcloud configuration's)")
+	registerGKEClusterCmd.Flags().BoolVar(&gkeInternalIP, "internal-ip", false, "Connect to the private endpoint of the cluster")
+	registerGKEClusterCmd.MarkFlagRequired("location")
+
+	registerAKSClusterCmd.Flags().StringVarP(&aksResourceGroup, "resource-group", "g", "", "Resource group of the cluster (required)")
+	registerAKSClusterCmd.Flags().StringVar(&aksSubscription, "subscription", "", "Azure subscription of the cluster (defaults to the az CLI's)")
+	registerAKSClusterCmd.Flags().BoolVar(&aksAdmin, "admin", false, "Use the cluster's admin credentials instead of the user credentials")
+	registerAKSClusterCmd.MarkFlagRequired("resource-group")
+}
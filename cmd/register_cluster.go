@@ -8,7 +8,13 @@ import (
 	"time"
 
 	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
 	clustercore "aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
+	"aeswibon.com/github/gitopsctl/internal/utils"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"k8s.io/client-go/tools/clientcmd"
@@ -16,11 +22,24 @@ import (
 
 var (
 	// Flags for register-cluster command
-	clusterRegName        string // Name of the cluster
-	clusterKubeconfigPath string // Path to kubeconfig file
-	forceCluster          bool   // Force overwrite existing cluster
-	dryRunCluster         bool   // Preview registration without applying
-	testConnection        bool   // Test cluster connectivity during registration
+	clusterRegName           string            // Name of the cluster
+	clusterKubeconfigPath    string            // Path to kubeconfig file
+	clusterInCluster         bool              // Connect with the service account of the controller's pod
+	forceCluster             bool              // Force overwrite existing cluster
+	dryRunCluster            bool              // Preview registration without applying
+	testConnection           bool              // Test cluster connectivity during registration
+	clusterQPS               float32           // Client-side QPS limit for the cluster
+	clusterBurst             int               // Client-side burst limit for the cluster
+	clusterMaxApplies        int               // Maximum number of applications applying to the cluster at once
+	clusterHealthInterval    string            // Health check interval for the cluster
+	clusterVariables         map[string]string // Variables substituted into the cluster's applications' manifests
+	clusterLabels            map[string]string // Labels applications with a placement select the cluster by
+	clusterImpersonate       string            // User the cluster's applications are applied as
+	clusterImpersonateGroups []string          // Groups the cluster's applications are applied as
+	clusterRegReason         string            // Why the cluster is registered, recorded in the audit log
+	clusterRegControlSocket  string            // Path of the controller's control socket
+
+	registerClusterOutput utils.OutputOptions // Output options for the registered cluster
 )
 
 // clusterRegistrationConfig holds validated configuration for cluster registration
@@ -28,12 +47,12 @@ type clusterRegistrationConfig struct {
 	name           string
 	kubeconfigPath string
 	resolvedPath   string
+	inCluster      bool
 }
 
 var registerClusterCmd = &cobra.Command{
-	Use:     "register-cluster",
-	GroupID: "clusterGroup",
-	Short:   "Register a new Kubernetes cluster",
+	Use:   "register",
+	Short: "Register a new Kubernetes cluster",
 	Long: `Registers a new Kubernetes cluster with gitopsctl for GitOps management.
 
 This command validates the kubeconfig file, optionally tests connectivity,
@@ -58,19 +77,40 @@ Examples:
   # Force overwrite existing cluster
   gitopsctl cluster register -n prod -k ~/.kube/config --force
 
+  # Register the cluster the controller runs in, from inside its pod
+  gitopsctl cluster register -n in-cluster --in-cluster
+
   # Auto-detect kubeconfig from environment
-  gitopsctl cluster register -n local`,
+  gitopsctl cluster register -n local
+
+  # Limit the controller to 20 requests per second against the cluster
+  gitopsctl cluster register -n prod -k ~/.kube/config --qps 20 --burst 40
+
+  # Let only 2 applications apply to a small cluster at a time
+  gitopsctl cluster register -n edge -k ~/.kube/edge.yaml --max-concurrent-applies 2
+
+  # Check the cluster's health every minute instead of every 5 minutes
+  gitopsctl cluster register -n prod -k ~/.kube/config --health-check-interval 1m
+
+  # Print the registered cluster as JSON
+  gitopsctl cluster register -n prod -k ~/.kube/config -o json`,
 	RunE: runRegisterClusterCommand,
 }
 
 func runRegisterClusterCommand(cmd *cobra.Command, args []string) error {
+	if err := registerClusterOutput.Validate(); err != nil {
+		return err
+	}
+
 	config, err := validateAndNormalizeClusterInput()
 	if err != nil {
 		return err
 	}
 
-	if err := common.ValidateKubeconfigFile(config.resolvedPath); err != nil {
-		return err
+	if !config.inCluster {
+		if err := common.ValidateKubeconfigFile(config.resolvedPath); err != nil {
+			return err
+		}
 	}
 
 	if testConnection {
@@ -79,7 +119,7 @@ func runRegisterClusterCommand(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	_, clusterExists, err := clustercore.VerifyCluster(config.name)
+	clusterExists, err := clusterRegistered(config.name)
 	if err != nil {
 		return err
 	}
@@ -98,32 +138,26 @@ func runRegisterClusterCommand(cmd *cobra.Command, args []string) error {
 }
 
 func validateAndNormalizeClusterInput() (*clusterRegistrationConfig, error) {
-	config := &clusterRegistrationConfig{}
-
-	if strings.TrimSpace(clusterRegName) == "" {
-		return nil, fmt.Errorf("cluster name is required")
+	config, err := validateClusterSettings()
+	if err != nil {
+		return nil, err
 	}
 
-	config.name = strings.TrimSpace(clusterRegName)
-	if err := common.ValidateName(config.name); err != nil {
-		return nil, err
+	if clusterInCluster {
+		if !k8s.InClusterAvailable() {
+			return nil, fmt.Errorf("--in-cluster can only be used inside a Kubernetes pod with a service account")
+		}
+		config.inCluster = true
+		return config, nil
 	}
 
 	// Handle kubeconfig path
 	if strings.TrimSpace(clusterKubeconfigPath) == "" {
-		if kubeconfigEnv := os.Getenv("KUBECONFIG"); kubeconfigEnv != "" {
-			config.kubeconfigPath = kubeconfigEnv
-		} else if homeDir, err := os.UserHomeDir(); err == nil {
-			defaultPath := filepath.Join(homeDir, ".kube", "config")
-			if _, err := os.Stat(defaultPath); err == nil {
-				config.kubeconfigPath = defaultPath
-				logger.Info("Auto-detected kubeconfig", zap.String("path", defaultPath))
-			} else {
-				return nil, fmt.Errorf("kubeconfig path is required and could not be auto-detected")
-			}
-		} else {
-			return nil, fmt.Errorf("kubeconfig path is required")
+		path, err := defaultKubeconfigPath()
+		if err != nil {
+			return nil, err
 		}
+		config.kubeconfigPath = path
 	} else {
 		config.kubeconfigPath = strings.TrimSpace(clusterKubeconfigPath)
 	}
@@ -136,8 +170,80 @@ func validateAndNormalizeClusterInput() (*clusterRegistrationConfig, error) {
 	return config, nil
 }
 
+// validateClusterSettings validates the cluster name and the settings shared by all the ways of
+// registering a cluster, and returns the configuration without its kubeconfig.
+func validateClusterSettings() (*clusterRegistrationConfig, error) {
+	config := &clusterRegistrationConfig{}
+
+	if strings.TrimSpace(clusterRegName) == "" {
+		return nil, fmt.Errorf("cluster name is required")
+	}
+
+	config.name = strings.TrimSpace(clusterRegName)
+	if err := common.ValidateName(config.name); err != nil {
+		return nil, fmt.Errorf("invalid cluster name: %w", err)
+	}
+
+	if clusterQPS < 0 {
+		return nil, fmt.Errorf("--qps cannot be negative")
+	}
+	if clusterBurst < 0 {
+		return nil, fmt.Errorf("--burst cannot be negative")
+	}
+	if clusterMaxApplies < 0 {
+		return nil, fmt.Errorf("--max-concurrent-applies cannot be negative")
+	}
+	if err := clustercore.ValidateHealthCheckInterval(clusterHealthInterval); err != nil {
+		return nil, err
+	}
+	if err := render.ValidateVariables(clusterVariables); err != nil {
+		return nil, fmt.Errorf("invalid --var: %w", err)
+	}
+	if err := common.ValidateLabels(clusterLabels); err != nil {
+		return nil, fmt.Errorf("invalid --label: %w", err)
+	}
+	if err := k8s.ValidateImpersonation(clusterImpersonate, clusterImpersonateGroups); err != nil {
+		return nil, fmt.Errorf("invalid --impersonate-user or --impersonate-group: %w", err)
+	}
+	return config, nil
+}
+
+// clusterRegistered reports whether a cluster with the given name is already registered.
+func clusterRegistered(name string) (bool, error) {
+	clusters, err := clustercore.LoadClusters(clustercore.DefaultClusterConfigFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load cluster configurations: %w", err)
+	}
+	clusters.RLock()
+	defer clusters.RUnlock()
+	_, exists := clusters.Get(name)
+	return exists, nil
+}
+
+// defaultKubeconfigPath returns the kubeconfig named by $KUBECONFIG, or ~/.kube/config if it exists.
+func defaultKubeconfigPath() (string, error) {
+	if kubeconfigEnv := os.Getenv("KUBECONFIG"); kubeconfigEnv != "" {
+		return kubeconfigEnv, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("kubeconfig path is required")
+	}
+	defaultPath := filepath.Join(homeDir, ".kube", "config")
+	if _, err := os.Stat(defaultPath); err != nil {
+		return "", fmt.Errorf("kubeconfig path is required and could not be auto-detected")
+	}
+	logger.Info("Auto-detected kubeconfig", zap.String("path", defaultPath))
+	return defaultPath, nil
+}
+
 func testClusterConnectivity(config *clusterRegistrationConfig) error {
 	logger.Info("Testing cluster connectivity...", zap.String("cluster", config.name))
+	if config.inCluster {
+		// The in-cluster configuration was already built when validating the input
+		logger.Info("Cluster connectivity test passed", zap.String("cluster", config.name))
+		return nil
+	}
 
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	loadingRules.ExplicitPath = config.resolvedPath
@@ -168,24 +274,37 @@ func handleExistingCluster(exists bool, clusterName string) error {
 }
 
 func createClusterConfig(config *clusterRegistrationConfig) *clustercore.Cluster {
-	status := "Pending"
+	status := clustercore.StatusPending
 	message := "Cluster registered, awaiting validation"
 
 	if testConnection {
-		status = "Active"
+		status = clustercore.StatusActive
 		message = "Cluster registered and connectivity verified"
 	}
 
 	return &clustercore.Cluster{
-		Name:           config.name,
-		KubeconfigPath: config.resolvedPath,
-		RegisteredAt:   time.Now(),
-		Status:         status,
-		Message:        message,
+		Name:                 config.name,
+		KubeconfigPath:       config.resolvedPath,
+		InCluster:            config.inCluster,
+		QPS:                  clusterQPS,
+		Burst:                clusterBurst,
+		MaxConcurrentApplies: clusterMaxApplies,
+		HealthCheckInterval:  clusterHealthInterval,
+		Variables:            clusterVariables,
+		Labels:               clusterLabels,
+		ImpersonateUser:      clusterImpersonate,
+		ImpersonateGroups:    clusterImpersonateGroups,
+		RegisteredAt:         time.Now(),
+		Status:               status,
+		Message:              message,
 	}
 }
 
 func displayDryRunClusterSummary(newCluster *clustercore.Cluster, isUpdate bool) error {
+	if registerClusterOutput.IsMachineOutput() {
+		return utils.RenderObject(registerClusterOutput, newCluster)
+	}
+
 	action := "CREATE"
 	if isUpdate {
 		action = "UPDATE"
@@ -195,7 +314,15 @@ func displayDryRunClusterSummary(newCluster *clustercore.Cluster, isUpdate bool)
 	fmt.Printf("Action: %s cluster\n", action)
 	fmt.Printf("Configuration:\n")
 	fmt.Printf("  Name:        %s\n", newCluster.Name)
-	fmt.Printf("  Kubeconfig:  %s\n", newCluster.KubeconfigPath)
+	fmt.Printf("  Kubeconfig:  %s\n", newCluster.ConfigSource())
+	fmt.Printf("  Rate Limit:  %s\n", clusterRateLimit(newCluster))
+	fmt.Printf("  Health:      every %s\n", newCluster.EffectiveHealthCheckInterval())
+	if len(newCluster.Variables) > 0 {
+		fmt.Printf("  Variables:   %s\n", formatVariables(newCluster.Variables))
+	}
+	if len(newCluster.Labels) > 0 {
+		fmt.Printf("  Labels:      %s\n", common.FormatLabels(newCluster.Labels))
+	}
 	fmt.Printf("  Status:      %s\n", newCluster.Status)
 	fmt.Printf("  Message:     %s\n", newCluster.Message)
 	fmt.Printf("\nTo apply these changes, run the command again without --dry-run\n")
@@ -203,18 +330,40 @@ func displayDryRunClusterSummary(newCluster *clustercore.Cluster, isUpdate bool)
 	return nil
 }
 
+// saveAndConfirmCluster registers the cluster through the controller if it is running, as it would
+// otherwise overwrite the registration with its own state, or else saves it in the configuration.
 func saveAndConfirmCluster(newCluster *clustercore.Cluster, isUpdate bool) error {
-	clusters, err := clustercore.LoadClusters(clustercore.DefaultClusterConfigFile)
-	if err != nil {
-		return fmt.Errorf("failed to load existing clusters: %w", err)
+	remote, err := controller.NewRemoteClient(logger, clusterRegControlSocket)
+	if err == nil && remote.IsDispatcherRunning() {
+		if err := remote.RegisterCluster(newCluster); err != nil {
+			logger.Error("Failed to register cluster with the controller", zap.String("cluster", newCluster.Name), zap.Error(err))
+			return fmt.Errorf("failed to register cluster: %w", err)
+		}
+	} else {
+		logger.Debug("Controller not reachable, registering cluster in configuration", zap.Error(err))
+		clusters, err := clustercore.LoadClusters(clustercore.DefaultClusterConfigFile)
+		if err != nil {
+			return fmt.Errorf("failed to load existing clusters: %w", err)
+		}
+		clusters.Lock()
+		clusters.Add(newCluster)
+		err = clustercore.SaveClusters(clusters, clustercore.DefaultClusterConfigFile)
+		clusters.Unlock()
+		if err != nil {
+			return fmt.Errorf("failed to save cluster configuration: %w", err)
+		}
 	}
+	recordAudit(audit.ActionRegister, event.KindCluster, newCluster.Name, clusterRegReason, "")
 
-	clusters.Lock()
-	defer clusters.Unlock()
+	logger.Info("Cluster registered successfully",
+		zap.String("name", newCluster.Name),
+		zap.String("kubeconfig", newCluster.ConfigSource()),
+		zap.String("status", string(newCluster.Status)),
+		zap.Bool("is_update", isUpdate),
+	)
 
-	clusters.Add(newCluster)
-	if err := clustercore.SaveClusters(clusters, clustercore.DefaultClusterConfigFile); err != nil {
-		return fmt.Errorf("failed to save cluster configuration: %w", err)
+	if registerClusterOutput.IsMachineOutput() {
+		return utils.RenderObject(registerClusterOutput, newCluster)
 	}
 
 	// Success message
@@ -227,7 +376,15 @@ func saveAndConfirmCluster(newCluster *clustercore.Cluster, isUpdate bool) error
 
 	fmt.Printf("\n%s Cluster '%s' %s successfully!\n\n", emoji, newCluster.Name, action)
 	fmt.Printf("Configuration:\n")
-	fmt.Printf("  Kubeconfig: %s\n", newCluster.KubeconfigPath)
+	fmt.Printf("  Kubeconfig: %s\n", newCluster.ConfigSource())
+	fmt.Printf("  Rate Limit: %s\n", clusterRateLimit(newCluster))
+	fmt.Printf("  Health:     every %s\n", newCluster.EffectiveHealthCheckInterval())
+	if len(newCluster.Variables) > 0 {
+		fmt.Printf("  Variables:  %s\n", formatVariables(newCluster.Variables))
+	}
+	if len(newCluster.Labels) > 0 {
+		fmt.Printf("  Labels:     %s\n", common.FormatLabels(newCluster.Labels))
+	}
 	fmt.Printf("  Status:     %s\n", newCluster.Status)
 
 	fmt.Printf("\nNext steps:\n")
@@ -235,29 +392,40 @@ func saveAndConfirmCluster(newCluster *clustercore.Cluster, isUpdate bool) error
 	fmt.Printf("  • List all clusters: gitopsctl cluster list\n")
 	fmt.Printf("  • Register applications: gitopsctl app register --cluster %s\n", newCluster.Name)
 
-	logger.Info("Cluster registered successfully",
-		zap.String("name", newCluster.Name),
-		zap.String("kubeconfig", newCluster.KubeconfigPath),
-		zap.String("status", newCluster.Status),
-		zap.Bool("is_update", isUpdate),
-	)
-
 	return nil
 }
 
 func init() {
-	rootCmd.AddCommand(registerClusterCmd)
+	clusterCmd.AddCommand(registerClusterCmd)
 
 	registerClusterCmd.Flags().StringVarP(&clusterRegName, "name", "n", "", "Unique name for the Kubernetes cluster (required)")
 	registerClusterCmd.Flags().StringVarP(&clusterKubeconfigPath, "kubeconfig", "k", "", "Path to kubeconfig file (auto-detected if not specified)")
 
-	registerClusterCmd.Flags().BoolVar(&forceCluster, "force", false, "Force overwrite existing cluster")
-	registerClusterCmd.Flags().BoolVar(&dryRunCluster, "dry-run", false, "Preview registration without applying changes")
-	registerClusterCmd.Flags().BoolVar(&testConnection, "test", false, "Test cluster connectivity during registration")
+	addClusterSettingsFlags(registerClusterCmd)
 
 	registerClusterCmd.MarkFlagRequired("name")
-	registerClusterCmd.MarkFlagRequired("kubeconfig")
+	registerClusterCmd.Flags().BoolVar(&clusterInCluster, "in-cluster", false, "Connect with the service account of the pod the controller runs in, instead of a kubeconfig")
+	registerClusterCmd.MarkFlagsOneRequired("kubeconfig", "in-cluster")
+	registerClusterCmd.MarkFlagsMutuallyExclusive("kubeconfig", "in-cluster")
 	registerClusterCmd.RegisterFlagCompletionFunc("kubeconfig", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{}, cobra.ShellCompDirectiveFilterFileExt
 	})
 }
+
+// addClusterSettingsFlags adds the flags shared by all the ways of registering a cluster.
+func addClusterSettingsFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&forceCluster, "force", false, "Force overwrite existing cluster")
+	cmd.Flags().StringVar(&clusterRegReason, "reason", "", "Why the cluster is registered or updated, recorded in the audit log")
+	cmd.Flags().BoolVar(&dryRunCluster, "dry-run", false, "Preview registration without applying changes")
+	cmd.Flags().BoolVar(&testConnection, "test", false, "Test cluster connectivity during registration")
+	cmd.Flags().Float32Var(&clusterQPS, "qps", 0, fmt.Sprintf("Maximum queries per second the controller sends to the cluster (default %d)", k8s.DefaultQPS))
+	cmd.Flags().StringVar(&clusterHealthInterval, "health-check-interval", "", fmt.Sprintf("How often the controller checks the cluster's health, e.g. 1m (default %s)", clustercore.DefaultClusterHealthCheckInterval))
+	cmd.Flags().StringToStringVar(&clusterLabels, "label", nil, "Label applications registered with --cluster-selector select the cluster by, as KEY=VALUE (repeatable)")
+	cmd.Flags().StringToStringVar(&clusterVariables, "var", nil, "Variable substituted as ${KEY} into the manifests of applications using --substitute, as KEY=VALUE (repeatable)")
+	cmd.Flags().StringVar(&clusterImpersonate, "impersonate-user", "", "User the resources of the cluster's applications are applied as, impersonated with the kubeconfig's credentials")
+	cmd.Flags().StringSliceVar(&clusterImpersonateGroups, "impersonate-group", nil, "Group impersonated along with --impersonate-user (repeatable)")
+	cmd.Flags().IntVar(&clusterBurst, "burst", 0, fmt.Sprintf("Maximum burst of queries above --qps (default %d)", k8s.DefaultBurst))
+	cmd.Flags().IntVar(&clusterMaxApplies, "max-concurrent-applies", 0, "Maximum number of applications applying manifests to the cluster at the same time (default unlimited)")
+	cmd.Flags().StringVar(&clusterRegControlSocket, "control-socket", controller.DefaultControlSocket, "Path of the controller's local control socket")
+	utils.AddOutputFlags(cmd, &registerClusterOutput)
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/git"
+	"github.com/spf13/cobra"
+)
+
+// wizardAttempts is how many invalid answers the registration wizard accepts for a step before giving up.
+const wizardAttempts = 3
+
+// runRegisterWizard prompts for each registration setting that was not given as a flag, validating
+// every answer before moving on, and sets the corresponding flags from the answers.
+func runRegisterWizard(cmd *cobra.Command) error {
+	if registerAppOutput.IsMachineOutput() {
+		return fmt.Errorf("--interactive cannot be used with --output")
+	}
+	flags := cmd.Flags()
+	fmt.Printf("🧙 Registering a new application. Press Enter to accept the [default].\n\n")
+
+	if !flags.Changed("name") {
+		apps, _, err := loadAndCheckApplications("")
+		if err != nil {
+			return err
+		}
+		name, err := askUntilValid("Application name", "", func(answer string) (string, error) {
+			if err := common.ValidateName(answer); err != nil {
+				return "", fmt.Errorf("invalid application name: %w", err)
+			}
+			apps.RLock()
+			_, exists := apps.Get(answer)
+			apps.RUnlock()
+			if exists && !forceApp {
+				return "", fmt.Errorf("application '%s' already exists; choose a different name", answer)
+			}
+			return answer, nil
+		})
+		if err != nil {
+			return err
+		}
+		flags.Set("name", name)
+	}
+
+	var branches []string
+	if !flags.Changed("repo") {
+		repo, err := askUntilValid("Repository URL (HTTPS or SSH)", "", func(answer string) (string, error) {
+			if err := common.ValidateGitURL(answer); err != nil {
+				return "", err
+			}
+			fmt.Printf("🔍 Listing branches of %s...\n", answer)
+			ctx, cancel := context.WithTimeout(context.Background(), git.VerifyTimeout)
+			defer cancel()
+			var err error
+			branches, err = git.RemoteBranches(ctx, answer)
+			return answer, err
+		})
+		if err != nil {
+			return err
+		}
+		flags.Set("repo", repo)
+	}
+	repo := strings.TrimSpace(repoURL)
+	if branches == nil {
+		// The repository came from --repo; make sure it is reachable before asking about its contents
+		if err := common.ValidateGitURL(repo); err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), git.VerifyTimeout)
+		var err error
+		branches, err = git.RemoteBranches(ctx, repo)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+
+	if !flags.Changed("branch") {
+		ctx, cancel := context.WithTimeout(context.Background(), git.VerifyTimeout)
+		defaultBranch, err := git.DefaultBranch(ctx, repo)
+		cancel()
+		if err != nil {
+			defaultBranch = git.FallbackBranch
+		}
+		selected, err := pickOne("Branch", branches, defaultBranch, func(answer string) error {
+			if len(branches) > 0 && !slices.Contains(branches, answer) {
+				return fmt.Errorf("branch '%s' not found in %s", answer, repo)
+			}
+			return common.ValidateBranch(answer)
+		})
+		if err != nil {
+			return err
+		}
+		flags.Set("branch", selected)
+	}
+
+	if !flags.Changed("path") {
+		ctx, cancel := context.WithTimeout(context.Background(), git.VerifyTimeout)
+		dirs, _ := git.ListDirectories(ctx, repo, branch, "")
+		cancel()
+		selected, err := pickOne("Path to the manifests", dirs, "", func(answer string) error {
+			normalized, err := common.NormalizeRepoPath(answer)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), git.VerifyTimeout)
+			defer cancel()
+			return git.VerifyRepository(ctx, repo, branch, normalized)
+		})
+		if err != nil {
+			return err
+		}
+		flags.Set("path", selected)
+	}
+
+	if !flags.Changed("cluster") && !flags.Changed("cluster-selector") && !strings.EqualFold(strings.TrimSpace(appType), app.TypeTerraform) {
+		clusters := clusterNames()
+		if len(clusters) == 0 {
+			return fmt.Errorf("no clusters are registered\nRegister one first with 'gitopsctl cluster register'")
+		}
+		slices.Sort(clusters)
+		selected, err := pickOne("Cluster", clusters, clusters[0], func(answer string) error {
+			if !slices.Contains(clusters, answer) {
+				return fmt.Errorf("cluster '%s' not found", answer)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		flags.Set("cluster", selected)
+	}
+
+	if !flags.Changed("interval") {
+		selected, err := askUntilValid("Polling interval", interval, func(answer string) (string, error) {
+			_, err := common.ParsePollingInterval(answer)
+			return answer, err
+		})
+		if err != nil {
+			return err
+		}
+		flags.Set("interval", selected)
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// pickOne prompts for one of options, shown as a numbered list, accepting either its number or a
+// value typed in full. A typed value that is not among the options is accepted if validate allows it.
+func pickOne(label string, options []string, def string, validate func(string) error) (string, error) {
+	if len(options) > 0 {
+		fmt.Printf("%s (enter a number or a value):\n", label)
+		for i, option := range options {
+			fmt.Printf("  %d) %s\n", i+1, option)
+		}
+	}
+	return askUntilValid(label, def, func(answer string) (string, error) {
+		if n, err := strconv.Atoi(answer); err == nil && len(options) > 0 {
+			if n < 1 || n > len(options) {
+				return "", fmt.Errorf("choose a number between 1 and %d", len(options))
+			}
+			answer = options[n-1]
+		}
+		if err := validate(answer); err != nil {
+			return "", err
+		}
+		return answer, nil
+	})
+}
+
+// askUntilValid prompts until check accepts an answer, falling back to def when nothing is
+// entered, and gives up after wizardAttempts invalid answers.
+func askUntilValid(label, def string, check func(string) (string, error)) (string, error) {
+	message := label
+	if def != "" {
+		message = fmt.Sprintf("%s [%s]", label, def)
+	}
+	for attempt := 1; ; attempt++ {
+		answer := common.DefaultIfEmpty(common.Prompt(message), def)
+		if answer == "" {
+			fmt.Printf("  ✗ A value is required\n")
+		} else if value, err := check(answer); err != nil {
+			fmt.Printf("  ✗ %s\n", strings.ReplaceAll(err.Error(), "\n", "\n    "))
+		} else {
+			return value, nil
+		}
+		if attempt == wizardAttempts {
+			return "", fmt.Errorf("no valid %s entered after %d attempts", strings.ToLower(label), wizardAttempts)
+		}
+	}
+}
+
+// registerWizardPreRun runs the registration wizard when --interactive is set. It runs before
+// cobra checks required flags, so the flags it sets satisfy them.
+func registerWizardPreRun(cmd *cobra.Command, _ []string) error {
+	if !registerInteractive {
+		return nil
+	}
+	return runRegisterWizard(cmd)
+}
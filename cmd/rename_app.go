@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var renameAppControlSocket string // Path of the controller's control socket
+
+var renameAppCmd = &cobra.Command{
+	Use:   "rename <name> <new-name>",
+	Short: "Rename a GitOps application",
+	Long: `Renames a registered application in place, keeping its configuration, sync history
+and events. Unlike deleting and registering it again, nothing is lost.
+
+If the controller is running, the rename is sent through its local control socket: the
+application's reconciliation loop is stopped, the application is renamed, and the loop
+is started again under the new name. Otherwise the stored configuration is updated directly.
+
+The next sync re-applies the application's manifests so the tracking labels on its
+resources name the new application.`,
+	Example: `  # Rename an application
+  gitopsctl app rename myapp storefront
+
+  # Use a non-default control socket
+  gitopsctl app rename myapp storefront --control-socket /var/run/gitopsctl.sock`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRenameAppCommand,
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return appNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func runRenameAppCommand(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	newName := strings.TrimSpace(args[1])
+	if err := common.ValidateName(newName); err != nil {
+		return fmt.Errorf("invalid application name: %w", err)
+	}
+	if newName == name {
+		return fmt.Errorf("new name must differ from the current name")
+	}
+
+	apps, targetApp, err := loadAndFindApplication(name)
+	if err != nil {
+		return err
+	}
+	if targetApp == nil {
+		return fmt.Errorf("application '%s' not found\nUse 'gitopsctl app list' to see registered applications", name)
+	}
+	apps.RLock()
+	_, taken := apps.Get(newName)
+	apps.RUnlock()
+	if taken {
+		return fmt.Errorf("application '%s' already exists", newName)
+	}
+
+	remote, err := controller.NewRemoteClient(logger, renameAppControlSocket)
+	if err == nil && remote.IsDispatcherRunning() {
+		if err := remote.RenameApp(name, newName); err != nil {
+			logger.Error("Failed to rename application", zap.String("name", name), zap.String("new_name", newName), zap.Error(err))
+			return fmt.Errorf("failed to rename application: %w", err)
+		}
+		logger.Info("Application renamed via CLI", zap.String("name", name), zap.String("new_name", newName))
+		fmt.Printf("✅ Application '%s' renamed to '%s'.\n", name, newName)
+		fmt.Printf("   The controller restarted its reconciliation loop under the new name.\n")
+	} else {
+		logger.Debug("Controller not reachable, renaming application in configuration", zap.Error(err))
+		apps.Lock()
+		renameErr := apps.Rename(name, newName)
+		if renameErr == nil {
+			renameErr = app.SaveApplications(apps, app.DefaultAppConfigFile)
+		}
+		apps.Unlock()
+		if renameErr != nil {
+			logger.Error("Failed to save application configuration", zap.String("app", name), zap.Error(renameErr))
+			return fmt.Errorf("failed to rename application: %w", renameErr)
+		}
+		if err := renameEventsInFile(event.KindApplication, name, newName); err != nil {
+			logger.Warn("Failed to rename application events", zap.String("app", name), zap.Error(err))
+		}
+		logger.Info("Application renamed in configuration", zap.String("name", name), zap.String("new_name", newName))
+		fmt.Printf("✅ Application '%s' renamed to '%s'.\n", name, newName)
+		fmt.Printf("   The controller is not running; the change takes effect when it starts.\n")
+	}
+
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • View application details: gitopsctl app describe %s\n", newName)
+	fmt.Printf("  • Re-apply with new tracking labels now: gitopsctl app sync %s\n", newName)
+	return nil
+}
+
+// renameEventsInFile moves the events recorded for an object to its new name in the event log.
+func renameEventsInFile(kind, oldName, newName string) error {
+	events, err := event.LoadEvents(event.DefaultEventFile)
+	if err != nil {
+		return err
+	}
+	events.Lock()
+	defer events.Unlock()
+	events.Rename(kind, oldName, newName)
+	return event.SaveEvents(events, event.DefaultEventFile)
+}
+
+func init() {
+	appCmd.AddCommand(renameAppCmd)
+
+	renameAppCmd.Flags().StringVar(&renameAppControlSocket, "control-socket", controller.DefaultControlSocket, "Path of the controller's local control socket")
+}
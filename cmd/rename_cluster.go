@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var renameClusterControlSocket string // Path of the controller's control socket
+
+var renameClusterCmd = &cobra.Command{
+	Use:   "rename <name> <new-name>",
+	Short: "Rename a registered cluster",
+	Long: `Renames a registered cluster in place and points every application deployed to it at
+the new name, keeping the cluster's health history and events.
+
+If the controller is running, the rename is sent through its local control socket and the
+reconciliation loops of the affected applications are restarted. Otherwise the stored
+configuration is updated directly.`,
+	Example: `  # Rename a cluster
+  gitopsctl cluster rename prod prod-eu
+
+  # Use a non-default control socket
+  gitopsctl cluster rename prod prod-eu --control-socket /var/run/gitopsctl.sock`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRenameClusterCommand,
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return clusterNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func runRenameClusterCommand(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	newName := strings.TrimSpace(args[1])
+	if err := common.ValidateName(newName); err != nil {
+		return fmt.Errorf("invalid cluster name: %w", err)
+	}
+	if newName == name {
+		return fmt.Errorf("new name must differ from the current name")
+	}
+
+	clusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
+	if err != nil {
+		logger.Error("Failed to load clusters", zap.Error(err))
+		return fmt.Errorf("failed to load clusters: %w", err)
+	}
+	clusters.RLock()
+	_, exists := clusters.Get(name)
+	_, taken := clusters.Get(newName)
+	clusters.RUnlock()
+	if !exists {
+		return fmt.Errorf("cluster '%s' not found\nUse 'gitopsctl cluster list' to see registered clusters", name)
+	}
+	if taken {
+		return fmt.Errorf("cluster '%s' already exists", newName)
+	}
+
+	remote, err := controller.NewRemoteClient(logger, renameClusterControlSocket)
+	if err == nil && remote.IsDispatcherRunning() {
+		if err := remote.RenameCluster(name, newName); err != nil {
+			logger.Error("Failed to rename cluster", zap.String("name", name), zap.String("new_name", newName), zap.Error(err))
+			return fmt.Errorf("failed to rename cluster: %w", err)
+		}
+		logger.Info("Cluster renamed via CLI", zap.String("name", name), zap.String("new_name", newName))
+		fmt.Printf("✅ Cluster '%s' renamed to '%s'.\n", name, newName)
+		fmt.Printf("   The controller restarted the applications deployed to it.\n")
+	} else {
+		logger.Debug("Controller not reachable, renaming cluster in configuration", zap.Error(err))
+		appNames, err := renameClusterInFiles(clusters, name, newName)
+		if err != nil {
+			logger.Error("Failed to rename cluster", zap.String("name", name), zap.Error(err))
+			return fmt.Errorf("failed to rename cluster: %w", err)
+		}
+		if err := renameEventsInFile(event.KindCluster, name, newName); err != nil {
+			logger.Warn("Failed to rename cluster events", zap.String("cluster", name), zap.Error(err))
+		}
+		logger.Info("Cluster renamed in configuration", zap.String("name", name), zap.String("new_name", newName), zap.Strings("apps", appNames))
+		fmt.Printf("✅ Cluster '%s' renamed to '%s'.\n", name, newName)
+		if len(appNames) > 0 {
+			fmt.Printf("   Updated applications: %s\n", strings.Join(appNames, ", "))
+		}
+		fmt.Printf("   The controller is not running; the change takes effect when it starts.\n")
+	}
+
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • View cluster details: gitopsctl cluster describe %s\n", newName)
+	fmt.Printf("  • Check cluster health: gitopsctl cluster status\n")
+	return nil
+}
+
+// renameClusterInFiles renames the cluster and updates the applications deployed to it in the
+// configuration files. It returns the names of the updated applications.
+func renameClusterInFiles(clusters *cluster.Clusters, name, newName string) ([]string, error) {
+	apps, err := app.LoadApplications(app.DefaultAppConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load applications: %w", err)
+	}
+
+	clusters.Lock()
+	defer clusters.Unlock()
+	if err := clusters.Rename(name, newName); err != nil {
+		return nil, err
+	}
+	if err := cluster.SaveClusters(clusters, cluster.DefaultClusterConfigFile); err != nil {
+		return nil, fmt.Errorf("failed to save cluster configuration: %w", err)
+	}
+
+	apps.Lock()
+	defer apps.Unlock()
+	appNames := apps.RenameCluster(name, newName)
+	if len(appNames) > 0 {
+		if err := app.SaveApplications(apps, app.DefaultAppConfigFile); err != nil {
+			return nil, fmt.Errorf("cluster renamed, but failed to save applications: %w", err)
+		}
+	}
+	return appNames, nil
+}
+
+func init() {
+	clusterCmd.AddCommand(renameClusterCmd)
+
+	renameClusterCmd.Flags().StringVar(&renameClusterControlSocket, "control-socket", controller.DefaultControlSocket, "Path of the controller's local control socket")
+}
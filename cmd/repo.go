@@ -0,0 +1,17 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var repoCmd = &cobra.Command{
+	Use:     "repo",
+	GroupID: "appGroup",
+	Short:   "Inspect Git repositories",
+	Long:    `Look up the branches and directories of Git repositories before registering applications from them.`,
+	Example: `  # List the branches and top-level directories of a repository
+  gitopsctl repo browse https://github.com/user/repo.git`,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	rootCmd.AddCommand(repoCmd)
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	resetAppControlSocket string       // Path of the controller's control socket
+	resetAppSelection     appSelection // Applications reset instead of a named one
+)
+
+var resetAppCmd = &cobra.Command{
+	Use:     "reset [name]",
+	Aliases: []string{"resume"},
+	Short:   "Clear an application's consecutive failures and end its backoff",
+	Long: `Clears the consecutive failure count of an application so that it stops backing
+off and resumes polling at its normal interval. Use this after fixing the cause of
+repeated sync failures instead of waiting for the backoff to expire.
+
+Applications that were suspended after too many consecutive failures are only
+reconciled again once they are reset. Resetting a two-phase application that was
+rolled back also lets its next sync retry the revision that was rolled back.
+
+If the controller is running, the reset is sent through its local control socket and
+takes effect immediately. Otherwise the stored failure count is cleared directly.
+
+With --all, --selector or --cluster instead of a name, every matching application is reset, up to
+--parallel at a time, and the result for each is printed followed by a summary. Applications that
+are neither failing nor suspended are skipped.`,
+	Example: `  # Reset the failures of an application
+  gitopsctl app reset myapp
+
+  # Resume every application suspended on a cluster after its maintenance
+  gitopsctl app resume --cluster prod
+
+  # Use a non-default control socket
+  gitopsctl app reset myapp --control-socket /var/run/gitopsctl.sock`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runResetAppCommand,
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return appNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func runResetAppCommand(cmd *cobra.Command, args []string) error {
+	bulk, err := resetAppSelection.isBulk(args)
+	if err != nil {
+		return err
+	}
+	if bulk {
+		return runBulkReset()
+	}
+	name := strings.TrimSpace(args[0])
+
+	apps, targetApp, err := loadAndFindApplication(name)
+	if err != nil {
+		return err
+	}
+	if targetApp == nil {
+		return fmt.Errorf("application '%s' not found\nUse 'gitopsctl app list' to see registered applications", name)
+	}
+	failures := targetApp.ConsecutiveFailures
+
+	remote, err := controller.NewRemoteClient(logger, resetAppControlSocket)
+	if err == nil && remote.IsDispatcherRunning() {
+		remote.ResetFailures(name)
+		logger.Info("Failure reset requested via CLI", zap.String("name", name), zap.Int("failures", failures))
+		fmt.Printf("🔄 Cleared %d consecutive failure(s) for application '%s'.\n", failures, name)
+		fmt.Printf("   The controller will resume polling at its normal interval (%s).\n", targetApp.Interval)
+	} else {
+		logger.Debug("Controller not reachable, resetting failures in configuration", zap.Error(err))
+		if err := resetInConfiguration(apps, targetApp); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Cleared %d consecutive failure(s) for application '%s'.\n", failures, name)
+		fmt.Printf("   The controller is not running; the change takes effect when it starts.\n")
+	}
+
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • Trigger manual sync: gitopsctl app sync %s\n", name)
+	fmt.Printf("  • Monitor sync status: gitopsctl app status --watch\n")
+	return nil
+}
+
+// resetInConfiguration clears an application's failures in the stored configuration and resumes it
+// if it was suspended, for when the controller is not running.
+func resetInConfiguration(apps *app.Applications, targetApp *app.Application) error {
+	apps.Lock()
+	failures := targetApp.ConsecutiveFailures
+	targetApp.ConsecutiveFailures = 0
+	targetApp.UnhealthyGitHash, targetApp.UnhealthyValuesDigest = "", ""
+	if targetApp.Status == app.StatusSuspended {
+		_, _ = targetApp.SetStatus(app.StatusPending, "Reconciliation resumed, awaiting next sync")
+	}
+	saveErr := app.SaveApplications(apps, app.DefaultAppConfigFile)
+	apps.Unlock()
+	if saveErr != nil {
+		logger.Error("Failed to save application configuration", zap.String("app", targetApp.Name), zap.Error(saveErr))
+		return fmt.Errorf("failed to save application configuration: %w", saveErr)
+	}
+	logger.Info("Failure count reset in configuration", zap.String("name", targetApp.Name), zap.Int("failures", failures))
+	return nil
+}
+
+// runBulkReset clears the failures of every selected application that is failing or suspended,
+// through the controller if it is running.
+func runBulkReset() error {
+	apps, selected, err := resetAppSelection.load()
+	if err != nil {
+		return err
+	}
+	remote, err := controller.NewRemoteClient(logger, resetAppControlSocket)
+	if err != nil || !remote.IsDispatcherRunning() {
+		logger.Debug("Controller not reachable, resetting failures in configuration", zap.Error(err))
+		remote = nil
+	}
+
+	err = runBulk(&resetAppSelection, "Resetting", selected, func(a *app.Application) bulkResult {
+		failures := a.ConsecutiveFailures
+		if failures == 0 && a.Status != app.StatusSuspended && a.UnhealthyGitHash == "" {
+			return bulkResult{name: a.Name, skipped: true, message: "no failures to clear"}
+		}
+		message := fmt.Sprintf("cleared %d consecutive failure(s)", failures)
+		if a.Status == app.StatusSuspended {
+			message = "resumed, " + message
+		}
+		if remote != nil {
+			if err := remote.RequestReset(a.Name); err != nil {
+				return bulkResult{name: a.Name, err: fmt.Errorf("failed to request reset: %w", err)}
+			}
+		} else if err := resetInConfiguration(apps, a); err != nil {
+			return bulkResult{name: a.Name, err: err}
+		}
+		return bulkResult{name: a.Name, message: message}
+	})
+	if remote == nil {
+		fmt.Printf("   The controller is not running; the changes take effect when it starts.\n")
+	}
+
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • Trigger manual sync: gitopsctl app sync %s\n", resetAppSelection.flags())
+	fmt.Printf("  • Monitor sync status: gitopsctl app status --watch\n")
+	return err
+}
+
+func init() {
+	appCmd.AddCommand(resetAppCmd)
+
+	resetAppCmd.Flags().StringVar(&resetAppControlSocket, "control-socket", controller.DefaultControlSocket, "Path of the controller's local control socket")
+	addAppSelectionFlags(resetAppCmd, &resetAppSelection, "Reset")
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/progressive"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	rolloutAppName          string        // Rollout or Canary to update, the application's only one if empty
+	rolloutAppFull          bool          // Skip all remaining steps when promoting
+	rolloutAppTimeout       time.Duration // Timeout for updating the rollout in the cluster
+	rolloutAppControlSocket string        // Path of the controller's local control socket
+	rolloutAppReason        string        // Why the rollout is promoted or aborted
+)
+
+var rolloutAppCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Promote or abort canary rollouts of an application",
+	Long: `Controls the Argo Rollouts Rollouts and Flagger Canaries among an application's managed resources.
+Their progress is shown by 'gitopsctl app describe'.`,
+}
+
+var promoteRolloutAppCmd = &cobra.Command{
+	Use:   "promote <name>",
+	Short: "Promote a canary rollout of an application to its next step",
+	Long: `Promotes a canary rollout of an application.
+
+A paused Argo Rollout is resumed, and one that is not paused skips its current step. With --full,
+it skips all remaining steps and analysis. A Flagger Canary skips its analysis, so Flagger promotes
+it with its next check.
+
+Name the rollout with --rollout unless the application manages only one.`,
+	Example: `  # Promote the only rollout of an application to its next step
+  gitopsctl app rollout promote web
+
+  # Promote a specific rollout past all remaining steps
+  gitopsctl app rollout promote web --rollout prod/web --full`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runRolloutAppCommand,
+	ValidArgsFunction: completeRolloutApp,
+}
+
+var abortRolloutAppCmd = &cobra.Command{
+	Use:   "abort <name>",
+	Short: "Abort a canary rollout of an application",
+	Long: `Aborts an Argo Rollout of an application, shifting all traffic back to the stable version.
+The rollout stays aborted until a new revision is pushed.
+
+Flagger Canaries cannot be aborted; Flagger rolls them back itself when their analysis fails.`,
+	Example: `  # Abort the only rollout of an application
+  gitopsctl app rollout abort web`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runRolloutAppCommand,
+	ValidArgsFunction: completeRolloutApp,
+}
+
+func runRolloutAppCommand(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	abort := cmd.Name() == "abort"
+
+	_, targetApp, err := loadAndFindApplication(name)
+	if err != nil {
+		return err
+	}
+	if targetApp == nil {
+		return fmt.Errorf("application '%s' not found\nUse 'gitopsctl app list' to see registered applications", name)
+	}
+	ref, err := progressive.Find(targetApp, strings.TrimSpace(rolloutAppName))
+	if err != nil {
+		return err
+	}
+	if abort && k8s.RolloutProvider(ref.Kind) == k8s.RolloutProviderFlagger {
+		return fmt.Errorf("%s is a Flagger Canary, which cannot be aborted; Flagger rolls it back when its analysis fails", ref)
+	}
+
+	cl, _, err := cluster.VerifyCluster(targetApp.ClusterName)
+	if err != nil {
+		return err
+	}
+	client, err := newAppClientSet(targetApp, cl)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rolloutAppTimeout)
+	defer cancel()
+	if abort {
+		err = client.AbortRollout(ctx, ref)
+	} else {
+		err = client.PromoteRollout(ctx, ref, rolloutAppFull)
+	}
+	if err != nil {
+		return err
+	}
+	logger.Info("Rollout updated via CLI", zap.String("name", name), zap.String("rollout", ref.String()), zap.Bool("abort", abort))
+
+	if abort {
+		recordAudit(audit.ActionRollback, event.KindApplication, name, rolloutAppReason, "Aborted "+ref.String())
+		fmt.Printf("🛑 Aborted %s; traffic is shifted back to the stable version.\n", ref)
+	} else {
+		fmt.Printf("⏩ Promoted %s.\n", ref)
+	}
+
+	// Refresh the progress shown by describe right away if the controller is running
+	if remote, err := controller.NewRemoteClient(logger, rolloutAppControlSocket); err == nil && remote.IsDispatcherRunning() {
+		remote.TriggerSync(name, app.Trigger{Actor: audit.LocalActor(), Reason: rolloutAppReason})
+	}
+
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • Follow the rollout: gitopsctl app describe %s\n", name)
+	return nil
+}
+
+// completeRolloutApp completes the application names of the rollout commands.
+func completeRolloutApp(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return appNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRolloutName completes the --rollout flag with the rollouts of the named application.
+func completeRolloutName(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	apps, err := app.LoadApplications(app.DefaultAppConfigFile)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	apps.RLock()
+	defer apps.RUnlock()
+	a, ok := apps.Get(args[0])
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, ref := range progressive.Rollouts(a) {
+		names = append(names, ref.Namespace+"/"+ref.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	appCmd.AddCommand(rolloutAppCmd)
+	rolloutAppCmd.AddCommand(promoteRolloutAppCmd, abortRolloutAppCmd)
+
+	for _, c := range []*cobra.Command{promoteRolloutAppCmd, abortRolloutAppCmd} {
+		c.Flags().StringVar(&rolloutAppName, "rollout", "", "Rollout or Canary to update as namespace/name or name (default: the application's only one)")
+		c.Flags().DurationVar(&rolloutAppTimeout, "timeout", 30*time.Second, "Timeout for updating the rollout in the cluster")
+		c.Flags().StringVar(&rolloutAppControlSocket, "control-socket", controller.DefaultControlSocket, "Path of the controller's local control socket")
+		c.Flags().StringVar(&rolloutAppReason, "reason", "", "Why the rollout is updated, recorded in the audit log")
+		c.RegisterFlagCompletionFunc("rollout", completeRolloutName)
+	}
+	promoteRolloutAppCmd.Flags().BoolVar(&rolloutAppFull, "full", false, "Skip all remaining steps and analysis of an Argo Rollout")
+}
@@ -3,15 +3,33 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/controller"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 var (
-	cfgFile string
-	logger  *zap.Logger
+	cfgFile    string
+	stateDir   string // Directory the configs/ state directory is in
+	useProfile string // Profile of the configuration file to use instead of the current one
+	launchDir  string // Working directory the command was started in, before entering stateDir
+	logger     *zap.Logger
+	logLevel   zap.AtomicLevel // Level of the global logger, adjustable after it is built
+)
+
+var (
+	// Logging global flags
+	logLevelName  string // Minimum level of logged messages
+	logFormat     string // Log encoding: console, json
+	logFile       string // Path of the file to log to instead of the terminal
+	logMaxSize    int    // Size in megabytes at which the log file is rotated
+	logMaxBackups int    // Number of rotated log files to keep
 )
 
 var (
@@ -27,27 +45,23 @@ var rootCmd = &cobra.Command{
 	Use:   "gitopsctl",
 	Short: "A lightweight GitOps controller for Kubernetes",
 	Long: `gitopsctl is a minimalistic, self-hosted GitOps controller that watches Git repositories
-and applies Kubernetes manifests to target clusters.`,
-	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		// Initialize Zap logger
-		// Create a new production configuration for the logger
-		config := zap.NewProductionConfig()
-		config.OutputPaths = []string{"stdout"}
-		config.ErrorOutputPaths = []string{"stderr"}
-
-		config.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
-		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-		config.EncoderConfig.EncodeCaller = zapcore.ShortCallerEncoder
-		config.EncoderConfig.CallerKey = "caller"
-		config.EncoderConfig.LevelKey = "level"
-		config.EncoderConfig.TimeKey = "ts"
-		config.EncoderConfig.MessageKey = "msg"
-
-		config.Encoding = "console"
-		config.DisableStacktrace = true
+and applies Kubernetes manifests to target clusters.
 
+Every flag can also be set with an environment variable named after it, such as
+GITOPSCTL_API_ADDRESS for --api-address or GITOPSCTL_LOG_LEVEL for --log-level. Flags given on the
+command line win over environment variables, which win over the configuration file.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyEnvironment(cmd); err != nil {
+			return err
+		}
+		if err := loadConfigFile(cmd); err != nil {
+			return err
+		}
+		if err := enterStateDir(); err != nil {
+			return err
+		}
 		var err error
-		logger, err = config.Build() // Use the exported variable
+		logger, err = newLogger(cmd)
 		if err != nil {
 			return fmt.Errorf("failed to initialize logger: %w", err)
 		}
@@ -57,8 +71,74 @@ and applies Kubernetes manifests to target clusters.`,
 	SilenceUsage: true,
 }
 
+// enterStateDir changes to the state directory, if one is set, so that the configs/ files are read
+// and written there. The directory the command was started in is kept in launchDir.
+func enterStateDir() error {
+	if stateDir == "" {
+		return nil
+	}
+	var err error
+	if launchDir, err = os.Getwd(); err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	// Commands reading the configuration file again, such as 'config use-profile', find it from there
+	if cfgFile != "" && !filepath.IsAbs(cfgFile) {
+		cfgFile = filepath.Join(launchDir, cfgFile)
+	}
+	if err := os.Chdir(stateDir); err != nil {
+		return fmt.Errorf("failed to enter state directory: %w", err)
+	}
+	return nil
+}
+
+// newLogger builds the global logger from the logging flags. Logs go to --log-file if set, and
+// otherwise to stdout, or to stderr when cmd writes machine-readable output to stdout.
+func newLogger(cmd *cobra.Command) (*zap.Logger, error) {
+	level, err := zapcore.ParseLevel(logLevelName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level '%s' (use debug, info, warn or error)", logLevelName)
+	}
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	encoderConfig.EncodeCaller = zapcore.ShortCallerEncoder
+	encoderConfig.CallerKey = "caller"
+	encoderConfig.LevelKey = "level"
+	encoderConfig.TimeKey = "ts"
+	encoderConfig.MessageKey = "msg"
+
+	var encoder zapcore.Encoder
+	switch strings.ToLower(logFormat) {
+	case "console":
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	case "json":
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	default:
+		return nil, fmt.Errorf("invalid log format '%s' (use console or json)", logFormat)
+	}
+
+	output := zapcore.Lock(os.Stdout)
+	// Keep stdout clean for scripts consuming machine-readable output
+	if f := cmd.Flags().Lookup("output"); f != nil && f.Value.String() != "" && f.Value.String() != "table" {
+		output = zapcore.Lock(os.Stderr)
+	}
+	if logFile != "" {
+		file, err := common.OpenRotatingFile(logFile, int64(logMaxSize)*1024*1024, logMaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		output = file
+	}
+
+	logLevel = zap.NewAtomicLevelAt(level)
+	core := zapcore.NewSamplerWithOptions(zapcore.NewCore(encoder, output, logLevel), time.Second, 100, 100)
+	return zap.New(core, zap.AddCaller(), zap.ErrorOutput(zapcore.Lock(os.Stderr))), nil
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
+	addLegacyCommands()
 	if err := rootCmd.Execute(); err != nil {
 		if logger != nil {
 			logger.Error("Command execution failed", zap.Error(err))
@@ -82,5 +162,19 @@ func init() {
 	rootCmd.AddGroup(appGroup)
 	rootCmd.AddGroup(clusterGroup)
 	rootCmd.AddCommand(startCmd)
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.gitopsctl.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "Configuration file setting flag defaults (defaults to $"+ConfigFileEnv+", or $HOME/"+DefaultConfigFileName+" if it exists)")
+	rootCmd.PersistentFlags().StringVar(&useProfile, "use-profile", "", "Profile of the configuration file to use instead of the current one (see 'gitopsctl config profiles')")
+	rootCmd.PersistentFlags().StringVar(&stateDir, "state-dir", "", "Directory holding the configs/ state directory, and relative paths given to commands (defaults to the working directory)")
+	rootCmd.PersistentFlags().StringVar(&logLevelName, "log-level", "info", "Minimum level of logged messages: debug, info, warn, error")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "console", "Log format: console, json")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Path of the file to write logs to instead of the terminal, rotated by size (the background controller defaults to "+controller.DefaultLogFile+")")
+	rootCmd.PersistentFlags().IntVar(&logMaxSize, "log-max-size", 100, "Size in megabytes at which --log-file is rotated (0 to never rotate)")
+	rootCmd.PersistentFlags().IntVar(&logMaxBackups, "log-max-backups", 5, "Number of rotated log files to keep")
+
+	rootCmd.RegisterFlagCompletionFunc("log-level", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"debug", "info", "warn", "error"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.RegisterFlagCompletionFunc("log-format", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"console", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
 }
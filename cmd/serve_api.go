@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/api"
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/auth"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	serveAPIAddress       string        // Address for the standalone API server to listen on
+	serveAPIControlSocket string        // Path of the controller's control socket
+	serveAPIRefresh       time.Duration // How often to reload state from disk
+	serveAPIStatusPage    bool          // Serve only the public status page
+	serveAPIAuthConfig    string        // Path of the authentication configuration
+	serveAPITokenFile     string        // Path of the API token store
+)
+
+var serveAPICmd = &cobra.Command{
+	Use:   "serve-api",
+	Short: "Run the API server standalone against an existing controller",
+	Long: `Starts only the REST API server and connects it to a controller started with
+'gitopsctl start --no-api' through the controller's local control socket.
+
+This lets the controller and the API server be run, scaled, and firewalled independently.
+Application and cluster state is read from the configuration files and reloaded periodically.
+The controller is the only writer of those files: the API server sends registrations and other
+changes to the controller, which persists them.
+
+With --status-page-only, the server serves nothing but a read-only status page at /status (and
+/status.json) showing application names, health and last sync times. Repository URLs, paths and
+messages are never shown, and no controller is needed, so it can be exposed to dashboards on team
+screens without authentication.`,
+	Example: `  # Run the controller without the API server
+  gitopsctl start --no-api
+
+  # In another process, serve the API against that controller
+  gitopsctl serve-api --api-address :8080 --control-socket configs/controller.sock
+
+  # Serve a public status page for a team screen
+  gitopsctl serve-api --status-page-only --api-address :8081`,
+	Args: cobra.NoArgs,
+	RunE: runServeAPICommand,
+}
+
+func runServeAPICommand(cmd *cobra.Command, args []string) error {
+	apps, err := app.LoadApplications(app.DefaultAppConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load applications: %w", err)
+	}
+
+	clusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load clusters: %w", err)
+	}
+
+	var apiServer *api.Server
+	if serveAPIStatusPage {
+		apiServer = api.NewStatusServer(logger, apps)
+	} else {
+		authenticator, err := newAuthenticator(serveAPIAuthConfig, serveAPITokenFile)
+		if err != nil {
+			return err
+		}
+
+		remote, err := controller.NewRemoteClient(logger, serveAPIControlSocket)
+		if err != nil {
+			return fmt.Errorf("failed to connect to controller: %w\nStart it with 'gitopsctl start --no-api'", err)
+		}
+		apiServer = api.NewServer(logger, apps, clusters, remote, authenticator, logLevel)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go refreshStateFromDisk(ctx, apps, clusters, serveAPIRefresh)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		if err := apiServer.Start(serveAPIAddress); err != nil && err != http.ErrServerClosed {
+			logger.Fatal("Failed to start API server", zap.Error(err))
+		}
+	}()
+
+	<-sigChan
+	logger.Info("Received shutdown signal. Stopping API server...")
+
+	timeoutCtx, timeoutCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer timeoutCancel()
+	if err := apiServer.Stop(timeoutCtx); err != nil {
+		logger.Error("API server shutdown error", zap.Error(err))
+	}
+
+	logger.Info("API server stopped gracefully.")
+	return nil
+}
+
+// refreshStateFromDisk periodically reloads applications and clusters persisted by the controller
+// so that the standalone API server reports up-to-date status.
+func refreshStateFromDisk(ctx context.Context, apps *app.Applications, clusters *cluster.Clusters, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			loadedApps, err := app.LoadApplications(app.DefaultAppConfigFile)
+			if err != nil {
+				logger.Warn("Failed to reload applications", zap.Error(err))
+				continue
+			}
+			loadedClusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
+			if err != nil {
+				logger.Warn("Failed to reload clusters", zap.Error(err))
+				continue
+			}
+
+			apps.Lock()
+			apps.Merge(loadedApps)
+			apps.Unlock()
+
+			clusters.Lock()
+			clusters.Merge(loadedClusters)
+			clusters.Unlock()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(serveAPICmd)
+
+	serveAPICmd.Flags().StringVarP(&serveAPIAddress, "api-address", "a", ":8080", "Address for the API server to listen on (e.g., :8080, 0.0.0.0:8080)")
+	serveAPICmd.Flags().StringVar(&serveAPIControlSocket, "control-socket", controller.DefaultControlSocket, "Path of the controller's local control socket")
+	serveAPICmd.Flags().DurationVar(&serveAPIRefresh, "refresh-interval", 5*time.Second, "How often to reload application and cluster state from disk")
+	serveAPICmd.Flags().StringVar(&serveAPIAuthConfig, "auth-config", auth.DefaultAuthConfigFile, "Path of the authentication configuration file (OpenID Connect provider and group permissions)")
+	serveAPICmd.Flags().StringVar(&serveAPITokenFile, "token-file", auth.DefaultTokenFile, "Path of the API token store managed with 'gitopsctl auth token'")
+	serveAPICmd.Flags().BoolVar(&serveAPIStatusPage, "status-page-only", false, "Serve only a read-only status page with application names, health and last sync times")
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -11,74 +12,296 @@ import (
 
 	"aeswibon.com/github/gitopsctl/internal/api"
 	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/alert"
 	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/auth"
+	"aeswibon.com/github/gitopsctl/internal/core/backup"
 	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/export"
+	"aeswibon.com/github/gitopsctl/internal/core/git"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
-var apiAddress string
+var (
+	apiAddress    string        // Address for the API server to listen on
+	noAPI         bool          // Run the controller without the API server
+	controlSocket string        // Path of the controller's local control socket
+	alertConfig   string        // Path of the alerting rules configuration
+	exportConfig  string        // Path of the sync and audit event exporter configuration
+	backupConfig  string        // Path of the scheduled backup configuration
+	authConfig    string        // Path of the authentication configuration
+	tokenFile     string        // Path of the API token store
+	pluginConfig  string        // Path of the manifest generator plugin configuration
+	repoCacheDir  string        // Directory for application clones kept across restarts
+	repoCacheSize string        // Size limit of the repository cache
+	drainTimeout  time.Duration // How long to wait for in-flight syncs on shutdown
+	applyTimeout  time.Duration // How long applying manifests to a cluster may take
+	connTimeout   time.Duration // How long connecting to a cluster may take
+	discoveryDir  string        // Directory API discovery results are persisted in
+	instanceID    string        // ID recorded on applied resources to identify this controller
+	pidFile       string        // Path of the file recording the controller's process ID
+	daemon        bool          // Run the controller in the background
+	autoInCluster bool          // Register the cluster the controller runs in when started inside a pod
+)
 
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start the GitOps controller and API server",
 	Long: `Starts the GitOps controller, which continuously watches registered Git repositories and applies manifests to Kubernetes clusters.
-Optionally starts a REST API server for programmatic management.`,
+Optionally starts a REST API server for programmatic management.
+
+The controller's process ID is recorded in --pid-file, which it keeps locked while it runs, and
+only one controller can run per PID file. With --daemon, the controller is started in the background with its output
+written to --log-file (` + controller.DefaultLogFile + ` by default); use 'gitopsctl stop' and 'gitopsctl restart' to manage it.`,
+	Example: `  # Run the controller in the foreground
+  gitopsctl start
+
+  # Run the controller in the background
+  gitopsctl start --daemon
+
+  # Stop the background controller
+  gitopsctl stop`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		apps, err := app.LoadApplications(app.DefaultAppConfigFile)
-		if err != nil {
-			return fmt.Errorf("failed to load applications: %w", err)
+		if daemon {
+			return startDaemon(cmd.Flags(), cmd.Flags())
 		}
+		return runStartCommand(cmd, args)
+	},
+}
 
-		clusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
-		if err != nil {
-			return fmt.Errorf("failed to load clusters: %w", err)
+// runStartCommand runs the controller, and the API server unless disabled, until interrupted.
+func runStartCommand(cmd *cobra.Command, args []string) error {
+	pidLock, err := controller.AcquirePIDFile(pidFile)
+	if err != nil {
+		var running *controller.AlreadyRunningError
+		if errors.As(err, &running) {
+			return fmt.Errorf("%w\nStop it with 'gitopsctl stop'", err)
 		}
+		return err
+	}
+	defer pidLock.Release()
+
+	if _, _, err := migrateStateFiles(); err != nil {
+		return err
+	}
+
+	apps, err := app.LoadApplications(app.DefaultAppConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load applications: %w", err)
+	}
 
-		if len(apps.List()) == 0 {
-			logger.Warn("No applications registered. Please use 'gitopsctl register' to add an application.")
+	clusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load clusters: %w", err)
+	}
+
+	events, err := event.LoadEvents(event.DefaultEventFile)
+	if err != nil {
+		return fmt.Errorf("failed to load events: %w", err)
+	}
+
+	alerting, err := alert.LoadConfig(alertConfig)
+	if err != nil {
+		return fmt.Errorf("failed to load alerting rules: %w", err)
+	}
+	alerting.Webhooks = append(alerting.Webhooks, configNotifications.Webhooks...)
+	alerting.Channels = append(alerting.Channels, configNotifications.Channels...)
+	logger.Info("Loaded alerting rules",
+		zap.Int("rules", len(alerting.Rules)),
+		zap.Int("webhooks", len(alerting.Webhooks)),
+		zap.Int("channels", len(alerting.Channels)))
+
+	exporting, err := export.LoadConfig(exportConfig)
+	if err != nil {
+		return fmt.Errorf("failed to load exporters: %w", err)
+	}
+	exporter, err := export.NewRunner(logger, exporting, export.DefaultStateFile)
+	if err != nil {
+		return err
+	}
+	if len(exporting.Exporters) > 0 {
+		logger.Info("Loaded event exporters", zap.Int("exporters", len(exporting.Exporters)))
+	}
+
+	backups, err := backup.LoadConfig(backupConfig)
+	if err != nil {
+		return fmt.Errorf("failed to load backup config: %w", err)
+	}
+	if backups.Scheduled() {
+		logger.Info("Scheduled backups enabled", zap.String("interval", backups.Interval), zap.String("bucket", backups.S3.Bucket))
+	}
+
+	authenticator, err := newAuthenticator(authConfig, tokenFile)
+	if err != nil {
+		return err
+	}
+
+	plugins, err := render.LoadPlugins(pluginConfig)
+	if err != nil {
+		return fmt.Errorf("failed to load manifest generator plugins: %w", err)
+	}
+
+	var repoCache *git.RepoCache
+	if repoCacheDir != "" {
+		maxSize, err := resource.ParseQuantity(repoCacheSize)
+		if err != nil {
+			return fmt.Errorf("invalid --repo-cache-max-size '%s': %w", repoCacheSize, err)
+		}
+		repoCache, err = git.NewRepoCache(logger, repoCacheDir, maxSize.Value())
+		if err != nil {
+			return err
 		}
+		logger.Info("Using persistent repository cache",
+			zap.String("dir", repoCacheDir),
+			zap.String("maxSize", maxSize.String()))
+	}
+
+	if len(apps.List()) == 0 {
+		logger.Warn("No applications registered. Please use 'gitopsctl app register' to add an application.")
+	}
 
-		if len(clusters.List()) == 0 {
-			logger.Warn("No clusters registered. Please use 'gitopsctl register' to add a cluster.")
+	if autoInCluster && k8s.InClusterAvailable() {
+		if err := registerInCluster(clusters); err != nil {
+			return err
 		}
+	}
 
-		ctrl := controller.NewController(logger, apps, clusters)
-		apiServer := api.NewServer(logger, apps, clusters, ctrl)
+	if len(clusters.List()) == 0 {
+		logger.Warn("No clusters registered. Please use 'gitopsctl cluster register' to add a cluster.")
+	}
 
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	ctrl := controller.NewController(logger, apps, clusters, events, alert.NewEngineFromConfig(logger, alerting), plugins, repoCache)
+	ctrl.SetDrainTimeout(drainTimeout)
+	ctrl.SetTimeouts(applyTimeout, connTimeout)
+	ctrl.SetDiscoveryCacheDir(discoveryDir)
+	ctrl.SetInstanceID(instanceID)
 
-		go func() {
-			if err := ctrl.Start(app.DefaultAppConfigFile); err != nil {
-				logger.Fatal("Failed to start controller", zap.Error(err))
-			}
-		}()
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		if err := ctrl.Start(app.DefaultAppConfigFile); err != nil {
+			logger.Fatal("Failed to start controller", zap.Error(err))
+		}
+	}()
+
+	exportCtx, stopExport := context.WithCancel(context.Background())
+	defer stopExport()
+	go exporter.Run(exportCtx)
+	go backups.Run(exportCtx, logger)
+
+	if controlSocket != "" {
+		if err := ctrl.ServeControlSocket(controlSocket); err != nil {
+			return fmt.Errorf("failed to start control socket: %w", err)
+		}
+	}
 
+	var apiServer *api.Server
+	if noAPI {
+		logger.Info("API server disabled (--no-api). Use 'gitopsctl serve-api' to run it separately.")
+	} else {
+		apiServer = api.NewServer(logger, apps, clusters, ctrl, authenticator, logLevel)
 		go func() {
 			if err := apiServer.Start(apiAddress); err != nil && err != http.ErrServerClosed {
 				logger.Fatal("Failed to start API server", zap.Error(err))
 			}
 		}()
+	}
 
-		// Wait for an interrupt signal
-		<-sigChan
-		logger.Info("Received shutdown signal. Stopping controller...")
+	// Wait for an interrupt signal
+	<-sigChan
+	logger.Info("Received shutdown signal. Stopping controller...")
 
-		timeoutCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
+	if apiServer != nil {
 		if err := apiServer.Stop(timeoutCtx); err != nil {
 			logger.Error("API server shutdown error", zap.Error(err))
 		}
-		ctrl.Stop()
+	}
+	ctrl.Stop()
+	stopExport()
 
-		logger.Info("Controller stopped gracefully.")
+	logger.Info("Controller stopped gracefully.")
+	return nil
+}
+
+// registerInCluster registers the cluster the controller runs in as cluster.InClusterName, using
+// the pod's service account, unless a cluster with that name is already registered.
+func registerInCluster(clusters *cluster.Clusters) error {
+	clusters.Lock()
+	defer clusters.Unlock()
+
+	if _, exists := clusters.Get(cluster.InClusterName); exists {
 		return nil
-	},
+	}
+	clusters.Add(&cluster.Cluster{
+		Name:         cluster.InClusterName,
+		InCluster:    true,
+		RegisteredAt: time.Now(),
+		Status:       cluster.StatusPending,
+		Message:      "Registered automatically from the controller's service account, awaiting validation",
+	})
+	if err := cluster.SaveClusters(clusters, cluster.DefaultClusterConfigFile); err != nil {
+		return fmt.Errorf("failed to save cluster configuration: %w", err)
+	}
+	logger.Info("Registered the cluster the controller runs in", zap.String("name", cluster.InClusterName))
+	return nil
 }
 
-func init() {
-	startCmd.Flags().StringVarP(&apiAddress, "api-address", "a", ":8080", "Address for the API server to listen on (e.g., :8080, 0.0.0.0:8080)")
+// newAuthenticator loads the authentication configuration and API tokens, and returns nil if
+// neither an identity provider nor a token is configured, which disables API authentication.
+func newAuthenticator(configPath, tokenPath string) (*auth.Authenticator, error) {
+	config, err := auth.LoadConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load authentication config: %w", err)
+	}
+	authenticator, err := auth.NewAuthenticator(config, tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load API tokens: %w", err)
+	}
+	if authenticator == nil {
+		logger.Warn("API authentication disabled. Configure an OpenID Connect provider or create an API token with 'gitopsctl auth token create' to require it.")
+		return nil, nil
+	}
+	fields := []zap.Field{zap.Int("groups", len(config.Groups))}
+	if config.OIDC != nil {
+		fields = append(fields, zap.String("issuer", config.OIDC.Issuer), zap.Bool("webLogin", config.OIDC.RedirectURL != ""))
+	}
+	logger.Info("API authentication enabled", fields...)
+	return authenticator, nil
+}
+
+// addStartFlags adds the flags that configure the controller to a command that starts it.
+func addStartFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&apiAddress, "api-address", "a", ":8080", "Address for the API server to listen on (e.g., :8080, 0.0.0.0:8080)")
+	flags.BoolVar(&noAPI, "no-api", false, "Run the controller without the API server")
+	flags.StringVar(&controlSocket, "control-socket", controller.DefaultControlSocket, "Path of the local control socket used by 'serve-api' (empty to disable)")
+	flags.StringVar(&alertConfig, "alert-config", alert.DefaultAlertConfigFile, "Path of the alerting rules configuration file")
+	flags.StringVar(&exportConfig, "export-config", export.DefaultExportConfigFile, "Path of the configuration of the exporters shipping sync and audit events to external systems")
+	flags.StringVar(&backupConfig, "backup-config", backup.DefaultConfigFile, "Path of the configuration of the scheduled backups uploaded to S3")
+	flags.StringVar(&authConfig, "auth-config", auth.DefaultAuthConfigFile, "Path of the authentication configuration file (OpenID Connect provider and group permissions)")
+	flags.StringVar(&tokenFile, "token-file", auth.DefaultTokenFile, "Path of the API token store managed with 'gitopsctl auth token'")
+	flags.StringVar(&pluginConfig, "plugin-config", render.DefaultPluginConfigFile, "Path of the manifest generator plugin configuration file")
+	flags.StringVar(&repoCacheDir, "repo-cache-dir", "", "Directory to keep application clones in across restarts (empty clones into temporary directories)")
+	flags.StringVar(&repoCacheSize, "repo-cache-max-size", "2Gi", "Size limit of the repository cache; least recently used clones are evicted (e.g., 500Mi, 2Gi, 0 for no limit)")
+	flags.StringVar(&discoveryDir, "discovery-cache-dir", k8s.DefaultDiscoveryCacheDir, "Directory to persist Kubernetes API discovery results in, per cluster (empty to cache in memory only)")
+	flags.StringVar(&instanceID, "instance-id", "", "ID recorded on applied resources to identify this controller (defaults to the host name)")
+	flags.DurationVar(&drainTimeout, "drain-timeout", controller.DefaultDrainTimeout, "How long to wait for in-flight syncs to finish on shutdown before interrupting them")
+	flags.DurationVar(&applyTimeout, "apply-timeout", controller.K8sApplyTimeout, "How long applying the manifests of a sync to a cluster may take")
+	flags.DurationVar(&connTimeout, "connect-timeout", controller.K8sConnectTimeout, "How long connecting to a cluster and checking its health may take")
+	flags.StringVar(&pidFile, "pid-file", controller.DefaultPIDFile, "Path of the file recording the controller's process ID")
+	flags.BoolVar(&autoInCluster, "register-in-cluster", true, "When running inside a Kubernetes pod, register that cluster as '"+cluster.InClusterName+"' if it is not registered yet")
+}
 
+func init() {
+	addStartFlags(startCmd.Flags())
+	startCmd.Flags().BoolVarP(&daemon, "daemon", "d", false, "Run the controller in the background, logging to --log-file")
 }
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apistats "aeswibon.com/github/gitopsctl/internal/api/stats"
+	"aeswibon.com/github/gitopsctl/internal/core/stats"
+	"aeswibon.com/github/gitopsctl/internal/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	statsAppName  string // Only show statistics for this application
+	statsWindow   string // Rolling window the statistics are computed over
+	statsOutput   string // Output format
+	statsNoHeader bool   // Hide table headers
+	statsDetails  bool   // Show additional details
+	statsDORA     bool   // Show DORA metrics instead of sync statistics
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show sync statistics for SLO reporting",
+	Long: `Displays sync statistics over a rolling window, per application and over all applications:
+the sync success rate, the mean time from a commit to the sync that deployed it, and failure
+streaks (consecutive failed syncs).
+
+Only syncs that succeeded or failed are counted; syncs held for approval or interrupted are not.
+Statistics are computed from the sync log the controller appends every sync to.
+
+With --dora, the DORA metrics are shown instead: deployment frequency, lead time for changes
+(the median time from a commit to the sync that deployed it) and change failure rate (the
+fraction of new commits with a failed sync, including rollbacks).`,
+	Example: `  # Show the statistics of the last 7 days
+  gitopsctl stats
+
+  # Show the statistics of an application over the last 30 days, with details
+  gitopsctl stats --app myapp --window 30d --details
+
+  # Show the DORA metrics of the last 30 days
+  gitopsctl stats --dora --window 30d
+
+  # Show the statistics as JSON, for an SLO dashboard
+  gitopsctl stats -o json`,
+	Args: cobra.NoArgs,
+	RunE: runStatsCommand,
+}
+
+func runStatsCommand(cmd *cobra.Command, args []string) error {
+	window, err := stats.ParseWindow(statsWindow)
+	if err != nil {
+		return err
+	}
+	format := strings.ToLower(statsOutput)
+	if format != "table" && format != "json" && format != "yaml" {
+		return fmt.Errorf("unsupported output format '%s'\nSupported formats: table, json, yaml", statsOutput)
+	}
+
+	now := time.Now()
+	syncs, err := stats.Load(stats.DefaultSyncLogFile, now.Add(-window))
+	if err != nil {
+		logger.Error("Failed to load sync log", zap.Error(err))
+		return err
+	}
+	if statsAppName != "" {
+		syncs = stats.ForApp(syncs, statsAppName)
+	}
+	report := stats.Compute(syncs, window, now)
+
+	if format != "table" {
+		return utils.RenderObject(utils.OutputOptions{OutputFormat: format}, apistats.ConvertToResponse(report))
+	}
+	if len(report.Apps) == 0 {
+		fmt.Printf("📋 No syncs in the last %s\n", statsWindow)
+		return nil
+	}
+
+	if statsDORA {
+		dora := report.DORA
+		fmt.Printf("Last %s: %.2f deploy(s) per day, lead time %s, change failure rate %s\n\n",
+			statsWindow, dora.DeploymentFrequency, dora.FormatLeadTime(), stats.FormatRate(dora.ChangeFailureRate))
+		items := make([]utils.Renderable, 0, len(report.Apps))
+		for _, a := range report.Apps {
+			items = append(items, a.DORA)
+		}
+		return utils.RenderTable(items, statsNoHeader, statsDetails)
+	}
+
+	total := report.Total
+	fmt.Printf("Last %s: %d sync(s), %s succeeded, mean time to deploy %s, %d application(s) failing\n\n",
+		statsWindow, total.Syncs, stats.FormatRate(total.SuccessRate), stats.FormatMeanTimeToDeploy(total), report.FailingApps)
+	items := make([]utils.Renderable, 0, len(report.Apps))
+	for _, a := range report.Apps {
+		items = append(items, a)
+	}
+	return utils.RenderTable(items, statsNoHeader, statsDetails)
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().StringVar(&statsAppName, "app", "", "Only show statistics for this application")
+	statsCmd.Flags().StringVar(&statsWindow, "window", "7d", "Rolling window the statistics are computed over (e.g., 30d, 12h)")
+	statsCmd.Flags().StringVarP(&statsOutput, "output", "o", "table", "Output format: table, json, yaml")
+	statsCmd.Flags().BoolVar(&statsNoHeader, "no-header", false, "Hide table headers")
+	statsCmd.Flags().BoolVar(&statsDetails, "details", false, "Show additional details")
+	statsCmd.Flags().BoolVar(&statsDORA, "dora", false, "Show DORA metrics: deployment frequency, lead time for changes and change failure rate")
+
+	statsCmd.RegisterFlagCompletionFunc("app", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return appNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+	statsCmd.RegisterFlagCompletionFunc("output", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"table", "json", "yaml"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
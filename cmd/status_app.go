@@ -1,18 +1,21 @@
 package cmd
 
 import (
+	"aeswibon.com/github/gitopsctl/internal/core/app"
 	"aeswibon.com/github/gitopsctl/internal/utils"
 	"github.com/spf13/cobra"
 )
 
-var statusAppOpts utils.ListOptions
+var (
+	statusAppOpts      utils.ListOptions
+	statusAppWatchOpts utils.WatchOptions
+)
 
 var statusAppCmd = &cobra.Command{
-	Use:     "status-apps",
-	GroupID: "appGroup",
-	Args:    cobra.NoArgs,
-	Short:   "Show status of registered GitOps applications",
-	Long:    `Displays the current status, last synced commit, and messages for all registered GitOps applications.`,
+	Use:   "status",
+	Args:  cobra.NoArgs,
+	Short: "Show status of registered GitOps applications",
+	Long:  `Displays the current status, last synced commit, and messages for all registered GitOps applications.`,
 	Example: `
   # Show status of all registered applications
   gitopsctl app status
@@ -22,6 +25,12 @@ var statusAppCmd = &cobra.Command{
 
   # Filter applications by status (synced, error, pending, stopped)
   gitopsctl app status --status synced
+
+  # Triage applications that failed or have not synced yet
+  gitopsctl app status --status error,pending
+
+  # Show the status of applications whose name, repository URL or message mentions timeout
+  gitopsctl app status --search timeout
 	
   # Sort applications by name or status
   gitopsctl app status --sort-by name
@@ -31,30 +40,38 @@ var statusAppCmd = &cobra.Command{
 
   # Compact view without headers
 	gitopsctl app status --no-header
+
+  # Watch application status, refreshing every 5 seconds
+  gitopsctl app status --watch --watch-interval 5s
 	`,
 	RunE: runStatusAppsCommand,
 }
 
 func runStatusAppsCommand(cmd *cobra.Command, args []string) error {
 	statusAppOpts.ShowDetails = true
-	return utils.RunListCommand(
-		logger,
-		statusAppOpts,
-		loadAppsForList,
-		filterAppsForList,
-		sortAppsForList,
-		handleEmptyAppsForList,
-	)
-
+	if err := statusAppWatchOpts.Validate(statusAppOpts.OutputFormat); err != nil {
+		return err
+	}
+	return utils.RunWatch(statusAppWatchOpts, func() error {
+		return utils.RunListCommand(
+			logger,
+			statusAppOpts,
+			loadAppsForList,
+			filterAppsForList,
+			sortAppsForList,
+			handleEmptyAppsForList,
+		)
+	})
 }
 
 func init() {
-	rootCmd.AddCommand(statusAppCmd)
-	utils.AddListFlags(statusAppCmd, &statusAppOpts, "name")
+	appCmd.AddCommand(statusAppCmd)
+	utils.AddListFlags(statusAppCmd, &statusAppOpts, "name", app.Statuses)
+	utils.AddWatchFlags(statusAppCmd, &statusAppWatchOpts)
 
 	statusAppCmd.Flags().Lookup("details").Hidden = true
-	statusAppCmd.Flags().Lookup("output").Usage = "Output format: table, json, yaml (default: table)"
-	statusAppCmd.Flags().Lookup("status").Usage = "Filter by status: all, synced, error, pending, stopped"
+	statusAppCmd.Flags().Lookup("output").Usage = "Output format: table, wide, json, yaml, csv (default: table)"
+	statusAppCmd.Flags().Lookup("status").Usage = "Filter by status: all, synced, error, pending, stopped; comma-separate several, prefix with '!' to exclude"
 	statusAppCmd.Flags().Lookup("sort-by").Usage = "Sort by: name, status, branch"
 
 	statusAppCmd.RegisterFlagCompletionFunc("status", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -5,18 +5,21 @@ import (
 	"os"
 	"text/tabwriter"
 
+	"aeswibon.com/github/gitopsctl/internal/common"
 	"aeswibon.com/github/gitopsctl/internal/core/cluster"
 	"aeswibon.com/github/gitopsctl/internal/utils"
 	"github.com/spf13/cobra"
 )
 
-var statusClusterOpts utils.ListOptions
+var (
+	statusClusterOpts      utils.ListOptions
+	statusClusterWatchOpts utils.WatchOptions
+)
 
 var statusClusterCmd = &cobra.Command{
-	Use:     "status-clusters",
-	GroupID: "clusterGroup",
-	Short:   "Show status of registered Kubernetes clusters",
-	Long:    `Displays the current health status, last checked time, and messages for all registered Kubernetes clusters.`,
+	Use:   "status",
+	Short: "Show status of registered Kubernetes clusters",
+	Long:  `Displays the current health status, last checked time, and messages for all registered Kubernetes clusters.`,
 	Example: `
   # Show status of all registered clusters
   gitopsctl cluster status
@@ -24,8 +27,14 @@ var statusClusterCmd = &cobra.Command{
   # Show status of clusters with details
   gitopsctl cluster status --details
  
-  # Filter clusters by status (healthy, error, pending)
-  gitopsctl cluster status --status healthy
+  # Filter clusters by status (e.g., active, degraded, unreachable)
+  gitopsctl cluster status --status unreachable
+
+  # Show every cluster that is not active
+  gitopsctl cluster status --status '!active'
+
+  # Show the status of clusters whose name, kubeconfig path or message mentions certificate
+  gitopsctl cluster status --search certificate
 
 	# Sort clusters by name or status
 	gitopsctl cluster status --sort-by name
@@ -35,52 +44,73 @@ var statusClusterCmd = &cobra.Command{
 
  	# Compact view without headers
 	gitopsctl cluster status --no-header
+
+	# Watch cluster health, refreshing every 2 seconds
+	gitopsctl cluster status --watch
 `,
 	RunE: func(cmdCobra *cobra.Command, args []string) error {
-		clusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
-		if err != nil {
-			return fmt.Errorf("failed to load cluster configurations: %w", err)
+		if err := statusClusterWatchOpts.Validate(statusClusterOpts.OutputFormat); err != nil {
+			return err
 		}
+		return utils.RunWatch(statusClusterWatchOpts, renderClusterStatus)
+	},
+}
 
-		if len(clusters.List()) == 0 {
-			logger.Info("No clusters registered. Use 'gitopsctl cluster register' to add one.")
-			return nil
-		}
+// renderClusterStatus loads the registered clusters and prints the health status table of those
+// matching the status filter and search.
+func renderClusterStatus() error {
+	filter, err := common.ParseStatusFilter(statusClusterOpts.StatusFilter, cluster.Statuses)
+	if err != nil {
+		return err
+	}
+	clusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configurations: %w", err)
+	}
+
+	if len(clusters.List()) == 0 {
+		logger.Info("No clusters registered. Use 'gitopsctl cluster register' to add one.")
+		return nil
+	}
 
-		fmt.Println("--- Kubernetes Cluster Health Status ---")
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
-
-		fmt.Fprintln(w, "NAME\tSTATUS\tMESSAGE\tLAST CHECKED\tKUBECONFIG PATH")
-		fmt.Fprintln(w, "----\t------\t-------\t------------\t---------------")
-
-		for _, cl := range clusters.List() {
-			lastChecked := "N/A"
-			if !cl.LastCheckedAt.IsZero() {
-				lastChecked = cl.LastCheckedAt.Format("2006-01-02 15:04:05 MST")
-			}
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-				cl.Name,
-				cl.Status,
-				cl.Message,
-				lastChecked,
-				cl.KubeconfigPath,
-			)
-		}
-		w.Flush()
-		fmt.Println("--------------------------------------")
+	fmt.Println("--- Kubernetes Cluster Health Status ---")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
 
-		return nil
-	},
+	fmt.Fprintln(w, "NAME\tSTATUS\tMESSAGE\tLAST CHECKED\tKUBECONFIG PATH")
+	fmt.Fprintln(w, "----\t------\t-------\t------------\t---------------")
+
+	for _, cl := range clusters.List() {
+		if !filter.Matches(string(cl.Status)) || !cl.MatchesSearch(statusClusterOpts.Search) {
+			continue
+		}
+		lastChecked := "N/A"
+		if !cl.LastCheckedAt.IsZero() {
+			lastChecked = cl.LastCheckedAt.Format("2006-01-02 15:04:05 MST")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			cl.Name,
+			cl.Status,
+			cl.Message,
+			lastChecked,
+			cl.ConfigSource(),
+		)
+	}
+	w.Flush()
+	fmt.Println("--------------------------------------")
+
+	return nil
 }
 
 func init() {
-	rootCmd.AddCommand(statusClusterCmd)
-	utils.AddListFlags(statusClusterCmd, &statusClusterOpts, "name")
+	clusterCmd.AddCommand(statusClusterCmd)
+	utils.AddListFlags(statusClusterCmd, &statusClusterOpts, "name", cluster.Statuses)
+	utils.AddWatchFlags(statusClusterCmd, &statusClusterWatchOpts)
 
 	statusClusterCmd.Flags().Lookup("details").Hidden = true
 
-	statusClusterCmd.Flags().Lookup("output").Usage = "Output format: table, json, yaml (default: table)"
-	statusClusterCmd.Flags().Lookup("status").Usage = "Filter by status: all, active, unreachable, error, pending"
+	statusClusterCmd.Flags().Lookup("output").Usage = "Output format: table, wide, json, yaml, csv (default: table)"
+	statusClusterCmd.Flags().Lookup("status").Usage = "Filter by status: all, active, unreachable, error, pending; comma-separate several, prefix with '!' to exclude"
+	statusClusterCmd.Flags().Lookup("search").Usage = "Only list clusters whose name, kubeconfig path or message contains this text, ignoring case"
 	statusClusterCmd.Flags().Lookup("sort-by").Usage = "Sort by: name, status, registered"
 
 	statusClusterCmd.RegisterFlagCompletionFunc("status", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	stopTimeout time.Duration // How long to wait for the controller to exit
+	stopForce   bool          // Kill the controller if it does not exit in time
+)
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running controller",
+	Long: `Stops the controller recorded in the PID file, whether it was started in the background
+with 'gitopsctl start --daemon' or in the foreground.
+
+The controller is asked to shut down gracefully and given --timeout to finish in-flight syncs.
+With --force, it is killed if it is still running after the timeout.`,
+	Example: `  # Stop the controller
+  gitopsctl stop
+
+  # Stop the controller, killing it if it does not exit within a minute
+  gitopsctl stop --timeout 1m --force`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stopped, err := stopController()
+		if err != nil {
+			return err
+		}
+		if !stopped {
+			fmt.Printf("The controller is not running (no controller holds %s).\n", pidFile)
+		}
+		return nil
+	},
+}
+
+var restartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Restart the controller in the background",
+	Long: `Stops the controller recorded in the PID file, if it is running, and starts it again in the
+background as 'gitopsctl start --daemon' would.
+
+The controller is started with the flags given to restart, which are the same as those of
+start; flags given when it was previously started are not remembered.`,
+	Example: `  # Restart the controller
+  gitopsctl restart
+
+  # Restart the controller without the API server
+  gitopsctl restart --no-api`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := stopController(); err != nil {
+			return err
+		}
+		return startDaemon(cmd.Flags(), startCmd.Flags())
+	},
+}
+
+// stopController stops the controller recorded in the PID file, reporting whether one was running.
+func stopController() (bool, error) {
+	pid, err := controller.RunningPID(pidFile)
+	if errors.Is(err, controller.ErrNotRunning) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Printf("Stopping the controller (PID %d)...\n", pid)
+	if err := controller.StopProcess(pidFile, pid, stopTimeout, stopForce); err != nil {
+		logger.Error("Failed to stop the controller", zap.Int("pid", pid), zap.Error(err))
+		if !stopForce {
+			return true, fmt.Errorf("%w\nUse --force to kill it, or a longer --timeout", err)
+		}
+		return true, err
+	}
+
+	logger.Info("Controller stopped", zap.Int("pid", pid))
+	fmt.Printf("✓ Controller stopped\n")
+	return true, nil
+}
+
+func init() {
+	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(restartCmd)
+
+	for _, cmd := range []*cobra.Command{stopCmd, restartCmd} {
+		cmd.Flags().DurationVar(&stopTimeout, "timeout", controller.DefaultStopTimeout, "How long to wait for the controller to shut down")
+		cmd.Flags().BoolVar(&stopForce, "force", false, "Kill the controller if it has not shut down within --timeout")
+	}
+	stopCmd.Flags().StringVar(&pidFile, "pid-file", controller.DefaultPIDFile, "Path of the file recording the controller's process ID")
+	addStartFlags(restartCmd.Flags())
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	suspendAppControlSocket string // Path of the controller's control socket
+	suspendAppReason        string // Why the application is suspended
+
+	suspendAppSelection appSelection // Applications suspended instead of a named one
+)
+
+var suspendAppCmd = &cobra.Command{
+	Use:   "suspend [name]",
+	Short: "Stop reconciling an application until it is reset",
+	Long: `Suspends an application: the controller completes any sync in progress and then stops
+reconciling it. Unlike stopping the controller, the suspension survives restarts. Run
+'gitopsctl app reset' to resume reconciliation.
+
+If the controller is running, the suspension is sent through its local control socket and
+takes effect immediately. Otherwise the stored status is changed directly. The suspension is
+recorded with the local user and --reason in the audit log.
+
+With --all, --selector or --cluster instead of a name, every matching application is suspended, up
+to --parallel at a time, and the result for each is printed followed by a summary.`,
+	Example: `  # Suspend an application during an incident
+  gitopsctl app suspend myapp --reason "INC-1234: stop reverting the manual fix"
+
+  # Resume it afterwards
+  gitopsctl app reset myapp
+
+  # Suspend every application deployed to a cluster during its maintenance
+  gitopsctl app suspend --cluster prod --reason "CHG-42: cluster upgrade"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSuspendAppCommand,
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return appNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func runSuspendAppCommand(cmd *cobra.Command, args []string) error {
+	bulk, err := suspendAppSelection.isBulk(args)
+	if err != nil {
+		return err
+	}
+	if bulk {
+		return runBulkSuspend()
+	}
+	name := strings.TrimSpace(args[0])
+
+	apps, targetApp, err := loadAndFindApplication(name)
+	if err != nil {
+		return err
+	}
+	if targetApp == nil {
+		return fmt.Errorf("application '%s' not found\nUse 'gitopsctl app list' to see registered applications", name)
+	}
+	if targetApp.Status == app.StatusSuspended {
+		return fmt.Errorf("application '%s' is already suspended\nUse 'gitopsctl app reset %s' to resume it", name, name)
+	}
+
+	trigger := app.Trigger{Actor: audit.LocalActor(), Reason: suspendAppReason}
+	remote, err := controller.NewRemoteClient(logger, suspendAppControlSocket)
+	if err == nil && remote.IsDispatcherRunning() {
+		remote.SuspendApp(name, trigger)
+		logger.Info("Suspension requested via CLI", zap.String("name", name), zap.String("actor", trigger.Actor))
+		fmt.Printf("⏸️  Suspending application '%s' once any sync in progress completes.\n", name)
+	} else {
+		logger.Debug("Controller not reachable, suspending application in configuration", zap.Error(err))
+		if err := suspendInConfiguration(apps, targetApp, trigger); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Suspended application '%s'.\n", name)
+		fmt.Printf("   The controller is not running; it will not start the application until it is reset.\n")
+	}
+	recordAudit(audit.ActionSuspend, event.KindApplication, name, suspendAppReason, "")
+
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • Resume reconciliation: gitopsctl app reset %s\n", name)
+	return nil
+}
+
+// suspendInConfiguration suspends an application in the stored configuration, for when the
+// controller is not running.
+func suspendInConfiguration(apps *app.Applications, targetApp *app.Application, trigger app.Trigger) error {
+	apps.Lock()
+	_, statusErr := targetApp.SetStatus(app.StatusSuspended, controller.SuspendedMessage(targetApp.Name, trigger))
+	targetApp.NextSyncAt = time.Time{}
+	saveErr := app.SaveApplications(apps, app.DefaultAppConfigFile)
+	apps.Unlock()
+	if statusErr != nil {
+		return statusErr
+	}
+	if saveErr != nil {
+		logger.Error("Failed to save application configuration", zap.String("app", targetApp.Name), zap.Error(saveErr))
+		return fmt.Errorf("failed to save application configuration: %w", saveErr)
+	}
+	logger.Info("Application suspended in configuration", zap.String("name", targetApp.Name))
+	return nil
+}
+
+// runBulkSuspend suspends every selected application, through the controller if it is running.
+func runBulkSuspend() error {
+	apps, selected, err := suspendAppSelection.load()
+	if err != nil {
+		return err
+	}
+	trigger := app.Trigger{Actor: audit.LocalActor(), Reason: suspendAppReason}
+	remote, err := controller.NewRemoteClient(logger, suspendAppControlSocket)
+	if err != nil || !remote.IsDispatcherRunning() {
+		logger.Debug("Controller not reachable, suspending applications in configuration", zap.Error(err))
+		remote = nil
+	}
+
+	err = runBulk(&suspendAppSelection, "Suspending", selected, func(a *app.Application) bulkResult {
+		if a.Status == app.StatusSuspended {
+			return bulkResult{name: a.Name, skipped: true, message: "already suspended"}
+		}
+		message := "suspended"
+		if remote != nil {
+			if err := remote.RequestSuspend(a.Name, trigger); err != nil {
+				return bulkResult{name: a.Name, err: fmt.Errorf("failed to request suspension: %w", err)}
+			}
+			message = "suspending once any sync in progress completes"
+		} else if err := suspendInConfiguration(apps, a, trigger); err != nil {
+			return bulkResult{name: a.Name, err: err}
+		}
+		recordAudit(audit.ActionSuspend, event.KindApplication, a.Name, suspendAppReason, "")
+		return bulkResult{name: a.Name, message: message}
+	})
+	if remote == nil {
+		fmt.Printf("   The controller is not running; it will not start the applications until they are reset.\n")
+	}
+
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • Resume reconciliation: gitopsctl app reset %s\n", suspendAppSelection.flags())
+	return err
+}
+
+func init() {
+	appCmd.AddCommand(suspendAppCmd)
+
+	suspendAppCmd.Flags().StringVar(&suspendAppControlSocket, "control-socket", controller.DefaultControlSocket, "Path of the controller's local control socket")
+	suspendAppCmd.Flags().StringVar(&suspendAppReason, "reason", "", "Why the application is suspended, recorded in the audit log")
+	addAppSelectionFlags(suspendAppCmd, &suspendAppSelection, "Suspend")
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	syncAppControlSocket string // Path of the controller's control socket
+	syncAppReason        string // Why the sync is requested
+	syncAppDryRun        string // Dry-run the sync instead; only "server" is supported
+
+	syncAppSelection appSelection // Applications synced instead of a named one
+)
+
+var syncAppCmd = &cobra.Command{
+	Use:   "sync [name]",
+	Short: "Trigger an immediate sync of a GitOps application",
+	Long: `Asks the running controller to reconcile an application immediately instead of
+waiting for its next polling interval.
+
+The controller must be running ('gitopsctl start') and reachable through its local control socket.
+The request is recorded with the local user and --reason in the application's sync history and
+the audit log.
+
+With --dry-run=server, the manifests of the latest revision are submitted to the cluster as
+server-side dry runs instead, and what the sync would do to each resource is printed. Nothing is
+changed in the cluster or in the application's status.
+
+With --all, --selector or --cluster instead of a name, every matching application is synced, up to
+--parallel at a time, and the result for each is printed followed by a summary.`,
+	Example: `  # Trigger an immediate sync
+  gitopsctl app sync myapp
+
+  # Record why the sync is requested
+  gitopsctl app sync myapp --reason "Pick up the rotated database password"
+
+  # Show what a sync would change, without applying anything
+  gitopsctl app sync myapp --dry-run=server
+
+  # Sync every application of the payments team
+  gitopsctl app sync --selector team=payments
+
+  # Sync every application deployed to a cluster, 10 at a time
+  gitopsctl app sync --cluster prod --parallel 10
+
+  # Use a non-default control socket
+  gitopsctl app sync myapp --control-socket /var/run/gitopsctl.sock`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSyncAppCommand,
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return appNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func runSyncAppCommand(cmd *cobra.Command, args []string) error {
+	bulk, err := syncAppSelection.isBulk(args)
+	if err != nil {
+		return err
+	}
+	if bulk {
+		return runBulkSync()
+	}
+	name := strings.TrimSpace(args[0])
+
+	_, targetApp, err := loadAndFindApplication(name)
+	if err != nil {
+		return err
+	}
+	if targetApp == nil {
+		return fmt.Errorf("application '%s' not found\nUse 'gitopsctl app list' to see registered applications", name)
+	}
+
+	remote, err := controller.NewRemoteClient(logger, syncAppControlSocket)
+	if err != nil {
+		return fmt.Errorf("failed to connect to controller: %w\nIs the controller running? Start it with 'gitopsctl start'", err)
+	}
+	if !remote.IsDispatcherRunning() {
+		return fmt.Errorf("controller is reachable but its dispatcher is not running")
+	}
+
+	if syncAppDryRun != "" {
+		if syncAppDryRun != "server" {
+			return fmt.Errorf("invalid --dry-run '%s', expected 'server'", syncAppDryRun)
+		}
+		report, err := remote.DryRunSync(name, app.Trigger{Actor: audit.LocalActor()})
+		if err != nil {
+			return fmt.Errorf("dry run failed: %w", err)
+		}
+		printDryRunReport(name, report)
+		return nil
+	}
+
+	trigger := app.Trigger{Actor: audit.LocalActor(), Reason: syncAppReason}
+	remote.TriggerSync(name, trigger)
+	recordAudit(audit.ActionSync, event.KindApplication, name, syncAppReason, "")
+	logger.Info("Manual sync requested via CLI", zap.String("name", name), zap.String("actor", trigger.Actor))
+
+	fmt.Printf("🔄 Sync requested for application '%s'.\n", name)
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • Monitor sync status: gitopsctl app status --watch\n")
+	fmt.Printf("  • View details: gitopsctl app describe %s\n", name)
+	return nil
+}
+
+// runBulkSync requests a sync of every selected application from the running controller.
+func runBulkSync() error {
+	if syncAppDryRun != "" {
+		return fmt.Errorf("--dry-run can only be used with an application name")
+	}
+	_, selected, err := syncAppSelection.load()
+	if err != nil {
+		return err
+	}
+	remote, err := controller.NewRemoteClient(logger, syncAppControlSocket)
+	if err != nil {
+		return fmt.Errorf("failed to connect to controller: %w\nIs the controller running? Start it with 'gitopsctl start'", err)
+	}
+	if !remote.IsDispatcherRunning() {
+		return fmt.Errorf("controller is reachable but its dispatcher is not running")
+	}
+
+	trigger := app.Trigger{Actor: audit.LocalActor(), Reason: syncAppReason}
+	err = runBulk(&syncAppSelection, "Syncing", selected, func(a *app.Application) bulkResult {
+		if a.Status == app.StatusSuspended {
+			return bulkResult{name: a.Name, skipped: true, message: "suspended; reset it to sync"}
+		}
+		if err := remote.RequestSync(a.Name, trigger); err != nil {
+			return bulkResult{name: a.Name, err: fmt.Errorf("failed to request sync: %w", err)}
+		}
+		recordAudit(audit.ActionSync, event.KindApplication, a.Name, syncAppReason, "")
+		return bulkResult{name: a.Name, message: "sync requested"}
+	})
+	logger.Info("Bulk sync requested via CLI", zap.String("selection", syncAppSelection.String()), zap.String("actor", trigger.Actor))
+
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  • Monitor sync status: gitopsctl app status --watch\n")
+	return err
+}
+
+// printDryRunReport prints what a sync of the application would do to each resource.
+func printDryRunReport(name string, report *controller.DryRunReport) {
+	fmt.Printf("🔍 Dry run of syncing application '%s' at %s:\n", name, report.Revision)
+	counts := make(map[string]int)
+	for _, r := range report.Resources {
+		counts[r.Action]++
+		resource := k8s.ResourceRef{Kind: r.Kind, Namespace: r.Namespace, Name: r.Name}
+		switch {
+		case r.Action == k8s.ActionFailed:
+			fmt.Printf("  ❌ %s: %s\n", resource, r.Error)
+		case !r.Validated:
+			fmt.Printf("  • %s would be %s (not validated, its CRD is not applied yet)\n", resource, r.Action)
+		default:
+			fmt.Printf("  • %s would be %s\n", resource, r.Action)
+		}
+		if r.Diff != "" {
+			for _, line := range strings.Split(strings.TrimRight(r.Diff, "\n"), "\n") {
+				fmt.Printf("      %s\n", line)
+			}
+		}
+	}
+	for _, e := range report.Errors {
+		fmt.Printf("  ❌ %s\n", e)
+	}
+	fmt.Printf("\n%d created, %d updated, %d unchanged, %d failed\n",
+		counts[k8s.ActionCreated], counts[k8s.ActionUpdated], counts[k8s.ActionUnchanged], counts[k8s.ActionFailed]+len(report.Errors))
+}
+
+func init() {
+	appCmd.AddCommand(syncAppCmd)
+
+	syncAppCmd.Flags().StringVar(&syncAppControlSocket, "control-socket", controller.DefaultControlSocket, "Path of the controller's local control socket")
+	syncAppCmd.Flags().StringVar(&syncAppDryRun, "dry-run", "", "Dry-run the sync on the cluster (\"server\") and print what it would do to each resource, without applying anything")
+	syncAppCmd.Flags().StringVar(&syncAppReason, "reason", "", "Why the sync is requested, recorded in the sync history and audit log")
+	addAppSelectionFlags(syncAppCmd, &syncAppSelection, "Sync")
+}
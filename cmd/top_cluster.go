@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/utils"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	topClusterTimeout time.Duration       // Timeout for live cluster queries
+	topClusterOutput  utils.OutputOptions // Output options for the top command
+)
+
+var topClusterCmd = &cobra.Command{
+	Use:   "top <name>",
+	Short: "Show CPU and memory capacity of a cluster's nodes",
+	Long: `Summarizes the CPU and memory of each node in a registered cluster.
+
+For every node it shows what is allocatable to pods, what running pods request,
+and, when metrics-server is installed in the cluster, what is actually in use.
+Use it to compare clusters before choosing where to deploy an application.`,
+	Example: `  # Show the capacity of a cluster
+  gitopsctl cluster top production
+
+  # Print the capacity as JSON
+  gitopsctl cluster top production -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTopClusterCommand,
+	ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return clusterNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func runTopClusterCommand(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+
+	if err := topClusterOutput.Validate(); err != nil {
+		return err
+	}
+
+	cl, _, err := cluster.VerifyCluster(name)
+	if err != nil {
+		return err
+	}
+
+	client, err := k8s.NewClientSet(zap.NewNop(), cl.KubeconfigPath, k8s.ClientOptions{QPS: cl.QPS, Burst: cl.Burst, InCluster: cl.InCluster})
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client for cluster '%s': %w", cl.Name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), topClusterTimeout)
+	defer cancel()
+
+	capacity, err := client.Capacity(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get capacity of cluster '%s': %w", cl.Name, err)
+	}
+
+	if topClusterOutput.IsMachineOutput() {
+		return utils.RenderObject(topClusterOutput, capacity)
+	}
+
+	printClusterCapacity(capacity)
+	return nil
+}
+
+// printClusterCapacity prints the human-readable capacity table of a cluster.
+func printClusterCapacity(capacity *k8s.Capacity) {
+	if len(capacity.Nodes) == 0 {
+		fmt.Println("No nodes found.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
+	headers := []string{"NODE", "READY", "CPU REQUESTS", "CPU USED", "MEMORY REQUESTS", "MEMORY USED", "PODS"}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	row := func(name, ready string, s k8s.ResourceSummary) {
+		cpuUsed, memoryUsed := "-", "-"
+		if capacity.MetricsAvailable {
+			cpuUsed = formatUsage(s.CPUUsage, s.CPUAllocatable, formatMillicores)
+			memoryUsed = formatUsage(s.MemoryUsage, s.MemoryAllocatable, formatBytes)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			name, ready,
+			formatUsage(s.CPURequested, s.CPUAllocatable, formatMillicores), cpuUsed,
+			formatUsage(s.MemoryRequested, s.MemoryAllocatable, formatBytes), memoryUsed,
+			s.Pods)
+	}
+	for _, node := range capacity.Nodes {
+		ready := "Yes"
+		if !node.Ready {
+			ready = "No"
+		}
+		row(node.Name, ready, node.ResourceSummary)
+	}
+	row("TOTAL", "", capacity.Total)
+	w.Flush()
+
+	if !capacity.MetricsAvailable {
+		fmt.Printf("\nUsage is unavailable: metrics-server does not appear to be installed in the cluster.\n")
+	}
+}
+
+// formatUsage formats an amount out of the allocatable total with its percentage (e.g., "1500m/4000m (37%)").
+func formatUsage(amount, allocatable int64, format func(int64) string) string {
+	percent := int64(0)
+	if allocatable > 0 {
+		percent = amount * 100 / allocatable
+	}
+	return fmt.Sprintf("%s/%s (%d%%)", format(amount), format(allocatable), percent)
+}
+
+// formatMillicores formats CPU millicores (e.g., "1500m").
+func formatMillicores(millicores int64) string {
+	return fmt.Sprintf("%dm", millicores)
+}
+
+// formatBytes formats a memory amount in mebibytes (e.g., "512Mi").
+func formatBytes(bytes int64) string {
+	return fmt.Sprintf("%dMi", bytes/(1<<20))
+}
+
+func init() {
+	clusterCmd.AddCommand(topClusterCmd)
+
+	topClusterCmd.Flags().DurationVar(&topClusterTimeout, "timeout", 30*time.Second, "Timeout for live cluster queries")
+	utils.AddOutputFlags(topClusterCmd, &topClusterOutput)
+}
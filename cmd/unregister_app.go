@@ -1,44 +1,66 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
+	"aeswibon.com/github/gitopsctl/internal/controller"
 	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cascade"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
 var (
-	unregisterAppName   string // Name of the application to unregister
-	forceUnregisterApp  bool   // Force unregister without confirmation
-	dryRunUnregisterApp bool   // Preview unregister without applying changes
+	unregisterAppName          string        // Name of the application to unregister
+	forceUnregisterApp         bool          // Force unregister without confirmation
+	dryRunUnregisterApp        bool          // Preview unregister without applying changes
+	cascadeUnregisterApp       bool          // Delete the application's managed resources from the cluster
+	keepHistoryUnregisterApp   bool          // Archive the application's sync history
+	unregisterAppTimeout       time.Duration // Timeout for deleting managed resources
+	unregisterAppControlSocket string        // Path of the controller's control socket
 )
 
 var unregisterAppCmd = &cobra.Command{
-	Use:     "unregister",
-	GroupID: "appGroup",
+	Use:     "delete",
+	Aliases: []string{"unregister"},
 	Short:   "Unregister a GitOps application from controller management",
 	Long: `Removes a registered application from GitOps controller's management.
 
 This command removes the application configuration from the controller, but does NOT:
-- Delete any resources from the Kubernetes cluster
+- Delete any resources from the Kubernetes cluster, unless --cascade is given
 - Remove the Git repository or its contents
 - Affect other applications
 
 The application will stop being synchronized, but existing resources will remain
 in the cluster until manually removed.
 
+Use --cascade to first delete the resources the application manages, as recorded by its
+last sync, from the cluster. If any of them cannot be deleted the application stays
+registered, so the command can be retried. Resources annotated with
+gitopsctl.io/delete-protection: "true" or gitopsctl.io/prune: "false" are kept.
+
+Use --keep-history to keep the application's configuration and sync history in
+configs/archived_applications.json for audits.
+
 Use --dry-run to preview what will be unregistered.
 Use --force to skip confirmation prompts.`,
 	Example: `  # Unregister an application with confirmation
-  gitopsctl app unregister --name myapp
+  gitopsctl app delete --name myapp
 
   # Preview unregistration without applying changes
-  gitopsctl app unregister --name myapp --dry-run
+  gitopsctl app delete --name myapp --dry-run
 
   # Force unregister without confirmation
-  gitopsctl app unregister --name myapp --force`,
+  gitopsctl app delete --name myapp --force
+
+  # Delete the application's resources from the cluster and keep its sync history
+  gitopsctl app delete --name myapp --cascade --keep-history`,
 	Args: cobra.NoArgs,
 	RunE: runUnregisterCommand,
 }
@@ -57,6 +79,10 @@ func runUnregisterCommand(cmd *cobra.Command, args []string) error {
 		return handleAppNotFound(unregisterAppName)
 	}
 
+	if cascadeUnregisterApp && targetApp.IsTerraform() {
+		return fmt.Errorf("--cascade cannot be used with Terraform application '%s'; destroy its infrastructure with 'terraform destroy' before unregistering it", targetApp.Name)
+	}
+
 	if dryRunUnregisterApp {
 		return displayUnregisterDryRun(targetApp)
 	}
@@ -103,6 +129,22 @@ func loadAndFindApplication(appName string) (*app.Applications, *app.Application
 	return apps, targetApp, nil
 }
 
+// newAppClientSet creates a Kubernetes client for the cluster an application is deployed to, which
+// impersonates the application's identity like the controller does when applying its resources.
+func newAppClientSet(targetApp *app.Application, cl *cluster.Cluster) (*k8s.ClientSet, error) {
+	user, groups := targetApp.EffectiveImpersonation(cl.ImpersonateUser, cl.ImpersonateGroups)
+	client, err := k8s.NewClientSet(zap.NewNop(), cl.KubeconfigPath, k8s.ClientOptions{
+		QPS:         cl.QPS,
+		Burst:       cl.Burst,
+		InCluster:   cl.InCluster,
+		Impersonate: k8s.Impersonation{User: user, Groups: groups},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client for cluster '%s': %w", cl.Name, err)
+	}
+	return client, nil
+}
+
 func handleAppNotFound(appName string) error {
 	logger.Warn("Application not found in registry",
 		zap.String("name", appName))
@@ -130,7 +172,14 @@ func displayUnregisterDryRun(targetApp *app.Application) error {
 	}
 
 	fmt.Printf("\nWarning: This will stop GitOps synchronization for this application.\n")
-	fmt.Printf("Existing Kubernetes resources will remain in the cluster.\n")
+	if cascadeUnregisterApp {
+		printCascadeResources(targetApp)
+	} else {
+		fmt.Printf("Existing Kubernetes resources will remain in the cluster.\n")
+	}
+	if keepHistoryUnregisterApp {
+		fmt.Printf("Its sync history (%d entries) will be archived in %s.\n", len(targetApp.SyncHistory), app.DefaultArchiveFile)
+	}
 	fmt.Printf("\nTo apply these changes, run the command again without --dry-run\n")
 
 	return nil
@@ -144,22 +193,90 @@ func confirmUnregister(targetApp *app.Application) bool {
 	fmt.Printf("  Status:         %s\n", targetApp.Status)
 
 	fmt.Printf("\n⚠️  Warning: This will stop GitOps synchronization for this application.\n")
-	fmt.Printf("Existing Kubernetes resources will remain in the cluster and must be manually removed if needed.\n\n")
+	if cascadeUnregisterApp {
+		printCascadeResources(targetApp)
+		fmt.Println()
+	} else {
+		fmt.Printf("Existing Kubernetes resources will remain in the cluster and must be manually removed if needed.\n\n")
+	}
 
 	return confirmAction("Are you sure you want to unregister this application?")
 }
 
-func performUnregistration(apps *app.Applications, targetApp *app.Application) error {
-	apps.Lock()
-	defer apps.Unlock()
+// printCascadeResources lists the managed resources --cascade deletes from the cluster.
+func printCascadeResources(targetApp *app.Application) {
+	if len(targetApp.ManagedResources) == 0 {
+		fmt.Printf("No managed resources are recorded for this application; nothing will be deleted from the cluster.\n")
+		return
+	}
+	fmt.Printf("These %d resource(s) will be DELETED from cluster '%s':\n", len(targetApp.ManagedResources), targetApp.ClusterName)
+	for _, r := range targetApp.ManagedResources {
+		if r.Namespace != "" {
+			fmt.Printf("  • %s %s/%s\n", r.Kind, r.Namespace, r.Name)
+		} else {
+			fmt.Printf("  • %s %s\n", r.Kind, r.Name)
+		}
+	}
+}
 
-	apps.Delete(targetApp.Name)
+// deleteManagedResources stops the application's reconciliation loop, if the controller is running,
+// and deletes its managed resources from the cluster.
+func deleteManagedResources(targetApp *app.Application) (int, error) {
+	if remote, err := controller.NewRemoteClient(logger, unregisterAppControlSocket); err == nil && remote.IsDispatcherRunning() {
+		// Keep the controller from applying the resources again while they are deleted
+		remote.StopApp(targetApp.Name)
+	}
+	if len(targetApp.ManagedResources) == 0 {
+		return 0, nil
+	}
 
-	if err := app.SaveApplications(apps, app.DefaultAppConfigFile); err != nil {
-		logger.Error("Failed to save applications after unregister",
-			zap.String("app", targetApp.Name),
-			zap.Error(err))
-		return fmt.Errorf("failed to save applications after unregister: %w", err)
+	cl, _, err := cluster.VerifyCluster(targetApp.ClusterName)
+	if err != nil {
+		return 0, err
+	}
+	client, err := newAppClientSet(targetApp, cl)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), unregisterAppTimeout)
+	defer cancel()
+
+	deleted, protected, errs := cascade.Delete(ctx, client, targetApp)
+	if len(protected) > 0 {
+		fmt.Printf("\n🛡️  Kept %d resource(s) protected from deletion:\n", len(protected))
+		for _, ref := range protected {
+			fmt.Printf("  • %s\n", ref)
+		}
+	}
+	if len(errs) > 0 {
+		fmt.Printf("\n❌ Failed to delete %d of %d resource(s):\n", len(errs), len(targetApp.ManagedResources))
+		for _, err := range errs {
+			fmt.Printf("  • %v\n", err)
+		}
+		return len(deleted), fmt.Errorf("failed to delete the managed resources of application '%s'; it is still registered, retry once the errors are resolved", targetApp.Name)
+	}
+	return len(deleted), nil
+}
+
+func performUnregistration(apps *app.Applications, targetApp *app.Application) error {
+	var deleted int
+	if cascadeUnregisterApp {
+		var err error
+		if deleted, err = deleteManagedResources(targetApp); err != nil {
+			logger.Error("Failed to delete managed resources", zap.String("app", targetApp.Name), zap.Error(err))
+			return err
+		}
+	}
+
+	if remote, err := controller.NewRemoteClient(logger, unregisterAppControlSocket); err == nil && remote.IsDispatcherRunning() {
+		// The controller would otherwise save the application again with its own state
+		if err := remote.UnregisterApp(targetApp.Name, keepHistoryUnregisterApp, cascadeUnregisterApp); err != nil {
+			logger.Error("Failed to unregister application with the controller", zap.String("app", targetApp.Name), zap.Error(err))
+			return fmt.Errorf("failed to unregister application: %w", err)
+		}
+	} else if err := unregisterInConfiguration(apps, targetApp); err != nil {
+		return err
 	}
 
 	logger.Info("Application unregistered successfully",
@@ -171,17 +288,65 @@ func performUnregistration(apps *app.Applications, targetApp *app.Application) e
 	fmt.Printf("Summary:\n")
 	fmt.Printf("  • GitOps synchronization stopped\n")
 	fmt.Printf("  • Application removed from controller\n")
-	fmt.Printf("  • Kubernetes resources remain in cluster '%s'\n", targetApp.ClusterName)
+	if targetApp.IsTerraform() {
+		fmt.Printf("  • Infrastructure managed by the Terraform configuration is left as it is\n")
+	} else if cascadeUnregisterApp {
+		fmt.Printf("  • %d resource(s) deleted from cluster '%s'\n", deleted, targetApp.ClusterName)
+	} else {
+		fmt.Printf("  • Kubernetes resources remain in cluster '%s'\n", targetApp.ClusterName)
+	}
+	if keepHistoryUnregisterApp {
+		fmt.Printf("  • Sync history archived in %s\n", app.DefaultArchiveFile)
+	}
 
 	fmt.Printf("\nNext steps:\n")
-	fmt.Printf("  • To manually clean up resources: kubectl delete -f <manifests> --namespace <namespace>\n")
-	fmt.Printf("  • To re-register: gitopsctl app register --name %s --repo %s --path %s --cluster %s\n",
-		targetApp.Name, targetApp.RepoURL, targetApp.Path, targetApp.ClusterName)
+	if targetApp.IsTerraform() {
+		fmt.Printf("  • To re-register: gitopsctl app register --name %s --repo %s --path %s --type terraform\n",
+			targetApp.Name, targetApp.RepoURL, targetApp.Path)
+	} else {
+		if !cascadeUnregisterApp {
+			fmt.Printf("  • To delete its resources: kubectl delete all -l %s\n", k8s.AppSelector(targetApp.Name))
+		}
+		fmt.Printf("  • To re-register: gitopsctl app register --name %s --repo %s --path %s --cluster %s\n",
+			targetApp.Name, targetApp.RepoURL, targetApp.Path, targetApp.ClusterName)
+	}
 	fmt.Printf("  • To list remaining apps: gitopsctl app list\n")
 
 	return nil
 }
 
+// unregisterInConfiguration removes the application from the stored configuration, archiving it
+// first with --keep-history, for when the controller is not running.
+func unregisterInConfiguration(apps *app.Applications, targetApp *app.Application) error {
+	apps.Lock()
+	defer apps.Unlock()
+
+	if keepHistoryUnregisterApp {
+		if err := app.ArchiveApplication(targetApp, cascadeUnregisterApp, app.DefaultArchiveFile, time.Now()); err != nil {
+			logger.Error("Failed to archive application", zap.String("app", targetApp.Name), zap.Error(err))
+			return fmt.Errorf("failed to archive application history: %w", err)
+		}
+	}
+
+	apps.Delete(targetApp.Name)
+	if err := os.Remove(app.TerraformPlanFile(targetApp.Name)); err != nil && !os.IsNotExist(err) {
+		logger.Warn("Failed to remove pending Terraform plan", zap.String("app", targetApp.Name), zap.Error(err))
+	}
+	for _, dir := range []string{app.RevisionDir(targetApp.Name), app.PreviousRevisionDir(targetApp.Name)} {
+		if err := os.RemoveAll(dir); err != nil {
+			logger.Warn("Failed to remove kept revision", zap.String("app", targetApp.Name), zap.Error(err))
+		}
+	}
+
+	if err := app.SaveApplications(apps, app.DefaultAppConfigFile); err != nil {
+		logger.Error("Failed to save applications after unregister",
+			zap.String("app", targetApp.Name),
+			zap.Error(err))
+		return fmt.Errorf("failed to save applications after unregister: %w", err)
+	}
+	return nil
+}
+
 func confirmAction(message string) bool {
 	fmt.Printf("%s [y/N]: ", message)
 	var response string
@@ -194,7 +359,7 @@ func confirmAction(message string) bool {
 }
 
 func init() {
-	rootCmd.AddCommand(unregisterAppCmd)
+	appCmd.AddCommand(unregisterAppCmd)
 
 	unregisterAppCmd.Flags().StringVarP(&unregisterAppName, "name", "n", "",
 		"Name of the application to unregister (required)")
@@ -202,6 +367,14 @@ func init() {
 		"Skip confirmation prompts")
 	unregisterAppCmd.Flags().BoolVar(&dryRunUnregisterApp, "dry-run", false,
 		"Preview the unregistration without applying changes")
+	unregisterAppCmd.Flags().BoolVar(&cascadeUnregisterApp, "cascade", false,
+		"Delete the application's managed resources from the cluster before unregistering it")
+	unregisterAppCmd.Flags().BoolVar(&keepHistoryUnregisterApp, "keep-history", false,
+		"Archive the application's configuration and sync history for audits")
+	unregisterAppCmd.Flags().DurationVar(&unregisterAppTimeout, "timeout", 2*time.Minute,
+		"Timeout for deleting the managed resources with --cascade")
+	unregisterAppCmd.Flags().StringVar(&unregisterAppControlSocket, "control-socket", controller.DefaultControlSocket,
+		"Path of the controller's local control socket")
 
 	unregisterAppCmd.MarkFlagRequired("name")
 }
@@ -2,41 +2,83 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/controller"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
 	"aeswibon.com/github/gitopsctl/internal/core/cluster"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
+const (
+	// dependentsBlock refuses to unregister a cluster that applications are deployed to.
+	dependentsBlock = "block"
+	// dependentsUnregister unregisters the applications deployed to the cluster along with it.
+	dependentsUnregister = "unregister"
+	// dependentsReassign moves the applications deployed to the cluster to another cluster.
+	dependentsReassign = "reassign"
+)
+
 var (
-	clusterUnregName       string
-	forceUnregisterCluster bool
+	clusterUnregName               string
+	forceUnregisterCluster         bool
+	unregisterClusterDependents    string // How to handle applications deployed to the cluster
+	unregisterClusterReassignTo    string // Cluster to move dependent applications to
+	unregisterClusterControlSocket string // Path of the controller's control socket
 )
 
 var unregisterClusterCmd = &cobra.Command{
-	Use:     "unregister-cluster",
-	GroupID: "clusterGroup",
+	Use:     "delete",
+	Aliases: []string{"unregister"},
 	Short:   "Unregister a Kubernetes cluster from gitopsctl management",
 	Long: `Removes a registered Kubernetes cluster from gitopsctl's management.
 
 This command removes the cluster configuration from the controller, but does not
-affect the actual Kubernetes cluster. Any applications associated with this 
-cluster will become dysfunctional until they are updated to reference a valid cluster.
+affect the actual Kubernetes cluster.
+
+A cluster that applications are deployed to is not unregistered unless --dependents
+says what to do with them:
+  block       Refuse to unregister the cluster (default)
+  unregister  Unregister the applications too; their resources remain in the cluster
+  reassign    Move the applications to another cluster, given with --reassign-to or
+              chosen interactively for each application
 
 Use the --force flag to skip confirmation prompts.`,
 	Example: `  # Unregister a cluster with confirmation
-  gitopsctl unregister-cluster --name my-cluster
+  gitopsctl cluster delete --name my-cluster
 
   # Unregister a cluster without confirmation
-  gitopsctl unregister-cluster --name my-cluster --force`,
+  gitopsctl cluster delete --name my-cluster --force
+
+  # Unregister a cluster and the applications deployed to it
+  gitopsctl cluster delete --name my-cluster --dependents unregister
+
+  # Move the applications deployed to the cluster to another cluster first
+  gitopsctl cluster delete --name my-cluster --reassign-to other-cluster`,
 	Args: cobra.NoArgs,
 	RunE: unregisterCluster,
 }
 
 func unregisterCluster(cm *cobra.Command, args []string) error {
+	clusterUnregName = strings.TrimSpace(clusterUnregName)
 	if err := common.ValidateName(clusterUnregName); err != nil {
-		return err
+		return fmt.Errorf("invalid cluster name: %w", err)
+	}
+	if unregisterClusterReassignTo != "" && !cm.Flags().Changed("dependents") {
+		unregisterClusterDependents = dependentsReassign
+	}
+	switch unregisterClusterDependents {
+	case dependentsBlock, dependentsUnregister:
+		if unregisterClusterReassignTo != "" {
+			return fmt.Errorf("--reassign-to can only be used with --dependents %s", dependentsReassign)
+		}
+	case dependentsReassign:
+	default:
+		return fmt.Errorf("invalid --dependents value '%s' (must be %s, %s or %s)",
+			unregisterClusterDependents, dependentsBlock, dependentsUnregister, dependentsReassign)
 	}
 
 	clusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
@@ -50,21 +92,57 @@ func unregisterCluster(cm *cobra.Command, args []string) error {
 	clusters.Lock()
 	defer clusters.Unlock()
 
-	clusterConfig, exists := clusters.Get(clusterUnregName)
-	if !exists {
+	if _, exists := clusters.Get(clusterUnregName); !exists {
 		logger.Warn("Cluster not found in registry",
 			zap.String("name", clusterUnregName))
 		fmt.Printf("Cluster '%s' is not registered. Nothing to unregister.\n", clusterUnregName)
 		return nil
 	}
 
+	apps, err := app.LoadApplications(app.DefaultAppConfigFile)
+	if err != nil {
+		logger.Error("Failed to load applications", zap.Error(err))
+		return fmt.Errorf("failed to load applications: %w", err)
+	}
+	apps.Lock()
+	defer apps.Unlock()
+
+	var dependents []*app.Application
+	for _, a := range apps.List() {
+		if a.ClusterName == clusterUnregName {
+			dependents = append(dependents, a)
+		}
+	}
+	slices.SortFunc(dependents, func(a, b *app.Application) int { return strings.Compare(a.Name, b.Name) })
+
+	// Target cluster of each reassigned application
+	targets := make(map[string]string, len(dependents))
+	if len(dependents) > 0 {
+		switch unregisterClusterDependents {
+		case dependentsBlock:
+			return dependentsError(dependents)
+		case dependentsReassign:
+			if err := chooseReassignTargets(clusters, dependents, targets); err != nil {
+				return err
+			}
+		}
+	}
+
 	if !forceUnregisterCluster {
 		fmt.Printf("Cluster to unregister:\n")
 		fmt.Printf("  Name: %s\n", clusterUnregName)
-		if clusterConfig != nil {
-			fmt.Printf("  Status: Registered\n")
+		fmt.Printf("  Status: Registered\n")
+		if len(dependents) > 0 {
+			fmt.Printf("\nApplications deployed to this cluster:\n")
+			for _, a := range dependents {
+				if target, ok := targets[a.Name]; ok {
+					fmt.Printf("  • %s → reassigned to '%s'\n", a.Name, target)
+				} else {
+					fmt.Printf("  • %s → unregistered (its resources remain in the cluster)\n", a.Name)
+				}
+			}
 		}
-		fmt.Printf("\nWarning: Applications associated with this cluster may become dysfunctional.\n")
+		fmt.Println()
 
 		if !common.ConfirmAction("Are you sure you want to unregister this cluster?") {
 			fmt.Println("Operation cancelled.")
@@ -72,6 +150,24 @@ func unregisterCluster(cm *cobra.Command, args []string) error {
 		}
 	}
 
+	// Update the applications before removing the cluster, so none is left pointing at it
+	if len(dependents) > 0 {
+		for _, a := range dependents {
+			if target, ok := targets[a.Name]; ok {
+				a.ClusterName = target
+				a.LastSyncedGitHash = "" // Apply the manifests to the new cluster on the next sync
+			} else {
+				apps.Delete(a.Name)
+			}
+		}
+		if err := app.SaveApplications(apps, app.DefaultAppConfigFile); err != nil {
+			logger.Error("Failed to save applications before cluster unregister",
+				zap.String("cluster", clusterUnregName),
+				zap.Error(err))
+			return fmt.Errorf("failed to save applications: %w", err)
+		}
+	}
+
 	clusters.Delete(clusterUnregName)
 
 	// Save clusters with better error handling
@@ -82,20 +178,123 @@ func unregisterCluster(cm *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to save clusters after unregister: %w", err)
 	}
 
+	notifyDependentsChanged(dependents, targets)
+
 	logger.Info("Cluster unregistered successfully",
-		zap.String("name", clusterUnregName))
+		zap.String("name", clusterUnregName),
+		zap.Int("dependents", len(dependents)),
+		zap.String("dependents_policy", unregisterClusterDependents))
 	fmt.Printf("✓ Cluster '%s' has been unregistered successfully.\n", clusterUnregName)
+	for _, a := range dependents {
+		if target, ok := targets[a.Name]; ok {
+			fmt.Printf("  • Application '%s' reassigned to cluster '%s'\n", a.Name, target)
+		} else {
+			fmt.Printf("  • Application '%s' unregistered\n", a.Name)
+		}
+	}
+	if len(targets) > 0 {
+		fmt.Printf("\nResources applied to '%s' by reassigned applications are not deleted.\n", clusterUnregName)
+	}
 
 	return nil
 }
 
+// dependentsError returns the error reported when applications block unregistering the cluster.
+func dependentsError(dependents []*app.Application) error {
+	names := make([]string, 0, len(dependents))
+	for _, a := range dependents {
+		names = append(names, a.Name)
+	}
+	return fmt.Errorf("cluster '%s' is in use by %d application(s): %s\n"+
+		"Use '--dependents %s' to unregister them too, or '--dependents %s' (optionally with --reassign-to <cluster>) to move them to another cluster",
+		clusterUnregName, len(dependents), strings.Join(names, ", "), dependentsUnregister, dependentsReassign)
+}
+
+// chooseReassignTargets sets the cluster each dependent application is moved to, either the
+// --reassign-to cluster or one chosen interactively for each application.
+func chooseReassignTargets(clusters *cluster.Clusters, dependents []*app.Application, targets map[string]string) error {
+	var candidates []string
+	for _, cl := range clusters.List() {
+		if cl.Name != clusterUnregName {
+			candidates = append(candidates, cl.Name)
+		}
+	}
+	slices.Sort(candidates)
+	if len(candidates) == 0 {
+		return fmt.Errorf("no other cluster is registered to reassign applications to\nRegister one with 'gitopsctl cluster register' or use '--dependents %s'", dependentsUnregister)
+	}
+
+	if unregisterClusterReassignTo != "" {
+		if unregisterClusterReassignTo == clusterUnregName {
+			return fmt.Errorf("cannot reassign applications to the cluster being unregistered")
+		}
+		if !slices.Contains(candidates, unregisterClusterReassignTo) {
+			return fmt.Errorf("cluster '%s' not found\nAvailable clusters: %s", unregisterClusterReassignTo, strings.Join(candidates, ", "))
+		}
+		for _, a := range dependents {
+			targets[a.Name] = unregisterClusterReassignTo
+		}
+		return nil
+	}
+
+	if forceUnregisterCluster {
+		return fmt.Errorf("--reassign-to is required to reassign applications with --force")
+	}
+	fmt.Printf("Cluster '%s' is in use by %d application(s).\n", clusterUnregName, len(dependents))
+	fmt.Printf("Available clusters: %s\n\n", strings.Join(candidates, ", "))
+	for _, a := range dependents {
+		target := common.Prompt(fmt.Sprintf("Reassign application '%s' to cluster [%s]", a.Name, candidates[0]))
+		if target == "" {
+			target = candidates[0]
+		}
+		if !slices.Contains(candidates, target) {
+			return fmt.Errorf("cluster '%s' not found\nAvailable clusters: %s", target, strings.Join(candidates, ", "))
+		}
+		targets[a.Name] = target
+	}
+	fmt.Println()
+	return nil
+}
+
+// notifyDependentsChanged tells a running controller to stop unregistered applications and restart
+// reassigned ones against their new cluster.
+func notifyDependentsChanged(dependents []*app.Application, targets map[string]string) {
+	if len(dependents) == 0 {
+		return
+	}
+	remote, err := controller.NewRemoteClient(logger, unregisterClusterControlSocket)
+	if err != nil || !remote.IsDispatcherRunning() {
+		logger.Debug("Controller not reachable, changes take effect when it starts", zap.Error(err))
+		return
+	}
+	for _, a := range dependents {
+		if _, ok := targets[a.Name]; ok {
+			remote.StartApp(a.Name)
+		} else {
+			remote.StopApp(a.Name)
+		}
+	}
+}
+
 func init() {
-	rootCmd.AddCommand(unregisterClusterCmd)
+	clusterCmd.AddCommand(unregisterClusterCmd)
 
 	unregisterClusterCmd.Flags().StringVarP(&clusterUnregName, "name", "n", "",
 		"Name of the cluster to unregister (required)")
 	unregisterClusterCmd.Flags().BoolVarP(&forceUnregisterCluster, "force", "f", false,
 		"Skip confirmation prompts")
+	unregisterClusterCmd.Flags().StringVar(&unregisterClusterDependents, "dependents", dependentsBlock,
+		"How to handle applications deployed to the cluster: block, unregister or reassign")
+	unregisterClusterCmd.Flags().StringVar(&unregisterClusterReassignTo, "reassign-to", "",
+		"Cluster to move the applications deployed to the cluster to (implies --dependents reassign)")
+	unregisterClusterCmd.Flags().StringVar(&unregisterClusterControlSocket, "control-socket", controller.DefaultControlSocket,
+		"Path of the controller's local control socket")
 
 	unregisterClusterCmd.MarkFlagRequired("name")
+	unregisterClusterCmd.RegisterFlagCompletionFunc("dependents", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{dependentsBlock, dependentsUnregister, dependentsReassign}, cobra.ShellCompDirectiveNoFileComp
+	})
+	unregisterClusterCmd.RegisterFlagCompletionFunc("reassign-to", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return clusterNames(), cobra.ShellCompDirectiveNoFileComp
+	})
 }
@@ -0,0 +1,146 @@
+// Package apierror defines the errors returned by the API, each with a stable, machine-readable
+// code in the error response so that clients can branch on failures without parsing messages.
+package apierror
+
+import (
+	"net/http"
+
+	"aeswibon.com/github/gitopsctl/internal/core/git"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Code identifies the kind of an API error. Codes are stable; messages are not.
+type Code string
+
+const (
+	// CodeValidationFailed means the request was malformed or invalid.
+	CodeValidationFailed Code = "ValidationFailed"
+	// CodeInvalidRequest means the request cannot be served as made, e.g. with an unsupported method.
+	CodeInvalidRequest Code = "InvalidRequest"
+	// CodeUnauthorized means the request was not authenticated.
+	CodeUnauthorized Code = "Unauthorized"
+	// CodeForbidden means the caller lacks the permission the request requires.
+	CodeForbidden Code = "Forbidden"
+	// CodeNotFound means the application, cluster or other object the request names does not exist.
+	CodeNotFound Code = "NotFound"
+	// CodeConflict means the request conflicts with the current state, e.g. a name already in use
+	// or an application that is suspended.
+	CodeConflict Code = "Conflict"
+	// CodeGitAuthFailed means a Git repository could not be accessed with the available credentials.
+	CodeGitAuthFailed Code = "GitAuthFailed"
+	// CodeK8sForbidden means the Kubernetes API server refused a call for lack of permissions.
+	CodeK8sForbidden Code = "K8sForbidden"
+	// CodeUpstreamFailed means a call to a Kubernetes cluster, Git repository or identity provider failed.
+	CodeUpstreamFailed Code = "UpstreamFailed"
+	// CodeUnavailable means a service the request depends on is temporarily unavailable.
+	CodeUnavailable Code = "Unavailable"
+	// CodeInternal means the request failed because of an error in gitopsctl.
+	CodeInternal Code = "Internal"
+)
+
+// Error is an API error, written as a common.ErrorResponse with its code.
+type Error struct {
+	// Status is the HTTP status code of the response.
+	Status int
+	// Code identifies the kind of error.
+	Code Code
+	// Message describes the error.
+	Message string
+	// Errors lists the individual failures of an operation on several objects, if any.
+	Errors []string
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// New creates an error with the given status, code and message.
+func New(status int, code Code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// ValidationFailed returns a 400 Bad Request error for an invalid request.
+func ValidationFailed(message string) *Error {
+	return New(http.StatusBadRequest, CodeValidationFailed, message)
+}
+
+// Unauthorized returns a 401 Unauthorized error for an unauthenticated request.
+func Unauthorized(message string) *Error {
+	return New(http.StatusUnauthorized, CodeUnauthorized, message)
+}
+
+// Forbidden returns a 403 Forbidden error for a request the caller is not allowed to make.
+func Forbidden(message string) *Error {
+	return New(http.StatusForbidden, CodeForbidden, message)
+}
+
+// NotFound returns a 404 Not Found error for a request naming an object that does not exist.
+func NotFound(message string) *Error {
+	return New(http.StatusNotFound, CodeNotFound, message)
+}
+
+// Conflict returns a 409 Conflict error for a request conflicting with the current state.
+func Conflict(message string) *Error {
+	return New(http.StatusConflict, CodeConflict, message)
+}
+
+// Upstream returns a 502 Bad Gateway error for a failed call to an external system.
+func Upstream(message string) *Error {
+	return New(http.StatusBadGateway, CodeUpstreamFailed, message)
+}
+
+// Unavailable returns a 503 Service Unavailable error.
+func Unavailable(message string) *Error {
+	return New(http.StatusServiceUnavailable, CodeUnavailable, message)
+}
+
+// Internal returns a 500 Internal Server Error error.
+func Internal(message string) *Error {
+	return New(http.StatusInternalServerError, CodeInternal, message)
+}
+
+// FromK8s returns a 502 Bad Gateway error for a failed Kubernetes API call, with the code
+// K8sForbidden if the API server refused it for lack of permissions. The message is followed by err.
+func FromK8s(message string, err error) *Error {
+	e := Upstream(message + ": " + err.Error())
+	if apierrors.IsForbidden(err) {
+		e.Code = CodeK8sForbidden
+	}
+	return e
+}
+
+// FromGit returns an error for a Git repository that could not be used: a 502 Bad Gateway error
+// with the code GitAuthFailed if it could not be accessed with the available credentials, and a
+// 400 Bad Request error otherwise. The message is followed by err.
+func FromGit(message string, err error) *Error {
+	if git.IsAccessDenied(err) {
+		return New(http.StatusBadGateway, CodeGitAuthFailed, message+": "+err.Error())
+	}
+	return ValidationFailed(message + ": " + err.Error())
+}
+
+// CodeForStatus returns the code of errors with an HTTP status code that were not created by this
+// package, such as the errors of the HTTP framework for unknown routes.
+func CodeForStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeValidationFailed
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusBadGateway:
+		return CodeUpstreamFailed
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeInvalidRequest
+}
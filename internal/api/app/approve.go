@@ -0,0 +1,58 @@
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Approve handles requests to approve a Terraform application's pending plan, or the pending
+// change of an application requiring approval.
+// The request names the plan it approves, so a plan replaced after it was reviewed is never
+// applied: it returns a 409 Conflict error unless the named plan is the one awaiting approval.
+// The controller applies the plan with its next sync, which it starts immediately.
+func (h *Handler) Approve(c echo.Context) error {
+	name := c.Param("name")
+	req := new(ApproveRequest)
+	if err := c.Bind(req); err != nil {
+		h.logger.Error("Failed to bind approve plan request", zap.Error(err))
+		return apierror.ValidationFailed("Invalid request payload")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	h.apps.RLock()
+	app, ok := h.apps.Get(name)
+	var snapshot appcore.Application
+	if ok {
+		snapshot = *app
+	}
+	h.apps.RUnlock()
+	if !ok {
+		h.logger.Warn("Plan approval requested for non-existent application", zap.String("name", name))
+		return apierror.NotFound("Application not found")
+	}
+	if !snapshot.RequiresApproval() {
+		return apierror.ValidationFailed("Application '" + name + "' does not require approval")
+	}
+	// Check the approval against a copy; the controller approves the plan itself
+	if err := snapshot.ApprovePlan(req.PlanID, time.Now()); err != nil {
+		return apierror.Conflict(err.Error())
+	}
+
+	h.controller.ApprovePlan(name, req.PlanID)
+
+	h.logger.Info("Plan approval requested for application", zap.String("name", name), zap.String("plan", req.PlanID))
+	var message string
+	if snapshot.IsTerraform() {
+		message = "Plan " + req.PlanID + " approved. The controller will apply it with its next sync: " + snapshot.TerraformPlan.Summary
+	} else {
+		message = "Change " + req.PlanID + " approved. The controller will apply it with its next sync: " + snapshot.PendingChange.Summary
+	}
+	return c.JSON(http.StatusAccepted, SyncTriggerResponse{Message: message, Status: snapshot.Status})
+}
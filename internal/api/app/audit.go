@@ -0,0 +1,26 @@
+package app
+
+import (
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// recordAudit records a change to an application requested through the API in the audit log.
+// A failure to record it is logged rather than failing the request, which has already taken effect.
+func (h *Handler) recordAudit(c echo.Context, action audit.Action, name, reason, details string) {
+	entry := audit.Entry{
+		Actor:     auth.Actor(c),
+		Action:    action,
+		Kind:      event.KindApplication,
+		Name:      name,
+		Reason:    reason,
+		Details:   details,
+		RequestID: auth.RequestID(c),
+	}
+	if err := audit.Record(audit.DefaultAuditFile, entry); err != nil {
+		h.logger.Warn("Failed to record audit entry", zap.String("name", name), zap.String("action", string(action)), zap.Error(err))
+	}
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"net/http"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Debug handles requests to turn debug logging of a single application on or off.
+// The controller then logs the application's debug messages whatever its log level, so that one
+// misbehaving application can be traced without raising the level of all of them. The setting
+// lasts until it is turned off or the controller exits.
+func (h *Handler) Debug(c echo.Context) error {
+	name := c.Param("name")
+	req := new(DebugRequest)
+	if err := c.Bind(req); err != nil {
+		h.logger.Error("Failed to bind debug logging request", zap.Error(err))
+		return apierror.ValidationFailed("Invalid request payload")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	h.apps.RLock()
+	app, ok := h.apps.Get(name)
+	var status appcore.Status
+	if ok {
+		status = app.Status
+	}
+	h.apps.RUnlock()
+	if !ok {
+		h.logger.Warn("Debug logging requested for non-existent application", zap.String("name", name))
+		return apierror.NotFound("Application not found")
+	}
+
+	h.controller.SetAppDebug(name, *req.Enabled)
+	h.logger.Info("Debug logging requested for application",
+		zap.String("name", name),
+		zap.Bool("enabled", *req.Enabled),
+		zap.String("actor", auth.Actor(c)))
+
+	message := "Debug logging turned off."
+	if *req.Enabled {
+		message = "Debug logging turned on until it is turned off or the controller restarts."
+	}
+	return c.JSON(http.StatusOK, SyncTriggerResponse{
+		Message: message,
+		Status:  status,
+	})
+}
@@ -2,18 +2,33 @@ package app
 
 import (
 	"net/http"
+	"strings"
 
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/common"
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
 	"github.com/labstack/echo/v4"
 )
 
 // List handles the retrieval of all registered applications.
-// It returns a list of Response objects containing the details of each application.
+// It returns a list of Response objects containing the details of each application. The q query
+// parameter keeps those whose name, repository URL or message contains it, ignoring case, and the
+// status query parameter, such as "error,pending" or "!synced", those with a matching status.
 func (h *Handler) List(c echo.Context) error {
+	q := strings.TrimSpace(c.QueryParam("q"))
+	filter, err := common.ParseStatusFilter(c.QueryParam("status"), appcore.Statuses)
+	if err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+
 	h.apps.RLock()
 	defer h.apps.RUnlock()
 
-	var responses []Response
+	responses := []Response{}
 	for _, app := range h.apps.List() {
+		if !filter.Matches(string(app.Status)) || !app.MatchesSearch(q) {
+			continue
+		}
 		responses = append(responses, ConvertToResponse(app))
 	}
 	return c.JSON(http.StatusOK, responses)
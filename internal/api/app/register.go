@@ -1,11 +1,22 @@
 package app
 
 import (
+	"context"
 	"net/http"
+	"reflect"
 	"strings"
 	"time"
 
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/common"
 	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
+	clustercore "aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/git"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/lint"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
+	"aeswibon.com/github/gitopsctl/internal/core/terraform"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
@@ -18,76 +29,339 @@ func (h *Handler) Register(c echo.Context) error {
 	req := new(RegisterRequest)
 	if err := c.Bind(req); err != nil {
 		h.logger.Error("Failed to bind register application request", zap.Error(err))
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+		return apierror.ValidationFailed("Invalid request payload")
 	}
 	if err := c.Validate(req); err != nil {
 		h.logger.Error("Failed to validate register application request", zap.Error(err))
 		return err
 	}
 
-	req.Path = strings.TrimPrefix(strings.TrimSuffix(req.Path, "/"), "/")
+	req.Name = strings.TrimSpace(req.Name)
+	if err := common.ValidateName(req.Name); err != nil {
+		return apierror.ValidationFailed("Invalid application name: " + err.Error())
+	}
+	req.Branch = strings.TrimSpace(req.Branch)
+	if req.Branch == "" {
+		req.Branch = h.detectDefaultBranch(c.Request().Context(), req.RepoURL)
+	}
+	if err := common.ValidateBranch(req.Branch); err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+	path, err := common.NormalizeRepoPath(req.Path)
+	if err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+	req.Path = path
+	if len(req.Paths) > 0 {
+		if req.Type == appcore.TypeTerraform {
+			return apierror.ValidationFailed("paths cannot be used with type terraform")
+		}
+		if req.Renderer != "" && req.Renderer != render.RendererYAML {
+			return apierror.ValidationFailed("paths can only be used with plain YAML manifests")
+		}
+		if req.Paths, err = render.NormalizeSourcePaths(req.Paths); err != nil {
+			return apierror.ValidationFailed(err.Error())
+		}
+	}
+	pollingInterval, err := common.ParsePollingInterval(req.Interval)
+	if err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+	if req.MaxInterval != "" {
+		if _, err := common.ParseMaxPollingInterval(req.MaxInterval, pollingInterval); err != nil {
+			return apierror.ValidationFailed(err.Error())
+		}
+	}
+	if req.Renderer == render.RendererYAML {
+		req.Renderer = ""
+	}
+	if req.ApplyStrategy == k8s.ApplyStrategyBestEffort {
+		req.ApplyStrategy = ""
+	}
+	if err := k8s.ValidateExcludePatterns(req.Exclude); err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+	plugins, err := render.LoadPlugins(render.DefaultPluginConfigFile)
+	if err != nil {
+		h.logger.Error("Failed to load manifest generator plugins", zap.Error(err))
+		return apierror.Internal("Failed to load manifest generator plugins")
+	}
+	if !plugins.IsValidRenderer(req.Renderer) {
+		return apierror.ValidationFailed("Unknown renderer '" + req.Renderer + "'")
+	}
+	if _, isPlugin := plugins.Get(req.Renderer); !isPlugin && len(req.PluginParams) > 0 {
+		return apierror.ValidationFailed("plugin_params can only be used when renderer names a plugin")
+	}
+	if req.Type == appcore.TypeKubernetes {
+		req.Type = ""
+	}
+	if req.SyncMode == appcore.SyncModeApply {
+		req.SyncMode = ""
+	}
+	if req.HealthTimeout != "" {
+		if req.SyncMode != appcore.SyncModeTwoPhase {
+			return apierror.ValidationFailed("health_timeout can only be used when sync_mode is two-phase")
+		}
+		if timeout, err := time.ParseDuration(req.HealthTimeout); err != nil || timeout <= 0 {
+			return apierror.ValidationFailed("Invalid health_timeout '" + req.HealthTimeout + "': must be a positive duration such as 5m")
+		}
+	}
+	var terraformSource *appcore.TerraformSource
+	if req.Type == appcore.TypeTerraform {
+		if req.ClusterName != "" || req.Renderer != "" || len(req.PluginParams) > 0 || req.Helm != nil || req.ApplyStrategy != "" || len(req.Exclude) > 0 ||
+			req.ApprovalRequired || req.SyncMode != "" || req.HealthTimeout != "" {
+			return apierror.ValidationFailed("cluster_name, renderer, plugin_params, helm, apply_strategy, exclude, approval_required, sync_mode and health_timeout cannot be used with type terraform")
+		}
+		if req.Terraform != nil {
+			if err := terraform.ValidateVarFiles(req.Terraform.VarFiles); err != nil {
+				return apierror.ValidationFailed(err.Error())
+			}
+			if req.Terraform.Binary == terraform.BinaryTerraform {
+				req.Terraform.Binary = ""
+			}
+			terraformSource = &appcore.TerraformSource{Binary: req.Terraform.Binary, VarFiles: req.Terraform.VarFiles, Vars: req.Terraform.Vars}
+		}
+	} else if req.Terraform != nil {
+		return apierror.ValidationFailed("terraform can only be used when type is terraform")
+	}
+	if req.Type == appcore.TypeTerraform && (req.Substitute || len(req.Variables) > 0 || req.ConfigHash || req.DefaultNamespace != "" || req.RequireNamespace ||
+		req.ImpersonateUser != "" || len(req.ImpersonateGroups) > 0) {
+		return apierror.ValidationFailed("substitute, variables, config_hash, default_namespace, require_namespace and impersonate_* cannot be used with type terraform")
+	}
+	if err := k8s.ValidateImpersonation(req.ImpersonateUser, req.ImpersonateGroups); err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+	if err := common.ValidateLabels(req.Labels); err != nil {
+		return apierror.ValidationFailed("Invalid labels: " + err.Error())
+	}
+	if req.ClusterName == "" && len(req.ClusterSelector) == 0 && req.Type != appcore.TypeTerraform {
+		return apierror.ValidationFailed("cluster_name or cluster_selector is required")
+	}
+	var placement *appcore.Placement
+	if len(req.ClusterSelector) > 0 {
+		if req.ClusterName != "" || req.Type == appcore.TypeTerraform {
+			return apierror.ValidationFailed("cluster_selector cannot be used with cluster_name or type terraform")
+		}
+		if err := common.ValidateLabels(req.ClusterSelector); err != nil {
+			return apierror.ValidationFailed("Invalid cluster_selector: " + err.Error())
+		}
+		placement = &appcore.Placement{ClusterSelector: req.ClusterSelector}
+	}
+	var lintConfig *appcore.LintConfig
+	if req.Lint != nil {
+		if req.Type == appcore.TypeTerraform {
+			return apierror.ValidationFailed("lint cannot be used with type terraform")
+		}
+		if err := lint.ValidateLinters(req.Lint.Linters); err != nil {
+			return apierror.ValidationFailed("Invalid lint: " + err.Error())
+		}
+		lintConfig = &appcore.LintConfig{Linters: req.Lint.Linters, Strict: req.Lint.Strict}
+	}
+	if req.Type == appcore.TypeTerraform && req.APIDeprecationPolicy != "" {
+		return apierror.ValidationFailed("api_deprecation_policy cannot be used with type terraform")
+	}
+	deprecationPolicy := req.APIDeprecationPolicy
+	if deprecationPolicy == appcore.APIDeprecationWarn {
+		deprecationPolicy = ""
+	}
+	if req.DefaultNamespace != "" {
+		if err := common.ValidateName(req.DefaultNamespace); err != nil {
+			return apierror.ValidationFailed("Invalid default_namespace: " + err.Error())
+		}
+		if req.RequireNamespace {
+			return apierror.ValidationFailed("default_namespace and require_namespace cannot be used together")
+		}
+	}
+	if err := render.ValidateVariables(req.Variables); err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+	var sources []appcore.Source
+	if len(req.Sources) > 0 {
+		if req.Type == appcore.TypeTerraform {
+			return apierror.ValidationFailed("sources cannot be used with type terraform")
+		}
+		for _, source := range req.Sources {
+			if strings.TrimSpace(source.Branch) == "" {
+				source.Branch = h.detectDefaultBranch(c.Request().Context(), strings.TrimSpace(source.RepoURL))
+			}
+			sources = append(sources, appcore.Source{Name: source.Name, RepoURL: source.RepoURL, Branch: source.Branch, Path: source.Path})
+		}
+		if sources, err = appcore.NormalizeSources(sources); err != nil {
+			return apierror.ValidationFailed(err.Error())
+		}
+		names := make([]string, len(sources))
+		for i, source := range sources {
+			if source.Path != "" && req.Renderer != "" {
+				return apierror.ValidationFailed("Source '" + source.Name + "' has a path, which can only be used with plain YAML manifests")
+			}
+			names[i] = source.Name
+		}
+		if req.Helm != nil {
+			if err := render.ValidateValuesFileSources(req.Helm.ValuesFiles, names); err != nil {
+				return apierror.ValidationFailed(err.Error())
+			}
+		}
+	}
+	var helm *appcore.HelmSource
+	if req.Helm != nil && (len(req.Helm.ValuesFiles) > 0 || len(req.Helm.Values) > 0 || req.Helm.Namespace != "" || req.Helm.RecordRelease) {
+		if req.Renderer != render.RendererHelm {
+			return apierror.ValidationFailed("helm can only be used when renderer is helm")
+		}
+		if err := render.ValidateValuesFiles(req.Helm.ValuesFiles); err != nil {
+			return apierror.ValidationFailed(err.Error())
+		}
+		if req.Helm.Namespace != "" {
+			if err := common.ValidateName(req.Helm.Namespace); err != nil {
+				return apierror.ValidationFailed("Invalid helm namespace: " + err.Error())
+			}
+		}
+		helm = &appcore.HelmSource{
+			ValuesFiles:   req.Helm.ValuesFiles,
+			Values:        req.Helm.Values,
+			Namespace:     req.Helm.Namespace,
+			RecordRelease: req.Helm.RecordRelease,
+		}
+	}
 
-	// Validate the referenced cluster exists
+	verifyRepo, err := boolQueryParam(c, "verify_repo")
+	if err != nil {
+		return err
+	}
+	if verifyRepo {
+		ctx, cancel := context.WithTimeout(c.Request().Context(), git.VerifyTimeout)
+		err := git.VerifyRepository(ctx, req.RepoURL, req.Branch, req.Path)
+		cancel()
+		if err != nil {
+			h.logger.Error("Repository verification failed",
+				zap.String("repoURL", req.RepoURL),
+				zap.String("branch", req.Branch),
+				zap.String("path", req.Path),
+				zap.Error(err))
+			return apierror.FromGit("Repository verification failed", err)
+		}
+	}
+
+	// Validate the referenced cluster exists; Terraform applications are not deployed to a cluster
 	h.clusters.RLock()
 	defer h.clusters.RUnlock()
+	if placement != nil {
+		target, err := h.placeApplication(req.Name, placement.ClusterSelector)
+		if err != nil {
+			return apierror.ValidationFailed(err.Error())
+		}
+		req.ClusterName = target
+	}
 	_, exists := h.clusters.Get(req.ClusterName)
-	if !exists {
+	if !exists && req.Type != appcore.TypeTerraform {
 		h.logger.Error("Cluster not found for application registration", zap.String("cluster", req.ClusterName))
-		return echo.NewHTTPError(http.StatusBadRequest, "Cluster '"+req.ClusterName+"' not found")
+		return apierror.ValidationFailed("Cluster '" + req.ClusterName + "' not found")
 	}
 
-	// Lock the applications map for modification
-	h.apps.Lock()
-	defer h.apps.Unlock()
-
-	// Check if app already exists to decide between add/update
-	existingApp, exists := h.apps.Get(req.Name)
+	h.apps.RLock()
+	_, exists = h.apps.Get(req.Name)
+	h.apps.RUnlock()
 	if exists {
 		h.logger.Warn("Application with this name already exists. Updating it.", zap.String("name", req.Name))
-		// Update existing application details
-		existingApp.RepoURL = req.RepoURL
-		existingApp.Branch = req.Branch
-		existingApp.Path = req.Path
-		existingApp.ClusterName = req.ClusterName
-		existingApp.Interval = req.Interval
-		parsedInterval, err := time.ParseDuration(req.Interval)
-		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Invalid interval format: "+err.Error())
-		}
-		existingApp.PollingInterval = parsedInterval
-		// Reset status/message/failures on update, assuming it's a re-registration
-		existingApp.Status = "Pending"
-		existingApp.Message = "Application updated, awaiting next sync."
-		existingApp.ConsecutiveFailures = 0
-
-	} else {
-		// Create new application
-		parsedInterval, err := time.ParseDuration(req.Interval)
-		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Invalid interval format: "+err.Error())
-		}
-		newApp := &appcore.Application{
-			Name:                req.Name,
-			RepoURL:             req.RepoURL,
-			Branch:              req.Branch,
-			Path:                req.Path,
-			ClusterName:         req.ClusterName,
-			Interval:            req.Interval,
-			PollingInterval:     parsedInterval,
-			Status:              "Pending",
-			Message:             "Application registered, awaiting first sync.",
-			ConsecutiveFailures: 0,
-		}
-		h.apps.Add(newApp)
 	}
 
-	if err := appcore.SaveApplications(h.apps, appcore.DefaultAppConfigFile); err != nil {
+	// The controller persists the registration, resetting the status of an existing application
+	spec := &appcore.Application{
+		Name:                 req.Name,
+		Labels:               req.Labels,
+		RepoURL:              req.RepoURL,
+		Branch:               req.Branch,
+		Path:                 req.Path,
+		Paths:                req.Paths,
+		Sources:              sources,
+		Substitute:           req.Substitute,
+		Variables:            req.Variables,
+		ConfigHash:           req.ConfigHash,
+		Submodules:           req.Submodules,
+		LFS:                  req.LFS,
+		ClusterName:          req.ClusterName,
+		Placement:            placement,
+		Type:                 req.Type,
+		Terraform:            terraformSource,
+		Interval:             req.Interval,
+		MaxInterval:          req.MaxInterval,
+		Renderer:             req.Renderer,
+		PluginParams:         req.PluginParams,
+		Helm:                 helm,
+		ApplyStrategy:        req.ApplyStrategy,
+		Exclude:              req.Exclude,
+		DefaultNamespace:     req.DefaultNamespace,
+		RequireNamespace:     req.RequireNamespace,
+		ImpersonateUser:      req.ImpersonateUser,
+		ImpersonateGroups:    req.ImpersonateGroups,
+		Lint:                 lintConfig,
+		APIDeprecationPolicy: deprecationPolicy,
+		ApprovalRequired:     req.ApprovalRequired,
+		SyncMode:             req.SyncMode,
+		HealthTimeout:        req.HealthTimeout,
+		PollingInterval:      pollingInterval,
+		Status:               appcore.StatusPending,
+		Message:              "Application registered, awaiting first sync.",
+		ConsecutiveFailures:  0,
+	}
+	if err := h.controller.RegisterApp(spec); err != nil {
 		h.logger.Error("Failed to save applications after registration", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save application configuration")
+		return apierror.Internal("Failed to save application configuration")
 	}
 
-	h.controller.StartApp(req.Name)
+	// A standalone API server keeps its own copy of the state; mirror the registration until its next refresh
+	h.apps.Lock()
+	if existing, ok := h.apps.Get(req.Name); !ok {
+		h.apps.Add(spec)
+	} else if !reflect.DeepEqual(existing.Spec(), spec.Spec()) {
+		existing.Reregister(spec)
+	}
+	h.apps.Unlock()
+
+	h.recordAudit(c, audit.ActionRegister, req.Name, req.Reason, "Repository "+req.RepoURL+", path "+req.Path)
 
 	h.logger.Info("Application registered/updated via API", zap.String("name", req.Name))
 	return c.JSON(http.StatusOK, map[string]string{"message": "Application registered/updated successfully", "name": req.Name})
 }
+
+// detectDefaultBranch returns the branch the repository's HEAD points to, falling back to
+// git.FallbackBranch when the remote cannot be reached.
+func (h *Handler) detectDefaultBranch(ctx context.Context, repoURL string) string {
+	ctx, cancel := context.WithTimeout(ctx, git.DefaultBranchTimeout)
+	defer cancel()
+
+	branch, err := git.DefaultBranch(ctx, repoURL)
+	if err != nil {
+		h.logger.Warn("Failed to detect the repository's default branch, using the fallback",
+			zap.String("repoURL", repoURL),
+			zap.String("branch", git.FallbackBranch),
+			zap.Error(err))
+		return git.FallbackBranch
+	}
+	return branch
+}
+
+// placeApplication chooses the cluster of an application registered with a cluster selector, as
+// the controller does. A re-registered application stays on its cluster while that cluster still
+// matches and is healthy. The caller must hold the read lock of the clusters.
+func (h *Handler) placeApplication(appName string, selector map[string]string) (string, error) {
+	h.apps.RLock()
+	placed := make(map[string]int)
+	current := ""
+	for _, a := range h.apps.List() {
+		if a.Name == appName {
+			current = a.ClusterName
+		} else if a.ClusterName != "" {
+			placed[a.ClusterName]++
+		}
+	}
+	h.apps.RUnlock()
+
+	if cl, ok := h.clusters.Get(current); ok && cl.Matches(selector) && cl.Status.Placeable() {
+		return cl.Name, nil
+	}
+	target, err := clustercore.Place(h.clusters.List(), selector, placed)
+	if err != nil {
+		return "", err
+	}
+	return target.Name, nil
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Rename handles renaming an application.
+//
+// The controller stops the application's reconciliation loop, renames and persists it, and starts
+// the loop again under the new name, keeping its sync history and events.
+// It returns 404 if the application does not exist and 409 if the new name is already taken.
+func (h *Handler) Rename(c echo.Context) error {
+	name := c.Param("name")
+
+	req := new(RenameRequest)
+	if err := c.Bind(req); err != nil {
+		h.logger.Error("Failed to bind rename application request", zap.Error(err))
+		return apierror.ValidationFailed("Invalid request payload")
+	}
+	if err := c.Validate(req); err != nil {
+		h.logger.Error("Failed to validate rename application request", zap.Error(err))
+		return err
+	}
+	newName := strings.TrimSpace(req.Name)
+	if err := common.ValidateName(newName); err != nil {
+		return apierror.ValidationFailed("Invalid application name: " + err.Error())
+	}
+	if newName == name {
+		return apierror.ValidationFailed("New name must differ from the current name")
+	}
+
+	h.apps.RLock()
+	_, exists := h.apps.Get(name)
+	_, taken := h.apps.Get(newName)
+	h.apps.RUnlock()
+	if !exists {
+		return apierror.NotFound("Application not found")
+	}
+	if taken {
+		return apierror.Conflict("Application '" + newName + "' already exists")
+	}
+
+	if err := h.controller.RenameApp(name, newName); err != nil {
+		h.logger.Error("Failed to rename application", zap.String("name", name), zap.String("new_name", newName), zap.Error(err))
+		return apierror.Internal("Failed to rename application: " + err.Error())
+	}
+
+	// A standalone API server keeps its own copy of the state; mirror the rename until its next refresh
+	h.apps.Lock()
+	defer h.apps.Unlock()
+	if _, ok := h.apps.Get(name); ok {
+		_ = h.apps.Rename(name, newName)
+	}
+	renamed, ok := h.apps.Get(newName)
+	if !ok {
+		return apierror.NotFound("Application not found")
+	}
+
+	h.logger.Info("Application renamed via API", zap.String("name", name), zap.String("new_name", newName))
+	return c.JSON(http.StatusOK, ConvertToResponse(renamed))
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"github.com/labstack/echo/v4"
+)
+
+// Repositories handles requests for the repositories of all applications with their fetch and size
+// statistics, slowest to fetch first. Applications whose repository has not been fetched yet are omitted.
+func (h *Handler) Repositories(c echo.Context) error {
+	h.apps.RLock()
+	defer h.apps.RUnlock()
+
+	apps := slices.DeleteFunc(h.apps.List(), func(app *appcore.Application) bool { return app.Repository == nil })
+	slices.SortFunc(apps, func(a, b *appcore.Application) int {
+		return cmp.Or(
+			cmp.Compare(b.Repository.LastFetchDuration(), a.Repository.LastFetchDuration()),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	responses := make([]RepositoryResponse, 0, len(apps))
+	for _, app := range apps {
+		responses = append(responses, RepositoryResponse{
+			Application:     app.Name,
+			RepoURL:         app.RepoURL,
+			Branch:          app.Branch,
+			RepositoryStats: *convertRepositoryStats(app.Repository),
+		})
+	}
+	return c.JSON(http.StatusOK, responses)
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"net/http"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// ResetFailures handles requests to clear an application's consecutive failures.
+// It asks the controller to end the application's backoff so that it resumes polling at its
+// normal interval, typically after an operator has fixed the cause of the failures.
+func (h *Handler) ResetFailures(c echo.Context) error {
+	name := c.Param("name")
+
+	h.apps.RLock()
+	app, ok := h.apps.Get(name)
+	var failures int
+	if ok {
+		failures = app.ConsecutiveFailures
+	}
+	h.apps.RUnlock()
+	if !ok {
+		h.logger.Warn("Failure reset requested for non-existent application", zap.String("name", name))
+		return apierror.NotFound("Application not found")
+	}
+
+	h.controller.ResetFailures(name)
+
+	h.logger.Info("Failure reset requested for application", zap.String("name", name), zap.Int("failures", failures))
+	return c.JSON(http.StatusAccepted, SyncTriggerResponse{
+		Message: "Failure reset requested. The application will resume polling at its normal interval.",
+		Status:  app.Status,
+	})
+}
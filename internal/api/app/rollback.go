@@ -0,0 +1,59 @@
+package app
+
+import (
+	"net/http"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Rollback handles requests to roll an application back to the healthy revision synced before its
+// last one, which two-phase syncs keep. The revision rolled back from is not applied again until the
+// application's failures are reset. Applications without a previous revision, or that are suspended,
+// return a 409 Conflict error. An optional reason is recorded with the requesting user in the audit log.
+func (h *Handler) Rollback(c echo.Context) error {
+	name := c.Param("name")
+	req := new(ReasonRequest)
+	if err := c.Bind(req); err != nil {
+		h.logger.Error("Failed to bind rollback request", zap.Error(err))
+		return apierror.ValidationFailed("Invalid request payload")
+	}
+
+	h.apps.RLock()
+	app, ok := h.apps.Get(name)
+	var status appcore.Status
+	var fromHash, toHash string
+	if ok {
+		status, fromHash, toHash = app.Status, app.LastSyncedGitHash, app.RollbackGitHash
+	}
+	h.apps.RUnlock()
+	if !ok {
+		h.logger.Warn("Rollback requested for non-existent application", zap.String("name", name))
+		return apierror.NotFound("Application not found")
+	}
+	if status == appcore.StatusSuspended {
+		return apierror.Conflict("Application is suspended; resume it before rolling it back")
+	}
+	if toHash == "" {
+		return apierror.Conflict("Application has no previous revision to roll back to; only two-phase syncs keep one")
+	}
+
+	trigger := appcore.Trigger{Actor: auth.Actor(c), Reason: req.Reason, RequestID: auth.RequestID(c)}
+	h.controller.RollbackApp(name, trigger)
+	h.recordAudit(c, audit.ActionRollback, name, req.Reason, "Rolled back from "+fromHash+" to "+toHash)
+
+	h.logger.Info("Rollback requested for application",
+		zap.String("name", name),
+		zap.String("from", fromHash),
+		zap.String("to", toHash),
+		zap.String("actor", trigger.Actor),
+		zap.String("requestID", trigger.RequestID))
+	return c.JSON(http.StatusAccepted, SyncTriggerResponse{
+		Message: "Rollback to " + toHash + " requested. Reset the application's failures to apply " + fromHash + " again.",
+		Status:  status,
+	})
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/progressive"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// PromoteRollout handles requests to promote a canary rollout of an application to its next step,
+// or with "full" past all remaining steps.
+// The request names the Argo Rollouts Rollout or Flagger Canary among the application's managed
+// resources, unless the application manages only one. The controller refreshes the rollout's
+// progress with a sync, which it starts immediately.
+func (h *Handler) PromoteRollout(c echo.Context) error {
+	return h.updateRollout(c, "promote", "promoted", func(ctx context.Context, client *k8s.ClientSet, ref k8s.ResourceRef, req *RolloutRequest) error {
+		return client.PromoteRollout(ctx, ref, req.Full)
+	})
+}
+
+// AbortRollout handles requests to abort a canary rollout of an application, shifting all traffic
+// back to the stable version. Only Argo Rollouts Rollouts can be aborted; aborting a Flagger
+// Canary returns a 400 Bad Request error. Aborts are recorded in the audit log as rollbacks.
+func (h *Handler) AbortRollout(c echo.Context) error {
+	return h.updateRollout(c, "abort", "aborted", func(ctx context.Context, client *k8s.ClientSet, ref k8s.ResourceRef, _ *RolloutRequest) error {
+		return client.AbortRollout(ctx, ref)
+	})
+}
+
+// updateRollout resolves the rollout named by the request and updates it in the application's cluster.
+func (h *Handler) updateRollout(c echo.Context, action, done string,
+	update func(ctx context.Context, client *k8s.ClientSet, ref k8s.ResourceRef, req *RolloutRequest) error) error {
+	name := c.Param("name")
+	req := new(RolloutRequest)
+	if err := c.Bind(req); err != nil {
+		h.logger.Error("Failed to bind rollout request", zap.Error(err))
+		return apierror.ValidationFailed("Invalid request payload")
+	}
+
+	h.apps.RLock()
+	existing, ok := h.apps.Get(name)
+	var snapshot appcore.Application
+	if ok {
+		snapshot = *existing
+	}
+	h.apps.RUnlock()
+	if !ok {
+		return apierror.NotFound("Application not found")
+	}
+
+	ref, err := progressive.Find(&snapshot, req.Rollout)
+	if err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+	if action == "abort" && k8s.RolloutProvider(ref.Kind) == k8s.RolloutProviderFlagger {
+		return apierror.ValidationFailed("Flagger Canaries cannot be aborted; Flagger rolls them back when their analysis fails")
+	}
+	client, err := h.clientFor(&snapshot, "its rollouts cannot be updated")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), RolloutTimeout)
+	defer cancel()
+	if err := update(ctx, client, ref, req); err != nil {
+		h.logger.Error("Failed to "+action+" rollout", zap.String("name", name), zap.String("rollout", ref.String()), zap.Error(err))
+		return apierror.FromK8s("Failed to "+action+" "+ref.String(), err)
+	}
+
+	if action == "abort" {
+		h.recordAudit(c, audit.ActionRollback, name, req.Reason, "Aborted "+ref.String())
+	}
+	h.controller.TriggerSync(name, appcore.Trigger{Actor: auth.Actor(c), Reason: req.Reason, RequestID: auth.RequestID(c)})
+
+	h.logger.Info("Rollout updated via API", zap.String("name", name), zap.String("action", action), zap.String("rollout", ref.String()))
+	return c.JSON(http.StatusOK, RolloutResponse{
+		Message: ref.String() + " " + done + ". Its progress is refreshed with the application's next sync.",
+		Rollout: ResourceResponse{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name},
+	})
+}
@@ -1,8 +1,10 @@
 package app
 
 import (
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
 	"aeswibon.com/github/gitopsctl/internal/controller"
 	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	authcore "aeswibon.com/github/gitopsctl/internal/core/auth"
 	clustercore "aeswibon.com/github/gitopsctl/internal/core/cluster"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -13,11 +15,11 @@ type Handler struct {
 	logger     *zap.Logger
 	apps       *appcore.Applications
 	clusters   *clustercore.Clusters
-	controller *controller.Controller
+	controller controller.Commander
 }
 
 // NewHandler creates a new application handler.
-func NewHandler(logger *zap.Logger, apps *appcore.Applications, clusters *clustercore.Clusters, controller *controller.Controller) *Handler {
+func NewHandler(logger *zap.Logger, apps *appcore.Applications, clusters *clustercore.Clusters, controller controller.Commander) *Handler {
 	return &Handler{
 		logger:     logger,
 		apps:       apps,
@@ -29,9 +31,21 @@ func NewHandler(logger *zap.Logger, apps *appcore.Applications, clusters *cluste
 // RegisterRoutes registers all application-related routes.
 func RegisterRoutes(g *echo.Group, handler *Handler) {
 	// Applications Management
-	g.POST("/applications", handler.Register)
-	g.GET("/applications", handler.List)
-	g.GET("/applications/:name", handler.Get)
-	g.DELETE("/applications/:name", handler.Unregister)
-	g.POST("/applications/:name/sync", handler.Sync)
+	g.POST("/applications", handler.Register, auth.Require(authcore.PermissionAppWrite))
+	g.GET("/applications", handler.List, auth.Require(authcore.PermissionAppRead))
+	g.GET("/applications/:name", handler.Get, auth.Require(authcore.PermissionAppRead))
+	g.DELETE("/applications/:name", handler.Unregister, auth.Require(authcore.PermissionAppWrite))
+	g.PATCH("/applications/:name", handler.Rename, auth.Require(authcore.PermissionAppWrite))
+	g.POST("/applications/:name/sync", handler.Sync, auth.Require(authcore.PermissionAppSync))
+	g.POST("/applications/:name/reset-failures", handler.ResetFailures, auth.Require(authcore.PermissionAppSync))
+	g.POST("/applications/:name/resume", handler.ResetFailures, auth.Require(authcore.PermissionAppSync))
+	g.POST("/applications/:name/suspend", handler.Suspend, auth.Require(authcore.PermissionAppSync))
+	g.POST("/applications/:name/debug", handler.Debug, auth.Require(authcore.PermissionAppSync))
+	g.POST("/applications/:name/approve", handler.Approve, auth.Require(authcore.PermissionAppSync))
+	g.POST("/applications/:name/rollback", handler.Rollback, auth.Require(authcore.PermissionAppSync))
+	g.POST("/applications/:name/rollouts/promote", handler.PromoteRollout, auth.Require(authcore.PermissionAppSync))
+	g.POST("/applications/:name/rollouts/abort", handler.AbortRollout, auth.Require(authcore.PermissionAppSync))
+
+	// Repositories
+	g.GET("/repositories", handler.Repositories, auth.Require(authcore.PermissionAppRead))
 }
@@ -3,6 +3,7 @@ package app
 import (
 	"net/http"
 
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
 	"github.com/labstack/echo/v4"
 )
 
@@ -17,7 +18,7 @@ func (h *Handler) Get(c echo.Context) error {
 
 	app, ok := h.apps.Get(name)
 	if !ok {
-		return echo.NewHTTPError(http.StatusNotFound, "Application not found")
+		return apierror.NotFound("Application not found")
 	}
 	return c.JSON(http.StatusOK, ConvertToResponse(app))
 }
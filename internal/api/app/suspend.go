@@ -0,0 +1,53 @@
+package app
+
+import (
+	"net/http"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Suspend handles requests to suspend an application's reconciliation.
+// The controller completes any sync in progress and then stops reconciling the application, including
+// across restarts, until its failures are reset. An optional reason is recorded with the requesting
+// user in the audit log.
+func (h *Handler) Suspend(c echo.Context) error {
+	name := c.Param("name")
+	req := new(ReasonRequest)
+	if err := c.Bind(req); err != nil {
+		h.logger.Error("Failed to bind suspend request", zap.Error(err))
+		return apierror.ValidationFailed("Invalid request payload")
+	}
+
+	h.apps.RLock()
+	app, ok := h.apps.Get(name)
+	var status appcore.Status
+	if ok {
+		status = app.Status
+	}
+	h.apps.RUnlock()
+	if !ok {
+		h.logger.Warn("Suspension requested for non-existent application", zap.String("name", name))
+		return apierror.NotFound("Application not found")
+	}
+	if status == appcore.StatusSuspended {
+		return apierror.Conflict("Application is already suspended")
+	}
+
+	trigger := appcore.Trigger{Actor: auth.Actor(c), Reason: req.Reason, RequestID: auth.RequestID(c)}
+	h.controller.SuspendApp(name, trigger)
+	h.recordAudit(c, audit.ActionSuspend, name, req.Reason, "")
+
+	h.logger.Info("Suspension requested for application",
+		zap.String("name", name),
+		zap.String("actor", trigger.Actor),
+		zap.String("requestID", trigger.RequestID))
+	return c.JSON(http.StatusAccepted, SyncTriggerResponse{
+		Message: "Suspension requested. Reset the application's failures to resume reconciliation.",
+		Status:  status,
+	})
+}
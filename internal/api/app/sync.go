@@ -1,18 +1,39 @@
 package app
 
 import (
+	"fmt"
 	"net/http"
 
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
 
 // Sync handles manual sync requests for an application.
 // It updates the application's status to "SyncRequested" and logs the request.
+// Suspended applications must be reset before they can be synced, so the request is refused with a conflict.
+// An optional reason is recorded with the requesting user in the application's sync history and the audit log.
 // This is a placeholder for triggering an immediate sync, which would typically involve signaling the controller
 // to wake up the specific application's goroutine and perform a sync now.
+// With the query parameter dryRun=server, the sync is dry-run instead (see dryRunSync).
 func (h *Handler) Sync(c echo.Context) error {
 	name := c.Param("name")
+	switch dryRun := c.QueryParam("dryRun"); dryRun {
+	case "":
+	case "server":
+		return h.dryRunSync(c, name)
+	default:
+		return apierror.ValidationFailed(fmt.Sprintf("Invalid dryRun '%s', expected 'server'", dryRun))
+	}
+	req := new(ReasonRequest)
+	if err := c.Bind(req); err != nil {
+		h.logger.Error("Failed to bind sync request", zap.Error(err))
+		return apierror.ValidationFailed("Invalid request payload")
+	}
+	trigger := appcore.Trigger{Actor: auth.Actor(c), Reason: req.Reason, RequestID: auth.RequestID(c)}
 
 	h.apps.Lock()
 	defer h.apps.Unlock()
@@ -20,17 +41,55 @@ func (h *Handler) Sync(c echo.Context) error {
 	app, ok := h.apps.Get(name)
 	if !ok {
 		h.logger.Warn("Manual sync requested for non-existent application", zap.String("name", name))
-		return echo.NewHTTPError(http.StatusNotFound, "Application not found")
+		return apierror.NotFound("Application not found")
 	}
 
-	h.controller.TriggerSync(name)
+	if _, err := app.SetStatus(appcore.StatusSyncRequested, "Manual sync requested."); err != nil {
+		h.logger.Warn("Manual sync refused", zap.String("name", name), zap.Error(err))
+		return apierror.Conflict(fmt.Sprintf("Application is %s; reset its failures to resume reconciliation", app.Status))
+	}
+	h.controller.TriggerSync(name, trigger)
+	h.recordAudit(c, audit.ActionSync, name, req.Reason, "")
 
-	app.Status = "SyncRequested"
-	app.Message = "Manual sync requested."
 	// No need to save to disk here, controller's next loop or signal will handle it.
-	h.logger.Info("Manual sync requested for application", zap.String("name", name))
+	h.logger.Info("Manual sync requested for application",
+		zap.String("name", name),
+		zap.String("actor", trigger.Actor),
+		zap.String("requestID", trigger.RequestID))
 	return c.JSON(http.StatusAccepted, SyncTriggerResponse{
 		Message: "Manual sync requested. The controller will process it shortly.",
-		Status:  "SyncRequested",
+		Status:  appcore.StatusSyncRequested,
 	})
 }
+
+// dryRunSync handles dry-run sync requests for an application.
+// The controller renders the manifests of the latest revision and submits them to the cluster as
+// server-side dry runs, and the response reports what a sync would do to each resource. Neither the
+// cluster nor the application's status is changed. Resources that would fail to apply are reported
+// in the response, which is still successful.
+func (h *Handler) dryRunSync(c echo.Context, name string) error {
+	h.apps.RLock()
+	app, ok := h.apps.Get(name)
+	terraform := ok && app.IsTerraform()
+	h.apps.RUnlock()
+	if !ok {
+		return apierror.NotFound("Application not found")
+	}
+	if terraform {
+		return apierror.ValidationFailed("Dry runs are not supported for Terraform applications; see the pending plan instead")
+	}
+
+	trigger := appcore.Trigger{Actor: auth.Actor(c), RequestID: auth.RequestID(c)}
+	report, err := h.controller.DryRunSync(name, trigger)
+	if err != nil {
+		h.logger.Error("Dry run of sync failed", zap.String("name", name), zap.Error(err))
+		return apierror.Upstream("Dry run failed: " + err.Error())
+	}
+
+	h.logger.Info("Dry run of sync completed for application",
+		zap.String("name", name),
+		zap.String("revision", report.Revision),
+		zap.String("actor", trigger.Actor),
+		zap.String("requestID", trigger.RequestID))
+	return c.JSON(http.StatusOK, ConvertToDryRunResponse(report))
+}
@@ -1,24 +1,288 @@
 package app
 
 import (
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/controller"
 	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
 )
 
+// CascadeTimeout is how long an unregister request may spend deleting managed resources from the cluster.
+const CascadeTimeout = 2 * time.Minute
+
+// RolloutTimeout is how long a promote or abort request may spend updating a rollout in the cluster.
+const RolloutTimeout = 30 * time.Second
+
 // RegisterRequest represents the request payload for registering an application.
 // This structure is used in the API requests to register a new application with the GitOps controller.
 type RegisterRequest struct {
 	// Name is the unique identifier for the application.
 	Name string `json:"name" validate:"required"`
+	// Labels group the application for bulk operations.
+	Labels map[string]string `json:"labels,omitempty"`
 	// RepoURL is the URL of the Git repository where the application's manifests are stored.
-	RepoURL string `json:"repo_url" validate:"required,url"`
+	RepoURL string `json:"repo_url" validate:"required,giturl"`
 	// Branch is the branch in the Git repository that contains the application's manifests.
-	Branch string `json:"branch" validate:"required"`
+	// When empty, the remote's default branch is used.
+	Branch string `json:"branch"`
 	// Path is the directory path within the repository where the manifests are located.
 	Path string `json:"path" validate:"required"`
+	// Paths lists additional paths or glob patterns in the repository whose manifests are applied
+	// together with Path's. Only plain YAML manifests can be combined.
+	Paths []string `json:"paths,omitempty"`
+	// Sources are additional repositories the application is composed from.
+	Sources []Source `json:"sources,omitempty" validate:"dive"`
+	// Substitute replaces ${CLUSTER_NAME}, ${APP_NAME} and the cluster's variables in the rendered manifests.
+	Substitute bool `json:"substitute"`
+	// Variables are substituted into the rendered manifests as ${KEY}; setting them implies Substitute.
+	Variables map[string]string `json:"variables,omitempty"`
+	// ConfigHash rolls Deployments, StatefulSets and DaemonSets when only the ConfigMaps or Secrets
+	// they reference change, by annotating their pod templates with a hash of them.
+	ConfigHash bool `json:"config_hash"`
+	// DefaultNamespace is the namespace namespaced resources whose manifest does not set one are
+	// applied to, "default" when empty.
+	DefaultNamespace string `json:"default_namespace"`
+	// RequireNamespace fails namespaced resources whose manifest does not set a namespace instead of
+	// applying them to the default namespace. It cannot be combined with DefaultNamespace.
+	RequireNamespace bool `json:"require_namespace"`
+	// ImpersonateUser is the user the resources are applied as, impersonated with the cluster's
+	// credentials. Empty uses the cluster's impersonate_user, if any.
+	ImpersonateUser string `json:"impersonate_user"`
+	// ImpersonateGroups are the groups impersonated along with ImpersonateUser.
+	ImpersonateGroups []string `json:"impersonate_groups,omitempty"`
+	// Lint checks the manifests with linters before they are applied.
+	Lint *Lint `json:"lint,omitempty"`
+	// APIDeprecationPolicy is "warn" (the default) to only report manifests using API versions
+	// deprecated in the cluster's Kubernetes version, or "block" to apply nothing when there are some.
+	APIDeprecationPolicy string `json:"api_deprecation_policy" validate:"omitempty,oneof=warn block"`
 	// ClusterName is the name of the Kubernetes cluster where the application will be deployed.
-	ClusterName string `json:"cluster_name" validate:"required"`
+	// It is required unless ClusterSelector is set. Terraform applications are not deployed to a
+	// cluster and must leave it empty.
+	ClusterName string `json:"cluster_name"`
+	// ClusterSelector places the application automatically on the healthy cluster with these labels
+	// and the most free capacity, instead of ClusterName, and moves it when the cluster becomes unhealthy.
+	ClusterSelector map[string]string `json:"cluster_selector,omitempty"`
+	// Type is the kind of configuration under Path: "kubernetes" (default) or "terraform".
+	Type string `json:"type" validate:"omitempty,oneof=kubernetes terraform"`
+	// Terraform configures how a Terraform application's configuration is planned.
+	Terraform *TerraformSource `json:"terraform"`
 	// Interval is the frequency at which the application should be synced with the Git repository.
 	Interval string `json:"interval" validate:"required"`
+	// Submodules initializes and updates the repository's submodules, recursively, on every fetch.
+	Submodules bool `json:"submodules"`
+	// LFS fetches the Git LFS objects of the synced commit; the controller needs git and git-lfs.
+	LFS bool `json:"lfs"`
+	// MaxInterval enables adaptive polling: the interval lengthens up to MaxInterval while the
+	// repository has no new commits, and returns to Interval after a change.
+	MaxInterval string `json:"max_interval,omitempty"`
+	// Renderer selects how manifests are produced: "yaml" (default), "jsonnet", "ytt", "helm" or a configured plugin.
+	Renderer string `json:"renderer"`
+	// PluginParams are passed to the manifest generator plugin when Renderer names a plugin.
+	PluginParams map[string]string `json:"plugin_params"`
+	// Helm holds the chart's values when Renderer is "helm".
+	Helm *HelmSource `json:"helm"`
+	// ApplyStrategy controls how manifest failures are handled: "best-effort" (default), "fail-fast" or "atomic".
+	ApplyStrategy string `json:"apply_strategy" validate:"omitempty,oneof=best-effort fail-fast atomic"`
+	// Exclude lists glob patterns, relative to Path, of manifest files or directories to skip. With
+	// Paths, patterns containing a slash are relative to the repository root.
+	Exclude []string `json:"exclude"`
+	// ApprovalRequired holds back detected changes until they are approved. Terraform applications
+	// always require approval and must leave it unset.
+	ApprovalRequired bool `json:"approval_required"`
+	// SyncMode is "apply" (default) or "two-phase", which waits for health and rolls back unhealthy revisions.
+	SyncMode string `json:"sync_mode" validate:"omitempty,oneof=apply two-phase"`
+	// HealthTimeout is how long a two-phase sync waits for resources to become healthy, "5m" if empty.
+	HealthTimeout string `json:"health_timeout"`
+	// Reason is why the application is registered or updated, recorded in the audit log.
+	Reason string `json:"reason"`
+}
+
+// Source is an additional repository an application is composed from.
+type Source struct {
+	// Name identifies the source; Helm values files refer to its files as "$name/<path>".
+	Name string `json:"name" validate:"required"`
+	// RepoURL is the URL of the source's Git repository.
+	RepoURL string `json:"repo_url" validate:"required"`
+	// Branch is the branch of the repository that is tracked. When empty, the remote's default
+	// branch is used.
+	Branch string `json:"branch"`
+	// Path is a directory of plain YAML manifests in the repository applied with the application's.
+	Path string `json:"path,omitempty"`
+	// LastSyncedGitHash is the commit of the source the application was last synced at.
+	// It is only set in responses.
+	LastSyncedGitHash string `json:"last_synced_git_hash,omitempty"`
+}
+
+// HelmSource holds the values and release settings a Helm chart is rendered with.
+type HelmSource struct {
+	// ValuesFiles are values files, relative to the application's path, applied in order.
+	ValuesFiles []string `json:"values_files,omitempty"`
+	// Values are inline values applied after ValuesFiles, taking precedence over them.
+	Values map[string]any `json:"values,omitempty"`
+	// Namespace is the release namespace the chart is rendered with, "default" if empty.
+	Namespace string `json:"namespace,omitempty"`
+	// RecordRelease records each sync as a revision of a Helm release named after the application.
+	RecordRelease bool `json:"record_release,omitempty"`
+}
+
+// TerraformSource configures how a Terraform application's configuration is planned and applied.
+type TerraformSource struct {
+	// Binary is the CLI the configuration is planned with, "terraform" (default) or "tofu".
+	Binary string `json:"binary,omitempty" validate:"omitempty,oneof=terraform tofu"`
+	// VarFiles are variable files, relative to the application's path, passed in order.
+	VarFiles []string `json:"var_files,omitempty"`
+	// Vars are input variables, taking precedence over VarFiles.
+	Vars map[string]string `json:"vars,omitempty"`
+}
+
+// TerraformPlan summarizes the last plan with changes of a Terraform application.
+type TerraformPlan struct {
+	// ID identifies the plan; it must be named to approve the plan.
+	ID string `json:"id"`
+	// GitHash is the commit the plan was made at.
+	GitHash string `json:"git_hash"`
+	// Summary is the plan's summary, e.g. "Plan: 1 to add, 0 to change, 0 to destroy."
+	Summary string `json:"summary"`
+	// Add, Change and Destroy count the resources the plan creates, updates and deletes.
+	Add     int `json:"add"`
+	Change  int `json:"change"`
+	Destroy int `json:"destroy"`
+	// PlannedAt is when the plan was made.
+	PlannedAt time.Time `json:"planned_at"`
+	// ApprovedAt is when the plan was approved, zero while it awaits approval.
+	ApprovedAt time.Time `json:"approved_at"`
+	// AppliedAt is when the plan was applied, zero until it is.
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// PendingChange is a change to a Kubernetes application requiring approval that has not been applied yet.
+type PendingChange struct {
+	// ID identifies the change; it must be named to approve the change.
+	ID string `json:"id"`
+	// GitHash is the commit the change was detected at.
+	GitHash string `json:"git_hash"`
+	// Summary counts the resources the change creates and updates, e.g. "1 to create, 2 to update."
+	Summary string `json:"summary"`
+	// Diff shows how each resource changes, as a line diff of its YAML against the live resource.
+	Diff string `json:"diff"`
+	// DetectedAt is when the change was detected.
+	DetectedAt time.Time `json:"detected_at"`
+	// ApprovedAt is when the change was approved, zero while it awaits approval.
+	ApprovedAt time.Time `json:"approved_at"`
+}
+
+// ApproveRequest represents the request payload for approving a Terraform application's plan or
+// the pending change of an application requiring approval.
+type ApproveRequest struct {
+	// PlanID is the ID of the pending plan or change being approved.
+	PlanID string `json:"plan_id" validate:"required"`
+}
+
+// RolloutRequest represents the request payload for promoting or aborting a canary rollout of an application.
+type RolloutRequest struct {
+	// Rollout names the Rollout or Canary resource as "namespace/name" or "name". It may be omitted
+	// when the application manages a single rollout.
+	Rollout string `json:"rollout"`
+	// Full skips all remaining steps and analysis of an Argo Rollout when promoting it.
+	Full bool `json:"full"`
+	// Reason is why the rollout is promoted or aborted, recorded in the audit log.
+	Reason string `json:"reason"`
+}
+
+// Rollout is the progress of a canary rollout of an application.
+type Rollout struct {
+	// Kind is the kind of the rollout resource, Rollout or Canary.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the rollout resource.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the rollout resource.
+	Name string `json:"name"`
+	// Provider is the controller driving the rollout, "argo-rollouts" or "flagger".
+	Provider string `json:"provider"`
+	// Phase is the phase reported by the provider.
+	Phase string `json:"phase"`
+	// Step is the number of canary steps completed, out of Steps.
+	Step int `json:"step"`
+	// Steps is the total number of canary steps, zero for rollouts without steps.
+	Steps int `json:"steps"`
+	// Weight is the percentage of traffic sent to the canary.
+	Weight int `json:"weight"`
+	// Paused reports whether the rollout waits to be promoted.
+	Paused bool `json:"paused"`
+	// Message is the provider's explanation of the phase, if any.
+	Message string `json:"message,omitempty"`
+}
+
+// Lint configures the linters an application's manifests are checked with before they are applied.
+type Lint struct {
+	// Linters are the linters run, in order: "kubeconform" and/or "kube-score".
+	Linters []string `json:"linters" validate:"required"`
+	// Strict applies nothing when a linter reports an error, instead of only reporting it.
+	Strict bool `json:"strict"`
+}
+
+// LintFinding is a problem a linter found in an application's manifests.
+type LintFinding struct {
+	// Linter is the linter that reported the finding.
+	Linter string `json:"linter"`
+	// Severity is "error" or "warning".
+	Severity string `json:"severity"`
+	// Resource is the resource the finding is about, if the linter names one.
+	Resource string `json:"resource,omitempty"`
+	// File is the manifest file the resource was read from.
+	File string `json:"file,omitempty"`
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// DeprecatedAPI is a manifest using an API version deprecated in, or removed from, the Kubernetes
+// version of an application's cluster.
+type DeprecatedAPI struct {
+	// Resource is the resource the manifest describes.
+	Resource string `json:"resource"`
+	// File is the manifest file the resource was read from.
+	File string `json:"file,omitempty"`
+	// APIVersion is the deprecated API version the manifest uses.
+	APIVersion string `json:"api_version"`
+	// DeprecatedIn is the Kubernetes version the API version was deprecated in.
+	DeprecatedIn string `json:"deprecated_in"`
+	// RemovedIn is the Kubernetes version the API version was removed in.
+	RemovedIn string `json:"removed_in"`
+	// Replacement is the API version to use instead, if any.
+	Replacement string `json:"replacement,omitempty"`
+	// Removed reports whether the cluster no longer serves the API version.
+	Removed bool `json:"removed"`
+}
+
+// ResourceSyncResult is the outcome of applying one Kubernetes resource during an application's last sync.
+type ResourceSyncResult struct {
+	// Kind is the Kubernetes kind of the resource.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the resource, empty for cluster-scoped resources.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+	// Action is what the sync did to the resource: "created", "updated", "unchanged" or "failed".
+	Action string `json:"action"`
+	// Error is why the resource could not be applied, set only when Action is "failed".
+	Error string `json:"error,omitempty"`
+}
+
+// RolloutResponse represents the response for rollout promote and abort requests.
+type RolloutResponse struct {
+	// Message describes the outcome of the request.
+	Message string `json:"message"`
+	// Rollout is the promoted or aborted resource.
+	Rollout ResourceResponse `json:"rollout"`
+}
+
+// RenameRequest represents the request payload for renaming an application.
+type RenameRequest struct {
+	// Name is the new name of the application.
+	Name string `json:"name" validate:"required"`
 }
 
 // Response represents the response payload for application operations.
@@ -26,45 +290,544 @@ type RegisterRequest struct {
 type Response struct {
 	// Name is the unique identifier for the application.
 	Name string `json:"name"`
+	// Labels group the application for bulk operations.
+	Labels map[string]string `json:"labels,omitempty"`
 	// RepoURL is the URL of the Git repository where the application's manifests are stored.
 	RepoURL string `json:"repo_url"`
 	// Branch is the branch in the Git repository that contains the application's manifests.
 	Branch string `json:"branch"`
 	// Path is the directory path within the repository where the manifests are located.
 	Path string `json:"path"`
+	// Paths lists the additional paths or glob patterns whose manifests are applied with Path's.
+	Paths []string `json:"paths,omitempty"`
+	// Sources are the additional repositories the application is composed from, with the commit
+	// each was last synced at.
+	Sources []Source `json:"sources,omitempty"`
+	// Substitute reports whether variables are substituted into the rendered manifests.
+	Substitute bool `json:"substitute"`
+	// Variables are the application's own variables substituted into its manifests.
+	Variables map[string]string `json:"variables,omitempty"`
+	// ConfigHash reports whether workloads are rolled when the ConfigMaps or Secrets they reference change.
+	ConfigHash bool `json:"config_hash"`
+	// DefaultNamespace is the namespace namespaced resources without one are applied to, if not "default".
+	DefaultNamespace string `json:"default_namespace,omitempty"`
+	// RequireNamespace reports whether namespaced resources without a namespace fail instead.
+	RequireNamespace bool `json:"require_namespace"`
+	// ImpersonateUser is the user the resources are applied as, if the application sets its own.
+	ImpersonateUser string `json:"impersonate_user,omitempty"`
+	// ImpersonateGroups are the groups impersonated along with ImpersonateUser.
+	ImpersonateGroups []string `json:"impersonate_groups,omitempty"`
+	// Lint is the linters the manifests are checked with before they are applied.
+	Lint *Lint `json:"lint,omitempty"`
+	// LintFindings are the problems the linters found in the manifests of the last sync that linted them.
+	LintFindings []LintFinding `json:"lint_findings,omitempty"`
+	// APIDeprecationPolicy is "block" if manifests using deprecated API versions are not applied.
+	APIDeprecationPolicy string `json:"api_deprecation_policy,omitempty"`
+	// DeprecatedAPIs are the manifests of the last sync that used API versions deprecated in, or
+	// removed from, the cluster's Kubernetes version.
+	DeprecatedAPIs []DeprecatedAPI `json:"deprecated_apis,omitempty"`
 	// ClusterName is the name of the Kubernetes cluster where the application will be deployed.
 	ClusterName string `json:"cluster_name"`
+	// ClusterSelector lists the labels of the clusters the application is placed on, if it is placed automatically.
+	ClusterSelector map[string]string `json:"cluster_selector,omitempty"`
+	// Type is the kind of configuration under Path, "kubernetes" or "terraform".
+	Type string `json:"type"`
+	// Terraform configures how a Terraform application's configuration is planned.
+	Terraform *TerraformSource `json:"terraform,omitempty"`
+	// TerraformPlan is the last plan with changes of a Terraform application.
+	TerraformPlan *TerraformPlan `json:"terraform_plan,omitempty"`
+	// ApprovalRequired reports whether detected changes are held back until they are approved.
+	ApprovalRequired bool `json:"approval_required"`
+	// PendingChange is the change of an application requiring approval that has not been applied yet.
+	PendingChange *PendingChange `json:"pending_change,omitempty"`
+	// SyncMode is "apply" or "two-phase".
+	SyncMode string `json:"sync_mode"`
+	// HealthTimeout is how long a two-phase sync waits for resources to become healthy.
+	HealthTimeout string `json:"health_timeout,omitempty"`
+	// Rollouts is the progress of the application's Argo Rollouts Rollouts and Flagger Canaries, as of its last sync.
+	Rollouts []Rollout `json:"rollouts,omitempty"`
+	// LastSyncResources is the outcome of each resource the last sync that applied manifests attempted.
+	LastSyncResources []ResourceSyncResult `json:"last_sync_resources,omitempty"`
+	// PartialGitHash is the commit the last sync failed part way through applying, whose next sync
+	// applies only the resources that were not applied. Empty when the last apply did not fail part way.
+	PartialGitHash string `json:"partial_git_hash,omitempty"`
 	// Interval is the frequency at which the application should be synced with the Git repository.
 	Interval string `json:"interval"`
+	// MaxInterval is the longest interval of adaptive polling, empty when the interval is fixed.
+	MaxInterval string `json:"max_interval,omitempty"`
+	// Submodules reports whether the repository's submodules are fetched.
+	Submodules bool `json:"submodules"`
+	// LFS reports whether the repository's Git LFS objects are fetched.
+	LFS bool `json:"lfs"`
+	// Renderer is how the application's manifests are produced ("yaml", "jsonnet", "ytt", "helm" or a plugin name).
+	Renderer string `json:"renderer"`
+	// PluginParams are the parameters passed to the manifest generator plugin.
+	PluginParams map[string]string `json:"plugin_params,omitempty"`
+	// Helm holds the chart's values when Renderer is "helm".
+	Helm *HelmSource `json:"helm,omitempty"`
+	// ApplyStrategy is how manifest failures are handled during a sync.
+	ApplyStrategy string `json:"apply_strategy"`
+	// Exclude lists the glob patterns of manifest paths that are skipped.
+	Exclude []string `json:"exclude,omitempty"`
 	// LastSyncedGitHash is the last commit hash that was successfully synced from the Git repository.
 	LastSyncedGitHash string `json:"last_synced_git_hash"`
-	// Status indicates the current status of the application (e.g., "active", "inactive", "error").
-	Status string `json:"status"`
+	// RollbackGitHash is the commit of the healthy revision synced before LastSyncedGitHash that the
+	// application can be rolled back to, empty if none is kept.
+	RollbackGitHash string `json:"rollback_git_hash,omitempty"`
+	// Status indicates the current status of the application (e.g., "Synced", "Error", "Suspended").
+	Status appcore.Status `json:"status"`
 	// Message provides additional information about the application's status, such as error messages or warnings.
 	Message string `json:"message"`
 	// ConsecutiveFailures counts the number of consecutive sync failures for the application.
 	ConsecutiveFailures int `json:"consecutive_failures"`
-	// LastUpdated is the timestamp of the last update to the application's status.
+	// LastUpdated is the timestamp of the last completed sync attempt, in RFC3339 format.
 	LastUpdated string `json:"last_updated"`
+	// LastSyncStartedAt is the time the last sync attempt started.
+	LastSyncStartedAt time.Time `json:"last_sync_started_at"`
+	// LastSyncFinishedAt is the time the last sync attempt finished.
+	LastSyncFinishedAt time.Time `json:"last_sync_finished_at"`
+	// LastSyncDuration is how long the last sync attempt took (e.g., "1.25s").
+	LastSyncDuration string `json:"last_sync_duration"`
+	// LastSyncAt is the time the controller last attempted to reconcile the application.
+	LastSyncAt time.Time `json:"last_sync_at"`
+	// NextSyncAt is the time the controller is scheduled to reconcile the application next.
+	NextSyncAt time.Time `json:"next_sync_at"`
+	// EffectiveInterval is the current interval between reconciliations, including any backoff.
+	EffectiveInterval string `json:"effective_interval"`
+	// RateLimitedUntil is when the Git provider accepts fetches of the repository again, if the
+	// last fetch was rejected for exceeding a rate limit.
+	RateLimitedUntil *time.Time `json:"rate_limited_until,omitempty"`
+	// SyncHistory holds the most recent sync attempts, oldest first.
+	SyncHistory []SyncEvent `json:"sync_history,omitempty"`
+	// Repository tracks the fetches and the clone of the application's repository.
+	Repository *RepositoryStats `json:"repository,omitempty"`
+}
+
+// RepositoryStats tracks how long fetching an application's repository takes and how large its clone is.
+type RepositoryStats struct {
+	// LastFetchDuration is how long the most recent clone or pull took (e.g., "1.25s").
+	LastFetchDuration string `json:"last_fetch_duration"`
+	// LastCloneDuration is how long the last clone took.
+	LastCloneDuration string `json:"last_clone_duration"`
+	// LastClonedAt is when the repository was last cloned.
+	LastClonedAt time.Time `json:"last_cloned_at"`
+	// LastPullDuration is how long the last pull took.
+	LastPullDuration string `json:"last_pull_duration"`
+	// LastPulledAt is when the repository was last pulled.
+	LastPulledAt time.Time `json:"last_pulled_at"`
+	// Clones is the number of clones since the application was registered.
+	Clones int `json:"clones"`
+	// Pulls is the number of pulls since the application was registered.
+	Pulls int `json:"pulls"`
+	// Checks is the number of polls that skipped the pull because the remote branch had not moved.
+	Checks int `json:"checks"`
+	// FetchFailures is the number of failed clones and pulls since the application was registered.
+	FetchFailures int `json:"fetch_failures"`
+	// SizeBytes is the size of the clone on disk, including its worktree.
+	SizeBytes int64 `json:"size_bytes"`
+	// Objects is the number of Git objects in the clone.
+	Objects int `json:"objects"`
+}
+
+// RepositoryResponse is the repository of an application, with its fetch and size statistics.
+type RepositoryResponse struct {
+	// Application is the name of the application.
+	Application string `json:"application"`
+	// RepoURL is the URL of the application's repository.
+	RepoURL string `json:"repo_url"`
+	// Branch is the branch the application is synced from.
+	Branch string `json:"branch"`
+	RepositoryStats
+}
+
+// SyncEvent is the outcome of a sync attempt of an application.
+type SyncEvent struct {
+	// Time is when the sync attempt finished.
+	Time time.Time `json:"time"`
+	// Status is the resulting application status.
+	Status appcore.Status `json:"status"`
+	// GitHash is the commit that was being synced, if known.
+	GitHash string `json:"git_hash,omitempty"`
+	// Message describes the outcome of the sync attempt.
+	Message string `json:"message,omitempty"`
+	// Actor is who requested the sync, empty for syncs the controller started on its own.
+	Actor string `json:"actor,omitempty"`
+	// Reason is why the sync was requested, as given by the actor.
+	Reason string `json:"reason,omitempty"`
+	// RequestID is the ID of the API request that requested the sync, if any.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// ReasonRequest represents the optional payload of manual sync and suspension requests.
+type ReasonRequest struct {
+	// Reason is why the operation is requested, recorded in the sync history and the audit log.
+	Reason string `json:"reason"`
+}
+
+// DebugRequest represents the payload of requests to turn debug logging of an application on or off.
+type DebugRequest struct {
+	// Enabled turns debug logging on or off.
+	Enabled *bool `json:"enabled" validate:"required"`
 }
 
 // SyncTriggerResponse represents the response for sync trigger requests.
 type SyncTriggerResponse struct {
+	Message string         `json:"message"`
+	Status  appcore.Status `json:"status"`
+}
+
+// DryRunResponse represents the response for dry-run sync requests.
+type DryRunResponse struct {
+	// Revision is the commit hash of the latest revision the manifests were rendered at.
+	Revision string `json:"revision"`
+	// Resources is what the sync would do to each resource, in manifest order.
+	Resources []DryRunResult `json:"resources"`
+	// Errors are the manifests that could not be read, which have no result among Resources.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// DryRunResult is what syncing an application would do to one resource, according to a server-side dry run.
+type DryRunResult struct {
+	// Kind is the Kubernetes kind of the resource.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the resource, empty for cluster-scoped resources.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+	// Action is what the sync would do to the resource: "created", "updated", "unchanged" or "failed".
+	Action string `json:"action"`
+	// Diff is a line diff of the resource's YAML, empty if it would not change or failed.
+	Diff string `json:"diff,omitempty"`
+	// Error is why the resource would fail to apply, set only when Action is "failed".
+	Error string `json:"error,omitempty"`
+	// Validated is false for custom resources whose CRD is not applied yet, which the API server
+	// cannot dry-run; their action and diff are predicted from the manifest only.
+	Validated bool `json:"validated"`
+}
+
+// ConvertToDryRunResponse converts the outcome of a dry run of a sync to a DryRunResponse.
+func ConvertToDryRunResponse(report *controller.DryRunReport) DryRunResponse {
+	response := DryRunResponse{
+		Revision:  report.Revision,
+		Resources: make([]DryRunResult, 0, len(report.Resources)),
+		Errors:    report.Errors,
+	}
+	for _, r := range report.Resources {
+		response.Resources = append(response.Resources, DryRunResult{
+			Kind:      r.Kind,
+			Namespace: r.Namespace,
+			Name:      r.Name,
+			Action:    r.Action,
+			Diff:      r.Diff,
+			Error:     r.Error,
+			Validated: r.Validated,
+		})
+	}
+	return response
+}
+
+// ResourceResponse identifies a Kubernetes resource managed by an application.
+type ResourceResponse struct {
+	// Kind is the Kubernetes kind of the resource.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the resource, empty for cluster-scoped resources.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+}
+
+// UnregisterResponse represents the response for unregister requests.
+type UnregisterResponse struct {
+	// Message describes the outcome of the request.
 	Message string `json:"message"`
-	Status  string `json:"status"`
+	// Name is the name of the unregistered application.
+	Name string `json:"name"`
+	// DeletedResources lists the resources deleted from the cluster when the request cascaded.
+	DeletedResources []ResourceResponse `json:"deleted_resources,omitempty"`
+	// ProtectedResources lists the resources kept in the cluster because they are protected from deletion.
+	ProtectedResources []ResourceResponse `json:"protected_resources,omitempty"`
+	// HistoryArchived reports whether the application's sync history was archived.
+	HistoryArchived bool `json:"history_archived"`
+}
+
+// ConvertToResourceResponses converts resource references to ResourceResponses.
+func ConvertToResourceResponses(refs []k8s.ResourceRef) []ResourceResponse {
+	if len(refs) == 0 {
+		return nil
+	}
+	resources := make([]ResourceResponse, 0, len(refs))
+	for _, ref := range refs {
+		resources = append(resources, ResourceResponse{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name})
+	}
+	return resources
 }
 
 // ConvertToResponse converts an Application to a Response.
 func ConvertToResponse(app *appcore.Application) Response {
+	lastUpdated := ""
+	if !app.LastSyncFinishedAt.IsZero() {
+		lastUpdated = app.LastSyncFinishedAt.Format(time.RFC3339)
+	}
 	return Response{
-		Name:                app.Name,
-		RepoURL:             app.RepoURL,
-		Branch:              app.Branch,
-		Path:                app.Path,
-		ClusterName:         app.ClusterName,
-		Interval:            app.Interval,
-		Status:              app.Status,
-		Message:             app.Message,
-		ConsecutiveFailures: app.ConsecutiveFailures,
+		Name:                 app.Name,
+		Labels:               app.Labels,
+		RepoURL:              app.RepoURL,
+		Branch:               app.Branch,
+		Path:                 app.Path,
+		Paths:                app.Paths,
+		Sources:              convertSources(app),
+		Substitute:           app.SubstitutesVariables(),
+		Variables:            app.Variables,
+		ConfigHash:           app.ConfigHash,
+		DefaultNamespace:     app.DefaultNamespace,
+		RequireNamespace:     app.RequireNamespace,
+		ImpersonateUser:      app.ImpersonateUser,
+		ImpersonateGroups:    app.ImpersonateGroups,
+		Lint:                 convertLint(app.Lint),
+		LintFindings:         convertLintFindings(app.LintFindings),
+		APIDeprecationPolicy: app.APIDeprecationPolicy,
+		DeprecatedAPIs:       convertDeprecatedAPIs(app.DeprecatedAPIs),
+		ClusterName:          app.ClusterName,
+		ClusterSelector:      clusterSelector(app.Placement),
+		Type:                 common.DefaultIfEmpty(app.Type, appcore.TypeKubernetes),
+		Terraform:            convertTerraformSource(app.Terraform),
+		TerraformPlan:        convertTerraformPlan(app.TerraformPlan),
+		ApprovalRequired:     app.ApprovalRequired,
+		PendingChange:        convertPendingChange(app.PendingChange),
+		SyncMode:             common.DefaultIfEmpty(app.SyncMode, appcore.SyncModeApply),
+		HealthTimeout:        healthTimeout(app),
+		Rollouts:             convertRollouts(app.Rollouts),
+		LastSyncResources:    convertResourceSyncResults(app.LastSyncResources),
+		PartialGitHash:       app.PartialGitHash,
+		Interval:             app.Interval,
+		MaxInterval:          app.MaxInterval,
+		Submodules:           app.Submodules,
+		LFS:                  app.LFS,
+		Renderer:             common.DefaultIfEmpty(app.Renderer, render.RendererYAML),
+		PluginParams:         app.PluginParams,
+		Helm:                 convertHelmSource(app.Helm),
+		ApplyStrategy:        common.DefaultIfEmpty(app.ApplyStrategy, k8s.ApplyStrategyBestEffort),
+		Exclude:              app.Exclude,
+		LastSyncedGitHash:    app.LastSyncedGitHash,
+		RollbackGitHash:      app.RollbackGitHash,
+		Status:               app.Status,
+		Message:              app.Message,
+		ConsecutiveFailures:  app.ConsecutiveFailures,
+		LastUpdated:          lastUpdated,
+		LastSyncStartedAt:    app.LastSyncStartedAt,
+		LastSyncFinishedAt:   app.LastSyncFinishedAt,
+		LastSyncDuration:     app.LastSyncDuration.String(),
+		LastSyncAt:           app.LastSyncAt,
+		NextSyncAt:           app.NextSyncAt,
+		EffectiveInterval:    app.EffectiveInterval,
+		RateLimitedUntil:     rateLimitedUntil(app),
+		SyncHistory:          convertSyncHistory(app.SyncHistory),
+		Repository:           convertRepositoryStats(app.Repository),
+	}
+}
+
+// rateLimitedUntil returns when the application's repository can be fetched again after a rate
+// limit response, or nil if it is not rate limited.
+func rateLimitedUntil(app *appcore.Application) *time.Time {
+	if app.RateLimitedUntil.IsZero() {
+		return nil
+	}
+	until := app.RateLimitedUntil
+	return &until
+}
+
+// convertRepositoryStats converts an application's repository statistics for a Response.
+func convertRepositoryStats(stats *appcore.RepositoryStats) *RepositoryStats {
+	if stats == nil {
+		return nil
+	}
+	return &RepositoryStats{
+		LastFetchDuration: stats.LastFetchDuration().String(),
+		LastCloneDuration: stats.LastCloneDuration.String(),
+		LastClonedAt:      stats.LastClonedAt,
+		LastPullDuration:  stats.LastPullDuration.String(),
+		LastPulledAt:      stats.LastPulledAt,
+		Clones:            stats.Clones,
+		Pulls:             stats.Pulls,
+		Checks:            stats.Checks,
+		FetchFailures:     stats.FetchFailures,
+		SizeBytes:         stats.SizeBytes,
+		Objects:           stats.Objects,
+	}
+}
+
+// convertSyncHistory converts an application's sync history for a Response.
+func convertSyncHistory(history []appcore.SyncEvent) []SyncEvent {
+	if len(history) == 0 {
+		return nil
+	}
+	converted := make([]SyncEvent, 0, len(history))
+	for _, e := range history {
+		converted = append(converted, SyncEvent{
+			Time:      e.Time,
+			Status:    e.Status,
+			GitHash:   e.GitHash,
+			Message:   e.Message,
+			Actor:     e.Actor,
+			Reason:    e.Reason,
+			RequestID: e.RequestID,
+		})
+	}
+	return converted
+}
+
+// convertHelmSource converts an application's Helm values for a Response.
+func convertHelmSource(helm *appcore.HelmSource) *HelmSource {
+	if helm == nil {
+		return nil
+	}
+	return &HelmSource{ValuesFiles: helm.ValuesFiles, Values: helm.Values, Namespace: helm.Namespace, RecordRelease: helm.RecordRelease}
+}
+
+// convertSources converts an application's additional sources, with their synced revisions, for a Response.
+func convertSources(app *appcore.Application) []Source {
+	if len(app.Sources) == 0 {
+		return nil
+	}
+	sources := make([]Source, len(app.Sources))
+	for i, source := range app.Sources {
+		sources[i] = Source{
+			Name:              source.Name,
+			RepoURL:           source.RepoURL,
+			Branch:            source.Branch,
+			Path:              source.Path,
+			LastSyncedGitHash: app.SourceRevisions[source.Name],
+		}
+	}
+	return sources
+}
+
+// convertTerraformSource converts a Terraform application's settings for a Response.
+func convertTerraformSource(source *appcore.TerraformSource) *TerraformSource {
+	if source == nil {
+		return nil
+	}
+	return &TerraformSource{Binary: source.Binary, VarFiles: source.VarFiles, Vars: source.Vars}
+}
+
+// convertTerraformPlan converts a Terraform application's last plan for a Response.
+func convertTerraformPlan(plan *appcore.TerraformPlan) *TerraformPlan {
+	if plan == nil {
+		return nil
+	}
+	return &TerraformPlan{
+		ID:         plan.ID,
+		GitHash:    plan.GitHash,
+		Summary:    plan.Summary,
+		Add:        plan.Add,
+		Change:     plan.Change,
+		Destroy:    plan.Destroy,
+		PlannedAt:  plan.PlannedAt,
+		ApprovedAt: plan.ApprovedAt,
+		AppliedAt:  plan.AppliedAt,
+	}
+}
+
+// convertPendingChange converts the pending change of an application requiring approval for a Response.
+func convertPendingChange(change *appcore.PendingChange) *PendingChange {
+	if change == nil {
+		return nil
+	}
+	return &PendingChange{
+		ID:         change.ID,
+		GitHash:    change.GitHash,
+		Summary:    change.Summary,
+		Diff:       change.Diff,
+		DetectedAt: change.DetectedAt,
+		ApprovedAt: change.ApprovedAt,
+	}
+}
+
+// convertRollouts converts the progress of an application's canary rollouts for a Response.
+func convertRollouts(rollouts []appcore.Rollout) []Rollout {
+	if len(rollouts) == 0 {
+		return nil
+	}
+	converted := make([]Rollout, 0, len(rollouts))
+	for _, r := range rollouts {
+		converted = append(converted, Rollout{
+			Kind:      r.Kind,
+			Namespace: r.Namespace,
+			Name:      r.Name,
+			Provider:  r.Provider,
+			Phase:     r.Phase,
+			Step:      r.Step,
+			Steps:     r.Steps,
+			Weight:    r.Weight,
+			Paused:    r.Paused,
+			Message:   r.Message,
+		})
+	}
+	return converted
+}
+
+// convertResourceSyncResults converts the per-resource outcome of an application's last sync for a Response.
+func convertResourceSyncResults(results []appcore.ResourceSyncResult) []ResourceSyncResult {
+	if len(results) == 0 {
+		return nil
+	}
+	converted := make([]ResourceSyncResult, 0, len(results))
+	for _, r := range results {
+		converted = append(converted, ResourceSyncResult{
+			Kind:      r.Kind,
+			Namespace: r.Namespace,
+			Name:      r.Name,
+			Action:    r.Action,
+			Error:     r.Error,
+		})
+	}
+	return converted
+}
+
+// convertLint converts the linters of an application for a Response.
+func convertLint(config *appcore.LintConfig) *Lint {
+	if config == nil {
+		return nil
+	}
+	return &Lint{Linters: config.Linters, Strict: config.Strict}
+}
+
+// convertLintFindings converts the lint findings of an application's last sync for a Response.
+func convertLintFindings(findings []appcore.LintFinding) []LintFinding {
+	if len(findings) == 0 {
+		return nil
+	}
+	converted := make([]LintFinding, 0, len(findings))
+	for _, f := range findings {
+		converted = append(converted, LintFinding{Linter: f.Linter, Severity: f.Severity, Resource: f.Resource, File: f.File, Message: f.Message})
+	}
+	return converted
+}
+
+// healthTimeout returns how long a two-phase application's syncs wait for health, empty for other applications.
+func healthTimeout(app *appcore.Application) string {
+	if !app.IsTwoPhase() {
+		return ""
+	}
+	return app.HealthTimeoutDuration().String()
+}
+
+// convertDeprecatedAPIs converts the deprecated API versions found by an application's last sync for a Response.
+func convertDeprecatedAPIs(deprecated []appcore.DeprecatedAPI) []DeprecatedAPI {
+	if len(deprecated) == 0 {
+		return nil
+	}
+	converted := make([]DeprecatedAPI, 0, len(deprecated))
+	for _, d := range deprecated {
+		converted = append(converted, DeprecatedAPI(d))
+	}
+	return converted
+}
+
+// clusterSelector returns the cluster selector of a placement, nil without one.
+func clusterSelector(placement *appcore.Placement) map[string]string {
+	if placement == nil {
+		return nil
 	}
+	return placement.ClusterSelector
 }
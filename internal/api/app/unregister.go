@@ -1,40 +1,148 @@
 package app
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
 	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cascade"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
 
 // Unregister handles the removal of an application by name.
-// It deletes the application from the applications store and saves the updated configuration.
+// The controller deletes the application from the applications store and saves the updated configuration.
 // If the application does not exist, it returns a 404 Not Found error.
 // This is useful for cleaning up applications that are no longer needed or have been removed from the Git repository.
+//
+// With the "cascade" query parameter, the application's managed resources are deleted from its
+// cluster first; if any cannot be deleted, it returns a 502 Bad Gateway error and the application
+// stays registered. With "keep_history", its configuration and sync history are archived.
 func (h *Handler) Unregister(c echo.Context) error {
 	name := c.Param("name")
+	cascadeDelete, err := boolQueryParam(c, "cascade")
+	if err != nil {
+		return err
+	}
+	keepHistory, err := boolQueryParam(c, "keep_history")
+	if err != nil {
+		return err
+	}
 
 	h.apps.RLock()
-	defer h.apps.RUnlock()
-
-	_, exists := h.apps.Get(name)
+	existing, exists := h.apps.Get(name)
+	var snapshot appcore.Application
+	if exists {
+		snapshot = *existing
+	}
+	h.apps.RUnlock()
 	if !exists {
-		return echo.NewHTTPError(http.StatusNotFound, "Application not found")
+		return apierror.NotFound("Application not found")
 	}
 
-	// Stop the controller's goroutine for this application FIRST
-	h.controller.StopApp(name)
-	h.apps.Lock()
-	defer h.apps.Unlock()
+	if cascadeDelete && snapshot.IsTerraform() {
+		return apierror.ValidationFailed("cascade cannot be used with Terraform applications; destroy their infrastructure with terraform first")
+	}
+
+	var deleted, protected []k8s.ResourceRef
+	if cascadeDelete {
+		// Keep the controller from applying the resources again while they are deleted
+		h.controller.StopApp(name)
+		if deleted, protected, err = h.deleteManagedResources(c.Request().Context(), &snapshot); err != nil {
+			return err
+		}
+	}
+
+	// The controller archives and removes the application, persists the removal and stops its loop
+	if err := h.controller.UnregisterApp(name, keepHistory, cascadeDelete); err != nil {
+		h.logger.Error("Failed to unregister application", zap.String("name", name), zap.Error(err))
+		return apierror.Internal("Failed to unregister application: " + err.Error())
+	}
 
-	// Remove the application from the store
+	// A standalone API server keeps its own copy of the state; mirror the removal until its next refresh
+	h.apps.Lock()
 	h.apps.Delete(name)
-	if err := appcore.SaveApplications(h.apps, appcore.DefaultAppConfigFile); err != nil {
-		h.logger.Error("Failed to save applications after unregister", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to remove application configuration")
+	h.apps.Unlock()
+
+	h.logger.Info("Application unregistered via API",
+		zap.String("name", name), zap.Bool("cascade", cascadeDelete), zap.Int("deleted", len(deleted)), zap.Int("protected", len(protected)),
+		zap.Bool("keep_history", keepHistory))
+	return c.JSON(http.StatusOK, UnregisterResponse{
+		Message:            "Application unregistered successfully",
+		Name:               name,
+		DeletedResources:   ConvertToResourceResponses(deleted),
+		ProtectedResources: ConvertToResourceResponses(protected),
+		HistoryArchived:    keepHistory,
+	})
+}
+
+// deleteManagedResources deletes the application's managed resources from its cluster. It returns
+// the deleted resources and those kept because they are protected from deletion.
+func (h *Handler) deleteManagedResources(ctx context.Context, a *appcore.Application) ([]k8s.ResourceRef, []k8s.ResourceRef, error) {
+	if len(a.ManagedResources) == 0 {
+		return nil, nil, nil
 	}
 
-	h.logger.Info("Application unregistered via API", zap.String("name", name))
-	return c.JSON(http.StatusOK, map[string]string{"message": "Application unregistered successfully", "name": name})
+	client, err := h.clientFor(a, "its resources cannot be deleted")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, CascadeTimeout)
+	defer cancel()
+
+	deleted, protected, errs := cascade.Delete(ctx, client, a)
+	if len(errs) > 0 {
+		messages := make([]string, 0, len(errs))
+		for _, err := range errs {
+			messages = append(messages, err.Error())
+		}
+		h.logger.Error("Failed to delete managed resources", zap.String("name", a.Name), zap.Strings("errors", messages))
+		apiErr := apierror.Upstream("Failed to delete managed resources; the application is still registered")
+		apiErr.Errors = messages
+		return nil, nil, apiErr
+	}
+	return deleted, protected, nil
+}
+
+// clientFor creates a Kubernetes client for the application's cluster, impersonating the
+// application's identity like the controller does when applying its resources. If the cluster is
+// not registered, it returns a 409 Conflict error whose message ends with consequence.
+func (h *Handler) clientFor(a *appcore.Application, consequence string) (*k8s.ClientSet, error) {
+	h.clusters.RLock()
+	cl, ok := h.clusters.Get(a.ClusterName)
+	var kubeconfigPath string
+	var opts k8s.ClientOptions
+	if ok {
+		kubeconfigPath = cl.KubeconfigPath
+		user, groups := a.EffectiveImpersonation(cl.ImpersonateUser, cl.ImpersonateGroups)
+		opts = k8s.ClientOptions{QPS: cl.QPS, Burst: cl.Burst, InCluster: cl.InCluster, Impersonate: k8s.Impersonation{User: user, Groups: groups}}
+	}
+	h.clusters.RUnlock()
+	if !ok {
+		return nil, apierror.Conflict("Cluster '" + a.ClusterName + "' not found; " + consequence)
+	}
+
+	client, err := k8s.NewClientSet(h.logger, kubeconfigPath, opts)
+	if err != nil {
+		h.logger.Error("Failed to create Kubernetes client", zap.String("name", a.Name), zap.Error(err))
+		return nil, apierror.Upstream("Failed to create Kubernetes client: " + err.Error())
+	}
+	return client, nil
+}
+
+// boolQueryParam parses an optional boolean query parameter, which defaults to false.
+func boolQueryParam(c echo.Context, name string) (bool, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, apierror.ValidationFailed("Invalid value for '" + name + "': must be true or false")
+	}
+	return b, nil
 }
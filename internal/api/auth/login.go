@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	authcore "aeswibon.com/github/gitopsctl/internal/core/auth"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// sessionCookie holds the ID token of a web UI session.
+	sessionCookie = "gitopsctl_session"
+	// loginCookie holds the state of a login in progress until the provider redirects back.
+	loginCookie = "gitopsctl_login"
+	// loginTimeout is how long a user has to complete a login at the provider.
+	loginTimeout = 10 * time.Minute
+	// defaultRedirect is where users land after logging in or out.
+	defaultRedirect = "/ui/"
+)
+
+// loginState is what a login in progress must remember across the redirect to the provider.
+type loginState struct {
+	State    string `json:"state"`
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+	Redirect string `json:"redirect"`
+}
+
+// MeResponse represents the user a request is authenticated as.
+type MeResponse struct {
+	User        string         `json:"user"`
+	Groups      []string       `json:"groups,omitempty"`
+	Permissions authcore.Grant `json:"permissions"`
+}
+
+// Login handles requests to log in to the web UI.
+// It redirects to the identity provider with an authorization code request protected by PKCE; the
+// provider redirects back to Callback, which then redirects to the relative URL in the "redirect" parameter.
+func (h *Handler) Login(c echo.Context) error {
+	oauthConfig, err := h.oauthConfig(c)
+	if err != nil {
+		return err
+	}
+
+	login := loginState{
+		State:    randomString(),
+		Verifier: oauth2.GenerateVerifier(),
+		Nonce:    randomString(),
+		Redirect: safeRedirect(c.QueryParam("redirect")),
+	}
+	data, err := json.Marshal(login)
+	if err != nil {
+		return apierror.Internal("Failed to start login")
+	}
+	c.SetCookie(&http.Cookie{
+		Name:     loginCookie,
+		Value:    base64.RawURLEncoding.EncodeToString(data),
+		Path:     "/auth",
+		MaxAge:   int(loginTimeout.Seconds()),
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return c.Redirect(http.StatusFound, oauthConfig.AuthCodeURL(login.State,
+		oauth2.S256ChallengeOption(login.Verifier),
+		oauth2.SetAuthURLParam("nonce", login.Nonce)))
+}
+
+// Callback handles the identity provider's redirect after a login.
+// It exchanges the authorization code for an ID token, verifies it and stores it in the session cookie.
+func (h *Handler) Callback(c echo.Context) error {
+	oauthConfig, err := h.oauthConfig(c)
+	if err != nil {
+		return err
+	}
+
+	var login loginState
+	cookie, err := c.Cookie(loginCookie)
+	if err == nil {
+		var data []byte
+		if data, err = base64.RawURLEncoding.DecodeString(cookie.Value); err == nil {
+			err = json.Unmarshal(data, &login)
+		}
+	}
+	if err != nil || login.State == "" || c.QueryParam("state") != login.State {
+		return apierror.ValidationFailed("Login expired or was started elsewhere. Please log in again.")
+	}
+	c.SetCookie(&http.Cookie{Name: loginCookie, Path: "/auth", MaxAge: -1})
+
+	if reason := c.QueryParam("error"); reason != "" {
+		h.logger.Warn("Identity provider rejected login",
+			zap.String("error", reason),
+			zap.String("description", c.QueryParam("error_description")))
+		return apierror.Unauthorized("Login failed: " + reason)
+	}
+
+	ctx := c.Request().Context()
+	token, err := oauthConfig.Exchange(ctx, c.QueryParam("code"), oauth2.VerifierOption(login.Verifier))
+	if err != nil {
+		h.logger.Error("Failed to exchange authorization code", zap.Error(err))
+		return apierror.Upstream("Failed to complete login with the identity provider")
+	}
+	idToken, _ := token.Extra("id_token").(string)
+	if idToken == "" {
+		return apierror.Upstream("Identity provider returned no ID token")
+	}
+	identity, err := h.authenticator.Authenticate(ctx, idToken, login.Nonce)
+	if err != nil {
+		h.logger.Warn("Rejected login", zap.Error(err))
+		return apierror.Forbidden("Login rejected: " + err.Error())
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     sessionCookie,
+		Value:    idToken,
+		Path:     "/",
+		Expires:  identity.Expiry,
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+	h.logger.Info("User logged in", zap.String("user", identity.User), zap.Stringer("permissions", identity.Permissions))
+	return c.Redirect(http.StatusFound, login.Redirect)
+}
+
+// Logout handles requests to log out of the web UI.
+// It clears the session and, if the identity provider supports it, ends the user's session there too.
+func (h *Handler) Logout(c echo.Context) error {
+	c.SetCookie(&http.Cookie{Name: sessionCookie, Path: "/", MaxAge: -1})
+
+	metadata, err := h.authenticator.Metadata(c.Request().Context())
+	if err != nil || metadata.EndSessionEndpoint == "" {
+		return c.Redirect(http.StatusFound, defaultRedirect)
+	}
+	endSession, err := url.Parse(metadata.EndSessionEndpoint)
+	if err != nil {
+		return c.Redirect(http.StatusFound, defaultRedirect)
+	}
+	query := endSession.Query()
+	query.Set("client_id", h.authenticator.Config().OIDC.ClientID)
+	endSession.RawQuery = query.Encode()
+	return c.Redirect(http.StatusFound, endSession.String())
+}
+
+// Me handles requests for the user the request is authenticated as.
+func (h *Handler) Me(c echo.Context) error {
+	identity := IdentityFrom(c)
+	return c.JSON(http.StatusOK, MeResponse{
+		User:        identity.User,
+		Groups:      identity.Groups,
+		Permissions: identity.Permissions,
+	})
+}
+
+// oauthConfig returns the OAuth 2.0 client configuration of the web UI login.
+func (h *Handler) oauthConfig(c echo.Context) (*oauth2.Config, error) {
+	oidc := h.authenticator.Config().OIDC
+	if oidc == nil || oidc.RedirectURL == "" {
+		return nil, apierror.NotFound("Web UI login is not configured: set oidc.redirectURL")
+	}
+	metadata, err := h.authenticator.Metadata(c.Request().Context())
+	if err != nil {
+		h.logger.Error("Failed to discover identity provider", zap.Error(err))
+		return nil, apierror.Unavailable("Identity provider unavailable")
+	}
+
+	var clientSecret string
+	if oidc.ClientSecretEnv != "" {
+		clientSecret = os.Getenv(oidc.ClientSecretEnv)
+	}
+	return &oauth2.Config{
+		ClientID:     oidc.ClientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  oidc.RedirectURL,
+		Scopes:       oidc.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  metadata.AuthorizationEndpoint,
+			TokenURL: metadata.TokenEndpoint,
+		},
+	}, nil
+}
+
+// safeRedirect returns redirect if it is a path on this server, so the login cannot be abused to
+// send users to other sites, and defaultRedirect otherwise.
+func safeRedirect(redirect string) string {
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.Contains(redirect, "\\") {
+		return defaultRedirect
+	}
+	return redirect
+}
+
+// randomString returns a random URL-safe string for login states and nonces.
+func randomString() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
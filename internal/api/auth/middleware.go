@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	authcore "aeswibon.com/github/gitopsctl/internal/core/auth"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// identityKey is the key of the authenticated identity in the request context.
+const identityKey = "identity"
+
+// anonymous is the identity of requests when authentication is disabled.
+var anonymous = &authcore.Identity{User: "anonymous", Permissions: authcore.Grant{authcore.PermissionAdmin}}
+
+// Middleware authenticates the request with its bearer token or session cookie. Each route then
+// checks the identity's permissions with Require.
+func (h *Handler) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := bearerToken(c.Request())
+		if token == "" {
+			if cookie, err := c.Cookie(sessionCookie); err == nil {
+				token = cookie.Value
+			}
+		}
+		if token == "" {
+			return apierror.Unauthorized("Authentication required")
+		}
+
+		identity, err := h.authenticator.Authenticate(c.Request().Context(), token, "")
+		if err != nil {
+			switch {
+			case errors.Is(err, authcore.ErrInvalidToken):
+				return apierror.Unauthorized("Invalid or expired token")
+			case errors.Is(err, authcore.ErrNoPermissions):
+				return apierror.Forbidden("You are in none of the groups granted access")
+			}
+			h.logger.Error("Failed to authenticate request", zap.String("uri", c.Request().RequestURI), zap.Error(err))
+			return apierror.Unavailable("Identity provider unavailable")
+		}
+
+		c.Set(identityKey, identity)
+		return next(c)
+	}
+}
+
+// Anonymous is used instead of Middleware when authentication is disabled. It lets every request
+// through as an anonymous user with all permissions.
+func Anonymous(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Set(identityKey, anonymous)
+		return next(c)
+	}
+}
+
+// Require returns middleware that rejects requests whose identity lacks the permission. It must
+// run after Middleware or Anonymous; requests without an identity are rejected.
+func Require(permission authcore.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			identity := IdentityFrom(c)
+			if identity == nil {
+				return apierror.Unauthorized("Authentication required")
+			}
+			if !identity.Permissions.Allows(permission) {
+				zap.L().Warn("Denied request",
+					zap.String("user", identity.User),
+					zap.Stringer("permissions", identity.Permissions),
+					zap.String("method", c.Request().Method),
+					zap.String("uri", c.Request().RequestURI))
+				return apierror.Forbidden("This requires the " + string(permission) + " permission")
+			}
+			return next(c)
+		}
+	}
+}
+
+// IdentityFrom returns the identity a request was authenticated as, or nil if it was not authenticated.
+func IdentityFrom(c echo.Context) *authcore.Identity {
+	identity, _ := c.Get(identityKey).(*authcore.Identity)
+	return identity
+}
+
+// Actor returns who a request was made by, for audit records: the authenticated user, or
+// "anonymous" when the API does not require authentication.
+func Actor(c echo.Context) string {
+	if identity := IdentityFrom(c); identity != nil && identity.User != "" {
+		return identity.User
+	}
+	return "anonymous"
+}
+
+// RequestID returns the ID of a request, for audit records and to correlate the logs of what it
+// caused: the X-Request-ID header it was sent with, or the ID generated for it otherwise.
+func RequestID(c echo.Context) string {
+	if id := c.Request().Header.Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return c.Response().Header().Get(echo.HeaderXRequestID)
+}
+
+// bearerToken returns the token of the request's "Authorization: Bearer" header.
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get(echo.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,32 @@
+// Package auth serves the OpenID Connect login of the web UI and authenticates API requests with
+// the session it establishes or with a bearer token issued by the identity provider.
+package auth
+
+import (
+	authcore "aeswibon.com/github/gitopsctl/internal/core/auth"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Handler handles login requests and authenticates API requests.
+type Handler struct {
+	logger        *zap.Logger
+	authenticator *authcore.Authenticator
+}
+
+// NewHandler creates a new authentication handler.
+func NewHandler(logger *zap.Logger, authenticator *authcore.Authenticator) *Handler {
+	return &Handler{
+		logger:        logger,
+		authenticator: authenticator,
+	}
+}
+
+// RegisterRoutes registers the login, callback, logout and current user routes.
+func RegisterRoutes(e *echo.Echo, handler *Handler) {
+	g := e.Group("/auth")
+	g.GET("/login", handler.Login)
+	g.GET("/callback", handler.Callback)
+	g.GET("/logout", handler.Logout)
+	g.GET("/me", handler.Me, handler.Middleware)
+}
@@ -0,0 +1,48 @@
+package cluster
+
+import (
+	"context"
+	"net/http"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Capacity summarizes the CPU and memory of a Kubernetes cluster's nodes.
+// It queries the cluster live, including usage from metrics-server when it is installed.
+// If the cluster does not exist, it returns a 404 Not Found error, and if the cluster
+// cannot be queried, a 502 Bad Gateway error.
+func (h *Handler) Capacity(c echo.Context) error {
+	name := c.Param("name")
+
+	h.clusters.RLock()
+	cl, ok := h.clusters.Get(name)
+	var kubeconfigPath string
+	var opts k8s.ClientOptions
+	if ok {
+		kubeconfigPath = cl.KubeconfigPath
+		opts = k8s.ClientOptions{QPS: cl.QPS, Burst: cl.Burst, InCluster: cl.InCluster}
+	}
+	h.clusters.RUnlock()
+	if !ok {
+		return apierror.NotFound("Cluster not found")
+	}
+
+	client, err := k8s.NewClientSet(h.logger, kubeconfigPath, opts)
+	if err != nil {
+		h.logger.Error("Failed to create Kubernetes client for cluster capacity", zap.String("name", name), zap.Error(err))
+		return apierror.Upstream("Failed to create Kubernetes client: " + err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), CapacityTimeout)
+	defer cancel()
+
+	capacity, err := client.Capacity(ctx)
+	if err != nil {
+		h.logger.Error("Failed to get cluster capacity", zap.String("name", name), zap.Error(err))
+		return apierror.FromK8s("Failed to get cluster capacity", err)
+	}
+	return c.JSON(http.StatusOK, ConvertToCapacityResponse(name, capacity))
+}
@@ -3,6 +3,7 @@ package cluster
 import (
 	"net/http"
 
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
 	"github.com/labstack/echo/v4"
 )
 
@@ -17,7 +18,7 @@ func (h *Handler) Get(c echo.Context) error {
 
 	cl, ok := h.clusters.Get(name)
 	if !ok {
-		return echo.NewHTTPError(http.StatusNotFound, "Cluster not found")
+		return apierror.NotFound("Cluster not found")
 	}
 	return c.JSON(http.StatusOK, ConvertToResponse(cl))
 }
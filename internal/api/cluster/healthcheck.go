@@ -3,6 +3,8 @@ package cluster
 import (
 	"net/http"
 
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	clustercore "aeswibon.com/github/gitopsctl/internal/core/cluster"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
@@ -18,16 +20,16 @@ func (h *Handler) HealthCheck(c echo.Context) error {
 	clusterToUpdate, exists := h.clusters.Get(name)
 	if !exists {
 		h.logger.Warn("Attempted to trigger check for non-existent cluster", zap.String("name", name))
-		return echo.NewHTTPError(http.StatusNotFound, ErrorResponse{Message: "Cluster not found"})
+		return apierror.NotFound("Cluster not found")
 	}
 
 	h.controller.TriggerClusterHealthCheck(name)
-	clusterToUpdate.Status = "CheckRequested"
-	clusterToUpdate.Message = "Manual health check requested. Controller received signal."
+	// Every cluster status can move to CheckRequested, so the transition cannot fail
+	_, _ = clusterToUpdate.SetStatus(clustercore.StatusCheckRequested, "Manual health check requested. Controller received signal.")
 	h.logger.Info("Manual cluster health check requested via API", zap.String("name", name))
 
 	return c.JSON(http.StatusAccepted, HealthCheckTriggerResponse{
 		Message: "Manual cluster health check requested. The controller will process it shortly.",
-		Status:  "CheckRequested",
+		Status:  clustercore.StatusCheckRequested,
 	})
 }
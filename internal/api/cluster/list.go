@@ -2,18 +2,33 @@ package cluster
 
 import (
 	"net/http"
+	"strings"
 
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/common"
+	clustercore "aeswibon.com/github/gitopsctl/internal/core/cluster"
 	"github.com/labstack/echo/v4"
 )
 
 // List handles the retrieval of all registered Kubernetes clusters.
-// It returns a list of Response objects containing the details of each cluster.
+// It returns a list of Response objects containing the details of each cluster. The q query
+// parameter keeps those whose name, kubeconfig path or message contains it, ignoring case, and the
+// status query parameter, such as "unreachable,degraded" or "!active", those with a matching status.
 func (h *Handler) List(c echo.Context) error {
+	q := strings.TrimSpace(c.QueryParam("q"))
+	filter, err := common.ParseStatusFilter(c.QueryParam("status"), clustercore.Statuses)
+	if err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+
 	h.clusters.RLock()
 	defer h.clusters.RUnlock()
 
-	var responses []Response
+	responses := []Response{}
 	for _, cl := range h.clusters.List() {
+		if !filter.Matches(string(cl.Status)) || !cl.MatchesSearch(q) {
+			continue
+		}
 		responses = append(responses, ConvertToResponse(cl))
 	}
 	return c.JSON(http.StatusOK, responses)
@@ -0,0 +1,65 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/owner"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Owner resolves which registered application manages a live resource in a Kubernetes cluster.
+// The resource is selected with the "kind", "name", and optional "namespace" query parameters.
+// If the cluster or the resource does not exist, it returns a 404 Not Found error, and if the
+// cluster cannot be queried, a 502 Bad Gateway error.
+func (h *Handler) Owner(c echo.Context) error {
+	name := c.Param("name")
+	query := owner.Query{
+		Cluster:   name,
+		Kind:      strings.TrimSpace(c.QueryParam("kind")),
+		Namespace: strings.TrimSpace(c.QueryParam("namespace")),
+		Name:      strings.TrimSpace(c.QueryParam("name")),
+	}
+	if query.Kind == "" || query.Name == "" {
+		return apierror.ValidationFailed("The 'kind' and 'name' query parameters are required")
+	}
+
+	h.clusters.RLock()
+	cl, ok := h.clusters.Get(name)
+	var kubeconfigPath string
+	var opts k8s.ClientOptions
+	if ok {
+		kubeconfigPath = cl.KubeconfigPath
+		opts = k8s.ClientOptions{QPS: cl.QPS, Burst: cl.Burst, InCluster: cl.InCluster}
+	}
+	h.clusters.RUnlock()
+	if !ok {
+		return apierror.NotFound("Cluster not found")
+	}
+
+	client, err := k8s.NewClientSet(h.logger, kubeconfigPath, opts)
+	if err != nil {
+		h.logger.Error("Failed to create Kubernetes client for owner lookup", zap.String("name", name), zap.Error(err))
+		return apierror.Upstream("Failed to create Kubernetes client: " + err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), OwnerTimeout)
+	defer cancel()
+
+	h.apps.RLock()
+	result, err := owner.Find(ctx, client, h.apps, query)
+	h.apps.RUnlock()
+	if errors.Is(err, owner.ErrNotFound) {
+		return apierror.NotFound(err.Error())
+	}
+	if err != nil {
+		h.logger.Error("Failed to find resource owner", zap.String("name", name), zap.Error(err))
+		return apierror.FromK8s("Failed to find resource owner", err)
+	}
+	return c.JSON(http.StatusOK, ConvertToOwnerResponse(result))
+}
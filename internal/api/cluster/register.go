@@ -2,49 +2,100 @@ package cluster
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
 	clustercore "aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
 
 // Register handles the registration of a new Kubernetes cluster.
 // It binds the request payload to a RegisterRequest struct, validates it,
-// and either adds a new cluster or updates an existing one.
+// and either adds a new cluster or updates an existing one. The change is recorded in the audit log.
 func (h *Handler) Register(c echo.Context) error {
 	req := new(RegisterRequest)
 	if err := c.Bind(req); err != nil {
 		h.logger.Error("Failed to bind register cluster request", zap.Error(err))
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+		return apierror.ValidationFailed("Invalid request payload")
 	}
 	if err := c.Validate(req); err != nil {
 		h.logger.Error("Failed to validate register cluster request", zap.Error(err))
 		return err
 	}
 
-	h.clusters.Lock()
-	defer h.clusters.Unlock()
+	req.Name = strings.TrimSpace(req.Name)
+	if err := common.ValidateName(req.Name); err != nil {
+		return apierror.ValidationFailed("Invalid cluster name: " + err.Error())
+	}
+	if err := clustercore.ValidateHealthCheckInterval(req.HealthCheckInterval); err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+	if err := render.ValidateVariables(req.Variables); err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+	if err := k8s.ValidateImpersonation(req.ImpersonateUser, req.ImpersonateGroups); err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+	if err := common.ValidateLabels(req.Labels); err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+	if req.InCluster && !k8s.InClusterAvailable() {
+		return apierror.ValidationFailed("in_cluster can only be used when the controller runs inside a Kubernetes pod")
+	}
 
-	if _, exists := h.clusters.Get(req.Name); exists {
+	h.clusters.RLock()
+	_, exists := h.clusters.Get(req.Name)
+	h.clusters.RUnlock()
+	if exists {
 		h.logger.Warn("Cluster with this name already exists. Updating its kubeconfig.", zap.String("name", req.Name))
 	}
 
 	newCluster := &clustercore.Cluster{
-		Name:           req.Name,
-		KubeconfigPath: req.KubeconfigPath,
-		RegisteredAt:   time.Now(),
-		Status:         "Active",
-		Message:        "Cluster registered successfully.",
+		Name:                 req.Name,
+		KubeconfigPath:       req.KubeconfigPath,
+		InCluster:            req.InCluster,
+		QPS:                  req.QPS,
+		Burst:                req.Burst,
+		MaxConcurrentApplies: req.MaxConcurrentApplies,
+		HealthCheckInterval:  req.HealthCheckInterval,
+		Variables:            req.Variables,
+		Labels:               req.Labels,
+		ImpersonateUser:      req.ImpersonateUser,
+		ImpersonateGroups:    req.ImpersonateGroups,
+		RegisteredAt:         time.Now(),
+		Status:               clustercore.StatusActive,
+		Message:              "Cluster registered successfully.",
 	}
-	h.clusters.Add(newCluster)
-
-	if err := clustercore.SaveClusters(h.clusters, clustercore.DefaultClusterConfigFile); err != nil {
+	// The controller persists the registration and health checks the cluster
+	if err := h.controller.RegisterCluster(newCluster); err != nil {
 		h.logger.Error("Failed to save clusters after registration", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save cluster configuration")
+		return apierror.Internal("Failed to save cluster configuration")
 	}
 
-	h.controller.TriggerClusterHealthCheck(req.Name)
+	// A standalone API server keeps its own copy of the state; mirror the registration until its next refresh
+	h.clusters.Lock()
+	h.clusters.Add(newCluster)
+	h.clusters.Unlock()
+
+	entry := audit.Entry{
+		Actor:     auth.Actor(c),
+		Action:    audit.ActionRegister,
+		Kind:      event.KindCluster,
+		Name:      req.Name,
+		Reason:    req.Reason,
+		RequestID: auth.RequestID(c),
+	}
+	if err := audit.Record(audit.DefaultAuditFile, entry); err != nil {
+		h.logger.Warn("Failed to record audit entry", zap.String("name", req.Name), zap.Error(err))
+	}
 
 	h.logger.Info("Cluster registered/updated via API", zap.String("name", req.Name))
 	return c.JSON(http.StatusOK, map[string]string{"message": "Cluster registered/updated successfully", "name": req.Name})
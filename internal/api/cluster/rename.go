@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"net/http"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Rename handles renaming a Kubernetes cluster.
+//
+// The controller renames and persists the cluster, points the applications deployed to it at the
+// new name, and restarts their reconciliation loops. The cluster's health history and events are kept.
+// It returns 404 if the cluster does not exist and 409 if the new name is already taken.
+func (h *Handler) Rename(c echo.Context) error {
+	name := c.Param("name")
+
+	req := new(RenameRequest)
+	if err := c.Bind(req); err != nil {
+		h.logger.Error("Failed to bind rename cluster request", zap.Error(err))
+		return apierror.ValidationFailed("Invalid request payload")
+	}
+	if err := c.Validate(req); err != nil {
+		h.logger.Error("Failed to validate rename cluster request", zap.Error(err))
+		return err
+	}
+	newName := strings.TrimSpace(req.Name)
+	if err := common.ValidateName(newName); err != nil {
+		return apierror.ValidationFailed("Invalid cluster name: " + err.Error())
+	}
+	if newName == name {
+		return apierror.ValidationFailed("New name must differ from the current name")
+	}
+
+	h.clusters.RLock()
+	_, exists := h.clusters.Get(name)
+	_, taken := h.clusters.Get(newName)
+	h.clusters.RUnlock()
+	if !exists {
+		return apierror.NotFound("Cluster not found")
+	}
+	if taken {
+		return apierror.Conflict("Cluster '" + newName + "' already exists")
+	}
+
+	if err := h.controller.RenameCluster(name, newName); err != nil {
+		h.logger.Error("Failed to rename cluster", zap.String("name", name), zap.String("new_name", newName), zap.Error(err))
+		return apierror.Internal("Failed to rename cluster: " + err.Error())
+	}
+
+	// A standalone API server keeps its own copy of the state; mirror the rename until its next refresh
+	h.apps.Lock()
+	h.apps.RenameCluster(name, newName)
+	h.apps.Unlock()
+
+	h.clusters.Lock()
+	defer h.clusters.Unlock()
+	if _, ok := h.clusters.Get(name); ok {
+		_ = h.clusters.Rename(name, newName)
+	}
+	renamed, ok := h.clusters.Get(newName)
+	if !ok {
+		return apierror.NotFound("Cluster not found")
+	}
+
+	h.logger.Info("Cluster renamed via API", zap.String("name", name), zap.String("new_name", newName))
+	return c.JSON(http.StatusOK, ConvertToResponse(renamed))
+}
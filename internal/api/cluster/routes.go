@@ -1,8 +1,10 @@
 package cluster
 
 import (
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
 	"aeswibon.com/github/gitopsctl/internal/controller"
 	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	authcore "aeswibon.com/github/gitopsctl/internal/core/auth"
 	clustercore "aeswibon.com/github/gitopsctl/internal/core/cluster"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -13,11 +15,11 @@ type Handler struct {
 	logger     *zap.Logger
 	clusters   *clustercore.Clusters
 	apps       *appcore.Applications
-	controller *controller.Controller
+	controller controller.Commander
 }
 
 // NewHandler creates a new cluster handler.
-func NewHandler(logger *zap.Logger, clusters *clustercore.Clusters, apps *appcore.Applications, controller *controller.Controller) *Handler {
+func NewHandler(logger *zap.Logger, clusters *clustercore.Clusters, apps *appcore.Applications, controller controller.Commander) *Handler {
 	return &Handler{
 		logger:     logger,
 		clusters:   clusters,
@@ -29,9 +31,12 @@ func NewHandler(logger *zap.Logger, clusters *clustercore.Clusters, apps *appcor
 // RegisterRoutes registers all cluster-related routes.
 func RegisterRoutes(g *echo.Group, handler *Handler) {
 	// Clusters Management
-	g.POST("/clusters", handler.Register)
-	g.GET("/clusters", handler.List)
-	g.GET("/clusters/:name", handler.Get)
-	g.DELETE("/clusters/:name", handler.Unregister)
-	g.POST("/clusters/:name/check", handler.HealthCheck)
+	g.POST("/clusters", handler.Register, auth.Require(authcore.PermissionClusterWrite))
+	g.GET("/clusters", handler.List, auth.Require(authcore.PermissionAppRead))
+	g.GET("/clusters/:name", handler.Get, auth.Require(authcore.PermissionAppRead))
+	g.DELETE("/clusters/:name", handler.Unregister, auth.Require(authcore.PermissionClusterWrite))
+	g.PATCH("/clusters/:name", handler.Rename, auth.Require(authcore.PermissionClusterWrite))
+	g.POST("/clusters/:name/check", handler.HealthCheck, auth.Require(authcore.PermissionAppSync))
+	g.GET("/clusters/:name/capacity", handler.Capacity, auth.Require(authcore.PermissionAppRead))
+	g.GET("/clusters/:name/owner", handler.Owner, auth.Require(authcore.PermissionAppRead))
 }
@@ -4,6 +4,15 @@ import (
 	"time"
 
 	clustercore "aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/owner"
+)
+
+const (
+	// CapacityTimeout is how long a cluster capacity request may spend querying the cluster.
+	CapacityTimeout = 30 * time.Second
+	// OwnerTimeout is how long a resource owner lookup may spend querying the cluster.
+	OwnerTimeout = 30 * time.Second
 )
 
 // RegisterRequest defines the payload for registering a new cluster.
@@ -12,7 +21,35 @@ type RegisterRequest struct {
 	// Name is the unique identifier for the cluster.
 	Name string `json:"name" validate:"required"`
 	// KubeconfigPath is the file path to the kubeconfig file for accessing the Kubernetes cluster.
-	KubeconfigPath string `json:"kubeconfig_path" validate:"required,kubeconfigfile"`
+	// It is required unless InCluster is set.
+	KubeconfigPath string `json:"kubeconfig_path" validate:"required_without=InCluster,omitempty,kubeconfigfile"`
+	// InCluster registers the cluster the controller runs in, connected to with its pod's service account.
+	InCluster bool `json:"in_cluster" validate:"excluded_with=KubeconfigPath"`
+	// QPS limits the queries per second the controller sends to the cluster. Zero uses the default.
+	QPS float32 `json:"qps" validate:"gte=0"`
+	// Burst is the maximum burst of queries allowed above QPS. Zero uses the default.
+	Burst int `json:"burst" validate:"gte=0"`
+	// MaxConcurrentApplies limits how many applications apply to the cluster at the same time. Zero leaves it unlimited.
+	MaxConcurrentApplies int `json:"max_concurrent_applies" validate:"gte=0"`
+	// HealthCheckInterval is how often the controller checks the cluster's health (e.g., "1m"). Empty uses the default.
+	HealthCheckInterval string `json:"health_check_interval"`
+	// Variables are substituted into the manifests of the cluster's applications that use variable substitution.
+	Variables map[string]string `json:"variables,omitempty"`
+	// Labels are matched by the cluster selectors of applications placed automatically.
+	Labels map[string]string `json:"labels,omitempty"`
+	// ImpersonateUser is the user the resources of the cluster's applications are applied as, unless
+	// an application sets its own. Empty applies them with the cluster's credentials as they are.
+	ImpersonateUser string `json:"impersonate_user"`
+	// ImpersonateGroups are the groups impersonated along with ImpersonateUser.
+	ImpersonateGroups []string `json:"impersonate_groups,omitempty"`
+	// Reason is why the cluster is registered or updated, recorded in the audit log.
+	Reason string `json:"reason"`
+}
+
+// RenameRequest represents the request payload for renaming a cluster.
+type RenameRequest struct {
+	// Name is the new name of the cluster.
+	Name string `json:"name" validate:"required"`
 }
 
 // Response defines the structure for returning cluster details via the API.
@@ -22,35 +59,170 @@ type Response struct {
 	Name string `json:"name"`
 	// KubeconfigPath is the file path to the kubeconfig file for accessing the Kubernetes cluster.
 	KubeconfigPath string `json:"kubeconfig_path"`
+	// InCluster is true if the controller connects to the cluster with its pod's service account.
+	InCluster bool `json:"in_cluster,omitempty"`
+	// QPS is the client-side queries per second limit for the cluster, zero if the default is used.
+	QPS float32 `json:"qps,omitempty"`
+	// Burst is the client-side burst limit for the cluster, zero if the default is used.
+	Burst int `json:"burst,omitempty"`
+	// MaxConcurrentApplies is how many applications may apply to the cluster at the same time, zero if unlimited.
+	MaxConcurrentApplies int `json:"max_concurrent_applies,omitempty"`
+	// HealthCheckInterval is how often the controller checks the cluster's health.
+	HealthCheckInterval string `json:"health_check_interval"`
+	// Variables are substituted into the manifests of the cluster's applications that use variable substitution.
+	Variables map[string]string `json:"variables,omitempty"`
+	// Labels are matched by the cluster selectors of applications placed automatically.
+	Labels map[string]string `json:"labels,omitempty"`
+	// ImpersonateUser is the user the resources of the cluster's applications are applied as, if any.
+	ImpersonateUser string `json:"impersonate_user,omitempty"`
+	// ImpersonateGroups are the groups impersonated along with ImpersonateUser.
+	ImpersonateGroups []string `json:"impersonate_groups,omitempty"`
 	// RegisteredAt is the timestamp when the cluster was registered with the GitOps controller.
 	RegisteredAt time.Time `json:"registered_at"`
-	// Status indicates the current status of the cluster (e.g., "active", "inactive", "error").
-	Status string `json:"status"`
+	// Status indicates the current status of the cluster (e.g., "Active", "Degraded", "Unreachable", "CredentialsExpired").
+	Status clustercore.Status `json:"status"`
 	// Message provides additional information about the cluster's status, such as error messages or warnings.
 	Message string `json:"message"`
 	// LastCheckedAt is the timestamp of the last health check performed on the cluster.
 	LastCheckedAt time.Time `json:"last_checked_at"`
+	// Health holds the diagnostics from the last health check that reached the cluster.
+	Health *clustercore.ClusterHealth `json:"health,omitempty"`
 }
 
 // HealthCheckTriggerResponse represents the response for health check trigger requests.
 type HealthCheckTriggerResponse struct {
-	Message string `json:"message"`
-	Status  string `json:"status"`
-}
-
-// ErrorResponse represents an error response.
-type ErrorResponse struct {
-	Message string `json:"message"`
+	Message string             `json:"message"`
+	Status  clustercore.Status `json:"status"`
 }
 
 // ConvertToResponse converts a Cluster to a Response.
 func ConvertToResponse(cl *clustercore.Cluster) Response {
 	return Response{
-		Name:           cl.Name,
-		KubeconfigPath: cl.KubeconfigPath,
-		RegisteredAt:   cl.RegisteredAt,
-		Status:         cl.Status,
-		Message:        cl.Message,
-		LastCheckedAt:  cl.LastCheckedAt,
+		Name:                 cl.Name,
+		KubeconfigPath:       cl.KubeconfigPath,
+		InCluster:            cl.InCluster,
+		QPS:                  cl.QPS,
+		Burst:                cl.Burst,
+		MaxConcurrentApplies: cl.MaxConcurrentApplies,
+		HealthCheckInterval:  cl.EffectiveHealthCheckInterval().String(),
+		Variables:            cl.Variables,
+		Labels:               cl.Labels,
+		ImpersonateUser:      cl.ImpersonateUser,
+		ImpersonateGroups:    cl.ImpersonateGroups,
+		RegisteredAt:         cl.RegisteredAt,
+		Status:               cl.Status,
+		Message:              cl.Message,
+		LastCheckedAt:        cl.LastCheckedAt,
+		Health:               cl.Health,
+	}
+}
+
+// ResourceSummaryResponse holds CPU and memory figures for a node or a whole cluster.
+type ResourceSummaryResponse struct {
+	// CPUAllocatableMillis is the CPU available for pods, in millicores.
+	CPUAllocatableMillis int64 `json:"cpu_allocatable_millis"`
+	// CPURequestedMillis is the CPU requested by running pods, in millicores.
+	CPURequestedMillis int64 `json:"cpu_requested_millis"`
+	// CPUUsageMillis is the CPU in use, in millicores, if metrics are available.
+	CPUUsageMillis int64 `json:"cpu_usage_millis,omitempty"`
+	// MemoryAllocatableBytes is the memory available for pods.
+	MemoryAllocatableBytes int64 `json:"memory_allocatable_bytes"`
+	// MemoryRequestedBytes is the memory requested by running pods.
+	MemoryRequestedBytes int64 `json:"memory_requested_bytes"`
+	// MemoryUsageBytes is the memory in use, if metrics are available.
+	MemoryUsageBytes int64 `json:"memory_usage_bytes,omitempty"`
+	// Pods is the number of running pods.
+	Pods int `json:"pods"`
+}
+
+// NodeCapacityResponse holds the capacity of a single node.
+type NodeCapacityResponse struct {
+	// Name is the name of the node.
+	Name string `json:"name"`
+	// Ready reports whether the node is ready.
+	Ready bool `json:"ready"`
+	ResourceSummaryResponse
+}
+
+// CapacityResponse represents the response for cluster capacity requests.
+type CapacityResponse struct {
+	// Cluster is the name of the cluster.
+	Cluster string `json:"cluster"`
+	// MetricsAvailable reports whether usage figures were retrieved from metrics-server.
+	MetricsAvailable bool `json:"metrics_available"`
+	// Nodes holds the capacity of each node, sorted by name.
+	Nodes []NodeCapacityResponse `json:"nodes"`
+	// Total holds the sum over all nodes.
+	Total ResourceSummaryResponse `json:"total"`
+}
+
+// ConvertToCapacityResponse converts the capacity of a cluster to a CapacityResponse.
+func ConvertToCapacityResponse(clusterName string, capacity *k8s.Capacity) CapacityResponse {
+	resp := CapacityResponse{
+		Cluster:          clusterName,
+		MetricsAvailable: capacity.MetricsAvailable,
+		Nodes:            make([]NodeCapacityResponse, 0, len(capacity.Nodes)),
+		Total:            convertResourceSummary(capacity.Total),
+	}
+	for _, node := range capacity.Nodes {
+		resp.Nodes = append(resp.Nodes, NodeCapacityResponse{
+			Name:                    node.Name,
+			Ready:                   node.Ready,
+			ResourceSummaryResponse: convertResourceSummary(node.ResourceSummary),
+		})
+	}
+	return resp
+}
+
+// convertResourceSummary converts a resource summary to a ResourceSummaryResponse.
+func convertResourceSummary(s k8s.ResourceSummary) ResourceSummaryResponse {
+	return ResourceSummaryResponse{
+		CPUAllocatableMillis:   s.CPUAllocatable,
+		CPURequestedMillis:     s.CPURequested,
+		CPUUsageMillis:         s.CPUUsage,
+		MemoryAllocatableBytes: s.MemoryAllocatable,
+		MemoryRequestedBytes:   s.MemoryRequested,
+		MemoryUsageBytes:       s.MemoryUsage,
+		Pods:                   s.Pods,
+	}
+}
+
+// OwnerResponse represents the response for resource owner lookups.
+type OwnerResponse struct {
+	// Cluster is the name of the cluster the resource lives in.
+	Cluster string `json:"cluster"`
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the resource, empty for cluster-scoped resources.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+	// Managed reports whether an application manages the resource.
+	Managed bool `json:"managed"`
+	// App is the name of the managing application.
+	App string `json:"app,omitempty"`
+	// Registered reports whether the managing application is still registered for the cluster.
+	Registered bool `json:"registered"`
+	// Revision is the Git commit the resource was last applied from.
+	Revision string `json:"revision,omitempty"`
+	// Controller is the controller instance that last applied the resource.
+	Controller string `json:"controller,omitempty"`
+	// Source is how the owner was found: "labels" or "inventory".
+	Source string `json:"source,omitempty"`
+}
+
+// ConvertToOwnerResponse converts the result of an owner lookup to an OwnerResponse.
+func ConvertToOwnerResponse(result *owner.Result) OwnerResponse {
+	return OwnerResponse{
+		Cluster:    result.Cluster,
+		Kind:       result.Kind,
+		Namespace:  result.Namespace,
+		Name:       result.Name,
+		Managed:    result.Managed,
+		App:        result.App,
+		Registered: result.Registered,
+		Revision:   result.Revision,
+		Controller: result.Controller,
+		Source:     result.Source,
 	}
 }
@@ -3,38 +3,43 @@ package cluster
 import (
 	"net/http"
 
-	clustercore "aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
 
 // Unregister handles the removal of a Kubernetes cluster by name.
-// It deletes the cluster from the clusters store and saves the updated configuration.
+// The controller deletes the cluster from the clusters store and saves the updated configuration.
 func (h *Handler) Unregister(c echo.Context) error {
 	name := c.Param("name")
 
-	h.clusters.Lock()
-	defer h.clusters.Unlock()
-
+	h.clusters.RLock()
 	_, exists := h.clusters.Get(name)
+	h.clusters.RUnlock()
 	if !exists {
-		return echo.NewHTTPError(http.StatusNotFound, "Cluster not found")
+		return apierror.NotFound("Cluster not found")
 	}
 
 	h.apps.RLock()
-	defer h.apps.RUnlock()
 	for _, app := range h.apps.List() {
 		if app.ClusterName == name {
-			return echo.NewHTTPError(http.StatusConflict, "Cluster '"+name+"' is in use by application '"+app.Name+"'. Please unregister or update applications first.")
+			h.apps.RUnlock()
+			return apierror.Conflict("Cluster '" + name + "' is in use by application '" + app.Name + "'. Please unregister or update applications first.")
 		}
 	}
+	h.apps.RUnlock()
 
-	h.clusters.Delete(name)
-	if err := clustercore.SaveClusters(h.clusters, clustercore.DefaultClusterConfigFile); err != nil {
-		h.logger.Error("Failed to save clusters after unregister", zap.Error(err))
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to remove cluster configuration")
+	// The controller checks again that no application uses the cluster and persists the removal
+	if err := h.controller.UnregisterCluster(name); err != nil {
+		h.logger.Error("Failed to unregister cluster", zap.String("name", name), zap.Error(err))
+		return apierror.Internal("Failed to unregister cluster: " + err.Error())
 	}
 
+	// A standalone API server keeps its own copy of the state; mirror the removal until its next refresh
+	h.clusters.Lock()
+	h.clusters.Delete(name)
+	h.clusters.Unlock()
+
 	h.logger.Info("Cluster unregistered via API", zap.String("name", name))
 	return c.JSON(http.StatusOK, map[string]string{"message": "Cluster unregistered successfully", "name": name})
 }
@@ -0,0 +1,50 @@
+package event
+
+import (
+	"net/http"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	eventcore "aeswibon.com/github/gitopsctl/internal/core/event"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// List handles the retrieval of recorded controller events, oldest first.
+// The optional 'app' and 'cluster' query parameters restrict the result to events
+// about a single application or cluster, and 'type' restricts it to Normal or Warning events.
+func (h *Handler) List(c echo.Context) error {
+	appName := strings.TrimSpace(c.QueryParam("app"))
+	clusterName := strings.TrimSpace(c.QueryParam("cluster"))
+	eventType := strings.TrimSpace(c.QueryParam("type"))
+
+	if appName != "" && clusterName != "" {
+		return apierror.ValidationFailed("Only one of 'app' or 'cluster' may be specified")
+	}
+
+	kind, name := "", ""
+	switch {
+	case appName != "":
+		kind, name = eventcore.KindApplication, appName
+	case clusterName != "":
+		kind, name = eventcore.KindCluster, clusterName
+	}
+
+	events, err := eventcore.LoadEvents(h.eventFile)
+	if err != nil {
+		h.logger.Error("Failed to load events", zap.Error(err))
+		return apierror.Internal("Failed to load events")
+	}
+
+	events.RLock()
+	defer events.RUnlock()
+
+	responses := []Response{}
+	for _, ev := range events.Filter(kind, name) {
+		if eventType != "" && !strings.EqualFold(string(ev.Type), eventType) {
+			continue
+		}
+		responses = append(responses, ConvertToResponse(ev))
+	}
+	return c.JSON(http.StatusOK, responses)
+}
@@ -0,0 +1,29 @@
+package event
+
+import (
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
+	authcore "aeswibon.com/github/gitopsctl/internal/core/auth"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Handler handles event-related HTTP requests.
+type Handler struct {
+	logger    *zap.Logger
+	eventFile string
+}
+
+// NewHandler creates a new event handler.
+// Events are read from eventFile on each request so that the API reflects events
+// recorded by a controller running in a separate process.
+func NewHandler(logger *zap.Logger, eventFile string) *Handler {
+	return &Handler{
+		logger:    logger,
+		eventFile: eventFile,
+	}
+}
+
+// RegisterRoutes registers all event-related routes.
+func RegisterRoutes(g *echo.Group, handler *Handler) {
+	g.GET("/events", handler.List, auth.Require(authcore.PermissionAppRead))
+}
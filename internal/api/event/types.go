@@ -0,0 +1,41 @@
+package event
+
+import (
+	"time"
+
+	eventcore "aeswibon.com/github/gitopsctl/internal/core/event"
+)
+
+// Response defines the structure for returning controller events via the API.
+type Response struct {
+	// Kind is the kind of the object the event is about (e.g., "Application", "Cluster").
+	Kind string `json:"kind"`
+	// Name is the name of the object the event is about.
+	Name string `json:"name"`
+	// Type is the severity of the event ("Normal" or "Warning").
+	Type string `json:"type"`
+	// Reason is a short, CamelCase reason for the event.
+	Reason string `json:"reason"`
+	// Message is a human-readable description of the event.
+	Message string `json:"message"`
+	// Count is the number of times the event has occurred.
+	Count int `json:"count"`
+	// FirstTimestamp is when the event was first recorded.
+	FirstTimestamp time.Time `json:"first_timestamp"`
+	// LastTimestamp is when the event was most recently recorded.
+	LastTimestamp time.Time `json:"last_timestamp"`
+}
+
+// ConvertToResponse converts an event to its API response representation.
+func ConvertToResponse(ev *eventcore.Event) Response {
+	return Response{
+		Kind:           ev.InvolvedObject.Kind,
+		Name:           ev.InvolvedObject.Name,
+		Type:           string(ev.Type),
+		Reason:         ev.Reason,
+		Message:        ev.Message,
+		Count:          ev.Count,
+		FirstTimestamp: ev.FirstTimestamp,
+		LastTimestamp:  ev.LastTimestamp,
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// HealthStatusOK indicates that a probe or component check passed.
+	HealthStatusOK = "ok"
+	// HealthStatusFail indicates that a probe or component check failed.
+	HealthStatusFail = "fail"
+)
+
+// ComponentCheck represents the result of a single component-level health check.
+type ComponentCheck struct {
+	// Status is either "ok" or "fail".
+	Status string `json:"status"`
+	// Message provides additional information about the check result.
+	Message string `json:"message,omitempty"`
+}
+
+// HealthResponse represents the response payload for the liveness and readiness endpoints.
+// It is structured so that Kubernetes probes can rely on the HTTP status code while humans
+// and tooling can inspect the individual component checks.
+type HealthResponse struct {
+	// Status is the aggregated status of all checks: "ok" or "fail".
+	Status string `json:"status"`
+	// Timestamp is the time at which the checks were evaluated.
+	Timestamp time.Time `json:"timestamp"`
+	// Checks holds the component-level results keyed by component name.
+	Checks map[string]ComponentCheck `json:"checks,omitempty"`
+}
+
+// Liveness reports whether the API server process is alive.
+// It performs no dependency checks and always responds with 200 OK while the process can serve requests.
+func (s *Server) Liveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, HealthResponse{
+		Status:    HealthStatusOK,
+		Timestamp: time.Now(),
+		Checks: map[string]ComponentCheck{
+			"process": {Status: HealthStatusOK},
+		},
+	})
+}
+
+// Readiness reports whether the server is ready to accept traffic.
+// It verifies that configuration is loaded, the controller dispatcher is running and the
+// state store is writable. It responds with 503 Service Unavailable if any check fails.
+func (s *Server) Readiness(c echo.Context) error {
+	checks := map[string]ComponentCheck{
+		"config":     s.checkConfigLoaded(),
+		"controller": s.checkController(),
+		"store":      checkStoreWritable(filepath.Dir(appcore.DefaultAppConfigFile)),
+	}
+
+	resp := HealthResponse{
+		Status:    HealthStatusOK,
+		Timestamp: time.Now(),
+		Checks:    checks,
+	}
+	for _, check := range checks {
+		if check.Status != HealthStatusOK {
+			resp.Status = HealthStatusFail
+			return c.JSON(http.StatusServiceUnavailable, resp)
+		}
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// checkConfigLoaded verifies that the application and cluster stores have been loaded.
+func (s *Server) checkConfigLoaded() ComponentCheck {
+	if s.apps == nil || s.clusters == nil {
+		return ComponentCheck{Status: HealthStatusFail, Message: "configuration not loaded"}
+	}
+
+	s.apps.RLock()
+	appCount := len(s.apps.List())
+	s.apps.RUnlock()
+
+	s.clusters.RLock()
+	clusterCount := len(s.clusters.List())
+	s.clusters.RUnlock()
+
+	return ComponentCheck{
+		Status:  HealthStatusOK,
+		Message: fmt.Sprintf("%d application(s), %d cluster(s) loaded", appCount, clusterCount),
+	}
+}
+
+// checkController verifies that the controller command dispatcher is running.
+func (s *Server) checkController() ComponentCheck {
+	if s.controller == nil {
+		return ComponentCheck{Status: HealthStatusFail, Message: "controller not configured"}
+	}
+	if !s.controller.IsDispatcherRunning() {
+		return ComponentCheck{Status: HealthStatusFail, Message: "controller dispatcher is not running"}
+	}
+	return ComponentCheck{Status: HealthStatusOK, Message: "controller dispatcher is running"}
+}
+
+// checkStoreWritable verifies that the state directory exists and is writable
+// by creating and removing a temporary file inside it.
+func checkStoreWritable(dir string) ComponentCheck {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return ComponentCheck{Status: HealthStatusFail, Message: fmt.Sprintf("failed to create state directory %s: %v", dir, err)}
+	}
+	f, err := os.CreateTemp(dir, ".readyz-*")
+	if err != nil {
+		return ComponentCheck{Status: HealthStatusFail, Message: fmt.Sprintf("state directory %s is not writable: %v", dir, err)}
+	}
+	name := f.Name()
+	f.Close()
+	os.Remove(name)
+	return ComponentCheck{Status: HealthStatusOK, Message: fmt.Sprintf("state directory %s is writable", dir)}
+}
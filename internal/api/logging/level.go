@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"net/http"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Get handles the retrieval of the current log level.
+func (h *Handler) Get(c echo.Context) error {
+	return c.JSON(http.StatusOK, LevelResponse{Level: h.level.String()})
+}
+
+// Set handles requests to change the log level. The change takes effect immediately and lasts
+// until the process exits; the next start uses --log-level again.
+func (h *Handler) Set(c echo.Context) error {
+	req := new(LevelRequest)
+	if err := c.Bind(req); err != nil {
+		h.logger.Error("Failed to bind log level request", zap.Error(err))
+		return apierror.ValidationFailed("Invalid request payload")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+	level, err := zapcore.ParseLevel(req.Level)
+	if err != nil {
+		return apierror.ValidationFailed(err.Error())
+	}
+
+	previous := h.level.Level()
+	h.level.SetLevel(level)
+	h.logger.Info("Log level changed",
+		zap.String("from", previous.String()),
+		zap.String("to", level.String()))
+	return c.JSON(http.StatusOK, LevelResponse{Level: level.String()})
+}
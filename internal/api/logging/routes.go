@@ -0,0 +1,30 @@
+// Package logging serves the log level of the process serving the API, so it can be raised to
+// debug while investigating a problem and lowered again without a restart.
+package logging
+
+import (
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
+	authcore "aeswibon.com/github/gitopsctl/internal/core/auth"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Handler handles log level requests.
+type Handler struct {
+	logger *zap.Logger
+	level  zap.AtomicLevel
+}
+
+// NewHandler creates a new log level handler changing level, the level of the global logger.
+func NewHandler(logger *zap.Logger, level zap.AtomicLevel) *Handler {
+	return &Handler{
+		logger: logger,
+		level:  level,
+	}
+}
+
+// RegisterRoutes registers the log level routes.
+func RegisterRoutes(g *echo.Group, handler *Handler) {
+	g.GET("/controller/loglevel", handler.Get, auth.Require(authcore.PermissionAppRead))
+	g.PUT("/controller/loglevel", handler.Set, auth.Require(authcore.PermissionAdmin))
+}
@@ -0,0 +1,13 @@
+package logging
+
+// LevelRequest defines the structure for changing the log level via the API.
+type LevelRequest struct {
+	// Level is the new minimum level of logged messages.
+	Level string `json:"level" validate:"required,oneof=debug info warn error"`
+}
+
+// LevelResponse defines the structure for returning the log level via the API.
+type LevelResponse struct {
+	// Level is the minimum level of logged messages.
+	Level string `json:"level"`
+}
@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"time"
+
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/git"
+	"aeswibon.com/github/gitopsctl/internal/core/stats"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// ContentType is the content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Metrics handles requests for the controller's metrics in the Prometheus text exposition format.
+func (h *Handler) Metrics(c echo.Context) error {
+	h.apps.RLock()
+	apps := h.apps.List()
+	slices.SortFunc(apps, func(a, b *appcore.Application) int { return strings.Compare(a.Name, b.Name) })
+	var b strings.Builder
+	writeRepositoryMetrics(&b, apps)
+	h.apps.RUnlock()
+
+	now := time.Now()
+	syncs, err := stats.Load(h.syncLogFile, now.Add(-stats.DefaultWindow))
+	if err != nil {
+		h.logger.Error("Failed to load sync log, omitting DORA metrics", zap.Error(err))
+	} else {
+		writeDORAMetrics(&b, stats.Compute(syncs, stats.DefaultWindow, now))
+	}
+
+	return c.Blob(http.StatusOK, ContentType, []byte(b.String()))
+}
+
+// writeRepositoryMetrics writes the fetch and size metrics of the applications' repositories.
+func writeRepositoryMetrics(b *strings.Builder, apps []*appcore.Application) {
+	type sample struct {
+		labels string
+		value  float64
+	}
+	families := []struct {
+		name, kind, help string
+		samples          func(stats *appcore.RepositoryStats, labels string) []sample
+	}{
+		{
+			name: "gitopsctl_repository_fetch_duration_seconds",
+			kind: "gauge",
+			help: "How long the last clone, pull or ls-remote of the application's repository took.",
+			samples: func(stats *appcore.RepositoryStats, labels string) []sample {
+				var samples []sample
+				for _, operation := range git.Operations {
+					duration, at := stats.LastPullDuration, stats.LastPulledAt
+					switch operation {
+					case git.OperationClone:
+						duration, at = stats.LastCloneDuration, stats.LastClonedAt
+					case git.OperationCheck:
+						duration, at = stats.LastCheckDuration, stats.LastCheckedAt
+					}
+					if !at.IsZero() {
+						samples = append(samples, sample{labels + `,operation="` + string(operation) + `"`, duration.Seconds()})
+					}
+				}
+				return samples
+			},
+		},
+		{
+			name: "gitopsctl_repository_fetches_total",
+			kind: "counter",
+			help: "Successful clones and pulls of the application's repository, and ls-remotes that made a pull unnecessary.",
+			samples: func(stats *appcore.RepositoryStats, labels string) []sample {
+				return []sample{
+					{labels + `,operation="clone"`, float64(stats.Clones)},
+					{labels + `,operation="pull"`, float64(stats.Pulls)},
+					{labels + `,operation="ls-remote"`, float64(stats.Checks)},
+				}
+			},
+		},
+		{
+			name: "gitopsctl_repository_fetch_failures_total",
+			kind: "counter",
+			help: "Failed clones and pulls of the application's repository.",
+			samples: func(stats *appcore.RepositoryStats, labels string) []sample {
+				return []sample{{labels, float64(stats.FetchFailures)}}
+			},
+		},
+		{
+			name: "gitopsctl_repository_size_bytes",
+			kind: "gauge",
+			help: "Size of the clone of the application's repository on disk, including its worktree.",
+			samples: func(stats *appcore.RepositoryStats, labels string) []sample {
+				if stats.MeasuredRevision == "" {
+					return nil
+				}
+				return []sample{{labels, float64(stats.SizeBytes)}}
+			},
+		},
+		{
+			name: "gitopsctl_repository_objects",
+			kind: "gauge",
+			help: "Git objects in the clone of the application's repository.",
+			samples: func(stats *appcore.RepositoryStats, labels string) []sample {
+				if stats.MeasuredRevision == "" {
+					return nil
+				}
+				return []sample{{labels, float64(stats.Objects)}}
+			},
+		},
+	}
+
+	for _, family := range families {
+		fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", family.name, family.help, family.name, family.kind)
+		for _, app := range apps {
+			if app.Repository == nil {
+				continue
+			}
+			labels := fmt.Sprintf(`application="%s",repository="%s"`, escapeLabel(app.Name), escapeLabel(redactURL(app.RepoURL)))
+			for _, s := range family.samples(app.Repository, labels) {
+				fmt.Fprintf(b, "%s{%s} %g\n", family.name, s.labels, s.value)
+			}
+		}
+	}
+}
+
+// writeDORAMetrics writes the DORA metrics of each application with syncs in the report's window.
+func writeDORAMetrics(b *strings.Builder, report *stats.Report) {
+	families := []struct {
+		name, help string
+		value      func(d *stats.DORA) (float64, bool)
+	}{
+		{
+			name:  "gitopsctl_dora_deployment_frequency",
+			help:  "Changes deployed per day over the last 7 days.",
+			value: func(d *stats.DORA) (float64, bool) { return d.DeploymentFrequency, true },
+		},
+		{
+			name:  "gitopsctl_dora_lead_time_seconds",
+			help:  "Median time from a commit to the sync that deployed it over the last 7 days.",
+			value: func(d *stats.DORA) (float64, bool) { return d.LeadTime.Seconds(), d.Deploys > 0 },
+		},
+		{
+			name:  "gitopsctl_dora_change_failure_ratio",
+			help:  "Fraction of the changes synced over the last 7 days with a failed sync.",
+			value: func(d *stats.DORA) (float64, bool) { return d.ChangeFailureRate, true },
+		},
+		{
+			name:  "gitopsctl_dora_deploys",
+			help:  "Changes deployed over the last 7 days.",
+			value: func(d *stats.DORA) (float64, bool) { return float64(d.Deploys), true },
+		},
+	}
+
+	for _, family := range families {
+		fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n", family.name, family.help, family.name)
+		for _, app := range report.Apps {
+			if value, ok := family.value(app.DORA); ok {
+				fmt.Fprintf(b, "%s{application=\"%s\"} %g\n", family.name, escapeLabel(app.App), value)
+			}
+		}
+	}
+}
+
+// escapeLabel escapes a label value for the Prometheus text exposition format.
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// redactURL removes any credentials from a repository URL, so they are not exposed as a label.
+func redactURL(repoURL string) string {
+	u, err := url.Parse(repoURL)
+	if err != nil || u.User == nil {
+		return repoURL
+	}
+	u.User = nil
+	return u.String()
+}
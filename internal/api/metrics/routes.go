@@ -0,0 +1,33 @@
+// Package metrics serves controller metrics in the Prometheus text exposition format, for
+// example the time spent fetching each application's repository, the size of its clone and its
+// DORA metrics.
+package metrics
+
+import (
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Handler handles metrics requests.
+type Handler struct {
+	logger      *zap.Logger
+	apps        *appcore.Applications
+	syncLogFile string
+}
+
+// NewHandler creates a new metrics handler.
+// DORA metrics are computed from syncLogFile on each request so that they reflect syncs
+// recorded by a controller running in a separate process.
+func NewHandler(logger *zap.Logger, apps *appcore.Applications, syncLogFile string) *Handler {
+	return &Handler{
+		logger:      logger,
+		apps:        apps,
+		syncLogFile: syncLogFile,
+	}
+}
+
+// RegisterRoutes registers the metrics endpoint, protected by the given middleware.
+func RegisterRoutes(e *echo.Echo, handler *Handler, middleware ...echo.MiddlewareFunc) {
+	e.GET("/metrics", handler.Metrics, middleware...)
+}
@@ -2,14 +2,27 @@ package api
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
 	"aeswibon.com/github/gitopsctl/internal/api/app"
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
 	"aeswibon.com/github/gitopsctl/internal/api/cluster"
+	"aeswibon.com/github/gitopsctl/internal/api/event"
+	"aeswibon.com/github/gitopsctl/internal/api/logging"
+	"aeswibon.com/github/gitopsctl/internal/api/metrics"
+	"aeswibon.com/github/gitopsctl/internal/api/stats"
+	"aeswibon.com/github/gitopsctl/internal/api/status"
+	"aeswibon.com/github/gitopsctl/internal/api/web"
+	"aeswibon.com/github/gitopsctl/internal/common"
 	"aeswibon.com/github/gitopsctl/internal/controller"
 	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	authcore "aeswibon.com/github/gitopsctl/internal/core/auth"
 	clustercore "aeswibon.com/github/gitopsctl/internal/core/cluster"
+	eventcore "aeswibon.com/github/gitopsctl/internal/core/event"
+	statscore "aeswibon.com/github/gitopsctl/internal/core/stats"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"go.uber.org/zap"
@@ -27,50 +40,128 @@ type Server struct {
 	// clusters is the reference to the clusters store, which holds registered Kubernetes clusters.
 	clusters *clustercore.Clusters
 	// controller is the reference to the main controller that manages application synchronization.
-	controller *controller.Controller
+	controller controller.Commander
+	// authenticator authenticates API requests, or is nil if authentication is disabled.
+	authenticator *authcore.Authenticator
+	// logLevel is the level of the global logger, changed through the API.
+	logLevel zap.AtomicLevel
 }
 
 // NewServer creates a new API server instance.
 // It initializes the Echo instance, sets up middleware, and registers routes.
-func NewServer(logger *zap.Logger, apps *appcore.Applications, clusters *clustercore.Clusters, ctrl *controller.Controller) *Server {
+// If authenticator is not nil, API requests must be authenticated and are authorized by the
+// permissions of the user or API token. logLevel is the level of the global logger, which the API
+// can change at runtime.
+func NewServer(logger *zap.Logger, apps *appcore.Applications, clusters *clustercore.Clusters, ctrl controller.Commander, authenticator *authcore.Authenticator, logLevel zap.AtomicLevel) *Server {
+	s := newServer(logger, apps, clusters, ctrl)
+	s.authenticator = authenticator
+	s.logLevel = logLevel
+	s.e.Use(middleware.CORS())
+	s.registerRoutes()
+	return s
+}
+
+// NewStatusServer creates a server that only serves the public status page and the liveness probe.
+// It exposes no management API and needs no controller, so it can be reachable without authentication.
+func NewStatusServer(logger *zap.Logger, apps *appcore.Applications) *Server {
+	s := newServer(logger, apps, nil, nil)
+	status.RegisterRoutes(s.e, status.NewHandler(s.logger, s.apps))
+	s.e.GET("/", func(c echo.Context) error {
+		return c.Redirect(http.StatusFound, "/status")
+	})
+	s.e.GET("/healthz", s.Liveness)
+	return s
+}
+
+// newServer creates a server without routes, with the middleware shared by all servers.
+func newServer(logger *zap.Logger, apps *appcore.Applications, clusters *clustercore.Clusters, ctrl controller.Commander) *Server {
 	e := echo.New()
 	e.HideBanner = true
 	e.HidePort = true
 
 	e.Validator = NewCustomValidator()
+	e.HTTPErrorHandler = errorHandler
+	// Accept the caller's X-Request-ID or generate one, and return it with the response
+	e.Use(middleware.RequestID())
 	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
 		Format: `{"time":"${time_rfc3339_nano}","id":"${id}","remote_ip":"${remote_ip}",` +
 			`"host":"${host}","method":"${method}","uri":"${uri}","status":${status}, "latency":"${latency_human}"` +
 			`,"bytes_in":${bytes_in},"bytes_out":${bytes_out}}` + "\n",
 	}))
 	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
 
-	s := &Server{
+	return &Server{
 		e:          e,
 		logger:     logger,
 		apps:       apps,
 		clusters:   clusters,
 		controller: ctrl,
 	}
+}
 
-	s.registerRoutes()
-	return s
+// errorHandler writes error responses as a common.ErrorResponse with the error's code and the
+// ID of the request, so that clients can branch on failures and a failed request can be found in
+// the logs of the API server and of the operations it triggered. echo.HTTPError errors, such as
+// those of unknown routes, are given the code of their status; any other error is reported as an
+// internal server error.
+func errorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+	var apiErr *apierror.Error
+	if !errors.As(err, &apiErr) {
+		apiErr = apierror.Internal(http.StatusText(http.StatusInternalServerError))
+		var he *echo.HTTPError
+		if errors.As(err, &he) {
+			message, _ := he.Message.(string)
+			apiErr = apierror.New(he.Code, apierror.CodeForStatus(he.Code), common.DefaultIfEmpty(message, http.StatusText(he.Code)))
+		}
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(apiErr.Status)
+	} else {
+		err = c.JSON(apiErr.Status, common.ErrorResponse{
+			Code:      string(apiErr.Code),
+			Message:   apiErr.Message,
+			Errors:    apiErr.Errors,
+			RequestID: auth.RequestID(c),
+		})
+	}
+	if err != nil {
+		c.Logger().Error(err)
+	}
 }
 
 // RegisterRoutes defines all API endpoints.
 // It sets up the routes for managing applications, health checks, and other API functionalities.
 func (s *Server) registerRoutes() {
 	v1 := s.e.Group("/api/v1")
+	authenticate := auth.Anonymous
+	if s.authenticator != nil {
+		authHandler := auth.NewHandler(s.logger, s.authenticator)
+		authenticate = authHandler.Middleware
+		auth.RegisterRoutes(s.e, authHandler)
+	}
+	v1.Use(authenticate)
 
 	appHandler := app.NewHandler(s.logger, s.apps, s.clusters, s.controller)
 	clusterHandler := cluster.NewHandler(s.logger, s.clusters, s.apps, s.controller)
+	eventHandler := event.NewHandler(s.logger, eventcore.DefaultEventFile)
+	statsHandler := stats.NewHandler(s.logger, statscore.DefaultSyncLogFile)
 
 	app.RegisterRoutes(v1, appHandler)
 	cluster.RegisterRoutes(v1, clusterHandler)
+	event.RegisterRoutes(v1, eventHandler)
+	stats.RegisterRoutes(v1, statsHandler)
+	logging.RegisterRoutes(v1, logging.NewHandler(s.logger, s.logLevel))
+	status.RegisterRoutes(s.e, status.NewHandler(s.logger, s.apps))
+	metrics.RegisterRoutes(s.e, metrics.NewHandler(s.logger, s.apps, statscore.DefaultSyncLogFile), authenticate, auth.Require(authcore.PermissionAppRead))
 
-	s.e.GET("/health", s.HealthCheck)
+	s.e.GET("/healthz", s.Liveness)
+	s.e.GET("/readyz", s.Readiness)
 
+	web.RegisterRoutes(s.e)
 }
 
 // Echo returns the Echo instance used by the server.
@@ -95,11 +186,3 @@ func (s *Server) Stop(ctx context.Context) error {
 	defer cancel()
 	return s.e.Shutdown(timeoutCtx)
 }
-
-// HealthCheck is a simple endpoint to check if the API server is running.
-// It responds with a 200 OK status and a simple message.
-// This is useful for monitoring and health checks in production environments.
-func (s *Server) HealthCheck(c echo.Context) error {
-	// Simple health check: just respond with 200 OK
-	return c.String(http.StatusOK, "OK")
-}
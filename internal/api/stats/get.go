@@ -0,0 +1,37 @@
+package stats
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	statscore "aeswibon.com/github/gitopsctl/internal/core/stats"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Get handles the retrieval of sync statistics over a rolling window.
+// The optional 'window' query parameter sets the window (e.g., "30d" or "12h", 7 days by default)
+// and 'app' restricts the result to a single application.
+func (h *Handler) Get(c echo.Context) error {
+	window := statscore.DefaultWindow
+	if param := strings.TrimSpace(c.QueryParam("window")); param != "" {
+		var err error
+		if window, err = statscore.ParseWindow(param); err != nil {
+			return apierror.ValidationFailed(err.Error())
+		}
+	}
+	appName := strings.TrimSpace(c.QueryParam("app"))
+
+	now := time.Now()
+	syncs, err := statscore.Load(h.syncLogFile, now.Add(-window))
+	if err != nil {
+		h.logger.Error("Failed to load sync log", zap.Error(err))
+		return apierror.Internal("Failed to load sync log")
+	}
+	if appName != "" {
+		syncs = statscore.ForApp(syncs, appName)
+	}
+	return c.JSON(http.StatusOK, ConvertToResponse(statscore.Compute(syncs, window, now)))
+}
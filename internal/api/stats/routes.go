@@ -0,0 +1,31 @@
+// Package stats serves sync statistics over a rolling window, per application and over all
+// applications, for platform SLO reporting.
+package stats
+
+import (
+	"aeswibon.com/github/gitopsctl/internal/api/auth"
+	authcore "aeswibon.com/github/gitopsctl/internal/core/auth"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Handler handles sync statistics requests.
+type Handler struct {
+	logger      *zap.Logger
+	syncLogFile string
+}
+
+// NewHandler creates a new sync statistics handler.
+// Syncs are read from syncLogFile on each request so that the API reflects syncs
+// recorded by a controller running in a separate process.
+func NewHandler(logger *zap.Logger, syncLogFile string) *Handler {
+	return &Handler{
+		logger:      logger,
+		syncLogFile: syncLogFile,
+	}
+}
+
+// RegisterRoutes registers all sync statistics routes.
+func RegisterRoutes(g *echo.Group, handler *Handler) {
+	g.GET("/stats", handler.Get, auth.Require(authcore.PermissionAppRead))
+}
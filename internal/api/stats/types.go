@@ -0,0 +1,135 @@
+package stats
+
+import (
+	"time"
+
+	statscore "aeswibon.com/github/gitopsctl/internal/core/stats"
+)
+
+// Response defines the structure for returning sync statistics via the API.
+type Response struct {
+	// Window is how far back the statistics go (e.g., "168h0m0s").
+	Window string `json:"window"`
+	// From is when the window starts.
+	From time.Time `json:"from"`
+	// To is when the window ends, the time the statistics were computed.
+	To time.Time `json:"to"`
+	// Total is the statistics over the syncs of all applications.
+	Total Summary `json:"total"`
+	// FailingApps is the number of applications whose last sync failed.
+	FailingApps int `json:"failing_apps"`
+	// Apps is the statistics of each application with syncs in the window, by name.
+	Apps []AppStats `json:"apps"`
+	// DORA is the DORA metrics over all applications.
+	DORA DORA `json:"dora"`
+}
+
+// Summary is the statistics of a set of syncs. Syncs held for approval or interrupted are not counted.
+type Summary struct {
+	// Syncs is the number of syncs that succeeded or failed.
+	Syncs int `json:"syncs"`
+	// Succeeded is the number of syncs that left the application synced.
+	Succeeded int `json:"succeeded"`
+	// Failed is the number of syncs that failed.
+	Failed int `json:"failed"`
+	// SuccessRate is the fraction of syncs that succeeded, from 0 to 1, or 1 when there were no syncs.
+	SuccessRate float64 `json:"success_rate"`
+	// Deploys is the number of successful syncs that deployed a new commit.
+	Deploys int `json:"deploys"`
+	// MeanTimeToDeploy is the mean time from a commit to the sync that deployed it (e.g., "2m30s"),
+	// empty when there were no deploys.
+	MeanTimeToDeploy string `json:"mean_time_to_deploy,omitempty"`
+	// MeanTimeToDeploySeconds is MeanTimeToDeploy in seconds.
+	MeanTimeToDeploySeconds float64 `json:"mean_time_to_deploy_seconds"`
+	// LongestFailureStreak is the most consecutive failed syncs of an application.
+	LongestFailureStreak int `json:"longest_failure_streak"`
+}
+
+// AppStats is the statistics of an application's syncs.
+type AppStats struct {
+	Summary
+	// App is the name of the application.
+	App string `json:"app"`
+	// FailureStreak is the number of consecutive failed syncs the window ends with.
+	FailureStreak int `json:"failure_streak"`
+	// LastSync is when the application's last counted sync finished.
+	LastSync time.Time `json:"last_sync"`
+	// DORA is the DORA metrics of the application.
+	DORA DORA `json:"dora"`
+}
+
+// DORA is the DORA metrics of an application, or of all applications. A change is a commit the
+// application had not synced yet; it failed if any sync of it failed.
+type DORA struct {
+	// Deploys is the number of changes deployed.
+	Deploys int `json:"deploys"`
+	// DeploymentFrequency is the number of deploys per day.
+	DeploymentFrequency float64 `json:"deployment_frequency_per_day"`
+	// LeadTime is the median time from a commit to the sync that deployed it (e.g., "4m10s"), empty
+	// when there were no deploys.
+	LeadTime string `json:"lead_time,omitempty"`
+	// LeadTimeSeconds is LeadTime in seconds.
+	LeadTimeSeconds float64 `json:"lead_time_seconds"`
+	// Changes is the number of changes synced, deployed or not.
+	Changes int `json:"changes"`
+	// FailedChanges is the number of changes with a failed sync.
+	FailedChanges int `json:"failed_changes"`
+	// ChangeFailureRate is the fraction of changes that failed, from 0 to 1.
+	ChangeFailureRate float64 `json:"change_failure_rate"`
+}
+
+// ConvertToResponse converts a sync statistics report to its API response representation.
+func ConvertToResponse(report *statscore.Report) Response {
+	resp := Response{
+		Window:      report.Window.String(),
+		From:        report.From,
+		To:          report.To,
+		Total:       convertSummary(report.Total),
+		FailingApps: report.FailingApps,
+		Apps:        make([]AppStats, 0, len(report.Apps)),
+		DORA:        convertDORA(report.DORA),
+	}
+	for _, a := range report.Apps {
+		resp.Apps = append(resp.Apps, AppStats{
+			Summary:       convertSummary(a.Summary),
+			App:           a.App,
+			FailureStreak: a.FailureStreak,
+			LastSync:      a.LastSync,
+			DORA:          convertDORA(a.DORA),
+		})
+	}
+	return resp
+}
+
+// convertSummary converts the statistics of a set of syncs for a Response.
+func convertSummary(s statscore.Summary) Summary {
+	summary := Summary{
+		Syncs:                s.Syncs,
+		Succeeded:            s.Succeeded,
+		Failed:               s.Failed,
+		SuccessRate:          s.SuccessRate,
+		Deploys:              s.Deploys,
+		LongestFailureStreak: s.LongestFailureStreak,
+	}
+	if s.Deploys > 0 {
+		summary.MeanTimeToDeploy = s.MeanTimeToDeploy.Round(time.Second).String()
+		summary.MeanTimeToDeploySeconds = s.MeanTimeToDeploy.Seconds()
+	}
+	return summary
+}
+
+// convertDORA converts DORA metrics for a Response.
+func convertDORA(d *statscore.DORA) DORA {
+	dora := DORA{
+		Deploys:             d.Deploys,
+		DeploymentFrequency: d.DeploymentFrequency,
+		Changes:             d.Changes,
+		FailedChanges:       d.FailedChanges,
+		ChangeFailureRate:   d.ChangeFailureRate,
+	}
+	if d.Deploys > 0 {
+		dora.LeadTime = d.LeadTime.Round(time.Second).String()
+		dora.LeadTimeSeconds = d.LeadTime.Seconds()
+	}
+	return dora
+}
@@ -0,0 +1,111 @@
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// RefreshInterval is how often the status page reloads itself.
+const RefreshInterval = 30 * time.Second
+
+// page is the status page. It reloads itself and needs no scripts, so it works on any screen with a browser.
+var page = template.Must(template.New("status").Funcs(template.FuncMap{
+	"since": since,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <meta http-equiv="refresh" content="{{.Refresh}}">
+  <title>Status</title>
+  <style>
+    body { margin: 0; padding: 32px; font: 18px/1.4 -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; background: #0d1117; color: #e6edf3; }
+    h1 { margin: 0 0 8px; font-size: 28px; }
+    p { margin: 0 0 24px; color: #8b949e; }
+    ul { display: grid; grid-template-columns: repeat(auto-fill, minmax(260px, 1fr)); gap: 16px; list-style: none; margin: 0; padding: 0; }
+    li { padding: 16px; border-radius: 8px; border-left: 8px solid #8b949e; background: #161b22; }
+    li.Healthy { border-color: #3fb950; }
+    li.Progressing { border-color: #d29922; }
+    li.Degraded { border-color: #f85149; }
+    strong { display: block; font-size: 22px; overflow-wrap: anywhere; }
+    span { color: #8b949e; }
+  </style>
+</head>
+<body>
+  <h1>{{.Healthy}} of {{len .Apps}} applications healthy</h1>
+  <p>Updated {{.Now.Format "15:04:05 MST"}}</p>
+  <ul>
+  {{- range .Apps}}
+    <li class="{{.Health}}"><strong>{{.Name}}</strong>{{.Health}} · <span>{{since .LastSyncedAt $.Now}}</span></li>
+  {{- end}}
+  </ul>
+</body>
+</html>
+`))
+
+// Page renders the status page.
+func (h *Handler) Page(c echo.Context) error {
+	apps := h.list()
+	healthy := 0
+	for _, a := range apps {
+		if a.Health == HealthHealthy {
+			healthy++
+		}
+	}
+
+	var buf bytes.Buffer
+	err := page.Execute(&buf, map[string]any{
+		"Apps":    apps,
+		"Healthy": healthy,
+		"Now":     time.Now(),
+		"Refresh": int(RefreshInterval.Seconds()),
+	})
+	if err != nil {
+		h.logger.Error("Failed to render status page", zap.Error(err))
+		return apierror.Internal("Failed to render status page")
+	}
+	return c.HTMLBlob(http.StatusOK, buf.Bytes())
+}
+
+// List returns the status page's applications as JSON, sorted by name.
+func (h *Handler) List(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.list())
+}
+
+// list returns the redacted applications, sorted by name.
+func (h *Handler) list() []Response {
+	h.apps.RLock()
+	defer h.apps.RUnlock()
+
+	responses := []Response{}
+	for _, app := range h.apps.List() {
+		responses = append(responses, ConvertToResponse(app))
+	}
+	sort.Slice(responses, func(i, j int) bool { return responses[i].Name < responses[j].Name })
+	return responses
+}
+
+// since describes how long ago t was, relative to now.
+func since(t, now time.Time) string {
+	if t.IsZero() {
+		return "never synced"
+	}
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "synced just now"
+	case d < time.Hour:
+		return fmt.Sprintf("synced %dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("synced %dh ago", int(d.Hours()))
+	}
+	return "synced " + t.Format("2006-01-02")
+}
@@ -0,0 +1,29 @@
+// Package status serves the public status page, a read-only view of application health meant for
+// dashboards on shared screens. It shows only application names, health and last sync times.
+package status
+
+import (
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Handler handles status page requests.
+type Handler struct {
+	logger *zap.Logger
+	apps   *appcore.Applications
+}
+
+// NewHandler creates a new status page handler.
+func NewHandler(logger *zap.Logger, apps *appcore.Applications) *Handler {
+	return &Handler{
+		logger: logger,
+		apps:   apps,
+	}
+}
+
+// RegisterRoutes registers the status page as HTML and JSON.
+func RegisterRoutes(e *echo.Echo, handler *Handler) {
+	e.GET("/status", handler.Page)
+	e.GET("/status.json", handler.List)
+}
@@ -0,0 +1,50 @@
+package status
+
+import (
+	"time"
+
+	appcore "aeswibon.com/github/gitopsctl/internal/core/app"
+)
+
+// Health is the coarse health of an application shown on the status page.
+type Health string
+
+const (
+	// HealthHealthy means the application's last sync succeeded.
+	HealthHealthy Health = "Healthy"
+	// HealthProgressing means the application awaits a sync or an approval.
+	HealthProgressing Health = "Progressing"
+	// HealthDegraded means the application's last sync failed, was rolled back, or its reconciliation is suspended.
+	HealthDegraded Health = "Degraded"
+	// HealthUnknown means the application is not being reconciled.
+	HealthUnknown Health = "Unknown"
+)
+
+// Response is an application as shown on the status page. It deliberately omits everything but the
+// name, health and last sync time, so repository URLs, paths and error messages are never exposed.
+type Response struct {
+	// Name is the name of the application.
+	Name string `json:"name"`
+	// Health is the coarse health of the application.
+	Health Health `json:"health"`
+	// LastSyncedAt is when the last sync attempt finished, zero if the application was never synced.
+	LastSyncedAt time.Time `json:"last_synced_at"`
+}
+
+// HealthOf maps an application status to its health.
+func HealthOf(status appcore.Status) Health {
+	switch {
+	case status == appcore.StatusSynced:
+		return HealthHealthy
+	case status.IsFailed(), status == appcore.StatusSuspended:
+		return HealthDegraded
+	case status == appcore.StatusPending, status == appcore.StatusSyncRequested, status == appcore.StatusAwaitingApproval:
+		return HealthProgressing
+	}
+	return HealthUnknown
+}
+
+// ConvertToResponse converts an Application to its redacted status page representation.
+func ConvertToResponse(app *appcore.Application) Response {
+	return Response{Name: app.Name, Health: HealthOf(app.Status), LastSyncedAt: app.LastSyncFinishedAt}
+}
@@ -1,11 +1,9 @@
 package api
 
 import (
-	"net/http"
-
+	"aeswibon.com/github/gitopsctl/internal/api/apierror"
 	"aeswibon.com/github/gitopsctl/internal/common"
 	"github.com/go-playground/validator/v10"
-	"github.com/labstack/echo/v4"
 )
 
 // CustomValidator holds the go-playground validator instance.
@@ -41,10 +39,10 @@ func NewCustomValidator() *CustomValidator {
 
 // Validate validates the input struct.
 // It uses the go-playground validator to check the struct fields based on tags.
-// If validation fails, it returns an HTTP error with status 400 Bad Request.
+// If validation fails, it returns a ValidationFailed error with status 400 Bad Request.
 func (cv *CustomValidator) Validate(i any) error {
 	if err := cv.validator.Struct(i); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return apierror.ValidationFailed(err.Error())
 	}
 	return nil
 }
@@ -0,0 +1,325 @@
+// GitOpsCTL dashboard: a single page rendered from the /api/v1 endpoints, refreshed every few seconds while "Live" is checked.
+"use strict";
+
+const API = "../api/v1";
+const AUTH = "../auth";
+const REFRESH_INTERVAL = 5000;
+
+const OK = ["Synced", "Active"];
+const BAD = ["Error", "InvalidManifests", "Suspended", "RolledBack", "Unreachable", "CredentialsExpired"];
+
+const view = document.getElementById("view");
+const notice = document.getElementById("notice");
+const live = document.getElementById("live");
+const account = document.getElementById("account");
+
+// user is the logged in user, or null if the API does not require authentication.
+let user = null;
+
+// h creates an element with the given attributes and children; strings become text nodes, so API data is never parsed as HTML.
+function h(tag, attrs, ...children) {
+  const el = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs || {})) {
+    if (key.startsWith("on")) {
+      el.addEventListener(key.slice(2), value);
+    } else if (value !== false && value !== undefined && value !== null) {
+      el.setAttribute(key, value === true ? "" : value);
+    }
+  }
+  for (const child of children.flat()) {
+    if (child !== null && child !== undefined && child !== false) {
+      el.append(child instanceof Node ? child : String(child));
+    }
+  }
+  return el;
+}
+
+async function api(method, path, body) {
+  const resp = await fetch(API + path, {
+    method,
+    headers: body ? { "Content-Type": "application/json" } : {},
+    body: body ? JSON.stringify(body) : undefined,
+  });
+  if (resp.status === 401) {
+    login();
+  }
+  const data = await resp.json().catch(() => null);
+  if (!resp.ok) {
+    throw new Error((data && data.message) || `${method} ${path} returned ${resp.status}`);
+  }
+  return data;
+}
+
+// login sends the browser to the identity provider, returning to the current view afterwards.
+function login() {
+  location.href = `${AUTH}/login?redirect=${encodeURIComponent(location.pathname + location.hash)}`;
+}
+
+// loadUser reads who is logged in; a 404 means the API does not require authentication.
+async function loadUser() {
+  const resp = await fetch(`${AUTH}/me`);
+  if (resp.status === 401) {
+    login();
+    return;
+  }
+  if (resp.ok) {
+    user = await resp.json();
+    account.replaceChildren(h("span", { title: `Permissions: ${user.permissions.join(", ")}` }, user.user), " ", h("a", { href: `${AUTH}/logout` }, "Log out"));
+    account.hidden = false;
+  }
+}
+
+// canOperate reports whether the user may sync, suspend and approve applications and check clusters.
+function canOperate() {
+  return !user || user.permissions.includes("app:sync") || user.permissions.includes("admin");
+}
+
+function showNotice(message, error) {
+  notice.textContent = message;
+  notice.className = error ? "error" : "";
+  notice.hidden = false;
+}
+
+function badge(status) {
+  const cls = OK.includes(status) ? "ok" : BAD.includes(status) ? "bad" : "warn";
+  return h("span", { class: `badge ${cls}` }, status || "Unknown");
+}
+
+// isSet reports whether a timestamp is set; Go encodes unset timestamps as year 1.
+function isSet(value) {
+  return Boolean(value) && !value.startsWith("0001-");
+}
+
+function time(value) {
+  if (!isSet(value)) {
+    return h("span", { class: "muted" }, "-");
+  }
+  const date = new Date(value);
+  return h("time", { datetime: value, title: date.toLocaleString() }, ago(date));
+}
+
+function ago(date) {
+  const seconds = Math.round((Date.now() - date.getTime()) / 1000);
+  const abs = Math.abs(seconds);
+  const text = abs < 60 ? `${abs}s` : abs < 3600 ? `${Math.round(abs / 60)}m` : abs < 86400 ? `${Math.round(abs / 3600)}h` : `${Math.round(abs / 86400)}d`;
+  return seconds >= 0 ? `${text} ago` : `in ${text}`;
+}
+
+function hash(value) {
+  return value ? h("code", { title: value }, value.slice(0, 8)) : h("span", { class: "muted" }, "-");
+}
+
+function table(headers, rows, empty) {
+  if (rows.length === 0) {
+    return h("p", { class: "muted" }, empty);
+  }
+  return h("table", null, h("thead", null, h("tr", null, headers.map((header) => h("th", null, header)))), h("tbody", null, rows));
+}
+
+function diff(text) {
+  return h("pre", { class: "diff" }, text.split("\n").map((line) => {
+    const cls = line.startsWith("+") ? "add" : line.startsWith("-") ? "del" : line.startsWith("@@") ? "hunk" : null;
+    return h("span", { class: cls }, line + "\n");
+  }));
+}
+
+// action returns a button that sends the request and re-renders the view once it is accepted, or
+// null if the user's role does not allow it.
+function action(label, method, path, body, options = {}) {
+  if (!canOperate()) {
+    return null;
+  }
+  return h("button", {
+    class: options.danger ? "danger" : null,
+    onclick: async (e) => {
+      if (options.confirm && !window.confirm(options.confirm)) {
+        return;
+      }
+      let payload = body;
+      if (options.reason) {
+        const reason = window.prompt(options.reason);
+        if (reason === null) {
+          return;
+        }
+        payload = { ...body, reason };
+      }
+      e.target.disabled = true;
+      try {
+        const result = await api(method, path, payload);
+        showNotice((result && result.message) || `${label} requested`);
+        await render();
+      } catch (err) {
+        showNotice(err.message, true);
+      } finally {
+        e.target.disabled = false;
+      }
+    },
+  }, label);
+}
+
+async function applicationsView() {
+  const apps = (await api("GET", "/applications")) || [];
+  apps.sort((a, b) => a.name.localeCompare(b.name));
+  return [
+    h("h1", null, "Applications"),
+    table(["Name", "Status", "Cluster", "Revision", "Last sync", "Next sync", "Message"], apps.map((a) => h("tr", null,
+      h("td", null, h("a", { href: `#/applications/${encodeURIComponent(a.name)}` }, a.name)),
+      h("td", null, badge(a.status)),
+      h("td", null, a.type === "terraform" ? h("span", { class: "muted" }, "terraform") : a.cluster_name),
+      h("td", null, hash(a.last_synced_git_hash)),
+      h("td", null, time(a.last_sync_finished_at)),
+      h("td", null, time(a.next_sync_at)),
+      h("td", { class: "message" }, a.message),
+    )), "No applications registered. Register one with 'gitopsctl app register'."),
+  ];
+}
+
+async function applicationView(name) {
+  const path = `/applications/${encodeURIComponent(name)}`;
+  const [a, events] = await Promise.all([
+    api("GET", path),
+    api("GET", `/events?app=${encodeURIComponent(name)}`),
+  ]);
+
+  const actions = [action("Sync", "POST", `${path}/sync`, null, { reason: `Why sync ${a.name}? (optional, recorded in the audit log)` })];
+  if (a.status === "Suspended") {
+    actions.push(action("Resume", "POST", `${path}/reset-failures`));
+  } else {
+    actions.push(action("Suspend", "POST", `${path}/suspend`, null, { danger: true, reason: `Suspend ${a.name}? It is not reconciled until it is resumed.\nReason (optional, recorded in the audit log):` }));
+  }
+  const plan = a.terraform_plan;
+  const pending = a.pending_change || (plan && !isSet(plan.applied_at) ? plan : null);
+  if (pending && !isSet(pending.approved_at)) {
+    actions.push(action("Approve", "POST", `${path}/approve`, { plan_id: pending.id }));
+  }
+  if (a.rollback_git_hash && a.status !== "Suspended") {
+    const to = a.rollback_git_hash.slice(0, 8);
+    actions.push(action(`Roll back to ${to}`, "POST", `${path}/rollback`, null, { danger: true, reason: `Roll ${a.name} back to ${to}? Its current revision is not applied again until it is reset.\nReason (optional, recorded in the audit log):` }));
+  }
+  for (const r of (a.rollouts || []).filter((r) => r.provider === "argo-rollouts")) {
+    const rollout = `${r.namespace}/${r.name}`;
+    actions.push(action(`Abort rollout ${r.name}`, "POST", `${path}/rollouts/abort`, { rollout }, { danger: true, confirm: `Abort ${rollout} and shift all traffic back to the stable version?` }));
+  }
+
+  const sections = [
+    h("h1", null, a.name, " ", badge(a.status)),
+    a.message ? h("p", null, a.message) : null,
+    h("div", { class: "actions" }, actions),
+    h("dl", null,
+      h("dt", null, "Repository"), h("dd", null, `${a.repo_url} @ ${a.branch || "default branch"}`),
+      h("dt", null, "Path"), h("dd", null, a.path),
+      h("dt", null, "Cluster"), h("dd", null, a.cluster_name || "-"),
+      h("dt", null, "Revision"), h("dd", null, hash(a.last_synced_git_hash)),
+      h("dt", null, "Previous revision"), h("dd", null, hash(a.rollback_git_hash)),
+      h("dt", null, "Sync mode"), h("dd", null, a.sync_mode),
+      h("dt", null, "Interval"), h("dd", null, `${a.interval} (effective ${a.effective_interval || a.interval})`),
+      h("dt", null, "Failures"), h("dd", null, a.consecutive_failures),
+      h("dt", null, "Last sync"), h("dd", null, time(a.last_sync_finished_at), a.last_sync_duration ? ` (took ${a.last_sync_duration})` : ""),
+      h("dt", null, "Next sync"), h("dd", null, time(a.next_sync_at)),
+    ),
+  ];
+
+  if (a.pending_change) {
+    sections.push(h("h2", null, "Pending change at ", hash(a.pending_change.git_hash)), h("p", null, a.pending_change.summary), diff(a.pending_change.diff || ""));
+  }
+  if (a.terraform_plan) {
+    sections.push(h("h2", null, "Terraform plan at ", hash(a.terraform_plan.git_hash)), h("p", null, a.terraform_plan.summary));
+  }
+  if (a.rollouts && a.rollouts.length) {
+    sections.push(h("h2", null, "Rollouts"), table(["Rollout", "Provider", "Phase", "Step", "Weight", "Message"], a.rollouts.map((r) => h("tr", null,
+      h("td", null, `${r.kind} ${r.namespace}/${r.name}`),
+      h("td", null, r.provider),
+      h("td", null, r.phase, r.paused ? " (paused)" : ""),
+      h("td", null, r.steps ? `${r.step}/${r.steps}` : "-"),
+      h("td", null, `${r.weight}%`),
+      h("td", { class: "message" }, r.message),
+    )), ""));
+  }
+
+  const history = (a.sync_history || []).slice().reverse();
+  sections.push(h("h2", null, "Sync history"), table(["Finished", "Status", "Revision", "Requested by", "Message"], history.map((s) => h("tr", null,
+    h("td", null, time(s.time)),
+    h("td", null, badge(s.status)),
+    h("td", null, hash(s.git_hash)),
+    h("td", null, s.actor || "-", s.reason ? ` (${s.reason})` : ""),
+    h("td", { class: "message" }, s.message),
+  )), "No syncs yet."));
+
+  sections.push(h("h2", null, "Events"), eventsTable((events || []).slice(-20), false));
+  return sections;
+}
+
+async function clustersView() {
+  const clusters = (await api("GET", "/clusters")) || [];
+  clusters.sort((a, b) => a.name.localeCompare(b.name));
+  return [
+    h("h1", null, "Clusters"),
+    table(["Name", "Status", "Version", "Nodes", "Last checked", "Message", ""], clusters.map((c) => h("tr", null,
+      h("td", null, c.name),
+      h("td", null, badge(c.status)),
+      h("td", null, (c.health && c.health.serverVersion) || "-"),
+      h("td", null, c.health ? `${c.health.readyNodes}/${c.health.nodes} ready` : "-"),
+      h("td", null, time(c.last_checked_at)),
+      h("td", { class: "message" }, c.message),
+      h("td", null, action("Check", "POST", `/clusters/${encodeURIComponent(c.name)}/check`)),
+    )), "No clusters registered. Register one with 'gitopsctl cluster register'."),
+  ];
+}
+
+async function eventsView() {
+  const events = (await api("GET", "/events")) || [];
+  return [h("h1", null, "Events"), eventsTable(events.slice(-200), true)];
+}
+
+function eventsTable(events, showObject) {
+  const headers = ["Last seen", "Type", "Reason"].concat(showObject ? ["Object"] : [], ["Message", "Count"]);
+  return table(headers, events.slice().reverse().map((e) => h("tr", null,
+    h("td", null, time(e.last_timestamp)),
+    h("td", null, h("span", { class: `badge ${e.type === "Warning" ? "bad" : "ok"}` }, e.type)),
+    h("td", null, e.reason),
+    showObject ? h("td", null, e.kind === "Application" ? h("a", { href: `#/applications/${encodeURIComponent(e.name)}` }, e.name) : `${e.kind}/${e.name}`) : null,
+    h("td", { class: "message" }, e.message),
+    h("td", null, e.count),
+  )), "No events recorded.");
+}
+
+// route returns the view named by the URL fragment and its argument, e.g. "#/applications/web".
+function route() {
+  const [, name, arg] = (location.hash || "#/applications").split("/");
+  return { name: name || "applications", arg: arg && decodeURIComponent(arg) };
+}
+
+let rendering = false;
+
+async function render() {
+  if (rendering) {
+    return;
+  }
+  rendering = true;
+  const { name, arg } = route();
+  document.querySelectorAll("header nav a").forEach((a) => a.classList.toggle("active", a.dataset.view === name));
+  try {
+    let content;
+    switch (name) {
+      case "clusters": content = await clustersView(); break;
+      case "events": content = await eventsView(); break;
+      default: content = arg ? await applicationView(arg) : await applicationsView();
+    }
+    view.replaceChildren(...content.flat().filter(Boolean));
+  } catch (err) {
+    view.replaceChildren(h("p", { class: "muted" }, `Failed to load: ${err.message}`));
+  } finally {
+    rendering = false;
+  }
+}
+
+window.addEventListener("hashchange", () => {
+  notice.hidden = true;
+  render();
+});
+setInterval(() => {
+  if (live.checked && !document.hidden) {
+    render();
+  }
+}, REFRESH_INTERVAL);
+loadUser().finally(render);
@@ -0,0 +1,27 @@
+// Package web serves the dashboard, a single-page UI embedded in the binary that drives the API.
+package web
+
+import (
+	"embed"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Prefix is the path the dashboard is served under.
+const Prefix = "/ui"
+
+//go:embed static
+var static embed.FS
+
+// RegisterRoutes serves the dashboard under Prefix and redirects the root path to it.
+func RegisterRoutes(e *echo.Echo) {
+	e.StaticFS(Prefix, echo.MustSubFS(static, "static"))
+	e.GET(Prefix, redirect)
+	e.GET("/", redirect)
+}
+
+// redirect sends the browser to the dashboard's index page.
+func redirect(c echo.Context) error {
+	return c.Redirect(http.StatusFound, Prefix+"/")
+}
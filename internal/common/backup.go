@@ -0,0 +1,17 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// BackupFile writes data, the contents of the file at path in format version, to a new backup
+// next to it named <path>.v<version>.<timestamp>.bak, and returns the backup's path.
+func BackupFile(path string, data []byte, version int) (string, error) {
+	backup := fmt.Sprintf("%s.v%d.%s.bak", path, version, time.Now().Format("20060102T150405"))
+	if err := os.WriteFile(backup, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return backup, nil
+}
@@ -25,6 +25,21 @@ func DefaultIfEmpty(s, defaultVal string) string {
 	return s
 }
 
+// MatchesSearch reports whether any of fields contains term, ignoring case. An empty term matches
+// everything.
+func MatchesSearch(term string, fields ...string) bool {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term == "" {
+		return true
+	}
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), term) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetRelativeTime formats a time.Time object into a human-readable relative time string.
 func GetRelativeTime(t time.Time) string {
 	if t.IsZero() {
@@ -50,36 +65,26 @@ func GetRelativeTime(t time.Time) string {
 	}
 }
 
-func isAlphaNumeric(char byte) bool {
-	return (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9')
-}
-
-// ValidateName checks if the provided name is valid in kubernetes naming conventions.
-func ValidateName(name string) error {
-	if name == "" {
-		return fmt.Errorf("cluster name is required (use --name or -n flag)")
-	}
-
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return fmt.Errorf("cluster name cannot be empty or whitespace only")
-	}
-
-	if len(name) > 63 {
-		return fmt.Errorf("cluster name too long (maximum 63 characters)")
-	}
-
-	if !isAlphaNumeric(name[0]) || !isAlphaNumeric(name[len(name)-1]) {
-		return fmt.Errorf("cluster name must start and end with an alphanumeric character")
+// GetRelativeFutureTime formats a future time.Time object into a human-readable string such as "in 5m".
+// Times in the past are reported as "due".
+func GetRelativeFutureTime(t time.Time) string {
+	if t.IsZero() {
+		return "N/A"
 	}
 
-	for _, char := range name {
-		if !isAlphaNumeric(byte(char)) && char != '-' {
-			return fmt.Errorf("invalid character '%c' in cluster name '%s'; only alphanumeric characters and hyphens are allowed", char, name)
-		}
+	diff := time.Until(t)
+	switch {
+	case diff <= 0:
+		return "due"
+	case diff < time.Minute:
+		return fmt.Sprintf("in %ds", int(diff.Seconds()))
+	case diff < time.Hour:
+		return fmt.Sprintf("in %dm", int(diff.Minutes()))
+	case diff < 24*time.Hour:
+		return fmt.Sprintf("in %dh", int(diff.Hours()))
+	default:
+		return fmt.Sprintf("in %dd", int(diff.Hours()/24))
 	}
-
-	return nil
 }
 
 // ConfirmAction prompts the user for confirmation before proceeding with an action.
@@ -93,3 +98,14 @@ func ConfirmAction(message string) bool {
 	response = strings.ToLower(strings.TrimSpace(response))
 	return response == "y" || response == "yes"
 }
+
+// Prompt asks the user for a single-word answer and returns it trimmed, or an empty string if
+// nothing was entered.
+func Prompt(message string) string {
+	fmt.Printf("%s: ", message)
+	var response string
+	if _, err := fmt.Scanln(&response); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(response)
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// StatusFilter selects applications or clusters by status.
+type StatusFilter struct {
+	include []string // Statuses of which one must match; empty matches any status
+	exclude []string // Statuses none of which may match
+}
+
+// ParseStatusFilter parses a comma-separated list of statuses, each optionally negated with a
+// leading '!', such as "error,pending" or "!synced". A resource matches when it has one of the
+// statuses listed without '!', if any, and none of those listed with '!'. "all" or an empty filter
+// matches every status. Statuses are compared ignoring case and must be one of statuses.
+func ParseStatusFilter[S ~string](filter string, statuses []S) (StatusFilter, error) {
+	var f StatusFilter
+	if strings.TrimSpace(filter) == "" || strings.EqualFold(strings.TrimSpace(filter), "all") {
+		return f, nil
+	}
+	for _, term := range strings.Split(filter, ",") {
+		term = strings.TrimSpace(term)
+		negated := strings.HasPrefix(term, "!")
+		name := strings.TrimSpace(strings.TrimPrefix(term, "!"))
+		status, ok := canonicalStatus(name, statuses)
+		if !ok {
+			names := make([]string, len(statuses))
+			for i, s := range statuses {
+				names[i] = string(s)
+			}
+			return StatusFilter{}, fmt.Errorf("unknown status '%s' (must be all, %s, optionally negated with '!')", name, strings.Join(names, ", "))
+		}
+		if negated {
+			f.exclude = append(f.exclude, status)
+		} else {
+			f.include = append(f.include, status)
+		}
+	}
+	return f, nil
+}
+
+// canonicalStatus returns the status of statuses equal to name, ignoring case.
+func canonicalStatus[S ~string](name string, statuses []S) (string, bool) {
+	for _, s := range statuses {
+		if strings.EqualFold(string(s), name) {
+			return string(s), true
+		}
+	}
+	return "", false
+}
+
+// IsAll reports whether the filter matches every status.
+func (f StatusFilter) IsAll() bool {
+	return len(f.include) == 0 && len(f.exclude) == 0
+}
+
+// Matches reports whether a resource with status is selected by the filter.
+func (f StatusFilter) Matches(status string) bool {
+	if slices.Contains(f.exclude, status) {
+		return false
+	}
+	return len(f.include) == 0 || slices.Contains(f.include, status)
+}
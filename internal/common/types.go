@@ -1,13 +1,22 @@
 package common
 
+import "time"
+
 // ErrorResponse defines a standard error response structure.
 // This structure is used in the API responses to provide consistent error messages and details.
-// It includes a message field for the error description and an optional details field for additional context.
+// It includes a stable, machine-readable code clients can branch on, a message field for the error
+// description and optional details for additional context.
 type ErrorResponse struct {
+	// Code identifies the kind of error (e.g., "NotFound", "GitAuthFailed"); see package apierror.
+	Code string `json:"code"`
 	// Message is a brief description of the error that occurred.
 	Message string `json:"message"`
 	// Details provides additional context or information about the error, if available.
 	Details string `json:"details,omitempty"`
+	// Errors lists the individual failures of an operation on several objects, if any.
+	Errors []string `json:"errors,omitempty"`
+	// RequestID is the ID of the request, to find it in the logs.
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // SyncTriggerRequest defines the payload for triggering a manual sync.
@@ -33,3 +42,55 @@ type APIContext struct {
 	// Potentially hold references to controller, logger, etc.
 	// For Echo, handlers usually receive echo.Context and you can store data in it.
 }
+
+// ConditionAlerting is the condition type set on an object while one of its alerting rules is firing.
+const ConditionAlerting = "Alerting"
+
+// ConditionRateLimited is the condition type set on an application while its Git provider rejects
+// fetches of its repository for exceeding a rate limit.
+const ConditionRateLimited = "RateLimited"
+
+// Condition describes one aspect of an object's current state, in the style of Kubernetes conditions.
+type Condition struct {
+	// Type is the type of the condition (e.g., "Alerting").
+	Type string `json:"type"`
+	// Status is "True" or "False".
+	Status string `json:"status"`
+	// Reason is a short, CamelCase reason for the condition's last transition.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable description of the condition.
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is when the condition last changed status.
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// SetCondition adds or updates a condition in the list and reports whether anything changed.
+// LastTransitionTime is only updated when the condition's status changes.
+func SetCondition(conditions *[]Condition, cond Condition) bool {
+	for i := range *conditions {
+		existing := &(*conditions)[i]
+		if existing.Type != cond.Type {
+			continue
+		}
+		if existing.Status == cond.Status && existing.Reason == cond.Reason && existing.Message == cond.Message {
+			return false
+		}
+		if existing.Status == cond.Status {
+			cond.LastTransitionTime = existing.LastTransitionTime
+		}
+		*existing = cond
+		return true
+	}
+	*conditions = append(*conditions, cond)
+	return true
+}
+
+// FindCondition returns the condition of the given type, or nil if it is not set.
+func FindCondition(conditions []Condition, conditionType string) *Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
@@ -4,11 +4,138 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path"
 	"strings"
+	"time"
 
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+const (
+	// MaxNameLength is the maximum length of an application or cluster name.
+	MaxNameLength = 63
+	// MinPollingInterval is the shortest interval at which an application's repository may be polled.
+	MinPollingInterval = 10 * time.Second
+	// MaxPollingInterval is the longest interval at which an application's repository may be polled.
+	MaxPollingInterval = 24 * time.Hour
+)
+
+func isAlphaNumeric(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9')
+}
+
+// ValidateName checks that an application or cluster name follows Kubernetes naming conventions:
+// at most MaxNameLength lowercase alphanumeric characters or hyphens, starting and ending with an
+// alphanumeric character.
+func ValidateName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("name cannot be empty or whitespace only")
+	}
+
+	if len(name) > MaxNameLength {
+		return fmt.Errorf("name too long (maximum %d characters)", MaxNameLength)
+	}
+
+	if !isAlphaNumeric(name[0]) || !isAlphaNumeric(name[len(name)-1]) {
+		return fmt.Errorf("name must start and end with a lowercase alphanumeric character")
+	}
+
+	for _, char := range name {
+		if char > 127 || (!isAlphaNumeric(byte(char)) && char != '-') {
+			return fmt.Errorf("invalid character '%c' in name '%s'; only lowercase alphanumeric characters and hyphens are allowed", char, name)
+		}
+	}
+
+	return nil
+}
+
+// ParsePollingInterval parses an application's polling interval and checks that it lies between
+// MinPollingInterval and MaxPollingInterval.
+func ParsePollingInterval(interval string) (time.Duration, error) {
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval '%s': %w (examples: 30s, 5m, 1h)", interval, err)
+	}
+	if d < MinPollingInterval {
+		return 0, fmt.Errorf("interval must be at least %s to avoid excessive polling", MinPollingInterval)
+	}
+	if d > MaxPollingInterval {
+		return 0, fmt.Errorf("interval cannot exceed %s", MaxPollingInterval)
+	}
+	return d, nil
+}
+
+// ParseMaxPollingInterval parses the longest polling interval of an application with adaptive
+// polling and checks that it is longer than the application's interval and at most MaxPollingInterval.
+func ParseMaxPollingInterval(maxInterval string, interval time.Duration) (time.Duration, error) {
+	d, err := time.ParseDuration(maxInterval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid max interval '%s': %w (examples: 30m, 1h)", maxInterval, err)
+	}
+	if d <= interval {
+		return 0, fmt.Errorf("max interval must be longer than the interval %s", interval)
+	}
+	if d > MaxPollingInterval {
+		return 0, fmt.Errorf("max interval cannot exceed %s", MaxPollingInterval)
+	}
+	return d, nil
+}
+
+// ValidateBranch checks that a branch name is a valid Git reference name, following the rules
+// of 'git check-ref-format --branch'.
+func ValidateBranch(branch string) error {
+	if branch == "" {
+		return fmt.Errorf("branch cannot be empty")
+	}
+	invalid := func(reason string) error {
+		return fmt.Errorf("invalid branch name '%s': %s", branch, reason)
+	}
+	switch {
+	case strings.HasPrefix(branch, "-"):
+		return invalid("cannot start with '-'")
+	case strings.HasPrefix(branch, "/"), strings.HasSuffix(branch, "/"), strings.Contains(branch, "//"):
+		return invalid("cannot start or end with '/' or contain '//'")
+	case strings.HasSuffix(branch, "."), strings.HasSuffix(branch, ".lock"):
+		return invalid("cannot end with '.' or '.lock'")
+	case strings.Contains(branch, ".."), strings.Contains(branch, "@{"), branch == "@":
+		return invalid("cannot contain '..' or '@{', or be '@'")
+	}
+	for _, component := range strings.Split(branch, "/") {
+		if strings.HasPrefix(component, ".") {
+			return invalid("path components cannot start with '.'")
+		}
+	}
+	for _, char := range branch {
+		if char < 0x20 || char == 0x7f || strings.ContainsRune(" ~^:?*[\\", char) {
+			return invalid(fmt.Sprintf("cannot contain %q", char))
+		}
+	}
+	return nil
+}
+
+// NormalizeRepoPath trims whitespace and surrounding slashes from the path of an application's
+// manifests within its repository and cleans it. The path must stay inside the repository;
+// "." selects the repository root.
+func NormalizeRepoPath(repoPath string) (string, error) {
+	trimmed := strings.Trim(strings.TrimSpace(repoPath), "/")
+	if trimmed == "" {
+		return "", fmt.Errorf("path cannot be empty or contain only slashes")
+	}
+	cleaned := path.Clean(trimmed)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("path '%s' must stay inside the repository", repoPath)
+	}
+	return cleaned, nil
+}
+
+// ValidateGitURL checks that a repository URL is an HTTP(S) or SSH Git URL.
+func ValidateGitURL(repoURL string) error {
+	if !IsValidGitURL(repoURL) {
+		return fmt.Errorf("invalid repository URL format: %s\nMust be a valid HTTPS or SSH Git URL", repoURL)
+	}
+	return nil
+}
+
 // IsValidGitURL validates if a string is a basic Git URL (HTTPS or SSH format)
 // It checks for common patterns like "git@host:repo.git" for SSH and "http(s)://host/repo.git" for HTTPS.
 func IsValidGitURL(s string) bool {
@@ -27,8 +154,8 @@ func IsValidGitURL(s string) bool {
 // It checks that the path is not empty or just slashes after trimming leading and trailing slashes.
 // This is useful to ensure that the path provided for manifests in the repository is meaningful.
 func IsValidRepoPath(s string) bool {
-	trimmed := strings.TrimPrefix(strings.TrimSuffix(s, "/"), "/")
-	return trimmed != "" // Path cannot be empty or just slashes after trimming
+	_, err := NormalizeRepoPath(s)
+	return err == nil
 }
 
 // ParseURL is a helper to parse a URL. Using net/url.ParseRequestURI for stricter parsing.
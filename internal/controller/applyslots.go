@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// applySlotRecheckInterval is how often an application waiting for an apply slot reads the
+// cluster's limit again, so that a raised limit is noticed without waiting for a release.
+const applySlotRecheckInterval = 5 * time.Second
+
+// applySlots counts the applications applying manifests to a cluster, so that the cluster's
+// MaxConcurrentApplies is respected whatever it was when the applies started. The fields are
+// protected by the controller's applySlotsMu.
+type applySlots struct {
+	// inUse is the number of applies in progress, including those started under another limit.
+	inUse int
+	// waiting is the number of applies waiting for a slot.
+	waiting int
+	// released is closed, and replaced, whenever a slot is released, to wake the waiting applies.
+	released chan struct{}
+}
+
+// acquireApplySlot waits until the application may apply its manifests to its cluster, if the
+// cluster limits its concurrent applies, and returns the function releasing the slot.
+//
+// The limit is read again on every attempt, so a lowered limit holds back new applies until enough
+// of those in progress have finished. Waiting counts against neither the sync's apply timeout nor
+// its failures. An error is returned only if ctx is done first.
+func (c *Controller) acquireApplySlot(ctx context.Context, logger *zap.Logger, clusterName string) (release func(), err error) {
+	var waitStart time.Time
+	for {
+		limit := c.maxConcurrentApplies(clusterName)
+
+		c.applySlotsMu.Lock()
+		s, ok := c.applySlots[clusterName]
+		if !ok {
+			s = &applySlots{released: make(chan struct{})}
+			c.applySlots[clusterName] = s
+		}
+		if limit <= 0 || s.inUse < limit {
+			s.inUse++
+			if !waitStart.IsZero() {
+				s.waiting--
+			}
+			c.applySlotsMu.Unlock()
+			if !waitStart.IsZero() {
+				logger.Debug("Acquired apply slot", zap.String("cluster", clusterName), zap.Duration("waited", time.Since(waitStart)))
+			}
+			return func() { c.releaseApplySlot(clusterName, s) }, nil
+		}
+		if waitStart.IsZero() {
+			s.waiting++
+			waitStart = time.Now()
+			logger.Info("Waiting for another application to finish applying to the cluster",
+				zap.String("cluster", clusterName), zap.Int("maxConcurrentApplies", limit))
+		}
+		released := s.released
+		c.applySlotsMu.Unlock()
+
+		select {
+		case <-released:
+		case <-time.After(applySlotRecheckInterval):
+		case <-ctx.Done():
+			c.applySlotsMu.Lock()
+			s.waiting--
+			c.forgetIdleApplySlots(clusterName, s)
+			c.applySlotsMu.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// releaseApplySlot releases a slot acquired from s for the cluster and wakes the waiting applies.
+func (c *Controller) releaseApplySlot(clusterName string, s *applySlots) {
+	c.applySlotsMu.Lock()
+	defer c.applySlotsMu.Unlock()
+
+	s.inUse--
+	close(s.released)
+	s.released = make(chan struct{})
+	c.forgetIdleApplySlots(clusterName, s)
+}
+
+// forgetIdleApplySlots removes the slots of a cluster once no apply holds or waits for one, so that
+// renamed and unregistered clusters are not kept. The caller must hold applySlotsMu.
+func (c *Controller) forgetIdleApplySlots(clusterName string, s *applySlots) {
+	if s.inUse == 0 && s.waiting == 0 && c.applySlots[clusterName] == s {
+		delete(c.applySlots, clusterName)
+	}
+}
+
+// maxConcurrentApplies returns the MaxConcurrentApplies of the cluster, or zero if it is not registered.
+func (c *Controller) maxConcurrentApplies(clusterName string) int {
+	c.clusters.RLock()
+	defer c.clusters.RUnlock()
+	if cl, ok := c.clusters.Get(clusterName); ok {
+		return cl.MaxConcurrentApplies
+	}
+	return 0
+}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"go.uber.org/zap"
+)
+
+// MaxPendingChangeDiff is the maximum size of the diff stored with a pending change; longer diffs are truncated.
+const MaxPendingChangeDiff = 64 * 1024
+
+// holdForApproval decides whether the rendered manifests of an application requiring approval may be applied.
+//
+// A change approved at the current commit and values is applied. Otherwise the manifests are diffed against
+// the cluster: a change that modifies no resource is applied right away, and any other change is held back
+// as the application's pending change until it is approved. It reports whether the sync must stop here,
+// in which case the application's status has been updated but not saved.
+func (c *Controller) holdForApproval(ctx context.Context, logger *zap.Logger, application *app.Application, k8sClient *k8s.ClientSet,
+	applyDir, currentHash, valuesDigest string, tracking k8s.Tracking) bool {
+	change := application.PendingChange
+	if change != nil && change.GitHash == currentHash && change.ValuesDigest == valuesDigest {
+		if !change.ApprovedAt.IsZero() {
+			logger.Info("Applying approved change", zap.String("change", change.ID))
+			return false
+		}
+		logger.Debug("Change awaits approval", zap.String("change", change.ID))
+		c.setAppStatus(application, app.StatusAwaitingApproval, changeAwaitingApprovalMessage(application))
+		return true
+	}
+	if change != nil {
+		logger.Info("Pending change is outdated, diffing again", zap.String("change", change.ID), zap.String("changeHash", change.GitHash))
+		c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "ChangeOutdated",
+			fmt.Sprintf("Change %s detected at %s was replaced by a change at %s", change.ID, change.GitHash, currentHash))
+		application.PendingChange = nil
+	}
+
+	logger.Info("Diffing Kubernetes manifests against the cluster...", zap.String("sourceDir", applyDir))
+	changes, diffErrors := k8sClient.DiffManifests(ctx, applyDir, manifestOptions(application), tracking)
+	if len(diffErrors) > 0 {
+		errorMessages := make([]string, len(diffErrors))
+		for i, e := range diffErrors {
+			errorMessages[i] = e.Error()
+		}
+		errMsg := fmt.Sprintf("Failed to diff %d manifest(s), nothing was applied: %s", len(diffErrors), strings.Join(errorMessages, "; "))
+		logger.Error("Failed to diff Kubernetes manifests", zap.String("details", errMsg))
+		c.setAppStatus(application, app.StatusError, errMsg)
+		application.ConsecutiveFailures++
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "DiffFailed", application.Message)
+		c.recordSyncEvent(application, currentHash)
+		return true
+	}
+	if len(changes) == 0 {
+		logger.Info("Manifests change no resources, applying without approval", zap.String("hash", currentHash))
+		return false
+	}
+
+	diff := formatChanges(changes)
+	sum := sha256.Sum256([]byte(currentHash + "\n" + valuesDigest + "\n" + diff))
+	if len(diff) > MaxPendingChangeDiff {
+		diff = diff[:MaxPendingChangeDiff] + "\n... diff truncated\n"
+	}
+	application.PendingChange = &app.PendingChange{
+		ID:           hex.EncodeToString(sum[:])[:12],
+		GitHash:      currentHash,
+		ValuesDigest: valuesDigest,
+		Summary:      summarizeChanges(changes),
+		Diff:         diff,
+		DetectedAt:   time.Now(),
+	}
+	application.ConsecutiveFailures = 0
+	c.setAppStatus(application, app.StatusAwaitingApproval, changeAwaitingApprovalMessage(application))
+	c.recordSyncEvent(application, currentHash)
+	c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "ChangeReady",
+		fmt.Sprintf("Change %s at %s: %s", application.PendingChange.ID, currentHash, application.PendingChange.Summary))
+	logger.Info("Change awaits approval", zap.String("change", application.PendingChange.ID), zap.String("summary", application.PendingChange.Summary))
+	return true
+}
+
+// approvePlan approves the running application's pending plan or change if it has the given ID.
+// It reports whether it was approved, in which case the caller should sync to apply it.
+func (c *Controller) approvePlan(logger *zap.Logger, application *app.Application, planID, appConfigFile string) bool {
+	if err := application.ApprovePlan(planID, time.Now()); err != nil {
+		logger.Warn("Rejected plan approval", zap.String("plan", planID), zap.Error(err))
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "PlanApprovalRejected", err.Error())
+		return false
+	}
+	logger.Info("Plan approved", zap.String("plan", planID))
+	reason, message := approvalEvent(application, planID)
+	c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, reason, message)
+	c.saveAppStatus(application, appConfigFile, true)
+	return true
+}
+
+// approvalEvent returns the reason and message of the event recorded when an application's plan or change is approved.
+func approvalEvent(application *app.Application, planID string) (string, string) {
+	if application.IsTerraform() {
+		return "PlanApproved", fmt.Sprintf("Plan %s approved: %s", planID, application.TerraformPlan.Summary)
+	}
+	return "ChangeApproved", fmt.Sprintf("Change %s approved: %s", planID, application.PendingChange.Summary)
+}
+
+// changeAwaitingApprovalMessage returns the status message of an application whose change awaits approval.
+func changeAwaitingApprovalMessage(application *app.Application) string {
+	change := application.PendingChange
+	return fmt.Sprintf("Change %s at %s awaits approval: %s Approve it with 'gitopsctl app approve %s'",
+		change.ID, change.GitHash, change.Summary, application.Name)
+}
+
+// summarizeChanges counts the resources a change creates and updates, e.g. "1 to create, 2 to update."
+func summarizeChanges(changes []k8s.ResourceChange) string {
+	var create, update int
+	for _, change := range changes {
+		if change.Action == k8s.ChangeCreate {
+			create++
+		} else {
+			update++
+		}
+	}
+	return fmt.Sprintf("%d to create, %d to update.", create, update)
+}
+
+// formatChanges joins the diffs of the changed resources, each under a header naming the resource.
+func formatChanges(changes []k8s.ResourceChange) string {
+	var sb strings.Builder
+	for _, change := range changes {
+		fmt.Fprintf(&sb, "%s (%s)\n%s", change.Resource, change.Action, change.Diff)
+	}
+	return sb.String()
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"go.uber.org/zap"
+)
+
+// clusterClient is the Kubernetes client set shared by all applications and health checks of a cluster.
+type clusterClient struct {
+	// clientSet is the shared client set.
+	clientSet *k8s.ClientSet
+	// kubeconfigPath, inCluster, qps and burst are the cluster settings the client set was created with.
+	kubeconfigPath string
+	inCluster      bool
+	qps            float32
+	burst          int
+	// kubeconfigModTime is when the kubeconfig file was last modified when the client set was created.
+	kubeconfigModTime time.Time
+	// impersonating are the client sets impersonating application identities, by identity, which
+	// share the rate limit and discovery cache of clientSet.
+	impersonating map[string]*k8s.ClientSet
+}
+
+// clientFor returns the shared client set for a cluster.
+//
+// The client set is created on first use and recreated when the cluster's kubeconfig path or
+// rate limits change, or the kubeconfig file is modified (for example by 'aws eks update-kubeconfig'
+// or a rotated token), so its rate limiter and discovery cache are reused across syncs. When a
+// discovery cache directory is set, each cluster's discovery results are persisted in a
+// subdirectory named after the cluster.
+func (c *Controller) clientFor(cl *cluster.Cluster) (*k8s.ClientSet, error) {
+	c.clientsMu.Lock()
+	defer c.clientsMu.Unlock()
+
+	cc, err := c.clusterClientLocked(cl)
+	if err != nil {
+		return nil, err
+	}
+	return cc.clientSet, nil
+}
+
+// appClientFor returns the client set an application's resources are applied with on a cluster.
+//
+// It is the cluster's shared client set, impersonating the user and groups of the application or,
+// if it sets none, of the cluster. Impersonating client sets are created on first use and reuse
+// the shared client set's rate limiter and discovery cache.
+func (c *Controller) appClientFor(cl *cluster.Cluster, application *app.Application) (*k8s.ClientSet, error) {
+	c.clientsMu.Lock()
+	defer c.clientsMu.Unlock()
+
+	cc, err := c.clusterClientLocked(cl)
+	if err != nil {
+		return nil, err
+	}
+	user, groups := application.EffectiveImpersonation(cl.ImpersonateUser, cl.ImpersonateGroups)
+	identity := k8s.Impersonation{User: user, Groups: groups}
+	if identity.IsZero() {
+		return cc.clientSet, nil
+	}
+	key := identity.String()
+	if clientSet, ok := cc.impersonating[key]; ok {
+		return clientSet, nil
+	}
+	clientSet, err := cc.clientSet.Impersonating(identity)
+	if err != nil {
+		return nil, err
+	}
+	cc.impersonating[key] = clientSet
+	return clientSet, nil
+}
+
+// clusterClientLocked returns the shared client of a cluster, creating it if needed as described
+// for clientFor. The caller must hold clientsMu.
+func (c *Controller) clusterClientLocked(cl *cluster.Cluster) (*clusterClient, error) {
+	modTime := kubeconfigModTime(cl)
+	if cc, ok := c.clients[cl.Name]; ok &&
+		cc.kubeconfigPath == cl.KubeconfigPath && cc.inCluster == cl.InCluster && cc.qps == cl.QPS && cc.burst == cl.Burst &&
+		cc.kubeconfigModTime.Equal(modTime) {
+		return cc, nil
+	}
+
+	opts := k8s.ClientOptions{QPS: cl.QPS, Burst: cl.Burst, InCluster: cl.InCluster}
+	if c.discoveryCacheDir != "" {
+		opts.DiscoveryCacheDir = filepath.Join(c.discoveryCacheDir, cl.Name)
+	}
+	clientSet, err := k8s.NewClientSet(c.logger.With(zap.String("cluster", cl.Name)), cl.KubeconfigPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	cc := &clusterClient{
+		clientSet:         clientSet,
+		kubeconfigPath:    cl.KubeconfigPath,
+		inCluster:         cl.InCluster,
+		qps:               cl.QPS,
+		burst:             cl.Burst,
+		kubeconfigModTime: modTime,
+		impersonating:     make(map[string]*k8s.ClientSet),
+	}
+	c.clients[cl.Name] = cc
+	return cc, nil
+}
+
+// kubeconfigModTime returns when the kubeconfig file of a cluster was last modified, or the zero
+// time if the cluster connects in-cluster or the file cannot be read.
+func kubeconfigModTime(cl *cluster.Cluster) time.Time {
+	if cl.InCluster || cl.KubeconfigPath == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(cl.KubeconfigPath)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -2,44 +2,54 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/alert"
 	"aeswibon.com/github/gitopsctl/internal/core/app"
 	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
 	"aeswibon.com/github/gitopsctl/internal/core/git"
 	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/notify"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
+	"aeswibon.com/github/gitopsctl/internal/core/stats"
 	"go.uber.org/zap"
+	"k8s.io/client-go/util/workqueue"
 )
 
-// ClusterCommandType defines the type of command for a cluster.
-type ClusterCommandType string
-
-// ClusterCommand represents a command to be executed for a specific cluster.
-type ClusterCommand struct {
-	Type        ClusterCommandType
-	ClusterName string
-}
-
 const (
-	// ClusterCommandCheck indicates a command to check the health of a cluster.
-	// This is used to trigger a health check for the cluster's connectivity and status.
-	ClusterCommandCheck ClusterCommandType = "CHECK"
 	// MaxConsecutiveFailures defines the maximum number of consecutive failures
-	// before the reconciliation loop stops for an application.
+	// before the reconciliation loop stops for an application and it is suspended.
 	MaxConsecutiveFailures = 5
 	// BaseBackoffDuration defines the base duration for exponential backoff
 	BaseBackoffDuration = 5 * time.Second
 	// GitOperationTimeout defines the timeout for Git operations like clone/pull.
 	GitOperationTimeout = 60 * time.Second
-	// K8sApplyTimeout defines the timeout for applying Kubernetes manifests.
+	// K8sApplyTimeout defines the default timeout for applying Kubernetes manifests.
 	K8sApplyTimeout = 120 * time.Second
-	// K8sConnectTimeout defines the timeout for establishing a connection to the Kubernetes cluster.
+	// K8sConnectTimeout defines the default timeout for establishing a connection to the Kubernetes cluster.
 	K8sConnectTimeout = 10 * time.Second
+	// ClusterHealthCheckWorkers is the number of cluster health checks that can run at the same time.
+	ClusterHealthCheckWorkers = 4
+	// SlowAPILatency is the API server response time above which a cluster is reported as degraded.
+	SlowAPILatency = 2 * time.Second
+	// CertificateExpiryWarning is how long before its client certificate expires a cluster is reported as degraded.
+	CertificateExpiryWarning = 7 * 24 * time.Hour
+	// DefaultDrainTimeout is how long Stop waits for in-flight syncs to finish before cancelling them.
+	DefaultDrainTimeout = 30 * time.Second
+
+	// suspendedAlertRule is the rule name of the notification sent when an application is suspended.
+	suspendedAlertRule = "ApplicationSuspended"
 )
 
 // AppCommandType defines the type of command for an application.
@@ -53,8 +63,24 @@ const (
 	// This is used to gracefully stop the reconciliation loop for an application.
 	AppCommandStop AppCommandType = "STOP"
 	// AppCommandSync indicates a command to trigger an immediate sync for an app.
-	// This is used to force a synchronization of the application's Git repository
+	// This is used to force a synchronization of the application's Git repository.
+	// Its Data holds the app.Trigger of the request under "trigger".
 	AppCommandSync AppCommandType = "SYNC"
+	// AppCommandResetFailures indicates a command to clear an app's consecutive failures.
+	// This ends any backoff so the app resumes polling at its normal interval.
+	AppCommandResetFailures AppCommandType = "RESET_FAILURES"
+	// AppCommandApprovePlan indicates a command to approve a Terraform app's pending plan, or the
+	// pending change of an app requiring approval.
+	// Its Data holds the approved plan's or change's ID under "plan"; the app is synced to apply it.
+	AppCommandApprovePlan AppCommandType = "APPROVE_PLAN"
+	// AppCommandSuspend indicates a command to suspend an app's reconciliation until its failures are reset.
+	// Unlike a stop, the suspension survives controller restarts.
+	// Its Data holds the app.Trigger of the request under "trigger".
+	AppCommandSuspend AppCommandType = "SUSPEND"
+	// AppCommandRollback indicates a command to apply the healthy revision before an app's last one again.
+	// The revision rolled back from is not applied again until the app's failures are reset.
+	// Its Data holds the app.Trigger of the request under "trigger".
+	AppCommandRollback AppCommandType = "ROLLBACK"
 )
 
 // AppCommand represents a command to be executed for a specific application.
@@ -70,14 +96,48 @@ type AppCommand struct {
 	Data map[string]any
 }
 
+// Commander is the set of operations the API layer uses to drive the controller.
+//
+// It is implemented by Controller for in-process use and by RemoteClient when the
+// API server runs standalone and talks to a controller over its control socket.
+type Commander interface {
+	StartApp(appName string)
+	StopApp(appName string)
+	TriggerSync(appName string, trigger app.Trigger)
+	DryRunSync(appName string, trigger app.Trigger) (*DryRunReport, error)
+	ResetFailures(appName string)
+	ApprovePlan(appName, planID string)
+	SuspendApp(appName string, trigger app.Trigger)
+	RollbackApp(appName string, trigger app.Trigger)
+	SetAppDebug(appName string, enabled bool)
+	TriggerClusterHealthCheck(clusterName string)
+	RenameApp(oldName, newName string) error
+	RenameCluster(oldName, newName string) error
+	RegisterApp(spec *app.Application) error
+	UnregisterApp(appName string, keepHistory, resourcesDeleted bool) error
+	RegisterCluster(cl *cluster.Cluster) error
+	UnregisterCluster(clusterName string) error
+	IsDispatcherRunning() bool
+}
+
 // AppRuntime holds the context and cancel function for a running application goroutine.
 //
 // It is used to manage the lifecycle of the application's reconciliation loop.
 type appRuntime struct {
 	// Context is used to manage the lifecycle of the application's reconciliation loop.
 	cancel context.CancelFunc
-	// syncChan is a channel used to trigger immediate synchronization of the application.
-	syncChan chan struct{}
+	// syncChan is a channel used to trigger immediate synchronization of the application at an operator's request.
+	syncChan chan app.Trigger
+	// resetChan is a channel used to clear the application's consecutive failures.
+	resetChan chan struct{}
+	// approveChan is a channel used to approve the pending Terraform plan or change with the given ID.
+	approveChan chan string
+	// suspendChan is a channel used to suspend the application at an operator's request.
+	suspendChan chan app.Trigger
+	// rollbackChan is a channel used to roll the application back to its previous revision at an operator's request.
+	rollbackChan chan app.Trigger
+	// done is closed once the reconciliation loop has exited and saved its final status.
+	done chan struct{}
 }
 
 // Controller orchestrates the GitOps reconciliation loop.
@@ -90,37 +150,147 @@ type Controller struct {
 	apps *app.Applications
 	// Clusters holds the list of clusters to which applications can be deployed.
 	clusters *cluster.Clusters
+	// Events holds the events recorded for controller actions.
+	events *event.Events
+	// Alerts evaluates alerting rules against applications and clusters; nil disables alerting.
+	alerts *alert.Engine
+	// AppStates validates application status changes and runs the hooks registered for them.
+	appStates *app.StateMachine
+	// ClusterStates validates cluster status changes and runs the hooks registered for them.
+	clusterStates *cluster.StateMachine
+	// Plugins holds the configured manifest generator plugins.
+	plugins *render.Plugins
+	// RepoCache keeps application clones across restarts; nil clones into temporary directories.
+	repoCache *git.RepoCache
 	// Context is used to manage cancellation and timeouts for the reconciliation loops.
 	ctx context.Context
 	// Cancel function to stop the context and signal all goroutines to exit.
 	cancel context.CancelFunc
-	// AppCommandChan is a channel for receiving commands to start, stop, or sync applications.
-	appCommandChan chan AppCommand
-	// ClusterCommandChan is a channel for receiving commands related to cluster health checks.
-	clusterCommandChan chan ClusterCommand
+	// DrainCtx is the parent context of in-flight syncs; it outlives ctx by up to drainTimeout on shutdown.
+	drainCtx context.Context
+	// DrainCancel cancels any syncs still in flight once the drain timeout has passed.
+	drainCancel context.CancelFunc
+	// DrainTimeout is how long Stop waits for in-flight syncs to finish.
+	drainTimeout time.Duration
+	// ApplyTimeout bounds applying the manifests of a sync, rollback or rollout refresh.
+	applyTimeout time.Duration
+	// ConnectTimeout bounds connecting to a cluster and its health checks.
+	connectTimeout time.Duration
+	// AppQueue holds pending commands to start, stop, sync, or reset applications.
+	appQueue *appCommandQueue
+	// ClusterQueue holds the names of clusters waiting for a health check, including scheduled ones.
+	clusterQueue workqueue.TypedDelayingInterface[string]
+	// Clients holds the Kubernetes client set shared by everything talking to each cluster, keyed by cluster name.
+	clients map[string]*clusterClient
+	// clientsMu protects the clients map.
+	clientsMu sync.Mutex
+	// ApplySlots limits the applications applying to each cluster at the same time, keyed by cluster name.
+	applySlots map[string]*applySlots
+	// applySlotsMu protects the applySlots map and its entries.
+	applySlotsMu sync.Mutex
+	// DiscoveryCacheDir is where API discovery results are persisted per cluster; empty keeps them in memory.
+	discoveryCacheDir string
+	// InstanceID identifies this controller on the resources it applies.
+	instanceID string
 	// RunningApps holds the currently running applications and their contexts.
 	runningApps map[string]*appRuntime
 	// mu protects the appContexts map to ensure thread-safe access.
 	mu sync.Mutex
 	// WaitGroup is used to wait for all reconciliation goroutines to finish before shutdown.
 	wg sync.WaitGroup
+	// appConfigFile is the path of the applications file the controller was started with.
+	appConfigFile string
+	// RateLimits holds when each Git provider host accepts fetches again after a rate limit response.
+	rateLimits map[string]time.Time
+	// rateLimitsMu protects the rateLimits map.
+	rateLimitsMu sync.Mutex
+	// DebugApps holds the names of the applications debug logging is turned on for.
+	debugApps map[string]bool
+	// debugAppsMu protects the debugApps map.
+	debugAppsMu sync.Mutex
+	// dispatcherRunning reports whether the command dispatcher goroutine is currently active.
+	dispatcherRunning atomic.Bool
 }
 
 // NewController creates a new Controller instance.
 //
 // It initializes the context and sets up the logger and applications.
-func NewController(logger *zap.Logger, apps *app.Applications, clusters *cluster.Clusters) *Controller {
+func NewController(logger *zap.Logger, apps *app.Applications, clusters *cluster.Clusters, events *event.Events, alerts *alert.Engine, plugins *render.Plugins, repoCache *git.RepoCache) *Controller {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &Controller{
-		logger:             logger,
-		apps:               apps,
-		clusters:           clusters,
-		ctx:                ctx,
-		cancel:             cancel,
-		appCommandChan:     make(chan AppCommand, 10),
-		clusterCommandChan: make(chan ClusterCommand, 10),
-		runningApps:        make(map[string]*appRuntime),
+	drainCtx, drainCancel := context.WithCancel(context.Background())
+	c := &Controller{
+		logger:         logger,
+		apps:           apps,
+		clusters:       clusters,
+		events:         events,
+		alerts:         alerts,
+		appStates:      app.NewStateMachine(),
+		clusterStates:  cluster.NewStateMachine(),
+		plugins:        plugins,
+		repoCache:      repoCache,
+		ctx:            ctx,
+		cancel:         cancel,
+		drainCtx:       drainCtx,
+		drainCancel:    drainCancel,
+		drainTimeout:   DefaultDrainTimeout,
+		applyTimeout:   K8sApplyTimeout,
+		connectTimeout: K8sConnectTimeout,
+		instanceID:     DefaultInstanceID(),
+		appQueue:       newAppCommandQueue(),
+		clusterQueue:   workqueue.NewTypedDelayingQueueWithConfig(workqueue.TypedDelayingQueueConfig[string]{Name: "clusters"}),
+		clients:        make(map[string]*clusterClient),
+		applySlots:     make(map[string]*applySlots),
+		runningApps:    make(map[string]*appRuntime),
+		rateLimits:     make(map[string]time.Time),
+		debugApps:      make(map[string]bool),
 	}
+	c.appStates.OnTransition(c.notifySuspension)
+	c.clusterStates.OnTransition(c.recordClusterTransition)
+	return c
+}
+
+// SetDrainTimeout sets how long Stop waits for in-flight syncs to finish before cancelling them.
+//
+// A zero timeout cancels in-flight syncs immediately.
+func (c *Controller) SetDrainTimeout(timeout time.Duration) {
+	c.drainTimeout = timeout
+}
+
+// SetTimeouts sets how long applying manifests to a cluster and connecting to it may take.
+//
+// It must be called before Start. A zero timeout keeps the default of K8sApplyTimeout or
+// K8sConnectTimeout.
+func (c *Controller) SetTimeouts(apply, connect time.Duration) {
+	if apply > 0 {
+		c.applyTimeout = apply
+	}
+	if connect > 0 {
+		c.connectTimeout = connect
+	}
+}
+
+// SetDiscoveryCacheDir sets the directory API discovery results are persisted in, one subdirectory per cluster.
+//
+// It must be called before Start. An empty directory caches discovery in memory only.
+func (c *Controller) SetDiscoveryCacheDir(dir string) {
+	c.discoveryCacheDir = dir
+}
+
+// SetInstanceID sets the ID recorded on applied resources to identify this controller.
+//
+// An empty ID keeps the default (see DefaultInstanceID).
+func (c *Controller) SetInstanceID(id string) {
+	if id != "" {
+		c.instanceID = id
+	}
+}
+
+// DefaultInstanceID returns the default controller instance ID, the host name of the machine.
+func DefaultInstanceID() string {
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return k8s.ManagedByValue
 }
 
 // Start begins the reconciliation loop for all registered applications.
@@ -128,12 +298,31 @@ func NewController(logger *zap.Logger, apps *app.Applications, clusters *cluster
 // It spawns a goroutine for each application to handle its synchronization process.
 func (c *Controller) Start(appConfigFile string) error {
 	c.logger.Info("Starting GitOps controller...")
+	c.appConfigFile = appConfigFile
+
+	if removed, err := git.CleanUpOrphanedRepoDirs(c.logger, "", false); err != nil {
+		c.logger.Warn("Failed to clean up orphaned repository directories", zap.Error(err))
+	} else if len(removed) > 0 {
+		c.logger.Info("Removed orphaned repository directories", zap.Int("count", len(removed)))
+	}
 
 	c.wg.Add(1)
 	go c.commandDispatcher(appConfigFile)
 
-	c.wg.Add(1)
-	go c.clusterHealthChecker()
+	c.logger.Info("Starting cluster health check workers...", zap.Int("workers", ClusterHealthCheckWorkers))
+	for range ClusterHealthCheckWorkers {
+		c.wg.Add(1)
+		go c.clusterHealthWorker()
+	}
+
+	if c.alerts != nil {
+		c.wg.Add(2)
+		go c.alertEvaluator()
+		go func() {
+			defer c.wg.Done()
+			c.alerts.Run(c.ctx)
+		}()
+	}
 
 	c.apps.RLock()
 	defer c.apps.RUnlock()
@@ -141,8 +330,16 @@ func (c *Controller) Start(appConfigFile string) error {
 	appsToStart := c.apps.List()
 	if len(appsToStart) > 0 {
 		c.logger.Info(fmt.Sprintf("Attempting to launch %d existing application reconciliation loops...", len(appsToStart)))
+		// Resume applications whose sync was interrupted by the last shutdown first
+		sort.SliceStable(appsToStart, func(i, j int) bool {
+			return appsToStart[i].Status == app.StatusInterrupted && appsToStart[j].Status != app.StatusInterrupted
+		})
 		for _, application := range appsToStart {
-			c.appCommandChan <- AppCommand{Type: AppCommandStart, AppName: application.Name}
+			if application.Status == app.StatusSuspended {
+				c.logger.Warn("Not starting suspended application; reset it to resume reconciliation", zap.String("app", application.Name))
+				continue
+			}
+			c.appQueue.Add(AppCommand{Type: AppCommandStart, AppName: application.Name})
 		}
 	} else {
 		c.logger.Info("No existing applications found to launch at startup.")
@@ -155,7 +352,7 @@ func (c *Controller) Start(appConfigFile string) error {
 	if len(clustersToCheck) > 0 {
 		c.logger.Info(fmt.Sprintf("Triggering initial health checks for %d clusters...", len(clustersToCheck)))
 		for _, cl := range clustersToCheck {
-			c.clusterCommandChan <- ClusterCommand{Type: ClusterCommandCheck, ClusterName: cl.Name}
+			c.clusterQueue.Add(cl.Name)
 		}
 	} else {
 		c.logger.Info("No existing clusters found to check at startup.")
@@ -167,148 +364,355 @@ func (c *Controller) Start(appConfigFile string) error {
 
 // Stop gracefully stops all reconciliation loops.
 //
-// It cancels the context and waits for all goroutines to finish.
+// It cancels the context and waits for all goroutines to finish. Syncs that are in flight
+// are allowed to complete for up to the drain timeout; any still running after that are
+// cancelled and their applications are marked "Interrupted" so they are resumed first on restart.
 func (c *Controller) Stop() {
 	c.logger.Info("Stopping GitOps controller...")
-	c.cancel()                  // Signal all goroutines to stop
-	close(c.appCommandChan)     // Close the command channel
-	close(c.clusterCommandChan) // Close the cluster command channel
-	c.wg.Wait()                 // Wait for all goroutines to finish
+	c.cancel()                // Signal all goroutines to stop
+	c.appQueue.ShutDown()     // Stop accepting application commands
+	c.clusterQueue.ShutDown() // Stop accepting cluster health checks
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait() // Wait for all goroutines to finish
+		close(done)
+	}()
+
+	timer := time.NewTimer(c.drainTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		c.logger.Warn("In-flight syncs did not finish within the drain timeout, cancelling them",
+			zap.Duration("drainTimeout", c.drainTimeout))
+		c.drainCancel()
+		<-done
+	}
+	c.drainCancel()
 	c.logger.Info("GitOps controller stopped.")
 }
 
-// StartApp sends a command to start or restart an application's reconciliation loop.
+// StartApp queues a command to start or restart an application's reconciliation loop.
 //
-// It will reload the application's definition from the config file.
+// It will reload the application's definition from the config file. It never blocks.
 func (c *Controller) StartApp(appName string) {
-	c.appCommandChan <- AppCommand{Type: AppCommandStart, AppName: appName}
+	c.appQueue.Add(AppCommand{Type: AppCommandStart, AppName: appName})
 }
 
-// StopApp sends a command to stop an application's reconciliation loop.
+// StopApp queues a command to stop an application's reconciliation loop.
 //
-// It will gracefully stop the reconciliation loop for the specified application.
+// It will gracefully stop the reconciliation loop for the specified application. It never blocks.
 func (c *Controller) StopApp(appName string) {
-	c.appCommandChan <- AppCommand{Type: AppCommandStop, AppName: appName}
+	c.appQueue.Add(AppCommand{Type: AppCommandStop, AppName: appName})
 }
 
-// TriggerSync sends a command to trigger an immediate sync for an application.
+// TriggerSync queues a command to trigger an immediate sync for an application.
 //
-// This is useful for forcing a synchronization of the application's Git repository
-func (c *Controller) TriggerSync(appName string) {
-	c.appCommandChan <- AppCommand{Type: AppCommandSync, AppName: appName}
+// This is useful for forcing a synchronization of the application's Git repository.
+// Requests made while a sync is already queued for the application are merged into it, and the
+// sync is recorded in the application's sync history as requested by the latest trigger.
+func (c *Controller) TriggerSync(appName string, trigger app.Trigger) {
+	if !c.appQueue.Add(AppCommand{Type: AppCommandSync, AppName: appName, Data: map[string]any{"trigger": trigger}}) {
+		c.logger.Debug("Sync already queued for application, merging request", zap.String("app", appName))
+	}
+}
+
+// ResetFailures sends a command to clear an application's consecutive failures.
+//
+// This is used after an operator fixes the underlying issue, so the application stops
+// backing off and resumes polling at its normal interval.
+func (c *Controller) ResetFailures(appName string) {
+	c.appQueue.Add(AppCommand{Type: AppCommandResetFailures, AppName: appName})
+}
+
+// ApprovePlan sends a command to approve a Terraform application's pending plan, or the pending
+// change of an application requiring approval.
+//
+// The plan is applied by the next sync, which is triggered immediately, unless a newer commit
+// was pushed in the meantime: then the plan is replaced by one that must be approved again.
+func (c *Controller) ApprovePlan(appName, planID string) {
+	c.appQueue.Add(AppCommand{Type: AppCommandApprovePlan, AppName: appName, Data: map[string]any{"plan": planID}})
+}
+
+// SuspendApp queues a command to suspend an application's reconciliation.
+//
+// A sync in progress is completed first. The application is not reconciled again, including
+// after a controller restart, until its failures are reset. It never blocks.
+func (c *Controller) SuspendApp(appName string, trigger app.Trigger) {
+	c.appQueue.Add(AppCommand{Type: AppCommandSuspend, AppName: appName, Data: map[string]any{"trigger": trigger}})
+}
+
+// RollbackApp queues a command to roll an application back to the healthy revision before its last one.
+//
+// Only two-phase syncs keep the previous revision. The revision rolled back from is not applied
+// again until the application's failures are reset. It never blocks.
+func (c *Controller) RollbackApp(appName string, trigger app.Trigger) {
+	if !c.appQueue.Add(AppCommand{Type: AppCommandRollback, AppName: appName, Data: map[string]any{"trigger": trigger}}) {
+		c.logger.Debug("Rollback already queued for application, merging request", zap.String("app", appName))
+	}
 }
 
 // TriggerClusterHealthCheck sends a command to trigger an immediate health check for a cluster.
 //
 // This is useful for manually checking the connectivity and status of a cluster.
 func (c *Controller) TriggerClusterHealthCheck(clusterName string) {
-	c.clusterCommandChan <- ClusterCommand{Type: ClusterCommandCheck, ClusterName: clusterName}
+	c.clusterQueue.Add(clusterName)
+}
+
+// IsDispatcherRunning reports whether the command dispatcher is accepting application commands.
+//
+// It is used by readiness probes to verify that the controller is operational.
+func (c *Controller) IsDispatcherRunning() bool {
+	return c.dispatcherRunning.Load()
 }
 
 // CommandDispatcher is the central goroutine that processes application commands.
 //
-// It listens for commands to start, stop, or sync applications and manages their reconciliation loops.
+// It takes commands to start, stop, sync, or reset applications from the command queue
+// and manages their reconciliation loops.
 func (c *Controller) commandDispatcher(appConfigFile string) {
 	defer c.wg.Done()
+	c.dispatcherRunning.Store(true)
+	defer c.dispatcherRunning.Store(false)
 	c.logger.Info("Starting controller command dispatcher...")
 
 	for {
-		select {
-		case cmd, ok := <-c.appCommandChan:
-			if !ok { // Channel closed, dispatcher should exit
-				c.logger.Info("Command channel closed, dispatcher exiting.")
-				c.stopAllAppGoroutines() // Stop any remaining app goroutines
-				return
-			}
-			c.handleAppCommand(cmd, appConfigFile)
-		case <-c.ctx.Done(): // Main controller context cancelled, dispatcher should exit
-			c.logger.Info("Main controller context cancelled, dispatcher exiting.")
+		appName, cmds, shutdown := c.appQueue.Get()
+		if shutdown { // Queue shut down, dispatcher should exit
+			c.logger.Info("Command queue shut down, dispatcher exiting.")
 			c.stopAllAppGoroutines() // Stop any remaining app goroutines
 			return
 		}
+		if c.ctx.Err() == nil {
+			for _, cmd := range cmds {
+				c.handleAppCommand(cmd, appConfigFile)
+			}
+		}
+		c.appQueue.Done(appName)
 	}
 }
 
-// ClusterHealthChecker periodically checks the health of registered clusters.
+// clusterHealthWorker performs the cluster health checks queued in clusterQueue.
 //
-// It runs in a separate goroutine and checks cluster connectivity at regular intervals.
-func (c *Controller) clusterHealthChecker() {
+// ClusterHealthCheckWorkers workers run concurrently. After each check the cluster is queued again
+// after its health check interval, so periodic and manually triggered checks of the same cluster
+// are deduplicated and never run at the same time.
+func (c *Controller) clusterHealthWorker() {
 	defer c.wg.Done()
-	c.logger.Info("Cluster health checker started.")
-
-	ticker := time.NewTicker(cluster.DefaultClusterHealthCheckInterval)
-	defer ticker.Stop()
 
 	for {
-		select {
-		case <-ticker.C:
-			c.clusters.RLock()
-			defer c.clusters.RUnlock()
-
-			clustersToCheck := c.clusters.List()
-			for _, cl := range clustersToCheck {
-				c.performClusterHealthCheck(c.ctx, cl)
-			}
-		case cmd, ok := <-c.clusterCommandChan:
-			if !ok {
-				c.logger.Info("Cluster command channel closed, health checker exiting.")
-				return
-			}
-			if cmd.Type == ClusterCommandCheck {
-				cl, exists := c.clusters.Get(cmd.ClusterName)
-				if exists {
-					c.logger.Info("Manual health check triggered for cluster", zap.String("cluster", cmd.ClusterName))
-					c.performClusterHealthCheck(c.ctx, cl)
-				} else {
-					c.logger.Warn("Attempted manual health check for non-existent cluster", zap.String("cluster", cmd.ClusterName))
-				}
-			}
-		case <-c.ctx.Done():
-			c.logger.Info("Main controller context cancelled, cluster health checker exiting.")
+		clusterName, shutdown := c.clusterQueue.Get()
+		if shutdown {
+			c.logger.Debug("Cluster queue shut down, health check worker exiting.")
 			return
 		}
+
+		// Check a copy so the stored cluster is only modified while holding the write lock
+		c.clusters.RLock()
+		stored, exists := c.clusters.Get(clusterName)
+		var cl cluster.Cluster
+		if exists {
+			cl = *stored
+			cl.HealthHistory = slices.Clone(stored.HealthHistory)
+		}
+		c.clusters.RUnlock()
+
+		switch {
+		case !exists:
+			c.logger.Warn("Attempted health check for non-existent cluster", zap.String("cluster", clusterName))
+		case c.ctx.Err() == nil:
+			c.performClusterHealthCheck(c.ctx, &cl)
+			c.clusterQueue.AddAfter(clusterName, cl.EffectiveHealthCheckInterval())
+		}
+		c.clusterQueue.Done(clusterName)
 	}
 }
 
 // PerformClusterHealthCheck performs a connectivity check for a given cluster and updates its status.
 //
-// It creates a Kubernetes client for the cluster and checks connectivity.
+// It uses the cluster's shared Kubernetes client to check connectivity. The check updates cl,
+// which must be a copy of the stored cluster, and then copies the result into the store. While
+// applications use placement, it also records the cluster's capacity, and moves the applications
+// placed on the cluster elsewhere when it is unhealthy.
 func (c *Controller) performClusterHealthCheck(ctx context.Context, cl *cluster.Cluster) {
 	logger := c.logger.With(zap.String("cluster", cl.Name))
 	logger.Debug("Performing health check for cluster.")
 
-	// Create a client for the specific cluster
-	k8sClient, err := k8s.NewClientSet(logger, cl.KubeconfigPath)
+	k8sClient, err := c.clientFor(cl)
 	if err != nil {
 		logger.Error("Failed to create K8s client for cluster health check", zap.Error(err))
-		cl.Status = "Error"
-		cl.Message = fmt.Sprintf("Failed to create K8s client: %v", err)
+		c.setClusterStatus(cl, cluster.StatusError, fmt.Sprintf("Failed to create K8s client: %v", err))
 	} else {
-		checkCtx, checkCancel := context.WithTimeout(ctx, K8sConnectTimeout)
+		checkCtx, checkCancel := context.WithTimeout(ctx, c.connectTimeout)
 		defer checkCancel()
-		if err := k8sClient.CheckConnectivity(checkCtx); err != nil {
+		diag, err := k8sClient.Diagnose(checkCtx)
+		if k8s.IsCredentialError(err) {
+			logger.Warn("Cluster credentials were rejected or could not be obtained", zap.Error(err))
+			c.setClusterStatus(cl, cluster.StatusCredentialsExpired, fmt.Sprintf("Credentials expired or rejected: %v", err))
+		} else if err != nil {
 			logger.Warn("Cluster connectivity check failed", zap.Error(err))
-			cl.Status = "Unreachable"
-			cl.Message = fmt.Sprintf("Connectivity failed: %v", err)
+			c.setClusterStatus(cl, cluster.StatusUnreachable, fmt.Sprintf("Connectivity failed: %v", err))
 		} else {
-			logger.Debug("Cluster connectivity check successful.")
-			cl.Status = "Active"
-			cl.Message = "Connectivity successful."
+			cl.Health = clusterHealth(diag, time.Now())
+			if c.placementInUse() {
+				recordCapacity(checkCtx, logger, k8sClient, cl.Health)
+			}
+			if len(cl.Health.Warnings) > 0 {
+				logger.Warn("Cluster is degraded", zap.Strings("warnings", cl.Health.Warnings))
+				c.setClusterStatus(cl, cluster.StatusDegraded, strings.Join(cl.Health.Warnings, "; "))
+			} else {
+				logger.Debug("Cluster connectivity check successful.")
+				c.setClusterStatus(cl, cluster.StatusActive, "Connectivity successful.")
+			}
 		}
 	}
 	cl.LastCheckedAt = time.Now()
+	cl.RecordHealthCheck(cluster.HealthCheckEvent{Time: cl.LastCheckedAt, Status: cl.Status, Message: cl.Message})
+
+	// Save cluster status, unless the cluster was removed while it was being checked
+	c.clusters.Lock()
+	if stored, ok := c.clusters.Get(cl.Name); ok {
+		stored.Status = cl.Status
+		stored.Message = cl.Message
+		stored.LastCheckedAt = cl.LastCheckedAt
+		stored.HealthHistory = cl.HealthHistory
+		stored.Health = cl.Health
+		if err := cluster.SaveClusters(c.clusters, cluster.DefaultClusterConfigFile); err != nil {
+			logger.Error("Failed to save cluster status to file", zap.Error(err))
+		}
+	}
+	c.clusters.Unlock()
+
+	if !cl.Status.Placeable() {
+		c.rebalanceCluster(cl.Name)
+	}
+}
+
+// clusterHealth builds the health record of a cluster from its diagnostics.
+//
+// Nodes that are not ready, a slow API server, and a client certificate that expires within
+// CertificateExpiryWarning are reported as warnings.
+func clusterHealth(diag *k8s.Diagnostics, now time.Time) *cluster.ClusterHealth {
+	health := &cluster.ClusterHealth{
+		ServerVersion:     diag.ServerVersion,
+		APILatency:        diag.VersionLatency,
+		Nodes:             diag.Nodes,
+		ReadyNodes:        diag.ReadyNodes,
+		NodeListLatency:   diag.NodeListLatency,
+		CertificateExpiry: diag.CertificateExpiry,
+		CheckedAt:         now,
+	}
+
+	if diag.NodeError != "" {
+		health.Warnings = append(health.Warnings, diag.NodeError)
+	}
+	if diag.Nodes == 0 {
+		health.Warnings = append(health.Warnings, "Cluster has no nodes")
+	} else if diag.ReadyNodes < diag.Nodes {
+		health.Warnings = append(health.Warnings, fmt.Sprintf("%d of %d node(s) not ready", diag.Nodes-diag.ReadyNodes, diag.Nodes))
+	}
+	if diag.VersionLatency > SlowAPILatency {
+		health.Warnings = append(health.Warnings, fmt.Sprintf("API server responded slowly (%s)", diag.VersionLatency.Round(time.Millisecond)))
+	}
+	if expiry := diag.CertificateExpiry; !expiry.IsZero() {
+		if !expiry.After(now) {
+			health.Warnings = append(health.Warnings, fmt.Sprintf("Client certificate expired at %s", expiry.Format(time.RFC3339)))
+		} else if expiry.Sub(now) < CertificateExpiryWarning {
+			health.Warnings = append(health.Warnings, fmt.Sprintf("Client certificate expires in %s", expiry.Sub(now).Round(time.Hour)))
+		}
+	}
+	return health
+}
+
+// AlertEvaluator periodically evaluates the alerting rules against all applications and clusters.
+//
+// It runs in a separate goroutine and exits when the controller's context is cancelled.
+func (c *Controller) alertEvaluator() {
+	defer c.wg.Done()
+	c.logger.Info("Alert evaluator started.")
+
+	ticker := time.NewTicker(alert.DefaultEvaluationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.evaluateAlerts()
+		case <-c.ctx.Done():
+			c.logger.Info("Main controller context cancelled, alert evaluator exiting.")
+			return
+		}
+	}
+}
+
+// EvaluateAlerts evaluates the alerting rules once and updates the Alerting condition of affected objects.
+//
+// Alerts that fire or resolve are also recorded as events.
+func (c *Controller) evaluateAlerts() {
+	now := time.Now()
+
+	c.apps.Lock()
+	var appSubjects []alert.Subject
+	for _, a := range c.apps.List() {
+		since, consecutive := a.StatusStreak()
+		appSubjects = append(appSubjects, alert.Subject{
+			Kind: alert.KindApplication, Name: a.Name, Status: string(a.Status), Message: a.Message,
+			Since: since, Consecutive: consecutive, Conditions: a.Conditions,
+		})
+	}
+	appsChanged := false
+	for _, result := range c.alerts.Evaluate(appSubjects, now) {
+		if a, ok := c.apps.Get(result.Subject.Name); ok && common.SetCondition(&a.Conditions, result.Condition) {
+			appsChanged = true
+		}
+		c.recordAlertEvents(result)
+	}
+	if appsChanged {
+		if err := app.SaveApplications(c.apps, c.appConfigFile); err != nil {
+			c.logger.Error("Failed to save application conditions to file", zap.Error(err))
+		}
+	}
+	c.apps.Unlock()
 
-	// Save cluster status
 	c.clusters.Lock()
-	if err := cluster.SaveClusters(c.clusters, cluster.DefaultClusterConfigFile); err != nil {
-		logger.Error("Failed to save cluster status to file", zap.Error(err))
+	var clusterSubjects []alert.Subject
+	for _, cl := range c.clusters.List() {
+		since, consecutive := cl.StatusStreak()
+		clusterSubjects = append(clusterSubjects, alert.Subject{
+			Kind: alert.KindCluster, Name: cl.Name, Status: string(cl.Status), Message: cl.Message,
+			Since: since, Consecutive: consecutive, Conditions: cl.Conditions,
+		})
+	}
+	clustersChanged := false
+	for _, result := range c.alerts.Evaluate(clusterSubjects, now) {
+		if cl, ok := c.clusters.Get(result.Subject.Name); ok && common.SetCondition(&cl.Conditions, result.Condition) {
+			clustersChanged = true
+		}
+		c.recordAlertEvents(result)
+	}
+	if clustersChanged {
+		if err := cluster.SaveClusters(c.clusters, cluster.DefaultClusterConfigFile); err != nil {
+			c.logger.Error("Failed to save cluster conditions to file", zap.Error(err))
+		}
 	}
 	c.clusters.Unlock()
 }
 
+// recordAlertEvents records an event for each alert that fired or resolved.
+func (c *Controller) recordAlertEvents(result alert.Result) {
+	for _, n := range result.Notifications {
+		if n.State == notify.StateFiring {
+			c.recordEvent(n.Kind, n.Name, event.TypeWarning, "AlertFiring", fmt.Sprintf("Rule '%s': %s", n.Rule, n.Message))
+		} else {
+			c.recordEvent(n.Kind, n.Name, event.TypeNormal, "AlertResolved", fmt.Sprintf("Rule '%s': %s", n.Rule, n.Message))
+		}
+	}
+}
+
 // HandleAppCommand processes a single application command.
 //
-// It starts, stops, or syncs the specified application based on the command type.
+// It starts, stops, syncs, suspends, or resets the failures of the specified application based on the command type.
 func (c *Controller) handleAppCommand(cmd AppCommand, appConfigFile string) {
 	c.logger.Debug("Received app command", zap.String("type", string(cmd.Type)), zap.String("app", cmd.AppName))
 
@@ -317,29 +721,42 @@ func (c *Controller) handleAppCommand(cmd AppCommand, appConfigFile string) {
 
 	switch cmd.Type {
 	case AppCommandStart:
-		// Load the application config fresh in case it was updated
-		c.apps.RLock()
-		defer c.apps.RUnlock()
+		// Place applications with a placement on a healthy cluster before they start
+		c.placeApp(cmd.AppName, appConfigFile)
 
-		appConfig, exists := c.apps.Get(cmd.AppName)
+		// Load the application config fresh in case it was updated. The locks are released before
+		// its status is saved, which takes the applications lock for writing.
+		c.apps.RLock()
+		stored, exists := c.apps.Get(cmd.AppName)
+		var appConfig app.Application
+		if exists {
+			appConfig = *stored
+		}
+		c.apps.RUnlock()
 		if !exists {
 			c.logger.Error("Attempted to start non-existent application", zap.String("app", cmd.AppName))
 			return
 		}
 
 		c.clusters.RLock()
-		defer c.clusters.RUnlock()
-
 		_, clusterExists := c.clusters.Get(appConfig.ClusterName)
-		if !clusterExists {
+		c.clusters.RUnlock()
+
+		// Terraform applications are not deployed to a cluster
+		if !clusterExists && !appConfig.IsTerraform() {
 			c.logger.Error("Attempted to start application with non-existent cluster",
 				zap.String("app", cmd.AppName),
 				zap.String("cluster", appConfig.ClusterName))
 
-			appConfig.Status = "Error"
-			appConfig.Message = fmt.Sprintf("Cluster '%s' does not exist", appConfig.ClusterName)
-			appConfig.ConsecutiveFailures = 0               // Reset failures on critical error
-			c.saveAppStatus(appConfig, appConfigFile, true) // Force save on critical error
+			c.setAppStatus(&appConfig, app.StatusError, fmt.Sprintf("Cluster '%s' does not exist", appConfig.ClusterName))
+			appConfig.ConsecutiveFailures = 0 // Reset failures on critical error
+			c.recordEvent(event.KindApplication, appConfig.Name, event.TypeWarning, "ClusterNotFound", appConfig.Message)
+			c.saveAppStatus(&appConfig, appConfigFile, true) // Force save on critical error
+			return
+		}
+
+		if appConfig.Status == app.StatusSuspended {
+			c.logger.Warn("Not starting suspended application; reset it to resume reconciliation", zap.String("app", cmd.AppName))
 			return
 		}
 
@@ -350,13 +767,7 @@ func (c *Controller) handleAppCommand(cmd AppCommand, appConfigFile string) {
 			// The deferred func in reconcileApp will clean up the old entry from runningApps
 		}
 
-		appCtx, appCancel := context.WithCancel(c.ctx) // New context for the app
-		syncChan := make(chan struct{}, 1)             // New sync channel for the app
-
-		appCopy := *appConfig // Create a copy for the goroutine
-		c.wg.Add(1)
-		c.runningApps[cmd.AppName] = &appRuntime{cancel: appCancel, syncChan: syncChan}
-		go c.reconcileApp(appCtx, &appCopy, appConfigFile, appCancel, syncChan)
+		c.launchApp(&appConfig, appConfigFile)
 
 	case AppCommandStop:
 		if runtime, ok := c.runningApps[cmd.AppName]; ok {
@@ -368,17 +779,150 @@ func (c *Controller) handleAppCommand(cmd AppCommand, appConfigFile string) {
 		}
 
 	case AppCommandSync:
+		trigger, _ := cmd.Data["trigger"].(app.Trigger)
 		if runtime, ok := c.runningApps[cmd.AppName]; ok {
 			select {
-			case runtime.syncChan <- struct{}{}:
-				c.logger.Info("Manual sync signal sent to application", zap.String("app", cmd.AppName))
+			case runtime.syncChan <- trigger:
+				c.logger.Info("Manual sync signal sent to application",
+					zap.String("app", cmd.AppName),
+					zap.String("actor", trigger.Actor),
+					zap.String("requestID", trigger.RequestID))
+				c.recordEvent(event.KindApplication, cmd.AppName, event.TypeNormal, "SyncRequested", "Manual sync requested by "+trigger.String())
 			default:
 				c.logger.Warn("Application sync channel is busy, skipping immediate sync", zap.String("app", cmd.AppName))
 			}
 		} else {
 			c.logger.Warn("Attempted to trigger sync for non-running application", zap.String("app", cmd.AppName))
 		}
+
+	case AppCommandResetFailures:
+		if runtime, ok := c.runningApps[cmd.AppName]; ok {
+			select {
+			case runtime.resetChan <- struct{}{}:
+				c.logger.Info("Failure reset signal sent to application", zap.String("app", cmd.AppName))
+			default:
+				c.logger.Debug("Application failure reset already pending", zap.String("app", cmd.AppName))
+			}
+			return
+		}
+
+		// The reconciliation loop is not running: reset the stored failures and start it again
+		c.apps.RLock()
+		defer c.apps.RUnlock()
+		appConfig, exists := c.apps.Get(cmd.AppName)
+		if !exists {
+			c.logger.Error("Attempted to reset failures of non-existent application", zap.String("app", cmd.AppName))
+			return
+		}
+		c.logger.Info("Resetting failures and restarting application", zap.String("app", cmd.AppName))
+		c.recordEvent(event.KindApplication, cmd.AppName, event.TypeNormal, "FailuresReset",
+			fmt.Sprintf("Cleared %d consecutive failure(s)", appConfig.ConsecutiveFailures))
+		resetApp := *appConfig
+		resetApp.ConsecutiveFailures = 0
+		resetApp.UnhealthyGitHash, resetApp.UnhealthyValuesDigest = "", ""
+		if resetApp.Status == app.StatusSuspended {
+			c.setAppStatus(&resetApp, app.StatusPending, "Reconciliation resumed, awaiting next sync")
+		}
+		c.launchApp(&resetApp, appConfigFile)
+
+	case AppCommandApprovePlan:
+		planID, _ := cmd.Data["plan"].(string)
+		if runtime, ok := c.runningApps[cmd.AppName]; ok {
+			select {
+			case runtime.approveChan <- planID:
+				c.logger.Info("Plan approval sent to application", zap.String("app", cmd.AppName), zap.String("plan", planID))
+			default:
+				c.logger.Warn("Application approval channel is busy, skipping approval", zap.String("app", cmd.AppName))
+			}
+			return
+		}
+
+		// The reconciliation loop is not running: approve the stored plan or change, which is applied once it starts
+		c.apps.Lock()
+		defer c.apps.Unlock()
+		appConfig, exists := c.apps.Get(cmd.AppName)
+		if !exists {
+			c.logger.Error("Attempted to approve the plan of non-existent application", zap.String("app", cmd.AppName))
+			return
+		}
+		if err := appConfig.ApprovePlan(planID, time.Now()); err != nil {
+			c.logger.Warn("Rejected plan approval", zap.String("app", cmd.AppName), zap.Error(err))
+			c.recordEvent(event.KindApplication, cmd.AppName, event.TypeWarning, "PlanApprovalRejected", err.Error())
+			return
+		}
+		reason, message := approvalEvent(appConfig, planID)
+		c.recordEvent(event.KindApplication, cmd.AppName, event.TypeNormal, reason, message)
+		if err := app.SaveApplications(c.apps, appConfigFile); err != nil {
+			c.logger.Error("Failed to save application status to file", zap.Error(err))
+		}
+
+	case AppCommandSuspend:
+		trigger, _ := cmd.Data["trigger"].(app.Trigger)
+		if runtime, ok := c.runningApps[cmd.AppName]; ok {
+			select {
+			case runtime.suspendChan <- trigger:
+				c.logger.Info("Suspension signal sent to application", zap.String("app", cmd.AppName))
+			default:
+				c.logger.Debug("Application suspension already pending", zap.String("app", cmd.AppName))
+			}
+			return
+		}
+
+		// The reconciliation loop is not running: suspend the stored application so it is not started
+		c.apps.Lock()
+		defer c.apps.Unlock()
+		appConfig, exists := c.apps.Get(cmd.AppName)
+		if !exists {
+			c.logger.Error("Attempted to suspend non-existent application", zap.String("app", cmd.AppName))
+			return
+		}
+		if appConfig.Status == app.StatusSuspended {
+			return
+		}
+		c.setAppStatus(appConfig, app.StatusSuspended, SuspendedMessage(cmd.AppName, trigger))
+		appConfig.NextSyncAt = time.Time{}
+		c.recordEvent(event.KindApplication, cmd.AppName, event.TypeWarning, "ReconcileSuspended", appConfig.Message)
+		if err := app.SaveApplications(c.apps, appConfigFile); err != nil {
+			c.logger.Error("Failed to save application status to file", zap.Error(err))
+		}
+
+	case AppCommandRollback:
+		trigger, _ := cmd.Data["trigger"].(app.Trigger)
+		if runtime, ok := c.runningApps[cmd.AppName]; ok {
+			select {
+			case runtime.rollbackChan <- trigger:
+				c.logger.Info("Rollback signal sent to application",
+					zap.String("app", cmd.AppName),
+					zap.String("actor", trigger.Actor),
+					zap.String("requestID", trigger.RequestID))
+			default:
+				c.logger.Debug("Application rollback already pending", zap.String("app", cmd.AppName))
+			}
+		} else {
+			c.logger.Warn("Attempted to roll back non-running application", zap.String("app", cmd.AppName))
+		}
+	}
+}
+
+// launchApp starts a reconciliation goroutine for a copy of the application.
+//
+// The caller must hold c.mu.
+func (c *Controller) launchApp(appConfig *app.Application, appConfigFile string) {
+	appCtx, appCancel := context.WithCancel(c.ctx) // New context for the app
+	runtime := &appRuntime{
+		cancel:       appCancel,
+		syncChan:     make(chan app.Trigger, 1), // New sync channel for the app
+		resetChan:    make(chan struct{}, 1),    // New failure reset channel for the app
+		approveChan:  make(chan string, 1),      // New plan approval channel for the app
+		suspendChan:  make(chan app.Trigger, 1), // New suspension channel for the app
+		rollbackChan: make(chan app.Trigger, 1), // New rollback channel for the app
+		done:         make(chan struct{}),
 	}
+
+	appCopy := *appConfig // Create a copy for the goroutine
+	c.wg.Add(1)
+	c.runningApps[appConfig.Name] = runtime
+	go c.reconcileApp(appCtx, &appCopy, appConfigFile, runtime)
 }
 
 // StopAllAppGoroutines iterates and stops all currently running application goroutines.
@@ -397,203 +941,850 @@ func (c *Controller) stopAllAppGoroutines() {
 // ReconcileApp runs the GitOps loop for a single application.
 //
 // It handles Git repository synchronization and Kubernetes manifest application.
-func (c *Controller) reconcileApp(appCtx context.Context, app *app.Application, appConfigFile string, appCancel context.CancelFunc, syncChan chan struct{}) {
+func (c *Controller) reconcileApp(appCtx context.Context, application *app.Application, appConfigFile string, runtime *appRuntime) {
 	defer c.wg.Done() // Decrement WaitGroup counter when the goroutine finishes
 	// Ensure the app's cancel func is removed from the map when this goroutine exits
 	defer func() {
 		c.mu.Lock()
 		// Only delete if this goroutine was the one registered in runningApps
-		if rt, ok := c.runningApps[app.Name]; ok && &rt.cancel == &appCancel {
-			delete(c.runningApps, app.Name)
-			c.logger.Debug("Removed app from runningApps map", zap.String("app", app.Name))
+		if rt, ok := c.runningApps[application.Name]; ok && rt == runtime {
+			delete(c.runningApps, application.Name)
+			c.logger.Debug("Removed app from runningApps map", zap.String("app", application.Name))
 		}
 		c.mu.Unlock()
-		appCancel() // Also call the app's cancel func to ensure its context is marked done
+		runtime.cancel() // Also call the app's cancel func to ensure its context is marked done
+		close(runtime.done)
 	}()
 
-	logger := c.logger.With(zap.String("app", app.Name))
+	logger := c.appLogger(application.Name)
 	logger.Info("Starting reconciliation loop for application",
-		zap.String("repo", app.RepoURL),
-		zap.String("branch", app.Branch),
-		zap.String("path", app.Path),
-		zap.Duration("interval", app.PollingInterval))
+		zap.String("repo", application.RepoURL),
+		zap.String("branch", application.Branch),
+		zap.String("path", application.Path),
+		zap.Duration("interval", application.PollingInterval))
+	every := application.Interval
+	if application.IsAdaptive() {
+		every += fmt.Sprintf(" to %s, depending on activity", application.MaxIntervalDuration())
+	}
+	c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "ReconcileStarted",
+		fmt.Sprintf("Watching %s@%s every %s", application.RepoURL, application.Branch, every))
 
-	// Get cluster configuration for this application
+	// Get cluster configuration for this application; Terraform applications are not deployed to a cluster
 	c.clusters.RLock()
-	defer c.clusters.RUnlock()
-	targetCluster, exists := c.clusters.Get(app.ClusterName)
-	if !exists {
-		logger.Error("Cluster configuration not found for application", zap.String("cluster", app.ClusterName))
-		app.Status = "Error"
-		app.Message = fmt.Sprintf("Cluster '%s' does not exist", app.ClusterName)
-		app.ConsecutiveFailures = 0               // Reset failures on critical error
-		c.saveAppStatus(app, appConfigFile, true) // Force save on critical error
+	targetCluster, exists := c.clusters.Get(application.ClusterName)
+	c.clusters.RUnlock()
+	if !exists && !application.IsTerraform() {
+		logger.Error("Cluster configuration not found for application", zap.String("cluster", application.ClusterName))
+		c.setAppStatus(application, app.StatusError, fmt.Sprintf("Cluster '%s' does not exist", application.ClusterName))
+		application.ConsecutiveFailures = 0 // Reset failures on critical error
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "ClusterNotFound", application.Message)
+		c.saveAppStatus(application, appConfigFile, true) // Force save on critical error
 		return
 	}
 
-	// Create a temporary directory for this app's Git repository
-	repoDir, err := git.CreateTempRepoDir()
+	// Use the app's cached clone if a repository cache is configured, or a temporary directory otherwise
+	var (
+		repoDir string
+		err     error
+	)
+	if c.repoCache != nil {
+		repoDir, err = c.repoCache.Acquire(application.Name, application.RepoURL, application.Branch)
+	} else {
+		repoDir, err = git.CreateTempRepoDir(application.Name)
+	}
 	if err != nil {
 		logger.Error("Failed to create temporary repo directory", zap.Error(err))
-		app.Status = "Error"
-		app.Message = fmt.Sprintf("Failed to create temp dir: %v", err)
-		c.saveAppStatus(app, appConfigFile, true) // Force save on critical error
+		c.setAppStatus(application, app.StatusError, fmt.Sprintf("Failed to create temp dir: %v", err))
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "SetupFailed", application.Message)
+		c.saveAppStatus(application, appConfigFile, true) // Force save on critical error
 		return
 	}
 	defer func() {
+		if c.repoCache != nil {
+			// Keep the cached clone for the next start
+			c.repoCache.Release(repoDir)
+			return
+		}
 		// Clean up the temporary directory after use
 		if cleanupErr := git.CleanUpRepo(logger, repoDir); cleanupErr != nil {
 			logger.Error("Failed to clean up repo directory", zap.String("dir", repoDir), zap.Error(cleanupErr))
 		}
 	}()
 
-	// Use kubeconfig path from the cluster configuration
-	k8sClient, err := k8s.NewClientSet(logger, targetCluster.KubeconfigPath)
-	if err != nil {
-		logger.Error("Failed to create Kubernetes client for application", zap.Error(err))
-		app.Status = "Error"
-		app.Message = fmt.Sprintf("Failed to create K8s client: %v", err)
-		c.saveAppStatus(app, appConfigFile, true) // Force save on critical error
-		return
-	}
+	var k8sClient *k8s.ClientSet
+	if !application.IsTerraform() {
+		// Use the client shared by all applications on the cluster, impersonating the application's identity
+		k8sClient, err = c.appClientFor(targetCluster, application)
+		if err != nil {
+			logger.Error("Failed to create Kubernetes client for application", zap.Error(err))
+			c.setAppStatus(application, app.StatusError, fmt.Sprintf("Failed to create K8s client: %v", err))
+			c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "SetupFailed", application.Message)
+			c.saveAppStatus(application, appConfigFile, true) // Force save on critical error
+			return
+		}
 
-	// Perform an initial connectivity check with the Kubernetes cluster with a timeout
-	// This ensures the controller can connect to the cluster before starting the reconciliation loop.
-	// If the connection fails, we log the error and update the application's status accordingly.
-	logger.Info("Checking connectivity to Kubernetes cluster", zap.String("kubeconfig", targetCluster.KubeconfigPath))
-	connectCtx, connectCancel := context.WithTimeout(appCtx, K8sConnectTimeout)
-	defer connectCancel()
-	if err := k8sClient.CheckConnectivity(connectCtx); err != nil {
-		logger.Error("Failed to connect to Kubernetes cluster", zap.Error(err))
-		app.Status = "Error"
-		app.Message = fmt.Sprintf("K8s connectivity error: %v", err)
-		c.saveAppStatus(app, appConfigFile, true) // Force save on critical error
-		return
+		// Perform an initial connectivity check with the Kubernetes cluster with a timeout
+		// This ensures the controller can connect to the cluster before starting the reconciliation loop.
+		// If the connection fails, we log the error and update the application's status accordingly.
+		logger.Info("Checking connectivity to Kubernetes cluster", zap.String("kubeconfig", targetCluster.ConfigSource()))
+		connectCtx, connectCancel := context.WithTimeout(appCtx, c.connectTimeout)
+		defer connectCancel()
+		if err := k8sClient.CheckConnectivity(connectCtx); err != nil {
+			logger.Error("Failed to connect to Kubernetes cluster", zap.Error(err))
+			reason := "ClusterUnreachable"
+			if k8s.IsCredentialError(err) {
+				reason = "CredentialsExpired"
+				c.setAppStatus(application, app.StatusError, fmt.Sprintf("K8s credentials expired or rejected: %v", err))
+			} else {
+				c.setAppStatus(application, app.StatusError, fmt.Sprintf("K8s connectivity error: %v", err))
+			}
+			c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, reason, application.Message)
+			c.saveAppStatus(application, appConfigFile, true) // Force save on critical error
+			return
+		}
 	}
 
 	// Initial sync attempt immediately
-	c.performSync(appCtx, logger, app, repoDir, k8sClient, appConfigFile)
+	c.syncApp(appCtx, logger, application, repoDir, k8sClient, appConfigFile)
+	if c.suspendIfFailing(logger, application, appConfigFile) {
+		return
+	}
 
 	// Set up a ticker for periodic polling of the Git repository
-	ticker := time.NewTicker(app.PollingInterval)
+	ticker := time.NewTicker(c.scheduleNextSync(logger, application, appConfigFile))
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			// Calculate effective polling interval with backoff
-			currentInterval := app.PollingInterval
-			if app.ConsecutiveFailures > 0 {
-				backoffFactor := time.Duration(1 << (app.ConsecutiveFailures - 1)) // Exponential backoff
-				backoffDuration := min(BaseBackoffDuration*backoffFactor, currentInterval*MaxConsecutiveFailures)
-				currentInterval = backoffDuration
-				logger.Warn("Applying backoff due to previous failures",
-					zap.Int("failures", app.ConsecutiveFailures),
-					zap.Duration("nextInterval", currentInterval))
+			c.syncApp(appCtx, logger, application, repoDir, k8sClient, appConfigFile)
+			if c.suspendIfFailing(logger, application, appConfigFile) {
+				return
 			}
-
 			// Reset ticker with potentially new interval
-			ticker.Reset(currentInterval)
+			ticker.Reset(c.scheduleNextSync(logger, application, appConfigFile))
+
+		case <-runtime.resetChan: // Operator cleared the failures
+			logger.Info("Consecutive failures reset for application.", zap.Int("previousFailures", application.ConsecutiveFailures))
+			c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "FailuresReset",
+				fmt.Sprintf("Cleared %d consecutive failure(s)", application.ConsecutiveFailures))
+			application.ConsecutiveFailures = 0
+			application.UnhealthyGitHash, application.UnhealthyValuesDigest = "", "" // Retry a rolled back revision
+			ticker.Reset(c.scheduleNextSync(logger, application, appConfigFile))
+			c.saveAppStatus(application, appConfigFile, true)
+
+		case planID := <-runtime.approveChan: // Operator approved the pending plan or change
+			if !c.approvePlan(logger, application, planID, appConfigFile) {
+				continue
+			}
+			c.syncApp(appCtx, logger, application, repoDir, k8sClient, appConfigFile)
+			if c.suspendIfFailing(logger, application, appConfigFile) {
+				return
+			}
+			ticker.Reset(c.scheduleNextSync(logger, application, appConfigFile))
 
-			c.performSync(appCtx, logger, app, repoDir, k8sClient, appConfigFile)
+		case trigger := <-runtime.suspendChan: // Operator suspended the application
+			c.suspendApp(withRequestID(logger, trigger), application, appConfigFile, SuspendedMessage(application.Name, trigger))
+			return
 
-		case <-syncChan: // Manual sync trigger
-			logger.Info("Manual sync triggered via API for application.", zap.String("app", app.Name))
-			c.performSync(appCtx, logger, app, repoDir, k8sClient, appConfigFile)
+		case trigger := <-runtime.rollbackChan: // Operator rolled back to the previous revision
+			c.rollBackApp(appCtx, withRequestID(logger, trigger), application, k8sClient, appConfigFile, trigger)
+			if c.suspendIfFailing(logger, application, appConfigFile) {
+				return
+			}
+			ticker.Reset(c.scheduleNextSync(logger, application, appConfigFile))
+
+		case trigger := <-runtime.syncChan: // Manual sync trigger
+			syncLogger := withRequestID(logger, trigger)
+			syncLogger.Info("Manual sync triggered for application.", zap.String("actor", trigger.Actor), zap.String("reason", trigger.Reason))
+			application.SyncTrigger = &trigger
+			c.syncApp(appCtx, syncLogger, application, repoDir, k8sClient, appConfigFile)
+			application.SyncTrigger = nil
+			if c.suspendIfFailing(logger, application, appConfigFile) {
+				return
+			}
+			ticker.Reset(c.scheduleNextSync(logger, application, appConfigFile))
 
 		case <-appCtx.Done():
 			logger.Info("Reconciliation loop stopping for application.", zap.String("reason", appCtx.Err().Error()))
+			c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "ReconcileStopped", "Reconciliation loop stopped")
 			// Only update status if it's not already stopped or explicitly error
-			if app.Status != "Stopped" && app.Status != "Error" {
-				app.Status = "Stopped"
-				app.Message = fmt.Sprintf("Controller shut down: %v", appCtx.Err())
+			if application.Status != app.StatusStopped && !application.Status.IsFailed() && application.Status != app.StatusSuspended &&
+				application.Status != app.StatusInterrupted {
+				c.setAppStatus(application, app.StatusStopped, fmt.Sprintf("Controller shut down: %v", appCtx.Err()))
 
-				c.saveAppStatus(app, appConfigFile, true) // Force save on shutdown
+				c.saveAppStatus(application, appConfigFile, true) // Force save on shutdown
 			}
 			return
 		}
 	}
 }
 
+// syncApp performs a sync of the application that survives a controller shutdown.
+//
+// The sync is cancelled when the application's loop is stopped or restarted, but on shutdown it
+// may run until the drain timeout passes. A sync cut off by the drain timeout does not count as a
+// failure; the application is marked "Interrupted" instead so the next start resumes it first.
+func (c *Controller) syncApp(appCtx context.Context, logger *zap.Logger, application *app.Application, repoDir string, k8sClient *k8s.ClientSet, appConfigFile string) {
+	if appCtx.Err() != nil {
+		return // Don't start a new sync once the loop is stopping
+	}
+
+	syncCtx, cancel := context.WithCancel(c.drainCtx)
+	defer cancel()
+	if trigger := application.SyncTrigger; trigger != nil {
+		// Correlate the Kubernetes API calls of the sync with the API request that triggered it
+		syncCtx = k8s.WithRequestID(syncCtx, trigger.RequestID)
+	}
+	stop := context.AfterFunc(appCtx, func() {
+		if c.ctx.Err() == nil {
+			cancel() // The application was stopped or restarted, not the controller
+		}
+	})
+	defer stop()
+
+	previousFailures := application.ConsecutiveFailures
+	c.performSync(syncCtx, logger, application, repoDir, k8sClient, appConfigFile)
+	if c.drainCtx.Err() == nil {
+		if !application.IsTerraform() && syncCtx.Err() == nil {
+			c.refreshRollouts(syncCtx, logger, application, k8sClient, appConfigFile)
+		}
+		return
+	}
+
+	logger.Warn("Sync interrupted by controller shutdown")
+	c.setAppStatus(application, app.StatusInterrupted, "Sync interrupted by controller shutdown; it will be resumed on restart")
+	application.ConsecutiveFailures = previousFailures
+	c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "SyncInterrupted", application.Message)
+	c.saveAppStatus(application, appConfigFile, true)
+}
+
+// suspendIfFailing suspends the application once it has failed MaxConsecutiveFailures times in a row.
+//
+// A suspended application is no longer reconciled, including after a controller restart, until an
+// operator resets its failures. It reports whether the application was suspended, in which case
+// the caller must stop its reconciliation loop.
+func (c *Controller) suspendIfFailing(logger *zap.Logger, application *app.Application, appConfigFile string) bool {
+	if application.ConsecutiveFailures < MaxConsecutiveFailures {
+		return false
+	}
+
+	logger.Error("Suspending reconciliation after repeated failures", zap.Int("failures", application.ConsecutiveFailures))
+	c.suspendApp(logger, application, appConfigFile, fmt.Sprintf("Suspended after %d consecutive failures; run 'gitopsctl app reset %s' to resume. Last error: %s",
+		application.ConsecutiveFailures, application.Name, application.Message))
+	return true
+}
+
+// withRequestID adds the ID of the API request that triggered an operation to the logger's fields,
+// if the operation was requested through the API.
+func withRequestID(logger *zap.Logger, trigger app.Trigger) *zap.Logger {
+	if trigger.RequestID == "" {
+		return logger
+	}
+	return logger.With(zap.String("requestID", trigger.RequestID))
+}
+
+// SuspendedMessage returns the status message of an application suspended at an operator's request.
+func SuspendedMessage(appName string, trigger app.Trigger) string {
+	return fmt.Sprintf("Suspended by %s; run 'gitopsctl app reset %s' to resume", trigger, appName)
+}
+
+// suspendApp moves the application to Suspended with the given message, records the suspension and saves it.
+// The caller must stop the application's reconciliation loop.
+func (c *Controller) suspendApp(logger *zap.Logger, application *app.Application, appConfigFile, message string) {
+	c.setAppStatus(application, app.StatusSuspended, message)
+	application.NextSyncAt = time.Time{}
+	logger.Warn("Reconciliation suspended", zap.String("reason", message))
+	c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "ReconcileSuspended", application.Message)
+	c.saveAppStatus(application, appConfigFile, true)
+}
+
+// EffectiveInterval returns the polling interval for an application, taking adaptive polling into
+// account, exponential backoff when the application has consecutive failures, and waiting for the
+// reset of a Git provider's rate limit.
+func EffectiveInterval(application *app.Application) time.Duration {
+	interval := application.PollInterval()
+	if application.ConsecutiveFailures > 0 {
+		backoffFactor := time.Duration(1 << (application.ConsecutiveFailures - 1)) // Exponential backoff
+		interval = min(BaseBackoffDuration*backoffFactor, application.PollingInterval*MaxConsecutiveFailures)
+	}
+	return max(interval, time.Until(application.RateLimitedUntil))
+}
+
+// scheduleNextSync records when the application was last synced and when the next sync will happen.
+//
+// It returns the effective interval until the next sync so the caller can reset its ticker.
+func (c *Controller) scheduleNextSync(logger *zap.Logger, application *app.Application, appConfigFile string) time.Duration {
+	interval := EffectiveInterval(application)
+	if application.ConsecutiveFailures > 0 {
+		logger.Warn("Applying backoff due to previous failures",
+			zap.Int("failures", application.ConsecutiveFailures),
+			zap.Duration("nextInterval", interval))
+	}
+
+	now := time.Now()
+	application.LastSyncAt = now
+	application.NextSyncAt = now.Add(interval)
+	application.EffectiveInterval = interval.String()
+	c.saveAppStatus(application, appConfigFile, true)
+	return interval
+}
+
 // PerformSync checks the Git repository for changes and applies Kubernetes manifests.
 //
 // It updates the application's status and handles errors appropriately.
-func (c *Controller) performSync(ctx context.Context, logger *zap.Logger, app *app.Application, repoDir string, k8sClient *k8s.ClientSet, appConfigFile string) {
-	previousStatus := app.Status
-	previousHash := app.LastSyncedGitHash
-	previousFailures := app.ConsecutiveFailures
+func (c *Controller) performSync(ctx context.Context, logger *zap.Logger, application *app.Application, repoDir string, k8sClient *k8s.ClientSet, appConfigFile string) {
+	application.LastSyncStartedAt = time.Now()
+	defer func() {
+		application.LastSyncFinishedAt = time.Now()
+		application.LastSyncDuration = application.LastSyncFinishedAt.Sub(application.LastSyncStartedAt)
+	}()
+
+	previousStatus := application.Status
+	previousHash := application.LastSyncedGitHash
+	previousFailures := application.ConsecutiveFailures
 
 	logger.Debug("Polling Git repository...")
-	currentHash, err := git.CloneOrPull(ctx, logger, app.RepoURL, app.Branch, repoDir)
+	currentHash, err := c.fetchRepository(ctx, logger, application, repoDir)
+	var rateLimited *git.RateLimitError
+	if errors.As(err, &rateLimited) {
+		c.holdForRateLimit(logger, application, rateLimited, appConfigFile)
+		return
+	}
+	c.clearRateLimit(logger, application, appConfigFile)
 	if err != nil {
 		logger.Error("Failed to pull Git repository", zap.Error(err))
-		app.Status = "Error"
-		app.Message = fmt.Sprintf("Git pull error: %v", err)
-		app.ConsecutiveFailures++
-		c.saveAppStatus(app, appConfigFile, previousStatus != app.Status || previousHash != app.LastSyncedGitHash)
+		c.setAppStatus(application, app.StatusError, fmt.Sprintf("Git pull error: %v", err))
+		application.ConsecutiveFailures++
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "GitPullFailed", application.Message)
+		c.recordSyncEvent(application, "")
+		c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash)
 		return
 	}
 
-	if currentHash == app.LastSyncedGitHash {
+	if currentHash != application.LastSyncedGitHash {
+		// Reported with the sync event, for the time from commit to deploy and the change failure rate
+		if application.SyncCommitTime, err = git.CommitTime(repoDir, currentHash); err != nil {
+			logger.Warn("Failed to get the commit time, not reporting the commit's lead time", zap.Error(err))
+		}
+		defer func() { application.SyncCommitTime = time.Time{} }()
+	}
+
+	if application.IsTerraform() {
+		c.performTerraformSync(ctx, logger, application, repoDir, currentHash, appConfigFile)
+		return
+	}
+
+	sources := &sourceCheckouts{}
+	if len(application.Sources) > 0 {
+		sources, err = c.fetchSources(ctx, logger, application, c.repoCache)
+		if errors.As(err, &rateLimited) {
+			c.holdForRateLimit(logger, application, rateLimited, appConfigFile)
+			return
+		}
+		if err != nil {
+			logger.Error("Failed to pull source repository", zap.Error(err))
+			c.setAppStatus(application, app.StatusError, fmt.Sprintf("Git pull error: %v", err))
+			application.ConsecutiveFailures++
+			c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "GitPullFailed", application.Message)
+			c.recordSyncEvent(application, currentHash)
+			c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash)
+			return
+		}
+		defer sources.release()
+	}
+
+	renderRequest := newRenderRequest(application, repoDir, sources, currentHash)
+	valuesDigest, err := render.ValuesDigest(renderRequest)
+	if err != nil {
+		logger.Error("Failed to resolve Helm values", zap.Error(err))
+		c.setAppStatus(application, app.StatusError, fmt.Sprintf("Failed to resolve Helm values: %v", err))
+		application.ConsecutiveFailures++
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "RenderFailed", application.Message)
+		c.recordSyncEvent(application, currentHash)
+		c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash)
+		return
+	}
+	valuesDigest = sourcesDigest(application, valuesDigest, sources.revisions)
+	variables := c.substitutionVariables(application)
+	valuesDigest = variablesDigest(valuesDigest, variables)
+
+	if application.UnhealthyGitHash != "" && currentHash == application.UnhealthyGitHash && valuesDigest == application.UnhealthyValuesDigest {
+		logger.Debug("Skipping revision whose resources did not become healthy", zap.String("hash", currentHash))
+		return
+	}
+
+	if currentHash == application.LastSyncedGitHash && valuesDigest == application.LastSyncedValuesDigest {
 		logger.Debug("No new changes detected in Git repository", zap.String("hash", currentHash))
 		// Only change status to Synced if it was previously an error, otherwise keep it as is
-		if app.Status == "Error" || app.Status == "Pending" || app.Status == "SyncRequested" {
-			app.Status = "Synced"
-			app.Message = fmt.Sprintf("Up to date at %s", currentHash)
-			app.ConsecutiveFailures = 0 // Reset failures on successful "check"
-			c.saveAppStatus(app, appConfigFile, previousStatus != app.Status || previousHash != app.LastSyncedGitHash)
+		if change := application.PendingChange; change != nil {
+			// The branch went back to the synced commit, so the held back change no longer applies
+			c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "ChangeOutdated",
+				fmt.Sprintf("Change %s detected at %s was dropped, %s is already synced", change.ID, change.GitHash, currentHash))
+			application.PendingChange = nil
+		}
+		if application.Status == app.StatusError || application.Status == app.StatusPending || application.Status == app.StatusSyncRequested || application.Status == app.StatusInterrupted ||
+			application.Status == app.StatusAwaitingApproval {
+			c.setAppStatus(application, app.StatusSynced, fmt.Sprintf("Up to date at %s", currentHash))
+			application.ConsecutiveFailures = 0 // Reset failures on successful "check"
+			c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "Synced", application.Message)
+			c.recordSyncEvent(application, currentHash)
+			c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash)
 		} else {
 			// No actual change, just update timestamp/message if desired, but don't force save
 			// unless explicitly status changed.
-			app.Message = fmt.Sprintf("Up to date at %s", currentHash)
+			application.Message = fmt.Sprintf("Up to date at %s", currentHash)
 		}
 		return
 	}
 
-	logger.Info("New changes detected in Git repository",
-		zap.String("oldHash", app.LastSyncedGitHash),
-		zap.String("newHash", currentHash))
+	if currentHash == application.LastSyncedGitHash {
+		logger.Info("Helm values changed, syncing at the same Git hash", zap.String("hash", currentHash))
+	} else {
+		logger.Info("New changes detected in Git repository",
+			zap.String("oldHash", application.LastSyncedGitHash),
+			zap.String("newHash", currentHash))
+	}
 
-	manifestsDir := filepath.Join(repoDir, app.Path)
-	if _, err := os.Stat(manifestsDir); os.IsNotExist(err) {
-		logger.Error("Manifests path does not exist in repository", zap.String("path", app.Path))
-		app.Status = "Error"
-		app.Message = fmt.Sprintf("Manifests path '%s' not found in repo after cloning. Check 'path' in config or repo structure.", app.Path)
-		app.ConsecutiveFailures++
-		c.saveAppStatus(app, appConfigFile, previousStatus != app.Status || previousHash != app.LastSyncedGitHash)
+	applyDir, cleanup, reason, err := c.prepareManifests(ctx, logger, application, repoDir, sources, renderRequest, variables)
+	if err != nil {
+		c.setAppStatus(application, app.StatusError, err.Error())
+		application.ConsecutiveFailures++
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, reason, application.Message)
+		c.recordSyncEvent(application, currentHash)
+		c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash)
+		return
+	}
+	defer cleanup()
+
+	if application.Lint != nil && c.lintManifests(ctx, logger, application, applyDir, currentHash) {
+		c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash)
+		return
+	}
+
+	// Wait for the cluster to accept another apply before the apply timeout starts
+	releaseApplySlot, err := c.acquireApplySlot(ctx, logger, application.ClusterName)
+	if err != nil {
+		logger.Info("Sync stopped while waiting to apply to the cluster", zap.Error(err))
 		return
 	}
+	defer releaseApplySlot()
 
-	logger.Info("Applying Kubernetes manifests...", zap.String("sourceDir", manifestsDir))
-	k8sApplyCtx, k8sApplyCancel := context.WithTimeout(ctx, K8sApplyTimeout)
+	k8sApplyCtx, k8sApplyCancel := context.WithTimeout(ctx, c.applyTimeout)
 	defer k8sApplyCancel() // Ensure the context is cancelled after applying manifests
-	applyErrors := k8sClient.ApplyManifests(k8sApplyCtx, manifestsDir)
+
+	if c.checkAPIVersions(k8sApplyCtx, logger, application, k8sClient, applyDir, currentHash) {
+		c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash)
+		return
+	}
+
+	logger.Info("Validating Kubernetes manifests against cluster schemas...", zap.String("sourceDir", applyDir))
+	if validationErrors := k8sClient.ValidateManifests(k8sApplyCtx, applyDir, manifestOptions(application)); len(validationErrors) > 0 {
+		errorMessages := make([]string, len(validationErrors))
+		for i, e := range validationErrors {
+			errorMessages[i] = e.Error()
+		}
+		errMsg := fmt.Sprintf("%d manifest(s) failed validation, nothing was applied: %s", len(validationErrors), strings.Join(errorMessages, "; "))
+		logger.Error("Kubernetes manifests failed validation", zap.String("details", errMsg))
+		c.setAppStatus(application, app.StatusInvalidManifests, errMsg)
+		application.ConsecutiveFailures++
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "InvalidManifests", application.Message)
+		c.recordSyncEvent(application, currentHash)
+		c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash)
+		return
+	}
+
+	tracking := c.syncTracking(application, currentHash)
+	if application.ApprovalRequired && c.holdForApproval(k8sApplyCtx, logger, application, k8sClient, applyDir, currentHash, valuesDigest, tracking) {
+		c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash || previousFailures != application.ConsecutiveFailures)
+		return
+	}
+
+	var (
+		applyResults []k8s.ResourceResult
+		applyErrors  []error
+	)
+	resumed := resumableResources(application, currentHash, valuesDigest)
+	if len(resumed) > 0 {
+		logger.Info("Resuming partially applied sync, applying only the resources that were not applied",
+			zap.String("sourceDir", applyDir), zap.Int("alreadyApplied", len(resumed)))
+		applyResults, applyErrors = k8sClient.ResumeManifests(k8sApplyCtx, applyDir, application.ApplyStrategy, manifestOptions(application), tracking, resourceRefs(resumed))
+	} else {
+		logger.Info("Applying Kubernetes manifests...", zap.String("sourceDir", applyDir))
+		applyResults, applyErrors = k8sClient.ApplyManifests(k8sApplyCtx, applyDir, application.ApplyStrategy, manifestOptions(application), tracking)
+	}
+	application.LastSyncResources = append(resumed, toResourceSyncResults(applyResults)...)
+	appliedResources := append(resourceRefs(resumed), k8s.AppliedResources(applyResults)...)
+	application.PartialGitHash, application.PartialValuesDigest = "", ""
 	if len(applyErrors) > 0 {
+		if len(appliedResources) > 0 {
+			application.PartialGitHash, application.PartialValuesDigest = currentHash, valuesDigest
+		}
 		errorMessages := make([]string, len(applyErrors))
 		for i, e := range applyErrors {
 			errorMessages[i] = e.Error()
 		}
 		errMsg := fmt.Sprintf("Failed to apply %d manifest(s): %s", len(applyErrors), strings.Join(errorMessages, "; "))
 		logger.Error("Failed to apply Kubernetes manifests", zap.String("details", errMsg))
-		app.Status = "Error"
-		app.Message = errMsg
-		app.ConsecutiveFailures++
-		c.saveAppStatus(app, appConfigFile, previousStatus != app.Status || previousHash != app.LastSyncedGitHash)
+		c.setAppStatus(application, app.StatusError, errMsg)
+		application.ConsecutiveFailures++
+		application.PendingChange = nil // A partially applied change must be diffed and approved again
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "ApplyFailed", application.Message)
+		c.recordHelmRelease(k8sApplyCtx, logger, application, k8sClient, renderRequest, applyDir, k8s.HelmReleaseStatusFailed, errMsg)
+		c.recordSyncEvent(application, currentHash)
+		c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash)
 		return
 	}
 
-	app.LastSyncedGitHash = currentHash
-	app.Status = "Synced"
-	app.Message = fmt.Sprintf("Successfully synced to %s", currentHash)
-	app.ConsecutiveFailures = 0 // Reset failures on successful sync
+	if application.IsTwoPhase() {
+		failed := c.awaitHealthOrRollback(ctx, logger, application, k8sClient, appliedResources, applyDir, currentHash, valuesDigest)
+		// Waiting for health may outlast the apply timeout, so the rest of the sync gets a fresh one
+		k8sApplyCtx, k8sApplyCancel = context.WithTimeout(ctx, c.applyTimeout)
+		defer k8sApplyCancel()
+		if failed {
+			c.recordHelmRelease(k8sApplyCtx, logger, application, k8sClient, renderRequest, applyDir, k8s.HelmReleaseStatusFailed, application.Message)
+			c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash || previousFailures != application.ConsecutiveFailures)
+			return
+		}
+	}
+
+	application.LastSyncedGitHash = currentHash
+	application.LastSyncedValuesDigest = valuesDigest
+	application.SourceRevisions = sources.revisions
+	application.UnhealthyGitHash, application.UnhealthyValuesDigest = "", ""
+	c.setAppStatus(application, app.StatusSynced, fmt.Sprintf("Successfully synced to %s", currentHash))
+	application.ConsecutiveFailures = 0 // Reset failures on successful sync
+	application.ManagedResources = toManagedResources(appliedResources)
+	application.PendingChange = nil
+	c.recordSyncEvent(application, currentHash)
+	c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "Synced",
+		fmt.Sprintf("Applied %d resource(s) at %s", len(appliedResources), currentHash))
 	logger.Info("Successfully applied Kubernetes manifests", zap.String("hash", currentHash))
+	c.recordHelmRelease(k8sApplyCtx, logger, application, k8sClient, renderRequest, applyDir, k8s.HelmReleaseStatusDeployed,
+		fmt.Sprintf("Applied by gitopsctl at %s", currentHash))
+
+	c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash || previousFailures != application.ConsecutiveFailures)
+}
+
+// prepareManifests prepares the manifests of an application checked out in repoDir to be applied:
+// its manifest paths and sources are combined, or its manifests rendered, and the variables are
+// substituted into them. It returns the directory holding the manifests and a function removing the
+// temporary directories it created. On failure, it returns the event reason the failure is recorded
+// with and an error whose message is the application's status message.
+func (c *Controller) prepareManifests(ctx context.Context, logger *zap.Logger, application *app.Application, repoDir string,
+	sources *sourceCheckouts, renderRequest render.Request, variables map[string]string) (string, func(), string, error) {
+	var tempDirs []string
+	cleanup := func() {
+		for _, dir := range tempDirs {
+			os.RemoveAll(dir)
+		}
+	}
+
+	manifestsDir := filepath.Join(repoDir, application.Path)
+	if _, err := os.Stat(manifestsDir); os.IsNotExist(err) {
+		logger.Error("Manifests path does not exist in repository", zap.String("path", application.Path))
+		return "", nil, "ManifestPathNotFound",
+			fmt.Errorf("Manifests path '%s' not found in repo after cloning. Check 'path' in config or repo structure.", application.Path)
+	}
+
+	applyDir := manifestsDir
+	if len(application.Paths) > 0 || application.HasSourceManifests() {
+		combinedDir, err := os.MkdirTemp("", "gitopsctl-sources-")
+		if err == nil {
+			tempDirs = append(tempDirs, combinedDir)
+			err = render.CombineSources(repoDir, application.SourcePaths(), combinedDir)
+			applyDir = combinedDir
+		}
+		if err == nil {
+			err = combineSourceManifests(application, sources, combinedDir)
+		}
+		if err != nil {
+			logger.Error("Failed to combine manifest paths", zap.Strings("paths", application.SourcePaths()), zap.Error(err))
+			cleanup()
+			return "", nil, "ManifestPathNotFound", fmt.Errorf("Failed to combine manifest paths: %v", err)
+		}
+	} else if application.Renderer != "" && application.Renderer != render.RendererYAML {
+		renderDir, err := os.MkdirTemp("", "gitopsctl-render-")
+		if err == nil {
+			tempDirs = append(tempDirs, renderDir)
+			logger.Info("Rendering manifests...", zap.String("renderer", application.Renderer))
+			applyDir, err = render.Render(ctx, logger, c.plugins, renderRequest, renderDir)
+		}
+		if err != nil {
+			logger.Error("Failed to render manifests", zap.String("renderer", application.Renderer), zap.Error(err))
+			cleanup()
+			return "", nil, "RenderFailed", fmt.Errorf("Failed to render manifests with %s: %v", application.Renderer, err)
+		}
+	}
+
+	if variables != nil {
+		substitutedDir, err := os.MkdirTemp("", "gitopsctl-substitute-")
+		if err == nil {
+			tempDirs = append(tempDirs, substitutedDir)
+			err = render.SubstituteVariables(applyDir, substitutedDir, variables)
+			applyDir = substitutedDir
+		}
+		if err != nil {
+			logger.Error("Failed to substitute variables into manifests", zap.Error(err))
+			cleanup()
+			return "", nil, "SubstitutionFailed", fmt.Errorf("Failed to substitute variables: %v", err)
+		}
+	}
+	return applyDir, cleanup, "", nil
+}
+
+// newRenderRequest returns the request the manifests of the application checked out in repoDir,
+// with its additional sources, are rendered with at revision.
+func newRenderRequest(application *app.Application, repoDir string, sources *sourceCheckouts, revision string) render.Request {
+	return render.Request{
+		Renderer:  application.Renderer,
+		SourceDir: filepath.Join(repoDir, application.Path),
+		RepoDir:   repoDir,
+		Sources:   sources.dirs,
+		Helm:      helmOptions(application),
+		Input: render.PluginInput{
+			App:      application.Name,
+			RepoURL:  application.RepoURL,
+			Branch:   application.Branch,
+			Path:     application.Path,
+			Revision: revision,
+			Params:   application.PluginParams,
+		},
+	}
+}
+
+// helmOptions returns the options the helm renderer renders the application's chart with.
+func helmOptions(application *app.Application) render.HelmOptions {
+	opts := render.HelmOptions{ReleaseName: application.Name}
+	if application.Helm != nil {
+		opts.ValuesFiles = application.Helm.ValuesFiles
+		opts.Values = application.Helm.Values
+		opts.Namespace = application.Helm.Namespace
+	}
+	return opts
+}
+
+// manifestOptions returns the options the application's manifests are read and applied with.
+func manifestOptions(application *app.Application) k8s.ManifestOptions {
+	return k8s.ManifestOptions{
+		Exclude:          application.Exclude,
+		DefaultNamespace: application.DefaultNamespace,
+		RequireNamespace: application.RequireNamespace,
+	}
+}
+
+// syncTracking returns the tracking information the resources of the application are stamped with
+// when they are applied at revision.
+func (c *Controller) syncTracking(application *app.Application, revision string) k8s.Tracking {
+	tracking := k8s.Tracking{App: application.Name, Revision: revision, Controller: c.instanceID, HashConfig: application.ConfigHash}
+	if recordsHelmRelease(application) {
+		tracking.HelmRelease = application.Name
+		tracking.HelmNamespace = common.DefaultIfEmpty(application.Helm.Namespace, render.DefaultHelmNamespace)
+	}
+	return tracking
+}
+
+// recordsHelmRelease reports whether the application's syncs are recorded as Helm release revisions.
+func recordsHelmRelease(application *app.Application) bool {
+	return application.Renderer == render.RendererHelm && application.Helm != nil && application.Helm.RecordRelease
+}
+
+// recordHelmRelease records a sync of the application as a revision of its Helm release, if it is
+// enabled. A failure to record it is reported as a warning event but does not fail the sync.
+func (c *Controller) recordHelmRelease(ctx context.Context, logger *zap.Logger, application *app.Application, k8sClient *k8s.ClientSet,
+	renderRequest render.Request, applyDir, status, description string) {
+	if !recordsHelmRelease(application) {
+		return
+	}
+	release := k8s.HelmRelease{
+		Name:        application.Name,
+		Namespace:   common.DefaultIfEmpty(application.Helm.Namespace, render.DefaultHelmNamespace),
+		Status:      status,
+		Description: description,
+	}
 
-	c.saveAppStatus(app, appConfigFile, previousStatus != app.Status || previousHash != app.LastSyncedGitHash || previousFailures != app.ConsecutiveFailures)
+	err := func() error {
+		var err error
+		if release.Chart, err = render.ChartMetadata(renderRequest.SourceDir); err != nil {
+			return err
+		}
+		if release.Values, err = render.HelmValues(renderRequest); err != nil {
+			return err
+		}
+		manifest, err := os.ReadFile(filepath.Join(applyDir, render.RenderedManifestFile))
+		if err != nil {
+			return fmt.Errorf("failed to read rendered manifests: %w", err)
+		}
+		release.Manifest = string(manifest)
+		_, err = k8sClient.RecordHelmRelease(ctx, release)
+		return err
+	}()
+	if err != nil {
+		logger.Warn("Failed to record Helm release", zap.String("namespace", release.Namespace), zap.Error(err))
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "HelmReleaseRecordFailed",
+			fmt.Sprintf("Failed to record Helm release %s/%s: %v", release.Namespace, release.Name, err))
+	}
+}
+
+// recordSyncEvent appends the application's current status to its sync history, with who requested
+// the sync if an operator did, and to the sync log statistics are computed from.
+func (c *Controller) recordSyncEvent(application *app.Application, gitHash string) {
+	syncEvent := app.SyncEvent{
+		Time:    time.Now(),
+		Status:  application.Status,
+		GitHash: gitHash,
+		Message: application.Message,
+	}
+	if trigger := application.SyncTrigger; trigger != nil {
+		syncEvent.Actor, syncEvent.Reason, syncEvent.RequestID = trigger.Actor, trigger.Reason, trigger.RequestID
+	}
+	application.RecordSyncEvent(syncEvent)
+
+	record := stats.Sync{
+		Time:       syncEvent.Time,
+		App:        application.Name,
+		Status:     syncEvent.Status,
+		GitHash:    gitHash,
+		CommitTime: application.SyncCommitTime,
+		Message:    syncEvent.Message,
+		Actor:      syncEvent.Actor,
+		Reason:     syncEvent.Reason,
+		RequestID:  syncEvent.RequestID,
+	}
+	if err := stats.Record(stats.DefaultSyncLogFile, record); err != nil {
+		c.logger.Error("Failed to record sync in the sync log", zap.String("app", application.Name), zap.Error(err))
+	}
+}
+
+// recordEvent records a controller event for the given object and persists the event log.
+func (c *Controller) recordEvent(kind, name string, eventType event.Type, reason, message string) {
+	c.events.Lock()
+	defer c.events.Unlock()
+
+	c.events.Record(kind, name, eventType, reason, message, time.Now())
+	if err := event.SaveEvents(c.events, event.DefaultEventFile); err != nil {
+		c.logger.Error("Failed to save events to file", zap.Error(err))
+	}
+}
+
+// toManagedResources converts applied resource references into the application's managed resource list.
+func toManagedResources(refs []k8s.ResourceRef) []app.ManagedResource {
+	resources := make([]app.ManagedResource, 0, len(refs))
+	for _, ref := range refs {
+		resources = append(resources, app.ManagedResource{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name})
+	}
+	return resources
+}
+
+// toResourceSyncResults converts the outcome of applying each resource into the application's
+// per-resource sync results.
+func toResourceSyncResults(results []k8s.ResourceResult) []app.ResourceSyncResult {
+	syncResults := make([]app.ResourceSyncResult, 0, len(results))
+	for _, r := range results {
+		result := app.ResourceSyncResult{
+			ManagedResource: app.ManagedResource{Kind: r.Kind, Namespace: r.Namespace, Name: r.Name},
+			Action:          r.Action,
+		}
+		if r.Err != nil {
+			result.Error = r.Err.Error()
+		}
+		syncResults = append(syncResults, result)
+	}
+	return syncResults
+}
+
+// fetchRepository clones or pulls the application's repository into repoDir, recording how long it
+// took and, when the clone changed, its size. A pull is skipped when listing the remote's references
+// shows the branch still at the commit the clone is at. With adaptive polling, the polling interval
+// is adapted to whether the fetch found new commits. It returns the HEAD commit hash.
+func (c *Controller) fetchRepository(ctx context.Context, logger *zap.Logger, application *app.Application, repoDir string) (string, error) {
+	if err := c.checkRateLimit(application.RepoURL); err != nil {
+		return "", err
+	}
+
+	operation := git.NextOperation(repoDir)
+	start := time.Now()
+	var hash string
+	var unchanged bool
+	var err error
+	if operation == git.OperationPull {
+		unchanged, err = c.remoteUnchanged(ctx, logger, application, repoDir)
+	}
+	switch {
+	case err != nil:
+	case unchanged:
+		operation, hash = git.OperationCheck, application.LastSyncedGitHash
+	default:
+		hash, err = git.CloneOrPull(ctx, logger, application.RepoURL, application.Branch, repoDir,
+			git.FetchOptions{Submodules: application.Submodules, LFS: application.LFS})
+	}
+	finished := time.Now()
+
+	var rateLimited *git.RateLimitError
+	if errors.As(err, &rateLimited) {
+		// Not a failure of the repository, so it is not counted as one
+		c.recordRateLimit(rateLimited)
+		return "", err
+	}
+	changed := application.RecordFetch(string(operation), hash, finished.Sub(start), finished, err != nil)
+	if err != nil {
+		return "", err
+	}
+	logger.Debug("Fetched Git repository", zap.String("operation", string(operation)), zap.Duration("duration", finished.Sub(start)))
+
+	if application.IsAdaptive() {
+		previous := application.PollInterval()
+		application.AdaptInterval(changed)
+		if interval := application.PollInterval(); interval != previous {
+			logger.Debug("Adapted polling interval to repository activity",
+				zap.Bool("newCommits", changed), zap.Duration("interval", interval))
+		}
+	}
+
+	// A pull without new commits leaves the clone as it was, so it is only measured after changes
+	if stats := application.Repository; operation == git.OperationClone || stats.MeasuredRevision != hash {
+		repoStats, err := git.Stats(repoDir)
+		if err != nil {
+			logger.Warn("Failed to measure repository", zap.Error(err))
+		} else {
+			application.RecordRepositorySize(hash, repoStats.SizeBytes, repoStats.Objects)
+		}
+	}
+	return hash, nil
+}
+
+// remoteUnchanged reports whether the remote branch still points to the last synced commit and the
+// clone in repoDir is at that commit, so pulling would fetch nothing. If the remote's references
+// cannot be listed, it reports false and the repository is pulled as usual, unless the provider
+// rejected the listing for exceeding a rate limit, which is returned as a *git.RateLimitError.
+func (c *Controller) remoteUnchanged(ctx context.Context, logger *zap.Logger, application *app.Application, repoDir string) (bool, error) {
+	// A clone the submodules or LFS objects were not fetched into yet, because the option was just
+	// enabled, would stay incomplete, so those applications are always pulled
+	if application.LastSyncedGitHash == "" || application.Submodules || application.LFS {
+		return false, nil
+	}
+	remoteHash, err := git.RemoteHash(ctx, application.RepoURL, application.Branch)
+	var rateLimited *git.RateLimitError
+	if errors.As(err, &rateLimited) {
+		return false, err
+	}
+	if err != nil {
+		logger.Debug("Failed to list remote references, pulling instead", zap.Error(err))
+		return false, nil
+	}
+	if remoteHash != application.LastSyncedGitHash {
+		return false, nil
+	}
+	// The clone may be shared with other applications, which could have left it at another commit
+	localHash, err := git.GetLatestCommitHash(logger, repoDir)
+	return err == nil && localHash == remoteHash, nil
 }
 
 // saveAppStatus is a helper to update and persist the application's status.
 //
-// It locks the applications list to ensure thread-safe updates.
+// It locks the applications list to ensure thread-safe updates. The repository statistics are
+// always updated in memory, but only persisted with other changes so an idle application is not
+// saved on every poll.
 func (c *Controller) saveAppStatus(appToSave *app.Application, appConfigFile string, forceSave bool) {
 	c.apps.Lock()
 	defer c.apps.Unlock()
@@ -604,28 +1795,59 @@ func (c *Controller) saveAppStatus(appToSave *app.Application, appConfigFile str
 		c.logger.Error("Attempted to save status for unknown application", zap.String("app", appToSave.Name))
 		return
 	}
+	originalApp.Repository = appToSave.Repository
 
 	// Check if actual status or hash changed, or if forced to save
 	if forceSave ||
 		originalApp.Status != appToSave.Status ||
 		originalApp.LastSyncedGitHash != appToSave.LastSyncedGitHash ||
-		originalApp.ConsecutiveFailures != appToSave.ConsecutiveFailures { // NEW: also save if failures change
+		originalApp.LastSyncedValuesDigest != appToSave.LastSyncedValuesDigest ||
+		originalApp.UnhealthyGitHash != appToSave.UnhealthyGitHash ||
+		originalApp.RollbackGitHash != appToSave.RollbackGitHash ||
+		originalApp.PartialGitHash != appToSave.PartialGitHash ||
+		originalApp.ConsecutiveFailures != appToSave.ConsecutiveFailures || // NEW: also save if failures change
+		originalApp.TerraformPlan != appToSave.TerraformPlan ||
+		originalApp.PendingChange != appToSave.PendingChange ||
+		!originalApp.RateLimitedUntil.Equal(appToSave.RateLimitedUntil) {
 
 		// Update the shared map with the current state of the goroutine's app copy
 		originalApp.Status = appToSave.Status
 		originalApp.Message = appToSave.Message
 		originalApp.LastSyncedGitHash = appToSave.LastSyncedGitHash
+		originalApp.LastSyncedValuesDigest = appToSave.LastSyncedValuesDigest
+		originalApp.SourceRevisions = appToSave.SourceRevisions
+		originalApp.UnhealthyGitHash = appToSave.UnhealthyGitHash
+		originalApp.UnhealthyValuesDigest = appToSave.UnhealthyValuesDigest
+		originalApp.RollbackGitHash = appToSave.RollbackGitHash
+		originalApp.PartialGitHash = appToSave.PartialGitHash
+		originalApp.PartialValuesDigest = appToSave.PartialValuesDigest
 		originalApp.ConsecutiveFailures = appToSave.ConsecutiveFailures // NEW: update failures
+		originalApp.SyncHistory = appToSave.SyncHistory
+		originalApp.LastSyncAt = appToSave.LastSyncAt
+		originalApp.LastSyncStartedAt = appToSave.LastSyncStartedAt
+		originalApp.LastSyncFinishedAt = appToSave.LastSyncFinishedAt
+		originalApp.LastSyncDuration = appToSave.LastSyncDuration
+		originalApp.NextSyncAt = appToSave.NextSyncAt
+		originalApp.EffectiveInterval = appToSave.EffectiveInterval
+		originalApp.AdaptiveInterval = appToSave.AdaptiveInterval
+		originalApp.RateLimitedUntil = appToSave.RateLimitedUntil
+		originalApp.ManagedResources = appToSave.ManagedResources
+		originalApp.LastSyncResources = appToSave.LastSyncResources
+		originalApp.LintFindings = appToSave.LintFindings
+		originalApp.DeprecatedAPIs = appToSave.DeprecatedAPIs
+		originalApp.Rollouts = appToSave.Rollouts
+		originalApp.TerraformPlan = appToSave.TerraformPlan
+		originalApp.PendingChange = appToSave.PendingChange
 
 		if err := app.SaveApplications(c.apps, appConfigFile); err != nil {
 			c.logger.Error("Failed to save application status to file", zap.Error(err))
 		} else {
-			c.logger.Debug("Application status saved to file", zap.String("app", appToSave.Name), zap.String("status", appToSave.Status))
+			c.logger.Debug("Application status saved to file", zap.String("app", appToSave.Name), zap.String("status", string(appToSave.Status)))
 		}
 	} else {
 		c.logger.Debug("No significant change to application status or failures, skipping save",
 			zap.String("app", appToSave.Name),
-			zap.String("current_status", appToSave.Status),
+			zap.String("current_status", string(appToSave.Status)),
 			zap.String("current_hash", appToSave.LastSyncedGitHash),
 			zap.Int("current_failures", appToSave.ConsecutiveFailures))
 	}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SetAppDebug turns debug logging on or off for a single application, whatever the level of the
+// controller's logger, so that one misbehaving application can be traced without flooding the logs
+// with the debug messages of all of them.
+//
+// It takes effect immediately, including for a sync in progress, and lasts until it is turned off
+// or the controller exits.
+func (c *Controller) SetAppDebug(appName string, enabled bool) {
+	c.debugAppsMu.Lock()
+	if enabled {
+		c.debugApps[appName] = true
+	} else {
+		delete(c.debugApps, appName)
+	}
+	c.debugAppsMu.Unlock()
+	c.logger.Info("Application debug logging changed", zap.String("app", appName), zap.Bool("enabled", enabled))
+}
+
+// isAppDebug reports whether debug logging is turned on for the application.
+func (c *Controller) isAppDebug(appName string) bool {
+	c.debugAppsMu.Lock()
+	defer c.debugAppsMu.Unlock()
+	return c.debugApps[appName]
+}
+
+// renameAppDebug keeps debug logging turned on for a renamed application.
+func (c *Controller) renameAppDebug(oldName, newName string) {
+	c.debugAppsMu.Lock()
+	defer c.debugAppsMu.Unlock()
+	if c.debugApps[oldName] {
+		delete(c.debugApps, oldName)
+		c.debugApps[newName] = true
+	}
+}
+
+// appLogger returns the logger of an application's reconciliation loop, which logs debug messages
+// while debug logging is turned on for the application.
+func (c *Controller) appLogger(appName string) *zap.Logger {
+	return c.logger.With(zap.String("app", appName)).WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &debugCore{Core: core, enabled: func() bool { return c.isAppDebug(appName) }}
+	}))
+}
+
+// debugCore is a zapcore.Core that writes entries of every level to the core it wraps while
+// enabled reports true, and otherwise leaves it to that core to decide.
+type debugCore struct {
+	zapcore.Core
+	enabled func() bool
+}
+
+// Enabled implements zapcore.Core.
+func (d *debugCore) Enabled(level zapcore.Level) bool {
+	return d.enabled() || d.Core.Enabled(level)
+}
+
+// With implements zapcore.Core.
+func (d *debugCore) With(fields []zapcore.Field) zapcore.Core {
+	return &debugCore{Core: d.Core.With(fields), enabled: d.enabled}
+}
+
+// Check implements zapcore.Core. While enabled, the entry bypasses the level and sampling of the
+// wrapped core, which then only encodes and writes it.
+func (d *debugCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if d.enabled() {
+		return checked.AddCore(entry, d)
+	}
+	return d.Core.Check(entry, checked)
+}
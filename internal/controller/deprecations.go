@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"go.uber.org/zap"
+)
+
+// checkAPIVersions looks for manifests in applyDir using API versions deprecated in, or removed
+// from, the Kubernetes version of the application's cluster, and records them on the application.
+// It reports whether the sync must stop, because the cluster no longer serves some of those API
+// versions or the application blocks deprecated ones; the application's status says why in that case.
+func (c *Controller) checkAPIVersions(ctx context.Context, logger *zap.Logger, application *app.Application, k8sClient *k8s.ClientSet, applyDir, currentHash string) bool {
+	serverVersion, err := k8sClient.ServerVersion(ctx)
+	if err != nil {
+		// The cluster's discovery information still shows which API versions it no longer serves
+		logger.Warn("Failed to get the cluster's Kubernetes version, checking API versions against discovery only", zap.Error(err))
+	}
+	// Manifests that cannot be read are reported by validation
+	deprecated, _ := k8sClient.CheckAPIVersions(applyDir, manifestOptions(application), serverVersion)
+	if len(deprecated) == 0 {
+		application.DeprecatedAPIs = nil
+		return false
+	}
+
+	application.DeprecatedAPIs = make([]app.DeprecatedAPI, 0, len(deprecated))
+	var removed, descriptions []string
+	for _, d := range deprecated {
+		file := d.File
+		if rel, err := filepath.Rel(applyDir, d.File); err == nil {
+			file = filepath.ToSlash(rel)
+		}
+		application.DeprecatedAPIs = append(application.DeprecatedAPIs, app.DeprecatedAPI{
+			Resource:     d.Resource.String(),
+			File:         file,
+			APIVersion:   d.APIVersion,
+			DeprecatedIn: d.DeprecatedIn,
+			RemovedIn:    d.RemovedIn,
+			Replacement:  d.Replacement,
+			Removed:      d.Removed,
+		})
+		if d.Removed {
+			removed = append(removed, d.String())
+		}
+		descriptions = append(descriptions, d.String())
+	}
+
+	var errMsg string
+	switch {
+	case len(removed) > 0:
+		errMsg = fmt.Sprintf("%d manifest(s) use API versions cluster '%s' (%s) no longer serves, nothing was applied: %s",
+			len(removed), application.ClusterName, common.DefaultIfEmpty(serverVersion, "unknown version"), strings.Join(removed, "; "))
+	case application.APIDeprecationPolicy == app.APIDeprecationBlock:
+		errMsg = fmt.Sprintf("%d manifest(s) use deprecated API versions, nothing was applied: %s", len(deprecated), strings.Join(descriptions, "; "))
+	default:
+		logger.Warn("Manifests use deprecated API versions", zap.Int("resources", len(deprecated)))
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "DeprecatedAPIs",
+			fmt.Sprintf("%d manifest(s) at %s use deprecated API versions: %s", len(deprecated), currentHash, strings.Join(descriptions, "; ")))
+		return false
+	}
+
+	logger.Error("Manifests use API versions that cannot be applied", zap.String("details", errMsg))
+	c.setAppStatus(application, app.StatusInvalidManifests, errMsg)
+	application.ConsecutiveFailures++
+	c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "DeprecatedAPIs", application.Message)
+	c.recordSyncEvent(application, currentHash)
+	return true
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/git"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"go.uber.org/zap"
+)
+
+// DryRunResource is what syncing an application would do to one resource, according to a
+// server-side dry run.
+type DryRunResource struct {
+	// Kind, Namespace and Name identify the resource.
+	Kind      string
+	Namespace string
+	Name      string
+	// Action is what the sync would do to the resource: created, updated, unchanged or failed.
+	Action string
+	// Diff is a line diff of the resource's YAML, empty if it would not change or failed.
+	Diff string
+	// Error is why the resource would fail to apply, set only when Action is failed.
+	Error string
+	// Validated is false for custom resources whose CRD is not applied yet, which the server
+	// cannot dry-run; their Action and Diff are only predicted from the manifest.
+	Validated bool
+}
+
+// DryRunReport is the outcome of a server-side dry run of syncing an application.
+type DryRunReport struct {
+	// Revision is the commit hash of the latest revision the manifests were rendered at.
+	Revision string
+	// Resources are the results of the resources in manifest order.
+	Resources []DryRunResource
+	// Errors are the manifests that could not be read, which have no result among Resources.
+	Errors []string
+}
+
+// DryRunSync renders the manifests of the latest revision of an application and submits them to
+// its cluster as server-side dry runs, reporting what a sync would do to each resource without
+// changing anything in the cluster or the application's state.
+//
+// The repository and the additional sources are cloned into temporary directories, so the dry run
+// does not wait for, or interfere with, the application's reconciliation loop. Terraform
+// applications are not supported.
+func (c *Controller) DryRunSync(appName string, trigger app.Trigger) (*DryRunReport, error) {
+	c.apps.RLock()
+	current, ok := c.apps.Get(appName)
+	var application app.Application
+	if ok {
+		application = *current // A copy, the reconciliation loop updates the application concurrently
+	}
+	c.apps.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("application '%s' not found", appName)
+	}
+	if application.IsTerraform() {
+		return nil, fmt.Errorf("dry runs are not supported for Terraform applications")
+	}
+	c.clusters.RLock()
+	targetCluster, exists := c.clusters.Get(application.ClusterName)
+	c.clusters.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("cluster '%s' does not exist", application.ClusterName)
+	}
+
+	logger := withRequestID(c.appLogger(appName), trigger)
+	logger.Info("Dry run of sync requested for application", zap.String("actor", trigger.Actor))
+	ctx := k8s.WithRequestID(c.ctx, trigger.RequestID)
+
+	k8sClient, err := c.appClientFor(targetCluster, &application)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	connectCtx, connectCancel := context.WithTimeout(ctx, c.connectTimeout)
+	defer connectCancel()
+	if err := k8sClient.CheckConnectivity(connectCtx); err != nil {
+		return nil, fmt.Errorf("K8s connectivity error: %w", err)
+	}
+	if err := c.checkRateLimit(application.RepoURL); err != nil {
+		return nil, err
+	}
+	repoDir, err := git.CreateTempRepoDir(appName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer func() {
+		if cleanupErr := git.CleanUpRepo(logger, repoDir); cleanupErr != nil {
+			logger.Error("Failed to clean up repo directory", zap.String("dir", repoDir), zap.Error(cleanupErr))
+		}
+	}()
+
+	gitCtx, gitCancel := context.WithTimeout(ctx, GitOperationTimeout)
+	defer gitCancel()
+	currentHash, err := git.CloneOrPull(gitCtx, logger, application.RepoURL, application.Branch, repoDir,
+		git.FetchOptions{Submodules: application.Submodules, LFS: application.LFS})
+	if err != nil {
+		return nil, fmt.Errorf("git clone error: %w", err)
+	}
+	sources := &sourceCheckouts{}
+	if len(application.Sources) > 0 {
+		if sources, err = c.fetchSources(gitCtx, logger, &application, nil); err != nil {
+			return nil, fmt.Errorf("git clone error: %w", err)
+		}
+		defer sources.release()
+	}
+
+	renderRequest := newRenderRequest(&application, repoDir, sources, currentHash)
+	applyDir, cleanup, _, err := c.prepareManifests(ctx, logger, &application, repoDir, sources, renderRequest, c.substitutionVariables(&application))
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	k8sCtx, k8sCancel := context.WithTimeout(ctx, c.applyTimeout)
+	defer k8sCancel()
+	results, readErrors := k8sClient.DryRunManifests(k8sCtx, applyDir, manifestOptions(&application), c.syncTracking(&application, currentHash))
+
+	report := &DryRunReport{Revision: currentHash, Resources: make([]DryRunResource, 0, len(results))}
+	for _, r := range results {
+		resource := DryRunResource{
+			Kind:      r.Kind,
+			Namespace: r.Namespace,
+			Name:      r.Name,
+			Action:    r.Action,
+			Diff:      r.Diff,
+			Validated: r.Validated,
+		}
+		if r.Err != nil {
+			resource.Error = r.Err.Error()
+		}
+		report.Resources = append(report.Resources, resource)
+	}
+	for _, e := range readErrors {
+		report.Errors = append(report.Errors, e.Error())
+	}
+	logger.Info("Dry run of sync finished", zap.String("hash", currentHash),
+		zap.Int("resources", len(report.Resources)), zap.Int("errors", len(report.Errors)))
+	return report, nil
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/lint"
+	"go.uber.org/zap"
+)
+
+// maxLintErrorsInMessage is how many lint errors are listed in the status message of a sync they blocked.
+const maxLintErrorsInMessage = 5
+
+// lintManifests checks the manifests in applyDir with the application's linters and records their
+// findings on the application. It reports whether the sync must stop, because a linter could not be
+// run or, in strict mode, reported errors; the application's status says why in that case.
+func (c *Controller) lintManifests(ctx context.Context, logger *zap.Logger, application *app.Application, applyDir, currentHash string) bool {
+	lintCtx, cancel := context.WithTimeout(ctx, lint.Timeout)
+	defer cancel()
+
+	logger.Info("Linting manifests...", zap.Strings("linters", application.Lint.Linters))
+	findings, err := lint.Run(lintCtx, application.Lint.Linters, applyDir, application.Exclude)
+	if err != nil {
+		logger.Error("Failed to lint manifests", zap.Error(err))
+		c.setAppStatus(application, app.StatusError, fmt.Sprintf("Failed to lint manifests: %v", err))
+		application.ConsecutiveFailures++
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "LintFailed", application.Message)
+		c.recordSyncEvent(application, currentHash)
+		return true
+	}
+	if len(findings) > app.MaxLintFindings {
+		findings = findings[:app.MaxLintFindings]
+	}
+	application.LintFindings = findings
+
+	errors, warnings := lint.Count(findings)
+	if errors == 0 && warnings == 0 {
+		return false
+	}
+	summary := fmt.Sprintf("Linters reported %d error(s) and %d warning(s) at %s", errors, warnings, currentHash)
+	if errors == 0 || !application.Lint.Strict {
+		logger.Warn("Manifests have lint findings", zap.Int("errors", errors), zap.Int("warnings", warnings))
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "LintFindings", summary)
+		return false
+	}
+
+	var listed []string
+	for _, f := range findings {
+		if f.Severity == lint.SeverityError && len(listed) < maxLintErrorsInMessage {
+			listed = append(listed, formatLintFinding(f))
+		}
+	}
+	errMsg := fmt.Sprintf("%d manifest lint error(s), nothing was applied: %s", errors, strings.Join(listed, "; "))
+	if errors > len(listed) {
+		errMsg += fmt.Sprintf("; and %d more", errors-len(listed))
+	}
+	logger.Error("Manifests failed strict linting", zap.String("details", errMsg))
+	c.setAppStatus(application, app.StatusInvalidManifests, errMsg)
+	application.ConsecutiveFailures++
+	c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "LintFailed", summary)
+	c.recordSyncEvent(application, currentHash)
+	return true
+}
+
+// formatLintFinding returns a lint finding as "linter: resource (file): message".
+func formatLintFinding(f app.LintFinding) string {
+	location := f.Resource
+	if f.File != "" {
+		location = strings.TrimSpace(location + " (" + f.File + ")")
+	}
+	if location == "" {
+		return f.Linter + ": " + f.Message
+	}
+	return f.Linter + ": " + location + ": " + f.Message
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"go.uber.org/zap"
+)
+
+// placeApp chooses the cluster of an application with a placement when it has none yet, its
+// cluster no longer exists or the cluster is unhealthy, and saves the choice.
+//
+// If no other cluster can take the application, it is left on its cluster and a warning event is
+// recorded; the next failed health check of the cluster tries again.
+func (c *Controller) placeApp(appName, appConfigFile string) {
+	c.apps.Lock()
+	defer c.apps.Unlock()
+
+	application, ok := c.apps.Get(appName)
+	if !ok || application.Placement == nil || application.Status == app.StatusSuspended {
+		return
+	}
+	c.clusters.RLock()
+	current, exists := c.clusters.Get(application.ClusterName)
+	if exists && current.Status.Placeable() {
+		c.clusters.RUnlock()
+		return
+	}
+	target, err := cluster.Place(c.clusters.List(), application.Placement.ClusterSelector, c.placedApps())
+	c.clusters.RUnlock()
+
+	logger := c.appLogger(appName)
+	if err != nil {
+		logger.Warn("Failed to place application", zap.String("cluster", application.ClusterName), zap.Error(err))
+		c.recordEvent(event.KindApplication, appName, event.TypeWarning, "PlacementFailed", err.Error())
+		return
+	}
+
+	previous := application.ClusterName
+	application.ClusterName = target.Name
+	if err := app.SaveApplications(c.apps, appConfigFile); err != nil {
+		logger.Error("Failed to save application placement", zap.Error(err))
+	}
+	message := fmt.Sprintf("Placed on cluster '%s'", target.Name)
+	if free, ok := target.Health.FreeCapacity(); ok {
+		message += fmt.Sprintf(" (%.0f%% capacity free)", free*100)
+	}
+	if previous != "" {
+		message += fmt.Sprintf(", moved from '%s'", previous)
+	}
+	logger.Info("Placed application", zap.String("cluster", target.Name), zap.String("previous", previous))
+	c.recordEvent(event.KindApplication, appName, event.TypeNormal, "Placed", message)
+}
+
+// placedApps counts the applications deployed to each cluster. The caller must hold the lock of
+// the applications.
+func (c *Controller) placedApps() map[string]int {
+	placed := make(map[string]int)
+	for _, a := range c.apps.List() {
+		if a.ClusterName != "" {
+			placed[a.ClusterName]++
+		}
+	}
+	return placed
+}
+
+// rebalanceCluster restarts the applications with a placement deployed to an unhealthy cluster,
+// which places them on another cluster as they start.
+func (c *Controller) rebalanceCluster(clusterName string) {
+	var moving []string
+	c.apps.RLock()
+	for _, a := range c.apps.List() {
+		if a.Placement != nil && a.ClusterName == clusterName && a.Status != app.StatusSuspended {
+			moving = append(moving, a.Name)
+		}
+	}
+	c.apps.RUnlock()
+
+	for _, name := range moving {
+		c.logger.Info("Rebalancing application away from unhealthy cluster", zap.String("app", name), zap.String("cluster", clusterName))
+		c.StartApp(name)
+	}
+}
+
+// placementInUse reports whether any application chooses its cluster with a placement, so that
+// health checks record the capacity of the clusters.
+func (c *Controller) placementInUse() bool {
+	c.apps.RLock()
+	defer c.apps.RUnlock()
+	for _, a := range c.apps.List() {
+		if a.Placement != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// recordCapacity records the allocatable and requested CPU and memory of a cluster in its health.
+// Failures, for example because the credentials may not list pods, leave the capacity unknown.
+func recordCapacity(ctx context.Context, logger *zap.Logger, client *k8s.ClientSet, health *cluster.ClusterHealth) {
+	capacity, err := client.Capacity(ctx)
+	if err != nil {
+		logger.Debug("Failed to record cluster capacity", zap.Error(err))
+		return
+	}
+	health.CPUAllocatable = capacity.Total.CPUAllocatable
+	health.CPURequested = capacity.Total.CPURequested
+	health.MemoryAllocatable = capacity.Total.MemoryAllocatable
+	health.MemoryRequested = capacity.Total.MemoryRequested
+}
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultPIDFile is the default path of the file holding the running controller's process ID.
+	DefaultPIDFile = "configs/controller.pid"
+	// DefaultLogFile is the default path of the log file of a controller started with --daemon.
+	DefaultLogFile = "configs/controller.log"
+	// DefaultStopTimeout is how long stopping waits for the controller to exit. It leaves time for
+	// the controller to drain in-flight syncs for up to DefaultDrainTimeout.
+	DefaultStopTimeout = DefaultDrainTimeout + 15*time.Second
+)
+
+// ErrNotRunning is returned when no controller process is running for a PID file.
+var ErrNotRunning = errors.New("controller is not running")
+
+// pidFileLockAttempts is how many times AcquirePIDFile tries to lock the PID file, as RunningPID
+// holds the lock for a moment when checking whether a controller is running.
+const pidFileLockAttempts = 5
+
+// AlreadyRunningError is returned by AcquirePIDFile when another controller holds the PID file.
+type AlreadyRunningError struct {
+	PID  int    // Process ID of the running controller, or zero if it has not recorded it yet
+	Path string // Path of the PID file
+}
+
+func (e *AlreadyRunningError) Error() string {
+	return fmt.Sprintf("a controller is already running (PID %d, recorded in %s)", e.PID, e.Path)
+}
+
+// PIDFile is the PID file of the running controller. The controller holds an exclusive lock on the
+// file for as long as it runs, so a controller is running exactly when its PID file is locked: a
+// file left behind by a controller that crashed, or recording a process ID since reused by another
+// process, is not mistaken for a running controller.
+type PIDFile struct {
+	path string
+	file *os.File
+}
+
+// AcquirePIDFile locks the PID file and records the current process in it as the running
+// controller. It returns an *AlreadyRunningError if another controller holds the file.
+func AcquirePIDFile(path string) (*PIDFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for PID file %s: %w", path, err)
+	}
+	for attempt := 1; ; attempt++ {
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open PID file %s: %w", path, err)
+		}
+		locked, err := lockPIDFile(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to lock PID file %s: %w", path, err)
+		}
+		if !locked {
+			file.Close()
+			if attempt < pidFileLockAttempts {
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			pid, _ := readPIDFile(path)
+			return nil, &AlreadyRunningError{PID: pid, Path: path}
+		}
+
+		// The controller holding the file may have removed it between it being opened and locked,
+		// in which case the lock is on a file no other process will find
+		opened, err := file.Stat()
+		current, statErr := os.Stat(path)
+		if err != nil || statErr != nil || !os.SameFile(opened, current) {
+			file.Close()
+			continue
+		}
+
+		if err := file.Truncate(0); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to write PID file %s: %w", path, err)
+		}
+		if _, err := file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to write PID file %s: %w", path, err)
+		}
+		return &PIDFile{path: path, file: file}, nil
+	}
+}
+
+// Release removes the PID file and releases its lock. The file is removed while still locked, so a
+// file written by a newer controller is left alone.
+func (p *PIDFile) Release() {
+	os.Remove(p.path)
+	p.file.Close()
+}
+
+// RunningPID returns the process ID recorded in the PID file if a controller holds the file. It
+// returns ErrNotRunning if the file does not exist or is not held by a running controller.
+func RunningPID(path string) (int, error) {
+	held, err := pidFileHeld(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check PID file %s: %w", path, err)
+	}
+	if !held {
+		return 0, ErrNotRunning
+	}
+	return readPIDFile(path)
+}
+
+// StopProcess asks the controller holding the PID file, with process ID pid, to shut down
+// gracefully with SIGTERM and waits up to timeout for it to exit. If it is still running after the
+// timeout and kill is set, it is killed.
+//
+// The process is only signalled while it still holds the PID file, so an unrelated process that
+// was given the same process ID is never signalled.
+func StopProcess(path string, pid int, timeout time.Duration, kill bool) error {
+	if !holdsPIDFile(path, pid) {
+		return nil
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return fmt.Errorf("failed to find process %d: %w", pid, err)
+	}
+	if err := proc.Signal(syscall.SIGTERM); err != nil {
+		if !holdsPIDFile(path, pid) {
+			return nil
+		}
+		return fmt.Errorf("failed to signal process %d: %w", pid, err)
+	}
+	if waitForExit(path, pid, timeout) {
+		return nil
+	}
+	if !kill {
+		return fmt.Errorf("process %d did not stop within %s", pid, timeout)
+	}
+	if !holdsPIDFile(path, pid) {
+		return nil
+	}
+	if err := proc.Kill(); err != nil && holdsPIDFile(path, pid) {
+		return fmt.Errorf("failed to kill process %d: %w", pid, err)
+	}
+	if !waitForExit(path, pid, 5*time.Second) {
+		return fmt.Errorf("process %d is still running after being killed", pid)
+	}
+	return nil
+}
+
+// readPIDFile reads the process ID recorded in a PID file.
+func readPIDFile(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, ErrNotRunning
+		}
+		return 0, fmt.Errorf("failed to read PID file %s: %w", path, err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("PID file %s does not contain a valid process ID", path)
+	}
+	return pid, nil
+}
+
+// holdsPIDFile reports whether the process with the given ID is the controller holding the PID file.
+func holdsPIDFile(path string, pid int) bool {
+	running, err := RunningPID(path)
+	return err == nil && running == pid
+}
+
+// waitForExit polls until the process no longer holds the PID file, reporting whether it released
+// it within timeout.
+func waitForExit(path string, pid int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for holdsPIDFile(path, pid) {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return true
+}
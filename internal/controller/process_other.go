@@ -0,0 +1,46 @@
+//go:build !unix
+
+package controller
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// DetachedProcAttr returns the attributes that start a process detached from the terminal.
+// Sessions are a Unix concept, so no attributes are needed on other platforms.
+func DetachedProcAttr() *syscall.SysProcAttr {
+	return nil
+}
+
+// lockPIDFile reports whether the open PID file is free for the current process. Advisory file
+// locks are not available on this platform, so the file is held by the process it records while
+// that process exists.
+func lockPIDFile(file *os.File) (bool, error) {
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return false, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	return err != nil || pid == os.Getpid() || !processAlive(pid), nil
+}
+
+// pidFileHeld reports whether the process recorded in the PID file exists.
+func pidFileHeld(path string) (bool, error) {
+	pid, err := readPIDFile(path)
+	if err != nil {
+		return false, nil
+	}
+	return processAlive(pid), nil
+}
+
+// processAlive reports whether a process with the given ID exists.
+func processAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
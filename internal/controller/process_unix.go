@@ -0,0 +1,46 @@
+//go:build unix
+
+package controller
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// DetachedProcAttr returns the attributes that start a process in its own session, so it keeps
+// running after the terminal that started it is closed.
+func DetachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
+
+// lockPIDFile takes an exclusive lock on the open PID file without waiting, reporting false if
+// another process holds it. The lock is released when the file is closed or the process exits.
+func lockPIDFile(file *os.File) (bool, error) {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// pidFileHeld reports whether a controller holds the lock on the PID file.
+func pidFileHeld(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_SH|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return false, syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
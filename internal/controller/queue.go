@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"sync"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// CommandQueueQPS is the sustained rate at which queued commands are dispatched.
+	CommandQueueQPS = 10
+	// CommandQueueBurst is the number of commands that can be dispatched at once before rate limiting applies.
+	CommandQueueBurst = 100
+)
+
+// pendingAppCommands holds the commands queued for a single application, merged so that
+// redundant requests are executed only once.
+type pendingAppCommands struct {
+	// lifecycle is the most recently requested AppCommandStart, AppCommandStop or AppCommandSuspend, if any.
+	lifecycle AppCommandType
+	// resetFailures reports whether a failure reset was requested.
+	resetFailures bool
+	// suspendTrigger is who requested the suspension when lifecycle is AppCommandSuspend.
+	suspendTrigger app.Trigger
+	// sync reports whether an immediate sync was requested.
+	sync bool
+	// syncTrigger is who most recently requested the sync.
+	syncTrigger app.Trigger
+	// approvePlan is the ID of the Terraform plan most recently approved, if any.
+	approvePlan string
+	// rollback reports whether a rollback to the previous revision was requested.
+	rollback bool
+	// rollbackTrigger is who most recently requested the rollback.
+	rollbackTrigger app.Trigger
+}
+
+// appCommandQueue is a rate-limited work queue of application commands.
+//
+// Adding a command never blocks. Commands for an application that has not been processed yet
+// are merged: the latest start, stop or suspension wins, a stop or suspension discards earlier sync, reset, approval and
+// rollback requests, the latest plan approval wins, repeated rollback requests collapse into one, and repeated sync requests (or a sync requested together with a
+// start, which syncs anyway) collapse into one. An application is never processed by more than one worker at a time.
+type appCommandQueue struct {
+	queue workqueue.TypedRateLimitingInterface[string]
+
+	mu      sync.Mutex
+	pending map[string]*pendingAppCommands
+}
+
+// newAppCommandQueue creates an empty application command queue.
+func newAppCommandQueue() *appCommandQueue {
+	return &appCommandQueue{
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			&workqueue.TypedBucketRateLimiter[string]{Limiter: rate.NewLimiter(rate.Limit(CommandQueueQPS), CommandQueueBurst)},
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "applications"},
+		),
+		pending: make(map[string]*pendingAppCommands),
+	}
+}
+
+// Add queues a command. It reports false if the command was merged into one already queued.
+func (q *appCommandQueue) Add(cmd AppCommand) bool {
+	q.mu.Lock()
+	p, ok := q.pending[cmd.AppName]
+	if !ok {
+		p = &pendingAppCommands{}
+		q.pending[cmd.AppName] = p
+	}
+
+	added := true
+	switch cmd.Type {
+	case AppCommandStart:
+		added = p.lifecycle != AppCommandStart
+		p.lifecycle = AppCommandStart
+		p.sync = false // Starting an application syncs it immediately
+	case AppCommandStop, AppCommandSuspend:
+		added = p.lifecycle != cmd.Type
+		p.lifecycle = cmd.Type
+		p.suspendTrigger, _ = cmd.Data["trigger"].(app.Trigger)
+		p.sync = false
+		p.resetFailures = false
+		p.approvePlan = ""
+		p.rollback = false
+	case AppCommandSync:
+		added = !p.sync && p.lifecycle != AppCommandStart
+		p.sync = p.lifecycle != AppCommandStart
+		p.syncTrigger, _ = cmd.Data["trigger"].(app.Trigger)
+	case AppCommandResetFailures:
+		added = !p.resetFailures
+		p.resetFailures = true
+	case AppCommandApprovePlan:
+		added = p.approvePlan == ""
+		p.approvePlan, _ = cmd.Data["plan"].(string)
+	case AppCommandRollback:
+		added = !p.rollback
+		p.rollback = true
+		p.rollbackTrigger, _ = cmd.Data["trigger"].(app.Trigger)
+	}
+	q.mu.Unlock()
+
+	q.queue.AddRateLimited(cmd.AppName)
+	return added
+}
+
+// Get blocks until an application has queued commands and returns them in execution order.
+// The caller must call Done with the application name once the commands have been handled.
+// It reports shutdown as true once the queue has been shut down and drained.
+func (q *appCommandQueue) Get() (string, []AppCommand, bool) {
+	appName, shutdown := q.queue.Get()
+	if shutdown {
+		return "", nil, true
+	}
+
+	q.mu.Lock()
+	p := q.pending[appName]
+	delete(q.pending, appName)
+	q.mu.Unlock()
+
+	var cmds []AppCommand
+	if p != nil {
+		switch p.lifecycle {
+		case "":
+		case AppCommandSuspend:
+			cmds = append(cmds, AppCommand{Type: p.lifecycle, AppName: appName, Data: map[string]any{"trigger": p.suspendTrigger}})
+		default:
+			cmds = append(cmds, AppCommand{Type: p.lifecycle, AppName: appName})
+		}
+		if p.resetFailures {
+			cmds = append(cmds, AppCommand{Type: AppCommandResetFailures, AppName: appName})
+		}
+		if p.approvePlan != "" {
+			cmds = append(cmds, AppCommand{Type: AppCommandApprovePlan, AppName: appName, Data: map[string]any{"plan": p.approvePlan}})
+		}
+		if p.rollback {
+			cmds = append(cmds, AppCommand{Type: AppCommandRollback, AppName: appName, Data: map[string]any{"trigger": p.rollbackTrigger}})
+		}
+		if p.sync {
+			cmds = append(cmds, AppCommand{Type: AppCommandSync, AppName: appName, Data: map[string]any{"trigger": p.syncTrigger}})
+		}
+	}
+	return appName, cmds, false
+}
+
+// Done marks the application's commands as handled.
+func (q *appCommandQueue) Done(appName string) {
+	q.queue.Forget(appName)
+	q.queue.Done(appName)
+}
+
+// Len returns the number of applications with queued commands.
+func (q *appCommandQueue) Len() int {
+	return q.queue.Len()
+}
+
+// ShutDown stops the queue; queued commands are still returned by Get before it reports shutdown.
+func (q *appCommandQueue) ShutDown() {
+	q.queue.ShutDown()
+}
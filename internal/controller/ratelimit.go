@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/git"
+	"go.uber.org/zap"
+)
+
+// errFetchHeldBack is the cause of the rate limit error returned for fetches that were not attempted
+// because another fetch from the same provider was rate limited.
+var errFetchHeldBack = errors.New("fetch held back until the rate limit resets")
+
+// checkRateLimit returns a *git.RateLimitError if a fetch from the repository's provider was rate
+// limited and the limit has not reset yet, so the repository should not be fetched.
+func (c *Controller) checkRateLimit(repoURL string) error {
+	host := git.RepoHost(repoURL)
+	c.rateLimitsMu.Lock()
+	defer c.rateLimitsMu.Unlock()
+	resetAt, ok := c.rateLimits[host]
+	if !ok {
+		return nil
+	}
+	if !time.Now().Before(resetAt) {
+		delete(c.rateLimits, host)
+		return nil
+	}
+	return &git.RateLimitError{Host: host, Provider: git.ProviderName(host), ResetAt: resetAt, Err: errFetchHeldBack}
+}
+
+// recordRateLimit holds back fetches of every repository on the provider's host until its rate limit resets.
+func (c *Controller) recordRateLimit(rateLimited *git.RateLimitError) {
+	c.rateLimitsMu.Lock()
+	defer c.rateLimitsMu.Unlock()
+	if rateLimited.ResetAt.After(c.rateLimits[rateLimited.Host]) {
+		c.rateLimits[rateLimited.Host] = rateLimited.ResetAt
+	}
+}
+
+// holdForRateLimit postpones the application's next sync until its Git provider's rate limit resets.
+//
+// Exceeding a rate limit says nothing about the application, so its status and failure count are
+// left alone; the RateLimited condition is set instead and an event is recorded when it starts.
+func (c *Controller) holdForRateLimit(logger *zap.Logger, application *app.Application, rateLimited *git.RateLimitError, appConfigFile string) {
+	message := fmt.Sprintf("%s rate limit exceeded; fetching from %s resumes at %s",
+		rateLimited.Provider, rateLimited.Host, rateLimited.ResetAt.Format(time.RFC3339))
+	logger.Warn("Git provider rate limit exceeded, waiting for it to reset",
+		zap.String("host", rateLimited.Host), zap.Time("resetAt", rateLimited.ResetAt))
+
+	if application.RateLimitedUntil.IsZero() {
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "RateLimited", message)
+	}
+	application.RateLimitedUntil = rateLimited.ResetAt
+	c.setAppCondition(application.Name, appConfigFile, common.Condition{
+		Type:               common.ConditionRateLimited,
+		Status:             "True",
+		Reason:             "RateLimitExceeded",
+		Message:            message,
+		LastTransitionTime: time.Now(),
+	})
+	c.saveAppStatus(application, appConfigFile, false)
+}
+
+// clearRateLimit clears the RateLimited condition of an application whose repository could be
+// fetched again. The application itself is saved when its next sync is scheduled.
+func (c *Controller) clearRateLimit(logger *zap.Logger, application *app.Application, appConfigFile string) {
+	if application.RateLimitedUntil.IsZero() {
+		return
+	}
+	logger.Info("Git provider rate limit reset, fetching again")
+	application.RateLimitedUntil = time.Time{}
+	c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "RateLimitReset", "Fetching the repository again")
+	c.setAppCondition(application.Name, appConfigFile, common.Condition{
+		Type:               common.ConditionRateLimited,
+		Status:             "False",
+		Reason:             "RateLimitReset",
+		LastTransitionTime: time.Now(),
+	})
+}
+
+// setAppCondition sets a condition on the application in the shared list, which owns the
+// conditions, and saves the applications if it changed.
+func (c *Controller) setAppCondition(appName, appConfigFile string, cond common.Condition) {
+	c.apps.Lock()
+	defer c.apps.Unlock()
+	a, ok := c.apps.Get(appName)
+	if !ok || !common.SetCondition(&a.Conditions, cond) {
+		return
+	}
+	if err := app.SaveApplications(c.apps, appConfigFile); err != nil {
+		c.logger.Error("Failed to save application conditions to file", zap.Error(err))
+	}
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"go.uber.org/zap"
+)
+
+// RegisterApp registers an application, or registers an existing one again with a new spec,
+// persists the change and starts or restarts its reconciliation loop.
+//
+// The controller is the only writer of the applications file while it runs, so a standalone API
+// server registers applications through it rather than saving its own copy of the state.
+func (c *Controller) RegisterApp(spec *app.Application) error {
+	c.apps.Lock()
+	existing, exists := c.apps.Get(spec.Name)
+	var previous app.Application
+	if exists {
+		previous = *existing
+		existing.Reregister(spec)
+	} else {
+		c.apps.Add(spec)
+	}
+	if err := app.SaveApplications(c.apps, c.appConfigPath()); err != nil {
+		if exists {
+			*existing = previous
+		} else {
+			c.apps.Delete(spec.Name)
+		}
+		c.apps.Unlock()
+		return fmt.Errorf("failed to save applications: %w", err)
+	}
+	c.apps.Unlock()
+
+	c.logger.Info("Application registered", zap.String("app", spec.Name), zap.Bool("updated", exists))
+	c.StartApp(spec.Name)
+	return nil
+}
+
+// UnregisterApp removes an application, persists the change and stops its reconciliation loop.
+// With keepHistory, its configuration and sync history are archived first, recording whether its
+// resources were deleted. Its pending Terraform plan and kept revisions are removed.
+func (c *Controller) UnregisterApp(appName string, keepHistory, resourcesDeleted bool) error {
+	c.apps.Lock()
+	existing, exists := c.apps.Get(appName)
+	if !exists {
+		c.apps.Unlock()
+		return fmt.Errorf("application '%s' not found", appName)
+	}
+	if keepHistory {
+		if err := app.ArchiveApplication(existing, resourcesDeleted, app.DefaultArchiveFile, time.Now()); err != nil {
+			c.apps.Unlock()
+			return fmt.Errorf("failed to archive application history: %w", err)
+		}
+	}
+	// Persist the removal before stopping the loop, so its final status is not saved again
+	c.apps.Delete(appName)
+	if err := app.SaveApplications(c.apps, c.appConfigPath()); err != nil {
+		c.apps.Add(existing)
+		c.apps.Unlock()
+		return fmt.Errorf("failed to save applications: %w", err)
+	}
+	c.apps.Unlock()
+
+	if err := os.Remove(app.TerraformPlanFile(appName)); err != nil && !os.IsNotExist(err) {
+		c.logger.Warn("Failed to remove pending Terraform plan", zap.String("app", appName), zap.Error(err))
+	}
+	for _, dir := range []string{app.RevisionDir(appName), app.PreviousRevisionDir(appName)} {
+		if err := os.RemoveAll(dir); err != nil {
+			c.logger.Warn("Failed to remove kept revision", zap.String("app", appName), zap.Error(err))
+		}
+	}
+
+	c.logger.Info("Application unregistered", zap.String("app", appName))
+	c.StopApp(appName)
+	return nil
+}
+
+// RegisterCluster registers a cluster, replacing an existing one with the same name, persists the
+// change and health checks the cluster.
+func (c *Controller) RegisterCluster(cl *cluster.Cluster) error {
+	c.clusters.Lock()
+	previous, exists := c.clusters.Get(cl.Name)
+	c.clusters.Add(cl)
+	if err := cluster.SaveClusters(c.clusters, cluster.DefaultClusterConfigFile); err != nil {
+		if exists {
+			c.clusters.Add(previous)
+		} else {
+			c.clusters.Delete(cl.Name)
+		}
+		c.clusters.Unlock()
+		return fmt.Errorf("failed to save clusters: %w", err)
+	}
+	c.clusters.Unlock()
+
+	c.logger.Info("Cluster registered", zap.String("cluster", cl.Name), zap.Bool("updated", exists))
+	c.TriggerClusterHealthCheck(cl.Name)
+	return nil
+}
+
+// UnregisterCluster removes a cluster no application is deployed to and persists the change.
+func (c *Controller) UnregisterCluster(clusterName string) error {
+	c.clusters.Lock()
+	defer c.clusters.Unlock()
+
+	existing, exists := c.clusters.Get(clusterName)
+	if !exists {
+		return fmt.Errorf("cluster '%s' not found", clusterName)
+	}
+	c.apps.RLock()
+	for _, a := range c.apps.List() {
+		if a.ClusterName == clusterName {
+			c.apps.RUnlock()
+			return fmt.Errorf("cluster '%s' is in use by application '%s'", clusterName, a.Name)
+		}
+	}
+	c.apps.RUnlock()
+
+	c.clusters.Delete(clusterName)
+	if err := cluster.SaveClusters(c.clusters, cluster.DefaultClusterConfigFile); err != nil {
+		c.clusters.Add(existing)
+		return fmt.Errorf("failed to save clusters: %w", err)
+	}
+	c.logger.Info("Cluster unregistered", zap.String("cluster", clusterName))
+	return nil
+}
@@ -0,0 +1,469 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"os"
+	"path/filepath"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultControlSocket is the default path of the controller's local control socket.
+	DefaultControlSocket = "configs/controller.sock"
+	// controlServiceName is the name under which the control service is registered with net/rpc.
+	controlServiceName = "Controller"
+)
+
+// CommandArgs is the request payload for control socket calls.
+type CommandArgs struct {
+	// Name is the application or cluster name the command applies to.
+	Name string
+	// NewName is the name an application or cluster is renamed to.
+	NewName string
+	// PlanID is the ID of the Terraform plan an approval applies to.
+	PlanID string
+	// Trigger is who requested a sync or suspension and why.
+	Trigger app.Trigger
+	// Enabled turns debug logging of an application on or off.
+	Enabled bool
+	// App is the application registered.
+	App *app.Application
+	// Cluster is the cluster registered.
+	Cluster *cluster.Cluster
+	// KeepHistory archives the configuration and sync history of an unregistered application.
+	KeepHistory bool
+	// ResourcesDeleted records that the resources of an unregistered application were deleted.
+	ResourcesDeleted bool
+}
+
+// CommandReply is the response payload for control socket calls.
+type CommandReply struct {
+	// DispatcherRunning reports whether the controller dispatcher is running.
+	DispatcherRunning bool
+	// DryRun is the outcome of a dry run of a sync.
+	DryRun *DryRunReport
+}
+
+// ControlService exposes controller operations over net/rpc.
+//
+// Every mutating call first reloads state from disk so that changes persisted by CLI commands are
+// visible to the controller before the command is dispatched. A standalone API server registers
+// and unregisters applications and clusters through the service instead of writing the state
+// files, so that the controller is their only writer.
+type ControlService struct {
+	c *Controller
+}
+
+// StartApp reloads state and starts or restarts the named application's reconciliation loop.
+func (s *ControlService) StartApp(args CommandArgs, reply *CommandReply) error {
+	if err := s.c.ReloadState(); err != nil {
+		return err
+	}
+	s.c.StartApp(args.Name)
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// StopApp stops the named application's reconciliation loop and reloads state.
+func (s *ControlService) StopApp(args CommandArgs, reply *CommandReply) error {
+	s.c.StopApp(args.Name)
+	if err := s.c.ReloadState(); err != nil {
+		return err
+	}
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// TriggerSync triggers an immediate sync for the named application.
+func (s *ControlService) TriggerSync(args CommandArgs, reply *CommandReply) error {
+	s.c.TriggerSync(args.Name, args.Trigger)
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// DryRunSync reloads state and dry-runs a sync of the named application.
+func (s *ControlService) DryRunSync(args CommandArgs, reply *CommandReply) error {
+	if err := s.c.ReloadState(); err != nil {
+		return err
+	}
+	report, err := s.c.DryRunSync(args.Name, args.Trigger)
+	if err != nil {
+		return err
+	}
+	reply.DryRun = report
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// ResetFailures clears the named application's consecutive failures.
+func (s *ControlService) ResetFailures(args CommandArgs, reply *CommandReply) error {
+	s.c.ResetFailures(args.Name)
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// ApprovePlan approves the named application's pending Terraform plan.
+func (s *ControlService) ApprovePlan(args CommandArgs, reply *CommandReply) error {
+	s.c.ApprovePlan(args.Name, args.PlanID)
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// SuspendApp suspends the named application's reconciliation.
+func (s *ControlService) SuspendApp(args CommandArgs, reply *CommandReply) error {
+	s.c.SuspendApp(args.Name, args.Trigger)
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// RollbackApp rolls the named application back to its previous revision.
+func (s *ControlService) RollbackApp(args CommandArgs, reply *CommandReply) error {
+	s.c.RollbackApp(args.Name, args.Trigger)
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// SetAppDebug turns debug logging of the named application on or off.
+func (s *ControlService) SetAppDebug(args CommandArgs, reply *CommandReply) error {
+	s.c.SetAppDebug(args.Name, args.Enabled)
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// TriggerClusterHealthCheck reloads state and triggers a health check for the named cluster.
+func (s *ControlService) TriggerClusterHealthCheck(args CommandArgs, reply *CommandReply) error {
+	if err := s.c.ReloadState(); err != nil {
+		return err
+	}
+	s.c.TriggerClusterHealthCheck(args.Name)
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// RenameApp reloads state and renames the named application.
+func (s *ControlService) RenameApp(args CommandArgs, reply *CommandReply) error {
+	if err := s.c.ReloadState(); err != nil {
+		return err
+	}
+	if err := s.c.RenameApp(args.Name, args.NewName); err != nil {
+		return err
+	}
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// RenameCluster reloads state and renames the named cluster.
+func (s *ControlService) RenameCluster(args CommandArgs, reply *CommandReply) error {
+	if err := s.c.ReloadState(); err != nil {
+		return err
+	}
+	if err := s.c.RenameCluster(args.Name, args.NewName); err != nil {
+		return err
+	}
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// RegisterApp reloads state and registers an application, or registers it again.
+func (s *ControlService) RegisterApp(args CommandArgs, reply *CommandReply) error {
+	if args.App == nil {
+		return errors.New("no application given")
+	}
+	if err := s.c.ReloadState(); err != nil {
+		return err
+	}
+	if err := s.c.RegisterApp(args.App); err != nil {
+		return err
+	}
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// UnregisterApp reloads state and unregisters the named application.
+func (s *ControlService) UnregisterApp(args CommandArgs, reply *CommandReply) error {
+	if err := s.c.ReloadState(); err != nil {
+		return err
+	}
+	if err := s.c.UnregisterApp(args.Name, args.KeepHistory, args.ResourcesDeleted); err != nil {
+		return err
+	}
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// RegisterCluster reloads state and registers a cluster, replacing one with the same name.
+func (s *ControlService) RegisterCluster(args CommandArgs, reply *CommandReply) error {
+	if args.Cluster == nil {
+		return errors.New("no cluster given")
+	}
+	if err := s.c.ReloadState(); err != nil {
+		return err
+	}
+	if err := s.c.RegisterCluster(args.Cluster); err != nil {
+		return err
+	}
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// UnregisterCluster reloads state and unregisters the named cluster.
+func (s *ControlService) UnregisterCluster(args CommandArgs, reply *CommandReply) error {
+	if err := s.c.ReloadState(); err != nil {
+		return err
+	}
+	if err := s.c.UnregisterCluster(args.Name); err != nil {
+		return err
+	}
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// Ping reports whether the controller dispatcher is running.
+func (s *ControlService) Ping(_ CommandArgs, reply *CommandReply) error {
+	reply.DispatcherRunning = s.c.IsDispatcherRunning()
+	return nil
+}
+
+// ReloadState reloads applications and clusters from disk into the controller's stores.
+//
+// Existing entries are updated in place so running reconciliation loops keep valid references.
+// Only the spec of existing applications is taken from the file: their runtime state, such as
+// their status, is the controller's own and newer than the file's.
+func (c *Controller) ReloadState() error {
+	loadedApps, err := app.LoadApplications(c.appConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to reload applications: %w", err)
+	}
+	loadedClusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to reload clusters: %w", err)
+	}
+
+	c.apps.Lock()
+	c.apps.MergeSpecs(loadedApps)
+	c.apps.Unlock()
+
+	c.clusters.Lock()
+	c.clusters.Merge(loadedClusters)
+	c.clusters.Unlock()
+	return nil
+}
+
+// ServeControlSocket exposes the controller on a local unix socket.
+//
+// The socket is closed and removed when the controller is stopped.
+func (c *Controller) ServeControlSocket(socketPath string) error {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for control socket %s: %w", socketPath, err)
+	}
+	// Remove a stale socket left behind by a previous run.
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale control socket %s: %w", socketPath, err)
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName(controlServiceName, &ControlService{c: c}); err != nil {
+		return fmt.Errorf("failed to register control service: %w", err)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on control socket %s: %w", socketPath, err)
+	}
+	c.logger.Info("Controller control socket listening", zap.String("socket", socketPath))
+
+	go func() {
+		<-c.ctx.Done()
+		listener.Close()
+		os.Remove(socketPath)
+	}()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					return
+				}
+				c.logger.Warn("Failed to accept control socket connection", zap.Error(err))
+				continue
+			}
+			go server.ServeConn(conn)
+		}
+	}()
+	return nil
+}
+
+// RemoteClient implements Commander by forwarding commands to a controller's control socket.
+type RemoteClient struct {
+	logger     *zap.Logger
+	socketPath string
+}
+
+// NewRemoteClient creates a RemoteClient for the controller listening on socketPath.
+//
+// It verifies that the controller is reachable before returning.
+func NewRemoteClient(logger *zap.Logger, socketPath string) (*RemoteClient, error) {
+	rc := &RemoteClient{logger: logger, socketPath: socketPath}
+	if _, err := rc.call("Ping", CommandArgs{}); err != nil {
+		return nil, fmt.Errorf("failed to reach controller at %s: %w", socketPath, err)
+	}
+	return rc, nil
+}
+
+// call invokes a control service method over a fresh connection.
+// A connection per call keeps the client resilient to controller restarts.
+func (rc *RemoteClient) call(method string, args CommandArgs) (*CommandReply, error) {
+	client, err := rpc.Dial("unix", rc.socketPath)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	reply := &CommandReply{}
+	if err := client.Call(controlServiceName+"."+method, args, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// send invokes a control service method and logs any failure.
+func (rc *RemoteClient) send(method, name string) {
+	rc.sendArgs(method, CommandArgs{Name: name})
+}
+
+// sendArgs invokes a control service method with the given arguments and logs any failure.
+func (rc *RemoteClient) sendArgs(method string, args CommandArgs) {
+	if _, err := rc.call(method, args); err != nil {
+		rc.logger.Error("Failed to send command to controller",
+			zap.String("method", method),
+			zap.String("name", args.Name),
+			zap.Error(err))
+	}
+}
+
+// StartApp asks the remote controller to start or restart an application's reconciliation loop.
+func (rc *RemoteClient) StartApp(appName string) {
+	rc.send("StartApp", appName)
+}
+
+// StopApp asks the remote controller to stop an application's reconciliation loop.
+func (rc *RemoteClient) StopApp(appName string) {
+	rc.send("StopApp", appName)
+}
+
+// TriggerSync asks the remote controller to sync an application immediately.
+func (rc *RemoteClient) TriggerSync(appName string, trigger app.Trigger) {
+	rc.sendArgs("TriggerSync", CommandArgs{Name: appName, Trigger: trigger})
+}
+
+// DryRunSync asks the remote controller to dry-run a sync of an application.
+func (rc *RemoteClient) DryRunSync(appName string, trigger app.Trigger) (*DryRunReport, error) {
+	reply, err := rc.call("DryRunSync", CommandArgs{Name: appName, Trigger: trigger})
+	if err != nil {
+		return nil, err
+	}
+	return reply.DryRun, nil
+}
+
+// ResetFailures asks the remote controller to clear an application's consecutive failures.
+func (rc *RemoteClient) ResetFailures(appName string) {
+	rc.send("ResetFailures", appName)
+}
+
+// ApprovePlan asks the remote controller to approve an application's pending Terraform plan.
+func (rc *RemoteClient) ApprovePlan(appName, planID string) {
+	rc.sendArgs("ApprovePlan", CommandArgs{Name: appName, PlanID: planID})
+}
+
+// SuspendApp asks the remote controller to suspend an application's reconciliation.
+func (rc *RemoteClient) SuspendApp(appName string, trigger app.Trigger) {
+	rc.sendArgs("SuspendApp", CommandArgs{Name: appName, Trigger: trigger})
+}
+
+// RollbackApp asks the remote controller to roll an application back to its previous revision.
+func (rc *RemoteClient) RollbackApp(appName string, trigger app.Trigger) {
+	rc.sendArgs("RollbackApp", CommandArgs{Name: appName, Trigger: trigger})
+}
+
+// SetAppDebug asks the remote controller to turn debug logging of an application on or off.
+func (rc *RemoteClient) SetAppDebug(appName string, enabled bool) {
+	rc.sendArgs("SetAppDebug", CommandArgs{Name: appName, Enabled: enabled})
+}
+
+// TriggerClusterHealthCheck asks the remote controller to health check a cluster immediately.
+func (rc *RemoteClient) TriggerClusterHealthCheck(clusterName string) {
+	rc.send("TriggerClusterHealthCheck", clusterName)
+}
+
+// RenameApp asks the remote controller to rename an application.
+func (rc *RemoteClient) RenameApp(oldName, newName string) error {
+	_, err := rc.call("RenameApp", CommandArgs{Name: oldName, NewName: newName})
+	return err
+}
+
+// RenameCluster asks the remote controller to rename a cluster.
+func (rc *RemoteClient) RenameCluster(oldName, newName string) error {
+	_, err := rc.call("RenameCluster", CommandArgs{Name: oldName, NewName: newName})
+	return err
+}
+
+// RegisterApp asks the remote controller to register an application, or register it again.
+func (rc *RemoteClient) RegisterApp(spec *app.Application) error {
+	_, err := rc.call("RegisterApp", CommandArgs{Name: spec.Name, App: spec})
+	return err
+}
+
+// UnregisterApp asks the remote controller to unregister an application.
+func (rc *RemoteClient) UnregisterApp(appName string, keepHistory, resourcesDeleted bool) error {
+	_, err := rc.call("UnregisterApp", CommandArgs{Name: appName, KeepHistory: keepHistory, ResourcesDeleted: resourcesDeleted})
+	return err
+}
+
+// RegisterCluster asks the remote controller to register a cluster.
+func (rc *RemoteClient) RegisterCluster(cl *cluster.Cluster) error {
+	_, err := rc.call("RegisterCluster", CommandArgs{Name: cl.Name, Cluster: cl})
+	return err
+}
+
+// UnregisterCluster asks the remote controller to unregister a cluster.
+func (rc *RemoteClient) UnregisterCluster(clusterName string) error {
+	_, err := rc.call("UnregisterCluster", CommandArgs{Name: clusterName})
+	return err
+}
+
+// RequestSync asks the remote controller to sync an application like TriggerSync, but returns the
+// failure to deliver the request instead of logging it, so bulk commands can report it.
+func (rc *RemoteClient) RequestSync(appName string, trigger app.Trigger) error {
+	_, err := rc.call("TriggerSync", CommandArgs{Name: appName, Trigger: trigger})
+	return err
+}
+
+// RequestSuspend asks the remote controller to suspend an application like SuspendApp, returning
+// the failure to deliver the request.
+func (rc *RemoteClient) RequestSuspend(appName string, trigger app.Trigger) error {
+	_, err := rc.call("SuspendApp", CommandArgs{Name: appName, Trigger: trigger})
+	return err
+}
+
+// RequestReset asks the remote controller to clear an application's failures like ResetFailures,
+// returning the failure to deliver the request.
+func (rc *RemoteClient) RequestReset(appName string) error {
+	_, err := rc.call("ResetFailures", CommandArgs{Name: appName})
+	return err
+}
+
+// IsDispatcherRunning reports whether the remote controller is reachable and its dispatcher is running.
+func (rc *RemoteClient) IsDispatcherRunning() bool {
+	reply, err := rc.call("Ping", CommandArgs{})
+	if err != nil {
+		return false
+	}
+	return reply.DispatcherRunning
+}
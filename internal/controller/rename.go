@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"fmt"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"go.uber.org/zap"
+)
+
+// RenameApp renames an application and persists the change.
+//
+// A running reconciliation loop is stopped, and waited for so its final status is saved under the
+// old name, before the application is renamed. The loop is then started again under the new name.
+// The application's sync history and events are kept.
+func (c *Controller) RenameApp(oldName, newName string) error {
+	c.mu.Lock()
+	runtime, running := c.runningApps[oldName]
+	c.mu.Unlock()
+	if running {
+		c.logger.Info("Stopping application reconciliation loop for rename", zap.String("app", oldName))
+		runtime.cancel()
+		<-runtime.done
+	}
+
+	c.apps.Lock()
+	err := c.apps.Rename(oldName, newName)
+	if err == nil {
+		if err = app.SaveApplications(c.apps, c.appConfigPath()); err != nil {
+			_ = c.apps.Rename(newName, oldName)
+			err = fmt.Errorf("failed to save applications: %w", err)
+		}
+	}
+	c.apps.Unlock()
+	if err != nil {
+		if running {
+			c.StartApp(oldName)
+		}
+		return err
+	}
+
+	c.renameEvents(event.KindApplication, oldName, newName)
+	c.renameAppDebug(oldName, newName)
+	c.recordEvent(event.KindApplication, newName, event.TypeNormal, "Renamed", fmt.Sprintf("Renamed from '%s'", oldName))
+	c.logger.Info("Application renamed", zap.String("from", oldName), zap.String("to", newName))
+
+	if running {
+		c.StartApp(newName)
+	}
+	return nil
+}
+
+// RenameCluster renames a cluster, points the applications deployed to it at the new name, and
+// persists both changes.
+//
+// The cluster's events are kept, its client set is recreated under the new name, and the
+// reconciliation loops of its running applications are restarted so they pick up the new name.
+func (c *Controller) RenameCluster(oldName, newName string) error {
+	c.clusters.Lock()
+	err := c.clusters.Rename(oldName, newName)
+	if err == nil {
+		if err = cluster.SaveClusters(c.clusters, cluster.DefaultClusterConfigFile); err != nil {
+			_ = c.clusters.Rename(newName, oldName)
+			err = fmt.Errorf("failed to save clusters: %w", err)
+		}
+	}
+	c.clusters.Unlock()
+	if err != nil {
+		return err
+	}
+
+	c.apps.Lock()
+	appNames := c.apps.RenameCluster(oldName, newName)
+	if len(appNames) > 0 {
+		if err := app.SaveApplications(c.apps, c.appConfigPath()); err != nil {
+			c.apps.Unlock()
+			return fmt.Errorf("cluster renamed, but failed to save applications: %w", err)
+		}
+	}
+	c.apps.Unlock()
+
+	c.clientsMu.Lock()
+	delete(c.clients, oldName)
+	c.clientsMu.Unlock()
+
+	c.renameEvents(event.KindCluster, oldName, newName)
+	c.recordEvent(event.KindCluster, newName, event.TypeNormal, "Renamed", fmt.Sprintf("Renamed from '%s'", oldName))
+	c.logger.Info("Cluster renamed", zap.String("from", oldName), zap.String("to", newName), zap.Strings("apps", appNames))
+
+	c.mu.Lock()
+	for _, appName := range appNames {
+		if _, ok := c.runningApps[appName]; ok {
+			c.StartApp(appName)
+		}
+	}
+	c.mu.Unlock()
+	c.TriggerClusterHealthCheck(newName)
+	return nil
+}
+
+// renameEvents moves the events recorded for an object to its new name and persists the event log.
+func (c *Controller) renameEvents(kind, oldName, newName string) {
+	c.events.Lock()
+	defer c.events.Unlock()
+
+	c.events.Rename(kind, oldName, newName)
+	if err := event.SaveEvents(c.events, event.DefaultEventFile); err != nil {
+		c.logger.Error("Failed to save events to file", zap.Error(err))
+	}
+}
+
+// appConfigPath returns the path of the applications file the controller persists to.
+func (c *Controller) appConfigPath() string {
+	if c.appConfigFile == "" {
+		return app.DefaultAppConfigFile
+	}
+	return c.appConfigFile
+}
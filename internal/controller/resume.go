@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+)
+
+// resumableResources returns the outcome of the resources the last sync applied if it failed part way
+// through applying the same commit with the same Helm values, so the sync only needs to apply the
+// rest. It returns nil when every resource must be applied.
+func resumableResources(application *app.Application, gitHash, valuesDigest string) []app.ResourceSyncResult {
+	if application.PartialGitHash == "" || application.PartialGitHash != gitHash || application.PartialValuesDigest != valuesDigest {
+		return nil
+	}
+	var applied []app.ResourceSyncResult
+	for _, r := range application.LastSyncResources {
+		if r.Action != k8s.ActionFailed {
+			applied = append(applied, r)
+		}
+	}
+	return applied
+}
+
+// resourceRefs returns references to the resources of sync results.
+func resourceRefs(results []app.ResourceSyncResult) []k8s.ResourceRef {
+	refs := make([]k8s.ResourceRef, 0, len(results))
+	for _, r := range results {
+		refs = append(refs, k8s.ResourceRef{Kind: r.Kind, Namespace: r.Namespace, Name: r.Name})
+	}
+	return refs
+}
@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"go.uber.org/zap"
+)
+
+// awaitHealthOrRollback completes a two-phase sync of the manifests just applied at currentHash.
+//
+// It waits up to the application's health timeout for the applied resources to become healthy. Healthy
+// manifests are kept as the revision to roll back to, and the revision kept before them as the one an
+// operator can roll back to (see rollBackApp). Otherwise the kept manifests of the previous
+// revision are applied again and the unhealthy revision is remembered, so it is not applied again until
+// the application is reset. It reports whether the sync failed, in which case the application's status
+// has been updated but not saved.
+func (c *Controller) awaitHealthOrRollback(ctx context.Context, logger *zap.Logger, application *app.Application, k8sClient *k8s.ClientSet,
+	applied []k8s.ResourceRef, applyDir, currentHash, valuesDigest string) bool {
+	timeout := application.HealthTimeoutDuration()
+	logger.Info("Waiting for applied resources to become healthy...", zap.String("hash", currentHash), zap.Duration("timeout", timeout))
+	healthErr := k8sClient.WaitForHealthy(ctx, applied, timeout)
+	if healthErr == nil {
+		previous, err := keepRevision(applyDir, app.RevisionDir(application.Name), app.PreviousRevisionDir(application.Name))
+		if err != nil {
+			logger.Warn("Failed to keep manifests of healthy revision for rollback", zap.Error(err))
+		} else if previous {
+			application.RollbackGitHash = application.LastSyncedGitHash
+		} else {
+			application.RollbackGitHash = ""
+		}
+		logger.Info("Applied resources are healthy", zap.String("hash", currentHash))
+		return false
+	}
+
+	if ctx.Err() != nil {
+		c.setAppStatus(application, app.StatusError, fmt.Sprintf("Health check of %s interrupted: %v", currentHash, healthErr))
+		application.ConsecutiveFailures++
+		c.recordSyncEvent(application, currentHash)
+		return true
+	}
+
+	logger.Error("Applied resources did not become healthy", zap.String("hash", currentHash), zap.Error(healthErr))
+	application.UnhealthyGitHash = currentHash
+	application.UnhealthyValuesDigest = valuesDigest
+	application.ConsecutiveFailures++
+	c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "Unhealthy",
+		fmt.Sprintf("Resources applied at %s are unhealthy: %v", currentHash, healthErr))
+
+	previousHash := application.LastSyncedGitHash
+	revisionDir := app.RevisionDir(application.Name)
+	if _, err := os.Stat(revisionDir); previousHash == "" || err != nil {
+		c.setAppStatus(application, app.StatusError, fmt.Sprintf("Resources applied at %s are unhealthy and there is no previous revision to roll back to: %v",
+			currentHash, healthErr))
+		c.recordSyncEvent(application, currentHash)
+		return true
+	}
+
+	rollbackCtx, cancel := context.WithTimeout(ctx, c.applyTimeout)
+	defer cancel()
+	logger.Warn("Rolling back to previous revision", zap.String("from", currentHash), zap.String("to", previousHash))
+	tracking := k8s.Tracking{App: application.Name, Revision: previousHash, Controller: c.instanceID, HashConfig: application.ConfigHash}
+	rollbackResults, rollbackErrors := k8sClient.ApplyManifests(rollbackCtx, revisionDir, application.ApplyStrategy, manifestOptions(application), tracking)
+	application.LastSyncResources = toResourceSyncResults(rollbackResults)
+	restored := k8s.AppliedResources(rollbackResults)
+	if len(rollbackErrors) > 0 {
+		errorMessages := make([]string, len(rollbackErrors))
+		for i, e := range rollbackErrors {
+			errorMessages[i] = e.Error()
+		}
+		c.setAppStatus(application, app.StatusError, fmt.Sprintf("Resources applied at %s are unhealthy (%v) and rolling back to %s failed: %s",
+			currentHash, healthErr, previousHash, strings.Join(errorMessages, "; ")))
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "RollbackFailed", application.Message)
+		c.recordSyncEvent(application, currentHash)
+		return true
+	}
+
+	application.ManagedResources = toManagedResources(restored)
+	c.setAppStatus(application, app.StatusRolledBack, fmt.Sprintf("Rolled back from %s to %s: %v. Push a fix, or run 'gitopsctl app reset %s' to retry it",
+		currentHash, previousHash, healthErr, application.Name))
+	c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "RolledBack",
+		fmt.Sprintf("Rolled back from %s to %s, reapplying %d resource(s)", currentHash, previousHash, len(restored)))
+	c.recordSyncEvent(application, currentHash)
+	logger.Info("Rolled back to previous revision", zap.String("from", currentHash), zap.String("to", previousHash))
+	return true
+}
+
+// rollBackApp applies the manifests of the healthy revision before the last one again at an
+// operator's request, as a two-phase sync does when the resources it applied do not become healthy.
+//
+// The revision rolled back from is not applied again, with the same Helm values, until the
+// application is reset, and the revision rolled back to becomes the kept one. The application's
+// status is updated and saved.
+func (c *Controller) rollBackApp(ctx context.Context, logger *zap.Logger, application *app.Application, k8sClient *k8s.ClientSet,
+	appConfigFile string, trigger app.Trigger) {
+	fromHash, toHash := application.LastSyncedGitHash, application.RollbackGitHash
+	revisionDir, previousDir := app.RevisionDir(application.Name), app.PreviousRevisionDir(application.Name)
+	if _, err := os.Stat(previousDir); toHash == "" || k8sClient == nil || err != nil {
+		logger.Warn("No previous revision to roll back to", zap.String("actor", trigger.Actor))
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "RollbackRejected",
+			fmt.Sprintf("Rollback requested by %s, but no previous revision is kept", trigger))
+		return
+	}
+	application.SyncTrigger = &trigger
+	defer func() { application.SyncTrigger = nil }()
+
+	rollbackCtx, cancel := context.WithTimeout(ctx, c.applyTimeout)
+	defer cancel()
+	logger.Warn("Rolling back to previous revision", zap.String("from", fromHash), zap.String("to", toHash), zap.String("actor", trigger.Actor))
+	tracking := k8s.Tracking{App: application.Name, Revision: toHash, Controller: c.instanceID, HashConfig: application.ConfigHash}
+	rollbackResults, rollbackErrors := k8sClient.ApplyManifests(rollbackCtx, previousDir, application.ApplyStrategy, manifestOptions(application), tracking)
+	application.LastSyncResources = toResourceSyncResults(rollbackResults)
+	if len(rollbackErrors) > 0 {
+		errorMessages := make([]string, len(rollbackErrors))
+		for i, e := range rollbackErrors {
+			errorMessages[i] = e.Error()
+		}
+		c.setAppStatus(application, app.StatusError, fmt.Sprintf("Rolling back from %s to %s, requested by %s, failed: %s",
+			fromHash, toHash, trigger, strings.Join(errorMessages, "; ")))
+		application.ConsecutiveFailures++
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "RollbackFailed", application.Message)
+		c.recordSyncEvent(application, toHash)
+		c.saveAppStatus(application, appConfigFile, true)
+		return
+	}
+
+	// The revision rolled back to is now the one a failed two-phase sync rolls back to
+	err := os.RemoveAll(revisionDir)
+	if err == nil {
+		err = os.Rename(previousDir, revisionDir)
+	}
+	if err != nil {
+		logger.Warn("Failed to keep manifests of the revision rolled back to", zap.Error(err))
+	}
+	restored := k8s.AppliedResources(rollbackResults)
+	application.UnhealthyGitHash, application.UnhealthyValuesDigest = fromHash, application.LastSyncedValuesDigest
+	application.LastSyncedGitHash = toHash
+	application.RollbackGitHash = ""
+	application.ManagedResources = toManagedResources(restored)
+	c.setAppStatus(application, app.StatusRolledBack, fmt.Sprintf("Rolled back from %s to %s by %s. Push a fix, or run 'gitopsctl app reset %s' to apply %s again",
+		fromHash, toHash, trigger, application.Name, fromHash))
+	c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, "RolledBack",
+		fmt.Sprintf("Rolled back from %s to %s by %s, reapplying %d resource(s)", fromHash, toHash, trigger, len(restored)))
+	c.recordSyncEvent(application, toHash)
+	c.saveAppStatus(application, appConfigFile, true)
+	logger.Info("Rolled back to previous revision", zap.String("from", fromHash), zap.String("to", toHash))
+}
+
+// keepRevision replaces the manifests kept in revisionDir with the YAML files under applyDir,
+// preserving their relative paths so exclude patterns still match them. The manifests it replaces
+// are moved to previousDir, replacing those kept there, and it reports whether there were any.
+func keepRevision(applyDir, revisionDir, previousDir string) (bool, error) {
+	staging := revisionDir + ".new"
+	if err := os.RemoveAll(staging); err != nil {
+		return false, err
+	}
+	err := filepath.WalkDir(applyDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || (!strings.HasSuffix(d.Name(), ".yaml") && !strings.HasSuffix(d.Name(), ".yml")) {
+			return err
+		}
+		rel, err := filepath.Rel(applyDir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(staging, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+	if err == nil {
+		err = os.MkdirAll(staging, 0755) // Keep an empty revision if nothing was applied
+	}
+	if err == nil {
+		err = os.RemoveAll(previousDir)
+	}
+	previous := false
+	if err == nil {
+		if err = os.Rename(revisionDir, previousDir); err == nil {
+			previous = true
+		} else if os.IsNotExist(err) {
+			err = nil
+		}
+	}
+	if err == nil {
+		err = os.Rename(staging, revisionDir)
+	}
+	if err != nil {
+		os.RemoveAll(staging)
+		return previous, fmt.Errorf("failed to keep manifests in %s: %w", revisionDir, err)
+	}
+	return previous, nil
+}
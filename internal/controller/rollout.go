@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/progressive"
+	"go.uber.org/zap"
+)
+
+// refreshRollouts updates the progress of the application's Argo Rollouts Rollouts and Flagger Canaries
+// and saves it if it changed, recording an event whenever a rollout enters a new phase.
+// Rollouts that cannot be fetched keep their last known progress.
+func (c *Controller) refreshRollouts(ctx context.Context, logger *zap.Logger, application *app.Application, k8sClient *k8s.ClientSet, appConfigFile string) {
+	refs := progressive.Rollouts(application)
+	if len(refs) == 0 && len(application.Rollouts) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.applyTimeout)
+	defer cancel()
+	statuses, errs := k8sClient.RolloutStatuses(ctx, refs)
+	for _, err := range errs {
+		logger.Warn("Failed to get rollout progress", zap.Error(err))
+	}
+
+	previous := application.Rollouts
+	rollouts := make([]app.Rollout, 0, len(refs))
+	for _, ref := range refs {
+		i := slices.IndexFunc(statuses, func(s k8s.RolloutStatus) bool { return s.Resource == ref })
+		if i < 0 {
+			if j := slices.IndexFunc(previous, func(r app.Rollout) bool { return sameRollout(r, ref) }); j >= 0 {
+				rollouts = append(rollouts, previous[j])
+			}
+			continue
+		}
+		s := statuses[i]
+		rollout := app.Rollout{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name, Provider: s.Provider, Phase: s.Phase,
+			Step: s.Step, Steps: s.Steps, Weight: s.Weight, Paused: s.Paused, Message: s.Message}
+		j := slices.IndexFunc(previous, func(r app.Rollout) bool { return sameRollout(r, ref) })
+		if rollout.Phase != "" && (j < 0 || previous[j].Phase != rollout.Phase) {
+			c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "RolloutProgressing",
+				fmt.Sprintf("%s is %s (%s)", ref, rollout.Phase, rollout.Progress()))
+		}
+		rollouts = append(rollouts, rollout)
+	}
+	if len(rollouts) == 0 {
+		rollouts = nil
+	}
+	if slices.Equal(previous, rollouts) {
+		return
+	}
+	application.Rollouts = rollouts
+	c.saveAppStatus(application, appConfigFile, true)
+}
+
+// sameRollout reports whether a rollout's progress belongs to the given resource.
+func sameRollout(r app.Rollout, ref k8s.ResourceRef) bool {
+	return r.Kind == ref.Kind && r.Namespace == ref.Namespace && r.Name == ref.Name
+}
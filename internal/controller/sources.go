@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/git"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
+	"go.uber.org/zap"
+)
+
+// sourceCheckouts are the clones of an application's additional sources for one sync.
+type sourceCheckouts struct {
+	// dirs maps each source's name to the directory it is checked out in.
+	dirs map[string]string
+	// revisions maps each source's name to the commit hash it is checked out at.
+	revisions map[string]string
+	// release gives the directories back to the repository cache, or removes them.
+	release func()
+}
+
+// fetchSources clones or pulls each additional source of the application into its own directory,
+// cached in cache like the application's repository, or a temporary directory if cache is nil. A
+// source the provider rejects for exceeding a rate limit is returned as a *git.RateLimitError; the
+// directories fetched so far are released in that case and on any other error.
+func (c *Controller) fetchSources(ctx context.Context, logger *zap.Logger, application *app.Application, cache *git.RepoCache) (*sourceCheckouts, error) {
+	checkouts := &sourceCheckouts{
+		dirs:      make(map[string]string, len(application.Sources)),
+		revisions: make(map[string]string, len(application.Sources)),
+	}
+	var acquired []string
+	checkouts.release = func() {
+		for _, dir := range acquired {
+			if cache != nil {
+				cache.Release(dir)
+			} else if err := git.CleanUpRepo(logger, dir); err != nil {
+				logger.Error("Failed to clean up source directory", zap.String("dir", dir), zap.Error(err))
+			}
+		}
+	}
+
+	for _, source := range application.Sources {
+		if err := c.checkRateLimit(source.RepoURL); err != nil {
+			checkouts.release()
+			return nil, err
+		}
+		var (
+			dir string
+			err error
+		)
+		if cache != nil {
+			dir, err = cache.Acquire(application.Name+"-"+source.Name, source.RepoURL, source.Branch)
+		} else {
+			dir, err = git.CreateTempRepoDir(application.Name + "-" + source.Name)
+		}
+		if err != nil {
+			checkouts.release()
+			return nil, fmt.Errorf("source '%s': %w", source.Name, err)
+		}
+		acquired = append(acquired, dir)
+
+		hash, err := git.CloneOrPull(ctx, logger, source.RepoURL, source.Branch, dir, git.FetchOptions{})
+		var rateLimited *git.RateLimitError
+		if errors.As(err, &rateLimited) {
+			c.recordRateLimit(rateLimited)
+			checkouts.release()
+			return nil, err
+		}
+		if err != nil {
+			checkouts.release()
+			return nil, fmt.Errorf("source '%s': %w", source.Name, err)
+		}
+		logger.Debug("Fetched source", zap.String("source", source.Name), zap.String("hash", hash))
+		checkouts.dirs[source.Name] = dir
+		checkouts.revisions[source.Name] = hash
+	}
+	return checkouts, nil
+}
+
+// combineSourceManifests copies the manifests of the additional sources with a path into outputDir,
+// each under "$name", next to the application's own manifests.
+func combineSourceManifests(application *app.Application, checkouts *sourceCheckouts, outputDir string) error {
+	for _, source := range application.Sources {
+		if source.Path == "" {
+			continue
+		}
+		if err := render.CombineSources(checkouts.dirs[source.Name], []string{source.Path}, filepath.Join(outputDir, "$"+source.Name)); err != nil {
+			return fmt.Errorf("source '%s': %w", source.Name, err)
+		}
+	}
+	return nil
+}
+
+// sourcesDigest folds the revisions of the application's additional sources into the digest of its
+// Helm values, so a new commit on any source is detected as a change like a change of the values.
+// Without sources the values digest is returned unchanged.
+func sourcesDigest(application *app.Application, valuesDigest string, revisions map[string]string) string {
+	if len(application.Sources) == 0 {
+		return valuesDigest
+	}
+	hash := sha256.New()
+	fmt.Fprintf(hash, "values=%s\n", valuesDigest)
+	for _, source := range application.Sources {
+		fmt.Fprintf(hash, "%s=%s\n", source.Name, revisions[source.Name])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
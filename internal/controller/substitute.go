@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
+)
+
+// substitutionVariables returns the variables substituted into the application's manifests: the
+// variables of its cluster, overridden by its own, and the built-in ones. It returns nil when the
+// application does not use variable substitution.
+func (c *Controller) substitutionVariables(application *app.Application) map[string]string {
+	if !application.SubstitutesVariables() {
+		return nil
+	}
+	vars := make(map[string]string)
+	c.clusters.RLock()
+	if cl, ok := c.clusters.Get(application.ClusterName); ok {
+		maps.Copy(vars, cl.Variables)
+	}
+	c.clusters.RUnlock()
+	maps.Copy(vars, application.Variables)
+	vars[render.VariableClusterName] = application.ClusterName
+	vars[render.VariableAppName] = application.Name
+	return vars
+}
+
+// variablesDigest folds the variables substituted into the application's manifests into the digest
+// of its Helm values, so changing a variable is detected as a change like a change of the values.
+// Without variables the values digest is returned unchanged.
+func variablesDigest(valuesDigest string, vars map[string]string) string {
+	if vars == nil {
+		return valuesDigest
+	}
+	hash := sha256.New()
+	fmt.Fprintf(hash, "values=%s\n", valuesDigest)
+	for _, name := range slices.Sorted(maps.Keys(vars)) {
+		fmt.Fprintf(hash, "%s=%d:%s\n", name, len(vars[name]), vars[name])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
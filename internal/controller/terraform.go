@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/terraform"
+	"go.uber.org/zap"
+)
+
+// TerraformTimeout defines the timeout for initializing and planning or applying a Terraform configuration.
+const TerraformTimeout = 30 * time.Minute
+
+// performTerraformSync plans or applies a Terraform application at the given commit.
+//
+// A new commit is planned; a plan with changes is saved and the application waits for it to be
+// approved. An approved plan is applied as long as it was made at the current commit, otherwise
+// it is replaced by a plan of the current commit that must be approved again.
+func (c *Controller) performTerraformSync(ctx context.Context, logger *zap.Logger, application *app.Application, repoDir, currentHash, appConfigFile string) {
+	previousStatus := application.Status
+	previousHash := application.LastSyncedGitHash
+	previousFailures := application.ConsecutiveFailures
+	save := func() {
+		c.saveAppStatus(application, appConfigFile, previousStatus != application.Status || previousHash != application.LastSyncedGitHash ||
+			previousFailures != application.ConsecutiveFailures)
+	}
+	fail := func(reason, message string) {
+		c.setAppStatus(application, app.StatusError, message)
+		application.ConsecutiveFailures++
+		c.recordEvent(event.KindApplication, application.Name, event.TypeWarning, reason, application.Message)
+		c.recordSyncEvent(application, currentHash)
+		save()
+	}
+
+	configDir := filepath.Join(repoDir, application.Path)
+	if _, err := os.Stat(configDir); os.IsNotExist(err) {
+		logger.Error("Terraform configuration path does not exist in repository", zap.String("path", application.Path))
+		fail("ConfigurationPathNotFound", fmt.Sprintf("Terraform configuration path '%s' not found in repo after cloning. Check 'path' in config or repo structure.", application.Path))
+		return
+	}
+	planFile, err := filepath.Abs(app.TerraformPlanFile(application.Name))
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(planFile), 0755)
+	}
+	if err != nil {
+		logger.Error("Failed to prepare Terraform plan directory", zap.Error(err))
+		fail("SetupFailed", fmt.Sprintf("Failed to prepare Terraform plan directory: %v", err))
+		return
+	}
+
+	var opts terraform.Options
+	runner := terraform.Runner{Dir: configDir}
+	if source := application.Terraform; source != nil {
+		runner.Binary = source.Binary
+		opts = terraform.Options{VarFiles: source.VarFiles, Vars: source.Vars}
+	}
+	for _, file := range opts.VarFiles {
+		if rel, err := filepath.Rel(repoDir, filepath.Join(configDir, file)); err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			fail("PlanFailed", fmt.Sprintf("Variable file '%s' is outside the repository", file))
+			return
+		}
+	}
+
+	tfCtx, cancel := context.WithTimeout(ctx, TerraformTimeout)
+	defer cancel()
+
+	plan := application.TerraformPlan
+	if plan.IsPending() && plan.GitHash == currentHash {
+		if _, err := os.Stat(planFile); err != nil {
+			logger.Warn("Saved Terraform plan is missing, planning again", zap.String("plan", plan.ID), zap.Error(err))
+		} else if plan.ApprovedAt.IsZero() {
+			logger.Debug("Terraform plan awaits approval", zap.String("plan", plan.ID))
+			c.setAppStatus(application, app.StatusAwaitingApproval, awaitingApprovalMessage(application))
+			save()
+			return
+		} else {
+			if err := c.applyTerraformPlan(tfCtx, logger, application, runner, planFile, currentHash); err != nil {
+				fail("ApplyFailed", fmt.Sprintf("Failed to apply plan %s: %v", plan.ID, err))
+				return
+			}
+			save()
+			return
+		}
+	}
+
+	if !plan.IsPending() && currentHash == application.LastSyncedGitHash {
+		logger.Debug("No new changes detected in Git repository", zap.String("hash", currentHash))
+		if application.Status == app.StatusError || application.Status == app.StatusPending || application.Status == app.StatusSyncRequested || application.Status == app.StatusInterrupted {
+			c.setAppStatus(application, app.StatusSynced, fmt.Sprintf("Up to date at %s", currentHash))
+			application.ConsecutiveFailures = 0
+			c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "Synced", application.Message)
+			c.recordSyncEvent(application, currentHash)
+			save()
+		} else {
+			application.Message = fmt.Sprintf("Up to date at %s", currentHash)
+		}
+		return
+	}
+
+	if plan.IsPending() {
+		logger.Info("Pending Terraform plan is outdated, planning again", zap.String("plan", plan.ID), zap.String("planHash", plan.GitHash))
+		c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "PlanOutdated",
+			fmt.Sprintf("Plan %s made at %s was replaced by a plan of %s", plan.ID, plan.GitHash, currentHash))
+		application.TerraformPlan = nil
+	}
+	os.Remove(planFile)
+
+	logger.Info("Planning Terraform configuration...", zap.String("hash", currentHash))
+	if err := runner.Init(tfCtx); err != nil {
+		logger.Error("Failed to initialize Terraform configuration", zap.Error(err))
+		fail("PlanFailed", fmt.Sprintf("Failed to initialize Terraform configuration: %v", err))
+		return
+	}
+	result, err := runner.Plan(tfCtx, planFile, opts)
+	if err != nil {
+		os.Remove(planFile)
+		logger.Error("Failed to plan Terraform configuration", zap.Error(err))
+		fail("PlanFailed", fmt.Sprintf("Failed to plan Terraform configuration: %v", err))
+		return
+	}
+
+	application.ConsecutiveFailures = 0
+	if !result.HasChanges {
+		os.Remove(planFile)
+		application.LastSyncedGitHash = currentHash
+		c.setAppStatus(application, app.StatusSynced, fmt.Sprintf("No changes at %s", currentHash))
+		c.recordSyncEvent(application, currentHash)
+		c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "Synced", application.Message)
+		logger.Info("Terraform plan has no changes", zap.String("hash", currentHash))
+		save()
+		return
+	}
+
+	application.TerraformPlan = &app.TerraformPlan{
+		ID:        result.ID,
+		GitHash:   currentHash,
+		Summary:   result.Summary(),
+		Add:       result.Add,
+		Change:    result.Change,
+		Destroy:   result.Destroy,
+		PlannedAt: time.Now(),
+	}
+	c.setAppStatus(application, app.StatusAwaitingApproval, awaitingApprovalMessage(application))
+	c.recordSyncEvent(application, currentHash)
+	c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "PlanReady",
+		fmt.Sprintf("Plan %s at %s: %s", result.ID, currentHash, result.Summary()))
+	logger.Info("Terraform plan awaits approval", zap.String("plan", result.ID), zap.String("summary", result.Summary()))
+	c.saveAppStatus(application, appConfigFile, true)
+}
+
+// applyTerraformPlan applies the application's approved plan. The plan is discarded either way:
+// a failed apply may have changed some of the infrastructure, so it must be planned again.
+func (c *Controller) applyTerraformPlan(ctx context.Context, logger *zap.Logger, application *app.Application, runner terraform.Runner,
+	planFile, currentHash string) error {
+	plan := *application.TerraformPlan
+	defer os.Remove(planFile)
+
+	logger.Info("Applying approved Terraform plan...", zap.String("plan", plan.ID), zap.String("hash", currentHash))
+	err := runner.Init(ctx)
+	if err == nil {
+		err = runner.Apply(ctx, planFile)
+	}
+	if err != nil {
+		logger.Error("Failed to apply Terraform plan", zap.String("plan", plan.ID), zap.Error(err))
+		application.TerraformPlan = nil
+		return err
+	}
+
+	plan.AppliedAt = time.Now()
+	application.TerraformPlan = &plan
+	application.LastSyncedGitHash = currentHash
+	c.setAppStatus(application, app.StatusSynced, fmt.Sprintf("Applied plan %s at %s: %s", plan.ID, currentHash, plan.Summary))
+	application.ConsecutiveFailures = 0
+	c.recordSyncEvent(application, currentHash)
+	c.recordEvent(event.KindApplication, application.Name, event.TypeNormal, "PlanApplied", application.Message)
+	logger.Info("Applied Terraform plan", zap.String("plan", plan.ID), zap.String("hash", currentHash))
+	return nil
+}
+
+// awaitingApprovalMessage returns the status message of an application whose plan awaits approval.
+func awaitingApprovalMessage(application *app.Application) string {
+	plan := application.TerraformPlan
+	return fmt.Sprintf("Plan %s at %s awaits approval: %s Approve it with 'gitopsctl app approve %s'",
+		plan.ID, plan.GitHash, plan.Summary, application.Name)
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/core/alert"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/notify"
+	"go.uber.org/zap"
+)
+
+// OnAppTransition registers a hook that is called whenever the controller changes an application's status.
+//
+// Hooks run synchronously on the goroutine that changed the status, so they must not block.
+func (c *Controller) OnAppTransition(hook app.TransitionHook) {
+	c.appStates.OnTransition(hook)
+}
+
+// OnClusterTransition registers a hook that is called whenever the controller changes a cluster's status.
+//
+// Hooks run synchronously on the goroutine that changed the status, so they must not block.
+func (c *Controller) OnClusterTransition(hook cluster.TransitionHook) {
+	c.clusterStates.OnTransition(hook)
+}
+
+// setAppStatus moves the application to the given status through the state machine.
+//
+// A transition the state machine rejects is logged and leaves the application unchanged.
+func (c *Controller) setAppStatus(application *app.Application, status app.Status, message string) {
+	if err := c.appStates.SetStatus(application, status, message); err != nil {
+		c.logger.Error("Rejected application status change", zap.String("app", application.Name), zap.Error(err))
+	}
+}
+
+// setClusterStatus moves the cluster to the given status through the state machine.
+//
+// A transition the state machine rejects is logged and leaves the cluster unchanged.
+func (c *Controller) setClusterStatus(cl *cluster.Cluster, status cluster.Status, message string) {
+	if err := c.clusterStates.SetStatus(cl, status, message); err != nil {
+		c.logger.Error("Rejected cluster status change", zap.String("cluster", cl.Name), zap.Error(err))
+	}
+}
+
+// notifySuspension sends a notification when an application is suspended and when it is resumed.
+func (c *Controller) notifySuspension(t app.Transition) {
+	n := notify.Notification{
+		Rule:   suspendedAlertRule,
+		Kind:   alert.KindApplication,
+		Name:   t.App,
+		Status: string(app.StatusSuspended),
+		Time:   time.Now(),
+	}
+	switch {
+	case t.To == app.StatusSuspended:
+		n.State = notify.StateFiring
+		n.Message = t.Message
+	case t.From == app.StatusSuspended:
+		n.State = notify.StateResolved
+		n.Message = fmt.Sprintf("Application '%s' was resumed", t.App)
+	default:
+		return
+	}
+	c.alerts.Notify(n)
+}
+
+// recordClusterTransition records an event when a cluster's health check changes its status.
+//
+// Only status changes are recorded to keep periodic checks from flooding the event log.
+func (c *Controller) recordClusterTransition(t cluster.Transition) {
+	if t.To == cluster.StatusActive {
+		c.recordEvent(event.KindCluster, t.Cluster, event.TypeNormal, "ClusterHealthy", t.Message)
+	} else {
+		c.recordEvent(event.KindCluster, t.Cluster, event.TypeWarning, "ClusterUnhealthy", t.Message)
+	}
+}
@@ -0,0 +1,285 @@
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/notify"
+)
+
+const (
+	// DefaultAlertConfigFile is the default path of the alerting configuration
+	DefaultAlertConfigFile = "configs/alerts.json"
+	// DefaultEvaluationInterval is how often alerting rules are evaluated.
+	DefaultEvaluationInterval = 30 * time.Second
+)
+
+const (
+	// KindApplication is the rule kind matching applications.
+	KindApplication = "Application"
+	// KindCluster is the rule kind matching clusters.
+	KindCluster = "Cluster"
+)
+
+// Rule describes when an alert should fire for an application or cluster.
+//
+// A rule matches objects of the given Kind (optionally restricted to a single Target)
+// whose status equals Status. It fires once the object has been in that status for at
+// least For, and/or for at least Consecutive consecutive observations (syncs for
+// applications, health checks for clusters). When both are set, both must hold.
+type Rule struct {
+	// Name uniquely identifies the rule and is used as the alert's reason.
+	Name string `json:"name"`
+	// Kind is the kind of object the rule applies to ("Application" or "Cluster").
+	Kind string `json:"kind"`
+	// Target restricts the rule to a single object name. Empty matches all objects of Kind.
+	Target string `json:"target,omitempty"`
+	// Status is the object status that triggers the rule (e.g., "Error", "Unreachable").
+	Status string `json:"status"`
+	// For is how long the object must stay in Status before the rule fires (e.g., "15m").
+	For string `json:"for,omitempty"`
+	// Consecutive is how many consecutive observations in Status are required before the rule fires.
+	Consecutive int `json:"consecutive,omitempty"`
+
+	// forDuration is the parsed duration of the For field.
+	forDuration time.Duration
+}
+
+// Channel is a webhook, email, incident or chat destination of notifications, with settings controlling when they are delivered.
+type Channel struct {
+	// Name identifies the channel in logs.
+	Name string `json:"name"`
+	// Webhook is the URL notifications are posted to.
+	Webhook string `json:"webhook,omitempty"`
+	// Email sends notifications by email. Empty fields are taken from the global SMTP settings, so
+	// an empty object emails the global recipients.
+	Email *notify.SMTPSettings `json:"email,omitempty"`
+	// PagerDuty opens a PagerDuty incident when an alert fires and resolves it when the alert resolves.
+	PagerDuty *notify.PagerDutySettings `json:"pagerduty,omitempty"`
+	// Opsgenie opens an Opsgenie alert when an alert fires and closes it when the alert resolves.
+	Opsgenie *notify.OpsgenieSettings `json:"opsgenie,omitempty"`
+	// Teams posts notifications to Microsoft Teams as cards linking to the application or cluster.
+	Teams *notify.TeamsSettings `json:"teams,omitempty"`
+	// Mattermost posts notifications to Mattermost as attachments linking to the application or cluster.
+	// Exactly one of Webhook, Email, PagerDuty, Opsgenie, Teams and Mattermost must be set.
+	Mattermost *notify.MattermostSettings `json:"mattermost,omitempty"`
+	// Digest batches notifications and posts them together at most once per interval (e.g., "15m").
+	// Empty posts every notification as it happens.
+	Digest string `json:"digest,omitempty"`
+	// Dedup is how long a firing alert is not posted again after it was posted (e.g., "1h"), so an
+	// alert that flaps with every poll does not page repeatedly. Empty posts every notification.
+	Dedup string `json:"dedup,omitempty"`
+	// QuietHours holds notifications back during a daily window and posts them as a digest once it ends.
+	QuietHours *notify.QuietHours `json:"quietHours,omitempty"`
+
+	// policy is the parsed delivery policy of the channel.
+	policy notify.Policy
+	// notifier delivers the channel's notifications to its webhook or recipients.
+	notifier notify.Notifier
+}
+
+// Config holds the alerting rules and the destinations notified when they fire or resolve.
+type Config struct {
+	// Webhooks are the URLs notifications are posted to as they happen.
+	Webhooks []string `json:"webhooks,omitempty"`
+	// Channels are the webhooks and email recipients notified, with digests, deduplication or quiet hours.
+	Channels []Channel `json:"channels,omitempty"`
+	// SMTP are the global email settings, which channels sending email override.
+	SMTP notify.SMTPSettings `json:"smtp,omitempty"`
+	// ExternalURL is the URL the API is reachable at (e.g., "https://gitops.example.com"), which
+	// chat messages link to. Empty omits the links.
+	ExternalURL string `json:"externalURL,omitempty"`
+	// Rules are the alerting rules to evaluate.
+	Rules []Rule `json:"rules"`
+}
+
+// LoadConfig loads and validates the alerting configuration from the specified file path.
+// If the file does not exist, it returns an empty configuration with no rules.
+func LoadConfig(filePath string) (*Config, error) {
+	config := &Config{}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, fmt.Errorf("failed to read alerting config file %s: %w", filePath, err)
+	}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal alerting config: %w", err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// validate checks the channels and rules for errors and parses their durations.
+func (c *Config) validate() error {
+	if err := c.validateChannels(); err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if strings.TrimSpace(rule.Name) == "" {
+			return fmt.Errorf("alerting rule %d: name is required", i+1)
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("alerting rule '%s': duplicate rule name", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		if rule.Kind != KindApplication && rule.Kind != KindCluster {
+			return fmt.Errorf("alerting rule '%s': kind must be '%s' or '%s'", rule.Name, KindApplication, KindCluster)
+		}
+		if strings.TrimSpace(rule.Status) == "" {
+			return fmt.Errorf("alerting rule '%s': status is required", rule.Name)
+		}
+		status, err := parseStatus(rule.Kind, rule.Status)
+		if err != nil {
+			return fmt.Errorf("alerting rule '%s': %w", rule.Name, err)
+		}
+		rule.Status = status
+		if rule.For != "" {
+			d, err := time.ParseDuration(rule.For)
+			if err != nil {
+				return fmt.Errorf("alerting rule '%s': invalid 'for' duration: %w", rule.Name, err)
+			}
+			rule.forDuration = d
+		}
+		if rule.Consecutive < 0 {
+			return fmt.Errorf("alerting rule '%s': consecutive must not be negative", rule.Name)
+		}
+		if rule.forDuration == 0 && rule.Consecutive == 0 {
+			return fmt.Errorf("alerting rule '%s': at least one of 'for' or 'consecutive' is required", rule.Name)
+		}
+	}
+	return nil
+}
+
+// validateChannels checks the channels for errors and parses their delivery policies.
+func (c *Config) validateChannels() error {
+	seen := make(map[string]bool)
+	for i := range c.Channels {
+		channel := &c.Channels[i]
+		if strings.TrimSpace(channel.Name) == "" {
+			return fmt.Errorf("notification channel %d: name is required", i+1)
+		}
+		if seen[channel.Name] {
+			return fmt.Errorf("notification channel '%s': duplicate channel name", channel.Name)
+		}
+		seen[channel.Name] = true
+		destinations := 0
+		for _, set := range []bool{strings.TrimSpace(channel.Webhook) != "", channel.Email != nil, channel.PagerDuty != nil, channel.Opsgenie != nil, channel.Teams != nil, channel.Mattermost != nil} {
+			if set {
+				destinations++
+			}
+		}
+		if destinations != 1 {
+			return fmt.Errorf("notification channel '%s': exactly one of webhook, email, pagerduty, opsgenie, teams or mattermost is required", channel.Name)
+		}
+		var err error
+		switch {
+		case channel.Email != nil:
+			channel.notifier, err = notify.NewEmailNotifier(channel.Email.Merge(c.SMTP))
+		case channel.PagerDuty != nil:
+			channel.notifier, err = notify.NewPagerDutyNotifier(*channel.PagerDuty)
+		case channel.Opsgenie != nil:
+			channel.notifier, err = notify.NewOpsgenieNotifier(*channel.Opsgenie)
+		case channel.Teams != nil:
+			channel.notifier, err = notify.NewTeamsNotifier(*channel.Teams, c.ExternalURL)
+		case channel.Mattermost != nil:
+			channel.notifier, err = notify.NewMattermostNotifier(*channel.Mattermost, c.ExternalURL)
+		default:
+			channel.notifier = notify.NewWebhookNotifier(channel.Webhook)
+		}
+		if err != nil {
+			return fmt.Errorf("notification channel '%s': %w", channel.Name, err)
+		}
+
+		if channel.Digest != "" {
+			if channel.policy.Digest, err = time.ParseDuration(channel.Digest); err != nil || channel.policy.Digest <= 0 {
+				return fmt.Errorf("notification channel '%s': invalid 'digest' interval '%s'", channel.Name, channel.Digest)
+			}
+		}
+		if channel.Dedup != "" {
+			if channel.policy.Dedup, err = time.ParseDuration(channel.Dedup); err != nil || channel.policy.Dedup <= 0 {
+				return fmt.Errorf("notification channel '%s': invalid 'dedup' window '%s'", channel.Name, channel.Dedup)
+			}
+		}
+		if channel.QuietHours != nil {
+			if err := channel.QuietHours.Validate(); err != nil {
+				return fmt.Errorf("notification channel '%s': %w", channel.Name, err)
+			}
+			channel.policy.QuietHours = channel.QuietHours
+		}
+	}
+	return nil
+}
+
+// parseStatus returns the canonical spelling of an application or cluster status, ignoring case.
+func parseStatus(kind, status string) (string, error) {
+	if kind == KindApplication {
+		s, err := app.ParseStatus(status)
+		return string(s), err
+	}
+	s, err := cluster.ParseStatus(status)
+	return string(s), err
+}
+
+// matches reports whether the rule applies to the given subject.
+func (r *Rule) matches(s Subject) bool {
+	if r.Kind != s.Kind {
+		return false
+	}
+	return r.Target == "" || r.Target == s.Name
+}
+
+// Subject is the observed state of an application or cluster used to evaluate rules.
+type Subject struct {
+	// Kind is the kind of the object ("Application" or "Cluster").
+	Kind string
+	// Name is the name of the object.
+	Name string
+	// Status is the object's current status.
+	Status string
+	// Message is the object's current status message.
+	Message string
+	// Since is when the object entered its current status, if known.
+	Since time.Time
+	// Consecutive is the number of consecutive observations in the current status.
+	Consecutive int
+	// Conditions are the object's current conditions.
+	Conditions []common.Condition
+}
+
+// conditionFor builds the Alerting condition for a subject from the rules currently firing for it.
+func conditionFor(firing []*Rule, s Subject, now time.Time) common.Condition {
+	if len(firing) == 0 {
+		return common.Condition{
+			Type:               common.ConditionAlerting,
+			Status:             "False",
+			LastTransitionTime: now,
+		}
+	}
+
+	names := make([]string, len(firing))
+	for i, rule := range firing {
+		names[i] = rule.Name
+	}
+	return common.Condition{
+		Type:               common.ConditionAlerting,
+		Status:             "True",
+		Reason:             firing[0].Name,
+		Message:            fmt.Sprintf("%s '%s' is %s (rules: %s): %s", s.Kind, s.Name, s.Status, strings.Join(names, ", "), s.Message),
+		LastTransitionTime: now,
+	}
+}
@@ -0,0 +1,207 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/notify"
+	"go.uber.org/zap"
+)
+
+// Result is the outcome of evaluating the rules for a single subject.
+type Result struct {
+	// Subject is the evaluated object.
+	Subject Subject
+	// Condition is the Alerting condition that should be set on the object.
+	Condition common.Condition
+	// Notifications are the alerts that fired or resolved during this evaluation.
+	Notifications []notify.Notification
+}
+
+// Engine evaluates alerting rules and sends notifications when they fire or resolve.
+//
+// It keeps track of when each object was first observed in its current status, so that
+// duration-based rules work for objects whose history does not record the transition.
+type Engine struct {
+	logger   *zap.Logger
+	rules    []Rule
+	notifier notify.Notifier
+	channels []*notify.Channel
+
+	mu        sync.Mutex
+	firstSeen map[string]observation
+	firing    map[string]map[string]bool
+}
+
+// observation records when an object was first seen in a status.
+type observation struct {
+	status string
+	since  time.Time
+}
+
+// NewEngine creates an alerting engine for the given rules.
+// Notifications are delivered through notifier.
+func NewEngine(logger *zap.Logger, rules []Rule, notifier notify.Notifier) *Engine {
+	return &Engine{
+		logger:    logger,
+		rules:     rules,
+		notifier:  notifier,
+		firstSeen: make(map[string]observation),
+		firing:    make(map[string]map[string]bool),
+	}
+}
+
+// NewEngineFromConfig creates an alerting engine from a loaded configuration.
+// Notifications are always logged as they happen, are posted to each configured webhook, and are
+// delivered to each configured webhook or email channel as its digest, deduplication and quiet hours allow.
+func NewEngineFromConfig(logger *zap.Logger, config *Config) *Engine {
+	notifiers := notify.Multi{notify.NewLogNotifier(logger)}
+	for _, url := range config.Webhooks {
+		notifiers = append(notifiers, notify.NewWebhookNotifier(url))
+	}
+	var channels []*notify.Channel
+	for _, ch := range config.Channels {
+		channel := notify.NewChannel(logger, ch.Name, ch.notifier, ch.policy)
+		channels = append(channels, channel)
+		notifiers = append(notifiers, channel)
+	}
+	engine := NewEngine(logger, config.Rules, notifiers)
+	engine.channels = channels
+	return engine
+}
+
+// Run delivers the notifications held back by the engine's channels until ctx is cancelled.
+func (e *Engine) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, channel := range e.channels {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			channel.Run(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// Evaluate evaluates all rules against the given subjects.
+//
+// A result is returned for every subject that a rule applies to, or that still carries an
+// Alerting condition from an earlier evaluation. Notifications for rules that started firing
+// or resolved are sent in the background. State for objects no longer present is discarded.
+func (e *Engine) Evaluate(subjects []Subject, now time.Time) []Result {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	present := make(map[string]bool, len(subjects))
+	var results []Result
+	for _, s := range subjects {
+		objectKey := s.Kind + "/" + s.Name
+		present[objectKey] = true
+
+		seen, ok := e.firstSeen[objectKey]
+		if !ok || seen.status != s.Status {
+			seen = observation{status: s.Status, since: now}
+			e.firstSeen[objectKey] = seen
+		}
+		if e.firing[objectKey] == nil {
+			e.firing[objectKey] = make(map[string]bool)
+		}
+		since := seen.since
+		if !s.Since.IsZero() && s.Since.Before(since) {
+			since = s.Since
+		}
+
+		var (
+			matched       bool
+			firing        []*Rule
+			notifications []notify.Notification
+		)
+		for i := range e.rules {
+			rule := &e.rules[i]
+			if !rule.matches(s) {
+				continue
+			}
+			matched = true
+
+			active := s.Status == rule.Status &&
+				now.Sub(since) >= rule.forDuration &&
+				s.Consecutive >= rule.Consecutive
+
+			switch {
+			case active && !e.firing[objectKey][rule.Name]:
+				e.firing[objectKey][rule.Name] = true
+				notifications = append(notifications, notify.Notification{
+					Rule:    rule.Name,
+					State:   notify.StateFiring,
+					Kind:    s.Kind,
+					Name:    s.Name,
+					Status:  rule.Status,
+					Message: fmt.Sprintf("%s '%s' has been %s since %s: %s", s.Kind, s.Name, s.Status, since.Format(time.RFC3339), s.Message),
+					Time:    now,
+				})
+			case !active && e.firing[objectKey][rule.Name]:
+				delete(e.firing[objectKey], rule.Name)
+				notifications = append(notifications, notify.Notification{
+					Rule:    rule.Name,
+					State:   notify.StateResolved,
+					Kind:    s.Kind,
+					Name:    s.Name,
+					Status:  rule.Status,
+					Message: fmt.Sprintf("%s '%s' is now %s", s.Kind, s.Name, s.Status),
+					Time:    now,
+				})
+			}
+			if active {
+				firing = append(firing, rule)
+			}
+		}
+
+		if !matched && common.FindCondition(s.Conditions, common.ConditionAlerting) == nil {
+			continue
+		}
+		results = append(results, Result{
+			Subject:       s,
+			Condition:     conditionFor(firing, s, now),
+			Notifications: notifications,
+		})
+		for _, n := range notifications {
+			e.send(n)
+		}
+	}
+
+	for objectKey := range e.firstSeen {
+		if !present[objectKey] {
+			delete(e.firstSeen, objectKey)
+			delete(e.firing, objectKey)
+		}
+	}
+
+	return results
+}
+
+// Notify sends a notification that is not tied to an alerting rule, such as an application
+// being suspended. It is a no-op on a nil engine.
+func (e *Engine) Notify(n notify.Notification) {
+	if e == nil {
+		return
+	}
+	e.send(n)
+}
+
+// send delivers a notification in the background so that slow destinations do not block the controller.
+func (e *Engine) send(n notify.Notification) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notify.DefaultWebhookTimeout)
+		defer cancel()
+		if err := e.notifier.Notify(ctx, n); err != nil {
+			e.logger.Error("Failed to deliver alert notification",
+				zap.String("rule", n.Rule),
+				zap.String("kind", n.Kind),
+				zap.String("name", n.Name),
+				zap.Error(err))
+		}
+	}()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
@@ -14,8 +15,323 @@ import (
 const (
 	// DefaultAppConfigFile is the default path to store registered applications
 	DefaultAppConfigFile = "configs/applications.json"
+	// MaxSyncHistory is the number of most recent sync events retained per application.
+	MaxSyncHistory = 10
+	// MaxLintFindings is the number of lint findings retained per application.
+	MaxLintFindings = 100
+	// DefaultTerraformPlanDir is the default directory pending Terraform plans are stored in, one file per application.
+	DefaultTerraformPlanDir = "configs/terraform"
+	// DefaultRevisionDir is the default directory the manifests of the last healthy revision of each
+	// two-phase application are kept in, one directory per application, so they can be rolled back to.
+	DefaultRevisionDir = "configs/revisions"
+	// DefaultHealthTimeout is how long a two-phase sync waits for applied resources to become healthy.
+	DefaultHealthTimeout = 5 * time.Minute
+	// AdaptiveIntervalGrowth is the factor the polling interval of an application with adaptive
+	// polling grows by with each poll that finds no new commits.
+	AdaptiveIntervalGrowth = 2
+
+	// TypeKubernetes is the type of applications whose manifests are applied to a Kubernetes cluster.
+	TypeKubernetes = "kubernetes"
+	// TypeTerraform is the type of applications whose path is a Terraform or OpenTofu configuration.
+	// Each new commit is planned; a plan with changes is only applied once it is approved.
+	TypeTerraform = "terraform"
+)
+
+// Types lists the application types.
+var Types = []string{TypeKubernetes, TypeTerraform}
+
+const (
+	// SyncModeApply applies each new revision and considers it synced once it is applied.
+	SyncModeApply = "apply"
+	// SyncModeTwoPhase applies each new revision, then waits for the applied resources to become healthy
+	// and rolls back to the previous revision if they do not within the application's health timeout.
+	SyncModeTwoPhase = "two-phase"
 )
 
+// SyncModes lists the sync modes.
+var SyncModes = []string{SyncModeApply, SyncModeTwoPhase}
+
+// SyncEvent records the outcome of a single synchronization attempt.
+type SyncEvent struct {
+	// Time is when the sync attempt finished.
+	Time time.Time `json:"time"`
+	// Status is the resulting application status (e.g., "Synced", "Error").
+	Status Status `json:"status"`
+	// GitHash is the commit that was being synced, if known.
+	GitHash string `json:"gitHash,omitempty"`
+	// Message describes the outcome of the sync attempt.
+	Message string `json:"message,omitempty"`
+	// Actor is who requested the sync, empty for syncs the controller started on its own.
+	Actor string `json:"actor,omitempty"`
+	// Reason is why the sync was requested, as given by the actor.
+	Reason string `json:"reason,omitempty"`
+	// RequestID is the ID of the API request that requested the sync, if any.
+	RequestID string `json:"requestID,omitempty"`
+}
+
+// Trigger identifies who requested an operation on an application and why.
+type Trigger struct {
+	// Actor is who requested the operation (see audit.Entry).
+	Actor string
+	// Reason is why the operation was requested, if given.
+	Reason string
+	// RequestID is the ID of the API request that requested the operation, if any, so that the logs
+	// of the operation can be correlated with the request.
+	RequestID string
+}
+
+// String describes the trigger for event and status messages, e.g. "alice (reason: hotfix)".
+func (t Trigger) String() string {
+	actor := t.Actor
+	if actor == "" {
+		actor = "an operator"
+	}
+	if t.Reason == "" {
+		return actor
+	}
+	return fmt.Sprintf("%s (reason: %s)", actor, t.Reason)
+}
+
+// ManagedResource identifies a Kubernetes resource applied by the controller for an application.
+type ManagedResource struct {
+	// Kind is the Kubernetes kind of the resource (e.g., Deployment).
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the resource, empty for cluster-scoped resources.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+}
+
+// ResourceSyncResult is the outcome of applying one Kubernetes resource during a sync.
+type ResourceSyncResult struct {
+	ManagedResource
+	// Action is what the sync did to the resource: created, updated, unchanged or failed.
+	Action string `json:"action"`
+	// Error is why the resource could not be applied, set only when Action is failed.
+	Error string `json:"error,omitempty"`
+}
+
+// HelmSource holds the values a Helm chart is rendered with when the application's Renderer is "helm".
+type HelmSource struct {
+	// ValuesFiles are values files, relative to Path, applied in order. Later files take precedence.
+	ValuesFiles []string `json:"valuesFiles,omitempty"`
+	// Values are inline values applied after ValuesFiles, taking precedence over them.
+	Values map[string]any `json:"values,omitempty"`
+	// Namespace is the release namespace the chart is rendered with and the release is recorded in.
+	// Empty means "default".
+	Namespace string `json:"namespace,omitempty"`
+	// RecordRelease records each sync as a revision of a Helm release named after the application,
+	// so helm list shows it. An existing release with that name is adopted.
+	RecordRelease bool `json:"recordRelease,omitempty"`
+}
+
+const (
+	// APIDeprecationWarn reports manifests using deprecated API versions without stopping the sync.
+	APIDeprecationWarn = "warn"
+	// APIDeprecationBlock stops the sync of manifests using deprecated API versions.
+	APIDeprecationBlock = "block"
+)
+
+// APIDeprecationPolicies lists the supported policies for manifests using deprecated API versions.
+var APIDeprecationPolicies = []string{APIDeprecationWarn, APIDeprecationBlock}
+
+// DeprecatedAPI is a manifest using an API version deprecated in, or removed from, the Kubernetes
+// version of the application's cluster.
+type DeprecatedAPI struct {
+	// Resource is the resource the manifest describes, as "Kind namespace/name".
+	Resource string `json:"resource"`
+	// File is the manifest file, relative to the rendered manifests.
+	File string `json:"file,omitempty"`
+	// APIVersion is the deprecated API version the manifest uses.
+	APIVersion string `json:"apiVersion"`
+	// DeprecatedIn is the Kubernetes version the API version was deprecated in.
+	DeprecatedIn string `json:"deprecatedIn"`
+	// RemovedIn is the Kubernetes version the API version was removed in.
+	RemovedIn string `json:"removedIn"`
+	// Replacement is the API version to use instead, empty if there is none.
+	Replacement string `json:"replacement,omitempty"`
+	// Removed reports whether the cluster no longer serves the API version.
+	Removed bool `json:"removed,omitempty"`
+}
+
+// LintConfig configures the linters an application's manifests are checked with before they are applied.
+type LintConfig struct {
+	// Linters are the linters run, in order: "kubeconform" checks the manifests against the
+	// Kubernetes schemas and "kube-score" scores them against best practices.
+	Linters []string `json:"linters"`
+	// Strict blocks the apply when a linter reports an error. Otherwise findings are only reported.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// LintFinding is a problem a linter found in an application's manifests.
+type LintFinding struct {
+	// Linter is the linter that reported the finding.
+	Linter string `json:"linter"`
+	// Severity is "error" or "warning".
+	Severity string `json:"severity"`
+	// Resource is the resource the finding is about, as "Kind namespace/name", if the linter names one.
+	Resource string `json:"resource,omitempty"`
+	// File is the manifest file the resource was read from, relative to the rendered manifests.
+	File string `json:"file,omitempty"`
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// Source is an additional repository an application is composed from, such as a repository of
+// environment-specific Helm values next to the chart's repository.
+type Source struct {
+	// Name identifies the source within the application. Helm values files refer to the source's
+	// files as "$Name/<path>".
+	Name string `json:"name"`
+	// RepoURL is the URL of the source's Git repository.
+	RepoURL string `json:"repoURL"`
+	// Branch is the branch of the repository that is tracked.
+	Branch string `json:"branch"`
+	// Path is a directory of plain YAML manifests in the repository that are applied with the
+	// application's. Empty when the source only provides files, such as Helm values.
+	Path string `json:"path,omitempty"`
+}
+
+// TerraformSource configures how a Terraform application's configuration is planned and applied.
+type TerraformSource struct {
+	// Binary is the command run to plan and apply, "terraform" (default) or "tofu" for OpenTofu.
+	Binary string `json:"binary,omitempty"`
+	// VarFiles are variable files, relative to Path, passed to plan in order.
+	VarFiles []string `json:"varFiles,omitempty"`
+	// Vars are input variables passed to plan, taking precedence over VarFiles.
+	Vars map[string]string `json:"vars,omitempty"`
+}
+
+// TerraformPlan summarizes the last Terraform plan with changes of an application.
+type TerraformPlan struct {
+	// ID identifies the plan; approving a plan names its ID, so a plan replaced in the meantime is never applied.
+	ID string `json:"id"`
+	// GitHash is the commit the plan was made at.
+	GitHash string `json:"gitHash"`
+	// Summary is the plan's summary, e.g. "Plan: 1 to add, 0 to change, 0 to destroy."
+	Summary string `json:"summary"`
+	// Add, Change and Destroy count the resources the plan creates, updates and deletes.
+	// A replaced resource counts as both added and destroyed.
+	Add     int `json:"add"`
+	Change  int `json:"change"`
+	Destroy int `json:"destroy"`
+	// PlannedAt is when the plan was made.
+	PlannedAt time.Time `json:"plannedAt"`
+	// ApprovedAt is when the plan was approved, zero while it awaits approval.
+	ApprovedAt time.Time `json:"approvedAt,omitempty"`
+	// AppliedAt is when the plan was applied, zero until it is.
+	AppliedAt time.Time `json:"appliedAt,omitempty"`
+}
+
+// IsPending reports whether the plan awaits approval or, once approved, being applied.
+func (p *TerraformPlan) IsPending() bool {
+	return p != nil && p.AppliedAt.IsZero()
+}
+
+// PendingChange is a change to a Kubernetes application that requires approval, held back until it is approved.
+type PendingChange struct {
+	// ID identifies the change; approving a change names its ID, so a change replaced in the meantime is never applied.
+	ID string `json:"id"`
+	// GitHash is the commit the change was detected at.
+	GitHash string `json:"gitHash"`
+	// ValuesDigest is the digest of the Helm values the change was detected with, if any.
+	ValuesDigest string `json:"valuesDigest,omitempty"`
+	// Summary counts the resources the change creates and updates, e.g. "1 to create, 2 to update."
+	Summary string `json:"summary"`
+	// Diff shows how each resource changes, as a line diff of its YAML against the live resource.
+	Diff string `json:"diff"`
+	// DetectedAt is when the change was detected.
+	DetectedAt time.Time `json:"detectedAt"`
+	// ApprovedAt is when the change was approved, zero while it awaits approval.
+	ApprovedAt time.Time `json:"approvedAt,omitempty"`
+}
+
+// Rollout is the progress of a canary or blue-green rollout of an application, driven by an
+// Argo Rollouts Rollout or a Flagger Canary among its managed resources.
+type Rollout struct {
+	// Kind is the kind of the rollout resource, Rollout or Canary.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the rollout resource.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the rollout resource.
+	Name string `json:"name"`
+	// Provider is the controller driving the rollout, "argo-rollouts" or "flagger".
+	Provider string `json:"provider"`
+	// Phase is the phase reported by the provider (e.g., Progressing, Paused, Healthy, Succeeded).
+	Phase string `json:"phase,omitempty"`
+	// Step is the number of canary steps completed, out of Steps. Both are zero for rollouts without steps.
+	Step int `json:"step,omitempty"`
+	// Steps is the total number of canary steps.
+	Steps int `json:"steps,omitempty"`
+	// Weight is the percentage of traffic sent to the canary.
+	Weight int `json:"weight"`
+	// Paused reports whether the rollout waits to be promoted.
+	Paused bool `json:"paused,omitempty"`
+	// Message is the provider's explanation of the phase, if any.
+	Message string `json:"message,omitempty"`
+}
+
+// Progress describes how far the rollout got, e.g. "step 2/4, 20% canary, paused".
+func (r Rollout) Progress() string {
+	progress := fmt.Sprintf("%d%% canary", r.Weight)
+	if r.Steps > 0 {
+		progress = fmt.Sprintf("step %d/%d, %s", r.Step, r.Steps, progress)
+	}
+	if r.Paused {
+		progress += ", paused"
+	}
+	return progress
+}
+
+// RepositoryStats tracks how long fetching an application's repository takes and how large its
+// clone is, to find repositories that slow reconciliation down.
+type RepositoryStats struct {
+	// LastCloneDuration is how long the last clone took.
+	LastCloneDuration time.Duration `json:"lastCloneDuration,omitempty"`
+	// LastClonedAt is when the repository was last cloned.
+	LastClonedAt time.Time `json:"lastClonedAt,omitempty"`
+	// LastPullDuration is how long the last pull took, including pulls that found no changes.
+	LastPullDuration time.Duration `json:"lastPullDuration,omitempty"`
+	// LastPulledAt is when the repository was last pulled.
+	LastPulledAt time.Time `json:"lastPulledAt,omitempty"`
+	// Clones is the number of clones since the application was registered.
+	Clones int `json:"clones,omitempty"`
+	// Pulls is the number of pulls since the application was registered.
+	Pulls int `json:"pulls,omitempty"`
+	// LastCheckDuration is how long the last listing of the remote's references that made a pull
+	// unnecessary took.
+	LastCheckDuration time.Duration `json:"lastCheckDuration,omitempty"`
+	// LastCheckedAt is when a listing of the remote's references last made a pull unnecessary.
+	LastCheckedAt time.Time `json:"lastCheckedAt,omitempty"`
+	// Checks is the number of polls that skipped the pull because the remote branch had not moved.
+	Checks int `json:"checks,omitempty"`
+	// FetchFailures is the number of failed clones and pulls since the application was registered.
+	FetchFailures int `json:"fetchFailures,omitempty"`
+	// SizeBytes is the size of the clone on disk, including its worktree.
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+	// Objects is the number of Git objects in the clone.
+	Objects int `json:"objects,omitempty"`
+	// MeasuredRevision is the commit the clone was at when its size was last measured.
+	MeasuredRevision string `json:"measuredRevision,omitempty"`
+	// Revision is the commit the clone was at after the last successful fetch.
+	Revision string `json:"revision,omitempty"`
+}
+
+// LastFetchDuration returns how long the most recent successful clone or pull took.
+func (s *RepositoryStats) LastFetchDuration() time.Duration {
+	if s.LastClonedAt.After(s.LastPulledAt) {
+		return s.LastCloneDuration
+	}
+	return s.LastPullDuration
+}
+
+// Placement chooses the cluster an application is deployed to among a fleet.
+type Placement struct {
+	// ClusterSelector lists the labels a cluster must have for the application to be placed on it.
+	// Empty matches every cluster.
+	ClusterSelector map[string]string `json:"clusterSelector,omitempty"`
+}
+
 // Application represents a single GitOps application managed by the controller.
 // It encapsulates all the necessary metadata and operational details required
 // to monitor and synchronize the application's state between Git and Kubernetes.
@@ -23,11 +339,15 @@ type Application struct {
 	// Name is a unique identifier for the application.
 	// It must be unique across all registered applications and should follow
 	// DNS subdomain naming conventions for compatibility with Kubernetes resources.
-	Name string `json:"name"`
+	Name string `json:"name" schema:"required"`
+
+	// Labels group applications, for example by team or environment, so that bulk commands such as
+	// 'app sync --selector' can act on them together.
+	Labels map[string]string `json:"labels,omitempty"`
 
 	// RepoURL specifies the URL of the Git repository where the application's manifests are stored.
 	// This URL can be HTTPS or SSH-based, depending on the user's authentication setup.
-	RepoURL string `json:"repoURL"`
+	RepoURL string `json:"repoURL" schema:"required"`
 
 	// Branch defines the Git branch to monitor for changes.
 	// The controller will track this branch for updates and apply changes accordingly.
@@ -37,25 +357,168 @@ type Application struct {
 	// This allows users to organize multiple applications or environments within a single repository.
 	Path string `json:"path"`
 
+	// Paths lists additional paths or glob patterns in the repository whose manifests are combined
+	// with Path's into a single apply set, for manifests split across directories. When it is set,
+	// Exclude patterns containing a slash are matched against paths relative to the repository root.
+	Paths []string `json:"paths,omitempty"`
+
+	// Sources are additional repositories the application is composed from. Each is fetched on
+	// every sync, and a new commit on any of them triggers a sync.
+	Sources []Source `json:"sources,omitempty"`
+
+	// Submodules initializes and updates the repository's submodules, recursively, on every fetch,
+	// for manifests that live in or refer to them.
+	Submodules bool `json:"submodules,omitempty"`
+
+	// LFS fetches the Git LFS objects of the synced commit, so files tracked with Git LFS hold their
+	// content rather than pointers. It needs the git and git-lfs binaries.
+	LFS bool `json:"lfs,omitempty"`
+
 	// ClusterName is the name of the Kubernetes cluster where the application will be deployed.
-	// This name is used for logging and status reporting purposes.
+	// This name is used for logging and status reporting purposes. It is empty for Terraform applications.
 	ClusterName string `json:"clusterName"`
 
+	// Placement chooses ClusterName automatically among the clusters matching a selector, and moves
+	// the application to another one when its cluster becomes unhealthy. Nil keeps ClusterName as set.
+	Placement *Placement `json:"placement,omitempty"`
+
+	// ImpersonateUser is the user the application's resources are applied as, with the controller's
+	// credentials for the cluster impersonating it, so that cluster RBAC limits what the application
+	// can change. Empty uses the cluster's ImpersonateUser, if any.
+	ImpersonateUser string `json:"impersonateUser,omitempty"`
+
+	// ImpersonateGroups are the groups impersonated along with ImpersonateUser.
+	ImpersonateGroups []string `json:"impersonateGroups,omitempty"`
+
+	// Type is the kind of configuration under Path: "kubernetes" (default) manifests, or a
+	// "terraform" configuration that is planned, and only applied once a plan is approved.
+	Type string `json:"type,omitempty"`
+
+	// Terraform configures how the configuration is planned when Type is "terraform".
+	Terraform *TerraformSource `json:"terraform,omitempty"`
+
+	// TerraformPlan is the last plan with changes of a Terraform application.
+	TerraformPlan *TerraformPlan `json:"terraformPlan,omitempty"`
+
+	// Renderer selects how manifests under Path are produced: "yaml" (default), "jsonnet", "ytt",
+	// or the name of a configured manifest generator plugin.
+	// Non-YAML sources are rendered by the controller before they are applied.
+	Renderer string `json:"renderer,omitempty"`
+
+	// PluginParams are passed to the manifest generator plugin when Renderer names a plugin.
+	PluginParams map[string]string `json:"pluginParams,omitempty"`
+
+	// Helm holds the chart's values when Renderer is "helm". Values files and inline values may
+	// refer to the controller's environment variables as ${NAME} or ${NAME:-default}.
+	Helm *HelmSource `json:"helm,omitempty"`
+
+	// ApplyStrategy controls how manifest failures are handled during a sync: "best-effort" (default)
+	// applies everything it can, "fail-fast" stops at the first failure, and "atomic" dry-runs all
+	// manifests first and applies nothing unless every one of them passes.
+	ApplyStrategy string `json:"applyStrategy,omitempty"`
+
+	// Exclude lists glob patterns, relative to Path, of manifest files or directories that are not applied.
+	// Individual resources can also be skipped with the "gitopsctl.io/ignore: \"true\"" annotation.
+	Exclude []string `json:"exclude,omitempty"`
+
+	// DefaultNamespace is the namespace namespaced resources whose manifest does not set one are
+	// applied to. Empty means "default".
+	DefaultNamespace string `json:"defaultNamespace,omitempty"`
+
+	// RequireNamespace fails the sync of namespaced resources whose manifest does not set a namespace,
+	// instead of applying them to the default namespace.
+	RequireNamespace bool `json:"requireNamespace,omitempty"`
+
+	// Lint checks the manifests with linters before they are validated against the cluster and
+	// applied. Nil means they are not linted.
+	Lint *LintConfig `json:"lint,omitempty"`
+
+	// APIDeprecationPolicy is what happens to a sync whose manifests use API versions deprecated in
+	// the cluster's Kubernetes version: "warn" (default) reports them and "block" applies nothing.
+	// API versions the cluster no longer serves always stop the sync, as they cannot be applied.
+	APIDeprecationPolicy string `json:"apiDeprecationPolicy,omitempty"`
+
+	// Substitute replaces ${NAME} references in the manifests, after they are rendered, with the
+	// built-in variables CLUSTER_NAME and APP_NAME, the cluster's Variables and the application's
+	// Variables. Setting Variables also enables substitution.
+	Substitute bool `json:"substitute,omitempty"`
+
+	// Variables are substituted into the manifests, taking precedence over the cluster's variables.
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// ConfigHash annotates the pod templates of Deployments, StatefulSets and DaemonSets with a hash
+	// of the ConfigMaps and Secrets in the manifests they reference, so a commit that only changes
+	// their data rolls the pods that use them.
+	ConfigHash bool `json:"configHash,omitempty"`
+
+	// ApprovalRequired holds back detected changes until they are approved: the application moves to
+	// AwaitingApproval with the change's diff in PendingChange, and only an approval applies it.
+	// Terraform applications always require approval and ignore this field.
+	ApprovalRequired bool `json:"approvalRequired,omitempty"`
+
+	// PendingChange is the change of an application requiring approval that has not been applied yet.
+	PendingChange *PendingChange `json:"pendingChange,omitempty"`
+
+	// SyncMode is "apply" (default) or "two-phase": a two-phase sync waits for the applied resources to
+	// become healthy and rolls back to the previous revision if they do not within HealthTimeout.
+	SyncMode string `json:"syncMode,omitempty"`
+
+	// HealthTimeout is how long a two-phase sync waits for applied resources to become healthy, as a
+	// duration string (e.g., "10m"). Empty means DefaultHealthTimeout.
+	HealthTimeout string `json:"healthTimeout,omitempty" schema:"duration"`
+
+	// UnhealthyGitHash is the commit of the last two-phase sync whose resources did not become healthy.
+	// It is not applied again, with the same Helm values, until the application is reset.
+	UnhealthyGitHash string `json:"unhealthyGitHash,omitempty"`
+
+	// UnhealthyValuesDigest is the Helm values digest UnhealthyGitHash was applied with.
+	UnhealthyValuesDigest string `json:"unhealthyValuesDigest,omitempty"`
+
+	// RollbackGitHash is the commit of the healthy revision synced before LastSyncedGitHash by a
+	// two-phase sync, whose manifests are kept in PreviousRevisionDir for an operator to roll back
+	// to. Empty when no previous revision is kept.
+	RollbackGitHash string `json:"rollbackGitHash,omitempty"`
+
+	// PartialGitHash is the commit of the last sync that failed after applying some of the resources,
+	// whose outcome is in LastSyncResources. The next sync of that commit, with the same Helm values,
+	// applies only the resources that were not applied. Empty when the last apply did not fail part way.
+	PartialGitHash string `json:"partialGitHash,omitempty"`
+
+	// PartialValuesDigest is the Helm values digest PartialGitHash was applied with.
+	PartialValuesDigest string `json:"partialValuesDigest,omitempty"`
+
 	// Interval is the polling interval as a string (e.g., "5m", "30s").
 	// It defines how frequently the controller should check the Git repository for changes.
-	Interval string `json:"interval"`
+	Interval string `json:"interval" schema:"required,duration"`
 
 	// PollingInterval is the parsed duration of the Interval field for internal use.
 	// This field is not serialized into JSON and is used for efficient time-based operations.
 	PollingInterval time.Duration `json:"-"`
 
+	// MaxInterval enables adaptive polling when set (e.g., "1h"): while the repository has no new
+	// commits, the polling interval grows from Interval up to MaxInterval, and it returns to
+	// Interval as soon as new commits are found.
+	MaxInterval string `json:"maxInterval,omitempty" schema:"duration"`
+
+	// AdaptiveInterval is the current polling interval of an application with adaptive polling.
+	// Zero means Interval.
+	AdaptiveInterval time.Duration `json:"adaptiveInterval,omitempty"`
+
 	// LastSyncedGitHash stores the Git commit hash of the last successfully synchronized state.
 	// This helps the controller detect changes and avoid redundant operations.
 	LastSyncedGitHash string `json:"lastSyncedGitHash,omitempty"`
 
+	// LastSyncedValuesDigest is the digest of the Helm values, after environment variable substitution,
+	// and of the revisions of Sources, of the last successful sync. A change to either triggers a sync
+	// even if the Git hash is unchanged.
+	LastSyncedValuesDigest string `json:"lastSyncedValuesDigest,omitempty"`
+
+	// SourceRevisions maps the name of each of Sources to the commit hash it was last synced at.
+	SourceRevisions map[string]string `json:"sourceRevisions,omitempty"`
+
 	// Status represents the current operational state of the application.
-	// Possible values include "Running", "Error", "Synced", "Pending", etc.
-	Status string `json:"status,omitempty"`
+	// See Statuses for the possible values and CanTransition for the allowed changes.
+	Status Status `json:"status,omitempty"`
 
 	// Message provides additional context about the application's current state.
 	// It can include error details, success messages, or other relevant information.
@@ -64,6 +527,333 @@ type Application struct {
 	// ConsecutiveFailures tracks the number of consecutive synchronization failures.
 	// This can be used to implement backoff logic or alerting mechanisms.
 	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+
+	// LastSyncAt is the time the controller last attempted to reconcile the application.
+	LastSyncAt time.Time `json:"lastSyncAt,omitempty"`
+
+	// LastSyncStartedAt is the time the last sync attempt started.
+	LastSyncStartedAt time.Time `json:"lastSyncStartedAt,omitempty"`
+
+	// LastSyncFinishedAt is the time the last sync attempt finished.
+	LastSyncFinishedAt time.Time `json:"lastSyncFinishedAt,omitempty"`
+
+	// LastSyncDuration is how long the last sync attempt took.
+	LastSyncDuration time.Duration `json:"lastSyncDuration,omitempty"`
+
+	// NextSyncAt is the time the controller is scheduled to reconcile the application next.
+	NextSyncAt time.Time `json:"nextSyncAt,omitempty"`
+
+	// EffectiveInterval is the current interval between reconciliations, including any backoff.
+	EffectiveInterval string `json:"effectiveInterval,omitempty" schema:"duration"`
+	// RateLimitedUntil is when the Git provider accepts requests again after rejecting a fetch of
+	// the repository for exceeding a rate limit. Zero when the last fetch was not rate limited.
+	RateLimitedUntil time.Time `json:"rateLimitedUntil,omitempty"`
+
+	// SyncHistory holds the most recent sync events, oldest first.
+	// It is capped at MaxSyncHistory entries.
+	SyncHistory []SyncEvent `json:"syncHistory,omitempty"`
+
+	// Repository tracks the fetches and the clone of the application's repository.
+	Repository *RepositoryStats `json:"repository,omitempty"`
+
+	// SyncTrigger is who requested the sync in progress, recorded with its sync event. It is only
+	// set by the controller while a manually requested sync runs, and is never persisted.
+	SyncTrigger *Trigger `json:"-"`
+	// SyncCommitTime is when the commit of the sync in progress was made, if the application has not
+	// synced that commit yet, and is recorded with its sync event. It is only set by the controller
+	// while the sync runs, and is never persisted.
+	SyncCommitTime time.Time `json:"-"`
+
+	// ManagedResources lists the Kubernetes resources applied during the last successful sync.
+	ManagedResources []ManagedResource `json:"managedResources,omitempty"`
+
+	// LastSyncResources is the outcome of each resource the last sync that applied manifests
+	// attempted, in manifest order.
+	LastSyncResources []ResourceSyncResult `json:"lastSyncResources,omitempty"`
+
+	// LintFindings are the problems the linters found in the manifests of the last sync that linted
+	// them, at most MaxLintFindings.
+	LintFindings []LintFinding `json:"lintFindings,omitempty"`
+
+	// DeprecatedAPIs are the manifests of the last sync that used API versions deprecated in, or
+	// removed from, the cluster's Kubernetes version.
+	DeprecatedAPIs []DeprecatedAPI `json:"deprecatedAPIs,omitempty"`
+
+	// Rollouts is the progress of the canary rollouts among the managed resources, as of the last sync.
+	Rollouts []Rollout `json:"rollouts,omitempty"`
+
+	// Conditions describe additional aspects of the application's state, such as whether it is alerting.
+	Conditions []common.Condition `json:"conditions,omitempty"`
+}
+
+// MatchesSearch reports whether the application's name, repository URL or message contains term,
+// ignoring case. An empty term matches every application.
+func (a *Application) MatchesSearch(term string) bool {
+	return common.MatchesSearch(term, a.Name, a.RepoURL, a.Message)
+}
+
+// IsTerraform reports whether the application is a Terraform configuration rather than Kubernetes manifests.
+func (a *Application) IsTerraform() bool {
+	return a.Type == TypeTerraform
+}
+
+// EffectiveImpersonation returns the user and groups the application's resources are applied as:
+// its own ImpersonateUser and ImpersonateGroups if it sets a user, or else those of its cluster.
+func (a *Application) EffectiveImpersonation(clusterUser string, clusterGroups []string) (string, []string) {
+	if a.ImpersonateUser != "" {
+		return a.ImpersonateUser, a.ImpersonateGroups
+	}
+	return clusterUser, clusterGroups
+}
+
+// IsTwoPhase reports whether the application's syncs wait for health and roll back unhealthy revisions.
+func (a *Application) IsTwoPhase() bool {
+	return a.SyncMode == SyncModeTwoPhase
+}
+
+// HealthTimeoutDuration returns how long a two-phase sync of the application waits for its resources to become healthy.
+func (a *Application) HealthTimeoutDuration() time.Duration {
+	if timeout, err := time.ParseDuration(a.HealthTimeout); err == nil && timeout > 0 {
+		return timeout
+	}
+	return DefaultHealthTimeout
+}
+
+// SubstitutesVariables reports whether variables are substituted into the application's manifests.
+func (a *Application) SubstitutesVariables() bool {
+	return a.Substitute || len(a.Variables) > 0
+}
+
+// SourcePaths returns all paths of the application's manifests: Path followed by Paths.
+func (a *Application) SourcePaths() []string {
+	return append([]string{a.Path}, a.Paths...)
+}
+
+// IsAdaptive reports whether the application's polling interval adapts to its repository's activity.
+func (a *Application) IsAdaptive() bool {
+	return a.MaxInterval != ""
+}
+
+// MaxIntervalDuration returns the longest polling interval of an application with adaptive polling.
+// It is never shorter than the application's interval.
+func (a *Application) MaxIntervalDuration() time.Duration {
+	if interval, err := time.ParseDuration(a.MaxInterval); err == nil && interval > a.PollingInterval {
+		return interval
+	}
+	return a.PollingInterval
+}
+
+// PollInterval returns the interval the application's repository is polled at when it has no failures:
+// its interval, or with adaptive polling the current adaptive interval.
+func (a *Application) PollInterval() time.Duration {
+	if !a.IsAdaptive() || a.AdaptiveInterval <= 0 {
+		return a.PollingInterval
+	}
+	return min(max(a.AdaptiveInterval, a.PollingInterval), a.MaxIntervalDuration())
+}
+
+// AdaptInterval adjusts the polling interval of an application with adaptive polling after its
+// repository was fetched: it returns to the application's interval if the fetch found new commits,
+// and otherwise grows by AdaptiveIntervalGrowth, up to the maximum interval.
+func (a *Application) AdaptInterval(changed bool) {
+	if !a.IsAdaptive() {
+		a.AdaptiveInterval = 0
+		return
+	}
+	if changed {
+		a.AdaptiveInterval = a.PollingInterval
+		return
+	}
+	a.AdaptiveInterval = min(a.PollInterval()*AdaptiveIntervalGrowth, a.MaxIntervalDuration())
+}
+
+// RevisionDir returns the directory the manifests of the last healthy revision of the named application are kept in.
+func RevisionDir(appName string) string {
+	return filepath.Join(DefaultRevisionDir, appName)
+}
+
+// PreviousRevisionDir returns the directory the manifests of the healthy revision before the last
+// one of the named application are kept in, for an operator to roll back to.
+func PreviousRevisionDir(appName string) string {
+	return RevisionDir(appName) + ".previous"
+}
+
+// TerraformPlanFile returns the path the pending Terraform plan of the named application is saved to.
+func TerraformPlanFile(appName string) string {
+	return filepath.Join(DefaultTerraformPlanDir, appName+".tfplan")
+}
+
+// RequiresApproval reports whether the application's changes are only applied once approved.
+func (a *Application) RequiresApproval() bool {
+	return a.IsTerraform() || a.ApprovalRequired
+}
+
+// ApprovePlan approves the application's pending Terraform plan or, for a Kubernetes application,
+// its pending change, which must have the given ID. The plan or change is replaced by an approved
+// copy, so copies of the application sharing it are unaffected.
+func (a *Application) ApprovePlan(planID string, now time.Time) error {
+	if !a.IsTerraform() {
+		return a.approveChange(planID, now)
+	}
+	plan := a.TerraformPlan
+	switch {
+	case !plan.IsPending():
+		return fmt.Errorf("application '%s' has no plan awaiting approval", a.Name)
+	case plan.ID != planID:
+		return fmt.Errorf("plan '%s' of application '%s' was replaced by plan '%s'", planID, a.Name, plan.ID)
+	case !plan.ApprovedAt.IsZero():
+		return fmt.Errorf("plan '%s' of application '%s' is already approved", planID, a.Name)
+	}
+	approved := *plan
+	approved.ApprovedAt = now
+	a.TerraformPlan = &approved
+	return nil
+}
+
+// approveChange approves the application's pending change, which must have the given ID.
+func (a *Application) approveChange(changeID string, now time.Time) error {
+	change := a.PendingChange
+	switch {
+	case change == nil:
+		return fmt.Errorf("application '%s' has no change awaiting approval", a.Name)
+	case change.ID != changeID:
+		return fmt.Errorf("change '%s' of application '%s' was replaced by change '%s'", changeID, a.Name, change.ID)
+	case !change.ApprovedAt.IsZero():
+		return fmt.Errorf("change '%s' of application '%s' is already approved", changeID, a.Name)
+	}
+	approved := *change
+	approved.ApprovedAt = now
+	a.PendingChange = &approved
+	return nil
+}
+
+// RecordSyncEvent appends a sync event to the application's history,
+// discarding the oldest entries beyond MaxSyncHistory.
+func (a *Application) RecordSyncEvent(event SyncEvent) {
+	history := append(a.SyncHistory, event)
+	if len(history) > MaxSyncHistory {
+		history = history[len(history)-MaxSyncHistory:]
+	}
+	// Copy so the slice never aliases a backing array shared with another Application value.
+	a.SyncHistory = append([]SyncEvent(nil), history...)
+}
+
+// RecordFetch records a clone or pull of the application's repository, operation being "clone",
+// "pull" or "ls-remote" for a pull skipped because the remote branch had not moved, that took duration, finished at now and, unless it failed, fetched revision.
+// It reports whether the fetch found a revision other than the last one fetched.
+func (a *Application) RecordFetch(operation, revision string, duration time.Duration, now time.Time, failed bool) bool {
+	// Copy so the stats are never shared with another Application value.
+	stats := RepositoryStats{}
+	if a.Repository != nil {
+		stats = *a.Repository
+	}
+	switch {
+	case failed:
+		stats.FetchFailures++
+	case operation == "clone":
+		stats.Clones++
+		stats.LastCloneDuration, stats.LastClonedAt = duration, now
+	case operation == "ls-remote":
+		stats.Checks++
+		stats.LastCheckDuration, stats.LastCheckedAt = duration, now
+	default:
+		stats.Pulls++
+		stats.LastPullDuration, stats.LastPulledAt = duration, now
+	}
+	changed := !failed && stats.Revision != revision
+	if !failed {
+		stats.Revision = revision
+	}
+	a.Repository = &stats
+	return changed
+}
+
+// RecordRepositorySize records the size of the application's clone, measured at revision.
+func (a *Application) RecordRepositorySize(revision string, sizeBytes int64, objects int) {
+	stats := RepositoryStats{}
+	if a.Repository != nil {
+		stats = *a.Repository
+	}
+	stats.SizeBytes, stats.Objects, stats.MeasuredRevision = sizeBytes, objects, revision
+	a.Repository = &stats
+}
+
+// StatusStreak returns when the application entered its current status and how many
+// consecutive sync events recorded it, based on the most recent sync history.
+func (a *Application) StatusStreak() (time.Time, int) {
+	var since time.Time
+	count := 0
+	for i := len(a.SyncHistory) - 1; i >= 0; i-- {
+		if a.SyncHistory[i].Status != a.Status {
+			break
+		}
+		since = a.SyncHistory[i].Time
+		count++
+	}
+	return since, count
+}
+
+// SetSpec sets the fields describing the application as registered by an operator, such as its
+// repository, cluster and sync settings, to those of spec. Its runtime state, maintained by the
+// controller, is left unchanged: its status, sync history and pending plans and changes.
+func (a *Application) SetSpec(spec *Application) {
+	a.Labels = spec.Labels
+	a.RepoURL = spec.RepoURL
+	a.Branch = spec.Branch
+	a.Path = spec.Path
+	a.Paths = spec.Paths
+	a.Sources = spec.Sources
+	a.Submodules = spec.Submodules
+	a.LFS = spec.LFS
+	a.ClusterName = spec.ClusterName
+	a.Placement = spec.Placement
+	a.ImpersonateUser = spec.ImpersonateUser
+	a.ImpersonateGroups = spec.ImpersonateGroups
+	a.Type = spec.Type
+	a.Terraform = spec.Terraform
+	a.Renderer = spec.Renderer
+	a.PluginParams = spec.PluginParams
+	a.Helm = spec.Helm
+	a.ApplyStrategy = spec.ApplyStrategy
+	a.Exclude = spec.Exclude
+	a.DefaultNamespace = spec.DefaultNamespace
+	a.RequireNamespace = spec.RequireNamespace
+	a.Lint = spec.Lint
+	a.APIDeprecationPolicy = spec.APIDeprecationPolicy
+	a.Substitute = spec.Substitute
+	a.Variables = spec.Variables
+	a.ConfigHash = spec.ConfigHash
+	a.ApprovalRequired = spec.ApprovalRequired
+	a.SyncMode = spec.SyncMode
+	a.HealthTimeout = spec.HealthTimeout
+	a.Interval = spec.Interval
+	a.PollingInterval = spec.PollingInterval
+	a.MaxInterval = spec.MaxInterval
+}
+
+// Spec returns a copy of the application holding only the fields set by SetSpec.
+func (a *Application) Spec() *Application {
+	spec := &Application{Name: a.Name}
+	spec.SetSpec(a)
+	return spec
+}
+
+// Reregister updates the application registered again with spec. Besides setting its spec, the
+// state that depends on the previous registration is reset: its pending plan and change, the
+// findings of its last sync, its adaptive interval and its failures, and it awaits its next sync.
+func (a *Application) Reregister(spec *Application) {
+	a.SetSpec(spec)
+	a.TerraformPlan = nil
+	a.AdaptiveInterval = 0
+	a.LintFindings = nil
+	a.DeprecatedAPIs = nil
+	a.PendingChange = nil
+	a.UnhealthyGitHash, a.UnhealthyValuesDigest = "", ""
+	a.PartialGitHash, a.PartialValuesDigest = "", ""
+	a.RollbackGitHash = ""
+	a.Status = StatusPending
+	a.Message = "Application updated, awaiting next sync."
+	a.ConsecutiveFailures = 0
 }
 
 // Applications represents a collection of Application objects.
@@ -135,9 +925,86 @@ func (a *Applications) Delete(name string) {
 	delete(a.Apps, name)
 }
 
+// Rename changes the name of an application and re-keys it in the collection.
+// Its sync history is kept; the last synced commit is cleared so the next sync re-applies the
+// manifests with tracking labels naming the new application.
+// The caller is responsible for acquiring the necessary write lock before calling this method.
+func (a *Applications) Rename(oldName, newName string) error {
+	app, ok := a.Apps[oldName]
+	if !ok {
+		return fmt.Errorf("application '%s' not found", oldName)
+	}
+	if _, exists := a.Apps[newName]; exists {
+		return fmt.Errorf("application '%s' already exists", newName)
+	}
+	delete(a.Apps, oldName)
+	app.Name = newName
+	app.LastSyncedGitHash = ""
+	app.LastSyncedValuesDigest = ""
+	app.RollbackGitHash = ""
+	app.SourceRevisions = nil
+	app.TerraformPlan = nil
+	a.Apps[newName] = app
+	return nil
+}
+
+// RenameCluster points the applications deployed to a cluster at its new name.
+// It returns the names of the updated applications.
+// The caller is responsible for acquiring the necessary write lock before calling this method.
+func (a *Applications) RenameCluster(oldName, newName string) []string {
+	var renamed []string
+	for _, app := range a.Apps {
+		if app.ClusterName == oldName {
+			app.ClusterName = newName
+			renamed = append(renamed, app.Name)
+		}
+	}
+	slices.Sort(renamed)
+	return renamed
+}
+
+// Merge synchronizes the collection with the applications in src.
+// Existing entries are updated in place so that pointers held by callers remain valid,
+// new entries are added and entries missing from src are removed.
+// The caller is responsible for acquiring the necessary write lock before calling this method.
+func (a *Applications) Merge(src *Applications) {
+	for name, app := range src.Apps {
+		if existing, ok := a.Apps[name]; ok {
+			*existing = *app
+			continue
+		}
+		a.Apps[name] = app
+	}
+	for name := range a.Apps {
+		if _, ok := src.Apps[name]; !ok {
+			delete(a.Apps, name)
+		}
+	}
+}
+
+// MergeSpecs synchronizes the collection with the applications in src like Merge, but only sets
+// the spec of existing entries (see SetSpec), keeping their runtime state. The controller reloads
+// the applications file this way, as its own runtime state is newer than the file's.
+// The caller is responsible for acquiring the necessary write lock before calling this method.
+func (a *Applications) MergeSpecs(src *Applications) {
+	for name, app := range src.Apps {
+		if existing, ok := a.Apps[name]; ok {
+			existing.SetSpec(app)
+			continue
+		}
+		a.Apps[name] = app
+	}
+	for name := range a.Apps {
+		if _, ok := src.Apps[name]; !ok {
+			delete(a.Apps, name)
+		}
+	}
+}
+
 // LoadApplications loads applications from the specified JSON file.
-// It initializes the Applications collection and populates it with data from the file.
-// If the file does not exist, it returns an empty collection.
+// It initializes the Applications collection and populates it with data from the file,
+// upgrading files of older versions in memory. If the file does not exist, it returns an
+// empty collection.
 func LoadApplications(filePath string) (*Applications, error) {
 	apps := NewApplications()
 	apps.mu.Lock() // Acquire lock for initial load
@@ -151,9 +1018,9 @@ func LoadApplications(filePath string) (*Applications, error) {
 		return nil, fmt.Errorf("failed to read applications file %s: %w", filePath, err)
 	}
 
-	var loadedApps []*Application
-	if err := json.Unmarshal(data, &loadedApps); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal applications data: %w", err)
+	loadedApps, _, err := decodeApplications(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid applications file %s:\n%w", filePath, err)
 	}
 
 	for _, app := range loadedApps {
@@ -184,7 +1051,7 @@ func SaveApplications(apps *Applications, filePath string) error {
 		list = append(list, app)
 	}
 
-	data, err := json.MarshalIndent(list, "", "  ")
+	data, err := json.MarshalIndent(applicationsFile{Version: FileVersion, Applications: list}, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal applications data: %w", err)
 	}
@@ -199,7 +1066,7 @@ func SaveApplications(apps *Applications, filePath string) error {
 // It returns the headers for the table representation of the Application.
 func (a *Application) ToTableHeaders(details bool) []string {
 	if details {
-		return []string{"NAME", "REPO URL", "BRANCH", "PATH", "CLUSTER", "INTERVAL", "STATUS", "LAST SYNCED HASH", "FAILURES", "MESSAGE"}
+		return []string{"NAME", "REPO URL", "BRANCH", "PATH", "CLUSTER", "INTERVAL", "STATUS", "LAST SYNCED HASH", "FAILURES", "LAST SYNC", "NEXT SYNC", "MESSAGE"}
 	}
 	return []string{"NAME", "REPO URL", "BRANCH", "PATH", "CLUSTER", "INTERVAL"}
 }
@@ -217,11 +1084,13 @@ func (a *Application) ToTableRow(details bool) []string {
 			common.TruncateString(a.RepoURL, 30),
 			common.DefaultIfEmpty(a.Branch, "main"),
 			common.TruncateString(a.Path, 20),
-			a.ClusterName,
+			common.DefaultIfEmpty(a.ClusterName, "-"),
 			a.Interval,
-			a.Status,
+			string(a.Status),
 			hash,
 			fmt.Sprintf("%d", a.ConsecutiveFailures),
+			common.GetRelativeTime(a.LastSyncAt),
+			common.GetRelativeFutureTime(a.NextSyncAt),
 			common.TruncateString(a.Message, 40),
 		}
 	}
@@ -230,51 +1099,148 @@ func (a *Application) ToTableRow(details bool) []string {
 		common.TruncateString(a.RepoURL, 30),
 		common.DefaultIfEmpty(a.Branch, "main"),
 		common.TruncateString(a.Path, 20),
-		a.ClusterName,
+		common.DefaultIfEmpty(a.ClusterName, "-"),
 		a.Interval,
 	}
 }
 
-// ToJSONMap implements cliutils.Renderable for JSON output.
-// It returns a map representation of the Application suitable for JSON serialization.
-func (a *Application) ToJSONMap() map[string]any {
-	return map[string]any{
-		"name":                 a.Name,
-		"repo_url":             a.RepoURL,
-		"branch":               common.DefaultIfEmpty(a.Branch, "main"),
-		"path":                 a.Path,
-		"cluster":              a.ClusterName,
-		"interval":             a.Interval,
-		"status":               a.Status,
-		"last_synced_hash":     a.LastSyncedGitHash,
-		"consecutive_failures": a.ConsecutiveFailures,
-		"message":              a.Message,
-	}
-}
-
-// ToYAMLString implements cliutils.Renderable for YAML output.
-// It returns a YAML-formatted string representation of the Application.
-func (a *Application) ToYAMLString() string {
-	// Build YAML string manually for simplicity
-	return fmt.Sprintf(`name: %s
-  repo_url: %s
-  branch: %s
-  path: %s
-  cluster: %s
-  interval: %s
-  status: %s
-  last_synced_hash: %s
-  consecutive_failures: %d
-  message: %s`,
+// ToRecord implements cliutils.Recorder for csv output.
+// It returns the detailed table columns with full values and RFC 3339 times.
+func (a *Application) ToRecord() []string {
+	return []string{
 		a.Name,
 		a.RepoURL,
 		common.DefaultIfEmpty(a.Branch, "main"),
 		a.Path,
 		a.ClusterName,
 		a.Interval,
-		a.Status,
+		string(a.Status),
 		a.LastSyncedGitHash,
-		a.ConsecutiveFailures,
+		fmt.Sprintf("%d", a.ConsecutiveFailures),
+		formatRecordTime(a.LastSyncAt),
+		formatRecordTime(a.NextSyncAt),
 		a.Message,
-	)
+	}
+}
+
+// formatRecordTime formats a time for csv output, empty if it is not set.
+func formatRecordTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// ToJSONMap implements cliutils.Renderable for JSON output.
+// It returns a map representation of the Application suitable for JSON serialization.
+func (a *Application) ToJSONMap() map[string]any {
+	lastSyncAt, nextSyncAt, lastSyncStartedAt, lastSyncFinishedAt := "", "", "", ""
+	if !a.LastSyncAt.IsZero() {
+		lastSyncAt = a.LastSyncAt.Format(time.RFC3339)
+	}
+	if !a.LastSyncStartedAt.IsZero() {
+		lastSyncStartedAt = a.LastSyncStartedAt.Format(time.RFC3339)
+	}
+	if !a.LastSyncFinishedAt.IsZero() {
+		lastSyncFinishedAt = a.LastSyncFinishedAt.Format(time.RFC3339)
+	}
+	if !a.NextSyncAt.IsZero() {
+		nextSyncAt = a.NextSyncAt.Format(time.RFC3339)
+	}
+	m := map[string]any{
+		"name":                  a.Name,
+		"repo_url":              a.RepoURL,
+		"branch":                common.DefaultIfEmpty(a.Branch, "main"),
+		"path":                  a.Path,
+		"cluster":               a.ClusterName,
+		"interval":              a.Interval,
+		"status":                a.Status,
+		"last_synced_hash":      a.LastSyncedGitHash,
+		"consecutive_failures":  a.ConsecutiveFailures,
+		"last_sync_at":          lastSyncAt,
+		"next_sync_at":          nextSyncAt,
+		"last_sync_started_at":  lastSyncStartedAt,
+		"last_sync_finished_at": lastSyncFinishedAt,
+		"last_sync_duration":    a.LastSyncDuration.String(),
+		"effective_interval":    a.EffectiveInterval,
+		"message":               a.Message,
+	}
+	if a.IsTerraform() {
+		m["type"] = a.Type
+		if a.TerraformPlan != nil {
+			m["terraform_plan"] = a.TerraformPlan
+		}
+	}
+	if a.ApprovalRequired {
+		m["approval_required"] = true
+	}
+	if len(a.Labels) > 0 {
+		m["labels"] = a.Labels
+	}
+	if len(a.Paths) > 0 {
+		m["paths"] = a.Paths
+	}
+	if len(a.Sources) > 0 {
+		sources := make([]map[string]any, 0, len(a.Sources))
+		for _, source := range a.Sources {
+			sources = append(sources, map[string]any{
+				"name":                 source.Name,
+				"repo_url":             source.RepoURL,
+				"branch":               source.Branch,
+				"path":                 source.Path,
+				"last_synced_git_hash": a.SourceRevisions[source.Name],
+			})
+		}
+		m["sources"] = sources
+	}
+	if a.SubstitutesVariables() {
+		m["substitute"] = true
+		if len(a.Variables) > 0 {
+			m["variables"] = a.Variables
+		}
+	}
+	if a.ConfigHash {
+		m["config_hash"] = true
+	}
+	if a.DefaultNamespace != "" {
+		m["default_namespace"] = a.DefaultNamespace
+	}
+	if a.RequireNamespace {
+		m["require_namespace"] = true
+	}
+	if a.Lint != nil {
+		m["lint"] = a.Lint
+	}
+	if len(a.LintFindings) > 0 {
+		m["lint_findings"] = a.LintFindings
+	}
+	if a.APIDeprecationPolicy != "" {
+		m["api_deprecation_policy"] = a.APIDeprecationPolicy
+	}
+	if len(a.DeprecatedAPIs) > 0 {
+		m["deprecated_apis"] = a.DeprecatedAPIs
+	}
+	if a.Submodules {
+		m["submodules"] = true
+	}
+	if a.LFS {
+		m["lfs"] = true
+	}
+	if !a.RateLimitedUntil.IsZero() {
+		m["rate_limited_until"] = a.RateLimitedUntil.Format(time.RFC3339)
+	}
+	if a.IsTwoPhase() {
+		m["sync_mode"] = a.SyncMode
+		m["health_timeout"] = a.HealthTimeoutDuration().String()
+	}
+	if a.IsAdaptive() {
+		m["max_interval"] = a.MaxIntervalDuration().String()
+	}
+	if a.PendingChange != nil {
+		m["pending_change"] = a.PendingChange
+	}
+	if len(a.Rollouts) > 0 {
+		m["rollouts"] = a.Rollouts
+	}
+	return m
 }
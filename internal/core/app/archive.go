@@ -0,0 +1,70 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// DefaultArchiveFile is the default path to store applications unregistered with their history kept
+	DefaultArchiveFile = "configs/archived_applications.json"
+	// MaxArchivedApplications is the number of most recently archived applications retained.
+	MaxArchivedApplications = 100
+)
+
+// ArchivedApplication is the record kept of an application that was unregistered with its history retained.
+type ArchivedApplication struct {
+	// Application is the application's configuration and sync history at the time it was unregistered.
+	Application Application `json:"application"`
+	// UnregisteredAt is when the application was unregistered.
+	UnregisteredAt time.Time `json:"unregisteredAt"`
+	// Cascade reports whether the application's managed resources were deleted from the cluster.
+	Cascade bool `json:"cascade"`
+}
+
+// LoadArchive loads the archived applications from the specified JSON file, oldest first.
+// If the file does not exist, it returns an empty list.
+func LoadArchive(filePath string) ([]*ArchivedApplication, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read application archive %s: %w", filePath, err)
+	}
+
+	var archived []*ArchivedApplication
+	if err := json.Unmarshal(data, &archived); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal application archive: %w", err)
+	}
+	return archived, nil
+}
+
+// ArchiveApplication appends an unregistered application to the archive in the specified JSON file,
+// discarding the oldest entries beyond MaxArchivedApplications.
+func ArchiveApplication(a *Application, cascade bool, filePath string, now time.Time) error {
+	archived, err := LoadArchive(filePath)
+	if err != nil {
+		return err
+	}
+	archived = append(archived, &ArchivedApplication{Application: *a, UnregisteredAt: now, Cascade: cascade})
+	if len(archived) > MaxArchivedApplications {
+		archived = archived[len(archived)-MaxArchivedApplications:]
+	}
+
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	data, err := json.MarshalIndent(archived, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal application archive: %w", err)
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write application archive %s: %w", filePath, err)
+	}
+	return nil
+}
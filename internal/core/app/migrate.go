@@ -0,0 +1,160 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/schema"
+)
+
+// FileVersion is the version of the applications file format written by SaveApplications.
+//
+// Version 1 files, written before the format was versioned, hold a bare array of applications,
+// some of which may still name their kubeconfig with the per-application kubeconfigPath field
+// that registered clusters replaced. Older versions are upgraded when the file is loaded.
+const FileVersion = 2
+
+// applicationsFile is the format of the applications file.
+type applicationsFile struct {
+	// Version is the version of the format, FileVersion.
+	Version int `json:"version" schema:"required"`
+	// Applications are the registered applications.
+	Applications []*Application `json:"applications"`
+}
+
+// legacyApplication is an application in a version 1 applications file.
+type legacyApplication struct {
+	Application
+	// KubeconfigPath is the kubeconfig the application was deployed with before it referred to a
+	// registered cluster by name.
+	KubeconfigPath string `json:"kubeconfigPath,omitempty"`
+}
+
+// Migration describes the upgrade of an applications file to the current version.
+type Migration struct {
+	// From is the version the file was upgraded from, FileVersion if it was already current.
+	From int
+	// Backup is the path the file was copied to before it was rewritten, empty if it was not.
+	Backup string
+	// LegacyClusters maps the names of the clusters applications were moved to from their
+	// per-application kubeconfig to the kubeconfig's path. The clusters must be registered for
+	// the applications to sync.
+	LegacyClusters map[string]string
+
+	filePath string         // Path of the applications file
+	data     []byte         // Contents of the file before the upgrade
+	apps     []*Application // Applications upgraded to the current format
+}
+
+// PlanMigration reads the applications file at filePath and upgrades its applications to the
+// current version in memory, without writing anything. Apply writes the upgraded file.
+//
+// The clusters migrated applications were moved to should be registered before the upgraded file
+// is written: once it is, the kubeconfig paths the clusters are named after are gone.
+func PlanMigration(filePath string) (*Migration, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Migration{From: FileVersion}, nil
+		}
+		return nil, fmt.Errorf("failed to read applications file %s: %w", filePath, err)
+	}
+
+	list, migration, err := decodeApplications(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid applications file %s:\n%w", filePath, err)
+	}
+	migration.filePath, migration.data, migration.apps = filePath, data, list
+	return migration, nil
+}
+
+// Apply writes the upgraded applications file planned by PlanMigration, after copying the file to
+// a backup next to it. A missing or current file is left untouched.
+func (m *Migration) Apply() error {
+	if m.From == FileVersion {
+		return nil
+	}
+
+	backup, err := common.BackupFile(m.filePath, m.data, m.From)
+	if err != nil {
+		return err
+	}
+	apps := NewApplications()
+	for _, app := range m.apps {
+		apps.Apps[app.Name] = app
+	}
+	if err := SaveApplications(apps, m.filePath); err != nil {
+		return err
+	}
+	m.Backup = backup
+	return nil
+}
+
+// ValidateApplications validates data, the contents of an applications file of any supported
+// version, against the schema of its version.
+func ValidateApplications(data []byte) error {
+	_, _, err := decodeApplications(data)
+	return err
+}
+
+// decodeApplications decodes an applications file of any supported version, after validating it
+// against the schema of that version, and upgrades its applications to the current format.
+func decodeApplications(data []byte) ([]*Application, *Migration, error) {
+	version := schema.Version(data)
+	migration := &Migration{From: version, LegacyClusters: make(map[string]string)}
+
+	switch {
+	case version == 1:
+		var legacy []*legacyApplication
+		if err := schema.Validate(data, &legacy); err != nil {
+			return nil, nil, err
+		}
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal applications data: %w", err)
+		}
+		list := make([]*Application, 0, len(legacy))
+		for _, app := range legacy {
+			if app == nil {
+				continue
+			}
+			if app.KubeconfigPath != "" && app.ClusterName == "" {
+				app.ClusterName = legacyClusterName(app.KubeconfigPath)
+				migration.LegacyClusters[app.ClusterName] = app.KubeconfigPath
+			}
+			list = append(list, &app.Application)
+		}
+		return list, migration, nil
+	case version > FileVersion:
+		return nil, nil, fmt.Errorf("applications file version %d is newer than the latest version supported, %d; upgrade gitopsctl", version, FileVersion)
+	}
+
+	var file applicationsFile
+	if err := schema.Validate(data, &file); err != nil {
+		return nil, nil, err
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal applications data: %w", err)
+	}
+	if file.Version != FileVersion {
+		return nil, nil, fmt.Errorf("unsupported applications file version %d", file.Version)
+	}
+	list := make([]*Application, 0, len(file.Applications))
+	for _, app := range file.Applications {
+		if app != nil {
+			list = append(list, app)
+		}
+	}
+	return list, migration, nil
+}
+
+// legacyClusterName returns the name of the cluster registered for applications migrated from a
+// per-application kubeconfig. It is derived from the kubeconfig's path, so that applications
+// sharing a kubeconfig share the cluster.
+func legacyClusterName(kubeconfigPath string) string {
+	sum := sha256.Sum256([]byte(kubeconfigPath))
+	return "kubeconfig-" + hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+)
+
+// NormalizeSources trims and validates the additional sources of an application: each needs a
+// unique name, a Git URL and a branch, and its path, if any, must stay inside its repository.
+func NormalizeSources(sources []Source) ([]Source, error) {
+	normalized := make([]Source, 0, len(sources))
+	seen := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		source.Name = strings.TrimSpace(source.Name)
+		source.RepoURL = strings.TrimSpace(source.RepoURL)
+		source.Branch = strings.TrimSpace(source.Branch)
+		if err := common.ValidateName(source.Name); err != nil {
+			return nil, fmt.Errorf("invalid source name '%s': %w", source.Name, err)
+		}
+		if seen[source.Name] {
+			return nil, fmt.Errorf("source '%s' is defined more than once", source.Name)
+		}
+		seen[source.Name] = true
+		if err := common.ValidateGitURL(source.RepoURL); err != nil {
+			return nil, fmt.Errorf("source '%s': %w", source.Name, err)
+		}
+		if err := common.ValidateBranch(source.Branch); err != nil {
+			return nil, fmt.Errorf("source '%s': %w", source.Name, err)
+		}
+		if strings.TrimSpace(source.Path) != "" {
+			path, err := common.NormalizeRepoPath(source.Path)
+			if err != nil {
+				return nil, fmt.Errorf("source '%s': %w", source.Name, err)
+			}
+			source.Path = path
+		} else {
+			source.Path = ""
+		}
+		normalized = append(normalized, source)
+	}
+	return normalized, nil
+}
+
+// HasSourceManifests reports whether any of the application's additional sources has manifests
+// to apply, rather than only providing files such as Helm values.
+func (a *Application) HasSourceManifests() bool {
+	for _, source := range a.Sources {
+		if source.Path != "" {
+			return true
+		}
+	}
+	return false
+}
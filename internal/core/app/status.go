@@ -0,0 +1,179 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Status is the operational state of an application.
+type Status string
+
+const (
+	// StatusPending means the application is registered or resumed and awaits its next sync.
+	StatusPending Status = "Pending"
+	// StatusSyncRequested means a manual sync was requested and has not completed yet.
+	StatusSyncRequested Status = "SyncRequested"
+	// StatusSynced means the cluster matches the last commit of the tracked branch.
+	StatusSynced Status = "Synced"
+	// StatusError means the last sync failed.
+	StatusError Status = "Error"
+	// StatusInvalidManifests means the last sync found manifests that failed validation, so nothing was applied.
+	StatusInvalidManifests Status = "InvalidManifests"
+	// StatusSuspended means reconciliation stopped, after repeated failures or at an operator's request,
+	// until an operator resets the application.
+	StatusSuspended Status = "Suspended"
+	// StatusStopped means the reconciliation loop was stopped.
+	StatusStopped Status = "Stopped"
+	// StatusInterrupted means a sync was cut off by a controller shutdown and is resumed on the next start.
+	StatusInterrupted Status = "Interrupted"
+	// StatusAwaitingApproval means a Terraform plan, or a change to an application requiring approval,
+	// is applied once it is approved.
+	StatusAwaitingApproval Status = "AwaitingApproval"
+	// StatusRolledBack means the resources of the last revision did not become healthy, or an operator
+	// rolled it back, so the previous revision was applied again.
+	StatusRolledBack Status = "RolledBack"
+)
+
+// Statuses lists every application status.
+var Statuses = []Status{
+	StatusPending,
+	StatusSyncRequested,
+	StatusSynced,
+	StatusError,
+	StatusInvalidManifests,
+	StatusSuspended,
+	StatusStopped,
+	StatusInterrupted,
+	StatusAwaitingApproval,
+	StatusRolledBack,
+}
+
+// ParseStatus returns the application status named s, ignoring case.
+func ParseStatus(s string) (Status, error) {
+	for _, status := range Statuses {
+		if strings.EqualFold(string(status), s) {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("unknown application status '%s'", s)
+}
+
+// IsValid reports whether s is a known application status.
+func (s Status) IsValid() bool {
+	return slices.Contains(Statuses, s)
+}
+
+// IsFailed reports whether the status is the result of a failed sync.
+func (s Status) IsFailed() bool {
+	return s == StatusError || s == StatusInvalidManifests || s == StatusRolledBack
+}
+
+// transitions lists the statuses an application may move to from each status.
+//
+// A sync, which may end in any of its outcomes, be interrupted by a shutdown or be followed by a
+// suspension, can start from any status but Suspended, and so can a manual sync request. A
+// suspended application can only be resumed by moving it back to Pending. A reconciliation loop is
+// only stopped while its application is neither failing nor interrupted, so that those statuses
+// survive a controller restart.
+var transitions = map[Status][]Status{
+	StatusPending: {StatusSyncRequested, StatusSynced, StatusError, StatusInvalidManifests, StatusAwaitingApproval,
+		StatusRolledBack, StatusInterrupted, StatusStopped, StatusSuspended},
+	StatusSyncRequested: {StatusSynced, StatusError, StatusInvalidManifests, StatusAwaitingApproval,
+		StatusRolledBack, StatusInterrupted, StatusStopped, StatusSuspended},
+	StatusSynced: {StatusSyncRequested, StatusError, StatusInvalidManifests, StatusAwaitingApproval,
+		StatusRolledBack, StatusInterrupted, StatusStopped, StatusSuspended},
+	StatusAwaitingApproval: {StatusSyncRequested, StatusSynced, StatusError, StatusInvalidManifests,
+		StatusRolledBack, StatusInterrupted, StatusStopped, StatusSuspended},
+	StatusError: {StatusSyncRequested, StatusSynced, StatusInvalidManifests, StatusAwaitingApproval,
+		StatusRolledBack, StatusInterrupted, StatusSuspended},
+	StatusInvalidManifests: {StatusSyncRequested, StatusSynced, StatusError, StatusAwaitingApproval,
+		StatusRolledBack, StatusInterrupted, StatusSuspended},
+	StatusRolledBack: {StatusSyncRequested, StatusSynced, StatusError, StatusInvalidManifests, StatusAwaitingApproval,
+		StatusInterrupted, StatusSuspended},
+	StatusStopped: {StatusSyncRequested, StatusSynced, StatusError, StatusInvalidManifests, StatusAwaitingApproval,
+		StatusRolledBack, StatusInterrupted, StatusSuspended},
+	StatusInterrupted: {StatusSyncRequested, StatusSynced, StatusError, StatusInvalidManifests, StatusAwaitingApproval,
+		StatusRolledBack, StatusSuspended},
+	StatusSuspended: {StatusPending},
+}
+
+// CanTransition reports whether an application may move from one status to another, as listed in
+// transitions. Staying in the same status is always allowed, and so is leaving an unknown status,
+// such as that of an application that was never synced.
+func CanTransition(from, to Status) bool {
+	switch {
+	case !to.IsValid():
+		return false
+	case from == to, !from.IsValid():
+		return true
+	}
+	return slices.Contains(transitions[from], to)
+}
+
+// Transition describes a change of an application's status.
+type Transition struct {
+	// App is the name of the application.
+	App string
+	// From is the status the application left.
+	From Status
+	// To is the status the application entered.
+	To Status
+	// Message is the application's message after the transition.
+	Message string
+}
+
+// SetStatus moves the application to the given status and sets its message.
+//
+// It returns the resulting transition, or an error and leaves the application unchanged if the
+// transition is not allowed.
+func (a *Application) SetStatus(to Status, message string) (Transition, error) {
+	if !CanTransition(a.Status, to) {
+		return Transition{}, fmt.Errorf("application '%s' cannot move from %s to %s", a.Name, a.Status, to)
+	}
+	t := Transition{App: a.Name, From: a.Status, To: to, Message: message}
+	a.Status = to
+	a.Message = message
+	return t, nil
+}
+
+// TransitionHook is called after an application changes status.
+type TransitionHook func(Transition)
+
+// StateMachine validates application status changes and notifies hooks when a status changes.
+type StateMachine struct {
+	mu    sync.RWMutex
+	hooks []TransitionHook
+}
+
+// NewStateMachine creates a state machine without hooks.
+func NewStateMachine() *StateMachine {
+	return &StateMachine{}
+}
+
+// OnTransition registers a hook that is called after every status change, in registration order.
+func (m *StateMachine) OnTransition(hook TransitionHook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook)
+}
+
+// SetStatus moves the application to the given status and sets its message.
+//
+// Hooks are only called when the status actually changes. An error is returned, and the
+// application left unchanged, if the transition is not allowed.
+func (m *StateMachine) SetStatus(a *Application, to Status, message string) error {
+	t, err := a.SetStatus(to, message)
+	if err != nil || t.From == t.To {
+		return err
+	}
+
+	m.mu.RLock()
+	hooks := m.hooks
+	m.mu.RUnlock()
+	for _, hook := range hooks {
+		hook(t)
+	}
+	return nil
+}
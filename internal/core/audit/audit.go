@@ -0,0 +1,165 @@
+// Package audit records who changed what and why, for compliance reviews.
+//
+// Entries are appended to a JSON Lines file, one object per line, so the controller, a standalone
+// API server and the CLI can all write to it and the file can be shipped to a log pipeline as is.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+)
+
+// DefaultAuditFile is the default path of the audit log.
+const DefaultAuditFile = "configs/audit.log"
+
+// Action is a recorded change.
+type Action string
+
+const (
+	// ActionSync is a manually requested sync of an application.
+	ActionSync Action = "sync"
+	// ActionRollback is an aborted canary rollout, shifting traffic back to the stable version.
+	ActionRollback Action = "rollback"
+	// ActionSuspend is a suspension of an application's reconciliation.
+	ActionSuspend Action = "suspend"
+	// ActionRegister is the registration or update of an application or cluster.
+	ActionRegister Action = "register"
+)
+
+// Entry is a recorded change.
+type Entry struct {
+	// Time is when the change was requested.
+	Time time.Time `json:"time"`
+	// Actor is who requested the change: an identity provider user, "token:<name>" for an API
+	// token, "local:<user>" for the CLI, or "anonymous" when the API does not require authentication.
+	Actor string `json:"actor"`
+	// Action is the change.
+	Action Action `json:"action"`
+	// Kind is the kind of the changed object, "Application" or "Cluster".
+	Kind string `json:"kind"`
+	// Name is the name of the changed object.
+	Name string `json:"name"`
+	// Reason is why the change was made, as given by the actor.
+	Reason string `json:"reason,omitempty"`
+	// Details describe the change, e.g. the aborted rollout.
+	Details string `json:"details,omitempty"`
+	// RequestID is the ID of the API request that made the change, empty for the CLI.
+	RequestID string `json:"requestID,omitempty"`
+}
+
+// mu serializes appends from the same process so entries are never interleaved.
+var mu sync.Mutex
+
+// Record appends an entry to the audit log at filePath, setting its time if it is zero.
+func Record(filePath string, entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	f, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", filePath, err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// Load reads the audit log at filePath, oldest entry first.
+// If the file does not exist, it returns no entries.
+func Load(filePath string) ([]Entry, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read audit log %s: %w", filePath, err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse audit log %s line %d: %w", filePath, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log %s: %w", filePath, err)
+	}
+	return entries, nil
+}
+
+// LocalActor returns the actor of changes made with the CLI: "local:" followed by the OS user.
+func LocalActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return "local:" + u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return "local:" + name
+	}
+	return "local"
+}
+
+// ToTableHeaders implements cliutils.Renderable for table output headers.
+func (e *Entry) ToTableHeaders(details bool) []string {
+	if details {
+		return []string{"TIME", "ACTOR", "ACTION", "OBJECT", "REASON", "DETAILS"}
+	}
+	return []string{"TIME", "ACTOR", "ACTION", "OBJECT", "REASON"}
+}
+
+// ToTableRow implements cliutils.Renderable for table output rows.
+func (e *Entry) ToTableRow(details bool) []string {
+	object := fmt.Sprintf("%s/%s", strings.ToLower(e.Kind), e.Name)
+	if details {
+		return []string{e.Time.Format(time.RFC3339), e.Actor, string(e.Action), object, e.Reason, e.Details}
+	}
+	return []string{
+		common.GetRelativeTime(e.Time),
+		e.Actor,
+		string(e.Action),
+		object,
+		common.TruncateString(e.Reason, 60),
+	}
+}
+
+// ToJSONMap implements cliutils.Renderable for JSON output.
+func (e *Entry) ToJSONMap() map[string]any {
+	return map[string]any{
+		"time":    e.Time.Format(time.RFC3339),
+		"actor":   e.Actor,
+		"action":  string(e.Action),
+		"kind":    e.Kind,
+		"name":    e.Name,
+		"reason":  e.Reason,
+		"details": e.Details,
+	}
+}
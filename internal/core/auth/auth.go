@@ -0,0 +1,220 @@
+// Package auth authenticates API and web UI users through an OpenID Connect identity provider or
+// API tokens, and grants them permissions through their groups or tokens.
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// DefaultAuthConfigFile is the default path of the authentication configuration.
+const DefaultAuthConfigFile = "configs/auth.json"
+
+// Permission is an operation an authenticated user or token may perform.
+type Permission string
+
+const (
+	// PermissionAppRead allows reading applications, clusters and events.
+	PermissionAppRead Permission = "app:read"
+	// PermissionAppSync allows syncing, suspending, resuming and approving applications, controlling
+	// their rollouts and health checking clusters.
+	PermissionAppSync Permission = "app:sync"
+	// PermissionAppWrite allows registering, renaming and unregistering applications.
+	PermissionAppWrite Permission = "app:write"
+	// PermissionClusterWrite allows registering, renaming and unregistering clusters.
+	PermissionClusterWrite Permission = "cluster:write"
+	// PermissionAdmin allows everything.
+	PermissionAdmin Permission = "admin"
+)
+
+// Permissions lists all permissions.
+var Permissions = []Permission{PermissionAppRead, PermissionAppSync, PermissionAppWrite, PermissionClusterWrite, PermissionAdmin}
+
+// Role is a named set of permissions that can be granted instead of listing them.
+type Role string
+
+const (
+	// RoleViewer grants app:read.
+	RoleViewer Role = "viewer"
+	// RoleOperator grants app:read and app:sync.
+	RoleOperator Role = "operator"
+	// RoleAdmin grants admin.
+	RoleAdmin Role = "admin"
+)
+
+// RolePermissions maps each role to the permissions it grants.
+var RolePermissions = map[Role]Grant{
+	RoleViewer:   {PermissionAppRead},
+	RoleOperator: {PermissionAppRead, PermissionAppSync},
+	RoleAdmin:    {PermissionAdmin},
+}
+
+// Grant is a set of permissions. In JSON it is a list of permissions and roles, or a single role
+// such as "viewer"; roles are expanded to their permissions.
+type Grant []Permission
+
+// ParseGrant parses permissions and roles, e.g. "app:read", "operator".
+func ParseGrant(values ...string) (Grant, error) {
+	var grant Grant
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if permissions, ok := RolePermissions[Role(value)]; ok {
+			grant = grant.With(permissions...)
+			continue
+		}
+		if !slices.Contains(Permissions, Permission(value)) {
+			return nil, fmt.Errorf("invalid permission '%s': must be one of %s, or a role: viewer, operator, admin", value, permissionNames())
+		}
+		grant = grant.With(Permission(value))
+	}
+	return grant, nil
+}
+
+// UnmarshalJSON parses a grant from a list of permissions and roles, or a single role.
+func (g *Grant) UnmarshalJSON(data []byte) error {
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return fmt.Errorf("a grant must be a role or a list of permissions")
+		}
+		values = []string{value}
+	}
+	grant, err := ParseGrant(values...)
+	if err != nil {
+		return err
+	}
+	*g = grant
+	return nil
+}
+
+// With returns the grant with the permissions added.
+func (g Grant) With(permissions ...Permission) Grant {
+	for _, permission := range permissions {
+		if !slices.Contains(g, permission) {
+			g = append(g, permission)
+		}
+	}
+	return g
+}
+
+// Allows reports whether the grant includes the permission, or admin.
+func (g Grant) Allows(permission Permission) bool {
+	return slices.Contains(g, permission) || slices.Contains(g, PermissionAdmin)
+}
+
+// String returns the permissions as a comma-separated list.
+func (g Grant) String() string {
+	names := make([]string, len(g))
+	for i, permission := range g {
+		names[i] = string(permission)
+	}
+	return strings.Join(names, ",")
+}
+
+// permissionNames returns all permissions as a comma-separated list.
+func permissionNames() string {
+	return Grant(Permissions).String()
+}
+
+// OIDCConfig configures the OpenID Connect identity provider, e.g. Okta, Azure AD or Keycloak.
+type OIDCConfig struct {
+	// Issuer is the provider's issuer URL; its discovery document is read from
+	// <Issuer>/.well-known/openid-configuration.
+	Issuer string `json:"issuer"`
+	// ClientID is the ID of the client registered for gitopsctl.
+	ClientID string `json:"clientID"`
+	// ClientSecretEnv names the environment variable holding the client's secret. Empty for a public
+	// client, which PKCE protects.
+	ClientSecretEnv string `json:"clientSecretEnv,omitempty"`
+	// RedirectURL is the URL of the callback the provider redirects to after the web UI login,
+	// <API URL>/auth/callback. Empty disables the web UI login; bearer tokens are still accepted.
+	RedirectURL string `json:"redirectURL,omitempty"`
+	// Audiences are the audiences accepted in bearer tokens besides ClientID, e.g. the API's
+	// application ID URI for Azure AD access tokens.
+	Audiences []string `json:"audiences,omitempty"`
+	// Scopes are requested at login. Defaults to "openid", "profile", "email".
+	Scopes []string `json:"scopes,omitempty"`
+	// UsernameClaim is the claim identifying the user in logs and the UI. Defaults to "email",
+	// falling back to "sub" when a token has no such claim.
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+	// GroupsClaim is the claim listing the user's groups. Defaults to "groups"; Azure AD app roles
+	// are in "roles".
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+}
+
+// Config holds the identity provider and the permissions granted to its users.
+type Config struct {
+	// OIDC is the identity provider. Only API tokens are accepted if it is not set.
+	OIDC *OIDCConfig `json:"oidc,omitempty"`
+	// Groups maps the provider's groups to the permissions granted to their members. A user in
+	// several groups is granted the permissions of all of them.
+	Groups map[string]Grant `json:"groups,omitempty"`
+	// Default is granted to all users of the provider, in addition to their groups' permissions.
+	Default Grant `json:"default,omitempty"`
+}
+
+// LoadConfig loads and validates the authentication configuration from the specified file path.
+// If the file does not exist, it returns an empty configuration with authentication disabled.
+func LoadConfig(filePath string) (*Config, error) {
+	config := &Config{}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, fmt.Errorf("failed to read authentication config file %s: %w", filePath, err)
+	}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal authentication config: %w", err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// validate checks the configuration for errors and fills in defaults.
+func (c *Config) validate() error {
+	if c.OIDC == nil {
+		return nil
+	}
+	oidc := c.OIDC
+	if strings.TrimSpace(oidc.Issuer) == "" {
+		return fmt.Errorf("oidc: issuer is required")
+	}
+	if strings.TrimSpace(oidc.ClientID) == "" {
+		return fmt.Errorf("oidc: clientID is required")
+	}
+	oidc.Issuer = strings.TrimSuffix(oidc.Issuer, "/")
+	if len(oidc.Scopes) == 0 {
+		oidc.Scopes = []string{"openid", "profile", "email"}
+	} else if !slices.Contains(oidc.Scopes, "openid") {
+		oidc.Scopes = append([]string{"openid"}, oidc.Scopes...)
+	}
+	if oidc.UsernameClaim == "" {
+		oidc.UsernameClaim = "email"
+	}
+	if oidc.GroupsClaim == "" {
+		oidc.GroupsClaim = "groups"
+	}
+
+	if len(c.Groups) == 0 && len(c.Default) == 0 {
+		return fmt.Errorf("no user would be granted access: map groups to permissions or set default")
+	}
+	return nil
+}
+
+// PermissionsFor returns the permissions granted to a member of groups.
+func (c *Config) PermissionsFor(groups []string) Grant {
+	grant := slices.Clone(c.Default)
+	for _, group := range groups {
+		grant = grant.With(c.Groups[group]...)
+	}
+	return grant
+}
@@ -0,0 +1,533 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // Registers the SHA-256 hash used by signingAlgorithms
+	_ "crypto/sha512" // Registers the SHA-384 and SHA-512 hashes used by signingAlgorithms
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// discoveryPath is where an issuer publishes its OpenID Connect discovery document.
+	discoveryPath = "/.well-known/openid-configuration"
+	// clockSkew is how far the clocks of the provider and the controller may drift apart.
+	clockSkew = time.Minute
+	// minKeyRefresh is the minimum time between two fetches of the provider's signing keys, so
+	// tokens signed with unknown keys cannot make the controller hammer the provider.
+	minKeyRefresh = time.Minute
+	// requestTimeout bounds each request to the provider.
+	requestTimeout = 10 * time.Second
+)
+
+var (
+	// ErrInvalidToken is returned, wrapped, for tokens that are malformed, expired or not signed by the provider.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrNoPermissions is returned, wrapped, for valid tokens that grant no permissions.
+	ErrNoPermissions = errors.New("no permissions granted")
+)
+
+// Metadata are the provider endpoints read from its discovery document.
+type Metadata struct {
+	// Issuer is the provider's issuer URL, which tokens must name.
+	Issuer string `json:"issuer"`
+	// AuthorizationEndpoint is where users are sent to log in.
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	// TokenEndpoint is where authorization codes are exchanged for tokens.
+	TokenEndpoint string `json:"token_endpoint"`
+	// JWKSURI is where the provider publishes its signing keys.
+	JWKSURI string `json:"jwks_uri"`
+	// EndSessionEndpoint is where users are sent to log out of the provider, if it supports it.
+	EndSessionEndpoint string `json:"end_session_endpoint,omitempty"`
+}
+
+// Identity is an authenticated user.
+type Identity struct {
+	// Subject is the provider's identifier of the user.
+	Subject string `json:"subject"`
+	// User is the value of the configured username claim, or Subject if the token has none.
+	User string `json:"user"`
+	// Groups are the user's groups.
+	Groups []string `json:"groups,omitempty"`
+	// Permissions are the permissions granted to the user.
+	Permissions Grant `json:"permissions"`
+	// Expiry is when the token the user authenticated with expires. Zero for an API token that does not expire.
+	Expiry time.Time `json:"expiry"`
+}
+
+// Authenticator verifies API tokens and tokens issued by the configured OpenID Connect provider.
+//
+// The provider's discovery document and signing keys are fetched on first use and cached, so the
+// controller starts even while the provider is unreachable. The API tokens are reloaded whenever
+// their file changes, so tokens created or revoked with the CLI take effect immediately.
+type Authenticator struct {
+	config    *Config
+	tokenFile string
+	client    *http.Client
+
+	mu            sync.Mutex
+	metadata      *Metadata
+	keys          map[string]signingKey
+	keysFetched   time.Time
+	keysFetching  chan struct{} // Closed when the fetch of the keys in progress, if any, completes
+	tokens        Tokens
+	tokensModTime time.Time
+}
+
+// NewAuthenticator creates an authenticator for the configuration and the API tokens stored in
+// tokenFile. It returns nil if there is neither an identity provider nor a token, which disables
+// authentication.
+func NewAuthenticator(config *Config, tokenFile string) (*Authenticator, error) {
+	a := &Authenticator{config: config, tokenFile: tokenFile, client: &http.Client{Timeout: requestTimeout}}
+	if err := a.reloadTokens(); err != nil {
+		return nil, err
+	}
+	if config.OIDC == nil && len(a.tokens) == 0 {
+		return nil, nil
+	}
+	return a, nil
+}
+
+// Config returns the authenticator's configuration.
+func (a *Authenticator) Config() *Config {
+	return a.config
+}
+
+// Metadata returns the provider's endpoints, fetching its discovery document on first use.
+func (a *Authenticator) Metadata(ctx context.Context) (*Metadata, error) {
+	if a.config.OIDC == nil {
+		return nil, errors.New("no OpenID Connect provider is configured")
+	}
+	a.mu.Lock()
+	metadata := a.metadata
+	a.mu.Unlock()
+	if metadata != nil {
+		return metadata, nil
+	}
+
+	// Fetched without holding the lock, so API tokens are still checked while the provider is slow
+	metadata = &Metadata{}
+	if err := a.getJSON(ctx, a.config.OIDC.Issuer+discoveryPath, metadata); err != nil {
+		return nil, fmt.Errorf("failed to discover OpenID Connect provider %s: %w", a.config.OIDC.Issuer, err)
+	}
+	if strings.TrimSuffix(metadata.Issuer, "/") != a.config.OIDC.Issuer {
+		return nil, fmt.Errorf("OpenID Connect provider reports issuer '%s', expected '%s'", metadata.Issuer, a.config.OIDC.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("OpenID Connect provider %s does not publish its authorization, token and key endpoints", a.config.OIDC.Issuer)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.metadata == nil {
+		a.metadata = metadata
+	}
+	return a.metadata, nil
+}
+
+// Authenticate verifies an API token or a token issued by the provider and returns the identity it
+// names. A non-empty nonce must match the token's nonce claim, as for the ID token of a login. An
+// error is returned if the token is invalid or grants no permissions.
+func (a *Authenticator) Authenticate(ctx context.Context, token, nonce string) (*Identity, error) {
+	if IsToken(token) {
+		return a.authenticateToken(token)
+	}
+	if a.config.OIDC == nil {
+		return nil, fmt.Errorf("%w: not an API token", ErrInvalidToken)
+	}
+
+	claims, err := a.verify(ctx, token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if nonce != "" && claims["nonce"] != nonce {
+		return nil, fmt.Errorf("%w: nonce does not match the login", ErrInvalidToken)
+	}
+
+	oidc := a.config.OIDC
+	identity := &Identity{Groups: stringsClaim(claims[oidc.GroupsClaim])}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.User, _ = claims[oidc.UsernameClaim].(string)
+	if identity.User == "" {
+		identity.User = identity.Subject
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		identity.Expiry = time.Unix(int64(exp), 0)
+	}
+	identity.Permissions = a.config.PermissionsFor(identity.Groups)
+	if len(identity.Permissions) == 0 {
+		return nil, fmt.Errorf("%w: user '%s' is in none of the groups granted access", ErrNoPermissions, identity.User)
+	}
+	return identity, nil
+}
+
+// authenticateToken looks up an API token by its secret.
+func (a *Authenticator) authenticateToken(secret string) (*Identity, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.reloadTokens(); err != nil {
+		return nil, err
+	}
+	token, ok := a.tokens.Find(secret)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown or revoked API token", ErrInvalidToken)
+	}
+	if token.Expired(time.Now()) {
+		return nil, fmt.Errorf("%w: API token '%s' expired", ErrInvalidToken, token.Name)
+	}
+	if len(token.Permissions) == 0 {
+		return nil, fmt.Errorf("%w: API token '%s' grants no permissions", ErrNoPermissions, token.Name)
+	}
+	return &Identity{
+		Subject:     "token:" + token.Name,
+		User:        "token:" + token.Name,
+		Permissions: token.Permissions,
+		Expiry:      token.ExpiresAt,
+	}, nil
+}
+
+// reloadTokens loads the API tokens if their file changed since they were last loaded.
+func (a *Authenticator) reloadTokens() error {
+	var modTime time.Time
+	if info, err := os.Stat(a.tokenFile); err == nil {
+		modTime = info.ModTime()
+	}
+	if a.tokens != nil && modTime.Equal(a.tokensModTime) {
+		return nil
+	}
+	tokens, err := LoadTokens(a.tokenFile)
+	if err != nil {
+		return err
+	}
+	a.tokens, a.tokensModTime = tokens, modTime
+	return nil
+}
+
+// verify checks the token's signature, issuer, audience and validity period at now and returns its claims.
+func (a *Authenticator) verify(ctx context.Context, token string, now time.Time) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: not a JSON Web Token", ErrInvalidToken)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header: %v", ErrInvalidToken, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	key, err := a.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims: %v", ErrInvalidToken, err)
+	}
+	oidc := a.config.OIDC
+	if iss, _ := claims["iss"].(string); strings.TrimSuffix(iss, "/") != oidc.Issuer {
+		return nil, fmt.Errorf("%w: issued by '%s'", ErrInvalidToken, iss)
+	}
+	audiences := append([]string{oidc.ClientID}, oidc.Audiences...)
+	if !slices.ContainsFunc(stringsClaim(claims["aud"]), func(aud string) bool { return slices.Contains(audiences, aud) }) {
+		return nil, fmt.Errorf("%w: not issued for this client", ErrInvalidToken)
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok || now.After(time.Unix(int64(exp), 0).Add(clockSkew)) {
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return nil, fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+// key returns the provider's signing key with the given ID, fetching the provider's keys again if
+// it is unknown, as after a key rotation. An empty ID matches the only key.
+//
+// The keys are fetched without holding the lock, so other requests are not held up by a slow
+// provider, and concurrent requests for unknown keys share a single fetch.
+func (a *Authenticator) key(ctx context.Context, kid string) (signingKey, error) {
+	metadata, err := a.Metadata(ctx)
+	if err != nil {
+		return signingKey{}, err
+	}
+
+	a.mu.Lock()
+	if key, ok := lookupKey(a.keys, kid); ok {
+		a.mu.Unlock()
+		return key, nil
+	}
+	fetching := a.keysFetching
+	if fetching == nil {
+		if time.Since(a.keysFetched) < minKeyRefresh {
+			a.mu.Unlock()
+			return signingKey{}, fmt.Errorf("%w: signed with unknown key '%s'", ErrInvalidToken, kid)
+		}
+		fetching = make(chan struct{})
+		a.keysFetching, a.keysFetched = fetching, time.Now()
+		a.mu.Unlock()
+
+		keys, fetchErr := a.fetchKeys(ctx, metadata.JWKSURI)
+		a.mu.Lock()
+		if fetchErr == nil {
+			a.keys = keys
+		}
+		a.keysFetching = nil
+		close(fetching)
+		a.mu.Unlock()
+		if fetchErr != nil {
+			return signingKey{}, fmt.Errorf("failed to fetch signing keys of OpenID Connect provider: %w", fetchErr)
+		}
+	} else {
+		a.mu.Unlock()
+		select {
+		case <-fetching:
+		case <-ctx.Done():
+			return signingKey{}, ctx.Err()
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if key, ok := lookupKey(a.keys, kid); ok {
+		return key, nil
+	}
+	return signingKey{}, fmt.Errorf("%w: signed with unknown key '%s'", ErrInvalidToken, kid)
+}
+
+// fetchKeys fetches the provider's signing keys, skipping keys for encryption and keys that cannot
+// be decoded.
+func (a *Authenticator) fetchKeys(ctx context.Context, jwksURI string) (map[string]signingKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := a.getJSON(ctx, jwksURI, &set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]signingKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if key, err := jwk.signingKey(); err == nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	return keys, nil
+}
+
+// getJSON fetches url and decodes its JSON body into v.
+func (a *Authenticator) getJSON(ctx context.Context, url string, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// lookupKey returns the key with the given ID, or the only key if the ID is empty.
+func lookupKey(keys map[string]signingKey, kid string) (signingKey, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	key, ok := keys[kid]
+	return key, ok
+}
+
+// jsonWebKey is an RSA or elliptic curve public key published by the provider.
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// signingKey is a signing key of the provider.
+type signingKey struct {
+	// key is an *rsa.PublicKey or *ecdsa.PublicKey.
+	key crypto.PublicKey
+	// alg is the only algorithm the key may be used with, if its JWK names one.
+	alg string
+}
+
+// signingKey decodes the key, along with the algorithm it is restricted to.
+func (k jsonWebKey) signingKey() (signingKey, error) {
+	if k.Alg != "" {
+		algorithm, ok := signingAlgorithms[k.Alg]
+		if !ok {
+			return signingKey{}, fmt.Errorf("unsupported signing algorithm '%s'", k.Alg)
+		}
+		if algorithm.kty != k.Kty {
+			return signingKey{}, fmt.Errorf("algorithm '%s' does not match key type '%s'", k.Alg, k.Kty)
+		}
+	}
+	key, err := k.publicKey()
+	if err != nil {
+		return signingKey{}, err
+	}
+	return signingKey{key: key, alg: k.Alg}, nil
+}
+
+// publicKey decodes the key.
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve '%s'", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type '%s'", k.Kty)
+}
+
+// signingAlgorithm describes a JWS algorithm tokens may be signed with.
+type signingAlgorithm struct {
+	kty   string         // Type of the keys the algorithm is used with
+	hash  crypto.Hash    // Hash of the signed data
+	pss   bool           // RSASSA-PSS rather than RSASSA-PKCS1-v1_5 for RSA keys
+	curve elliptic.Curve // Curve of the keys for ECDSA
+}
+
+// signingAlgorithms are the JWS algorithms accepted, by their exact name.
+var signingAlgorithms = map[string]signingAlgorithm{
+	"RS256": {kty: "RSA", hash: crypto.SHA256},
+	"RS384": {kty: "RSA", hash: crypto.SHA384},
+	"RS512": {kty: "RSA", hash: crypto.SHA512},
+	"PS256": {kty: "RSA", hash: crypto.SHA256, pss: true},
+	"PS384": {kty: "RSA", hash: crypto.SHA384, pss: true},
+	"PS512": {kty: "RSA", hash: crypto.SHA512, pss: true},
+	"ES256": {kty: "EC", hash: crypto.SHA256, curve: elliptic.P256()},
+	"ES384": {kty: "EC", hash: crypto.SHA384, curve: elliptic.P384()},
+	"ES512": {kty: "EC", hash: crypto.SHA512, curve: elliptic.P521()},
+}
+
+// verifySignature checks the signature of signed with key for the JWS algorithm alg, which must be
+// one of signingAlgorithms, match the type and curve of the key, and be the key's algorithm if it
+// is restricted to one.
+func verifySignature(alg string, key signingKey, signed string, signature []byte) error {
+	algorithm, ok := signingAlgorithms[alg]
+	if !ok {
+		return fmt.Errorf("unsupported signing algorithm '%s'", alg)
+	}
+	if key.alg != "" && key.alg != alg {
+		return fmt.Errorf("algorithm '%s' does not match the signing key's algorithm '%s'", alg, key.alg)
+	}
+	digest := algorithm.hash.New()
+	digest.Write([]byte(signed))
+	sum := digest.Sum(nil)
+
+	switch publicKey := key.key.(type) {
+	case *rsa.PublicKey:
+		if algorithm.kty != "RSA" {
+			break
+		}
+		if algorithm.pss {
+			return rsa.VerifyPSS(publicKey, algorithm.hash, sum, signature, nil)
+		}
+		return rsa.VerifyPKCS1v15(publicKey, algorithm.hash, sum, signature)
+	case *ecdsa.PublicKey:
+		if algorithm.kty != "EC" || publicKey.Curve != algorithm.curve {
+			break
+		}
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("malformed signature")
+		}
+		r, s := new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(publicKey, sum, r, s) {
+			return errors.New("signature does not match")
+		}
+		return nil
+	}
+	return fmt.Errorf("algorithm '%s' does not match the signing key", alg)
+}
+
+// decodeSegment decodes a base64url-encoded JSON segment of a token into v.
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// stringsClaim returns a claim that is a string or a list of strings as a list.
+func stringsClaim(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
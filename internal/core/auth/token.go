@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTokenFile is the default path of the API token store.
+	DefaultTokenFile = "configs/tokens.json"
+	// TokenPrefix starts every API token, telling them apart from the identity provider's tokens.
+	TokenPrefix = "gtp_"
+)
+
+// Token is an API token for scripts and CI. Only a hash of its secret is stored.
+type Token struct {
+	// Name identifies the token, e.g. the pipeline it was created for.
+	Name string `json:"name"`
+	// Hash is the hex-encoded SHA-256 hash of the token's secret.
+	Hash string `json:"hash"`
+	// Permissions are the permissions granted to requests made with the token.
+	Permissions Grant `json:"permissions"`
+	// CreatedAt is when the token was created.
+	CreatedAt time.Time `json:"createdAt"`
+	// ExpiresAt is when the token stops being accepted. Zero for a token that does not expire.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Expired reports whether the token has expired at now.
+func (t *Token) Expired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && now.After(t.ExpiresAt)
+}
+
+// NewToken creates a token granting permissions, expiring after ttl unless it is zero, and returns
+// it with its secret, which is not stored and cannot be recovered.
+func NewToken(name string, permissions Grant, ttl time.Duration) (*Token, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	secret := TokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	token := &Token{
+		Name:        name,
+		Hash:        hashSecret(secret),
+		Permissions: permissions,
+		CreatedAt:   time.Now(),
+	}
+	if ttl > 0 {
+		token.ExpiresAt = token.CreatedAt.Add(ttl)
+	}
+	return token, secret, nil
+}
+
+// Tokens is the collection of API tokens.
+type Tokens []*Token
+
+// Get returns the token with the given name.
+func (t Tokens) Get(name string) (*Token, bool) {
+	i := slices.IndexFunc(t, func(token *Token) bool { return token.Name == name })
+	if i < 0 {
+		return nil, false
+	}
+	return t[i], true
+}
+
+// Find returns the token whose secret is secret.
+func (t Tokens) Find(secret string) (*Token, bool) {
+	hash := []byte(hashSecret(secret))
+	for _, token := range t {
+		if subtle.ConstantTimeCompare(hash, []byte(token.Hash)) == 1 {
+			return token, true
+		}
+	}
+	return nil, false
+}
+
+// LoadTokens loads the API tokens from the specified file path.
+// If the file does not exist, it returns no tokens.
+func LoadTokens(filePath string) (Tokens, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Tokens{}, nil
+		}
+		return nil, fmt.Errorf("failed to read token file %s: %w", filePath, err)
+	}
+
+	var tokens Tokens
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// SaveTokens saves the API tokens to the specified file path, readable only by its owner.
+func SaveTokens(tokens Tokens, filePath string) error {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tokens: %w", err)
+	}
+	if err := os.WriteFile(filePath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write token file %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// IsToken reports whether a bearer token is an API token rather than one of the identity provider's.
+func IsToken(bearer string) bool {
+	return strings.HasPrefix(bearer, TokenPrefix)
+}
+
+// hashSecret returns the hex-encoded SHA-256 hash of a token secret. The secrets are random, so a
+// fast hash is enough.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
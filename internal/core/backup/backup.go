@@ -0,0 +1,395 @@
+// Package backup snapshots the controller's state into a single archive and restores it: the
+// application and cluster files, the sync, event and audit history, and the configuration files.
+//
+// Secrets, the API token store and the kubeconfig files of the registered clusters, are included
+// encrypted with a passphrase, and left out when no passphrase is given.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/core/alert"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
+	"aeswibon.com/github/gitopsctl/internal/core/auth"
+	"aeswibon.com/github/gitopsctl/internal/core/cluster"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/export"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
+	"aeswibon.com/github/gitopsctl/internal/core/stats"
+)
+
+const (
+	// DefaultPassphraseEnv is the environment variable holding the passphrase secrets are encrypted
+	// with, unless another one is configured.
+	DefaultPassphraseEnv = "GITOPSCTL_BACKUP_PASSPHRASE"
+
+	// formatVersion is the version of the archive format.
+	formatVersion = 1
+	// manifestName is the name of the manifest in the archive.
+	manifestName = "manifest.json"
+	// filesDir is the directory of the archive holding the state files.
+	filesDir = "files/"
+	// secretsName is the name of the encrypted secrets in the archive.
+	secretsName = "secrets.enc"
+
+	// readAttempts is how many times a JSON file that does not parse, because it is being written,
+	// is read before it is backed up as it is.
+	readAttempts = 5
+	// readRetryDelay is how long to wait before reading such a file again.
+	readRetryDelay = 100 * time.Millisecond
+)
+
+// StateFiles are the files of the controller's state included in backups, at their default paths.
+var StateFiles = []string{
+	app.DefaultAppConfigFile,
+	cluster.DefaultClusterConfigFile,
+	app.DefaultArchiveFile,
+	event.DefaultEventFile,
+	stats.DefaultSyncLogFile,
+	audit.DefaultAuditFile,
+	alert.DefaultAlertConfigFile,
+	export.DefaultExportConfigFile,
+	export.DefaultStateFile,
+	render.DefaultPluginConfigFile,
+	auth.DefaultAuthConfigFile,
+	DefaultConfigFile,
+}
+
+// Manifest describes the contents of a backup.
+type Manifest struct {
+	// Version is the version of the archive format.
+	Version int `json:"version"`
+	// CreatedAt is when the backup was created.
+	CreatedAt time.Time `json:"createdAt"`
+	// Files are the paths of the state files in the backup.
+	Files []string `json:"files"`
+	// Secrets are the paths of the encrypted secret files in the backup, empty if it has none.
+	Secrets []string `json:"secrets,omitempty"`
+}
+
+// Create writes a backup of the state files that exist, and of the secrets encrypted with
+// passphrase unless it is empty, to w as a gzip-compressed tar archive, and returns its manifest.
+func Create(w io.Writer, passphrase string) (*Manifest, error) {
+	manifest := &Manifest{Version: formatVersion, CreatedAt: time.Now().UTC()}
+	files := make(map[string][]byte)
+	for _, path := range StateFiles {
+		data, err := readStateFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		files[path] = data
+		manifest.Files = append(manifest.Files, path)
+	}
+
+	var secrets []byte
+	if passphrase != "" {
+		paths, err := secretFiles()
+		if err != nil {
+			return nil, err
+		}
+		contents := make(map[string][]byte)
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			contents[path] = data
+			manifest.Secrets = append(manifest.Secrets, path)
+		}
+		var plain bytes.Buffer
+		if err := writeArchive(&plain, nil, manifest.Secrets, contents); err != nil {
+			return nil, err
+		}
+		if secrets, err = encrypt(plain.Bytes(), passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal backup manifest: %w", err)
+	}
+	entries := map[string][]byte{manifestName: manifestData}
+	names := []string{manifestName}
+	for _, path := range manifest.Files {
+		entries[filesDir+path] = files[path]
+		names = append(names, filesDir+path)
+	}
+	if secrets != nil {
+		entries[secretsName] = secrets
+		names = append(names, secretsName)
+	}
+	if err := writeArchive(w, &manifest.CreatedAt, names, entries); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Archive is a backup read with Open.
+type Archive struct {
+	// Manifest describes the contents of the backup.
+	Manifest Manifest
+	// files are the contents of the state files, by path.
+	files map[string][]byte
+	// secrets are the encrypted secrets, nil if the backup has none.
+	secrets []byte
+}
+
+// Open reads a backup created with Create from r.
+func Open(r io.Reader) (*Archive, error) {
+	entries, err := readArchive(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	manifestData, ok := entries[manifestName]
+	if !ok {
+		return nil, fmt.Errorf("not a gitopsctl backup: %s is missing", manifestName)
+	}
+	a := &Archive{files: make(map[string][]byte), secrets: entries[secretsName]}
+	if err := json.Unmarshal(manifestData, &a.Manifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal backup manifest: %w", err)
+	}
+	if a.Manifest.Version > formatVersion {
+		return nil, fmt.Errorf("backup format version %d is newer than the latest version supported, %d; upgrade gitopsctl", a.Manifest.Version, formatVersion)
+	}
+	for _, path := range a.Manifest.Files {
+		if !slices.Contains(StateFiles, path) {
+			return nil, fmt.Errorf("backup holds unexpected file %s", path)
+		}
+		data, ok := entries[filesDir+path]
+		if !ok {
+			return nil, fmt.Errorf("backup is missing file %s", path)
+		}
+		a.files[path] = data
+	}
+	if len(a.Manifest.Secrets) > 0 && a.secrets == nil {
+		return nil, fmt.Errorf("backup is missing %s", secretsName)
+	}
+	return a, nil
+}
+
+// Restore writes the state files of the backup to their paths, replacing the current ones, and
+// its secrets, decrypted with passphrase, unless passphrase is empty. It returns the paths of the
+// files written.
+//
+// Only the secrets are authenticated, by their encryption, so nothing is written unless they agree
+// with the backed up clusters file: every secret must be the token store or the kubeconfig file of
+// a backed up cluster, and every backed up cluster's kubeconfig file must be among the secrets.
+func (a *Archive) Restore(passphrase string) ([]string, error) {
+	var secrets map[string][]byte
+	if passphrase != "" && a.secrets != nil {
+		plain, err := decrypt(a.secrets, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		if secrets, err = readArchive(bytes.NewReader(plain)); err != nil {
+			return nil, fmt.Errorf("failed to read backed up secrets: %w", err)
+		}
+		if err := a.checkSecrets(secrets); err != nil {
+			return nil, err
+		}
+	}
+
+	var restored []string
+	for _, path := range a.Manifest.Files {
+		if err := writeFile(path, a.files[path], 0644); err != nil {
+			return restored, err
+		}
+		restored = append(restored, path)
+	}
+
+	paths := make([]string, 0, len(secrets))
+	for path := range secrets {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	for _, path := range paths {
+		if err := writeFile(path, secrets[path], 0600); err != nil {
+			return restored, err
+		}
+		restored = append(restored, path)
+	}
+	return restored, nil
+}
+
+// checkSecrets checks the decrypted secrets against the backed up clusters file, or the current one
+// if the backup has none, before anything is restored.
+func (a *Archive) checkSecrets(secrets map[string][]byte) error {
+	var clusters *cluster.Clusters
+	if data, ok := a.files[cluster.DefaultClusterConfigFile]; ok {
+		var err error
+		if clusters, err = cluster.ParseClusters(data); err != nil {
+			return fmt.Errorf("backup holds an invalid clusters file: %w", err)
+		}
+	} else {
+		var err error
+		if clusters, err = cluster.LoadClusters(cluster.DefaultClusterConfigFile); err != nil {
+			return fmt.Errorf("failed to load clusters: %w", err)
+		}
+	}
+
+	allowed := secretFilesOf(clusters)
+	for path := range secrets {
+		if err := checkSecretPath(path); err != nil {
+			return fmt.Errorf("backup holds secret file %s: %w", path, err)
+		}
+		if !slices.Contains(allowed, path) {
+			return fmt.Errorf("backup holds unexpected secret file %s", path)
+		}
+	}
+	for _, path := range a.Manifest.Secrets {
+		if _, ok := secrets[path]; !ok {
+			return fmt.Errorf("backup lists secret file %s, which its encrypted secrets do not hold", path)
+		}
+	}
+	for _, c := range clusters.List() {
+		if c.InCluster || c.KubeconfigPath == "" {
+			continue
+		}
+		if _, ok := secrets[c.KubeconfigPath]; !ok {
+			return fmt.Errorf("backed up cluster '%s' uses kubeconfig %s, which the backup's encrypted secrets do not hold: it was missing when the backup was created, or the backed up clusters file was altered", c.Name, c.KubeconfigPath)
+		}
+	}
+	return nil
+}
+
+// checkSecretPath checks the path a secret is restored to, which must be clean and free of '..'
+// elements. Absolute paths are left to the check against the secret files of the clusters: only
+// kubeconfig files may have one, as clusters are registered with the absolute path of their
+// kubeconfig file, while the token store is always restored to its relative path.
+func checkSecretPath(path string) error {
+	if path == "" || filepath.Clean(path) != path {
+		return errors.New("path is not clean")
+	}
+	if slices.Contains(strings.Split(filepath.ToSlash(path), "/"), "..") {
+		return errors.New("path must not contain '..'")
+	}
+	return nil
+}
+
+// secretFiles returns the paths of the secret files: the API token store and the kubeconfig files
+// of the registered clusters.
+func secretFiles() ([]string, error) {
+	clusters, err := cluster.LoadClusters(cluster.DefaultClusterConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load clusters: %w", err)
+	}
+	return secretFilesOf(clusters), nil
+}
+
+// secretFilesOf returns the paths of the secret files for clusters: the API token store and their
+// kubeconfig files.
+func secretFilesOf(clusters *cluster.Clusters) []string {
+	paths := []string{auth.DefaultTokenFile}
+	for _, c := range clusters.List() {
+		if !c.InCluster && c.KubeconfigPath != "" && !slices.Contains(paths, c.KubeconfigPath) {
+			paths = append(paths, c.KubeconfigPath)
+		}
+	}
+	slices.Sort(paths[1:])
+	return paths
+}
+
+// readStateFile reads a state file. A JSON file that does not parse is read again a few times, in
+// case the controller was writing it.
+func readStateFile(path string) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if filepath.Ext(path) != ".json" || json.Valid(data) || attempt == readAttempts {
+			return data, nil
+		}
+		time.Sleep(readRetryDelay)
+	}
+}
+
+// writeFile writes data to the file at path, creating its directory if needed.
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeArchive writes the entries named by names to w as a gzip-compressed tar archive, with
+// modTime as their modification time, or the current time if it is nil.
+func writeArchive(w io.Writer, modTime *time.Time, names []string, entries map[string][]byte) error {
+	now := time.Now()
+	if modTime != nil {
+		now = *modTime
+	}
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		data := entries[name]
+		header := &tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: now, Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write %s to backup: %w", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s to backup: %w", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return nil
+}
+
+// readArchive reads the regular files of a gzip-compressed tar archive, by name.
+func readArchive(r io.Reader) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	entries := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		entries[header.Name] = data
+	}
+}
@@ -0,0 +1,82 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+const (
+	// keyIterations is the number of PBKDF2-HMAC-SHA256 iterations deriving the key from the passphrase.
+	keyIterations = 600000
+	// saltSize is the size of the random salt the key is derived with.
+	saltSize = 16
+)
+
+// secretsMagic starts encrypted secrets, identifying the format, and is authenticated with them.
+var secretsMagic = []byte("GTOPSEC1")
+
+// encrypt seals plaintext with AES-256-GCM, under a key derived from passphrase with PBKDF2 and a
+// random salt. The result holds the magic, the salt, the nonce and the ciphertext.
+func encrypt(plaintext []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(secretsMagic)+len(salt)+len(nonce)+len(plaintext)+gcm.Overhead())
+	out = append(out, secretsMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, plaintext, secretsMagic), nil
+}
+
+// decrypt opens secrets sealed by encrypt with passphrase.
+func decrypt(data []byte, passphrase string) ([]byte, error) {
+	if !bytes.HasPrefix(data, secretsMagic) {
+		return nil, errors.New("backed up secrets are in an unknown format")
+	}
+	data = data[len(secretsMagic):]
+	if len(data) < saltSize {
+		return nil, errors.New("backed up secrets are truncated")
+	}
+	gcm, err := newGCM(passphrase, data[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	data = data[saltSize:]
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("backed up secrets are truncated")
+	}
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], secretsMagic)
+	if err != nil {
+		return nil, errors.New("failed to decrypt backed up secrets: wrong passphrase or corrupted backup")
+	}
+	return plaintext, nil
+}
+
+// newGCM returns AES-256-GCM with the key derived from passphrase and salt.
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, keyIterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,184 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultAccessKeyIDEnv is the environment variable holding the S3 access key ID by default.
+	defaultAccessKeyIDEnv = "AWS_ACCESS_KEY_ID"
+	// defaultSecretAccessKeyEnv is the environment variable holding the S3 secret access key by default.
+	defaultSecretAccessKeyEnv = "AWS_SECRET_ACCESS_KEY"
+	// defaultSessionTokenEnv is the environment variable holding the S3 session token by default.
+	defaultSessionTokenEnv = "AWS_SESSION_TOKEN"
+	// uploadTimeout is the timeout for uploading a backup.
+	uploadTimeout = 5 * time.Minute
+	// maxErrorBody is how much of an error response is kept in the error.
+	maxErrorBody = 512
+)
+
+// S3Settings configure where backups are uploaded, in Amazon S3 or an S3-compatible object store.
+type S3Settings struct {
+	// Bucket is the bucket backups are uploaded to.
+	Bucket string `json:"bucket"`
+	// Prefix is prepended to the names of the backups (e.g., "gitopsctl/").
+	Prefix string `json:"prefix,omitempty"`
+	// Region is the region of the bucket (e.g., "eu-west-1").
+	Region string `json:"region"`
+	// Endpoint is the URL of an S3-compatible object store, such as MinIO, whose buckets are
+	// addressed by path. Empty uses Amazon S3 in Region.
+	Endpoint string `json:"endpoint,omitempty"`
+	// AccessKeyIDEnv names the environment variable holding the access key ID. Defaults to AWS_ACCESS_KEY_ID.
+	AccessKeyIDEnv string `json:"accessKeyIDEnv,omitempty"`
+	// SecretAccessKeyEnv names the environment variable holding the secret access key. Defaults to
+	// AWS_SECRET_ACCESS_KEY.
+	SecretAccessKeyEnv string `json:"secretAccessKeyEnv,omitempty"`
+	// SessionTokenEnv names the environment variable holding the session token of temporary
+	// credentials, sent if it is set. Defaults to AWS_SESSION_TOKEN.
+	SessionTokenEnv string `json:"sessionTokenEnv,omitempty"`
+}
+
+// validate checks the settings for errors and applies their defaults.
+func (s *S3Settings) validate() error {
+	if s.Bucket == "" {
+		return fmt.Errorf("s3: bucket is required")
+	}
+	if s.Region == "" {
+		return fmt.Errorf("s3: region is required")
+	}
+	if s.Endpoint != "" {
+		if u, err := url.Parse(s.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("s3: invalid endpoint '%s', expected an http or https URL", s.Endpoint)
+		}
+	}
+	if s.AccessKeyIDEnv == "" {
+		s.AccessKeyIDEnv = defaultAccessKeyIDEnv
+	}
+	if s.SecretAccessKeyEnv == "" {
+		s.SecretAccessKeyEnv = defaultSecretAccessKeyEnv
+	}
+	if s.SessionTokenEnv == "" {
+		s.SessionTokenEnv = defaultSessionTokenEnv
+	}
+	return nil
+}
+
+// objectURL returns the URL of the object with the given key.
+func (s *S3Settings) objectURL(key string) string {
+	if s.Endpoint == "" {
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.Bucket, s.Region, escapePath(key))
+	}
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(s.Endpoint, "/"), s.Bucket, escapePath(key))
+}
+
+// Upload uploads data as the object with the given key, prefixed with Prefix, and returns its URL.
+func (s *S3Settings) Upload(ctx context.Context, key string, data []byte) (string, error) {
+	accessKeyID := os.Getenv(s.AccessKeyIDEnv)
+	secretAccessKey := os.Getenv(s.SecretAccessKeyEnv)
+	if accessKeyID == "" || secretAccessKey == "" {
+		return "", fmt.Errorf("s3 credentials are not set: %s and %s are required", s.AccessKeyIDEnv, s.SecretAccessKeyEnv)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uploadTimeout)
+	defer cancel()
+	objectURL := s.objectURL(s.Prefix + key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL, bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	if token := os.Getenv(s.SessionTokenEnv); token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+	signV4(req, data, s.Region, accessKeyID, secretAccessKey, time.Now())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload backup to %s: %w", objectURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return "", fmt.Errorf("failed to upload backup to %s: %s: %s", objectURL, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return objectURL, nil
+}
+
+// signV4 signs an S3 request with AWS Signature Version 4, signing its host, its x-amz-* headers
+// and its payload.
+func signV4(req *http.Request, payload []byte, region, accessKeyID, secretAccessKey string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signed := []string{"host"}
+	canonicalHeaders := "host:" + req.URL.Host + "\n"
+	for _, name := range []string{"x-amz-content-sha256", "x-amz-date", "x-amz-security-token"} {
+		if value := req.Header.Get(name); value != "" {
+			signed = append(signed, name)
+			canonicalHeaders += name + ":" + strings.TrimSpace(value) + "\n"
+		}
+	}
+	signedHeaders := strings.Join(signed, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := []byte("AWS4" + secretAccessKey)
+	for _, part := range []string{date, region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+// escapePath escapes an object key as S3 expects in the canonical request: every byte but the
+// unreserved characters and slashes is percent-encoded.
+func escapePath(key string) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-._~/", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// sha256Hex returns the hex-encoded SHA-256 hash of data.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data with key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,134 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultConfigFile is the default path of the scheduled backup configuration.
+const DefaultConfigFile = "configs/backup.json"
+
+// Config configures the backups the controller creates on a schedule.
+type Config struct {
+	// Interval is how often a backup is created (e.g., "24h"). Empty disables scheduled backups.
+	Interval string `json:"interval,omitempty"`
+	// PassphraseEnv names the environment variable holding the passphrase secrets are encrypted
+	// with. Defaults to GITOPSCTL_BACKUP_PASSPHRASE; while it is not set, backups leave the secrets out.
+	PassphraseEnv string `json:"passphraseEnv,omitempty"`
+	// S3 is where backups are uploaded. It is required with Interval.
+	S3 *S3Settings `json:"s3,omitempty"`
+
+	// interval is the parsed Interval.
+	interval time.Duration
+}
+
+// LoadConfig loads and validates the scheduled backup configuration from the specified file path.
+// If the file does not exist, it returns an empty configuration with scheduled backups disabled.
+func LoadConfig(filePath string) (*Config, error) {
+	config := &Config{}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read backup config file %s: %w", filePath, err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal backup config: %w", err)
+		}
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// validate checks the configuration for errors and applies its defaults.
+func (c *Config) validate() error {
+	if c.PassphraseEnv == "" {
+		c.PassphraseEnv = DefaultPassphraseEnv
+	}
+	if c.S3 != nil {
+		if err := c.S3.validate(); err != nil {
+			return err
+		}
+	}
+	if c.Interval == "" {
+		return nil
+	}
+	var err error
+	if c.interval, err = time.ParseDuration(c.Interval); err != nil || c.interval <= 0 {
+		return fmt.Errorf("invalid backup interval '%s'", c.Interval)
+	}
+	if c.S3 == nil {
+		return fmt.Errorf("s3 is required with a backup interval")
+	}
+	return nil
+}
+
+// Scheduled reports whether the controller creates backups on a schedule.
+func (c *Config) Scheduled() bool {
+	return c.interval > 0
+}
+
+// Passphrase returns the passphrase secrets are encrypted with, empty if it is not set.
+func (c *Config) Passphrase() string {
+	return os.Getenv(c.PassphraseEnv)
+}
+
+// Name returns the name of a backup created at t.
+func Name(t time.Time) string {
+	return "gitopsctl-backup-" + t.UTC().Format("20060102T150405Z") + ".tar.gz"
+}
+
+// CreateAndUpload creates a backup, with the secrets encrypted with passphrase unless it is empty,
+// and uploads it to the configured S3 target, returning its URL.
+func (c *Config) CreateAndUpload(ctx context.Context, passphrase string) (string, *Manifest, error) {
+	if c.S3 == nil {
+		return "", nil, fmt.Errorf("no s3 target is configured")
+	}
+	var buf bytes.Buffer
+	manifest, err := Create(&buf, passphrase)
+	if err != nil {
+		return "", nil, err
+	}
+	url, err := c.S3.Upload(ctx, Name(manifest.CreatedAt), buf.Bytes())
+	if err != nil {
+		return "", nil, err
+	}
+	return url, manifest, nil
+}
+
+// Run creates a backup and uploads it at every interval until ctx is cancelled. It returns at once
+// if scheduled backups are disabled.
+func (c *Config) Run(ctx context.Context, logger *zap.Logger) {
+	if !c.Scheduled() {
+		return
+	}
+	if c.Passphrase() == "" {
+		logger.Warn("Scheduled backups leave secrets out: the passphrase to encrypt them with is not set", zap.String("env", c.PassphraseEnv))
+	}
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		url, manifest, err := c.CreateAndUpload(ctx, c.Passphrase())
+		if err != nil {
+			logger.Error("Scheduled backup failed", zap.Error(err))
+			continue
+		}
+		logger.Info("Uploaded scheduled backup",
+			zap.String("url", url),
+			zap.Int("files", len(manifest.Files)),
+			zap.Int("secrets", len(manifest.Secrets)))
+	}
+}
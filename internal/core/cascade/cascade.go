@@ -0,0 +1,40 @@
+package cascade
+
+import (
+	"context"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
+)
+
+// Delete deletes the managed resources recorded for an application from its cluster.
+//
+// Only the application's inventory (see app.Application.ManagedResources) is used, so resources
+// it never applied are left alone, and resources protected from deletion (see
+// k8s.IsDeletionProtected) are kept. It returns the deleted and the protected resources along with
+// any errors encountered; the application should only be unregistered once no errors remain.
+//
+// If the application's syncs are recorded as a Helm release, the release's revisions are removed
+// once its resources are deleted, like helm uninstall does.
+func Delete(ctx context.Context, client *k8s.ClientSet, a *app.Application) ([]k8s.ResourceRef, []k8s.ResourceRef, []error) {
+	refs := make([]k8s.ResourceRef, 0, len(a.ManagedResources))
+	for _, m := range a.ManagedResources {
+		refs = append(refs, k8s.ResourceRef{Kind: m.Kind, Namespace: m.Namespace, Name: m.Name})
+	}
+	var (
+		deleted, protected []k8s.ResourceRef
+		errs               []error
+	)
+	if len(refs) > 0 {
+		deleted, protected, errs = client.DeleteResources(ctx, refs)
+	}
+	if len(errs) == 0 && a.Helm != nil && a.Helm.RecordRelease {
+		namespace := common.DefaultIfEmpty(a.Helm.Namespace, render.DefaultHelmNamespace)
+		if err := client.DeleteHelmRelease(ctx, namespace, a.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return deleted, protected, errs
+}
@@ -0,0 +1,78 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// AKSOptions identifies an Azure Kubernetes Service cluster.
+type AKSOptions struct {
+	// Cluster is the name of the AKS cluster.
+	Cluster string
+	// ResourceGroup is the resource group of the cluster.
+	ResourceGroup string
+	// Subscription is the Azure subscription of the cluster. Empty uses the az CLI's default subscription.
+	Subscription string
+	// Admin gets the cluster's admin credentials instead of the user credentials.
+	Admin bool
+}
+
+// AKSKubeconfig gets the credentials of an AKS cluster with the az CLI and returns its kubeconfig.
+//
+// Clusters with Microsoft Entra ID integration authenticate with kubelogin; the login mode is set
+// to azurecli, so that tokens are obtained from the az CLI's login instead of an interactive device
+// code flow the controller cannot complete.
+func AKSKubeconfig(ctx context.Context, opts AKSOptions) (*clientcmdapi.Config, error) {
+	if opts.Cluster == "" || opts.ResourceGroup == "" {
+		return nil, fmt.Errorf("the AKS cluster name and resource group are required")
+	}
+	args := []string{"aks", "get-credentials", "--name", opts.Cluster, "--resource-group", opts.ResourceGroup, "--file", "-", "--only-show-errors"}
+	if opts.Subscription != "" {
+		args = append(args, "--subscription", opts.Subscription)
+	}
+	if opts.Admin {
+		args = append(args, "--admin")
+	}
+	output, err := run(ctx, "az", args...)
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.Load(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the kubeconfig of AKS cluster '%s': %w", opts.Cluster, err)
+	}
+	if _, ok := config.Contexts[config.CurrentContext]; !ok {
+		return nil, fmt.Errorf("the kubeconfig of AKS cluster '%s' has no current context", opts.Cluster)
+	}
+
+	for _, user := range config.AuthInfos {
+		if user.Exec == nil {
+			continue
+		}
+		user.Exec.InteractiveMode = clientcmdapi.NeverExecInteractiveMode
+		if user.Exec.Command == "kubelogin" {
+			user.Exec.Args = azureCLILogin(user.Exec.Args)
+		}
+	}
+	return config, nil
+}
+
+// azureCLILogin returns the arguments of 'kubelogin get-token' with the login mode set to azurecli,
+// dropping the arguments only the other modes use.
+func azureCLILogin(args []string) []string {
+	var converted []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--login", "-l":
+			i++ // Replaced below
+		case "--client-id", "--client-secret", "--client-certificate":
+			i++
+		default:
+			converted = append(converted, args[i])
+		}
+	}
+	return append(converted, "--login", "azurecli")
+}
@@ -0,0 +1,105 @@
+// Package cloud builds kubeconfigs for managed Kubernetes clusters (EKS, GKE and AKS) from the
+// credentials of their cloud CLIs.
+//
+// The kubeconfigs authenticate with the exec credential plugin of each cloud, so the controller
+// obtains short-lived tokens from the CLI's login instead of storing long-lived credentials.
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// DefaultKubeconfigDir is the directory the kubeconfigs of registered cloud clusters are written to.
+	DefaultKubeconfigDir = "configs/kubeconfigs"
+
+	// execAPIVersion is the version of the exec credential API the plugins are configured with.
+	execAPIVersion = "client.authentication.k8s.io/v1beta1"
+	// maxOutput is how much of a failed command's output is kept in its error.
+	maxOutput = 2000
+)
+
+// KubeconfigPath returns the path the kubeconfig of a cluster registered from a cloud is written to.
+func KubeconfigPath(clusterName string) string {
+	return filepath.Join(DefaultKubeconfigDir, clusterName+".yaml")
+}
+
+// WriteKubeconfig writes a kubeconfig to path, readable only by its owner, creating its directory
+// if needed.
+func WriteKubeconfig(path string, config *clientcmdapi.Config) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create kubeconfig directory: %w", err)
+	}
+	if err := clientcmd.WriteToFile(*config, path); err != nil {
+		return fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+	}
+	return nil
+}
+
+// newKubeconfig returns a kubeconfig with a single context, named contextName, connecting to server
+// with the base64-encoded CA certificate and authenticating with an exec credential plugin.
+func newKubeconfig(contextName, server, caData string, execConfig *clientcmdapi.ExecConfig) (*clientcmdapi.Config, error) {
+	ca, err := base64.StdEncoding.DecodeString(caData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate authority data: %w", err)
+	}
+	if execConfig.APIVersion == "" {
+		execConfig.APIVersion = execAPIVersion
+	}
+	// The controller runs unattended, so plugins must never prompt
+	execConfig.InteractiveMode = clientcmdapi.NeverExecInteractiveMode
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters[contextName] = &clientcmdapi.Cluster{Server: server, CertificateAuthorityData: ca}
+	config.AuthInfos[contextName] = &clientcmdapi.AuthInfo{Exec: execConfig}
+	config.Contexts[contextName] = &clientcmdapi.Context{Cluster: contextName, AuthInfo: contextName}
+	config.CurrentContext = contextName
+	return config, nil
+}
+
+// runJSON runs a cloud CLI and decodes the JSON it prints into v.
+func runJSON(ctx context.Context, v any, binary string, args ...string) error {
+	output, err := run(ctx, binary, args...)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(output, v); err != nil {
+		return fmt.Errorf("failed to parse the output of %s %s: %w", binary, args[0], err)
+	}
+	return nil
+}
+
+// run runs a cloud CLI with the given arguments and returns its output.
+func run(ctx context.Context, binary string, args ...string) ([]byte, error) {
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("the '%s' CLI is required in PATH: %w", binary, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		output := strings.TrimSpace(stderr.String())
+		if output == "" {
+			output = strings.TrimSpace(stdout.String())
+		}
+		if len(output) > maxOutput {
+			output = "..." + output[len(output)-maxOutput:]
+		}
+		return nil, fmt.Errorf("%s %s failed: %w: %s", binary, strings.Join(args[:min(2, len(args))], " "), err, output)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,62 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// EKSOptions identifies an Amazon EKS cluster and how to authenticate with it.
+type EKSOptions struct {
+	// Cluster is the name of the EKS cluster.
+	Cluster string
+	// Region is the AWS region of the cluster.
+	Region string
+	// Profile is the AWS CLI profile to use. Empty uses the default credential chain.
+	Profile string
+	// RoleARN is an IAM role assumed to get the token. Empty uses the profile's identity.
+	RoleARN string
+}
+
+// EKSKubeconfig describes an EKS cluster with the AWS CLI and returns a kubeconfig for it that gets
+// its tokens with 'aws eks get-token'.
+func EKSKubeconfig(ctx context.Context, opts EKSOptions) (*clientcmdapi.Config, error) {
+	if opts.Cluster == "" || opts.Region == "" {
+		return nil, fmt.Errorf("the EKS cluster name and region are required")
+	}
+	args := []string{"eks", "describe-cluster", "--name", opts.Cluster, "--region", opts.Region, "--output", "json"}
+	if opts.Profile != "" {
+		args = append(args, "--profile", opts.Profile)
+	}
+	var described struct {
+		Cluster struct {
+			Arn                  string `json:"arn"`
+			Endpoint             string `json:"endpoint"`
+			Status               string `json:"status"`
+			CertificateAuthority struct {
+				Data string `json:"data"`
+			} `json:"certificateAuthority"`
+		} `json:"cluster"`
+	}
+	if err := runJSON(ctx, &described, "aws", args...); err != nil {
+		return nil, err
+	}
+	if described.Cluster.Endpoint == "" {
+		return nil, fmt.Errorf("EKS cluster '%s' has no endpoint yet (status %s)", opts.Cluster, described.Cluster.Status)
+	}
+
+	execArgs := []string{"eks", "get-token", "--cluster-name", opts.Cluster, "--region", opts.Region, "--output", "json"}
+	if opts.RoleARN != "" {
+		execArgs = append(execArgs, "--role-arn", opts.RoleARN)
+	}
+	execConfig := &clientcmdapi.ExecConfig{
+		Command:     "aws",
+		Args:        execArgs,
+		InstallHint: "Install the AWS CLI: https://docs.aws.amazon.com/cli/latest/userguide/getting-started-install.html",
+	}
+	if opts.Profile != "" {
+		execConfig.Env = []clientcmdapi.ExecEnvVar{{Name: "AWS_PROFILE", Value: opts.Profile}}
+	}
+	return newKubeconfig(described.Cluster.Arn, described.Cluster.Endpoint, described.Cluster.CertificateAuthority.Data, execConfig)
+}
@@ -0,0 +1,66 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// GKEOptions identifies a Google Kubernetes Engine cluster.
+type GKEOptions struct {
+	// Cluster is the name of the GKE cluster.
+	Cluster string
+	// Location is the region or zone of the cluster.
+	Location string
+	// Project is the Google Cloud project of the cluster. Empty uses the gcloud CLI's default project.
+	Project string
+	// InternalIP connects to the private endpoint of the cluster instead of its public one.
+	InternalIP bool
+}
+
+// GKEKubeconfig describes a GKE cluster with the gcloud CLI and returns a kubeconfig for it that
+// gets its tokens with gke-gcloud-auth-plugin.
+func GKEKubeconfig(ctx context.Context, opts GKEOptions) (*clientcmdapi.Config, error) {
+	if opts.Cluster == "" || opts.Location == "" {
+		return nil, fmt.Errorf("the GKE cluster name and location are required")
+	}
+	args := []string{"container", "clusters", "describe", opts.Cluster, "--location", opts.Location, "--format", "json"}
+	if opts.Project != "" {
+		args = append(args, "--project", opts.Project)
+	}
+	var described struct {
+		Endpoint   string `json:"endpoint"`
+		Status     string `json:"status"`
+		MasterAuth struct {
+			ClusterCACertificate string `json:"clusterCaCertificate"`
+		} `json:"masterAuth"`
+		PrivateClusterConfig struct {
+			PrivateEndpoint string `json:"privateEndpoint"`
+		} `json:"privateClusterConfig"`
+	}
+	if err := runJSON(ctx, &described, "gcloud", args...); err != nil {
+		return nil, err
+	}
+
+	endpoint := described.Endpoint
+	if opts.InternalIP {
+		if endpoint = described.PrivateClusterConfig.PrivateEndpoint; endpoint == "" {
+			return nil, fmt.Errorf("GKE cluster '%s' has no private endpoint", opts.Cluster)
+		}
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("GKE cluster '%s' has no endpoint yet (status %s)", opts.Cluster, described.Status)
+	}
+
+	execConfig := &clientcmdapi.ExecConfig{
+		Command:            "gke-gcloud-auth-plugin",
+		ProvideClusterInfo: true,
+		InstallHint:        "Install gke-gcloud-auth-plugin: gcloud components install gke-gcloud-auth-plugin",
+	}
+	contextName := "gke_" + opts.Project + "_" + opts.Location + "_" + opts.Cluster
+	if opts.Project == "" {
+		contextName = "gke_" + opts.Location + "_" + opts.Cluster
+	}
+	return newKubeconfig(contextName, "https://"+endpoint, described.MasterAuth.ClusterCACertificate, execConfig)
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 	"sync"
 	"time"
 
@@ -15,26 +14,170 @@ import (
 const (
 	// DefaultClusterHealthCheckInterval is the default interval for checking cluster health.
 	DefaultClusterHealthCheckInterval = 5 * time.Minute
+	// MinClusterHealthCheckInterval is the shortest health check interval a cluster can be configured with.
+	MinClusterHealthCheckInterval = 10 * time.Second
 	// DefaultClusterConfigFile is the default path to store registered clusters
 	DefaultClusterConfigFile = "configs/clusters.json"
+	// InClusterName is the name the cluster the controller runs in is registered under.
+	InClusterName = "in-cluster"
+	// MaxHealthHistory is the number of most recent health checks retained per cluster.
+	MaxHealthHistory = 10
 )
 
+// HealthCheckEvent records the outcome of a single cluster health check.
+type HealthCheckEvent struct {
+	// Time is when the health check was performed.
+	Time time.Time `json:"time"`
+	// Status is the resulting cluster status (e.g., "Active", "Unreachable").
+	Status Status `json:"status"`
+	// Message describes the outcome of the health check.
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterHealth records the diagnostics gathered by the last successful health check of a cluster.
+type ClusterHealth struct {
+	// ServerVersion is the Kubernetes version reported by the API server (e.g., v1.30.2).
+	ServerVersion string `json:"serverVersion,omitempty"`
+	// APILatency is how long the API server took to respond to the version request.
+	APILatency time.Duration `json:"apiLatency,omitempty"`
+	// Nodes is the number of nodes in the cluster, or -1 if they could not be listed.
+	Nodes int `json:"nodes"`
+	// ReadyNodes is the number of nodes that are ready.
+	ReadyNodes int `json:"readyNodes"`
+	// NodeListLatency is how long listing the cluster's nodes took.
+	NodeListLatency time.Duration `json:"nodeListLatency,omitempty"`
+	// CertificateExpiry is when the kubeconfig's client certificate expires; zero if it does not use one.
+	CertificateExpiry time.Time `json:"certificateExpiry,omitempty"`
+	// CPUAllocatable and CPURequested are the CPU, in millicores, allocatable on the cluster's nodes
+	// and requested by its running pods. They are only recorded while applications use placement.
+	CPUAllocatable int64 `json:"cpuAllocatableMillis,omitempty"`
+	CPURequested   int64 `json:"cpuRequestedMillis,omitempty"`
+	// MemoryAllocatable and MemoryRequested are the same figures for memory, in bytes.
+	MemoryAllocatable int64 `json:"memoryAllocatableBytes,omitempty"`
+	MemoryRequested   int64 `json:"memoryRequestedBytes,omitempty"`
+	// Warnings lists the problems found by the health check, such as nodes that are not ready.
+	Warnings []string `json:"warnings,omitempty"`
+	// CheckedAt is when the diagnostics were gathered.
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
 // Cluster represents a registered Kubernetes cluster.
 // It contains the cluster name, path to the kubeconfig file, registration time,
 // and optional status and message fields for error handling or status reporting.
 type Cluster struct {
 	// Name is the unique identifier for the cluster.
-	Name string `json:"name"`
-	// KubeconfigPath is the path to the kubeconfig file for this cluster.
+	Name string `json:"name" schema:"required"`
+	// KubeconfigPath is the path to the kubeconfig file for this cluster. It is empty for an
+	// in-cluster cluster.
 	KubeconfigPath string `json:"kubeconfigPath"`
+	// InCluster reports whether the cluster is the one the controller runs in, connected to with
+	// the service account of its pod instead of a kubeconfig file.
+	InCluster bool `json:"inCluster,omitempty"`
+	// QPS limits the queries per second the controller sends to the cluster's API server.
+	// Zero uses the default of k8s.DefaultQPS.
+	QPS float32 `json:"qps,omitempty"`
+	// Burst is the maximum burst of queries allowed above QPS. Zero uses the default of k8s.DefaultBurst.
+	Burst int `json:"burst,omitempty"`
+	// MaxConcurrentApplies limits how many applications apply their manifests to the cluster at the
+	// same time; the others wait for their turn. Zero leaves applies unlimited.
+	MaxConcurrentApplies int `json:"maxConcurrentApplies,omitempty"`
+	// ImpersonateUser is the user the resources of the applications deployed to the cluster are
+	// applied as, with the cluster's credentials impersonating it, unless an application sets its own.
+	// Health checks use the credentials as they are.
+	ImpersonateUser string `json:"impersonateUser,omitempty"`
+	// ImpersonateGroups are the groups impersonated along with ImpersonateUser.
+	ImpersonateGroups []string `json:"impersonateGroups,omitempty"`
+	// HealthCheckInterval is how often the controller checks the cluster's health (e.g., "1m").
+	// Empty uses DefaultClusterHealthCheckInterval.
+	HealthCheckInterval string `json:"healthCheckInterval,omitempty" schema:"duration"`
+	// Variables are substituted into the manifests of the applications deployed to the cluster that
+	// use variable substitution, unless an application defines a variable of the same name.
+	Variables map[string]string `json:"variables,omitempty"`
+	// Labels describe the cluster (e.g., env=prod, region=eu), so that applications with a placement
+	// select the clusters they may be deployed to by them.
+	Labels map[string]string `json:"labels,omitempty"`
 	// RegisteredAt is the time when the cluster was registered.
 	RegisteredAt time.Time `json:"registeredAt"`
 	// Status and Message are optional fields for reporting the cluster's status.
-	Status string `json:"status,omitempty"`
+	Status Status `json:"status,omitempty"`
 	// Message can contain additional information about the cluster's status.
 	Message string `json:"message,omitempty"`
 	// LastCheckedAt is the last time the cluster was checked for status updates.
 	LastCheckedAt time.Time `json:"lastCheckedAt,omitempty"`
+	// Health holds the diagnostics from the last health check that reached the cluster.
+	Health *ClusterHealth `json:"health,omitempty"`
+	// HealthHistory holds the most recent health check results, oldest first.
+	HealthHistory []HealthCheckEvent `json:"healthHistory,omitempty"`
+	// Conditions describe additional aspects of the cluster's state, such as whether it is alerting.
+	Conditions []common.Condition `json:"conditions,omitempty"`
+}
+
+// ConfigSource describes where the cluster's connection settings come from: its kubeconfig file, or
+// the controller pod's service account for an in-cluster cluster.
+func (c *Cluster) ConfigSource() string {
+	if c.InCluster {
+		return "in-cluster service account"
+	}
+	return c.KubeconfigPath
+}
+
+// MatchesSearch reports whether the cluster's name, kubeconfig path or message contains term,
+// ignoring case. An empty term matches every cluster.
+func (c *Cluster) MatchesSearch(term string) bool {
+	return common.MatchesSearch(term, c.Name, c.ConfigSource(), c.Message)
+}
+
+// EffectiveHealthCheckInterval returns how often the cluster is health checked.
+// An empty or invalid HealthCheckInterval falls back to DefaultClusterHealthCheckInterval.
+func (c *Cluster) EffectiveHealthCheckInterval() time.Duration {
+	if c.HealthCheckInterval == "" {
+		return DefaultClusterHealthCheckInterval
+	}
+	interval, err := time.ParseDuration(c.HealthCheckInterval)
+	if err != nil || interval < MinClusterHealthCheckInterval {
+		return DefaultClusterHealthCheckInterval
+	}
+	return interval
+}
+
+// ValidateHealthCheckInterval checks that a health check interval is a duration of at least
+// MinClusterHealthCheckInterval. An empty interval selects the default.
+func ValidateHealthCheckInterval(interval string) error {
+	if interval == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return fmt.Errorf("invalid health check interval '%s': %w", interval, err)
+	}
+	if d < MinClusterHealthCheckInterval {
+		return fmt.Errorf("health check interval must be at least %s", MinClusterHealthCheckInterval)
+	}
+	return nil
+}
+
+// RecordHealthCheck appends a health check result to the cluster's history,
+// discarding the oldest entries beyond MaxHealthHistory.
+func (c *Cluster) RecordHealthCheck(event HealthCheckEvent) {
+	c.HealthHistory = append(c.HealthHistory, event)
+	if len(c.HealthHistory) > MaxHealthHistory {
+		c.HealthHistory = c.HealthHistory[len(c.HealthHistory)-MaxHealthHistory:]
+	}
+}
+
+// StatusStreak returns when the cluster entered its current status and how many
+// consecutive health checks reported it, based on the most recent health history.
+func (c *Cluster) StatusStreak() (time.Time, int) {
+	var since time.Time
+	count := 0
+	for i := len(c.HealthHistory) - 1; i >= 0; i-- {
+		if c.HealthHistory[i].Status != c.Status {
+			break
+		}
+		since = c.HealthHistory[i].Time
+		count++
+	}
+	return since, count
 }
 
 // Clusters represents a thread-safe collection of Cluster objects.
@@ -110,9 +253,45 @@ func (c *Clusters) Delete(name string) {
 	delete(c.Cs, name)
 }
 
+// Rename changes the name of a cluster and re-keys it in the collection.
+// Applications deployed to the cluster must be updated separately (see app.Applications.RenameCluster).
+// This method is not thread-safe and should be called with the write lock held.
+func (c *Clusters) Rename(oldName, newName string) error {
+	cluster, ok := c.Cs[oldName]
+	if !ok {
+		return fmt.Errorf("cluster '%s' not found", oldName)
+	}
+	if _, exists := c.Cs[newName]; exists {
+		return fmt.Errorf("cluster '%s' already exists", newName)
+	}
+	delete(c.Cs, oldName)
+	cluster.Name = newName
+	c.Cs[newName] = cluster
+	return nil
+}
+
+// Merge synchronizes the collection with the clusters in src.
+// Existing entries are updated in place, new entries are added and entries missing from src are removed.
+// This method is not thread-safe and should be called with the write lock held.
+func (c *Clusters) Merge(src *Clusters) {
+	for name, cluster := range src.Cs {
+		if existing, ok := c.Cs[name]; ok {
+			*existing = *cluster
+			continue
+		}
+		c.Cs[name] = cluster
+	}
+	for name := range c.Cs {
+		if _, ok := src.Cs[name]; !ok {
+			delete(c.Cs, name)
+		}
+	}
+}
+
 // LoadClusters loads clusters from the specified file path.
-// It reads the JSON data from the file, unmarshals it into Cluster objects,
-// and populates the Clusters collection. If the file does not exist, it returns an empty collection.
+// It reads the JSON data from the file, unmarshals it into Cluster objects, upgrading files of
+// older versions in memory, and populates the Clusters collection. If the file does not exist,
+// it returns an empty collection.
 // This function acquires its own lock as it's typically called at startup.
 func LoadClusters(filePath string) (*Clusters, error) {
 	clusters := NewClusters()
@@ -127,9 +306,9 @@ func LoadClusters(filePath string) (*Clusters, error) {
 		return nil, fmt.Errorf("failed to read clusters file %s: %w", filePath, err)
 	}
 
-	var loadedClusters []*Cluster
-	if err := json.Unmarshal(data, &loadedClusters); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal clusters data: %w", err)
+	loadedClusters, _, err := decodeClusters(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clusters file %s:\n%w", filePath, err)
 	}
 
 	for _, cluster := range loadedClusters {
@@ -139,6 +318,20 @@ func LoadClusters(filePath string) (*Clusters, error) {
 	return clusters, nil
 }
 
+// ParseClusters decodes the contents of a clusters file, such as one read from a backup, upgrading
+// files of older versions in memory.
+func ParseClusters(data []byte) (*Clusters, error) {
+	loadedClusters, _, err := decodeClusters(data)
+	if err != nil {
+		return nil, err
+	}
+	clusters := NewClusters()
+	for _, cluster := range loadedClusters {
+		clusters.Cs[cluster.Name] = cluster
+	}
+	return clusters, nil
+}
+
 // SaveClusters saves the current state of clusters to the specified file path.
 // It serializes the Clusters collection to JSON and writes it to the file.
 // If the directory does not exist, it creates it.
@@ -156,7 +349,7 @@ func SaveClusters(clusters *Clusters, filePath string) error {
 		list = append(list, cluster)
 	}
 
-	data, err := json.MarshalIndent(list, "", "  ")
+	data, err := json.MarshalIndent(clustersFile{Version: FileVersion, Clusters: list}, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal clusters data: %w", err)
 	}
@@ -207,7 +400,7 @@ func (c *Cluster) ToTableRow(details bool) []string {
 		return []string{
 			c.Name,
 			status,
-			common.TruncateString(c.KubeconfigPath, 30),
+			common.TruncateString(c.ConfigSource(), 30),
 			common.TruncateString(c.Message, 40),
 			c.RegisteredAt.Format("2006-01-02 15:04:05 MST"), // Consistent time format
 			lastChecked,
@@ -216,11 +409,28 @@ func (c *Cluster) ToTableRow(details bool) []string {
 	return []string{
 		c.Name,
 		status,
-		common.TruncateString(c.KubeconfigPath, 40),
+		common.TruncateString(c.ConfigSource(), 40),
 		c.RegisteredAt.Format("2006-01-02 15:04:05 MST"), // Consistent time format
 	}
 }
 
+// ToRecord implements cliutils.Recorder for csv output.
+// It returns the detailed table columns with full values, the plain status and RFC 3339 times.
+func (c *Cluster) ToRecord() []string {
+	lastChecked := ""
+	if !c.LastCheckedAt.IsZero() {
+		lastChecked = c.LastCheckedAt.Format(time.RFC3339)
+	}
+	return []string{
+		c.Name,
+		string(c.Status),
+		c.ConfigSource(),
+		c.Message,
+		c.RegisteredAt.Format(time.RFC3339),
+		lastChecked,
+	}
+}
+
 // ToJSONMap implements cliutils.Renderable for JSON output.
 // It formats the cluster information into a map suitable for JSON serialization.
 func (c *Cluster) ToJSONMap() map[string]any {
@@ -228,51 +438,41 @@ func (c *Cluster) ToJSONMap() map[string]any {
 	if !c.LastCheckedAt.IsZero() {
 		lastCheckedAt = c.LastCheckedAt.Format(time.RFC3339)
 	}
-	return map[string]any{
+	m := map[string]any{
 		"name":            c.Name,
 		"status":          c.Status,
 		"kubeconfig_path": c.KubeconfigPath,
+		"in_cluster":      c.InCluster,
 		"message":         c.Message,
 		"registered_at":   c.RegisteredAt.Format(time.RFC3339),
 		"last_checked_at": lastCheckedAt,
 	}
-}
-
-// ToYAMLString implements cliutils.Renderable for YAML output.
-// It formats the cluster information into a YAML string representation.
-func (c *Cluster) ToYAMLString() string {
-	lastCheckedAt := "N/A"
-	if !c.LastCheckedAt.IsZero() {
-		lastCheckedAt = c.LastCheckedAt.Format("2006-01-02 15:04:05 MST")
-	}
-	return fmt.Sprintf(`name: %s
-  status: %s
-  kubeconfig_path: %s
-  message: %s
-  registered_at: %s
-  last_checked_at: %s`,
-		c.Name,
-		c.Status,
-		c.KubeconfigPath,
-		c.Message,
-		c.RegisteredAt.Format("2006-01-02 15:04:05 MST"),
-		lastCheckedAt,
-	)
+	if len(c.Variables) > 0 {
+		m["variables"] = c.Variables
+	}
+	if len(c.Labels) > 0 {
+		m["labels"] = c.Labels
+	}
+	return m
 }
 
 // formatClusterStatus provides a formatted status string with emojis.
 // This function remains in cluster package as it's specific to cluster status logic.
-func formatClusterStatus(status string) string {
-	switch strings.ToLower(status) {
-	case "active", "connected", "ready":
-		return "✅ " + status
-	case "inactive", "disconnected", "unreachable":
-		return "❌ " + status
-	case "pending", "connecting", "checkrequested":
-		return "⏳ " + status
-	case "error", "failed":
-		return "❗ " + status
+func formatClusterStatus(status Status) string {
+	switch status {
+	case StatusActive:
+		return "✅ " + string(status)
+	case StatusDegraded:
+		return "⚠️ " + string(status)
+	case StatusUnreachable:
+		return "❌ " + string(status)
+	case StatusCredentialsExpired:
+		return "🔑 " + string(status)
+	case StatusPending, StatusCheckRequested:
+		return "⏳ " + string(status)
+	case StatusError:
+		return "❗ " + string(status)
 	default:
-		return "❓ " + status
+		return "❓ " + string(status)
 	}
 }
@@ -0,0 +1,139 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/schema"
+)
+
+// FileVersion is the version of the clusters file format written by SaveClusters.
+//
+// Version 1 files, written before the format was versioned, hold a bare array of clusters.
+// Older versions are upgraded when the file is loaded.
+const FileVersion = 2
+
+// clustersFile is the format of the clusters file.
+type clustersFile struct {
+	// Version is the version of the format, FileVersion.
+	Version int `json:"version" schema:"required"`
+	// Clusters are the registered clusters.
+	Clusters []*Cluster `json:"clusters"`
+}
+
+// Migration describes the upgrade of a clusters file to the current version.
+type Migration struct {
+	// From is the version the file was upgraded from, FileVersion if it was already current.
+	From int
+	// Backup is the path the file was copied to before it was rewritten, empty if it was not.
+	Backup string
+	// Registered are the names of the clusters registered for migrated applications.
+	Registered []string
+
+	filePath string    // Path of the clusters file
+	data     []byte    // Contents of the file before the upgrade, nil if it did not exist
+	clusters *Clusters // Clusters upgraded to the current format, with the registered ones
+}
+
+// PlanMigration reads the clusters file at filePath, upgrades its clusters to the current version
+// and registers a cluster for each entry of legacy, a map of cluster names to kubeconfig paths
+// from app.Migration.LegacyClusters, that is not registered yet, all in memory. Apply writes the
+// upgraded file.
+func PlanMigration(filePath string, legacy map[string]string) (*Migration, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read clusters file %s: %w", filePath, err)
+	}
+
+	migration := &Migration{From: FileVersion, filePath: filePath, data: data, clusters: NewClusters()}
+	if data != nil {
+		list, version, err := decodeClusters(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clusters file %s:\n%w", filePath, err)
+		}
+		migration.From = version
+		for _, cluster := range list {
+			migration.clusters.Cs[cluster.Name] = cluster
+		}
+	}
+
+	for name, kubeconfigPath := range legacy {
+		if _, ok := migration.clusters.Cs[name]; ok {
+			continue
+		}
+		migration.clusters.Cs[name] = &Cluster{
+			Name:           name,
+			KubeconfigPath: kubeconfigPath,
+			RegisteredAt:   time.Now(),
+			Status:         StatusPending,
+			Message:        "Cluster registered by migrating applications from their own kubeconfig, awaiting validation",
+		}
+		migration.Registered = append(migration.Registered, name)
+	}
+	slices.Sort(migration.Registered)
+	return migration, nil
+}
+
+// Apply writes the upgraded clusters file planned by PlanMigration, after copying the file to a
+// backup next to it. A missing file is created, and a current file with nothing to register is
+// left untouched.
+func (m *Migration) Apply() error {
+	if m.From == FileVersion && len(m.Registered) == 0 {
+		return nil
+	}
+
+	var backup string
+	if m.data != nil {
+		var err error
+		if backup, err = common.BackupFile(m.filePath, m.data, m.From); err != nil {
+			return err
+		}
+	}
+	if err := SaveClusters(m.clusters, m.filePath); err != nil {
+		return err
+	}
+	m.Backup = backup
+	return nil
+}
+
+// ValidateClusters validates data, the contents of a clusters file of any supported version,
+// against the schema of its version.
+func ValidateClusters(data []byte) error {
+	_, _, err := decodeClusters(data)
+	return err
+}
+
+// decodeClusters decodes a clusters file of any supported version, after validating it against
+// the schema of that version, and returns its clusters with the version.
+func decodeClusters(data []byte) ([]*Cluster, int, error) {
+	var list []*Cluster
+	version := schema.Version(data)
+	switch {
+	case version == 1:
+		if err := schema.Validate(data, &list); err != nil {
+			return nil, 0, err
+		}
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, 0, fmt.Errorf("failed to unmarshal clusters data: %w", err)
+		}
+	case version > FileVersion:
+		return nil, 0, fmt.Errorf("clusters file version %d is newer than the latest version supported, %d; upgrade gitopsctl", version, FileVersion)
+	default:
+		var file clustersFile
+		if err := schema.Validate(data, &file); err != nil {
+			return nil, 0, err
+		}
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, 0, fmt.Errorf("failed to unmarshal clusters data: %w", err)
+		}
+		if file.Version != FileVersion {
+			return nil, 0, fmt.Errorf("unsupported clusters file version %d", file.Version)
+		}
+		list = file.Clusters
+	}
+	return slices.DeleteFunc(list, func(c *Cluster) bool { return c == nil }), version, nil
+}
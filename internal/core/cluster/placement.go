@@ -0,0 +1,65 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+)
+
+// Matches reports whether the cluster has every label of selector. An empty selector matches
+// every cluster.
+func (c *Cluster) Matches(selector map[string]string) bool {
+	return common.MatchLabels(c.Labels, selector)
+}
+
+// Placeable reports whether applications may be placed on a cluster with this status: it was
+// healthy at its last check, or has not been checked yet.
+func (s Status) Placeable() bool {
+	return s.IsHealthy() || s == StatusPending || s == StatusCheckRequested || s == ""
+}
+
+// FreeCapacity returns the share of the cluster's allocatable CPU or memory, whichever is scarcer,
+// that running pods do not request, as of its last health check. ok is false if the check did not
+// record the cluster's capacity.
+func (h *ClusterHealth) FreeCapacity() (free float64, ok bool) {
+	if h == nil || h.CPUAllocatable <= 0 || h.MemoryAllocatable <= 0 {
+		return 0, false
+	}
+	cpu := 1 - float64(h.CPURequested)/float64(h.CPUAllocatable)
+	memory := 1 - float64(h.MemoryRequested)/float64(h.MemoryAllocatable)
+	return max(min(cpu, memory), 0), true
+}
+
+// Place chooses the cluster an application with the given cluster selector is deployed to, among
+// the placeable clusters matching the selector.
+//
+// Clusters whose capacity is known come first, the one with the most free capacity winning. Ties,
+// and clusters not checked yet, are broken by the number of applications already placed on each,
+// counted in placed by cluster name, and then by name.
+func Place(clusters []*Cluster, selector map[string]string, placed map[string]int) (*Cluster, error) {
+	var candidates []*Cluster
+	for _, cl := range clusters {
+		if cl.Matches(selector) && cl.Status.Placeable() {
+			candidates = append(candidates, cl)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no healthy cluster matches the selector '%s'", common.FormatLabels(selector))
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		freeA, knownA := a.Health.FreeCapacity()
+		freeB, knownB := b.Health.FreeCapacity()
+		switch {
+		case knownA != knownB:
+			return knownA
+		case knownA && freeA != freeB:
+			return freeA > freeB
+		case placed[a.Name] != placed[b.Name]:
+			return placed[a.Name] < placed[b.Name]
+		}
+		return a.Name < b.Name
+	})
+	return candidates[0], nil
+}
@@ -0,0 +1,143 @@
+package cluster
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Status is the health state of a cluster.
+type Status string
+
+const (
+	// StatusPending means the cluster is registered and has not been checked yet.
+	StatusPending Status = "Pending"
+	// StatusCheckRequested means a manual health check was requested and has not completed yet.
+	StatusCheckRequested Status = "CheckRequested"
+	// StatusActive means the last health check reached the cluster and found no problems.
+	StatusActive Status = "Active"
+	// StatusDegraded means the cluster is reachable but the last health check reported warnings.
+	StatusDegraded Status = "Degraded"
+	// StatusUnreachable means the last health check could not reach the cluster's API server.
+	StatusUnreachable Status = "Unreachable"
+	// StatusCredentialsExpired means the last health check reached the cluster's API server but its
+	// credentials were rejected or could not be obtained, typically because a token, client
+	// certificate or cloud login expired.
+	StatusCredentialsExpired Status = "CredentialsExpired"
+	// StatusError means the controller could not create a client for the cluster.
+	StatusError Status = "Error"
+)
+
+// Statuses lists every cluster status.
+var Statuses = []Status{
+	StatusPending,
+	StatusCheckRequested,
+	StatusActive,
+	StatusDegraded,
+	StatusUnreachable,
+	StatusCredentialsExpired,
+	StatusError,
+}
+
+// ParseStatus returns the cluster status named s, ignoring case.
+func ParseStatus(s string) (Status, error) {
+	for _, status := range Statuses {
+		if strings.EqualFold(string(status), s) {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("unknown cluster status '%s'", s)
+}
+
+// IsValid reports whether s is a known cluster status.
+func (s Status) IsValid() bool {
+	return slices.Contains(Statuses, s)
+}
+
+// IsHealthy reports whether the cluster was reachable at its last health check.
+func (s Status) IsHealthy() bool {
+	return s == StatusActive || s == StatusDegraded
+}
+
+// CanTransition reports whether a cluster may move from one status to another.
+//
+// A cluster only returns to Pending when it is registered again; every other known status can be
+// reached from any status.
+func CanTransition(from, to Status) bool {
+	switch {
+	case !to.IsValid():
+		return false
+	case from == to, from == "":
+		return true
+	case to == StatusPending:
+		return false
+	}
+	return true
+}
+
+// Transition describes a change of a cluster's status.
+type Transition struct {
+	// Cluster is the name of the cluster.
+	Cluster string
+	// From is the status the cluster left.
+	From Status
+	// To is the status the cluster entered.
+	To Status
+	// Message is the cluster's message after the transition.
+	Message string
+}
+
+// SetStatus moves the cluster to the given status and sets its message.
+//
+// It returns the resulting transition, or an error and leaves the cluster unchanged if the
+// transition is not allowed.
+func (c *Cluster) SetStatus(to Status, message string) (Transition, error) {
+	if !CanTransition(c.Status, to) {
+		return Transition{}, fmt.Errorf("cluster '%s' cannot move from %s to %s", c.Name, c.Status, to)
+	}
+	t := Transition{Cluster: c.Name, From: c.Status, To: to, Message: message}
+	c.Status = to
+	c.Message = message
+	return t, nil
+}
+
+// TransitionHook is called after a cluster changes status.
+type TransitionHook func(Transition)
+
+// StateMachine validates cluster status changes and notifies hooks when a status changes.
+type StateMachine struct {
+	mu    sync.RWMutex
+	hooks []TransitionHook
+}
+
+// NewStateMachine creates a state machine without hooks.
+func NewStateMachine() *StateMachine {
+	return &StateMachine{}
+}
+
+// OnTransition registers a hook that is called after every status change, in registration order.
+func (m *StateMachine) OnTransition(hook TransitionHook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook)
+}
+
+// SetStatus moves the cluster to the given status and sets its message.
+//
+// Hooks are only called when the status actually changes. An error is returned, and the
+// cluster left unchanged, if the transition is not allowed.
+func (m *StateMachine) SetStatus(c *Cluster, to Status, message string) error {
+	t, err := c.SetStatus(to, message)
+	if err != nil || t.From == t.To {
+		return err
+	}
+
+	m.mu.RLock()
+	hooks := m.hooks
+	m.mu.RUnlock()
+	for _, hook := range hooks {
+		hook(t)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+)
+
+const (
+	// DefaultEventFile is the default path to store controller events
+	DefaultEventFile = "configs/events.json"
+	// MaxEvents is the maximum number of events retained; the oldest are discarded first.
+	MaxEvents = 500
+	// MaxEventAge is how long an event is retained after it was last seen.
+	MaxEventAge = 24 * time.Hour
+)
+
+// Type is the severity of an event, mirroring Kubernetes event types.
+type Type string
+
+const (
+	// TypeNormal indicates an expected, informational event.
+	TypeNormal Type = "Normal"
+	// TypeWarning indicates an event that may require attention.
+	TypeWarning Type = "Warning"
+)
+
+const (
+	// KindApplication is the involved object kind for application events.
+	KindApplication = "Application"
+	// KindCluster is the involved object kind for cluster events.
+	KindCluster = "Cluster"
+)
+
+// ObjectReference identifies the object an event is about.
+type ObjectReference struct {
+	// Kind is the kind of the object (e.g., "Application", "Cluster").
+	Kind string `json:"kind"`
+	// Name is the name of the object.
+	Name string `json:"name"`
+}
+
+// Event records a single controller action or observation, in the style of Kubernetes events.
+// Repeated events with the same involved object, type, reason and message are aggregated
+// into a single entry whose Count and LastTimestamp are updated.
+type Event struct {
+	// InvolvedObject is the object the event is about.
+	InvolvedObject ObjectReference `json:"involvedObject"`
+	// Type is the severity of the event.
+	Type Type `json:"type"`
+	// Reason is a short, CamelCase reason for the event (e.g., "SyncFailed").
+	Reason string `json:"reason"`
+	// Message is a human-readable description of the event.
+	Message string `json:"message"`
+	// FirstTimestamp is when the event was first recorded.
+	FirstTimestamp time.Time `json:"firstTimestamp"`
+	// LastTimestamp is when the event was most recently recorded.
+	LastTimestamp time.Time `json:"lastTimestamp"`
+	// Count is the number of times the event has occurred.
+	Count int `json:"count"`
+}
+
+// Events represents a thread-safe, bounded collection of Event objects ordered oldest first.
+type Events struct {
+	Items []*Event
+	mu    sync.RWMutex
+}
+
+// NewEvents creates a new empty Events collection.
+func NewEvents() *Events {
+	return &Events{}
+}
+
+// Lock acquires a write lock on the Events collection.
+func (e *Events) Lock() {
+	e.mu.Lock()
+}
+
+// Unlock releases the write lock on the Events collection.
+func (e *Events) Unlock() {
+	e.mu.Unlock()
+}
+
+// RLock acquires a read lock on the Events collection.
+func (e *Events) RLock() {
+	e.mu.RLock()
+}
+
+// RUnlock releases the read lock on the Events collection.
+func (e *Events) RUnlock() {
+	e.mu.RUnlock()
+}
+
+// Record adds an event to the collection, aggregating it with the most recent matching event
+// for the same object, and applies the retention limits.
+// This method does not acquire its own lock, so it should be called with the write lock held.
+func (e *Events) Record(kind, name string, eventType Type, reason, message string, now time.Time) {
+	for i := len(e.Items) - 1; i >= 0; i-- {
+		ev := e.Items[i]
+		if ev.InvolvedObject.Kind != kind || ev.InvolvedObject.Name != name {
+			continue
+		}
+		if ev.Type == eventType && ev.Reason == reason && ev.Message == message {
+			ev.Count++
+			ev.LastTimestamp = now
+			// Move the aggregated event to the end so the collection stays ordered by LastTimestamp
+			e.Items = append(append(e.Items[:i], e.Items[i+1:]...), ev)
+			e.prune(now)
+			return
+		}
+		// Only the latest event for an object is considered for aggregation
+		break
+	}
+
+	e.Items = append(e.Items, &Event{
+		InvolvedObject: ObjectReference{Kind: kind, Name: name},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	})
+	e.prune(now)
+}
+
+// prune discards events older than MaxEventAge and the oldest events beyond MaxEvents.
+func (e *Events) prune(now time.Time) {
+	cutoff := now.Add(-MaxEventAge)
+	start := 0
+	for start < len(e.Items) && e.Items[start].LastTimestamp.Before(cutoff) {
+		start++
+	}
+	if len(e.Items)-start > MaxEvents {
+		start = len(e.Items) - MaxEvents
+	}
+	if start > 0 {
+		e.Items = append([]*Event(nil), e.Items[start:]...)
+	}
+}
+
+// Filter returns the events matching the given involved object kind and name, oldest first.
+// Empty kind or name values match any object.
+// This method does not acquire its own lock, so it should be called with a read lock held.
+func (e *Events) Filter(kind, name string) []*Event {
+	var matched []*Event
+	for _, ev := range e.Items {
+		if kind != "" && ev.InvolvedObject.Kind != kind {
+			continue
+		}
+		if name != "" && ev.InvolvedObject.Name != name {
+			continue
+		}
+		matched = append(matched, ev)
+	}
+	return matched
+}
+
+// Rename moves the events recorded for an object to its new name, so its history survives a rename.
+// This method does not acquire its own lock, so it should be called with the write lock held.
+func (e *Events) Rename(kind, oldName, newName string) {
+	for _, ev := range e.Items {
+		if ev.InvolvedObject.Kind == kind && ev.InvolvedObject.Name == oldName {
+			ev.InvolvedObject.Name = newName
+		}
+	}
+}
+
+// LoadEvents loads events from the specified file path.
+// If the file does not exist, it returns an empty collection.
+func LoadEvents(filePath string) (*Events, error) {
+	events := NewEvents()
+	events.mu.Lock()
+	defer events.mu.Unlock()
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return events, nil
+		}
+		return nil, fmt.Errorf("failed to read events file %s: %w", filePath, err)
+	}
+
+	if err := json.Unmarshal(data, &events.Items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal events data: %w", err)
+	}
+	sort.SliceStable(events.Items, func(i, j int) bool {
+		return events.Items[i].LastTimestamp.Before(events.Items[j].LastTimestamp)
+	})
+	events.prune(time.Now())
+
+	return events, nil
+}
+
+// SaveEvents saves the current events to the specified file path.
+// If the directory does not exist, it creates it.
+// This function does not acquire its own lock, so it should be called with the appropriate lock held.
+func SaveEvents(events *Events, filePath string) error {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	items := events.Items
+	if items == nil {
+		items = []*Event{}
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal events data: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write events file %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// ToTableHeaders implements cliutils.Renderable for table output headers.
+// It returns the headers for the table representation of the Event.
+func (ev *Event) ToTableHeaders(details bool) []string {
+	if details {
+		return []string{"LAST SEEN", "FIRST SEEN", "COUNT", "TYPE", "REASON", "OBJECT", "MESSAGE"}
+	}
+	return []string{"LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"}
+}
+
+// ToTableRow implements cliutils.Renderable for table output rows.
+// It returns a slice of strings representing the event formatted for table display.
+func (ev *Event) ToTableRow(details bool) []string {
+	object := fmt.Sprintf("%s/%s", strings.ToLower(ev.InvolvedObject.Kind), ev.InvolvedObject.Name)
+	if details {
+		return []string{
+			common.GetRelativeTime(ev.LastTimestamp),
+			common.GetRelativeTime(ev.FirstTimestamp),
+			fmt.Sprintf("%d", ev.Count),
+			string(ev.Type),
+			ev.Reason,
+			object,
+			ev.Message,
+		}
+	}
+	return []string{
+		common.GetRelativeTime(ev.LastTimestamp),
+		string(ev.Type),
+		ev.Reason,
+		object,
+		common.TruncateString(ev.Message, 80),
+	}
+}
+
+// ToJSONMap implements cliutils.Renderable for JSON output.
+// It returns a map representation of the Event suitable for JSON serialization.
+func (ev *Event) ToJSONMap() map[string]any {
+	return map[string]any{
+		"kind":            ev.InvolvedObject.Kind,
+		"name":            ev.InvolvedObject.Name,
+		"type":            string(ev.Type),
+		"reason":          ev.Reason,
+		"message":         ev.Message,
+		"count":           ev.Count,
+		"first_timestamp": ev.FirstTimestamp.Format(time.RFC3339),
+		"last_timestamp":  ev.LastTimestamp.Format(time.RFC3339),
+	}
+}
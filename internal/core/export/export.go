@@ -0,0 +1,448 @@
+// Package export ships sync and audit events to external systems, such as Elasticsearch, Loki, a
+// webhook or a Kafka topic, so deployment events feed existing observability pipelines.
+//
+// Exporters tail the sync log and the audit log, which the controller, the API server and the CLI
+// append to, and ship new entries in batches. How far each exporter got in each log is kept in a
+// state file, so a batch that cannot be delivered is retried, and nothing is shipped twice or lost
+// across restarts.
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/core/audit"
+	"aeswibon.com/github/gitopsctl/internal/core/event"
+	"aeswibon.com/github/gitopsctl/internal/core/stats"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultExportConfigFile is the default path of the exporter configuration.
+	DefaultExportConfigFile = "configs/exporters.json"
+	// DefaultStateFile is the default path of the file recording how far each exporter shipped each log.
+	DefaultStateFile = "configs/export_state.json"
+	// DefaultBatchSize is the default maximum number of events shipped in one request.
+	DefaultBatchSize = 100
+	// DefaultFlushInterval is the default interval at which exporters ship new events.
+	DefaultFlushInterval = 10 * time.Second
+	// DefaultMaxRetries is the default number of times a failed batch is retried before the exporter
+	// gives up until its next flush.
+	DefaultMaxRetries = 3
+	// DefaultTimeout is the timeout for shipping a single batch.
+	DefaultTimeout = 30 * time.Second
+
+	// initialRetryDelay is how long an exporter waits before retrying a failed batch the first time;
+	// the delay doubles with each retry, up to maxRetryDelay.
+	initialRetryDelay = time.Second
+	// maxRetryDelay is the longest an exporter waits between retries of a failed batch.
+	maxRetryDelay = 30 * time.Second
+)
+
+const (
+	// SourceSync is the source of events about finished syncs, from the sync log.
+	SourceSync = "sync"
+	// SourceAudit is the source of events about manual changes, from the audit log.
+	SourceAudit = "audit"
+)
+
+// Sources lists the sources of exported events.
+var Sources = []string{SourceSync, SourceAudit}
+
+// Event is a sync or audit event as shipped to an external system.
+type Event struct {
+	// Source is where the event comes from: "sync" or "audit".
+	Source string `json:"source"`
+	// Time is when the sync finished or the change was requested.
+	Time time.Time `json:"time"`
+	// Kind is the kind of the object the event is about, "Application" or "Cluster".
+	Kind string `json:"kind"`
+	// Name is the name of the object the event is about.
+	Name string `json:"name"`
+	// Status is the application status a sync resulted in.
+	Status string `json:"status,omitempty"`
+	// GitHash is the commit a sync was syncing, if known.
+	GitHash string `json:"gitHash,omitempty"`
+	// CommitTime is when the commit a sync deployed was made, if it deployed a new commit.
+	CommitTime *time.Time `json:"commitTime,omitempty"`
+	// Action is the change recorded by an audit event (e.g., "sync", "register").
+	Action string `json:"action,omitempty"`
+	// Actor is who requested the sync or change, empty for syncs the controller started on its own.
+	Actor string `json:"actor,omitempty"`
+	// Reason is why the sync or change was requested, as given by the actor.
+	Reason string `json:"reason,omitempty"`
+	// Message describes the outcome of a sync or the details of a change.
+	Message string `json:"message,omitempty"`
+	// RequestID is the ID of the API request that requested the sync or change, if any.
+	RequestID string `json:"requestID,omitempty"`
+}
+
+// fromSync converts an entry of the sync log to an event.
+func fromSync(s stats.Sync) Event {
+	e := Event{
+		Source:    SourceSync,
+		Time:      s.Time,
+		Kind:      event.KindApplication,
+		Name:      s.App,
+		Status:    string(s.Status),
+		GitHash:   s.GitHash,
+		Actor:     s.Actor,
+		Reason:    s.Reason,
+		Message:   s.Message,
+		RequestID: s.RequestID,
+	}
+	if !s.CommitTime.IsZero() {
+		e.CommitTime = &s.CommitTime
+	}
+	return e
+}
+
+// fromAudit converts an entry of the audit log to an event.
+func fromAudit(entry audit.Entry) Event {
+	return Event{
+		Source:    SourceAudit,
+		Time:      entry.Time,
+		Kind:      entry.Kind,
+		Name:      entry.Name,
+		Action:    string(entry.Action),
+		Actor:     entry.Actor,
+		Reason:    entry.Reason,
+		Message:   entry.Details,
+		RequestID: entry.RequestID,
+	}
+}
+
+// Sink ships batches of events to an external system.
+type Sink interface {
+	Ship(ctx context.Context, events []Event) error
+}
+
+// Exporter configures where events are shipped and how they are batched.
+type Exporter struct {
+	// Name identifies the exporter in logs and in the state file.
+	Name string `json:"name"`
+	// Webhook posts each batch as JSON to a URL.
+	Webhook *WebhookSettings `json:"webhook,omitempty"`
+	// Elasticsearch indexes events with the bulk API.
+	Elasticsearch *ElasticsearchSettings `json:"elasticsearch,omitempty"`
+	// Loki pushes events as log lines.
+	Loki *LokiSettings `json:"loki,omitempty"`
+	// Kafka produces events to a topic through a Kafka REST proxy.
+	// Exactly one of Webhook, Elasticsearch, Loki and Kafka must be set.
+	Kafka *KafkaSettings `json:"kafka,omitempty"`
+	// Sources are the events shipped: "sync" and/or "audit". Empty ships both.
+	Sources []string `json:"sources,omitempty"`
+	// BatchSize is the maximum number of events shipped in one request, 100 by default.
+	BatchSize int `json:"batchSize,omitempty"`
+	// FlushInterval is how often new events are shipped (e.g., "30s"), every 10 seconds by default.
+	FlushInterval string `json:"flushInterval,omitempty"`
+	// MaxRetries is how many times a failed batch is retried, with exponential backoff, before the
+	// exporter gives up until its next flush. Defaults to 3.
+	MaxRetries *int `json:"maxRetries,omitempty"`
+
+	// flushInterval is the parsed FlushInterval.
+	flushInterval time.Duration
+	// sink ships the exporter's batches.
+	sink Sink
+}
+
+// Config holds the exporters events are shipped with.
+type Config struct {
+	// Exporters are the external systems events are shipped to.
+	Exporters []Exporter `json:"exporters"`
+}
+
+// LoadConfig loads and validates the exporter configuration from the specified file path.
+// If the file does not exist, it returns an empty configuration with no exporters.
+func LoadConfig(filePath string) (*Config, error) {
+	config := &Config{}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, fmt.Errorf("failed to read exporter config file %s: %w", filePath, err)
+	}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal exporter config: %w", err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// validate checks the exporters for errors, applies their defaults and creates their sinks.
+func (c *Config) validate() error {
+	seen := make(map[string]bool)
+	for i := range c.Exporters {
+		exporter := &c.Exporters[i]
+		if strings.TrimSpace(exporter.Name) == "" {
+			return fmt.Errorf("exporter %d: name is required", i+1)
+		}
+		if seen[exporter.Name] {
+			return fmt.Errorf("exporter '%s': duplicate exporter name", exporter.Name)
+		}
+		seen[exporter.Name] = true
+
+		destinations := 0
+		for _, set := range []bool{exporter.Webhook != nil, exporter.Elasticsearch != nil, exporter.Loki != nil, exporter.Kafka != nil} {
+			if set {
+				destinations++
+			}
+		}
+		if destinations != 1 {
+			return fmt.Errorf("exporter '%s': exactly one of webhook, elasticsearch, loki or kafka is required", exporter.Name)
+		}
+		var err error
+		switch {
+		case exporter.Webhook != nil:
+			exporter.sink, err = NewWebhookSink(*exporter.Webhook)
+		case exporter.Elasticsearch != nil:
+			exporter.sink, err = NewElasticsearchSink(*exporter.Elasticsearch)
+		case exporter.Loki != nil:
+			exporter.sink, err = NewLokiSink(*exporter.Loki)
+		case exporter.Kafka != nil:
+			exporter.sink, err = NewKafkaSink(*exporter.Kafka)
+		}
+		if err != nil {
+			return fmt.Errorf("exporter '%s': %w", exporter.Name, err)
+		}
+
+		if len(exporter.Sources) == 0 {
+			exporter.Sources = Sources
+		}
+		for _, source := range exporter.Sources {
+			if !slices.Contains(Sources, source) {
+				return fmt.Errorf("exporter '%s': unknown source '%s', use %s", exporter.Name, source, strings.Join(Sources, " or "))
+			}
+		}
+		if exporter.BatchSize < 0 {
+			return fmt.Errorf("exporter '%s': batchSize must not be negative", exporter.Name)
+		}
+		if exporter.BatchSize == 0 {
+			exporter.BatchSize = DefaultBatchSize
+		}
+		exporter.flushInterval = DefaultFlushInterval
+		if exporter.FlushInterval != "" {
+			if exporter.flushInterval, err = time.ParseDuration(exporter.FlushInterval); err != nil || exporter.flushInterval <= 0 {
+				return fmt.Errorf("exporter '%s': invalid flushInterval '%s'", exporter.Name, exporter.FlushInterval)
+			}
+		}
+		if exporter.MaxRetries == nil {
+			retries := DefaultMaxRetries
+			exporter.MaxRetries = &retries
+		} else if *exporter.MaxRetries < 0 {
+			return fmt.Errorf("exporter '%s': maxRetries must not be negative", exporter.Name)
+		}
+	}
+	return nil
+}
+
+// Runner ships new entries of the sync and audit logs with each configured exporter.
+type Runner struct {
+	logger    *zap.Logger
+	exporters []Exporter
+	// logs are the paths of the logs events are read from, by source.
+	logs map[string]string
+	// stateFile is where the offsets are persisted.
+	stateFile string
+
+	// mu protects offsets.
+	mu sync.Mutex
+	// offsets are how many bytes of each log each exporter shipped, by exporter name and source.
+	offsets map[string]map[string]int64
+}
+
+// NewRunner creates a runner for the configured exporters, reading the sync log and the audit log
+// at their default paths and resuming from the offsets recorded in stateFile.
+func NewRunner(logger *zap.Logger, config *Config, stateFile string) (*Runner, error) {
+	r := &Runner{
+		logger:    logger,
+		exporters: config.Exporters,
+		logs:      map[string]string{SourceSync: stats.DefaultSyncLogFile, SourceAudit: audit.DefaultAuditFile},
+		stateFile: stateFile,
+		offsets:   make(map[string]map[string]int64),
+	}
+	data, err := os.ReadFile(stateFile)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read export state file %s: %w", stateFile, err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &r.offsets); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal export state: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// Run ships new events with every exporter at its flush interval until ctx is cancelled.
+func (r *Runner) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := range r.exporters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.runExporter(ctx, &r.exporters[i])
+		}()
+	}
+	wg.Wait()
+}
+
+// runExporter ships new events with the exporter, then again at each flush interval, until ctx is cancelled.
+func (r *Runner) runExporter(ctx context.Context, exporter *Exporter) {
+	ticker := time.NewTicker(exporter.flushInterval)
+	defer ticker.Stop()
+	for {
+		r.flush(ctx, exporter)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// flush ships the events of each of the exporter's sources added since its last flush, in batches.
+// It stops at the first batch that cannot be shipped; that batch is tried again at the next flush.
+func (r *Runner) flush(ctx context.Context, exporter *Exporter) {
+	for _, source := range exporter.Sources {
+		for ctx.Err() == nil {
+			offset := r.offset(exporter.Name, source)
+			events, next, err := readEvents(r.logs[source], source, offset, exporter.BatchSize)
+			if err != nil {
+				r.logger.Error("Failed to read events to export", zap.String("exporter", exporter.Name), zap.String("source", source), zap.Error(err))
+				return
+			}
+			if len(events) > 0 {
+				if err := r.ship(ctx, exporter, events); err != nil {
+					r.logger.Error("Failed to export events, retrying at the next flush",
+						zap.String("exporter", exporter.Name), zap.String("source", source), zap.Int("events", len(events)), zap.Error(err))
+					return
+				}
+				r.logger.Debug("Exported events", zap.String("exporter", exporter.Name), zap.String("source", source), zap.Int("events", len(events)))
+			}
+			if next != offset {
+				r.setOffset(exporter.Name, source, next)
+			}
+			if len(events) < exporter.BatchSize {
+				break
+			}
+		}
+	}
+}
+
+// ship ships a batch with the exporter's sink, retrying up to its MaxRetries times with exponential backoff.
+func (r *Runner) ship(ctx context.Context, exporter *Exporter, events []Event) error {
+	delay := initialRetryDelay
+	for attempt := 0; ; attempt++ {
+		shipCtx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+		err := exporter.sink.Ship(shipCtx, events)
+		cancel()
+		if err == nil || attempt >= *exporter.MaxRetries {
+			return err
+		}
+		r.logger.Warn("Failed to export events, retrying", zap.String("exporter", exporter.Name),
+			zap.Int("attempt", attempt+1), zap.Duration("delay", delay), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
+
+// offset returns how many bytes of a source's log the exporter shipped.
+func (r *Runner) offset(exporter, source string) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.offsets[exporter][source]
+}
+
+// setOffset records how many bytes of a source's log the exporter shipped and persists the offsets.
+func (r *Runner) setOffset(exporter, source string, offset int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.offsets[exporter] == nil {
+		r.offsets[exporter] = make(map[string]int64)
+	}
+	r.offsets[exporter][source] = offset
+
+	data, err := json.MarshalIndent(r.offsets, "", "  ")
+	if err == nil {
+		if err = os.MkdirAll(filepath.Dir(r.stateFile), 0755); err == nil {
+			err = os.WriteFile(r.stateFile, data, 0644)
+		}
+	}
+	if err != nil {
+		r.logger.Error("Failed to save export state", zap.String("file", r.stateFile), zap.Error(err))
+	}
+}
+
+// readEvents reads up to limit events from the log of a source, starting offset bytes into it, and
+// returns them with the offset to continue from. A log shorter than offset was replaced, so it is
+// read from the start. Lines that cannot be parsed are skipped, and a last line that is still being
+// written is left for the next read.
+func readEvents(filePath, source string, offset int64, limit int) ([]Event, int64, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, nil
+		}
+		return nil, offset, fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, offset, fmt.Errorf("failed to stat %s: %w", filePath, err)
+	}
+	if info.Size() < offset {
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, fmt.Errorf("failed to seek in %s: %w", filePath, err)
+	}
+
+	var events []Event
+	reader := bufio.NewReader(f)
+	for len(events) < limit {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, offset, fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		offset += int64(len(line))
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		switch source {
+		case SourceSync:
+			var s stats.Sync
+			if json.Unmarshal(line, &s) == nil {
+				events = append(events, fromSync(s))
+			}
+		case SourceAudit:
+			var entry audit.Entry
+			if json.Unmarshal(line, &entry) == nil {
+				events = append(events, fromAudit(entry))
+			}
+		}
+	}
+	return events, offset, nil
+}
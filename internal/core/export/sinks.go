@@ -0,0 +1,406 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultElasticsearchIndex is the index events are written to when none is configured.
+	DefaultElasticsearchIndex = "gitopsctl-events"
+	// lokiJob is the job label of the streams events are pushed to.
+	lokiJob = "gitopsctl"
+	// maxErrorBody is how much of an error response is kept in the error.
+	maxErrorBody = 512
+)
+
+// WebhookSettings configure an exporter posting batches of events to a URL.
+type WebhookSettings struct {
+	// URL is where each batch is posted, as {"events": [...]}.
+	URL string `json:"url"`
+	// TokenEnv names the environment variable holding a bearer token sent with each batch, if any.
+	TokenEnv string `json:"tokenEnv,omitempty"`
+	// Headers are additional headers sent with each batch.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// ElasticsearchSettings configure an exporter indexing events in Elasticsearch or OpenSearch.
+type ElasticsearchSettings struct {
+	// URL is the base URL of the cluster (e.g., "https://es.example.com:9200").
+	URL string `json:"url"`
+	// Index is the index, or data stream, events are written to. Defaults to "gitopsctl-events".
+	Index string `json:"index,omitempty"`
+	// APIKeyEnv names the environment variable holding an encoded API key, if any.
+	APIKeyEnv string `json:"apiKeyEnv,omitempty"`
+	// Username authenticates with basic authentication, with the password in PasswordEnv.
+	Username string `json:"username,omitempty"`
+	// PasswordEnv names the environment variable holding the password of Username.
+	PasswordEnv string `json:"passwordEnv,omitempty"`
+}
+
+// LokiSettings configure an exporter pushing events to Grafana Loki.
+type LokiSettings struct {
+	// URL is the base URL of Loki (e.g., "http://loki:3100").
+	URL string `json:"url"`
+	// TenantID is sent as the X-Scope-OrgID header of multi-tenant Loki installations, if set.
+	TenantID string `json:"tenantID,omitempty"`
+	// Labels are added to the labels of every stream, besides job, source, kind and name.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Username authenticates with basic authentication, with the password in PasswordEnv.
+	Username string `json:"username,omitempty"`
+	// PasswordEnv names the environment variable holding the password of Username.
+	PasswordEnv string `json:"passwordEnv,omitempty"`
+}
+
+// KafkaSettings configure an exporter producing events to a Kafka topic through a Kafka REST proxy
+// (the Confluent REST Proxy API v2).
+type KafkaSettings struct {
+	// RESTProxyURL is the base URL of the REST proxy (e.g., "http://kafka-rest:8082").
+	RESTProxyURL string `json:"restProxyURL"`
+	// Topic is the topic events are produced to. Each event is keyed by the name of its object.
+	Topic string `json:"topic"`
+	// Username authenticates with basic authentication, with the password in PasswordEnv.
+	Username string `json:"username,omitempty"`
+	// PasswordEnv names the environment variable holding the password of Username.
+	PasswordEnv string `json:"passwordEnv,omitempty"`
+}
+
+// httpSink ships batches as HTTP POST requests.
+type httpSink struct {
+	client *http.Client
+	// authorize sets the authentication headers of a request, if any.
+	authorize func(req *http.Request) error
+}
+
+// post posts body to endpoint and reports non-2xx responses as errors. It returns the response body.
+func (s *httpSink) post(ctx context.Context, endpoint, contentType string, headers map[string]string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if s.authorize != nil {
+		if err := s.authorize(req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post to %s: %w", redact(endpoint), err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message := strings.TrimSpace(string(respBody))
+		if len(message) > maxErrorBody {
+			message = message[:maxErrorBody] + "..."
+		}
+		return nil, fmt.Errorf("%s returned status %d: %s", redact(endpoint), resp.StatusCode, message)
+	}
+	return respBody, nil
+}
+
+// basicAuth returns a function setting basic authentication with the password in passwordEnv, or
+// nil if username is empty.
+func basicAuth(username, passwordEnv string) (func(req *http.Request) error, error) {
+	if username == "" {
+		return nil, nil
+	}
+	if passwordEnv == "" {
+		return nil, fmt.Errorf("passwordEnv is required with username")
+	}
+	return func(req *http.Request) error {
+		password, err := fromEnv(passwordEnv)
+		if err != nil {
+			return err
+		}
+		req.SetBasicAuth(username, password)
+		return nil
+	}, nil
+}
+
+// fromEnv returns the value of an environment variable holding a secret, which must be set.
+func fromEnv(name string) (string, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
+
+// validateURL checks that a configured URL is an absolute http or https URL.
+func validateURL(field, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s must be an http or https URL", field)
+	}
+	return nil
+}
+
+// redact removes any credentials from a URL, so they are not logged.
+func redact(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	u.User = nil
+	return u.String()
+}
+
+// WebhookSink posts batches of events as JSON to a URL.
+type WebhookSink struct {
+	httpSink
+	settings WebhookSettings
+}
+
+// NewWebhookSink creates a sink posting batches of events to a webhook.
+func NewWebhookSink(settings WebhookSettings) (*WebhookSink, error) {
+	if err := validateURL("url", settings.URL); err != nil {
+		return nil, err
+	}
+	sink := &WebhookSink{httpSink: httpSink{client: &http.Client{}}, settings: settings}
+	if settings.TokenEnv != "" {
+		sink.authorize = func(req *http.Request) error {
+			token, err := fromEnv(settings.TokenEnv)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		}
+	}
+	return sink, nil
+}
+
+// Ship posts the events as {"events": [...]}.
+func (w *WebhookSink) Ship(ctx context.Context, events []Event) error {
+	body, err := json.Marshal(map[string][]Event{"events": events})
+	if err != nil {
+		return fmt.Errorf("failed to marshal events: %w", err)
+	}
+	_, err = w.post(ctx, w.settings.URL, "application/json", w.settings.Headers, body)
+	return err
+}
+
+// ElasticsearchSink indexes events with the Elasticsearch bulk API.
+type ElasticsearchSink struct {
+	httpSink
+	settings ElasticsearchSettings
+}
+
+// NewElasticsearchSink creates a sink indexing events in Elasticsearch.
+func NewElasticsearchSink(settings ElasticsearchSettings) (*ElasticsearchSink, error) {
+	if err := validateURL("url", settings.URL); err != nil {
+		return nil, err
+	}
+	if settings.APIKeyEnv != "" && settings.Username != "" {
+		return nil, fmt.Errorf("only one of apiKeyEnv or username may be set")
+	}
+	if settings.Index == "" {
+		settings.Index = DefaultElasticsearchIndex
+	}
+	authorize, err := basicAuth(settings.Username, settings.PasswordEnv)
+	if err != nil {
+		return nil, err
+	}
+	if settings.APIKeyEnv != "" {
+		authorize = func(req *http.Request) error {
+			key, err := fromEnv(settings.APIKeyEnv)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Authorization", "ApiKey "+key)
+			return nil
+		}
+	}
+	return &ElasticsearchSink{httpSink: httpSink{client: &http.Client{}, authorize: authorize}, settings: settings}, nil
+}
+
+// Ship indexes the events with a single bulk request. Events are created rather than indexed, so
+// they can be written to data streams. A bulk request that fails for any event fails as a whole.
+func (es *ElasticsearchSink) Ship(ctx context.Context, events []Event) error {
+	action, err := json.Marshal(map[string]map[string]string{"create": {"_index": es.settings.Index}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal bulk action: %w", err)
+	}
+	var body bytes.Buffer
+	for _, e := range events {
+		doc, err := json.Marshal(elasticsearchDocument{Timestamp: e.Time, Event: e})
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+		body.Write(action)
+		body.WriteByte('\n')
+		body.Write(doc)
+		body.WriteByte('\n')
+	}
+
+	respBody, err := es.post(ctx, strings.TrimSuffix(es.settings.URL, "/")+"/_bulk", "application/x-ndjson", nil, body.Bytes())
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+			Error  struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("failed to parse bulk response: %w", err)
+	}
+	if !result.Errors {
+		return nil
+	}
+	failed := 0
+	var reason string
+	for _, item := range result.Items {
+		for _, r := range item {
+			if r.Status >= 300 {
+				failed++
+				if reason == "" {
+					reason = r.Error.Type + ": " + r.Error.Reason
+				}
+			}
+		}
+	}
+	return fmt.Errorf("%d of %d event(s) could not be indexed: %s", failed, len(events), reason)
+}
+
+// elasticsearchDocument is an event as indexed in Elasticsearch, with the @timestamp field data
+// streams and Kibana expect.
+type elasticsearchDocument struct {
+	Timestamp time.Time `json:"@timestamp"`
+	Event
+}
+
+// LokiSink pushes events to Loki as log lines, one stream per source and object.
+type LokiSink struct {
+	httpSink
+	settings LokiSettings
+}
+
+// NewLokiSink creates a sink pushing events to Loki.
+func NewLokiSink(settings LokiSettings) (*LokiSink, error) {
+	if err := validateURL("url", settings.URL); err != nil {
+		return nil, err
+	}
+	authorize, err := basicAuth(settings.Username, settings.PasswordEnv)
+	if err != nil {
+		return nil, err
+	}
+	return &LokiSink{httpSink: httpSink{client: &http.Client{}, authorize: authorize}, settings: settings}, nil
+}
+
+// Ship pushes the events with a single push request. Each line is the event as JSON.
+func (l *LokiSink) Ship(ctx context.Context, events []Event) error {
+	type stream struct {
+		Stream map[string]string `json:"stream"`
+		Values [][2]string       `json:"values"`
+	}
+	var streams []*stream
+	byLabels := make(map[string]*stream)
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+		key := e.Source + "/" + e.Kind + "/" + e.Name
+		s, ok := byLabels[key]
+		if !ok {
+			labels := map[string]string{"job": lokiJob, "source": e.Source, "kind": e.Kind, "name": e.Name}
+			for name, value := range l.settings.Labels {
+				labels[name] = value
+			}
+			s = &stream{Stream: labels}
+			byLabels[key] = s
+			streams = append(streams, s)
+		}
+		s.Values = append(s.Values, [2]string{strconv.FormatInt(e.Time.UnixNano(), 10), string(line)})
+	}
+
+	body, err := json.Marshal(map[string][]*stream{"streams": streams})
+	if err != nil {
+		return fmt.Errorf("failed to marshal streams: %w", err)
+	}
+	var headers map[string]string
+	if l.settings.TenantID != "" {
+		headers = map[string]string{"X-Scope-OrgID": l.settings.TenantID}
+	}
+	_, err = l.post(ctx, strings.TrimSuffix(l.settings.URL, "/")+"/loki/api/v1/push", "application/json", headers, body)
+	return err
+}
+
+// KafkaSink produces events to a Kafka topic through a Kafka REST proxy.
+type KafkaSink struct {
+	httpSink
+	settings KafkaSettings
+}
+
+// NewKafkaSink creates a sink producing events to a Kafka topic.
+func NewKafkaSink(settings KafkaSettings) (*KafkaSink, error) {
+	if err := validateURL("restProxyURL", settings.RESTProxyURL); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(settings.Topic) == "" {
+		return nil, fmt.Errorf("topic is required")
+	}
+	authorize, err := basicAuth(settings.Username, settings.PasswordEnv)
+	if err != nil {
+		return nil, err
+	}
+	return &KafkaSink{httpSink: httpSink{client: &http.Client{}, authorize: authorize}, settings: settings}, nil
+}
+
+// Ship produces the events with a single request, keyed by the name of their object so the events
+// of an application stay in order within a partition.
+func (k *KafkaSink) Ship(ctx context.Context, events []Event) error {
+	type record struct {
+		Key   string `json:"key"`
+		Value Event  `json:"value"`
+	}
+	records := make([]record, 0, len(events))
+	for _, e := range events {
+		records = append(records, record{Key: e.Kind + "/" + e.Name, Value: e})
+	}
+	body, err := json.Marshal(map[string][]record{"records": records})
+	if err != nil {
+		return fmt.Errorf("failed to marshal records: %w", err)
+	}
+	endpoint := strings.TrimSuffix(k.settings.RESTProxyURL, "/") + "/topics/" + url.PathEscape(k.settings.Topic)
+	respBody, err := k.post(ctx, endpoint, "application/vnd.kafka.json.v2+json", map[string]string{"Accept": "application/vnd.kafka.v2+json"}, body)
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Offsets []struct {
+			ErrorCode *int   `json:"error_code"`
+			Error     string `json:"error"`
+		} `json:"offsets"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("failed to parse REST proxy response: %w", err)
+	}
+	for _, offset := range result.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("failed to produce to topic %s: %s", k.settings.Topic, offset.Error)
+		}
+	}
+	return nil
+}
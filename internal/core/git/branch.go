@@ -0,0 +1,96 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+const (
+	// FallbackBranch is the branch used when the remote's default branch cannot be determined.
+	FallbackBranch = "main"
+	// DefaultBranchTimeout bounds how long detecting a remote's default branch may take.
+	DefaultBranchTimeout = 15 * time.Second
+)
+
+// DefaultBranch returns the name of the branch the remote repository's HEAD points to,
+// such as "main" or "master".
+//
+// Only the remote's references are listed; nothing is cloned. If the server does not advertise
+// HEAD as a symbolic reference, the branch whose commit matches HEAD is returned instead.
+func DefaultBranch(ctx context.Context, repoURL string) (string, error) {
+	refs, err := listRemote(ctx, repoURL)
+	if err != nil {
+		return "", err
+	}
+
+	var head *plumbing.Reference
+	for _, ref := range refs {
+		if ref.Name() == plumbing.HEAD {
+			head = ref
+			break
+		}
+	}
+	if head == nil {
+		return "", fmt.Errorf("remote %s does not advertise a HEAD reference", repoURL)
+	}
+	if head.Type() == plumbing.SymbolicReference {
+		if !head.Target().IsBranch() {
+			return "", fmt.Errorf("remote %s HEAD points to %s, which is not a branch", repoURL, head.Target())
+		}
+		return head.Target().Short(), nil
+	}
+
+	// HEAD was advertised by hash only; pick the branch at the same commit, preferring the fallback
+	var match string
+	for _, ref := range refs {
+		if !ref.Name().IsBranch() || ref.Hash() != head.Hash() {
+			continue
+		}
+		if ref.Name().Short() == FallbackBranch {
+			return FallbackBranch, nil
+		}
+		if match == "" {
+			match = ref.Name().Short()
+		}
+	}
+	if match == "" {
+		return "", fmt.Errorf("could not determine the default branch of %s", repoURL)
+	}
+	return match, nil
+}
+
+// RemoteHash returns the commit hash the branch points to in the remote repository.
+//
+// Only the remote's references are listed, like git ls-remote, so it is much cheaper than a pull.
+func RemoteHash(ctx context.Context, repoURL, branch string) (string, error) {
+	refs, err := listRemote(ctx, repoURL)
+	if err != nil {
+		return "", err
+	}
+	name := plumbing.NewBranchReferenceName(branch)
+	for _, ref := range refs {
+		if ref.Name() == name {
+			return ref.Hash().String(), nil
+		}
+	}
+	return "", fmt.Errorf("branch '%s' not found in %s", branch, repoURL)
+}
+
+// listRemote lists the references advertised by a remote repository, like git ls-remote.
+func listRemote(ctx context.Context, repoURL string) ([]*plumbing.Reference, error) {
+	remote := gogit.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{repoURL},
+	})
+	refs, err := remote.ListContext(ctx, &gogit.ListOptions{Auth: setupAuth(repoURL)})
+	if err != nil {
+		return nil, rateLimitError(repoURL, fmt.Errorf("failed to list references of %s: %w", repoURL, err), time.Now())
+	}
+	return refs, nil
+}
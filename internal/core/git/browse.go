@@ -0,0 +1,66 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// RemoteBranches lists the branches of a remote repository, sorted by name.
+// Only the remote's references are listed, like git ls-remote --heads.
+func RemoteBranches(ctx context.Context, repoURL string) ([]string, error) {
+	refs, err := listRemote(ctx, repoURL)
+	if err != nil {
+		return nil, describeRemoteError(repoURL, err)
+	}
+
+	var branches []string
+	for _, ref := range refs {
+		if ref.Name().IsBranch() {
+			branches = append(branches, ref.Name().Short())
+		}
+	}
+	slices.Sort(branches)
+	return branches, nil
+}
+
+// ListDirectories lists the directories directly under dir at the latest commit of a branch,
+// as paths relative to the repository root, sorted by name. An empty dir or "." lists the
+// top-level directories.
+//
+// The branch is fetched with a shallow, in-memory clone; nothing is written to disk.
+func ListDirectories(ctx context.Context, repoURL, branch, dir string) ([]string, error) {
+	commit, err := fetchCommit(ctx, repoURL, branch)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the tree of commit %s: %w", commit.Hash, err)
+	}
+
+	dir = path.Clean("/" + dir)[1:]
+	if dir != "" {
+		tree, err = tree.Tree(dir)
+		if errors.Is(err, object.ErrDirectoryNotFound) || errors.Is(err, object.ErrEntryNotFound) {
+			return nil, fmt.Errorf("directory '%s' does not exist on branch '%s' of %s", dir, branch, repoURL)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory '%s' of %s: %w", dir, repoURL, err)
+		}
+	}
+
+	var dirs []string
+	for _, entry := range tree.Entries {
+		if entry.Mode == filemode.Dir {
+			dirs = append(dirs, path.Join(dir, entry.Name))
+		}
+	}
+	slices.Sort(dirs)
+	return dirs, nil
+}
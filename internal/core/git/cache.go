@@ -0,0 +1,200 @@
+package git
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"go.uber.org/zap"
+)
+
+// RepoCache keeps per-application clones in a directory that survives controller restarts,
+// so repositories only need to be pulled rather than cloned again when the controller starts.
+//
+// Cached clones are checked for integrity before they are reused and are evicted, least
+// recently used first, when the total size of the cache exceeds its limit.
+type RepoCache struct {
+	logger   *zap.Logger
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	inUse map[string]bool
+}
+
+// NewRepoCache creates a repository cache in dir, creating the directory if needed.
+// A maxBytes of zero or less disables size-based eviction.
+func NewRepoCache(logger *zap.Logger, dir string, maxBytes int64) (*RepoCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create repository cache directory %s: %w", dir, err)
+	}
+	return &RepoCache{
+		logger:   logger,
+		dir:      dir,
+		maxBytes: maxBytes,
+		inUse:    make(map[string]bool),
+	}, nil
+}
+
+// Acquire returns the cached clone directory for an application's repository and branch.
+//
+// If the directory holds a clone that fails the integrity check it is removed, so that the
+// next CloneOrPull starts from a fresh clone. The directory is protected from eviction until
+// it is passed to Release.
+func (rc *RepoCache) Acquire(appName, repoURL, branch string) (string, error) {
+	sum := sha256.Sum256([]byte(repoURL + "\x00" + branch))
+	repoDir := filepath.Join(rc.dir, safeDirName(appName)+"-"+hex.EncodeToString(sum[:])[:12])
+
+	rc.mu.Lock()
+	rc.inUse[repoDir] = true
+	rc.mu.Unlock()
+
+	if _, err := os.Stat(repoDir); err == nil {
+		if err := verifyClone(repoDir, repoURL); err != nil {
+			rc.logger.Warn("Cached repository failed integrity check, it will be cloned again",
+				zap.String("dir", repoDir), zap.Error(err))
+			if err := os.RemoveAll(repoDir); err != nil {
+				rc.Release(repoDir)
+				return "", fmt.Errorf("failed to remove corrupt cached repository %s: %w", repoDir, err)
+			}
+		} else {
+			rc.logger.Info("Reusing cached repository", zap.String("app", appName), zap.String("dir", repoDir))
+		}
+	}
+
+	now := time.Now()
+	if err := os.MkdirAll(repoDir, 0755); err != nil {
+		rc.Release(repoDir)
+		return "", fmt.Errorf("failed to create cached repository directory %s: %w", repoDir, err)
+	}
+	_ = os.Chtimes(repoDir, now, now) // Record the access for least-recently-used eviction
+
+	rc.Evict()
+	return repoDir, nil
+}
+
+// Release marks a directory returned by Acquire as no longer in use, making it eligible for eviction.
+// The clone itself is kept so it can be reused later.
+func (rc *RepoCache) Release(repoDir string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	delete(rc.inUse, repoDir)
+}
+
+// Evict removes the least recently used clones that are not in use until the cache fits within its size limit.
+func (rc *RepoCache) Evict() {
+	if rc.maxBytes <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(rc.dir)
+	if err != nil {
+		rc.logger.Warn("Failed to read repository cache directory", zap.String("dir", rc.dir), zap.Error(err))
+		return
+	}
+
+	type cacheEntry struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var (
+		cached []cacheEntry
+		total  int64
+	)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(rc.dir, entry.Name())
+		size := dirSize(path)
+		total += size
+		cached = append(cached, cacheEntry{path: path, size: size, modTime: info.ModTime()})
+	}
+	if total <= rc.maxBytes {
+		return
+	}
+
+	sort.Slice(cached, func(i, j int) bool { return cached[i].modTime.Before(cached[j].modTime) })
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, entry := range cached {
+		if total <= rc.maxBytes {
+			break
+		}
+		if rc.inUse[entry.path] {
+			continue
+		}
+		if err := os.RemoveAll(entry.path); err != nil {
+			rc.logger.Warn("Failed to evict cached repository", zap.String("dir", entry.path), zap.Error(err))
+			continue
+		}
+		total -= entry.size
+		rc.logger.Info("Evicted cached repository", zap.String("dir", entry.path), zap.Int64("bytes", entry.size))
+	}
+	if total > rc.maxBytes {
+		rc.logger.Warn("Repository cache exceeds its size limit; remaining clones are in use",
+			zap.Int64("bytes", total), zap.Int64("limit", rc.maxBytes))
+	}
+}
+
+// verifyClone checks that repoDir holds a readable clone of repoURL with a valid HEAD commit.
+func verifyClone(repoDir, repoURL string) error {
+	repo, err := gogit.PlainOpen(repoDir)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+	remote, err := repo.Remote("origin")
+	if err != nil {
+		return fmt.Errorf("failed to read origin remote: %w", err)
+	}
+	if urls := remote.Config().URLs; len(urls) == 0 || urls[0] != repoURL {
+		return fmt.Errorf("origin remote %v does not match %s", urls, repoURL)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	if _, err := repo.CommitObject(head.Hash()); err != nil {
+		return fmt.Errorf("failed to read HEAD commit %s: %w", head.Hash(), err)
+	}
+	return nil
+}
+
+// dirSize returns the total size of the regular files under dir.
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// safeDirName replaces characters that are not safe in a directory name.
+func safeDirName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,98 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"go.uber.org/zap"
+)
+
+// TempRepoDirPrefix is the name prefix of the temporary directories repositories are cloned into.
+const TempRepoDirPrefix = "gitopsctl-repo-"
+
+// tempRepoDirPattern returns the os.MkdirTemp pattern for an application's clone directory.
+//
+// The name records the application and the PID of the controller instance that owns the
+// directory, e.g. "gitopsctl-repo-myapp-4242-123456789", so that directories left behind by a
+// controller that is no longer running can be recognized and removed.
+func tempRepoDirPattern(appName string) string {
+	return fmt.Sprintf("%s%s-%d-*", TempRepoDirPrefix, safeDirName(appName), os.Getpid())
+}
+
+// ownerPID extracts the PID of the controller instance that created a temporary clone directory.
+// It returns false for directories that do not follow the naming scheme of tempRepoDirPattern.
+func ownerPID(dirName string) (int, bool) {
+	parts := strings.Split(strings.TrimPrefix(dirName, TempRepoDirPrefix), "-")
+	if len(parts) < 3 {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(parts[len(parts)-2])
+	if err != nil || pid <= 0 {
+		return 0, false
+	}
+	return pid, true
+}
+
+// processRunning reports whether a process with the given PID exists.
+func processRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// FindOrphanedRepoDirs returns the temporary clone directories in tempDir that are not owned by
+// a running controller instance. Directories without an owner in their name, such as those
+// created by older versions, are always considered orphaned. An empty tempDir means os.TempDir().
+func FindOrphanedRepoDirs(tempDir string) ([]string, error) {
+	if tempDir == "" {
+		tempDir = os.TempDir()
+	}
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read temporary directory %s: %w", tempDir, err)
+	}
+
+	var orphaned []string
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), TempRepoDirPrefix) {
+			continue
+		}
+		if pid, ok := ownerPID(entry.Name()); ok && processRunning(pid) {
+			continue
+		}
+		orphaned = append(orphaned, filepath.Join(tempDir, entry.Name()))
+	}
+	return orphaned, nil
+}
+
+// CleanUpOrphanedRepoDirs removes the temporary clone directories in tempDir that were left
+// behind by controller instances that are no longer running, for example after a crash.
+// When dryRun is true nothing is removed. It returns the directories that were (or would be) removed.
+func CleanUpOrphanedRepoDirs(logger *zap.Logger, tempDir string, dryRun bool) ([]string, error) {
+	orphaned, err := FindOrphanedRepoDirs(tempDir)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return orphaned, nil
+	}
+
+	var removed []string
+	var errs []error
+	for _, dir := range orphaned {
+		if err := CleanUpRepo(logger, dir); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		removed = append(removed, dir)
+	}
+	return removed, errors.Join(errs...)
+}
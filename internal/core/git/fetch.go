@@ -0,0 +1,76 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+)
+
+// FetchOptions selects what CloneOrPull fetches besides the files of the branch.
+type FetchOptions struct {
+	// Submodules initializes and updates the repository's submodules, recursively.
+	Submodules bool
+	// LFS replaces the Git LFS pointer files of the checked out commit with their content. It runs
+	// "git lfs pull", so it needs the git and git-lfs binaries.
+	LFS bool
+}
+
+// updateSubmodules initializes the submodules of the repository and checks out the commits recorded
+// for them, recursively.
+func updateSubmodules(ctx context.Context, repo *gogit.Repository, repoURL string) error {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	submodules, err := worktree.Submodules()
+	if err != nil {
+		return fmt.Errorf("failed to read submodules: %w", err)
+	}
+	err = submodules.UpdateContext(ctx, &gogit.SubmoduleUpdateOptions{
+		Init:              true,
+		RecurseSubmodules: gogit.DefaultSubmoduleRecursionDepth,
+		Auth:              setupAuth(repoURL),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update submodules: %w", err)
+	}
+	return nil
+}
+
+// pullLFS fetches the Git LFS objects of the commit checked out in repoDir and writes their content
+// over the pointer files.
+func pullLFS(ctx context.Context, repoDir string) error {
+	path, err := exec.LookPath("git")
+	if err != nil {
+		return fmt.Errorf("Git LFS requires the 'git' binary, with git-lfs, in PATH: %w", err)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, "lfs", "pull")
+	cmd.Dir = repoDir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git lfs pull failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// restoreLFSPointers puts the pointer files back in place of the Git LFS content written by pullLFS,
+// which go-git sees as unstaged changes and would refuse to pull over. Clones without LFS objects
+// are left alone.
+func restoreLFSPointers(worktree *gogit.Worktree, repoDir string) error {
+	if _, err := os.Stat(filepath.Join(repoDir, ".git", "lfs")); err != nil {
+		return nil
+	}
+	if err := worktree.Reset(&gogit.ResetOptions{Mode: gogit.HardReset}); err != nil {
+		return fmt.Errorf("failed to restore Git LFS pointer files in %s: %w", repoDir, err)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -15,8 +16,9 @@ import (
 
 // CloneOrPull performs a Git clone if the target directory doesn't contain a valid Git repository.
 // If the repository already exists, it performs a Git pull to fetch the latest changes.
+// Submodules and Git LFS objects are then fetched as selected by options.
 // Returns the HEAD commit hash after the operation.
-func CloneOrPull(ctx context.Context, logger *zap.Logger, repoURL, branch, targetDir string) (string, error) {
+func CloneOrPull(ctx context.Context, logger *zap.Logger, repoURL, branch, targetDir string, options FetchOptions) (string, error) {
 	var repo *gogit.Repository
 	var err error
 
@@ -41,7 +43,7 @@ func CloneOrPull(ctx context.Context, logger *zap.Logger, repoURL, branch, targe
 				Auth:          setupAuth(repoURL), // Handles SSH agent/keys
 			})
 			if err != nil {
-				return "", fmt.Errorf("failed to clone repository %s: %w", repoURL, err)
+				return "", rateLimitError(repoURL, fmt.Errorf("failed to clone repository %s: %w", repoURL, err), time.Now())
 			}
 		} else {
 			// Another error occurred while trying to open the repository.
@@ -58,6 +60,9 @@ func CloneOrPull(ctx context.Context, logger *zap.Logger, repoURL, branch, targe
 		if err != nil {
 			return "", fmt.Errorf("failed to get worktree for %s: %w", targetDir, err)
 		}
+		if err := restoreLFSPointers(worktree, targetDir); err != nil {
+			return "", err
+		}
 
 		err = worktree.PullContext(ctx, &gogit.PullOptions{
 			RemoteName:    "origin",
@@ -70,11 +75,24 @@ func CloneOrPull(ctx context.Context, logger *zap.Logger, repoURL, branch, targe
 			if err == gogit.NoErrAlreadyUpToDate {
 				logger.Debug("Repository already up-to-date", zap.String("repoURL", repoURL))
 			} else {
-				return "", fmt.Errorf("failed to pull repository %s: %w", repoURL, err)
+				return "", rateLimitError(repoURL, fmt.Errorf("failed to pull repository %s: %w", repoURL, err), time.Now())
 			}
 		}
 	}
 
+	if options.Submodules {
+		logger.Debug("Updating submodules", zap.String("targetDir", targetDir))
+		if err := updateSubmodules(ctx, repo, repoURL); err != nil {
+			return "", rateLimitError(repoURL, fmt.Errorf("failed to fetch submodules of %s: %w", repoURL, err), time.Now())
+		}
+	}
+	if options.LFS {
+		logger.Debug("Fetching Git LFS objects", zap.String("targetDir", targetDir))
+		if err := pullLFS(ctx, targetDir); err != nil {
+			return "", fmt.Errorf("failed to fetch Git LFS objects of %s: %w", repoURL, err)
+		}
+	}
+
 	// Get the HEAD commit hash after either clone or pull operation.
 	head, err := repo.Head()
 	if err != nil {
@@ -97,6 +115,19 @@ func GetLatestCommitHash(logger *zap.Logger, repoPath string) (string, error) {
 	return head.Hash().String(), nil
 }
 
+// CommitTime returns when a commit of a local Git repository was committed.
+func CommitTime(repoPath, hash string) (time.Time, error) {
+	repo, err := gogit.PlainOpen(repoPath)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to open repository %s: %w", repoPath, err)
+	}
+	commit, err := repo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get commit %s: %w", hash, err)
+	}
+	return commit.Committer.When, nil
+}
+
 // setupAuth provides authentication for Git operations.
 // For SSH-based repositories, it attempts to use the SSH agent or default SSH keys.
 // For HTTPS-based repositories, it currently supports public repositories without authentication.
@@ -126,10 +157,11 @@ func CleanUpRepo(logger *zap.Logger, repoDir string) error {
 	return nil
 }
 
-// CreateTempRepoDir creates a temporary directory for cloning a repository.
-// The directory is created with a unique name to ensure isolation between different Git operations.
-func CreateTempRepoDir() (string, error) {
-	tmpDir, err := os.MkdirTemp("", "gitopsctl-repo-*")
+// CreateTempRepoDir creates a temporary directory for cloning an application's repository.
+// The directory is created with a unique name to ensure isolation between different Git operations,
+// and records the application and controller instance so orphaned directories can be cleaned up.
+func CreateTempRepoDir(appName string) (string, error) {
+	tmpDir, err := os.MkdirTemp("", tempRepoDirPattern(appName))
 	if err != nil {
 		return "", fmt.Errorf("failed to create temporary directory: %w", err)
 	}
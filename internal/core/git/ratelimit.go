@@ -0,0 +1,121 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// DefaultRateLimitWait is how long fetches from a provider are held back after a rate limit
+// response that does not say when the limit resets.
+const DefaultRateLimitWait = time.Minute
+
+// RateLimitError is returned when a Git provider rejects a request because a rate limit was exceeded.
+type RateLimitError struct {
+	// Host is the provider's host, e.g. "github.com". Rate limits apply to every repository on it.
+	Host string
+	// Provider is the provider's name, e.g. "GitHub", or its host if it is not recognized.
+	Provider string
+	// ResetAt is when the provider accepts requests again.
+	ResetAt time.Time
+	// Err is the error returned by the request.
+	Err error
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s rate limit exceeded until %s: %v", e.Provider, e.ResetAt.Format(time.RFC3339), e.Err)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// RepoHost returns the host of a repository URL, in HTTP(S), SSH or scp-like ("git@host:path") form,
+// or an empty string if it has none.
+func RepoHost(repoURL string) string {
+	if u, err := url.Parse(repoURL); err == nil && u.Host != "" {
+		return strings.ToLower(u.Hostname())
+	}
+	if at := strings.Index(repoURL, "@"); at >= 0 {
+		if colon := strings.Index(repoURL[at:], ":"); colon > 0 {
+			return strings.ToLower(repoURL[at+1 : at+colon])
+		}
+	}
+	return ""
+}
+
+// ProviderName returns the name of the provider hosting repositories on host.
+func ProviderName(host string) string {
+	switch {
+	case host == "github.com" || strings.HasSuffix(host, ".github.com"):
+		return "GitHub"
+	case host == "gitlab.com" || strings.HasPrefix(host, "gitlab."):
+		return "GitLab"
+	}
+	return host
+}
+
+// rateLimitError returns a RateLimitError wrapping err if it is a provider's rate limit response,
+// and err unchanged otherwise.
+//
+// GitHub and GitLab answer with 429 Too Many Requests, or 403 Forbidden for GitHub's secondary
+// rate limits, and tell when the limit resets in Retry-After, X-RateLimit-Reset (GitHub) or
+// RateLimit-Reset (GitLab). go-git drops the headers of 403 responses, so those are recognized by
+// their body and wait DefaultRateLimitWait.
+func rateLimitError(repoURL string, err error, now time.Time) error {
+	if err == nil {
+		return nil
+	}
+
+	var resetAt time.Time
+	var unexpected *plumbing.UnexpectedError
+	if errors.As(err, &unexpected) {
+		httpErr, ok := unexpected.Err.(*http.Err)
+		if !ok || httpErr.StatusCode() != nethttp.StatusTooManyRequests {
+			return err
+		}
+		resetAt = rateLimitReset(httpErr.Response.Header, now)
+	} else if !errors.Is(err, transport.ErrAuthorizationFailed) || !strings.Contains(strings.ToLower(err.Error()), "rate limit") {
+		return err
+	}
+	if resetAt.IsZero() {
+		resetAt = now.Add(DefaultRateLimitWait)
+	}
+
+	host := RepoHost(repoURL)
+	return &RateLimitError{Host: host, Provider: ProviderName(host), ResetAt: resetAt, Err: err}
+}
+
+// rateLimitReset returns when a rate limit resets according to the response headers, or zero if
+// they do not say.
+func rateLimitReset(header nethttp.Header, now time.Time) time.Time {
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return now.Add(time.Duration(seconds) * time.Second)
+		}
+		if at, err := nethttp.ParseTime(retryAfter); err == nil {
+			return at
+		}
+	}
+	for _, name := range []string{"X-RateLimit-Reset", "RateLimit-Reset"} {
+		reset, err := strconv.ParseInt(header.Get(name), 10, 64)
+		switch {
+		case err != nil || reset <= 0:
+			continue
+		case reset < 1_000_000_000:
+			// Seconds until the reset, as in the IETF RateLimit header fields, rather than a Unix time
+			return now.Add(time.Duration(reset) * time.Second)
+		default:
+			return time.Unix(reset, 0)
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,98 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/format/idxfile"
+)
+
+// Operation is how a repository is fetched.
+type Operation string
+
+const (
+	// OperationClone is a fresh clone into an empty directory.
+	OperationClone Operation = "clone"
+	// OperationPull is a pull into an existing clone.
+	OperationPull Operation = "pull"
+	// OperationCheck is a listing of the remote's references that found the clone up to date, so
+	// nothing was pulled.
+	OperationCheck Operation = "ls-remote"
+)
+
+// Operations lists the ways a repository is fetched.
+var Operations = []Operation{OperationClone, OperationPull, OperationCheck}
+
+// NextOperation returns how CloneOrPull will fetch the repository into targetDir.
+func NextOperation(targetDir string) Operation {
+	if _, err := gogit.PlainOpen(targetDir); err != nil {
+		return OperationClone
+	}
+	return OperationPull
+}
+
+// RepoStats is the footprint of a clone on disk.
+type RepoStats struct {
+	// SizeBytes is the total size of the clone's files, including its worktree.
+	SizeBytes int64
+	// Objects is the number of Git objects stored in the clone, packed or loose.
+	Objects int
+}
+
+// Stats measures the clone in repoDir. The objects are counted from the pack indexes and the loose
+// object directories, so no object needs to be read.
+func Stats(repoDir string) (RepoStats, error) {
+	objectsDir := filepath.Join(repoDir, ".git", "objects")
+	if _, err := os.Stat(objectsDir); err != nil {
+		return RepoStats{}, fmt.Errorf("failed to read objects of repository %s: %w", repoDir, err)
+	}
+
+	objects := 0
+	indexes, _ := filepath.Glob(filepath.Join(objectsDir, "pack", "*.idx"))
+	for _, path := range indexes {
+		count, err := packObjects(path)
+		if err != nil {
+			return RepoStats{}, err
+		}
+		objects += count
+	}
+
+	// Loose objects are stored in directories named after the first two hex digits of their hash
+	entries, err := os.ReadDir(objectsDir)
+	if err != nil {
+		return RepoStats{}, fmt.Errorf("failed to read objects of repository %s: %w", repoDir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || len(entry.Name()) != 2 {
+			continue
+		}
+		loose, err := os.ReadDir(filepath.Join(objectsDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		objects += len(loose)
+	}
+
+	return RepoStats{SizeBytes: dirSize(repoDir), Objects: objects}, nil
+}
+
+// packObjects returns the number of objects in the pack of the index at path.
+func packObjects(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open pack index %s: %w", path, err)
+	}
+	defer f.Close()
+
+	index := idxfile.NewMemoryIndex()
+	if err := idxfile.NewDecoder(f).Decode(index); err != nil {
+		return 0, fmt.Errorf("failed to read pack index %s: %w", path, err)
+	}
+	count, err := index.Count()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count objects in pack index %s: %w", path, err)
+	}
+	return int(count), nil
+}
@@ -0,0 +1,134 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// VerifyTimeout bounds how long verifying a repository before registration may take.
+const VerifyTimeout = 30 * time.Second
+
+// VerifyRepository checks that an application's repository can be used before it is registered:
+// the URL is reachable with the available credentials, the branch exists, and the manifests path
+// is a directory at the branch's latest commit.
+//
+// The references are listed first, so unreachable repositories and missing branches are reported
+// without fetching anything. The path is then checked against a shallow, in-memory fetch of the
+// branch; nothing is written to disk. The returned errors describe how to fix the problem.
+func VerifyRepository(ctx context.Context, repoURL, branch, repoPath string) error {
+	branches, err := RemoteBranches(ctx, repoURL)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(branches, branch) {
+		if len(branches) == 0 {
+			return fmt.Errorf("branch '%s' not found: repository %s has no branches", branch, repoURL)
+		}
+		return fmt.Errorf("branch '%s' not found in %s\nAvailable branches: %s", branch, repoURL, strings.Join(branches, ", "))
+	}
+
+	commit, err := fetchCommit(ctx, repoURL, branch)
+	if err != nil {
+		return err
+	}
+
+	repoPath = path.Clean(repoPath)
+	if repoPath == "." {
+		return nil
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return fmt.Errorf("failed to read the tree of commit %s: %w", commit.Hash, err)
+	}
+	entry, err := tree.FindEntry(repoPath)
+	if errors.Is(err, object.ErrEntryNotFound) || errors.Is(err, object.ErrDirectoryNotFound) {
+		return fmt.Errorf("path '%s' does not exist on branch '%s' of %s (commit %s)",
+			repoPath, branch, repoURL, commit.Hash.String()[:7])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up path '%s' in %s: %w", repoPath, repoURL, err)
+	}
+	if entry.Mode.IsFile() {
+		return fmt.Errorf("path '%s' on branch '%s' of %s is a file, but must be a directory of manifests",
+			repoPath, branch, repoURL)
+	}
+	return nil
+}
+
+// remoteError is a failure to reach a remote repository, described with how to fix it. It wraps
+// the failure so that its cause can still be checked, e.g. by IsAccessDenied.
+type remoteError struct {
+	message string
+	err     error
+}
+
+// Error implements the error interface.
+func (e *remoteError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the failure.
+func (e *remoteError) Unwrap() error {
+	return e.err
+}
+
+// IsAccessDenied reports whether err is a failure to access a remote repository because
+// credentials were missing or rejected.
+func IsAccessDenied(err error) bool {
+	return errors.Is(err, transport.ErrAuthenticationRequired) || errors.Is(err, transport.ErrAuthorizationFailed)
+}
+
+// describeRemoteError turns a failure to reach a remote repository into an actionable error.
+func describeRemoteError(repoURL string, err error) error {
+	var message string
+	switch {
+	case errors.Is(err, transport.ErrRepositoryNotFound):
+		message = fmt.Sprintf("repository %s not found\nCheck the URL, or that your credentials can access it", repoURL)
+	case IsAccessDenied(err):
+		message = fmt.Sprintf("access to repository %s was denied\nPrivate HTTPS repositories are not supported yet; use an SSH URL with a key loaded in your SSH agent", repoURL)
+	case errors.Is(err, transport.ErrEmptyRemoteRepository):
+		message = fmt.Sprintf("repository %s is empty\nPush the application's manifests first", repoURL)
+	case errors.Is(err, context.DeadlineExceeded):
+		message = fmt.Sprintf("timed out connecting to repository %s\nCheck the URL and your network connection", repoURL)
+	default:
+		message = fmt.Sprintf("repository %s is not reachable: %v", repoURL, err)
+	}
+	return &remoteError{message: message, err: err}
+}
+
+// fetchCommit fetches the latest commit of a branch into memory, with a shallow clone that
+// checks nothing out, so its tree can be inspected without writing to disk.
+func fetchCommit(ctx context.Context, repoURL, branch string) (*object.Commit, error) {
+	repo, err := gogit.CloneContext(ctx, memory.NewStorage(), nil, &gogit.CloneOptions{
+		URL:           repoURL,
+		ReferenceName: plumbing.NewBranchReferenceName(branch),
+		SingleBranch:  true,
+		Depth:         1,
+		NoCheckout:    true,
+		Tags:          gogit.NoTags,
+		Auth:          setupAuth(repoURL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch branch '%s' of %s: %w", branch, repoURL, err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve branch '%s' of %s: %w", branch, repoURL, err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit %s of %s: %w", head.Hash(), repoURL, err)
+	}
+	return commit, nil
+}
@@ -0,0 +1,286 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	"aeswibon.com/github/gitopsctl/internal/core/render"
+	"sigs.k8s.io/yaml"
+)
+
+// argoApplicationKind is the kind of Argo CD Application resources.
+const argoApplicationKind = "Application"
+
+// commitSHA matches a full or abbreviated Git commit hash, which Argo CD accepts as a target revision.
+var commitSHA = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// argoApplication is the subset of an Argo CD Application that is imported.
+type argoApplication struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     struct {
+		Source      *argoSource     `json:"source"`
+		Sources     []argoSource    `json:"sources"`
+		Destination argoDestination `json:"destination"`
+		SyncPolicy  *struct {
+			Automated *struct {
+				Prune bool `json:"prune"`
+			} `json:"automated"`
+		} `json:"syncPolicy"`
+	} `json:"spec"`
+}
+
+// argoSource is the source of an Argo CD Application.
+type argoSource struct {
+	RepoURL        string         `json:"repoURL"`
+	TargetRevision string         `json:"targetRevision"`
+	Path           string         `json:"path"`
+	Chart          string         `json:"chart"`
+	Ref            string         `json:"ref"`
+	Helm           *argoHelm      `json:"helm"`
+	Kustomize      map[string]any `json:"kustomize"`
+	Directory      argoDirectory  `json:"directory"`
+	Plugin         *struct {
+		Name string `json:"name"`
+		Env  []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"env"`
+	} `json:"plugin"`
+}
+
+// argoDirectory is the directory configuration of an Argo CD Application source.
+type argoDirectory struct {
+	Recurse bool           `json:"recurse"`
+	Exclude string         `json:"exclude"`
+	Include string         `json:"include"`
+	Jsonnet map[string]any `json:"jsonnet"`
+}
+
+// argoHelm is the Helm configuration of an Argo CD Application source.
+type argoHelm struct {
+	ReleaseName  string         `json:"releaseName"`
+	ValueFiles   []string       `json:"valueFiles"`
+	Values       string         `json:"values"`
+	ValuesObject map[string]any `json:"valuesObject"`
+	Parameters   []struct {
+		Name        string `json:"name"`
+		Value       string `json:"value"`
+		ForceString bool   `json:"forceString"`
+	} `json:"parameters"`
+}
+
+// argoDestination is the cluster and namespace an Argo CD Application deploys to.
+type argoDestination struct {
+	Server    string `json:"server"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// ArgoCD translates the Argo CD Applications in the given files into gitopsctl applications.
+// The plugins are the configured manifest generator plugins, which config management plugins
+// are mapped to by name.
+//
+// Each source is mapped to a repository, branch and path; Helm, Jsonnet and plugin sources select
+// the matching renderer. Applications using features gitopsctl lacks, such as Helm repository charts
+// or pinned commits, are rejected; settings it handles differently are reported as warnings.
+// Documents of other kinds, such as AppProjects, are skipped.
+func ArgoCD(files []string, plugins *render.Plugins) ([]Result, error) {
+	documents, err := readDocuments(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, doc := range documents {
+		if doc.kind != argoApplicationKind {
+			continue
+		}
+		var application argoApplication
+		if err := doc.decode(&application); err != nil {
+			return nil, err
+		}
+		result, err := translateArgoApplication(application, plugins)
+		if err != nil {
+			return nil, fmt.Errorf("application %s: %w", qualifiedName(application.Metadata), err)
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no Argo CD Applications found in %s", strings.Join(files, ", "))
+	}
+	return results, nil
+}
+
+// translateArgoApplication translates a single Argo CD Application.
+func translateArgoApplication(application argoApplication, plugins *render.Plugins) (Result, error) {
+	result := Result{
+		Source: "Application " + qualifiedName(application.Metadata),
+		Destination: Destination{
+			Name:   application.Spec.Destination.Name,
+			Server: strings.TrimSuffix(application.Spec.Destination.Server, "/"),
+		},
+	}
+	warn := func(format string, args ...any) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	source, err := argoApplicationSource(application)
+	if err != nil {
+		return Result{}, err
+	}
+	if source.Chart != "" {
+		return Result{}, fmt.Errorf("charts from Helm repositories are not supported, only charts stored in Git")
+	}
+
+	branch := source.TargetRevision
+	if branch == "HEAD" {
+		branch = ""
+	}
+	if commitSHA.MatchString(branch) {
+		return Result{}, fmt.Errorf("target revision '%s' pins a commit; gitopsctl tracks branches", branch)
+	}
+	if strings.ContainsAny(branch, "*^~<>= ") {
+		return Result{}, fmt.Errorf("target revision '%s' is a version constraint; gitopsctl tracks branches", branch)
+	}
+
+	newApp, err := newApplication(application.Metadata.Name, source.RepoURL, branch, source.Path)
+	if err != nil {
+		return Result{}, err
+	}
+	result.App = newApp
+
+	namespace := application.Spec.Destination.Namespace
+	switch {
+	case source.Helm != nil:
+		if newApp.Helm, err = argoHelmSource(source.Helm, namespace); err != nil {
+			return Result{}, err
+		}
+		newApp.Renderer = render.RendererHelm
+		if source.Helm.ReleaseName != "" && source.Helm.ReleaseName != newApp.Name {
+			warn("release name '%s' is not kept; the chart is rendered with the application name as its release name", source.Helm.ReleaseName)
+		}
+	case source.Plugin != nil:
+		if _, ok := plugins.Get(source.Plugin.Name); !ok {
+			return Result{}, fmt.Errorf("config management plugin '%s' has no matching plugin in %s", source.Plugin.Name, render.DefaultPluginConfigFile)
+		}
+		newApp.Renderer = source.Plugin.Name
+		for _, env := range source.Plugin.Env {
+			if newApp.PluginParams == nil {
+				newApp.PluginParams = make(map[string]string)
+			}
+			newApp.PluginParams[env.Name] = env.Value
+		}
+	case source.Kustomize != nil:
+		warn("kustomize options are not supported; the path's YAML files are applied as they are, unless a kustomize plugin is configured and selected with 'gitopsctl app register --renderer'")
+	case len(source.Directory.Jsonnet) > 0:
+		newApp.Renderer = render.RendererJsonnet
+		warn("Jsonnet external variables, top-level arguments and library paths are not imported")
+	}
+
+	if namespace != "" && namespace != "default" && newApp.Renderer != render.RendererHelm {
+		warn("destination namespace '%s' is not applied; resources without a namespace are created in 'default'", namespace)
+	}
+	if newApp.Renderer == "" && source.Kustomize == nil {
+		dir := source.Directory
+		if !dir.Recurse {
+			warn("Argo CD only reads the path's top-level files without directory.recurse; gitopsctl also applies manifests in its subdirectories")
+		}
+		if dir.Exclude != "" {
+			newApp.Exclude = splitArgoGlob(dir.Exclude)
+			if err := k8s.ValidateExcludePatterns(newApp.Exclude); err != nil {
+				return Result{}, err
+			}
+		}
+		if dir.Include != "" {
+			warn("include pattern '%s' is not supported; every manifest under the path is applied", dir.Include)
+		}
+	}
+
+	syncPolicy := application.Spec.SyncPolicy
+	if syncPolicy == nil || syncPolicy.Automated == nil {
+		warn("the application is synced manually in Argo CD; gitopsctl syncs it automatically every polling interval")
+	} else if syncPolicy.Automated.Prune {
+		warn("resources removed from the repository are not pruned by gitopsctl")
+	}
+	return result, nil
+}
+
+// argoApplicationSource returns the Application's source. Applications with several sources are
+// only supported if all but one of them are references, which gitopsctl cannot resolve anyway.
+func argoApplicationSource(application argoApplication) (argoSource, error) {
+	if application.Spec.Source != nil {
+		return *application.Spec.Source, nil
+	}
+	var sources []argoSource
+	for _, source := range application.Spec.Sources {
+		if source.Ref == "" {
+			sources = append(sources, source)
+		}
+	}
+	if len(sources) != 1 {
+		return argoSource{}, fmt.Errorf("applications must have exactly one source, found %d", len(sources))
+	}
+	return sources[0], nil
+}
+
+// argoHelmSource translates the Helm options of a source. Inline values, the values object and
+// parameters are merged into the inline values in the order Argo CD applies them.
+func argoHelmSource(helm *argoHelm, namespace string) (*app.HelmSource, error) {
+	for _, file := range helm.ValueFiles {
+		if strings.HasPrefix(file, "$") || strings.Contains(file, "://") {
+			return nil, fmt.Errorf("values file '%s' is not supported; values files must be in the chart's repository", file)
+		}
+	}
+	if err := render.ValidateValuesFiles(helm.ValueFiles); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any)
+	if helm.Values != "" {
+		var inline map[string]any
+		if err := yaml.Unmarshal([]byte(helm.Values), &inline); err != nil {
+			return nil, fmt.Errorf("invalid helm values: %w", err)
+		}
+		render.MergeValues(values, inline)
+	}
+	render.MergeValues(values, helm.ValuesObject)
+	for _, param := range helm.Parameters {
+		value := param.Value
+		if param.ForceString {
+			quoted, err := yaml.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			value = strings.TrimSpace(string(quoted))
+		}
+		set, err := render.ParseHelmSet([]string{param.Name + "=" + value})
+		if err != nil {
+			return nil, fmt.Errorf("invalid helm parameter: %w", err)
+		}
+		render.MergeValues(values, set)
+	}
+
+	source := &app.HelmSource{ValuesFiles: helm.ValueFiles, Namespace: namespace}
+	if len(values) > 0 {
+		source.Values = values
+	}
+	return source, nil
+}
+
+// splitArgoGlob splits an Argo CD directory glob into exclude patterns. Argo CD accepts several
+// patterns as a brace list, such as "{config.json,env-usw2/*}".
+func splitArgoGlob(glob string) []string {
+	if strings.HasPrefix(glob, "{") && strings.HasSuffix(glob, "}") {
+		glob = glob[1 : len(glob)-1]
+	}
+	var patterns []string
+	for _, pattern := range strings.Split(glob, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
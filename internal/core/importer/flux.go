@@ -0,0 +1,237 @@
+package importer
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+)
+
+const (
+	// fluxKustomizationKind is the kind of Flux Kustomization resources.
+	fluxKustomizationKind = "Kustomization"
+	// fluxGitRepositoryKind is the kind of Flux GitRepository resources.
+	fluxGitRepositoryKind = "GitRepository"
+	// fluxDefaultBranch is the branch a GitRepository without a ref tracks.
+	fluxDefaultBranch = "master"
+)
+
+// fluxKustomization is the subset of a Flux Kustomization that is imported.
+type fluxKustomization struct {
+	APIVersion string     `json:"apiVersion"`
+	Metadata   objectMeta `json:"metadata"`
+	Spec       struct {
+		Path      string `json:"path"`
+		Prune     bool   `json:"prune"`
+		Suspend   bool   `json:"suspend"`
+		SourceRef struct {
+			Kind      string `json:"kind"`
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"sourceRef"`
+		TargetNamespace string           `json:"targetNamespace"`
+		KubeConfig      map[string]any   `json:"kubeConfig"`
+		PostBuild       map[string]any   `json:"postBuild"`
+		Decryption      map[string]any   `json:"decryption"`
+		DependsOn       []map[string]any `json:"dependsOn"`
+		Patches         []any            `json:"patches"`
+		Images          []any            `json:"images"`
+		Components      []string         `json:"components"`
+	} `json:"spec"`
+}
+
+// fluxGitRepository is the subset of a Flux GitRepository that is imported.
+type fluxGitRepository struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     struct {
+		URL      string `json:"url"`
+		Interval string `json:"interval"`
+		Ref      *struct {
+			Branch string `json:"branch"`
+			Tag    string `json:"tag"`
+			SemVer string `json:"semver"`
+			Name   string `json:"name"`
+			Commit string `json:"commit"`
+		} `json:"ref"`
+		SecretRef         map[string]any `json:"secretRef"`
+		RecurseSubmodules bool           `json:"recurseSubmodules"`
+	} `json:"spec"`
+}
+
+// Flux translates the Flux Kustomizations in the given files into gitopsctl applications.
+//
+// The GitRepository each Kustomization refers to must be in one of the files; it provides the
+// repository, branch and polling interval. Kustomizations deploy to the cluster Flux runs in unless
+// they set a kubeConfig. Kustomizations using features gitopsctl lacks, such as OCI or bucket
+// sources or tag references, are rejected; settings it handles differently are reported as
+// warnings. Documents of other kinds are skipped.
+func Flux(files []string) ([]Result, error) {
+	documents, err := readDocuments(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		kustomizations []fluxKustomization
+		repositories   []fluxGitRepository
+	)
+	for _, doc := range documents {
+		switch doc.kind {
+		case fluxKustomizationKind:
+			var kustomization fluxKustomization
+			if err := doc.decode(&kustomization); err != nil {
+				return nil, err
+			}
+			// kustomize's own kustomization.yaml files share the kind.
+			if strings.HasPrefix(kustomization.APIVersion, "kustomize.toolkit.fluxcd.io/") {
+				kustomizations = append(kustomizations, kustomization)
+			}
+		case fluxGitRepositoryKind:
+			var repository fluxGitRepository
+			if err := doc.decode(&repository); err != nil {
+				return nil, err
+			}
+			repositories = append(repositories, repository)
+		}
+	}
+	if len(kustomizations) == 0 {
+		return nil, fmt.Errorf("no Flux Kustomizations found in %s", strings.Join(files, ", "))
+	}
+
+	results := make([]Result, 0, len(kustomizations))
+	for _, kustomization := range kustomizations {
+		result, err := translateFluxKustomization(kustomization, repositories)
+		if err != nil {
+			return nil, fmt.Errorf("kustomization %s: %w", qualifiedName(kustomization.Metadata), err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// translateFluxKustomization translates a single Flux Kustomization, using the GitRepository it refers to.
+func translateFluxKustomization(kustomization fluxKustomization, repositories []fluxGitRepository) (Result, error) {
+	result := Result{Source: "Kustomization " + qualifiedName(kustomization.Metadata)}
+	warn := func(format string, args ...any) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(format, args...))
+	}
+	spec := kustomization.Spec
+
+	ref := spec.SourceRef
+	if ref.Kind != fluxGitRepositoryKind {
+		return Result{}, fmt.Errorf("%s sources are not supported, only GitRepository", ref.Kind)
+	}
+	refNamespace := common.DefaultIfEmpty(ref.Namespace, kustomization.Metadata.Namespace)
+	var repository *fluxGitRepository
+	for i := range repositories {
+		meta := repositories[i].Metadata
+		if meta.Name == ref.Name && (refNamespace == "" || meta.Namespace == "" || meta.Namespace == refNamespace) {
+			repository = &repositories[i]
+			break
+		}
+	}
+	if repository == nil {
+		return Result{}, fmt.Errorf("GitRepository %s not found; pass the file defining it with another --file",
+			qualifiedName(objectMeta{Name: ref.Name, Namespace: refNamespace}))
+	}
+
+	branch, err := fluxBranch(repository)
+	if err != nil {
+		return Result{}, err
+	}
+	repoURL, err := fluxRepoURL(repository.Spec.URL)
+	if err != nil {
+		return Result{}, err
+	}
+	newApp, err := newApplication(kustomization.Metadata.Name, repoURL, branch, spec.Path)
+	if err != nil {
+		return Result{}, err
+	}
+	result.App = newApp
+
+	if interval := repository.Spec.Interval; interval != "" {
+		if _, err := common.ParsePollingInterval(interval); err != nil {
+			warn("the GitRepository's interval is not used: %v", err)
+		} else {
+			newApp.Interval = interval
+		}
+	}
+	newApp.Submodules = repository.Spec.RecurseSubmodules
+
+	if spec.KubeConfig == nil {
+		result.Destination = Destination{Server: InClusterServer}
+	} else {
+		warn("the Kustomization deploys to a remote cluster through its kubeConfig, which cannot be imported")
+	}
+
+	warn("Flux builds the path with kustomize; if it has a kustomization.yaml, configure a kustomize plugin and select it with 'gitopsctl app register --renderer'")
+	if spec.TargetNamespace != "" {
+		warn("target namespace '%s' is not applied; resources without a namespace are created in 'default'", spec.TargetNamespace)
+	}
+	if len(spec.Patches) > 0 || len(spec.Images) > 0 || len(spec.Components) > 0 {
+		warn("patches, image overrides and components are not supported")
+	}
+	if spec.PostBuild != nil {
+		warn("post-build variable substitution is not supported")
+	}
+	if spec.Decryption != nil {
+		warn("SOPS decryption is not supported")
+	}
+	if len(spec.DependsOn) > 0 {
+		warn("dependencies on other Kustomizations are not supported; every application syncs independently")
+	}
+	if repository.Spec.SecretRef != nil {
+		warn("the GitRepository's credentials are not imported; gitopsctl uses the SSH agent for SSH URLs and reads HTTPS repositories anonymously")
+	}
+	if spec.Prune {
+		warn("resources removed from the repository are not pruned by gitopsctl")
+	}
+	if spec.Suspend {
+		warn("the Kustomization is suspended in Flux; gitopsctl syncs it as soon as the controller runs")
+	}
+	return result, nil
+}
+
+// fluxBranch returns the branch a GitRepository tracks. References to tags, semver ranges and
+// commits are rejected, since gitopsctl only tracks branches.
+func fluxBranch(repository *fluxGitRepository) (string, error) {
+	ref := repository.Spec.Ref
+	if ref == nil {
+		return fluxDefaultBranch, nil
+	}
+	switch {
+	case ref.Commit != "" || ref.Tag != "" || ref.SemVer != "":
+		return "", fmt.Errorf("GitRepository %s refers to a tag, semver range or commit; gitopsctl tracks branches", qualifiedName(repository.Metadata))
+	case ref.Name != "":
+		branch, ok := strings.CutPrefix(ref.Name, "refs/heads/")
+		if !ok {
+			return "", fmt.Errorf("GitRepository %s refers to '%s'; gitopsctl tracks branches", qualifiedName(repository.Metadata), ref.Name)
+		}
+		return branch, nil
+	case ref.Branch != "":
+		return ref.Branch, nil
+	default:
+		return fluxDefaultBranch, nil
+	}
+}
+
+// fluxRepoURL converts the ssh:// URLs Flux uses into the scp-like form gitopsctl accepts.
+// Other URLs are returned unchanged.
+func fluxRepoURL(repoURL string) (string, error) {
+	if !strings.HasPrefix(repoURL, "ssh://") {
+		return repoURL, nil
+	}
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository URL '%s': %w", repoURL, err)
+	}
+	if u.Port() != "" && u.Port() != "22" {
+		return "", fmt.Errorf("SSH URL '%s' uses a custom port, which gitopsctl does not support", repoURL)
+	}
+	user := "git"
+	if u.User != nil && u.User.Username() != "" {
+		user = u.User.Username()
+	}
+	return fmt.Sprintf("%s@%s:%s", user, u.Hostname(), strings.TrimPrefix(u.Path, "/")), nil
+}
@@ -0,0 +1,167 @@
+// Package importer translates the application resources of other GitOps tools, such as Argo CD
+// Applications and Flux Kustomizations, into gitopsctl applications.
+package importer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"sigs.k8s.io/yaml"
+)
+
+// InClusterServer is the API server address Argo CD and Flux use for the cluster they run in.
+const InClusterServer = "https://kubernetes.default.svc"
+
+// Destination identifies the cluster an imported resource deploys to, as named by its source tool.
+// An empty Destination means the cluster could not be determined.
+type Destination struct {
+	// Name is the cluster's name, as registered with the source tool.
+	Name string
+	// Server is the cluster's API server address.
+	Server string
+}
+
+// String returns the destination as shown to users.
+func (d Destination) String() string {
+	switch {
+	case d.Name != "" && d.Server != "":
+		return fmt.Sprintf("%s (%s)", d.Name, d.Server)
+	case d.Name != "":
+		return d.Name
+	case d.Server != "":
+		return d.Server
+	default:
+		return "unknown"
+	}
+}
+
+// Result is an application translated from a resource of another tool.
+type Result struct {
+	// Source identifies the resource the application was translated from, e.g. "Application argocd/guestbook".
+	Source string
+	// App is the translated application. Its Branch is empty if the resource tracks the repository's
+	// default branch, its Interval is empty if the resource does not set one, and its ClusterName is
+	// left to the caller to resolve from Destination.
+	App *app.Application
+	// Destination is the cluster the resource deploys to.
+	Destination Destination
+	// Warnings describe settings of the resource that gitopsctl does not support or handles differently.
+	Warnings []string
+}
+
+// document is a decoded YAML document of an imported file.
+type document struct {
+	// file is the file the document was read from.
+	file string
+	// index is the position of the document within the file.
+	index int
+	// kind is the document's kind.
+	kind string
+	// data is the document's YAML.
+	data []byte
+}
+
+// objectMeta is the part of a resource's metadata the importers use.
+type objectMeta struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// readDocuments reads the YAML documents of the given files, in order. A file named "-" is read
+// from standard input. Empty documents are skipped, and the items of List documents, as printed by
+// kubectl get -o yaml, are read as documents of their own.
+func readDocuments(files []string) ([]document, error) {
+	var documents []document
+	for _, file := range files {
+		var (
+			data []byte
+			err  error
+		)
+		if file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		for i, doc := range strings.Split(string(data), "\n---") {
+			doc = strings.TrimSpace(strings.TrimPrefix(doc, "---"))
+			if doc == "" {
+				continue
+			}
+			var header struct {
+				Kind  string           `json:"kind"`
+				Items []map[string]any `json:"items"`
+			}
+			if err := yaml.Unmarshal([]byte(doc), &header); err != nil {
+				return nil, fmt.Errorf("failed to decode YAML from %s (doc %d): %w", file, i, err)
+			}
+			if header.Kind != "List" {
+				documents = append(documents, document{file: file, index: i, kind: header.Kind, data: []byte(doc)})
+				continue
+			}
+			for _, item := range header.Items {
+				data, err := yaml.Marshal(item)
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode list item from %s (doc %d): %w", file, i, err)
+				}
+				kind, _ := item["kind"].(string)
+				documents = append(documents, document{file: file, index: i, kind: kind, data: data})
+			}
+		}
+	}
+	return documents, nil
+}
+
+// decode decodes the document into v.
+func (d document) decode(v any) error {
+	if err := yaml.Unmarshal(d.data, v); err != nil {
+		return fmt.Errorf("failed to decode %s from %s (doc %d): %w", d.kind, d.file, d.index, err)
+	}
+	return nil
+}
+
+// newApplication returns an application with the fields shared by every importer, validating
+// its name, repository URL and path. An empty path selects the repository's root.
+func newApplication(name, repoURL, branch, repoPath string) (*app.Application, error) {
+	if err := common.ValidateName(name); err != nil {
+		return nil, fmt.Errorf("invalid application name: %w", err)
+	}
+	if err := common.ValidateGitURL(repoURL); err != nil {
+		return nil, err
+	}
+	if branch != "" {
+		if err := common.ValidateBranch(branch); err != nil {
+			return nil, err
+		}
+	}
+	if strings.Trim(strings.TrimSpace(repoPath), "/") == "" {
+		repoPath = "."
+	}
+	normalized, err := common.NormalizeRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	return &app.Application{
+		Name:    name,
+		RepoURL: repoURL,
+		Branch:  branch,
+		Path:    normalized,
+		Status:  app.StatusPending,
+		Message: "Application registered, awaiting first sync",
+	}, nil
+}
+
+// qualifiedName returns the namespace/name of a resource, or just its name if it has no namespace.
+func qualifiedName(meta objectMeta) string {
+	if meta.Namespace == "" {
+		return meta.Name
+	}
+	return meta.Namespace + "/" + meta.Name
+}
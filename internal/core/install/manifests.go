@@ -0,0 +1,218 @@
+// Package install generates the Kubernetes manifests for running the controller inside a cluster.
+package install
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Name is the name given to every generated resource.
+	Name = "gitopsctl"
+	// DefaultNamespace is the namespace the controller is deployed to.
+	DefaultNamespace = "gitopsctl"
+	// DefaultImage is the container image the controller runs from.
+	DefaultImage = "ghcr.io/aeswibon/gitopsctl:latest"
+	// DefaultClusterRole is the cluster role bound to the controller's service account.
+	DefaultClusterRole = "cluster-admin"
+	// DefaultStorageSize is the size of the volume claim holding the controller's state.
+	DefaultStorageSize = "1Gi"
+	// WorkingDir is the controller's working directory in the container.
+	WorkingDir = "/var/lib/gitopsctl"
+	// APIPort is the port the API server listens on in the container.
+	APIPort = 8080
+)
+
+// Options configures the generated manifests.
+type Options struct {
+	// Namespace is the namespace the controller is deployed to.
+	Namespace string
+	// Image is the container image the controller runs from.
+	Image string
+	// ClusterRole is the cluster role bound to the controller's service account.
+	ClusterRole string
+	// StorageSize is the size of the volume claim holding the configs directory.
+	StorageSize string
+	// StorageClass is the storage class of the volume claim, empty for the cluster's default.
+	StorageClass string
+	// Args are extra flags passed to 'gitopsctl start'.
+	Args []string
+}
+
+// Generate returns the Namespace, RBAC, PersistentVolumeClaim, Deployment and Service that run the
+// controller inside a cluster, as a multi-document YAML stream. The Service and the pod's probes
+// are left out if Args disable the API server with --no-api.
+//
+// The controller's configs directory is kept on the volume claim, so registered applications,
+// clusters and events survive restarts. The cluster the controller runs in is registered
+// automatically as cluster.InClusterName when it starts.
+func Generate(opts Options) ([]byte, error) {
+	storageSize, err := resource.ParseQuantity(opts.StorageSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage size '%s': %w", opts.StorageSize, err)
+	}
+
+	labels := map[string]string{
+		"app.kubernetes.io/name":       Name,
+		"app.kubernetes.io/component":  "controller",
+		"app.kubernetes.io/managed-by": Name,
+	}
+	meta := metav1.ObjectMeta{Name: Name, Namespace: opts.Namespace, Labels: labels}
+	serveAPI := !slices.Contains(opts.Args, "--no-api")
+
+	objects := []runtime.Object{
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: opts.Namespace, Labels: labels},
+		},
+		&corev1.ServiceAccount{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+			ObjectMeta: meta,
+		},
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: Name, Labels: labels},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     opts.ClusterRole,
+			},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      Name,
+				Namespace: opts.Namespace,
+			}},
+		},
+		stateClaim(meta, storageSize, opts.StorageClass),
+		deployment(meta, labels, opts, serveAPI),
+	}
+	if serveAPI {
+		objects = append(objects, &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: meta,
+			Spec: corev1.ServiceSpec{
+				Selector: labels,
+				Ports: []corev1.ServicePort{{
+					Name:       "http",
+					Port:       APIPort,
+					TargetPort: intstr.FromString("http"),
+				}},
+			},
+		})
+	}
+
+	var out bytes.Buffer
+	for i, obj := range objects {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %T: %w", obj, err)
+		}
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(data)
+	}
+	return out.Bytes(), nil
+}
+
+// stateClaim returns the volume claim the controller's configs directory is stored on.
+func stateClaim(meta metav1.ObjectMeta, size resource.Quantity, storageClass string) *corev1.PersistentVolumeClaim {
+	claim := &corev1.PersistentVolumeClaim{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+		ObjectMeta: *meta.DeepCopy(),
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+	claim.Name = Name + "-state"
+	if storageClass != "" {
+		claim.Spec.StorageClassName = &storageClass
+	}
+	return claim
+}
+
+// deployment returns the controller's Deployment. A single replica runs at a time, since the
+// state volume can only be written by one controller. The API server's port and the probes
+// checking it are only added if serveAPI is set.
+func deployment(meta metav1.ObjectMeta, labels map[string]string, opts Options, serveAPI bool) *appsv1.Deployment {
+	replicas := int32(1)
+	nonRoot := true
+	noEscalation := false
+	user := int64(65532)
+
+	probe := func(path string) *corev1.Probe {
+		return &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: path, Port: intstr.FromString("http")},
+			},
+			PeriodSeconds: 10,
+		}
+	}
+
+	container := corev1.Container{
+		Name:       "controller",
+		Image:      opts.Image,
+		Args:       append([]string{"start", fmt.Sprintf("--api-address=:%d", APIPort)}, opts.Args...),
+		WorkingDir: WorkingDir,
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &noEscalation,
+			ReadOnlyRootFilesystem:   &nonRoot,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "state", MountPath: WorkingDir + "/configs"},
+			{Name: "tmp", MountPath: "/tmp"},
+		},
+	}
+	if serveAPI {
+		container.Ports = []corev1.ContainerPort{{Name: "http", ContainerPort: APIPort}}
+		container.ReadinessProbe = probe("/readyz")
+		container.LivenessProbe = probe("/healthz")
+	}
+
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: meta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: Name,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: &nonRoot,
+						RunAsUser:    &user,
+						FSGroup:      &user,
+					},
+					Containers: []corev1.Container{container},
+					Volumes: []corev1.Volume{
+						{
+							Name: "state",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: Name + "-state"},
+							},
+						},
+						{
+							Name:         "tmp",
+							VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
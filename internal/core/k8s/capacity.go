@@ -0,0 +1,167 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// nodeMetricsResource is the metrics-server resource reporting node usage.
+var nodeMetricsResource = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}
+
+// ResourceSummary holds CPU and memory figures for a node or a whole cluster.
+// CPU is in millicores and memory in bytes.
+type ResourceSummary struct {
+	// CPUAllocatable is the CPU available for pods.
+	CPUAllocatable int64 `json:"cpuAllocatableMillis"`
+	// CPURequested is the CPU requested by running pods.
+	CPURequested int64 `json:"cpuRequestedMillis"`
+	// CPUUsage is the CPU in use as reported by metrics-server, if it is available.
+	CPUUsage int64 `json:"cpuUsageMillis,omitempty"`
+	// MemoryAllocatable is the memory available for pods.
+	MemoryAllocatable int64 `json:"memoryAllocatableBytes"`
+	// MemoryRequested is the memory requested by running pods.
+	MemoryRequested int64 `json:"memoryRequestedBytes"`
+	// MemoryUsage is the memory in use as reported by metrics-server, if it is available.
+	MemoryUsage int64 `json:"memoryUsageBytes,omitempty"`
+	// Pods is the number of running pods.
+	Pods int `json:"pods"`
+}
+
+// add adds the figures of other to s.
+func (s *ResourceSummary) add(other ResourceSummary) {
+	s.CPUAllocatable += other.CPUAllocatable
+	s.CPURequested += other.CPURequested
+	s.CPUUsage += other.CPUUsage
+	s.MemoryAllocatable += other.MemoryAllocatable
+	s.MemoryRequested += other.MemoryRequested
+	s.MemoryUsage += other.MemoryUsage
+	s.Pods += other.Pods
+}
+
+// NodeCapacity summarizes the resources of a single node.
+type NodeCapacity struct {
+	// Name is the name of the node.
+	Name string `json:"name"`
+	// Ready reports whether the node's Ready condition is true.
+	Ready bool `json:"ready"`
+	ResourceSummary
+}
+
+// Capacity summarizes the resources of a cluster's nodes.
+type Capacity struct {
+	// Nodes holds the capacity of each node, sorted by name.
+	Nodes []NodeCapacity `json:"nodes"`
+	// Total holds the sum over all nodes.
+	Total ResourceSummary `json:"total"`
+	// MetricsAvailable reports whether usage figures were retrieved from metrics-server.
+	MetricsAvailable bool `json:"metricsAvailable"`
+}
+
+// Capacity summarizes the allocatable, requested and, when metrics-server is installed, used
+// CPU and memory of every node in the cluster.
+func (cs *ClientSet) Capacity(ctx context.Context) (*Capacity, error) {
+	nodes, err := cs.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	pods, err := cs.kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	byNode := make(map[string]*NodeCapacity, len(nodes.Items))
+	capacity := &Capacity{Nodes: make([]NodeCapacity, len(nodes.Items))}
+	for i, node := range nodes.Items {
+		capacity.Nodes[i] = NodeCapacity{
+			Name:  node.Name,
+			Ready: nodeReady(&node),
+			ResourceSummary: ResourceSummary{
+				CPUAllocatable:    node.Status.Allocatable.Cpu().MilliValue(),
+				MemoryAllocatable: node.Status.Allocatable.Memory().Value(),
+			},
+		}
+		byNode[node.Name] = &capacity.Nodes[i]
+	}
+
+	for _, pod := range pods.Items {
+		node, ok := byNode[pod.Spec.NodeName]
+		if !ok {
+			continue // Not scheduled yet
+		}
+		cpu, memory := podRequests(&pod)
+		node.CPURequested += cpu
+		node.MemoryRequested += memory
+		node.Pods++
+	}
+
+	if usage, err := cs.nodeUsage(ctx); err != nil {
+		cs.logger.Debug("Node metrics unavailable, is metrics-server installed?", zap.Error(err))
+	} else {
+		capacity.MetricsAvailable = true
+		for name, u := range usage {
+			if node, ok := byNode[name]; ok {
+				node.CPUUsage = u.CPUUsage
+				node.MemoryUsage = u.MemoryUsage
+			}
+		}
+	}
+
+	sort.Slice(capacity.Nodes, func(i, j int) bool { return capacity.Nodes[i].Name < capacity.Nodes[j].Name })
+	for _, node := range capacity.Nodes {
+		capacity.Total.add(node.ResourceSummary)
+	}
+	return capacity, nil
+}
+
+// podRequests returns the CPU (millicores) and memory (bytes) a pod requests from its node.
+// Like the scheduler, it counts the larger of the containers' total and any single init container,
+// plus the pod overhead.
+func podRequests(pod *corev1.Pod) (int64, int64) {
+	var cpu, memory int64
+	for _, c := range pod.Spec.Containers {
+		cpu += c.Resources.Requests.Cpu().MilliValue()
+		memory += c.Resources.Requests.Memory().Value()
+	}
+	for _, c := range pod.Spec.InitContainers {
+		cpu = max(cpu, c.Resources.Requests.Cpu().MilliValue())
+		memory = max(memory, c.Resources.Requests.Memory().Value())
+	}
+	cpu += pod.Spec.Overhead.Cpu().MilliValue()
+	memory += pod.Spec.Overhead.Memory().Value()
+	return cpu, memory
+}
+
+// nodeUsage returns the CPU and memory usage of each node as reported by metrics-server.
+func (cs *ClientSet) nodeUsage(ctx context.Context) (map[string]ResourceSummary, error) {
+	list, err := cs.dynamicClient.Resource(nodeMetricsResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]ResourceSummary, len(list.Items))
+	for _, item := range list.Items {
+		values, _, err := unstructured.NestedStringMap(item.Object, "usage")
+		if err != nil {
+			return nil, fmt.Errorf("unexpected metrics for node %s: %w", item.GetName(), err)
+		}
+		var summary ResourceSummary
+		if cpu, err := resource.ParseQuantity(values["cpu"]); err == nil {
+			summary.CPUUsage = cpu.MilliValue()
+		}
+		if memory, err := resource.ParseQuantity(values["memory"]); err == nil {
+			summary.MemoryUsage = memory.Value()
+		}
+		usage[item.GetName()] = summary
+	}
+	return usage, nil
+}
@@ -0,0 +1,158 @@
+package k8s
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ConfigHashAnnotation is set on the pod template of workloads to a hash of the ConfigMaps and
+// Secrets they reference, so changing only their data rolls the workload's pods.
+const ConfigHashAnnotation = "gitopsctl.io/config-hash"
+
+// configHashKinds are the workloads whose pod templates are annotated with ConfigHashAnnotation.
+var configHashKinds = map[string]bool{"Deployment": true, "StatefulSet": true, "DaemonSet": true}
+
+// annotateConfigHashes sets ConfigHashAnnotation on the pod templates of the workloads among objects
+// that reference ConfigMaps or Secrets defined among objects. ConfigMaps and Secrets that are not in
+// the manifests are not hashed, as their content is not known; workloads referencing none keep
+// their pod template as it is.
+func annotateConfigHashes(objects []manifestObject) {
+	configs := make(map[string]*unstructured.Unstructured)
+	for _, m := range objects {
+		if kind := m.gvk.Kind; (kind == "ConfigMap" || kind == "Secret") && m.gvk.Group == "" {
+			configs[configKey(kind, m.obj.GetNamespace(), m.obj.GetName())] = m.obj
+		}
+	}
+	if len(configs) == 0 {
+		return
+	}
+
+	for _, m := range objects {
+		if !configHashKinds[m.gvk.Kind] || m.gvk.Group != "apps" {
+			continue
+		}
+		podSpec, ok, _ := unstructured.NestedMap(m.obj.Object, "spec", "template", "spec")
+		if !ok {
+			continue
+		}
+		var keys []string
+		for _, ref := range configReferences(podSpec) {
+			key := configKey(ref.kind, m.obj.GetNamespace(), ref.name)
+			if _, found := configs[key]; found {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		sort.Strings(keys)
+
+		hash := sha256.New()
+		for i, key := range keys {
+			if i > 0 && keys[i-1] == key {
+				continue
+			}
+			config := configs[key].Object
+			data, _ := json.Marshal([]any{config["data"], config["binaryData"], config["stringData"]})
+			fmt.Fprintf(hash, "%s\n%d\n", key, len(data))
+			hash.Write(data)
+		}
+		annotations, _, _ := unstructured.NestedStringMap(m.obj.Object, "spec", "template", "metadata", "annotations")
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[ConfigHashAnnotation] = hex.EncodeToString(hash.Sum(nil))
+		_ = unstructured.SetNestedStringMap(m.obj.Object, annotations, "spec", "template", "metadata", "annotations")
+	}
+}
+
+// configRef is a ConfigMap or Secret referenced by a pod.
+type configRef struct {
+	kind string
+	name string
+}
+
+// configReferences returns the ConfigMaps and Secrets a pod spec mounts as volumes, including
+// projected ones, or reads environment variables from.
+func configReferences(podSpec map[string]any) []configRef {
+	var refs []configRef
+	add := func(kind string, obj map[string]any, field string) {
+		if name, ok, _ := unstructured.NestedString(obj, field); ok && name != "" {
+			refs = append(refs, configRef{kind: kind, name: name})
+		}
+	}
+
+	volumes, _, _ := unstructured.NestedSlice(podSpec, "volumes")
+	for _, v := range volumes {
+		volume, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if configMap, ok := volume["configMap"].(map[string]any); ok {
+			add("ConfigMap", configMap, "name")
+		}
+		if secret, ok := volume["secret"].(map[string]any); ok {
+			add("Secret", secret, "secretName")
+		}
+		sources, _, _ := unstructured.NestedSlice(volume, "projected", "sources")
+		for _, s := range sources {
+			source, ok := s.(map[string]any)
+			if !ok {
+				continue
+			}
+			if configMap, ok := source["configMap"].(map[string]any); ok {
+				add("ConfigMap", configMap, "name")
+			}
+			if secret, ok := source["secret"].(map[string]any); ok {
+				add("Secret", secret, "name")
+			}
+		}
+	}
+
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, _ := unstructured.NestedSlice(podSpec, field)
+		for _, c := range containers {
+			container, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			envFrom, _, _ := unstructured.NestedSlice(container, "envFrom")
+			for _, e := range envFrom {
+				source, ok := e.(map[string]any)
+				if !ok {
+					continue
+				}
+				if configMap, ok := source["configMapRef"].(map[string]any); ok {
+					add("ConfigMap", configMap, "name")
+				}
+				if secret, ok := source["secretRef"].(map[string]any); ok {
+					add("Secret", secret, "name")
+				}
+			}
+			env, _, _ := unstructured.NestedSlice(container, "env")
+			for _, e := range env {
+				variable, ok := e.(map[string]any)
+				if !ok {
+					continue
+				}
+				if configMap, ok, _ := unstructured.NestedMap(variable, "valueFrom", "configMapKeyRef"); ok {
+					add("ConfigMap", configMap, "name")
+				}
+				if secret, ok, _ := unstructured.NestedMap(variable, "valueFrom", "secretKeyRef"); ok {
+					add("Secret", secret, "name")
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// configKey identifies a ConfigMap or Secret by kind, namespace and name.
+func configKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
@@ -0,0 +1,126 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// CRDEstablishTimeout is how long an apply waits for the CustomResourceDefinitions it applied to
+	// be established before applying custom resources of the kinds they define.
+	CRDEstablishTimeout = time.Minute
+	// crdEstablishPollInterval is how often the CustomResourceDefinitions are checked while waiting.
+	crdEstablishPollInterval = 500 * time.Millisecond
+)
+
+// crdResource is the resource CustomResourceDefinitions are served as.
+var crdResource = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// definedKind returns the kind a manifest object defines if it is a CustomResourceDefinition.
+func definedKind(m manifestObject) (schema.GroupKind, bool) {
+	if m.gvk.Kind != "CustomResourceDefinition" || m.gvk.Group != crdResource.Group {
+		return schema.GroupKind{}, false
+	}
+	group, _, _ := unstructured.NestedString(m.obj.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(m.obj.Object, "spec", "names", "kind")
+	return schema.GroupKind{Group: group, Kind: kind}, true
+}
+
+// crdDefinedKinds returns the kinds defined by the CustomResourceDefinitions among objects.
+func crdDefinedKinds(objects []manifestObject) map[schema.GroupKind]bool {
+	defined := make(map[schema.GroupKind]bool)
+	for _, m := range objects {
+		if gk, ok := definedKind(m); ok {
+			defined[gk] = true
+		}
+	}
+	return defined
+}
+
+// undefinedCustomResource reports whether err means the kind of a manifest object is unknown to the
+// cluster because it is defined by a CustomResourceDefinition among the manifests that has not been
+// applied yet. Such objects cannot be checked against the cluster before the apply.
+func undefinedCustomResource(err error, m manifestObject, defined map[schema.GroupKind]bool) bool {
+	return meta.IsNoMatchError(err) && defined[m.gvk.GroupKind()]
+}
+
+// crdsNeededLater returns the names of the CustomResourceDefinitions among objects that were applied
+// in wave without error and define the kind of an object applied in one of the later waves.
+func crdsNeededLater(objects []manifestObject, wave []int, later [][]int, errs []error) []string {
+	defined := make(map[schema.GroupKind]string)
+	for _, i := range wave {
+		if gk, ok := definedKind(objects[i]); ok && errs[i] == nil {
+			defined[gk] = objects[i].obj.GetName()
+		}
+	}
+	if len(defined) == 0 {
+		return nil
+	}
+
+	var names []string
+	for _, w := range later {
+		for _, i := range w {
+			gk := objects[i].gvk.GroupKind()
+			if name, ok := defined[gk]; ok {
+				names = append(names, name)
+				delete(defined, gk)
+			}
+		}
+	}
+	return names
+}
+
+// awaitCRDsEstablished waits up to CRDEstablishTimeout for the named CustomResourceDefinitions to be
+// established, then refreshes the REST mapper so the kinds they define can be applied. CRDs that are
+// still not established when it gives up are logged, and the custom resources of their kinds are
+// left to fail when they are applied.
+func (cs *ClientSet) awaitCRDsEstablished(ctx context.Context, names []string) {
+	cs.logger.Info("Waiting for CustomResourceDefinitions to be established", zap.Strings("crds", names))
+	deadline := time.NewTimer(CRDEstablishTimeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(crdEstablishPollInterval)
+	defer ticker.Stop()
+
+	pending := names
+	for {
+		var waiting []string
+		for _, name := range pending {
+			crd, err := cs.dynamicClient.Resource(crdResource).Get(ctx, name, metav1.GetOptions{})
+			if err != nil || !crdEstablished(crd) {
+				waiting = append(waiting, name)
+			}
+		}
+		pending = waiting
+		if len(pending) == 0 {
+			break
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+		case <-deadline.C:
+		}
+		cs.logger.Warn("CustomResourceDefinitions not established, applying their custom resources anyway",
+			zap.Strings("crds", pending), zap.Duration("timeout", CRDEstablishTimeout))
+		break
+	}
+	cs.refreshMapper()
+}
+
+// crdEstablished reports whether a CustomResourceDefinition's Established condition is true, meaning
+// the API server serves the kind it defines.
+func crdEstablished(crd *unstructured.Unstructured) bool {
+	for _, cond := range conditions(crd) {
+		if cond["type"] == "Established" {
+			return cond["status"] == "True"
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// removedAuthProviders are the in-tree auth providers that Kubernetes clients no longer ship, with
+// the exec credential plugin replacing each.
+var removedAuthProviders = map[string]string{
+	"gcp":   "gke-gcloud-auth-plugin",
+	"azure": "kubelogin",
+}
+
+// IsCredentialError reports whether err means the cluster's credentials were rejected or could not
+// be obtained, rather than that the API server could not be reached: the server answered 401
+// Unauthorized, the exec credential plugin (aws eks get-token, gke-gcloud-auth-plugin, kubelogin)
+// failed, or the server refused an expired client certificate.
+func IsCredentialError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if apierrors.IsUnauthorized(err) {
+		return true
+	}
+	// client-go flattens the errors of exec plugins and TLS alerts into their messages
+	msg := err.Error()
+	return strings.Contains(msg, "getting credentials:") ||
+		strings.Contains(msg, "tls: expired certificate") ||
+		strings.Contains(msg, "tls: certificate required")
+}
+
+// checkAuthProvider rejects configurations using an auth provider that Kubernetes clients no longer
+// ship, naming the exec plugin to use instead.
+func checkAuthProvider(config *rest.Config) error {
+	if config.AuthProvider == nil {
+		return nil
+	}
+	if plugin, ok := removedAuthProviders[config.AuthProvider.Name]; ok {
+		return fmt.Errorf("the %s auth provider has been removed from Kubernetes clients; configure the user with the %s exec credential plugin instead",
+			config.AuthProvider.Name, plugin)
+	}
+	return nil
+}
+
+// KubeconfigAuth describes how the current context of a kubeconfig authenticates: with an exec
+// credential plugin, an auth provider, a client certificate, a token or basic auth.
+func KubeconfigAuth(kubeconfigPath string) (string, error) {
+	kubeconfig, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse kubeconfig %s: %w", kubeconfigPath, err)
+	}
+	kubeContext, ok := kubeconfig.Contexts[kubeconfig.CurrentContext]
+	if !ok {
+		return "", fmt.Errorf("current context %q not found in kubeconfig %s", kubeconfig.CurrentContext, kubeconfigPath)
+	}
+	user, ok := kubeconfig.AuthInfos[kubeContext.AuthInfo]
+	if !ok {
+		return "", fmt.Errorf("user %q for context %q not found in kubeconfig %s", kubeContext.AuthInfo, kubeconfig.CurrentContext, kubeconfigPath)
+	}
+	switch {
+	case user.Exec != nil:
+		return "exec plugin " + user.Exec.Command, nil
+	case user.AuthProvider != nil:
+		return "auth provider " + user.AuthProvider.Name, nil
+	case user.ClientCertificate != "" || len(user.ClientCertificateData) > 0:
+		return "client certificate", nil
+	case user.Token != "" || user.TokenFile != "":
+		return "token", nil
+	case user.Username != "":
+		return "basic auth", nil
+	}
+	return "none", nil
+}
@@ -0,0 +1,69 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DeleteResources deletes resources from the cluster.
+//
+// Resources are deleted in the reverse of the order they are applied in, so workloads are removed
+// before the services, configuration, RBAC, namespaces and CRDs they depend on. Deletion of the
+// objects they own, such as pods, continues in the background. Resources that no longer exist
+// are treated as deleted, and resources protected from deletion (see IsDeletionProtected) are
+// kept. It returns the deleted and the protected resources along with any errors encountered.
+func (cs *ClientSet) DeleteResources(ctx context.Context, refs []ResourceRef) ([]ResourceRef, []ResourceRef, []error) {
+	ordered := slices.Clone(refs)
+	slices.SortStableFunc(ordered, func(a, b ResourceRef) int {
+		return kindWave(b.Kind) - kindWave(a.Kind)
+	})
+
+	propagation := metav1.DeletePropagationBackground
+	var deleted, protected []ResourceRef
+	var deleteErrors []error
+	for _, ref := range ordered {
+		dr, err := cs.resourceClient(ref.Kind, ref.Namespace)
+		if err == nil {
+			var live *unstructured.Unstructured
+			live, err = dr.Get(ctx, ref.Name, metav1.GetOptions{})
+			if err == nil && IsDeletionProtected(live) {
+				cs.logger.Info("Keeping resource protected from deletion",
+					zap.String("kind", ref.Kind), zap.String("name", ref.Name), zap.String("namespace", ref.Namespace))
+				protected = append(protected, ref)
+				continue
+			}
+			if err == nil {
+				err = dr.Delete(ctx, ref.Name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+			}
+		}
+		switch {
+		case apierrors.IsNotFound(err):
+			cs.logger.Debug("Resource already deleted",
+				zap.String("kind", ref.Kind), zap.String("name", ref.Name), zap.String("namespace", ref.Namespace))
+			deleted = append(deleted, ref)
+		case err != nil:
+			cs.logger.Error("Failed to delete resource",
+				zap.String("kind", ref.Kind), zap.String("name", ref.Name), zap.String("namespace", ref.Namespace), zap.Error(err))
+			deleteErrors = append(deleteErrors, fmt.Errorf("failed to delete %s: %w", ref, err))
+		default:
+			cs.logger.Info("Deleted resource",
+				zap.String("kind", ref.Kind), zap.String("name", ref.Name), zap.String("namespace", ref.Namespace))
+			deleted = append(deleted, ref)
+		}
+	}
+	return deleted, protected, deleteErrors
+}
+
+// IsDeletionProtected reports whether a resource is exempt from deletion by the controller: it is
+// annotated with DeleteProtectionAnnotation set to "true" or with PruneAnnotation set to "false".
+func IsDeletionProtected(obj *unstructured.Unstructured) bool {
+	annotations := obj.GetAnnotations()
+	return strings.EqualFold(annotations[DeleteProtectionAnnotation], "true") || strings.EqualFold(annotations[PruneAnnotation], "false")
+}
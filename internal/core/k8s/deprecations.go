@@ -0,0 +1,137 @@
+package k8s
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DeprecatedAPI is a manifest that uses an API version deprecated in, or removed from, the
+// Kubernetes version of the cluster it is applied to.
+type DeprecatedAPI struct {
+	// Resource is the resource the manifest describes.
+	Resource ResourceRef
+	// File is the manifest file the resource was read from.
+	File string
+	// APIVersion is the deprecated API version the manifest uses (e.g., policy/v1beta1).
+	APIVersion string
+	// DeprecatedIn is the Kubernetes version the API version was deprecated in (e.g., 1.21).
+	DeprecatedIn string
+	// RemovedIn is the Kubernetes version the API version was removed in (e.g., 1.25).
+	RemovedIn string
+	// Replacement is the API version to use instead, empty if the kind was removed altogether.
+	Replacement string
+	// Removed reports whether the cluster no longer serves the API version, so the manifest cannot be applied.
+	Removed bool
+}
+
+// String describes the deprecation, e.g. "PodDisruptionBudget web/web uses policy/v1beta1, removed
+// in Kubernetes 1.25; use policy/v1".
+func (d DeprecatedAPI) String() string {
+	s := fmt.Sprintf("%s uses %s, ", d.Resource, d.APIVersion)
+	if d.Removed {
+		s += "removed in Kubernetes " + d.RemovedIn
+	} else {
+		s += fmt.Sprintf("deprecated in Kubernetes %s and removed in %s", d.DeprecatedIn, d.RemovedIn)
+	}
+	if d.Replacement != "" {
+		s += "; use " + d.Replacement
+	}
+	return s
+}
+
+// apiDeprecation is when Kubernetes deprecated and removed an API version of a kind, as minor
+// versions of Kubernetes 1.x.
+type apiDeprecation struct {
+	deprecatedIn int
+	removedIn    int
+	replacement  string
+}
+
+// apiDeprecations lists the API versions removed from Kubernetes, from the deprecated API migration guide.
+var apiDeprecations = func() map[schema.GroupVersionKind]apiDeprecation {
+	table := make(map[schema.GroupVersionKind]apiDeprecation)
+	add := func(groupVersion string, kinds []string, deprecatedIn, removedIn int, replacement string) {
+		gv, _ := schema.ParseGroupVersion(groupVersion)
+		for _, kind := range kinds {
+			table[gv.WithKind(kind)] = apiDeprecation{deprecatedIn: deprecatedIn, removedIn: removedIn, replacement: replacement}
+		}
+	}
+	workloads := []string{"Deployment", "DaemonSet", "ReplicaSet", "StatefulSet"}
+	add("extensions/v1beta1", workloads, 9, 16, "apps/v1")
+	add("apps/v1beta1", workloads, 9, 16, "apps/v1")
+	add("apps/v1beta2", workloads, 9, 16, "apps/v1")
+	add("extensions/v1beta1", []string{"NetworkPolicy"}, 9, 16, "networking.k8s.io/v1")
+	add("extensions/v1beta1", []string{"PodSecurityPolicy"}, 10, 16, "policy/v1beta1")
+	add("extensions/v1beta1", []string{"Ingress"}, 14, 22, "networking.k8s.io/v1")
+	add("networking.k8s.io/v1beta1", []string{"Ingress", "IngressClass"}, 19, 22, "networking.k8s.io/v1")
+	add("apiextensions.k8s.io/v1beta1", []string{"CustomResourceDefinition"}, 16, 22, "apiextensions.k8s.io/v1")
+	add("admissionregistration.k8s.io/v1beta1", []string{"MutatingWebhookConfiguration", "ValidatingWebhookConfiguration"}, 16, 22, "admissionregistration.k8s.io/v1")
+	add("apiregistration.k8s.io/v1beta1", []string{"APIService"}, 19, 22, "apiregistration.k8s.io/v1")
+	add("rbac.authorization.k8s.io/v1beta1", []string{"ClusterRole", "ClusterRoleBinding", "Role", "RoleBinding"}, 17, 22, "rbac.authorization.k8s.io/v1")
+	add("scheduling.k8s.io/v1beta1", []string{"PriorityClass"}, 14, 22, "scheduling.k8s.io/v1")
+	add("storage.k8s.io/v1beta1", []string{"CSIDriver", "CSINode", "StorageClass", "VolumeAttachment"}, 19, 22, "storage.k8s.io/v1")
+	add("certificates.k8s.io/v1beta1", []string{"CertificateSigningRequest"}, 19, 22, "certificates.k8s.io/v1")
+	add("coordination.k8s.io/v1beta1", []string{"Lease"}, 19, 22, "coordination.k8s.io/v1")
+	add("batch/v1beta1", []string{"CronJob"}, 21, 25, "batch/v1")
+	add("discovery.k8s.io/v1beta1", []string{"EndpointSlice"}, 21, 25, "discovery.k8s.io/v1")
+	add("events.k8s.io/v1beta1", []string{"Event"}, 19, 25, "events.k8s.io/v1")
+	add("autoscaling/v2beta1", []string{"HorizontalPodAutoscaler"}, 22, 25, "autoscaling/v2")
+	add("policy/v1beta1", []string{"PodDisruptionBudget"}, 21, 25, "policy/v1")
+	add("policy/v1beta1", []string{"PodSecurityPolicy"}, 21, 25, "")
+	add("node.k8s.io/v1beta1", []string{"RuntimeClass"}, 20, 25, "node.k8s.io/v1")
+	add("autoscaling/v2beta2", []string{"HorizontalPodAutoscaler"}, 23, 26, "autoscaling/v2")
+	add("flowcontrol.apiserver.k8s.io/v1beta1", []string{"FlowSchema", "PriorityLevelConfiguration"}, 23, 26, "flowcontrol.apiserver.k8s.io/v1")
+	add("storage.k8s.io/v1beta1", []string{"CSIStorageCapacity"}, 24, 27, "storage.k8s.io/v1")
+	add("flowcontrol.apiserver.k8s.io/v1beta2", []string{"FlowSchema", "PriorityLevelConfiguration"}, 26, 29, "flowcontrol.apiserver.k8s.io/v1")
+	add("flowcontrol.apiserver.k8s.io/v1beta3", []string{"FlowSchema", "PriorityLevelConfiguration"}, 29, 32, "flowcontrol.apiserver.k8s.io/v1")
+	return table
+}()
+
+// serverMinorVersion matches the major and minor version of a Kubernetes version such as v1.30.2-eks-1234.
+var serverMinorVersion = regexp.MustCompile(`^v?1\.(\d+)`)
+
+// CheckAPIVersions reports the manifests in a directory that use an API version deprecated in, or
+// removed from, serverVersion, the Kubernetes version of the cluster (e.g., v1.30.2).
+//
+// Deprecations are looked up in the list of API versions Kubernetes removed. An API version from
+// that list the cluster's discovery information does not serve is reported as removed even when
+// serverVersion cannot be parsed. Excluded paths and ignored resources are skipped, as they are by
+// ApplyManifests; manifests that cannot be read are returned as errors.
+func (cs *ClientSet) CheckAPIVersions(manifestsDir string, opts ManifestOptions, serverVersion string) ([]DeprecatedAPI, []error) {
+	minor := -1
+	if match := serverMinorVersion.FindStringSubmatch(serverVersion); match != nil {
+		minor, _ = strconv.Atoi(match[1])
+	}
+
+	objects, checkErrors := cs.readManifests(manifestsDir, opts.Exclude)
+	var deprecated []DeprecatedAPI
+	for _, m := range objects {
+		deprecation, ok := apiDeprecations[*m.gvk]
+		if !ok {
+			continue
+		}
+		_, err := cs.mapper.RESTMapping(m.gvk.GroupKind(), m.gvk.Version)
+		removed := meta.IsNoMatchError(err) || (minor >= 0 && minor >= deprecation.removedIn)
+		if !removed && minor >= 0 && minor < deprecation.deprecatedIn {
+			continue
+		}
+		deprecated = append(deprecated, DeprecatedAPI{
+			Resource:     m.ref(),
+			File:         m.path,
+			APIVersion:   m.gvk.GroupVersion().String(),
+			DeprecatedIn: "1." + strconv.Itoa(deprecation.deprecatedIn),
+			RemovedIn:    "1." + strconv.Itoa(deprecation.removedIn),
+			Replacement:  deprecation.replacement,
+			Removed:      removed,
+		})
+	}
+	if len(deprecated) > 0 {
+		cs.logger.Info("Manifests use deprecated API versions", zap.Int("resources", len(deprecated)), zap.String("serverVersion", serverVersion))
+	}
+	return deprecated, checkErrors
+}
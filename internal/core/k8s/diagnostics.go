@@ -0,0 +1,107 @@
+package k8s
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Diagnostics describes the state of a cluster's API server, nodes and credentials.
+type Diagnostics struct {
+	// ServerVersion is the Kubernetes version reported by the API server (e.g., v1.30.2).
+	ServerVersion string
+	// VersionLatency is how long the API server took to report its version.
+	VersionLatency time.Duration
+	// Nodes is the number of nodes in the cluster, or -1 if they could not be listed.
+	Nodes int
+	// ReadyNodes is the number of nodes whose Ready condition is true.
+	ReadyNodes int
+	// NodeListLatency is how long listing the nodes took.
+	NodeListLatency time.Duration
+	// NodeError describes why the nodes could not be listed. It is empty if the credentials
+	// are not allowed to list nodes, as that does not indicate a problem with the cluster.
+	NodeError string
+	// CertificateExpiry is when the client certificate used to authenticate expires.
+	// It is zero when the credentials do not use a client certificate.
+	CertificateExpiry time.Time
+}
+
+// Diagnose checks the cluster's API server, node readiness and client certificate.
+//
+// It returns an error only if the API server cannot be reached. Nodes that cannot be listed,
+// for example because the credentials lack permission, are reported in the diagnostics instead.
+func (cs *ClientSet) Diagnose(ctx context.Context) (*Diagnostics, error) {
+	diag := &Diagnostics{Nodes: -1}
+
+	start := time.Now()
+	version, err := cs.serverVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes server version: %w", err)
+	}
+	diag.ServerVersion = version.GitVersion
+	diag.VersionLatency = time.Since(start)
+
+	start = time.Now()
+	nodes, err := cs.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	diag.NodeListLatency = time.Since(start)
+	switch {
+	case apierrors.IsForbidden(err):
+	case err != nil:
+		diag.NodeError = fmt.Sprintf("failed to list nodes: %v", err)
+	default:
+		diag.Nodes = len(nodes.Items)
+		for _, node := range nodes.Items {
+			if nodeReady(&node) {
+				diag.ReadyNodes++
+			}
+		}
+	}
+
+	if expiry, err := cs.clientCertificateExpiry(); err == nil {
+		diag.CertificateExpiry = expiry
+	}
+	return diag, nil
+}
+
+// nodeReady reports whether the node's Ready condition is true.
+func nodeReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// clientCertificateExpiry returns when the client certificate in the client configuration expires.
+// It returns the zero time if the configuration does not use a client certificate.
+func (cs *ClientSet) clientCertificateExpiry() (time.Time, error) {
+	certData := cs.config.TLSClientConfig.CertData
+	if len(certData) == 0 && cs.config.TLSClientConfig.CertFile != "" {
+		data, err := os.ReadFile(cs.config.TLSClientConfig.CertFile)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		certData = data
+	}
+	if len(certData) == 0 {
+		return time.Time{}, nil
+	}
+
+	block, _ := pem.Decode(certData)
+	if block == nil {
+		return time.Time{}, fmt.Errorf("client certificate is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse client certificate: %w", err)
+	}
+	return cert.NotAfter, nil
+}
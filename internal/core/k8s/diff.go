@@ -0,0 +1,195 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ChangeCreate means applying a manifest would create its resource.
+	ChangeCreate = "create"
+	// ChangeUpdate means applying a manifest would modify its existing resource.
+	ChangeUpdate = "update"
+	// diffContextLines is the number of unchanged lines shown around each change in a diff.
+	diffContextLines = 3
+)
+
+// ResourceChange describes how applying a manifest would change the cluster.
+type ResourceChange struct {
+	// Resource is the resource that would change.
+	Resource ResourceRef
+	// Action is ChangeCreate or ChangeUpdate.
+	Action string
+	// Diff is a line diff of the resource's YAML, prefixed with "+" for added and "-" for removed lines.
+	Diff string
+}
+
+// DiffManifests reports the resources that applying the manifests in a directory would change,
+// without changing anything in the cluster.
+//
+// Each object is stamped with the tracking information and submitted as a server-side dry run, and
+// the result is compared with the live resource, so defaulted fields do not show up as changes.
+// Server-managed metadata, status and the revision and controller annotations, which change with
+// every sync, are ignored. Unchanged resources are omitted. Excluded paths and ignored resources are
+// skipped, as they are by ApplyManifests.
+func (cs *ClientSet) DiffManifests(ctx context.Context, manifestsDir string, opts ManifestOptions, tracking Tracking) ([]ResourceChange, []error) {
+	cs.logger.Info("Diffing manifests", zap.String("directory", manifestsDir))
+
+	objects, diffErrors := cs.readManifests(manifestsDir, opts.Exclude)
+	if tracking.HashConfig {
+		annotateConfigHashes(objects)
+	}
+	defined := crdDefinedKinds(objects)
+	var changes []ResourceChange
+	for _, m := range objects {
+		tracking.stamp(m.obj)
+		dr, err := cs.resourceFor(m, opts)
+		if undefinedCustomResource(err, m, defined) {
+			// The kind is not served until its CRD is applied, so the resource can only be created
+			desired, err := comparableYAML(m.obj)
+			if err != nil {
+				diffErrors = append(diffErrors, fmt.Errorf("failed to diff %s from %s: %w", m.ref(), m.path, err))
+				continue
+			}
+			changes = append(changes, ResourceChange{Resource: m.ref(), Action: ChangeCreate, Diff: lineDiff("", desired)})
+			continue
+		}
+		if err != nil {
+			diffErrors = append(diffErrors, err)
+			continue
+		}
+		ref := m.ref()
+
+		live, err := dr.Get(ctx, m.obj.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			desired, err := comparableYAML(m.obj)
+			if err != nil {
+				diffErrors = append(diffErrors, fmt.Errorf("failed to diff %s from %s: %w", ref, m.path, err))
+				continue
+			}
+			changes = append(changes, ResourceChange{Resource: ref, Action: ChangeCreate, Diff: lineDiff("", desired)})
+			continue
+		}
+		if err != nil {
+			diffErrors = append(diffErrors, fmt.Errorf("failed to get %s from %s: %w", ref, m.path, err))
+			continue
+		}
+
+		obj := m.obj.DeepCopy()
+		obj.SetResourceVersion(live.GetResourceVersion())
+		predicted, err := dr.Update(ctx, obj, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+		if err != nil {
+			diffErrors = append(diffErrors, fmt.Errorf("dry run of %s from %s failed: %w", ref, m.path, err))
+			continue
+		}
+
+		before, err := comparableYAML(live)
+		if err == nil {
+			var after string
+			after, err = comparableYAML(predicted)
+			if err == nil && before != after {
+				changes = append(changes, ResourceChange{Resource: ref, Action: ChangeUpdate, Diff: lineDiff(before, after)})
+			}
+		}
+		if err != nil {
+			diffErrors = append(diffErrors, fmt.Errorf("failed to diff %s from %s: %w", ref, m.path, err))
+		}
+	}
+	return changes, diffErrors
+}
+
+// unchangedByUpdate reports whether updating the live resource with the manifest object would leave
+// it as it is, according to a server-side dry run of the update. The fields comparableYAML ignores
+// are not compared, so a resource is not updated only to record a new revision.
+func unchangedByUpdate(ctx context.Context, dr dynamic.ResourceInterface, m manifestObject, live *unstructured.Unstructured) (bool, error) {
+	obj := m.obj.DeepCopy()
+	obj.SetResourceVersion(live.GetResourceVersion())
+	predicted, err := dr.Update(ctx, obj, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+	if err != nil {
+		return false, err
+	}
+	before, err := comparableYAML(live)
+	if err != nil {
+		return false, err
+	}
+	after, err := comparableYAML(predicted)
+	if err != nil {
+		return false, err
+	}
+	return before == after, nil
+}
+
+// comparableYAML returns the YAML of a resource without the fields that change on every sync
+// or are managed by the server.
+func comparableYAML(obj *unstructured.Unstructured) (string, error) {
+	obj = obj.DeepCopy()
+	for _, field := range []string{"managedFields", "resourceVersion", "generation", "uid", "creationTimestamp"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+	if annotations := obj.GetAnnotations(); annotations != nil {
+		delete(annotations, RevisionAnnotation)
+		delete(annotations, ControllerAnnotation)
+		obj.SetAnnotations(annotations)
+	}
+	data, err := yaml.Marshal(obj.Object)
+	return string(data), err
+}
+
+// lineDiff returns the changed lines between two texts, with up to diffContextLines unchanged lines
+// around each change. Skipped unchanged lines are replaced by "...".
+func lineDiff(before, after string) string {
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(before, after)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+
+	type line struct {
+		prefix string
+		text   string
+	}
+	var all []line
+	for _, d := range diffs {
+		prefix := " "
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			prefix = "+"
+		case diffmatchpatch.DiffDelete:
+			prefix = "-"
+		}
+		for _, text := range strings.SplitAfter(d.Text, "\n") {
+			if text != "" {
+				all = append(all, line{prefix: prefix, text: strings.TrimSuffix(text, "\n")})
+			}
+		}
+	}
+
+	var sb strings.Builder
+	lastShown := -1
+	for i, l := range all {
+		show := l.prefix != " "
+		for j := max(0, i-diffContextLines); !show && j <= min(len(all)-1, i+diffContextLines); j++ {
+			show = all[j].prefix != " "
+		}
+		if !show {
+			continue
+		}
+		if i > lastShown+1 {
+			sb.WriteString("  ...\n")
+		}
+		fmt.Fprintf(&sb, "%s %s\n", l.prefix, l.text)
+		lastShown = i
+	}
+	if lastShown >= 0 && lastShown < len(all)-1 {
+		sb.WriteString("  ...\n")
+	}
+	return sb.String()
+}
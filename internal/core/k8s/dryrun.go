@@ -0,0 +1,116 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+)
+
+// DryRunResult is the outcome of a server-side dry run of applying one resource.
+type DryRunResult struct {
+	ResourceResult
+	// Diff is a line diff of the resource's YAML, as DiffManifests reports it, empty if the resource
+	// would not change or could not be applied.
+	Diff string
+	// Validated is false for custom resources whose CRD is among the manifests and not applied yet,
+	// which the server cannot dry-run.
+	Validated bool
+}
+
+// DryRunManifests reports what applying the manifests in a directory would do to each resource,
+// without changing anything in the cluster.
+//
+// Each object is stamped with the tracking information and submitted as a server-side dry-run
+// create, or update if it already exists, with strict field validation, so admission webhooks,
+// quotas and schemas are checked as they would be by a sync. The result is compared with the live
+// resource as DiffManifests does. Unlike a sync, every manifest is tried regardless of the apply
+// strategy. Excluded paths and ignored resources are skipped, as they are by ApplyManifests.
+// It returns the result of each resource in manifest order; manifests that could not be read have
+// no result but are among the errors.
+func (cs *ClientSet) DryRunManifests(ctx context.Context, manifestsDir string, opts ManifestOptions, tracking Tracking) ([]DryRunResult, []error) {
+	cs.logger.Info("Dry-running manifests", zap.String("directory", manifestsDir))
+
+	objects, readErrors := cs.readManifests(manifestsDir, opts.Exclude)
+	for _, m := range objects {
+		tracking.stamp(m.obj)
+	}
+	if tracking.HashConfig {
+		annotateConfigHashes(objects)
+	}
+	defined := crdDefinedKinds(objects)
+	results := make([]DryRunResult, 0, len(objects))
+	for _, m := range objects {
+		result := DryRunResult{ResourceResult: ResourceResult{ResourceRef: m.ref()}, Validated: true}
+		dr, err := cs.resourceFor(m, opts)
+		if undefinedCustomResource(err, m, defined) {
+			// The kind is not served until its CRD is applied, so the resource can only be created
+			result.Validated = false
+			var desired string
+			if desired, err = comparableYAML(m.obj); err == nil {
+				result.Action, result.Diff = ActionCreated, lineDiff("", desired)
+			}
+		} else if err == nil {
+			result.Action, result.Diff, err = dryRunObject(ctx, dr, m)
+		}
+		if err != nil {
+			result.Action, result.Diff, result.Err = ActionFailed, "", err
+			cs.logger.Debug("Dry run of resource failed",
+				zap.String("kind", m.gvk.Kind),
+				zap.String("name", m.obj.GetName()),
+				zap.String("namespace", m.obj.GetNamespace()),
+				zap.Error(err))
+		}
+		results = append(results, result)
+	}
+	return results, readErrors
+}
+
+// dryRunObject submits the manifest object as a server-side dry-run create, or update if it already
+// exists, and returns ActionCreated, ActionUpdated or ActionUnchanged for what applying it would do,
+// with a diff of the change.
+func dryRunObject(ctx context.Context, dr dynamic.ResourceInterface, m manifestObject) (string, string, error) {
+	live, err := dr.Get(ctx, m.obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		created, err := dr.Create(ctx, m.obj, metav1.CreateOptions{
+			DryRun:          []string{metav1.DryRunAll},
+			FieldValidation: metav1.FieldValidationStrict,
+		})
+		if err != nil {
+			return "", "", fmt.Errorf("dry run of creating %s from %s failed: %w", m.ref(), m.path, err)
+		}
+		after, err := comparableYAML(created)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to diff %s from %s: %w", m.ref(), m.path, err)
+		}
+		return ActionCreated, lineDiff("", after), nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get %s from %s: %w", m.ref(), m.path, err)
+	}
+
+	obj := m.obj.DeepCopy()
+	obj.SetResourceVersion(live.GetResourceVersion())
+	predicted, err := dr.Update(ctx, obj, metav1.UpdateOptions{
+		DryRun:          []string{metav1.DryRunAll},
+		FieldValidation: metav1.FieldValidationStrict,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("dry run of updating %s from %s failed: %w", m.ref(), m.path, err)
+	}
+
+	before, err := comparableYAML(live)
+	if err == nil {
+		var after string
+		if after, err = comparableYAML(predicted); err == nil {
+			if before == after {
+				return ActionUnchanged, "", nil
+			}
+			return ActionUpdated, lineDiff(before, after), nil
+		}
+	}
+	return "", "", fmt.Errorf("failed to diff %s from %s: %w", m.ref(), m.path, err)
+}
@@ -0,0 +1,84 @@
+package k8s
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// IgnoreAnnotation marks a resource in the manifests that the controller should not apply.
+	IgnoreAnnotation = "gitopsctl.io/ignore"
+	// DeleteProtectionAnnotation set to "true" keeps a resource when the application's resources
+	// are deleted, such as a PersistentVolumeClaim whose data must survive the application.
+	DeleteProtectionAnnotation = "gitopsctl.io/delete-protection"
+	// PruneAnnotation set to "false" protects a resource from deletion like DeleteProtectionAnnotation.
+	PruneAnnotation = "gitopsctl.io/prune"
+)
+
+// ValidateExcludePatterns checks that each exclusion pattern is a valid glob.
+func ValidateExcludePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("exclude pattern cannot be empty")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid exclude pattern '%s': %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// isExcluded reports whether a path relative to the manifests directory matches any exclusion pattern.
+//
+// Patterns use path.Match syntax and are matched against the slash-separated relative path.
+// Patterns without a slash also match the file or directory name at any depth, and a
+// matching directory excludes everything below it.
+func isExcluded(relPath string, patterns []string) bool {
+	name := path.Base(relPath)
+	for _, pattern := range patterns {
+		pattern = strings.Trim(pattern, "/")
+		if matched, _ := path.Match(pattern, relPath); matched {
+			return true
+		}
+		if !strings.Contains(pattern, "/") {
+			if matched, _ := path.Match(pattern, name); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ManifestFiles returns the YAML files under manifestsDir that are not excluded by a pattern, in
+// lexical order, as ApplyManifests reads them.
+func ManifestFiles(manifestsDir string, exclude []string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(manifestsDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != manifestsDir {
+			if rel, relErr := filepath.Rel(manifestsDir, p); relErr == nil && isExcluded(filepath.ToSlash(rel), exclude) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		if !d.IsDir() && (strings.HasSuffix(d.Name(), ".yaml") || strings.HasSuffix(d.Name(), ".yml")) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// isIgnored reports whether a manifest object carries the IgnoreAnnotation set to "true".
+func isIgnored(obj *unstructured.Unstructured) bool {
+	return strings.EqualFold(obj.GetAnnotations()[IgnoreAnnotation], "true")
+}
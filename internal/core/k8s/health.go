@@ -0,0 +1,231 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// HealthHealthy means a resource reached its desired state.
+	HealthHealthy = "Healthy"
+	// HealthProgressing means a resource is still rolling out.
+	HealthProgressing = "Progressing"
+	// HealthDegraded means a resource failed in a way that waiting will not fix.
+	HealthDegraded = "Degraded"
+
+	// HealthPollInterval is how often WaitForHealthy reassesses resources.
+	HealthPollInterval = 5 * time.Second
+)
+
+// failedWaitingReasons are the reasons of a waiting container that mean its pod will not start without a fix.
+var failedWaitingReasons = []string{"CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "CreateContainerConfigError", "InvalidImageName"}
+
+// ResourceHealth is the assessed health of a resource.
+type ResourceHealth struct {
+	// Resource is the assessed resource.
+	Resource ResourceRef
+	// Status is HealthHealthy, HealthProgressing or HealthDegraded.
+	Status string
+	// Message explains why the resource is not healthy, if it is not.
+	Message string
+}
+
+// String returns the resource with its health, and the reason it is not healthy if it is not.
+func (h ResourceHealth) String() string {
+	if h.Message == "" {
+		return h.Resource.String() + " is " + h.Status
+	}
+	return h.Resource.String() + " is " + h.Status + ": " + h.Message
+}
+
+// AssessHealth assesses the health of the given resources.
+//
+// Deployments, StatefulSets and DaemonSets are healthy once their latest spec is rolled out and all
+// their replicas are available, Pods once they are ready or have succeeded, Jobs once they complete, and
+// PersistentVolumeClaims once they are bound. Other kinds are healthy as soon as they exist.
+// A resource that cannot be fetched is reported as progressing.
+func (cs *ClientSet) AssessHealth(ctx context.Context, refs []ResourceRef) []ResourceHealth {
+	health := make([]ResourceHealth, 0, len(refs))
+	for _, ref := range refs {
+		obj, err := cs.GetResource(ctx, ref.Kind, ref.Namespace, ref.Name)
+		if err != nil {
+			health = append(health, ResourceHealth{Resource: ref, Status: HealthProgressing, Message: err.Error()})
+			continue
+		}
+		status, message := assessObject(obj)
+		health = append(health, ResourceHealth{Resource: ref, Status: status, Message: message})
+	}
+	return health
+}
+
+// WaitForHealthy waits until all given resources are healthy.
+//
+// It returns an error as soon as a resource is degraded, or once timeout passes while some resources
+// are still progressing. The error lists the resources that are not healthy.
+func (cs *ClientSet) WaitForHealthy(ctx context.Context, refs []ResourceRef, timeout time.Duration) error {
+	cs.logger.Info("Waiting for resources to become healthy", zap.Int("resources", len(refs)), zap.Duration("timeout", timeout))
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(HealthPollInterval)
+	defer ticker.Stop()
+
+	for {
+		var unhealthy []string
+		degraded := false
+		for _, h := range cs.AssessHealth(ctx, refs) {
+			if h.Status != HealthHealthy {
+				unhealthy = append(unhealthy, h.String())
+				degraded = degraded || h.Status == HealthDegraded
+			}
+		}
+		if len(unhealthy) == 0 {
+			return nil
+		}
+		if degraded {
+			return fmt.Errorf("%d resource(s) not healthy: %s", len(unhealthy), strings.Join(unhealthy, "; "))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return fmt.Errorf("%d resource(s) not healthy after %s: %s", len(unhealthy), timeout, strings.Join(unhealthy, "; "))
+		case <-ticker.C:
+		}
+	}
+}
+
+// assessObject returns the health of a live resource and, if it is not healthy, why.
+func assessObject(obj *unstructured.Unstructured) (string, string) {
+	generation := obj.GetGeneration()
+	observed, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+
+	switch obj.GetKind() {
+	case "Deployment":
+		for _, cond := range conditions(obj) {
+			if cond["type"] == "Progressing" && cond["status"] == "False" {
+				return HealthDegraded, fmt.Sprintf("%v", cond["message"])
+			}
+		}
+		if observed < generation {
+			return HealthProgressing, "waiting for the rollout to be observed"
+		}
+		replicas := specReplicas(obj)
+		updated, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+		available, _, _ := unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+		total, _, _ := unstructured.NestedInt64(obj.Object, "status", "replicas")
+		switch {
+		case updated < replicas:
+			return HealthProgressing, fmt.Sprintf("%d of %d replica(s) updated", updated, replicas)
+		case total > updated:
+			return HealthProgressing, fmt.Sprintf("%d old replica(s) pending termination", total-updated)
+		case available < updated:
+			return HealthProgressing, fmt.Sprintf("%d of %d updated replica(s) available", available, updated)
+		}
+	case "StatefulSet":
+		if observed < generation {
+			return HealthProgressing, "waiting for the rollout to be observed"
+		}
+		replicas := specReplicas(obj)
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		updated, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+		strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "updateStrategy", "type")
+		switch {
+		case ready < replicas:
+			return HealthProgressing, fmt.Sprintf("%d of %d replica(s) ready", ready, replicas)
+		case strategy != "OnDelete" && updated < replicas:
+			return HealthProgressing, fmt.Sprintf("%d of %d replica(s) updated", updated, replicas)
+		}
+	case "DaemonSet":
+		if observed < generation {
+			return HealthProgressing, "waiting for the rollout to be observed"
+		}
+		desired, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+		updated, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedNumberScheduled")
+		available, _, _ := unstructured.NestedInt64(obj.Object, "status", "numberAvailable")
+		switch {
+		case updated < desired:
+			return HealthProgressing, fmt.Sprintf("%d of %d pod(s) updated", updated, desired)
+		case available < desired:
+			return HealthProgressing, fmt.Sprintf("%d of %d pod(s) available", available, desired)
+		}
+	case "Pod":
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		if phase == "Succeeded" {
+			return HealthHealthy, ""
+		}
+		if phase == "Failed" {
+			reason, _, _ := unstructured.NestedString(obj.Object, "status", "reason")
+			return HealthDegraded, "pod failed " + reason
+		}
+		statuses, _, _ := unstructured.NestedSlice(obj.Object, "status", "containerStatuses")
+		for _, s := range statuses {
+			reason, _, _ := unstructured.NestedString(asMap(s), "state", "waiting", "reason")
+			for _, failed := range failedWaitingReasons {
+				if reason == failed {
+					name, _, _ := unstructured.NestedString(asMap(s), "name")
+					return HealthDegraded, fmt.Sprintf("container %s is in %s", name, reason)
+				}
+			}
+		}
+		for _, cond := range conditions(obj) {
+			if cond["type"] == "Ready" && cond["status"] == "True" {
+				return HealthHealthy, ""
+			}
+		}
+		return HealthProgressing, "pod is not ready"
+	case "Job":
+		for _, cond := range conditions(obj) {
+			if cond["status"] != "True" {
+				continue
+			}
+			switch cond["type"] {
+			case "Failed":
+				return HealthDegraded, fmt.Sprintf("job failed: %v", cond["message"])
+			case "Complete":
+				return HealthHealthy, ""
+			}
+		}
+		return HealthProgressing, "job has not completed"
+	case "PersistentVolumeClaim":
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		switch phase {
+		case "Bound":
+			return HealthHealthy, ""
+		case "Lost":
+			return HealthDegraded, "claim lost its volume"
+		}
+		return HealthProgressing, "claim is not bound"
+	}
+	return HealthHealthy, ""
+}
+
+// specReplicas returns the desired replicas of a workload, which default to 1.
+func specReplicas(obj *unstructured.Unstructured) int64 {
+	replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found {
+		return 1
+	}
+	return replicas
+}
+
+// conditions returns the status conditions of a resource.
+func conditions(obj *unstructured.Unstructured) []map[string]any {
+	list, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	conds := make([]map[string]any, 0, len(list))
+	for _, c := range list {
+		conds = append(conds, asMap(c))
+	}
+	return conds
+}
+
+// asMap returns v as a map, or an empty map if it is not one.
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
@@ -0,0 +1,309 @@
+package k8s
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// HelmReleaseNameAnnotation names the Helm release a resource belongs to.
+	HelmReleaseNameAnnotation = "meta.helm.sh/release-name"
+	// HelmReleaseNamespaceAnnotation is the namespace of the Helm release a resource belongs to.
+	HelmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+	// HelmReleaseStatusDeployed is the status of the current revision of a release.
+	HelmReleaseStatusDeployed = "deployed"
+	// HelmReleaseStatusSuperseded is the status of a revision replaced by a later one.
+	HelmReleaseStatusSuperseded = "superseded"
+	// HelmReleaseStatusFailed is the status of a revision that failed to apply.
+	HelmReleaseStatusFailed = "failed"
+	// MaxHelmReleaseHistory is how many revisions are kept per release, like helm upgrade's default --history-max.
+	MaxHelmReleaseHistory = 10
+
+	// helmReleaseSecretType is the type of the Secrets Helm stores releases in.
+	helmReleaseSecretType = "helm.sh/release.v1"
+	// helmReleaseKey is the Secret data key holding the encoded release.
+	helmReleaseKey = "release"
+)
+
+// HelmRelease describes a revision of a Helm release to record in the cluster.
+type HelmRelease struct {
+	// Name is the release name.
+	Name string
+	// Namespace is the namespace the release is recorded in.
+	Namespace string
+	// Chart is the chart's metadata, as read from its Chart.yaml.
+	Chart map[string]any
+	// Values are the user-supplied values the chart was rendered with.
+	Values map[string]any
+	// Manifest is the rendered YAML stream that was applied.
+	Manifest string
+	// Status is HelmReleaseStatusDeployed or HelmReleaseStatusFailed.
+	Status string
+	// Description explains how the revision was produced.
+	Description string
+}
+
+// helmReleaseRecord is the subset of Helm's release format that gitopsctl writes and reads.
+// Unknown fields of existing releases are kept when they are updated.
+type helmReleaseRecord struct {
+	Name      string         `json:"name"`
+	Info      helmInfo       `json:"info"`
+	Chart     helmChart      `json:"chart"`
+	Config    map[string]any `json:"config"`
+	Manifest  string         `json:"manifest"`
+	Version   int            `json:"version"`
+	Namespace string         `json:"namespace"`
+}
+
+// helmInfo is the info section of a Helm release.
+type helmInfo struct {
+	FirstDeployed time.Time `json:"first_deployed"`
+	LastDeployed  time.Time `json:"last_deployed"`
+	Description   string    `json:"description"`
+	Status        string    `json:"status"`
+}
+
+// helmChart is the chart section of a Helm release; only the metadata is recorded.
+type helmChart struct {
+	Metadata map[string]any `json:"metadata"`
+}
+
+// RecordHelmRelease records a new revision of a Helm release in the Secrets Helm stores releases
+// in, so that helm list, status, history and get show releases applied by gitopsctl.
+//
+// A release already installed with Helm is adopted: the revision continues its history, and the
+// previously deployed revision is marked superseded if the new one is deployed. Revisions beyond
+// MaxHelmReleaseHistory are removed, oldest first, keeping the deployed one. It returns the new
+// revision number.
+func (cs *ClientSet) RecordHelmRelease(ctx context.Context, rel HelmRelease) (int, error) {
+	secrets := cs.kubeClient.CoreV1().Secrets(rel.Namespace)
+	history, err := cs.helmReleaseHistory(ctx, rel.Namespace, rel.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	record := helmReleaseRecord{
+		Name: rel.Name,
+		Info: helmInfo{
+			FirstDeployed: now,
+			LastDeployed:  now,
+			Description:   rel.Description,
+			Status:        rel.Status,
+		},
+		Chart:     helmChart{Metadata: rel.Chart},
+		Config:    rel.Values,
+		Manifest:  rel.Manifest,
+		Version:   1,
+		Namespace: rel.Namespace,
+	}
+	if len(history) > 0 {
+		record.Info.FirstDeployed = history[0].record.Info.FirstDeployed
+		record.Version = history[len(history)-1].record.Version + 1
+	}
+
+	if rel.Status == HelmReleaseStatusDeployed {
+		for i := range history {
+			h := &history[i]
+			if h.record.Info.Status != HelmReleaseStatusDeployed {
+				continue
+			}
+			if err := updateHelmReleaseStatus(h.secret, HelmReleaseStatusSuperseded); err != nil {
+				return 0, err
+			}
+			if _, err := secrets.Update(ctx, h.secret, metav1.UpdateOptions{}); err != nil {
+				return 0, fmt.Errorf("failed to supersede revision %d of Helm release %s: %w", h.record.Version, rel.Name, err)
+			}
+			h.record.Info.Status = HelmReleaseStatusSuperseded
+		}
+	}
+
+	secret, err := newHelmReleaseSecret(record)
+	if err != nil {
+		return 0, err
+	}
+	secret.Labels["createdAt"] = strconv.FormatInt(now.Unix(), 10)
+	if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		return 0, fmt.Errorf("failed to record revision %d of Helm release %s: %w", record.Version, rel.Name, err)
+	}
+	cs.logger.Info("Recorded Helm release",
+		zap.String("release", rel.Name),
+		zap.String("namespace", rel.Namespace),
+		zap.Int("revision", record.Version),
+		zap.String("status", rel.Status))
+
+	cs.pruneHelmReleaseHistory(ctx, rel.Namespace, append(history, helmRevision{secret: secret, record: record}))
+	return record.Version, nil
+}
+
+// DeleteHelmRelease removes every recorded revision of a Helm release, like helm uninstall does
+// once the release's resources are deleted.
+func (cs *ClientSet) DeleteHelmRelease(ctx context.Context, namespace, name string) error {
+	history, err := cs.helmReleaseHistory(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	for _, h := range history {
+		err := cs.kubeClient.CoreV1().Secrets(namespace).Delete(ctx, h.secret.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete revision %d of Helm release %s/%s: %w", h.record.Version, namespace, name, err)
+		}
+	}
+	return nil
+}
+
+// helmRevision is a recorded revision of a Helm release and the Secret it is stored in.
+type helmRevision struct {
+	secret *corev1.Secret
+	record helmReleaseRecord
+}
+
+// helmReleaseHistory returns the recorded revisions of a release, oldest first.
+func (cs *ClientSet) helmReleaseHistory(ctx context.Context, namespace, name string) ([]helmRevision, error) {
+	list, err := cs.kubeClient.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: helmReleaseSelector(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions of Helm release %s/%s: %w", namespace, name, err)
+	}
+
+	history := make([]helmRevision, 0, len(list.Items))
+	for i := range list.Items {
+		secret := &list.Items[i]
+		if secret.Type != helmReleaseSecretType {
+			continue
+		}
+		var record helmReleaseRecord
+		if err := decodeHelmRelease(secret.Data[helmReleaseKey], &record); err != nil {
+			return nil, fmt.Errorf("failed to decode Helm release secret %s: %w", secret.Name, err)
+		}
+		history = append(history, helmRevision{secret: secret, record: record})
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].record.Version < history[j].record.Version })
+	return history, nil
+}
+
+// pruneHelmReleaseHistory deletes the oldest revisions that are not deployed once a release has
+// more than MaxHelmReleaseHistory. Failures are logged, since the new revision is already recorded.
+func (cs *ClientSet) pruneHelmReleaseHistory(ctx context.Context, namespace string, history []helmRevision) {
+	excess := len(history) - MaxHelmReleaseHistory
+	for _, h := range history {
+		if excess <= 0 {
+			return
+		}
+		if h.record.Info.Status == HelmReleaseStatusDeployed {
+			continue
+		}
+		if err := cs.kubeClient.CoreV1().Secrets(namespace).Delete(ctx, h.secret.Name, metav1.DeleteOptions{}); err != nil {
+			cs.logger.Warn("Failed to prune Helm release revision", zap.String("secret", h.secret.Name), zap.Error(err))
+			continue
+		}
+		excess--
+	}
+}
+
+// helmReleaseSelector returns the label selector matching the Secrets of a Helm release.
+func helmReleaseSelector(name string) string {
+	return labels.Set{"owner": "helm", "name": name}.String()
+}
+
+// newHelmReleaseSecret returns the Secret Helm stores a release revision in.
+func newHelmReleaseSecret(record helmReleaseRecord) (*corev1.Secret, error) {
+	encoded, err := encodeHelmRelease(record)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("sh.helm.release.v1.%s.v%d", record.Name, record.Version),
+			Namespace: record.Namespace,
+			Labels: map[string]string{
+				"name":         record.Name,
+				"owner":        "helm",
+				"status":       record.Info.Status,
+				"version":      strconv.Itoa(record.Version),
+				ManagedByLabel: ManagedByValue,
+			},
+		},
+		Type: helmReleaseSecretType,
+		Data: map[string][]byte{helmReleaseKey: encoded},
+	}, nil
+}
+
+// updateHelmReleaseStatus changes the status of the release stored in a Secret, keeping the
+// fields of the release that gitopsctl does not know about.
+func updateHelmReleaseStatus(secret *corev1.Secret, status string) error {
+	var release map[string]any
+	if err := decodeHelmRelease(secret.Data[helmReleaseKey], &release); err != nil {
+		return fmt.Errorf("failed to decode Helm release secret %s: %w", secret.Name, err)
+	}
+	if info, ok := release["info"].(map[string]any); ok {
+		info["status"] = status
+	}
+	encoded, err := encodeHelmRelease(release)
+	if err != nil {
+		return err
+	}
+	secret.Data[helmReleaseKey] = encoded
+	if secret.Labels == nil {
+		secret.Labels = make(map[string]string)
+	}
+	secret.Labels["status"] = status
+	secret.Labels["modifiedAt"] = strconv.FormatInt(time.Now().Unix(), 10)
+	return nil
+}
+
+// encodeHelmRelease encodes a release the way Helm stores it: gzipped JSON, base64 encoded.
+func encodeHelmRelease(release any) ([]byte, error) {
+	data, err := json.Marshal(release)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Helm release: %w", err)
+	}
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress Helm release: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress Helm release: %w", err)
+	}
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(compressed.Len()))
+	base64.StdEncoding.Encode(encoded, compressed.Bytes())
+	return encoded, nil
+}
+
+// decodeHelmRelease decodes a release stored by Helm into v. Releases stored without
+// compression, as by old Helm versions, are accepted too.
+func decodeHelmRelease(data []byte, v any) error {
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(data)))
+	n, err := base64.StdEncoding.Decode(decoded, data)
+	if err != nil {
+		return err
+	}
+	decoded = decoded[:n]
+	if bytes.HasPrefix(decoded, []byte{0x1f, 0x8b}) {
+		r, err := gzip.NewReader(bytes.NewReader(decoded))
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		if decoded, err = io.ReadAll(r); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(decoded, v)
+}
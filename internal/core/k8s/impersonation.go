@@ -0,0 +1,80 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Impersonation is the identity a client sends its requests as, on top of the credentials of its
+// kubeconfig or service account, so that cluster RBAC authorizes them as that user and groups. The
+// credentials must be allowed to impersonate them.
+type Impersonation struct {
+	// User is the user name requests are sent as. Empty impersonates no one.
+	User string
+	// Groups are the groups requests are sent as, which require User.
+	Groups []string
+}
+
+// IsZero reports whether no one is impersonated.
+func (i Impersonation) IsZero() bool {
+	return i.User == ""
+}
+
+// String returns the impersonated user followed by its groups, if any.
+func (i Impersonation) String() string {
+	if len(i.Groups) == 0 {
+		return i.User
+	}
+	return i.User + " (groups: " + strings.Join(i.Groups, ", ") + ")"
+}
+
+// ValidateImpersonation checks that impersonated groups come with a user to impersonate, as the
+// API server requires, and that no name is empty.
+func ValidateImpersonation(user string, groups []string) error {
+	if user == "" && len(groups) > 0 {
+		return fmt.Errorf("impersonated groups require a user to impersonate")
+	}
+	if user != "" && strings.TrimSpace(user) != user {
+		return fmt.Errorf("impersonated user '%s' must not start or end with whitespace", user)
+	}
+	for _, group := range groups {
+		if strings.TrimSpace(group) == "" {
+			return fmt.Errorf("impersonated groups must not be empty")
+		}
+	}
+	return nil
+}
+
+// Impersonating returns a client set for the same cluster that sends its requests as identity.
+//
+// It shares the client-side rate limit and the discovery cache of cs, so impersonating clients do
+// not multiply the load on the API server. An empty identity returns cs itself.
+func (cs *ClientSet) Impersonating(identity Impersonation) (*ClientSet, error) {
+	if identity.IsZero() {
+		return cs, nil
+	}
+	config := rest.CopyConfig(cs.config)
+	config.Impersonate = rest.ImpersonationConfig{UserName: identity.User, Groups: identity.Groups}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
+	}
+	return &ClientSet{
+		logger:         cs.logger.With(zap.String("impersonate", identity.User)),
+		kubeconfigPath: cs.kubeconfigPath,
+		dynamicClient:  dynamicClient,
+		kubeClient:     kubeClient,
+		mapper:         cs.mapper,
+		config:         config,
+	}, nil
+}
@@ -7,35 +7,80 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"aeswibon.com/github/gitopsctl/internal/common"
 	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/disk"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/homedir"
 )
 
 const (
+	// ApplyStrategyBestEffort applies every manifest and reports all failures at the end.
+	ApplyStrategyBestEffort = "best-effort"
+	// ApplyStrategyFailFast stops applying at the first manifest that fails.
+	ApplyStrategyFailFast = "fail-fast"
+	// ApplyStrategyAtomic dry-runs every manifest first and applies nothing unless all of them pass.
+	ApplyStrategyAtomic = "atomic"
+)
+
+// ApplyStrategies lists the supported apply strategies.
+var ApplyStrategies = []string{ApplyStrategyBestEffort, ApplyStrategyFailFast, ApplyStrategyAtomic}
+
+// IsValidApplyStrategy reports whether name is a supported apply strategy. An empty name selects best-effort.
+func IsValidApplyStrategy(name string) bool {
+	if name == "" {
+		return true
+	}
+	for _, s := range ApplyStrategies {
+		if name == s {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// DefaultNamespace is the namespace of namespaced resources whose manifest does not set one,
+	// unless ManifestOptions.DefaultNamespace is set.
+	DefaultNamespace = "default"
 	// DefaultAPITimeout is the default timeout for Kubernetes API requests
 	DefaultAPITimeout = 30 * time.Second
 	// DefaultQPS is the default queries per second for client-go
 	DefaultQPS = 100
 	// DefaultBurst is the default burst for client-go
 	DefaultBurst = 100
+	// DefaultDiscoveryCacheDir is the default directory API discovery results are persisted in.
+	DefaultDiscoveryCacheDir = "configs/cache/discovery"
+	// DefaultDiscoveryCacheTTL is how long persisted API discovery results are used before they are revalidated.
+	DefaultDiscoveryCacheTTL = 10 * time.Minute
+	// mapperResetInterval is the minimum time between discovery cache resets when a kind is not found,
+	// so that concurrent applies of the same new custom resource kind trigger a single rediscovery.
+	mapperResetInterval = 2 * time.Second
 )
 
 // ClientSet holds Kubernetes clients for dynamic interactions.
 // It encapsulates the dynamic client, REST mapper, and configuration required
 // for interacting with Kubernetes resources.
+//
+// A ClientSet is safe for concurrent use and is meant to be shared by everything talking to the
+// same cluster, so that they share its client-side rate limit and discovery cache.
 type ClientSet struct {
 	// logger is used for logging operations and errors.
 	logger *zap.Logger
@@ -43,18 +88,107 @@ type ClientSet struct {
 	kubeconfigPath string
 	// dynamicClient is the Kubernetes dynamic client for interacting with arbitrary resources.
 	dynamicClient dynamic.Interface
+	// kubeClient is the typed Kubernetes client used for discovery and core resources.
+	kubeClient kubernetes.Interface
 	// mapper is the REST mapper for translating GroupVersionKind to REST resources.
-	mapper meta.RESTMapper
+	mapper *restmapper.DeferredDiscoveryRESTMapper
 	// config is the Kubernetes configuration used to initialize clients.
 	config *rest.Config
+	// mapperMu protects mapperResetAt.
+	mapperMu sync.Mutex
+	// mapperResetAt is when the REST mapper's discovery cache was last reset.
+	mapperResetAt time.Time
 }
 
-// NewClientSet initializes a Kubernetes client set.
-// It attempts to use the provided kubeconfig file to build the configuration.
-// If the kubeconfig file is not provided or fails, it falls back to in-cluster configuration.
-func NewClientSet(logger *zap.Logger, kubeconfigPath string) (*ClientSet, error) {
-	var config *rest.Config
-	var err error
+// ClientOptions configures a ClientSet.
+type ClientOptions struct {
+	// QPS limits the queries per second sent to the API server. Zero uses DefaultQPS.
+	QPS float32
+	// Burst is the maximum burst of queries allowed above QPS. Zero uses DefaultBurst.
+	Burst int
+	// DiscoveryCacheDir is the directory API discovery results are persisted in, so they are reused
+	// across restarts and revalidated with conditional requests. Empty caches them in memory only.
+	DiscoveryCacheDir string
+	// InCluster connects with the service account of the pod the controller runs in, ignoring the
+	// kubeconfig path.
+	InCluster bool
+	// Impersonate is the identity requests are sent as. Empty sends them as the credentials' own.
+	Impersonate Impersonation
+}
+
+// ManifestOptions configures how the manifests of an application are read and mapped to resources.
+type ManifestOptions struct {
+	// Exclude lists glob patterns of manifest paths that are skipped (see ValidateExcludePatterns).
+	Exclude []string
+	// DefaultNamespace is the namespace of namespaced resources whose manifest does not set one.
+	// Empty means DefaultNamespace.
+	DefaultNamespace string
+	// RequireNamespace reports namespaced resources whose manifest does not set a namespace as
+	// errors, instead of placing them in the default namespace.
+	RequireNamespace bool
+}
+
+// ResourceRef identifies a Kubernetes resource applied from a manifest.
+type ResourceRef struct {
+	// Kind is the Kubernetes kind of the resource (e.g., Deployment).
+	Kind string
+	// Namespace is the namespace of the resource, empty for cluster-scoped resources.
+	Namespace string
+	// Name is the name of the resource.
+	Name string
+}
+
+// String returns the resource as "Kind namespace/name", or "Kind name" for cluster-scoped resources.
+func (r ResourceRef) String() string {
+	if r.Namespace == "" {
+		return r.Kind + " " + r.Name
+	}
+	return r.Kind + " " + r.Namespace + "/" + r.Name
+}
+
+// Actions reported for a resource by ApplyManifests.
+const (
+	// ActionCreated means the resource did not exist and was created.
+	ActionCreated = "created"
+	// ActionUpdated means the resource existed and was updated.
+	ActionUpdated = "updated"
+	// ActionUnchanged means the resource existed and updating it would not have changed it, so it was
+	// left as it is.
+	ActionUnchanged = "unchanged"
+	// ActionFailed means the resource could not be applied.
+	ActionFailed = "failed"
+)
+
+// ResourceResult is the outcome of applying one resource.
+type ResourceResult struct {
+	ResourceRef
+	// Action is what was done to the resource, one of the Action constants.
+	Action string
+	// Err is why the resource could not be applied, set only when Action is ActionFailed.
+	Err error
+}
+
+// AppliedResources returns the resources among results that were applied successfully, in order.
+func AppliedResources(results []ResourceResult) []ResourceRef {
+	var applied []ResourceRef
+	for _, r := range results {
+		if r.Action != ActionFailed {
+			applied = append(applied, r.ResourceRef)
+		}
+	}
+	return applied
+}
+
+// restConfig builds the configuration for connecting to a cluster, as described for NewClientSet.
+func restConfig(logger *zap.Logger, kubeconfigPath string, inCluster bool) (*rest.Config, error) {
+	if inCluster {
+		config, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("could not build in-cluster Kubernetes config: %w", err)
+		}
+		logger.Info("Using in-cluster configuration")
+		return config, nil
+	}
 
 	if kubeconfigPath == "" {
 		kubeconfigPath = filepath.Join(homedir.HomeDir(), ".kube", "config")
@@ -62,7 +196,7 @@ func NewClientSet(logger *zap.Logger, kubeconfigPath string) (*ClientSet, error)
 	}
 
 	// Use the specified kubeconfig file to build the config
-	config, err = clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
 	if err != nil {
 		// Fallback to in-cluster config if kubeconfig is not found or fails
 		logger.Warn("Failed to build config from kubeconfig, attempting in-cluster config", zap.Error(err))
@@ -74,44 +208,118 @@ func NewClientSet(logger *zap.Logger, kubeconfigPath string) (*ClientSet, error)
 	} else {
 		logger.Info("Using kubeconfig", zap.String("path", kubeconfigPath))
 	}
+	return config, nil
+}
+
+// InClusterAvailable reports whether the process runs inside a Kubernetes pod with a service
+// account it can connect to the cluster with.
+func InClusterAvailable() bool {
+	_, err := rest.InClusterConfig()
+	return err == nil
+}
+
+// NewClientSet initializes a Kubernetes client set.
+// With opts.InCluster, it uses the in-cluster configuration of the pod it runs in. Otherwise it
+// attempts to use the provided kubeconfig file to build the configuration, and falls back to the
+// in-cluster configuration if the kubeconfig file is not provided or fails.
+// Requests are rate limited client-side, API discovery is cached and users are impersonated
+// according to opts.
+func NewClientSet(logger *zap.Logger, kubeconfigPath string, opts ClientOptions) (*ClientSet, error) {
+	config, err := restConfig(logger, kubeconfigPath, opts.InCluster)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAuthProvider(config); err != nil {
+		return nil, err
+	}
 
 	config.Timeout = DefaultAPITimeout
+	config.Wrap(wrapRequestID)
 	config.QPS = DefaultQPS
+	if opts.QPS > 0 {
+		config.QPS = opts.QPS
+	}
 	config.Burst = DefaultBurst
+	if opts.Burst > 0 {
+		config.Burst = opts.Burst
+	}
+	// One rate limiter for all clients, including those impersonating others (see Impersonating)
+	config.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(config.QPS, config.Burst)
+	if !opts.Impersonate.IsZero() {
+		config.Impersonate = rest.ImpersonationConfig{UserName: opts.Impersonate.User, Groups: opts.Impersonate.Groups}
+	}
 
 	dynamicClient, err := dynamic.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
 	}
 
-	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	kubeClient, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
+	}
+
+	var discoveryClient discovery.CachedDiscoveryInterface = memory.NewMemCacheClient(kubeClient.Discovery())
+	if opts.DiscoveryCacheDir != "" {
+		discoveryClient, err = disk.NewCachedDiscoveryClientForConfig(config,
+			filepath.Join(opts.DiscoveryCacheDir, "discovery"),
+			filepath.Join(opts.DiscoveryCacheDir, "http"),
+			DefaultDiscoveryCacheTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cached discovery client: %w", err)
+		}
 	}
 
-	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
 	return &ClientSet{
 		logger:         logger,
 		kubeconfigPath: kubeconfigPath,
 		dynamicClient:  dynamicClient,
+		kubeClient:     kubeClient,
 		mapper:         mapper,
 		config:         config,
 	}, nil
 }
 
-// ApplyManifests applies Kubernetes manifests from a given directory to the cluster.
-// This function processes all YAML files in the specified directory, decodes them into
-// Kubernetes objects, and applies them to the cluster. It handles both creation and updates
-// of resources based on their existence in the cluster.
-func (cs *ClientSet) ApplyManifests(ctx context.Context, manifestsDir string) []error {
-	cs.logger.Info("Applying manifests", zap.String("directory", manifestsDir))
-	var applyErrors []error
+// manifestObject is a single Kubernetes object decoded from a manifest file.
+type manifestObject struct {
+	// path is the manifest file the object was read from.
+	path string
+	// index is the position of the object's document within the file.
+	index int
+	// obj is the decoded object.
+	obj *unstructured.Unstructured
+	// gvk is the object's GroupVersionKind.
+	gvk *schema.GroupVersionKind
+}
+
+// ref returns the reference to the resource the object describes.
+func (m manifestObject) ref() ResourceRef {
+	return ResourceRef{Kind: m.gvk.Kind, Namespace: m.obj.GetNamespace(), Name: m.obj.GetName()}
+}
+
+// readManifests decodes all objects from the YAML files in the given directory.
+// Files and directories matching an exclude pattern, and objects annotated with IgnoreAnnotation,
+// are skipped. Files and documents that cannot be read or decoded, and unnamed resources,
+// are reported as errors.
+func (cs *ClientSet) readManifests(manifestsDir string, exclude []string) ([]manifestObject, []error) {
+	var objects []manifestObject
+	var readErrors []error
 
 	err := filepath.WalkDir(manifestsDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
-			applyErrors = append(applyErrors, fmt.Errorf("filesystem error walking %s: %w", path, err))
+			readErrors = append(readErrors, fmt.Errorf("filesystem error walking %s: %w", path, err))
 			return nil
 		}
+		if len(exclude) > 0 && path != manifestsDir {
+			if relPath, relErr := filepath.Rel(manifestsDir, path); relErr == nil && isExcluded(filepath.ToSlash(relPath), exclude) {
+				cs.logger.Debug("Skipping excluded manifest path", zap.String("path", path))
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
 		if d.IsDir() {
 			return nil
 		}
@@ -123,14 +331,14 @@ func (cs *ClientSet) ApplyManifests(ctx context.Context, manifestsDir string) []
 		data, readErr := os.ReadFile(path)
 		if readErr != nil {
 			cs.logger.Error("Failed to read manifest file", zap.String("file", path), zap.Error(readErr))
-			applyErrors = append(applyErrors, fmt.Errorf("failed to read file %s: %w", path, readErr))
+			readErrors = append(readErrors, fmt.Errorf("failed to read file %s: %w", path, readErr))
 			return nil
 		}
 
 		decoder := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
-		objects := strings.Split(string(data), "\n---")
+		documents := strings.Split(string(data), "\n---")
 
-		for i, objStr := range objects {
+		for i, objStr := range documents {
 			trimmedObjStr := strings.TrimSpace(objStr)
 			if trimmedObjStr == "" {
 				continue
@@ -140,96 +348,289 @@ func (cs *ClientSet) ApplyManifests(ctx context.Context, manifestsDir string) []
 			_, gvk, decodeErr := decoder.Decode([]byte(trimmedObjStr), nil, unstructuredObj)
 			if decodeErr != nil {
 				cs.logger.Error("Failed to decode YAML object", zap.String("file", path), zap.Int("documentIdx", i), zap.Error(decodeErr))
-				applyErrors = append(applyErrors, fmt.Errorf("failed to decode YAML from %s (doc %d): %w", path, i, decodeErr))
+				readErrors = append(readErrors, fmt.Errorf("failed to decode YAML from %s (doc %d): %w", path, i, decodeErr))
+				continue
+			}
+
+			if isIgnored(unstructuredObj) {
+				cs.logger.Info("Skipping resource with ignore annotation",
+					zap.String("kind", gvk.Kind),
+					zap.String("name", unstructuredObj.GetName()),
+					zap.String("file", path))
 				continue
 			}
 
 			if unstructuredObj.GetName() == "" {
 				cs.logger.Warn("Skipping unnamed resource in manifest", zap.String("file", path), zap.Int("documentIdx", i), zap.String("kind", gvk.Kind))
-				applyErrors = append(applyErrors, fmt.Errorf("skipping unnamed resource in %s (doc %d) of kind %s", path, i, gvk.Kind))
+				readErrors = append(readErrors, fmt.Errorf("skipping unnamed resource in %s (doc %d) of kind %s", path, i, gvk.Kind))
 				continue
 			}
 
-			mapping, mappingErr := cs.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
-			if mappingErr != nil {
-				cs.logger.Error("Failed to get REST mapping for GVK",
-					zap.String("gvk", gvk.String()), zap.String("file", path), zap.Error(mappingErr))
-				applyErrors = append(applyErrors, fmt.Errorf("failed to get REST mapping for %s in %s: %w", gvk.String(), path, mappingErr))
-				continue
+			objects = append(objects, manifestObject{path: path, index: i, obj: unstructuredObj, gvk: gvk})
+		}
+		return nil
+	})
+	if err != nil {
+		readErrors = append(readErrors, fmt.Errorf("error during manifest directory walk %s: %w", manifestsDir, err))
+	}
+	return objects, readErrors
+}
+
+// resourceFor returns the dynamic resource client for a manifest object.
+// Namespaced objects without a namespace are defaulted to the "default" namespace.
+func (cs *ClientSet) resourceFor(m manifestObject, opts ManifestOptions) (dynamic.ResourceInterface, error) {
+	mapping, err := cs.mapper.RESTMapping(m.gvk.GroupKind(), m.gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// The kind may have been added since discovery was cached, e.g. by a CRD applied earlier
+		cs.resetMapper()
+		mapping, err = cs.mapper.RESTMapping(m.gvk.GroupKind(), m.gvk.Version)
+	}
+	if err != nil {
+		cs.logger.Error("Failed to get REST mapping for GVK",
+			zap.String("gvk", m.gvk.String()), zap.String("file", m.path), zap.Error(err))
+		return nil, fmt.Errorf("failed to get REST mapping for %s in %s: %w", m.gvk.String(), m.path, err)
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		// namespaced resources should specify the namespace
+		if m.obj.GetNamespace() == "" {
+			if opts.RequireNamespace {
+				return nil, fmt.Errorf("%s %s in %s has no namespace, which is required for namespaced resources", m.gvk.Kind, m.obj.GetName(), m.path)
 			}
+			namespace := common.DefaultIfEmpty(opts.DefaultNamespace, DefaultNamespace)
+			m.obj.SetNamespace(namespace)
+			cs.logger.Debug("Namespace not specified for namespaced resource, using the default namespace",
+				zap.String("kind", m.gvk.Kind),
+				zap.String("name", m.obj.GetName()),
+				zap.String("namespace", namespace))
+		}
+		return cs.dynamicClient.Resource(mapping.Resource).Namespace(m.obj.GetNamespace()), nil
+	}
+	// cluster-scoped resources should not specify the namespace
+	return cs.dynamicClient.Resource(mapping.Resource), nil
+}
 
-			var dr dynamic.ResourceInterface
-			if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
-				// namespaced resources should specify the namespace
-				if unstructuredObj.GetNamespace() == "" {
-					unstructuredObj.SetNamespace("default")
-					cs.logger.Debug("Namespace not specified for namespaced resource, defaulting to 'default'",
-						zap.String("kind", gvk.Kind),
-						zap.String("name", unstructuredObj.GetName()))
-				}
-				dr = cs.dynamicClient.Resource(mapping.Resource).Namespace(unstructuredObj.GetNamespace())
-			} else {
-				// cluster-scoped resources should not specify the namespace
-				dr = cs.dynamicClient.Resource(mapping.Resource)
+// resetMapper resets the REST mapper's discovery cache, unless it was reset within mapperResetInterval.
+func (cs *ClientSet) resetMapper() {
+	cs.mapperMu.Lock()
+	defer cs.mapperMu.Unlock()
+	if time.Since(cs.mapperResetAt) < mapperResetInterval {
+		return
+	}
+	cs.mapper.Reset()
+	cs.mapperResetAt = time.Now()
+}
+
+// refreshMapper resets the REST mapper's discovery cache regardless of when it was last reset, for
+// when kinds are known to have been added to the cluster.
+func (cs *ClientSet) refreshMapper() {
+	cs.mapperMu.Lock()
+	defer cs.mapperMu.Unlock()
+	cs.mapper.Reset()
+	cs.mapperResetAt = time.Now()
+}
+
+// ApplyManifests applies Kubernetes manifests from a given directory to the cluster.
+// This function processes all YAML files in the specified directory, decodes them into
+// Kubernetes objects, and applies them to the cluster. It handles both creation and updates
+// of resources based on their existence in the cluster.
+//
+// Resources are applied in dependency order: namespaces and CRDs first, then configuration and
+// RBAC, then services, then everything else. Resources within each step are applied concurrently.
+// Transient errors (see IsTransientError) are retried per resource with exponential backoff
+// within the same call. The strategy controls what happens when a manifest still fails
+// (see ApplyStrategies); an empty strategy means ApplyStrategyBestEffort. Paths matching an
+// exclude pattern of opts and resources annotated with IgnoreAnnotation are skipped, and
+// namespaced resources without a namespace are placed in the default namespace of opts.
+// Every applied resource is labelled and annotated with the tracking information (see Tracking),
+// so the resources of an application can be listed with AppSelector.
+// It returns the outcome of each resource that was attempted, in manifest order, along with any
+// errors encountered; manifests that could not be read have no result but are among the errors.
+func (cs *ClientSet) ApplyManifests(ctx context.Context, manifestsDir string, strategy string, opts ManifestOptions, tracking Tracking) ([]ResourceResult, []error) {
+	return cs.applyManifests(ctx, manifestsDir, strategy, opts, tracking, nil)
+}
+
+// ResumeManifests applies the manifests in a directory like ApplyManifests, except for the resources
+// in applied, which an earlier apply of the same manifests already applied. It is used to finish an
+// apply that failed part way through without applying every resource again. ConfigMap and Secret
+// hashes are still computed from all manifests. The results only cover the resources it attempted.
+func (cs *ClientSet) ResumeManifests(ctx context.Context, manifestsDir string, strategy string, opts ManifestOptions, tracking Tracking, applied []ResourceRef) ([]ResourceResult, []error) {
+	skip := make(map[ResourceRef]bool, len(applied))
+	for _, ref := range applied {
+		skip[ref] = true
+	}
+	return cs.applyManifests(ctx, manifestsDir, strategy, opts, tracking, skip)
+}
+
+// applyManifests applies the manifests in a directory as described for ApplyManifests, skipping
+// the resources in skip.
+func (cs *ClientSet) applyManifests(ctx context.Context, manifestsDir string, strategy string, opts ManifestOptions, tracking Tracking, skip map[ResourceRef]bool) ([]ResourceResult, []error) {
+	cs.logger.Info("Applying manifests", zap.String("directory", manifestsDir), zap.String("strategy", common.DefaultIfEmpty(strategy, ApplyStrategyBestEffort)),
+		zap.Int("skipped", len(skip)))
+
+	objects, applyErrors := cs.readManifests(manifestsDir, opts.Exclude)
+	if len(applyErrors) > 0 && strategy != "" && strategy != ApplyStrategyBestEffort {
+		return nil, applyErrors
+	}
+	for _, m := range objects {
+		tracking.stamp(m.obj)
+	}
+	if tracking.HashConfig {
+		annotateConfigHashes(objects)
+	}
+	if len(skip) > 0 {
+		remaining := objects[:0:0]
+		for _, m := range objects {
+			if !skip[m.ref()] {
+				remaining = append(remaining, m)
 			}
+		}
+		objects = remaining
+	}
 
-			// Try to get the resource
-			_, getErr := dr.Get(ctx, unstructuredObj.GetName(), metav1.GetOptions{})
-
-			if getErr != nil {
-				// Resource does not exist, create it
-				_, createErr := dr.Create(ctx, unstructuredObj, metav1.CreateOptions{})
-				if createErr != nil {
-					cs.logger.Error("Failed to create resource",
-						zap.String("kind", gvk.Kind),
-						zap.String("name", unstructuredObj.GetName()),
-						zap.String("namespace", unstructuredObj.GetNamespace()),
-						zap.Error(createErr))
-					applyErrors = append(applyErrors, fmt.Errorf("failed to create %s %s/%s from %s: %w", gvk.Kind, unstructuredObj.GetNamespace(), unstructuredObj.GetName(), path, createErr))
-					continue
-				}
-				cs.logger.Info("Created resource",
-					zap.String("kind", gvk.Kind),
-					zap.String("name", unstructuredObj.GetName()),
-					zap.String("namespace", unstructuredObj.GetNamespace()))
-			} else {
-				// Resource exists, update it (using simple update for MVP)
-				// For proper server-side apply, you'd use FieldManager and Apply method
-				// unstructuredObj.SetResourceVersion("") // Clear resource version for update (optional, usually handled by server-side apply)
-				_, updateErr := dr.Update(ctx, unstructuredObj, metav1.UpdateOptions{})
-				if updateErr != nil {
-					cs.logger.Error("Failed to update resource",
-						zap.String("kind", gvk.Kind),
-						zap.String("name", unstructuredObj.GetName()),
-						zap.String("namespace", unstructuredObj.GetNamespace()),
-						zap.Error(updateErr))
-					applyErrors = append(applyErrors, fmt.Errorf("failed to update %s %s/%s from %s: %w", gvk.Kind, unstructuredObj.GetNamespace(), unstructuredObj.GetName(), path, updateErr))
-					continue
-				}
-				cs.logger.Info("Updated resource",
-					zap.String("kind", gvk.Kind),
-					zap.String("name", unstructuredObj.GetName()),
-					zap.String("namespace", unstructuredObj.GetNamespace()))
+	if strategy == ApplyStrategyAtomic {
+		var dryRunFailures []ResourceResult
+		defined := crdDefinedKinds(objects)
+		for _, m := range objects {
+			dr, err := cs.resourceFor(m, opts)
+			if undefinedCustomResource(err, m, defined) {
+				continue
+			}
+			if err == nil {
+				err = cs.dryRun(ctx, dr, m)
+			}
+			if err != nil {
+				err = fmt.Errorf("dry run of %s %s/%s from %s failed: %w", m.gvk.Kind, m.obj.GetNamespace(), m.obj.GetName(), m.path, err)
+				applyErrors = append(applyErrors, err)
+				dryRunFailures = append(dryRunFailures, ResourceResult{ResourceRef: m.ref(), Action: ActionFailed, Err: err})
 			}
 		}
-		return nil
-	})
-	if err != nil {
-		applyErrors = append(applyErrors, fmt.Errorf("error during manifest directory walk %s: %w", manifestsDir, err))
+		if len(applyErrors) > 0 {
+			cs.logger.Warn("Dry run failed, no manifests were applied", zap.Int("errors", len(applyErrors)))
+			return dryRunFailures, applyErrors
+		}
+	}
+
+	results, objectErrors := cs.applyObjects(ctx, objects, strategy == ApplyStrategyFailFast, opts)
+	return results, append(applyErrors, objectErrors...)
+}
+
+// applyObject creates the manifest object in the cluster, or updates it if it already exists.
+// An existing resource that a server-side dry run shows the update would not change is not
+// updated, sparing the API server a write. It returns ActionCreated, ActionUpdated or
+// ActionUnchanged for what it did.
+func (cs *ClientSet) applyObject(ctx context.Context, dr dynamic.ResourceInterface, m manifestObject) (string, error) {
+	unstructuredObj, gvk, path := m.obj, m.gvk, m.path
+
+	// Try to get the resource
+	live, getErr := dr.Get(ctx, unstructuredObj.GetName(), metav1.GetOptions{})
+
+	if getErr != nil {
+		// Resource does not exist, create it
+		_, createErr := dr.Create(ctx, unstructuredObj, metav1.CreateOptions{})
+		if createErr != nil {
+			cs.logger.Error("Failed to create resource",
+				zap.String("kind", gvk.Kind),
+				zap.String("name", unstructuredObj.GetName()),
+				zap.String("namespace", unstructuredObj.GetNamespace()),
+				zap.Error(createErr))
+			return "", fmt.Errorf("failed to create %s %s/%s from %s: %w", gvk.Kind, unstructuredObj.GetNamespace(), unstructuredObj.GetName(), path, createErr)
+		}
+		cs.logger.Info("Created resource",
+			zap.String("kind", gvk.Kind),
+			zap.String("name", unstructuredObj.GetName()),
+			zap.String("namespace", unstructuredObj.GetNamespace()))
+		return ActionCreated, nil
 	}
-	return applyErrors
+
+	// Resource exists; skip the update if it would not change anything. If the dry run fails, the
+	// update is attempted anyway so that its error is the one reported.
+	if unchanged, err := unchangedByUpdate(ctx, dr, m, live); err == nil && unchanged {
+		cs.logger.Debug("Resource unchanged, skipping update",
+			zap.String("kind", gvk.Kind),
+			zap.String("name", unstructuredObj.GetName()),
+			zap.String("namespace", unstructuredObj.GetNamespace()))
+		return ActionUnchanged, nil
+	}
+
+	// Update it (using simple update for MVP)
+	// For proper server-side apply, you'd use FieldManager and Apply method
+	// unstructuredObj.SetResourceVersion("") // Clear resource version for update (optional, usually handled by server-side apply)
+	_, updateErr := dr.Update(ctx, unstructuredObj, metav1.UpdateOptions{})
+	if updateErr != nil {
+		cs.logger.Error("Failed to update resource",
+			zap.String("kind", gvk.Kind),
+			zap.String("name", unstructuredObj.GetName()),
+			zap.String("namespace", unstructuredObj.GetNamespace()),
+			zap.Error(updateErr))
+		return "", fmt.Errorf("failed to update %s %s/%s from %s: %w", gvk.Kind, unstructuredObj.GetNamespace(), unstructuredObj.GetName(), path, updateErr)
+	}
+	cs.logger.Info("Updated resource",
+		zap.String("kind", gvk.Kind),
+		zap.String("name", unstructuredObj.GetName()),
+		zap.String("namespace", unstructuredObj.GetNamespace()))
+	return ActionUpdated, nil
 }
 
 // CheckConnectivity verifies connectivity to the Kubernetes cluster.
 // It uses the Kubernetes clientset to fetch the server version, ensuring the cluster is reachable.
 func (cs *ClientSet) CheckConnectivity(ctx context.Context) error {
-	kubeClient, err := kubernetes.NewForConfig(cs.config)
-	if err != nil {
-		return fmt.Errorf("failed to create kubernetes clientset: %w", err)
-	}
-	_, err = kubeClient.Discovery().ServerVersion()
+	_, err := cs.serverVersion()
 	if err != nil {
 		return fmt.Errorf("failed to get Kubernetes server version: %w", err)
 	}
 	return nil
 }
+
+// ServerVersion returns the Kubernetes server version reported by the cluster (e.g., v1.30.2).
+func (cs *ClientSet) ServerVersion(ctx context.Context) (string, error) {
+	version, err := cs.serverVersion()
+	if err != nil {
+		return "", fmt.Errorf("failed to get Kubernetes server version: %w", err)
+	}
+	return version.GitVersion, nil
+}
+
+// serverVersion fetches the server version, retrying once if the credentials are rejected. Exec
+// credential plugins are run again after a 401 Unauthorized, so a token that expired before the
+// expiry the plugin reported, or without one, is replaced by the retry.
+func (cs *ClientSet) serverVersion() (*version.Info, error) {
+	info, err := cs.kubeClient.Discovery().ServerVersion()
+	if apierrors.IsUnauthorized(err) && cs.config.ExecProvider != nil {
+		cs.logger.Info("Credentials were rejected, retrying with refreshed exec plugin credentials")
+		info, err = cs.kubeClient.Discovery().ServerVersion()
+	}
+	return info, err
+}
+
+// NodeCount returns the number of nodes registered in the cluster.
+func (cs *ClientSet) NodeCount(ctx context.Context) (int, error) {
+	nodes, err := cs.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	return len(nodes.Items), nil
+}
+
+// Host returns the API server URL the client set is configured to talk to.
+func (cs *ClientSet) Host() string {
+	return cs.config.Host
+}
+
+// KubeconfigContext returns the current context name and its API server URL from a kubeconfig file.
+func KubeconfigContext(kubeconfigPath string) (string, string, error) {
+	kubeconfig, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse kubeconfig %s: %w", kubeconfigPath, err)
+	}
+	contextName := kubeconfig.CurrentContext
+	kubeContext, ok := kubeconfig.Contexts[contextName]
+	if !ok {
+		return contextName, "", fmt.Errorf("current context %q not found in kubeconfig %s", contextName, kubeconfigPath)
+	}
+	clusterInfo, ok := kubeconfig.Clusters[kubeContext.Cluster]
+	if !ok {
+		return contextName, "", fmt.Errorf("cluster %q for context %q not found in kubeconfig %s", kubeContext.Cluster, contextName, kubeconfigPath)
+	}
+	return contextName, clusterInfo.Server, nil
+}
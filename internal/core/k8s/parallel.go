@@ -0,0 +1,130 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultApplyConcurrency is the maximum number of resources applied at the same time within a wave.
+	DefaultApplyConcurrency = 10
+)
+
+// kindWaves lists the kinds other resources commonly depend on, grouped into the waves they are applied in.
+// Kinds that are not listed, including workloads and custom resources, are applied in the wave after
+// these, and lateKinds are applied last, once the services backing them have been applied.
+var kindWaves = [][]string{
+	{"Namespace", "CustomResourceDefinition", "PriorityClass", "StorageClass"},
+	{"ServiceAccount", "Secret", "ConfigMap", "LimitRange", "ResourceQuota", "PersistentVolume", "PersistentVolumeClaim", "ClusterRole", "Role"},
+	{"ClusterRoleBinding", "RoleBinding"},
+	{"Service"},
+}
+
+// lateKinds are applied after all other resources, as they change how the API server handles requests.
+var lateKinds = []string{"MutatingWebhookConfiguration", "ValidatingWebhookConfiguration", "APIService"}
+
+// kindWave returns the index of the wave resources of the given kind are applied in.
+func kindWave(kind string) int {
+	for i, kinds := range kindWaves {
+		for _, k := range kinds {
+			if k == kind {
+				return i
+			}
+		}
+	}
+	for _, k := range lateKinds {
+		if k == kind {
+			return len(kindWaves) + 1
+		}
+	}
+	return len(kindWaves)
+}
+
+// applyWaves groups the indexes of objects into the waves they are applied in.
+// Objects in a wave only depend on objects in earlier waves, so they can be applied concurrently.
+// Empty waves are omitted and objects keep their manifest order within a wave.
+func applyWaves(objects []manifestObject) [][]int {
+	waves := make([][]int, len(kindWaves)+2)
+	for i, m := range objects {
+		w := kindWave(m.gvk.Kind)
+		waves[w] = append(waves[w], i)
+	}
+
+	var nonEmpty [][]int
+	for _, wave := range waves {
+		if len(wave) > 0 {
+			nonEmpty = append(nonEmpty, wave)
+		}
+	}
+	return nonEmpty
+}
+
+// applyObjects applies the objects wave by wave, up to DefaultApplyConcurrency at a time within a wave.
+// After a wave that applied CustomResourceDefinitions, it waits for those defining the kinds of
+// objects in later waves to be established.
+// With failFast, no further objects are started once one has failed, and later waves are skipped.
+// The results of the objects that were attempted and the errors are returned in manifest order.
+func (cs *ClientSet) applyObjects(ctx context.Context, objects []manifestObject, failFast bool, opts ManifestOptions) ([]ResourceResult, []error) {
+	attempted := make([]bool, len(objects))
+	actions := make([]string, len(objects))
+	errs := make([]error, len(objects))
+	var failed atomic.Bool
+
+	waves := applyWaves(objects)
+	for w, wave := range waves {
+		sem := make(chan struct{}, DefaultApplyConcurrency)
+		var wg sync.WaitGroup
+		for _, i := range wave {
+			if failFast && failed.Load() {
+				break
+			}
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				m := objects[i]
+				dr, err := cs.resourceFor(m, opts)
+				if err == nil {
+					actions[i], err = cs.applyWithRetry(ctx, dr, m)
+				}
+				attempted[i] = true
+				errs[i] = err
+				if err != nil {
+					failed.Store(true)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if failFast && failed.Load() {
+			break
+		}
+		// Custom resources are only served once the CRDs defining their kinds are established
+		if crds := crdsNeededLater(objects, wave, waves[w+1:], errs); len(crds) > 0 {
+			cs.awaitCRDsEstablished(ctx, crds)
+		}
+	}
+
+	var results []ResourceResult
+	var applyErrors []error
+	for i, m := range objects {
+		switch {
+		case !attempted[i]:
+		case errs[i] != nil:
+			applyErrors = append(applyErrors, errs[i])
+			results = append(results, ResourceResult{ResourceRef: m.ref(), Action: ActionFailed, Err: errs[i]})
+		default:
+			results = append(results, ResourceResult{ResourceRef: m.ref(), Action: actions[i]})
+		}
+	}
+	if failFast && len(applyErrors) > 0 {
+		cs.logger.Warn("Stopping apply after first failure", zap.Int("applied", len(results)-len(applyErrors)), zap.Int("remaining", len(objects)-len(results)))
+	}
+	return results, applyErrors
+}
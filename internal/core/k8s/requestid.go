@@ -0,0 +1,41 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+)
+
+// requestIDHeader is the header Kubernetes API calls carry the ID of the request that caused them in.
+const requestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key of the request ID.
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx whose Kubernetes API calls carry id in an X-Request-ID header,
+// so they can be correlated with the gitopsctl API request that caused them in the logs of proxies
+// in front of the API server. An empty id returns ctx unchanged.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// requestIDTransport sets the X-Request-ID header of requests whose context carries a request ID.
+type requestIDTransport struct {
+	next http.RoundTripper
+}
+
+// wrapRequestID wraps the transport of a client set so that it sets the X-Request-ID header.
+func wrapRequestID(next http.RoundTripper) http.RoundTripper {
+	return &requestIDTransport{next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if id, ok := req.Context().Value(requestIDKey{}).(string); ok && req.Header.Get(requestIDHeader) == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(requestIDHeader, id)
+	}
+	return t.next.RoundTrip(req)
+}
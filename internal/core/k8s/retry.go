@@ -0,0 +1,73 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// DefaultApplyRetries is how many times a resource is retried after a transient apply error.
+	DefaultApplyRetries = 4
+	// DefaultApplyRetryBackoff is the delay before the first retry of a resource; it doubles after each attempt.
+	DefaultApplyRetryBackoff = 500 * time.Millisecond
+	// MaxApplyRetryBackoff caps the delay between retries of a resource.
+	MaxApplyRetryBackoff = 10 * time.Second
+)
+
+// IsTransientError reports whether an apply error is likely to succeed if retried, such as
+// conflicts, API throttling, server or webhook timeouts, and dropped connections.
+// Permanent errors such as validation failures or missing permissions return false.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch {
+	case apierrors.IsConflict(err),
+		apierrors.IsAlreadyExists(err), // created concurrently between our Get and Create
+		apierrors.IsTooManyRequests(err),
+		apierrors.IsServerTimeout(err),
+		apierrors.IsTimeout(err),
+		apierrors.IsServiceUnavailable(err),
+		apierrors.IsInternalError(err): // includes admission webhooks that failed to respond
+		return true
+	}
+	return utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// applyWithRetry applies a manifest object, retrying transient errors with exponential backoff.
+// Retries stop when ctx is done, so a resource never delays the sync beyond its deadline.
+// It returns the action applyObject took.
+func (cs *ClientSet) applyWithRetry(ctx context.Context, dr dynamic.ResourceInterface, m manifestObject) (string, error) {
+	backoff := DefaultApplyRetryBackoff
+	for attempt := 1; ; attempt++ {
+		action, err := cs.applyObject(ctx, dr, m)
+		if err == nil || !IsTransientError(err) || ctx.Err() != nil {
+			return action, err
+		}
+		if attempt > DefaultApplyRetries {
+			return "", fmt.Errorf("%w (gave up after %d attempts)", err, attempt)
+		}
+
+		cs.logger.Warn("Transient error applying resource, retrying",
+			zap.String("kind", m.gvk.Kind),
+			zap.String("name", m.obj.GetName()),
+			zap.String("namespace", m.obj.GetNamespace()),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return "", err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, MaxApplyRetryBackoff)
+	}
+}
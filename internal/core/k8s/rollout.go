@@ -0,0 +1,238 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// RolloutProviderArgo identifies Argo Rollouts Rollout resources.
+	RolloutProviderArgo = "argo-rollouts"
+	// RolloutProviderFlagger identifies Flagger Canary resources.
+	RolloutProviderFlagger = "flagger"
+
+	// argoRolloutResource and flaggerCanaryResource are the fully qualified resource types of the
+	// progressive delivery resources, so they are not confused with other CRDs of the same kind.
+	argoRolloutResource   = "rollouts.argoproj.io"
+	flaggerCanaryResource = "canaries.flagger.app"
+)
+
+// RolloutStatus is the progress of a canary or blue-green rollout driven by Argo Rollouts or Flagger.
+type RolloutStatus struct {
+	// Resource is the Rollout or Canary resource.
+	Resource ResourceRef
+	// Provider is RolloutProviderArgo or RolloutProviderFlagger.
+	Provider string
+	// Phase is the phase reported by the provider (e.g., Progressing, Paused, Healthy, Succeeded).
+	Phase string
+	// Step is the number of canary steps completed, and Steps the total number of steps.
+	// Both are zero for rollouts without steps, such as blue-green rollouts.
+	Step, Steps int
+	// Weight is the percentage of traffic sent to the canary.
+	Weight int
+	// Paused reports whether the rollout waits to be promoted.
+	Paused bool
+	// Message is the provider's explanation of the phase, if any.
+	Message string
+}
+
+// RolloutProvider returns the progressive delivery provider driving resources of a kind, or "" if none does.
+func RolloutProvider(kind string) string {
+	switch kind {
+	case "Rollout":
+		return RolloutProviderArgo
+	case "Canary":
+		return RolloutProviderFlagger
+	}
+	return ""
+}
+
+// RolloutStatuses reports the progress of the Argo Rollouts Rollouts and Flagger Canaries among the given resources.
+// Other resources are ignored. A rollout that cannot be fetched is reported as an error.
+func (cs *ClientSet) RolloutStatuses(ctx context.Context, refs []ResourceRef) ([]RolloutStatus, []error) {
+	var statuses []RolloutStatus
+	var errs []error
+	for _, ref := range refs {
+		provider := RolloutProvider(ref.Kind)
+		if provider == "" {
+			continue
+		}
+		obj, err := cs.rolloutResource(ctx, provider, ref)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		status := RolloutStatus{Resource: ref, Provider: provider}
+		if provider == RolloutProviderArgo {
+			argoRolloutProgress(obj, &status)
+		} else {
+			flaggerCanaryProgress(obj, &status)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, errs
+}
+
+// PromoteRollout promotes a rollout to its next step.
+//
+// A paused Argo Rollout is resumed, and an Argo Rollout that is not paused skips its current canary
+// step. With full, an Argo Rollout skips all remaining steps and analysis. A Flagger Canary is told
+// to skip its analysis, so Flagger promotes the canary with its next check.
+func (cs *ClientSet) PromoteRollout(ctx context.Context, ref ResourceRef, full bool) error {
+	provider := RolloutProvider(ref.Kind)
+	if provider == "" {
+		return fmt.Errorf("%s is not an Argo Rollouts Rollout or Flagger Canary", ref)
+	}
+	obj, err := cs.rolloutResource(ctx, provider, ref)
+	if err != nil {
+		return err
+	}
+	cs.logger.Info("Promoting rollout", zap.String("resource", ref.String()), zap.Bool("full", full))
+
+	if provider == RolloutProviderFlagger {
+		return cs.patchRollout(ctx, provider, ref, map[string]any{"spec": map[string]any{"skipAnalysis": true}}, false)
+	}
+
+	if full {
+		return cs.patchRollout(ctx, provider, ref, map[string]any{"status": map[string]any{"promoteFull": true}}, true)
+	}
+	pauseConditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "pauseConditions")
+	specPaused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused")
+	if len(pauseConditions) > 0 || specPaused {
+		if err := cs.patchRollout(ctx, provider, ref, map[string]any{"status": map[string]any{"pauseConditions": nil}}, true); err != nil {
+			return err
+		}
+		return cs.patchRollout(ctx, provider, ref, map[string]any{"spec": map[string]any{"paused": false}}, false)
+	}
+	step, found, _ := unstructured.NestedInt64(obj.Object, "status", "currentStepIndex")
+	steps, _, _ := unstructured.NestedSlice(obj.Object, "spec", "strategy", "canary", "steps")
+	if !found || int(step) >= len(steps) {
+		return fmt.Errorf("%s is neither paused nor at a canary step; there is nothing to promote", ref)
+	}
+	return cs.patchRollout(ctx, provider, ref, map[string]any{"status": map[string]any{"currentStepIndex": step + 1}}, true)
+}
+
+// AbortRollout aborts an Argo Rollout, shifting all traffic back to the stable version.
+// Flagger rolls back failed canaries itself and cannot be aborted on demand.
+func (cs *ClientSet) AbortRollout(ctx context.Context, ref ResourceRef) error {
+	provider := RolloutProvider(ref.Kind)
+	switch provider {
+	case "":
+		return fmt.Errorf("%s is not an Argo Rollouts Rollout or Flagger Canary", ref)
+	case RolloutProviderFlagger:
+		return fmt.Errorf("%s is a Flagger Canary, which cannot be aborted; Flagger rolls it back when its analysis fails", ref)
+	}
+	if _, err := cs.rolloutResource(ctx, provider, ref); err != nil {
+		return err
+	}
+	cs.logger.Info("Aborting rollout", zap.String("resource", ref.String()))
+	return cs.patchRollout(ctx, provider, ref, map[string]any{"status": map[string]any{"abort": true}}, true)
+}
+
+// rolloutResource fetches a Rollout or Canary resource.
+func (cs *ClientSet) rolloutResource(ctx context.Context, provider string, ref ResourceRef) (*unstructured.Unstructured, error) {
+	obj, err := cs.GetResource(ctx, rolloutResourceType(provider), ref.Namespace, ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", ref, err)
+	}
+	return obj, nil
+}
+
+// patchRollout applies a JSON merge patch to a Rollout or Canary resource, or to its status subresource.
+func (cs *ClientSet) patchRollout(ctx context.Context, provider string, ref ResourceRef, patch map[string]any, status bool) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	dr, err := cs.resourceClient(rolloutResourceType(provider), ref.Namespace)
+	if err != nil {
+		return err
+	}
+	var subresources []string
+	if status {
+		subresources = append(subresources, "status")
+	}
+	if _, err := dr.Patch(ctx, ref.Name, types.MergePatchType, data, metav1.PatchOptions{}, subresources...); err != nil {
+		return fmt.Errorf("failed to patch %s: %w", ref, err)
+	}
+	return nil
+}
+
+// rolloutResourceType returns the fully qualified resource type of a provider's rollout resources.
+func rolloutResourceType(provider string) string {
+	if provider == RolloutProviderFlagger {
+		return flaggerCanaryResource
+	}
+	return argoRolloutResource
+}
+
+// argoRolloutProgress fills in the progress of an Argo Rollout.
+func argoRolloutProgress(obj *unstructured.Unstructured, status *RolloutStatus) {
+	status.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	status.Message, _, _ = unstructured.NestedString(obj.Object, "status", "message")
+	pauseConditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "pauseConditions")
+	specPaused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused")
+	status.Paused = len(pauseConditions) > 0 || specPaused
+
+	steps, _, _ := unstructured.NestedSlice(obj.Object, "spec", "strategy", "canary", "steps")
+	if len(steps) == 0 {
+		return
+	}
+	step, _, _ := unstructured.NestedInt64(obj.Object, "status", "currentStepIndex")
+	status.Step = min(int(step), len(steps))
+	status.Steps = len(steps)
+	if status.Step == status.Steps {
+		status.Weight = 100
+		return
+	}
+	// The canary weight is the one set by the last completed setWeight step
+	for _, s := range steps[:status.Step] {
+		if weight, found, _ := unstructured.NestedInt64(asMap(s), "setWeight"); found {
+			status.Weight = int(weight)
+		}
+	}
+}
+
+// flaggerCanaryProgress fills in the progress of a Flagger Canary.
+func flaggerCanaryProgress(obj *unstructured.Unstructured, status *RolloutStatus) {
+	status.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+	status.Paused = status.Phase == "WaitingPromotion" || status.Phase == "Waiting"
+	weight, _, _ := unstructured.NestedInt64(obj.Object, "status", "canaryWeight")
+	status.Weight = int(weight)
+	for _, cond := range conditions(obj) {
+		if cond["type"] == "Promoted" {
+			status.Message, _ = cond["message"].(string)
+		}
+	}
+
+	stepWeights, _, _ := unstructured.NestedSlice(obj.Object, "spec", "analysis", "stepWeights")
+	stepWeight, _, _ := unstructured.NestedInt64(obj.Object, "spec", "analysis", "stepWeight")
+	iterations, _, _ := unstructured.NestedInt64(obj.Object, "spec", "analysis", "iterations")
+	switch {
+	case len(stepWeights) > 0:
+		status.Steps = len(stepWeights)
+		for _, w := range stepWeights {
+			if v, ok := w.(int64); ok && weight > 0 && v <= weight {
+				status.Step++
+			}
+		}
+	case stepWeight > 0:
+		maxWeight, found, _ := unstructured.NestedInt64(obj.Object, "spec", "analysis", "maxWeight")
+		if !found {
+			maxWeight = 50 // Flagger's default
+		}
+		status.Steps = int(maxWeight / stepWeight)
+		status.Step = min(int(weight/stepWeight), status.Steps)
+	case iterations > 0:
+		// A/B testing and blue-green canaries run a number of iterations instead of shifting weight
+		completed, _, _ := unstructured.NestedInt64(obj.Object, "status", "iterations")
+		status.Steps = int(iterations)
+		status.Step = min(int(completed), status.Steps)
+	}
+}
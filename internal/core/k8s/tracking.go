@@ -0,0 +1,157 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// ManagedByLabel is the well-known label naming the tool that manages a resource.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedByValue is the value of ManagedByLabel on every resource applied by the controller.
+	ManagedByValue = "gitopsctl"
+	// AppLabel names the application that owns an applied resource, so its resources can be selected.
+	// It is omitted when the application name is not a valid label value.
+	AppLabel = "gitopsctl.io/app"
+	// AppAnnotation names the application that owns an applied resource.
+	AppAnnotation = "gitopsctl.io/app"
+	// RevisionAnnotation records the Git commit a resource was last changed by. Resources a sync
+	// would not change are not updated, so it is not moved forward for them.
+	RevisionAnnotation = "gitopsctl.io/revision"
+	// ControllerAnnotation identifies the controller instance that last applied a resource.
+	ControllerAnnotation = "gitopsctl.io/controller"
+)
+
+// Tracking identifies the application, commit, and controller instance behind an applied resource.
+type Tracking struct {
+	// App is the name of the application that owns the resource.
+	App string `json:"app"`
+	// Revision is the Git commit the resource was last applied from.
+	Revision string `json:"revision,omitempty"`
+	// Controller is the instance ID of the controller that last applied the resource.
+	Controller string `json:"controller,omitempty"`
+	// HelmRelease is the Helm release the resource is recorded in, if any. Resources of a release
+	// are annotated with it so Helm recognizes them as part of the release.
+	HelmRelease string `json:"helmRelease,omitempty"`
+	// HelmNamespace is the namespace of HelmRelease.
+	HelmNamespace string `json:"helmNamespace,omitempty"`
+	// HashConfig annotates the pod templates of Deployments, StatefulSets and DaemonSets with a hash
+	// of the ConfigMaps and Secrets in the manifests they reference, with ConfigHashAnnotation.
+	// It is not recorded on resources.
+	HashConfig bool `json:"-"`
+}
+
+// stamp sets the tracking labels and annotations on a manifest object.
+// Values already present in the manifest for these keys are overwritten.
+func (t Tracking) stamp(obj *unstructured.Unstructured) {
+	objLabels := obj.GetLabels()
+	if objLabels == nil {
+		objLabels = make(map[string]string)
+	}
+	objLabels[ManagedByLabel] = ManagedByValue
+	if len(validation.IsValidLabelValue(t.App)) == 0 {
+		objLabels[AppLabel] = t.App
+	} else {
+		delete(objLabels, AppLabel)
+	}
+	obj.SetLabels(objLabels)
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[AppAnnotation] = t.App
+	annotations[RevisionAnnotation] = t.Revision
+	annotations[ControllerAnnotation] = t.Controller
+	if t.HelmRelease != "" {
+		annotations[HelmReleaseNameAnnotation] = t.HelmRelease
+		annotations[HelmReleaseNamespaceAnnotation] = t.HelmNamespace
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// TrackingFor returns the tracking information recorded on a resource, and whether the
+// resource is managed by gitopsctl.
+func TrackingFor(obj *unstructured.Unstructured) (Tracking, bool) {
+	annotations := obj.GetAnnotations()
+	if obj.GetLabels()[ManagedByLabel] != ManagedByValue || annotations[AppAnnotation] == "" {
+		return Tracking{}, false
+	}
+	return Tracking{
+		App:        annotations[AppAnnotation],
+		Revision:   annotations[RevisionAnnotation],
+		Controller: annotations[ControllerAnnotation],
+	}, true
+}
+
+// AppSelector returns the label selector matching the resources applied for an application.
+func AppSelector(appName string) string {
+	return labels.Set{ManagedByLabel: ManagedByValue, AppLabel: appName}.String()
+}
+
+// GetResource fetches a live resource from the cluster.
+//
+// The resource type is given the way kubectl accepts it (e.g., "Deployment", "deployments", or
+// "deployments.apps"). The namespace is ignored for cluster-scoped resources and defaults to
+// "default" for namespaced ones. Use TrackingFor to find out which application manages it.
+func (cs *ClientSet) GetResource(ctx context.Context, resource, namespace, name string) (*unstructured.Unstructured, error) {
+	dr, err := cs.resourceClient(resource, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return dr.Get(ctx, name, metav1.GetOptions{})
+}
+
+// resourceClient returns the dynamic client for a kubectl-style resource type in a namespace.
+// The namespace is ignored for cluster-scoped resources and defaults to "default" for namespaced ones.
+func (cs *ClientSet) resourceClient(resource, namespace string) (dynamic.ResourceInterface, error) {
+	gvk, err := cs.kindFor(resource)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := cs.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get REST mapping for %s: %w", gvk.String(), err)
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		return cs.dynamicClient.Resource(mapping.Resource).Namespace(namespace), nil
+	}
+	return cs.dynamicClient.Resource(mapping.Resource), nil
+}
+
+// kindFor resolves a kubectl-style resource name to its preferred GroupVersionKind.
+func (cs *ClientSet) kindFor(resource string) (schema.GroupVersionKind, error) {
+	resource = strings.ToLower(strings.TrimSpace(resource))
+	fullySpecified, groupResource := schema.ParseResourceArg(resource)
+	candidates := []schema.GroupVersionResource{groupResource.WithVersion("")}
+	if fullySpecified != nil {
+		candidates = append([]schema.GroupVersionResource{*fullySpecified}, candidates...)
+	}
+
+	var err error
+	for _, gvr := range candidates {
+		var gvk schema.GroupVersionKind
+		if gvk, err = cs.mapper.KindFor(gvr); err == nil {
+			return gvk, nil
+		}
+	}
+	cs.logger.Debug("Failed to resolve resource", zap.String("resource", resource), zap.Error(err))
+	if meta.IsNoMatchError(err) {
+		return schema.GroupVersionKind{}, fmt.Errorf("unknown resource type '%s'", resource)
+	}
+	return schema.GroupVersionKind{}, fmt.Errorf("failed to resolve resource type '%s': %w", resource, err)
+}
@@ -0,0 +1,88 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+)
+
+// ValidateManifests validates the manifests in a directory against the cluster's schemas
+// without changing anything in the cluster.
+//
+// Each object is submitted to the API server as a server-side dry run with strict field
+// validation, so the server checks it against its OpenAPI schemas, including those of
+// installed CRDs, and reports unknown or duplicate fields. Manifests that cannot be decoded
+// or whose kind is unknown to the cluster are also reported, except custom resources whose CRD is
+// among the manifests, which cannot be validated before it is applied. Dry-run failures that are not
+// schema errors (e.g., missing permissions) are left for the apply step to report.
+// Excluded paths and ignored resources are skipped, as they are by ApplyManifests.
+// All problems found are returned; an empty result means the manifests are valid.
+func (cs *ClientSet) ValidateManifests(ctx context.Context, manifestsDir string, opts ManifestOptions) []error {
+	cs.logger.Info("Validating manifests", zap.String("directory", manifestsDir))
+
+	objects, validationErrors := cs.readManifests(manifestsDir, opts.Exclude)
+	defined := crdDefinedKinds(objects)
+	for _, m := range objects {
+		dr, err := cs.resourceFor(m, opts)
+		if undefinedCustomResource(err, m, defined) {
+			cs.logger.Debug("Skipping validation of custom resource whose CRD is not applied yet",
+				zap.String("kind", m.gvk.Kind),
+				zap.String("name", m.obj.GetName()))
+			continue
+		}
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+			continue
+		}
+
+		if err := cs.dryRun(ctx, dr, m); err != nil {
+			if !isSchemaError(err) {
+				cs.logger.Debug("Ignoring non-schema dry-run failure during validation",
+					zap.String("kind", m.gvk.Kind),
+					zap.String("name", m.obj.GetName()),
+					zap.Error(err))
+				continue
+			}
+			cs.logger.Debug("Manifest failed validation",
+				zap.String("kind", m.gvk.Kind),
+				zap.String("name", m.obj.GetName()),
+				zap.String("namespace", m.obj.GetNamespace()),
+				zap.Error(err))
+			validationErrors = append(validationErrors, fmt.Errorf("invalid %s %s/%s in %s (doc %d): %w", m.gvk.Kind, m.obj.GetNamespace(), m.obj.GetName(), m.path, m.index, err))
+		}
+	}
+	return validationErrors
+}
+
+// dryRun submits the manifest object to the API server as a server-side dry-run create,
+// or update if it already exists, with strict field validation.
+func (cs *ClientSet) dryRun(ctx context.Context, dr dynamic.ResourceInterface, m manifestObject) error {
+	existing, err := dr.Get(ctx, m.obj.GetName(), metav1.GetOptions{})
+	switch {
+	case err == nil:
+		obj := m.obj.DeepCopy()
+		obj.SetResourceVersion(existing.GetResourceVersion())
+		_, err = dr.Update(ctx, obj, metav1.UpdateOptions{
+			DryRun:          []string{metav1.DryRunAll},
+			FieldValidation: metav1.FieldValidationStrict,
+		})
+		return err
+	case apierrors.IsNotFound(err):
+		_, err = dr.Create(ctx, m.obj, metav1.CreateOptions{
+			DryRun:          []string{metav1.DryRunAll},
+			FieldValidation: metav1.FieldValidationStrict,
+		})
+		return err
+	default:
+		return err
+	}
+}
+
+// isSchemaError reports whether an API error means the object does not match the server's schema.
+func isSchemaError(err error) bool {
+	return apierrors.IsInvalid(err) || apierrors.IsBadRequest(err)
+}
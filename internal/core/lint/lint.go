@@ -0,0 +1,250 @@
+// Package lint checks manifests with external linters: kubeconform for validity against the
+// Kubernetes schemas and kube-score for best practices.
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+)
+
+const (
+	// LinterKubeconform validates manifests against the Kubernetes JSON schemas with kubeconform.
+	LinterKubeconform = "kubeconform"
+	// LinterKubeScore scores manifests against best practices with kube-score.
+	LinterKubeScore = "kube-score"
+
+	// SeverityError is the severity of findings that block the apply in strict mode.
+	SeverityError = "error"
+	// SeverityWarning is the severity of findings that are only reported.
+	SeverityWarning = "warning"
+
+	// Timeout is how long the linters of a sync may run in total.
+	Timeout = 2 * time.Minute
+
+	// maxOutput is how much of a failed linter's output is kept in its error.
+	maxOutput = 2000
+)
+
+// Linters lists the supported linters.
+var Linters = []string{LinterKubeconform, LinterKubeScore}
+
+// kube-score grades, from its scorecard package.
+const (
+	kubeScoreGradeCritical = 1
+	kubeScoreGradeWarning  = 5
+)
+
+// ValidateLinters checks that at least one linter is given and that each is supported and given once.
+func ValidateLinters(linters []string) error {
+	if len(linters) == 0 {
+		return fmt.Errorf("at least one linter is required\nSupported linters: %s", strings.Join(Linters, ", "))
+	}
+	for i, linter := range linters {
+		if !slices.Contains(Linters, linter) {
+			return fmt.Errorf("unknown linter '%s'\nSupported linters: %s", linter, strings.Join(Linters, ", "))
+		}
+		if slices.Contains(linters[:i], linter) {
+			return fmt.Errorf("linter '%s' is given more than once", linter)
+		}
+	}
+	return nil
+}
+
+// Run runs the linters, in order, on the YAML files under manifestsDir that are not excluded, and
+// returns their findings. Each linter must be installed in PATH. Files are reported relative to
+// manifestsDir. An error means a linter could not be run or its report could not be read.
+func Run(ctx context.Context, linters []string, manifestsDir string, exclude []string) ([]app.LintFinding, error) {
+	files, err := k8s.ManifestFiles(manifestsDir, exclude)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list manifests: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	var findings []app.LintFinding
+	for _, linter := range linters {
+		var found []app.LintFinding
+		switch linter {
+		case LinterKubeconform:
+			found, err = kubeconform(ctx, files)
+		case LinterKubeScore:
+			found, err = kubeScore(ctx, files)
+		default:
+			err = fmt.Errorf("unknown linter '%s'", linter)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i := range found {
+			if rel, relErr := filepath.Rel(manifestsDir, found[i].File); relErr == nil {
+				found[i].File = filepath.ToSlash(rel)
+			}
+		}
+		findings = append(findings, found...)
+	}
+	return findings, nil
+}
+
+// HasErrors reports whether any of the findings is an error.
+func HasErrors(findings []app.LintFinding) bool {
+	return slices.ContainsFunc(findings, func(f app.LintFinding) bool { return f.Severity == SeverityError })
+}
+
+// Count returns the number of errors and warnings among the findings.
+func Count(findings []app.LintFinding) (errors, warnings int) {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	return errors, warnings
+}
+
+// kubeconform validates the files with kubeconform. Resources of kinds it has no schema for, such
+// as custom resources, are skipped. Invalid resources are errors.
+func kubeconform(ctx context.Context, files []string) ([]app.LintFinding, error) {
+	args := append([]string{"-output", "json", "-strict", "-ignore-missing-schemas"}, files...)
+	var report struct {
+		Resources []struct {
+			Filename         string `json:"filename"`
+			Kind             string `json:"kind"`
+			Name             string `json:"name"`
+			Status           string `json:"status"`
+			Msg              string `json:"msg"`
+			ValidationErrors []struct {
+				Path string `json:"path"`
+				Msg  string `json:"msg"`
+			} `json:"validationErrors"`
+		} `json:"resources"`
+	}
+	if err := runLinter(ctx, LinterKubeconform, args, &report); err != nil {
+		return nil, err
+	}
+
+	var findings []app.LintFinding
+	for _, r := range report.Resources {
+		if r.Status != "statusInvalid" && r.Status != "statusError" {
+			continue
+		}
+		finding := app.LintFinding{Linter: LinterKubeconform, Severity: SeverityError, File: r.Filename, Message: r.Msg}
+		if r.Kind != "" {
+			finding.Resource = k8s.ResourceRef{Kind: r.Kind, Name: r.Name}.String()
+		}
+		if len(r.ValidationErrors) == 0 {
+			findings = append(findings, finding)
+			continue
+		}
+		for _, ve := range r.ValidationErrors {
+			finding.Message = ve.Msg
+			if ve.Path != "" {
+				finding.Message = ve.Path + ": " + ve.Msg
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings, nil
+}
+
+// kubeScore scores the files with kube-score. Critical checks are errors and checks with warnings
+// are warnings; skipped and passing checks are not reported.
+func kubeScore(ctx context.Context, files []string) ([]app.LintFinding, error) {
+	args := append([]string{"score", "--output-format", "json"}, files...)
+	var report []struct {
+		FileName string `json:"file_name"`
+		TypeMeta struct {
+			Kind string `json:"kind"`
+		} `json:"type_meta"`
+		ObjectMeta struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"object_meta"`
+		Checks []struct {
+			Check struct {
+				Name string `json:"name"`
+			} `json:"check"`
+			Grade    int  `json:"grade"`
+			Skipped  bool `json:"skipped"`
+			Comments []struct {
+				Path    string `json:"path"`
+				Summary string `json:"summary"`
+			} `json:"comments"`
+		} `json:"checks"`
+	}
+	if err := runLinter(ctx, LinterKubeScore, args, &report); err != nil {
+		return nil, err
+	}
+
+	var findings []app.LintFinding
+	for _, object := range report {
+		resource := k8s.ResourceRef{Kind: object.TypeMeta.Kind, Namespace: object.ObjectMeta.Namespace, Name: object.ObjectMeta.Name}.String()
+		for _, check := range object.Checks {
+			var severity string
+			switch {
+			case check.Skipped:
+				continue
+			case check.Grade == kubeScoreGradeCritical:
+				severity = SeverityError
+			case check.Grade == kubeScoreGradeWarning:
+				severity = SeverityWarning
+			default:
+				continue
+			}
+			finding := app.LintFinding{Linter: LinterKubeScore, Severity: severity, Resource: resource, File: object.FileName, Message: check.Check.Name}
+			if len(check.Comments) == 0 {
+				findings = append(findings, finding)
+				continue
+			}
+			for _, comment := range check.Comments {
+				finding.Message = check.Check.Name + ": " + comment.Summary
+				if comment.Path != "" {
+					finding.Message += " (" + comment.Path + ")"
+				}
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings, nil
+}
+
+// runLinter runs a linter and decodes the JSON report it writes to its standard output into report.
+// Linters exit with an error status when they find problems, so that status is only an error when
+// no report could be decoded.
+func runLinter(ctx context.Context, name string, args []string, report any) error {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return fmt.Errorf("linting with %s requires the '%s' binary in PATH: %w", name, name, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+	if ctx.Err() != nil {
+		return fmt.Errorf("%s did not finish: %w", name, ctx.Err())
+	}
+	if decodeErr := json.Unmarshal(stdout.Bytes(), report); decodeErr != nil {
+		output := strings.TrimSpace(stderr.String())
+		if len(output) > maxOutput {
+			output = "..." + output[len(output)-maxOutput:]
+		}
+		if runErr != nil {
+			return fmt.Errorf("%s failed: %w: %s", name, runErr, output)
+		}
+		return fmt.Errorf("failed to parse %s report: %w", name, decodeErr)
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DigestRule is the rule of a notification that batches several notifications.
+	DigestRule = "Digest"
+	// maxFlushInterval is the longest a channel waits before checking whether held notifications are due.
+	maxFlushInterval = 30 * time.Second
+)
+
+// QuietHours is a daily window during which a channel holds notifications back. Held notifications
+// are delivered as a digest once the window ends.
+type QuietHours struct {
+	// Start is the time of day the window starts, as "HH:MM".
+	Start string `json:"start"`
+	// End is the time of day the window ends, as "HH:MM". A window ending before it starts runs past midnight.
+	End string `json:"end"`
+	// Timezone is the IANA time zone of Start and End (e.g., "Europe/Berlin"). Empty uses the controller's local time.
+	Timezone string `json:"timezone,omitempty"`
+	// Days restricts the window to the days it starts on (e.g., ["Sat", "Sun"]). Empty means every day.
+	Days []string `json:"days,omitempty"`
+
+	start, end time.Duration
+	location   *time.Location
+	days       []time.Weekday
+}
+
+// Validate checks the window and parses its times, time zone and days.
+func (q *QuietHours) Validate() error {
+	var err error
+	if q.start, err = parseTimeOfDay(q.Start); err != nil {
+		return fmt.Errorf("invalid quiet hours start: %w", err)
+	}
+	if q.end, err = parseTimeOfDay(q.End); err != nil {
+		return fmt.Errorf("invalid quiet hours end: %w", err)
+	}
+	if q.start == q.end {
+		return fmt.Errorf("quiet hours start and end must differ")
+	}
+	q.location = time.Local
+	if q.Timezone != "" {
+		if q.location, err = time.LoadLocation(q.Timezone); err != nil {
+			return fmt.Errorf("invalid quiet hours timezone: %w", err)
+		}
+	}
+	q.days = nil
+	for _, day := range q.Days {
+		weekday, ok := weekdays[strings.ToLower(strings.TrimSpace(day))]
+		if !ok {
+			return fmt.Errorf("invalid quiet hours day '%s': use Mon, Tue, Wed, Thu, Fri, Sat or Sun", day)
+		}
+		q.days = append(q.days, weekday)
+	}
+	return nil
+}
+
+// weekdays maps the abbreviated names of the days of the week to their time.Weekday.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Contains reports whether t falls within the window. The window must have been validated.
+func (q *QuietHours) Contains(t time.Time) bool {
+	t = t.In(q.location)
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	day := t.Weekday()
+	switch {
+	case q.start < q.end:
+		if offset < q.start || offset >= q.end {
+			return false
+		}
+	case offset >= q.start:
+	case offset < q.end:
+		day = (day + 6) % 7 // The window started the day before
+	default:
+		return false
+	}
+	return len(q.days) == 0 || slices.Contains(q.days, day)
+}
+
+// parseTimeOfDay parses "HH:MM" into the time since midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a time of day (HH:MM)", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Policy controls when a channel delivers notifications.
+type Policy struct {
+	// Digest batches notifications and delivers them together at most once per interval. Zero
+	// delivers every notification as it happens.
+	Digest time.Duration
+	// Dedup suppresses a firing alert that already fired within this window, along with its
+	// resolution, so an alert flapping with every poll is only sent once per window. An alert
+	// still firing when the window ends is sent again. Zero sends every notification.
+	Dedup time.Duration
+	// QuietHours holds notifications back during a daily window, if set.
+	QuietHours *QuietHours
+}
+
+// suppressed is a firing alert held back by deduplication.
+type suppressed struct {
+	notification Notification
+	repeats      int
+	until        time.Time
+}
+
+// Channel delivers notifications to a destination according to a Policy.
+//
+// Notifications it holds back are delivered by Run, which must be running for a channel with a
+// digest interval, deduplication window or quiet hours.
+type Channel struct {
+	logger   *zap.Logger
+	notifier Notifier
+	policy   Policy
+
+	mu         sync.Mutex
+	pending    []Notification
+	lastFlush  time.Time
+	lastFiring map[string]time.Time
+	suppressed map[string]*suppressed
+}
+
+// NewChannel creates a channel named name that delivers notifications to notifier according to policy.
+func NewChannel(logger *zap.Logger, name string, notifier Notifier, policy Policy) *Channel {
+	return &Channel{
+		logger:     logger.With(zap.String("channel", name)),
+		notifier:   notifier,
+		policy:     policy,
+		lastFlush:  time.Now(),
+		lastFiring: make(map[string]time.Time),
+		suppressed: make(map[string]*suppressed),
+	}
+}
+
+// Notify delivers the notification right away, or holds it back as the channel's policy requires.
+func (c *Channel) Notify(ctx context.Context, n Notification) error {
+	if !c.hold(n, time.Now()) {
+		return c.notifier.Notify(ctx, n)
+	}
+	return nil
+}
+
+// hold queues or suppresses the notification if the policy holds it back at now, and reports whether it did.
+func (c *Channel) hold(n Notification, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := n.Rule + "/" + n.Kind + "/" + n.Name
+	if c.policy.Dedup > 0 {
+		if s, ok := c.suppressed[key]; ok {
+			if n.State == StateResolved {
+				// The alert flapped back; its repeated firing was never sent, so neither is its resolution
+				delete(c.suppressed, key)
+			} else {
+				s.notification = n
+				s.repeats++
+			}
+			c.logger.Debug("Suppressed duplicate notification", zap.String("rule", n.Rule), zap.String("name", n.Name), zap.String("state", string(n.State)))
+			return true
+		}
+		if n.State == StateFiring {
+			if last, ok := c.lastFiring[key]; ok && now.Sub(last) < c.policy.Dedup {
+				c.suppressed[key] = &suppressed{notification: n, repeats: 1, until: last.Add(c.policy.Dedup)}
+				c.logger.Debug("Suppressed duplicate notification", zap.String("rule", n.Rule), zap.String("name", n.Name), zap.String("state", string(n.State)))
+				return true
+			}
+			c.lastFiring[key] = now
+		}
+	}
+
+	if c.policy.Digest == 0 && !c.quiet(now) {
+		return false
+	}
+	c.pending = append(c.pending, n)
+	return true
+}
+
+// quiet reports whether now falls within the channel's quiet hours.
+func (c *Channel) quiet(now time.Time) bool {
+	return c.policy.QuietHours != nil && c.policy.QuietHours.Contains(now)
+}
+
+// Run delivers held notifications once they are due until ctx is cancelled. Notifications still
+// held when it returns are delivered then, unless quiet hours are in effect.
+func (c *Channel) Run(ctx context.Context) {
+	interval := maxFlushInterval
+	if c.policy.Digest > 0 {
+		interval = min(interval, c.policy.Digest)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.flush(time.Now(), true)
+			return
+		case now := <-ticker.C:
+			c.flush(now, false)
+		}
+	}
+}
+
+// flush delivers the notifications that are due at now, as a single digest if there are several.
+// With final, held notifications are due regardless of the digest interval.
+func (c *Channel) flush(now time.Time, final bool) {
+	c.mu.Lock()
+	for key, s := range c.suppressed {
+		if now.Before(s.until) {
+			continue
+		}
+		// The alert is still firing after the deduplication window, so it is sent again
+		delete(c.suppressed, key)
+		n := s.notification
+		n.Message = fmt.Sprintf("%s (fired %d more time(s) since it was last sent)", n.Message, s.repeats)
+		c.pending = append(c.pending, n)
+		c.lastFiring[key] = now
+	}
+	for key, last := range c.lastFiring {
+		if now.Sub(last) >= c.policy.Dedup {
+			delete(c.lastFiring, key)
+		}
+	}
+	due := len(c.pending) > 0 && !c.quiet(now) && (final || now.Sub(c.lastFlush) >= c.policy.Digest)
+	if final && c.quiet(now) && len(c.pending) > 0 {
+		c.logger.Warn("Discarding notifications held back during quiet hours", zap.Int("notifications", len(c.pending)))
+	}
+	var batch []Notification
+	if due {
+		batch, c.pending = c.pending, nil
+		c.lastFlush = now
+	}
+	c.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+	n := batch[0]
+	if len(batch) > 1 {
+		n = Digest(batch, now)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultWebhookTimeout)
+	defer cancel()
+	if err := c.notifier.Notify(ctx, n); err != nil {
+		c.logger.Error("Failed to deliver notifications", zap.Int("notifications", len(batch)), zap.Error(err))
+	}
+}
+
+// Digest batches notifications into one, which is firing if any of them is.
+func Digest(batch []Notification, now time.Time) Notification {
+	var firing int
+	for _, n := range batch {
+		if n.State == StateFiring {
+			firing++
+		}
+	}
+	state := StateResolved
+	if firing > 0 {
+		state = StateFiring
+	}
+	return Notification{
+		Rule:          DigestRule,
+		State:         state,
+		Message:       fmt.Sprintf("%d notification(s): %d firing, %d resolved", len(batch), firing, len(batch)-firing),
+		Time:          now,
+		Notifications: batch,
+	}
+}
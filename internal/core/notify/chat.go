@@ -0,0 +1,166 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+)
+
+const (
+	// colorFiring and colorResolved are the accent colors of chat messages about firing and resolved alerts.
+	colorFiring   = "#c62828"
+	colorResolved = "#2e7d32"
+)
+
+// ObjectURL returns the API URL of the application or cluster a notification is about, or "" if
+// externalURL, the URL the API is reachable at, is not configured.
+func ObjectURL(externalURL string, n Notification) string {
+	if externalURL == "" || n.Name == "" {
+		return ""
+	}
+	collection := "applications"
+	if n.Kind == "Cluster" {
+		collection = "clusters"
+	}
+	return strings.TrimSuffix(externalURL, "/") + "/api/v1/" + collection + "/" + url.PathEscape(n.Name)
+}
+
+// TeamsSettings configure a Microsoft Teams incoming webhook or workflow.
+type TeamsSettings struct {
+	// Webhook is the URL of the incoming webhook or of the workflow's "When a Teams webhook request is received" trigger.
+	Webhook string `json:"webhook"`
+}
+
+// TeamsNotifier posts notifications to Microsoft Teams as Adaptive Cards.
+type TeamsNotifier struct {
+	webhook     *WebhookNotifier
+	externalURL string
+}
+
+// NewTeamsNotifier creates a notifier that posts cards to a Teams webhook, linking to the API at externalURL if it is set.
+func NewTeamsNotifier(settings TeamsSettings, externalURL string) (*TeamsNotifier, error) {
+	if strings.TrimSpace(settings.Webhook) == "" {
+		return nil, fmt.Errorf("webhook is required")
+	}
+	return &TeamsNotifier{webhook: NewWebhookNotifier(settings.Webhook), externalURL: externalURL}, nil
+}
+
+// Notify posts the notification as an Adaptive Card, listing the notifications of a digest.
+func (t *TeamsNotifier) Notify(ctx context.Context, n Notification) error {
+	style := "good"
+	if n.State == StateFiring {
+		style = "attention"
+	}
+	body := []map[string]any{
+		{"type": "TextBlock", "text": Title(n), "size": "Large", "weight": "Bolder", "color": style, "wrap": true},
+		{"type": "TextBlock", "text": n.Message, "wrap": true},
+	}
+	var actions []map[string]any
+	if n.Rule == DigestRule {
+		for _, batched := range n.Notifications {
+			text := fmt.Sprintf("**[%s] %s** %s", batched.State, Title(batched), batched.Message)
+			if link := ObjectURL(t.externalURL, batched); link != "" {
+				text += fmt.Sprintf(" [details](%s)", link)
+			}
+			body = append(body, map[string]any{"type": "TextBlock", "text": text, "wrap": true, "separator": true})
+		}
+	} else {
+		body = append(body, map[string]any{"type": "FactSet", "facts": []map[string]string{
+			{"title": "State", "value": string(n.State)},
+			{"title": "Rule", "value": n.Rule},
+			{"title": n.Kind, "value": n.Name},
+			{"title": "Status", "value": common.DefaultIfEmpty(n.Status, "-")},
+			{"title": "Time", "value": n.Time.Format(time.RFC1123)},
+		}})
+		if link := ObjectURL(t.externalURL, n); link != "" {
+			actions = append(actions, map[string]any{"type": "Action.OpenUrl", "title": "View " + strings.ToLower(n.Kind), "url": link})
+		}
+	}
+
+	card := map[string]any{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if len(actions) > 0 {
+		card["actions"] = actions
+	}
+	return t.webhook.post(ctx, map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{
+			{"contentType": "application/vnd.microsoft.card.adaptive", "content": card},
+		},
+	})
+}
+
+// MattermostSettings configure a Mattermost incoming webhook.
+type MattermostSettings struct {
+	// Webhook is the URL of the incoming webhook.
+	Webhook string `json:"webhook"`
+	// Channel overrides the webhook's channel, if the webhook allows it.
+	Channel string `json:"channel,omitempty"`
+	// Username overrides the name messages are posted as, if the webhook allows it.
+	Username string `json:"username,omitempty"`
+}
+
+// MattermostNotifier posts notifications to Mattermost as message attachments.
+type MattermostNotifier struct {
+	settings    MattermostSettings
+	webhook     *WebhookNotifier
+	externalURL string
+}
+
+// NewMattermostNotifier creates a notifier that posts to a Mattermost webhook, linking to the API at externalURL if it is set.
+func NewMattermostNotifier(settings MattermostSettings, externalURL string) (*MattermostNotifier, error) {
+	if strings.TrimSpace(settings.Webhook) == "" {
+		return nil, fmt.Errorf("webhook is required")
+	}
+	return &MattermostNotifier{settings: settings, webhook: NewWebhookNotifier(settings.Webhook), externalURL: externalURL}, nil
+}
+
+// Notify posts the notification as a message attachment, or one attachment per notification of a digest.
+func (m *MattermostNotifier) Notify(ctx context.Context, n Notification) error {
+	batch := []Notification{n}
+	text := ""
+	if n.Rule == DigestRule {
+		batch = n.Notifications
+		text = "**" + Title(n) + "**: " + n.Message
+	}
+	attachments := make([]map[string]any, 0, len(batch))
+	for _, b := range batch {
+		color := colorResolved
+		if b.State == StateFiring {
+			color = colorFiring
+		}
+		attachment := map[string]any{
+			"fallback": Title(b) + ": " + b.Message,
+			"color":    color,
+			"title":    Title(b),
+			"text":     b.Message,
+			"fields": []map[string]any{
+				{"short": true, "title": "State", "value": string(b.State)},
+				{"short": true, "title": "Rule", "value": b.Rule},
+				{"short": true, "title": b.Kind, "value": b.Name},
+				{"short": true, "title": "Status", "value": common.DefaultIfEmpty(b.Status, "-")},
+			},
+		}
+		if link := ObjectURL(m.externalURL, b); link != "" {
+			attachment["title_link"] = link
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	payload := map[string]any{"text": text, "attachments": attachments}
+	if m.settings.Channel != "" {
+		payload["channel"] = m.settings.Channel
+	}
+	if m.settings.Username != "" {
+		payload["username"] = m.settings.Username
+	}
+	return m.webhook.post(ctx, payload)
+}
@@ -0,0 +1,327 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+)
+
+// DefaultSMTPPort is the SMTP submission port used when none is configured.
+const DefaultSMTPPort = 587
+
+// SMTPSettings configure how email notifications are sent. Fields left empty in a channel's
+// settings are taken from the global settings (see Merge).
+type SMTPSettings struct {
+	// Host is the SMTP server's host name.
+	Host string `json:"host,omitempty"`
+	// Port is the SMTP server's port. Zero uses DefaultSMTPPort.
+	Port int `json:"port,omitempty"`
+	// Username authenticates with the server using PLAIN authentication. Empty sends without authenticating.
+	Username string `json:"username,omitempty"`
+	// PasswordEnv names the environment variable holding the password, so it is not stored in the configuration.
+	PasswordEnv string `json:"passwordEnv,omitempty"`
+	// TLS connects with implicit TLS (usually port 465) instead of upgrading the connection with STARTTLS.
+	TLS bool `json:"tls,omitempty"`
+	// From is the sender address.
+	From string `json:"from,omitempty"`
+	// To are the recipient addresses.
+	To []string `json:"to,omitempty"`
+	// SubjectTemplate, TextTemplate and HTMLTemplate override the default templates of the subject, the
+	// plain text body and the HTML body. They are Go templates executed with an EmailData.
+	SubjectTemplate string `json:"subjectTemplate,omitempty"`
+	TextTemplate    string `json:"textTemplate,omitempty"`
+	HTMLTemplate    string `json:"htmlTemplate,omitempty"`
+}
+
+// Merge returns the settings with every empty field taken from defaults.
+func (s SMTPSettings) Merge(defaults SMTPSettings) SMTPSettings {
+	merged := SMTPSettings{
+		Host:            common.DefaultIfEmpty(s.Host, defaults.Host),
+		Port:            s.Port,
+		Username:        common.DefaultIfEmpty(s.Username, defaults.Username),
+		PasswordEnv:     common.DefaultIfEmpty(s.PasswordEnv, defaults.PasswordEnv),
+		TLS:             s.TLS || defaults.TLS,
+		From:            common.DefaultIfEmpty(s.From, defaults.From),
+		To:              s.To,
+		SubjectTemplate: common.DefaultIfEmpty(s.SubjectTemplate, defaults.SubjectTemplate),
+		TextTemplate:    common.DefaultIfEmpty(s.TextTemplate, defaults.TextTemplate),
+		HTMLTemplate:    common.DefaultIfEmpty(s.HTMLTemplate, defaults.HTMLTemplate),
+	}
+	if merged.Port == 0 {
+		merged.Port = defaults.Port
+	}
+	if len(merged.To) == 0 {
+		merged.To = defaults.To
+	}
+	return merged
+}
+
+// EmailData is what email templates are executed with.
+type EmailData struct {
+	// Notification is the notification being sent. For a digest, Notification.Notifications lists the batched ones.
+	Notification
+	// Title describes the notification in a few words, e.g. "Sync failure: payments" or "Cluster outage: prod".
+	Title string
+}
+
+const (
+	defaultSubjectTemplate = `[gitopsctl] {{if eq .State "resolved"}}Resolved: {{end}}{{.Title}}`
+
+	defaultTextTemplate = `{{.Title}}
+{{if .Notifications}}{{.Message}}
+{{range .Notifications}}
+- [{{.State}}] {{.Kind}} {{.Name}} ({{.Rule}}) at {{.Time.Format "2006-01-02 15:04:05 MST"}}
+  {{.Message}}
+{{end}}{{else}}
+State:   {{.State}}
+Rule:    {{.Rule}}
+Object:  {{.Kind}} {{.Name}}
+Time:    {{.Time.Format "2006-01-02 15:04:05 MST"}}
+
+{{.Message}}
+{{end}}`
+
+	defaultHTMLTemplate = `<!DOCTYPE html>
+<html><body style="font-family: sans-serif">
+<h2 style="color: {{if eq .State "firing"}}#c62828{{else}}#2e7d32{{end}}">{{.Title}}</h2>
+{{if .Notifications}}<p>{{.Message}}</p>
+<table cellpadding="6" style="border-collapse: collapse">
+<tr><th align="left">State</th><th align="left">Object</th><th align="left">Rule</th><th align="left">Time</th><th align="left">Message</th></tr>
+{{range .Notifications}}<tr><td>{{.State}}</td><td>{{.Kind}} {{.Name}}</td><td>{{.Rule}}</td><td>{{.Time.Format "2006-01-02 15:04:05 MST"}}</td><td>{{.Message}}</td></tr>
+{{end}}</table>
+{{else}}<table cellpadding="4">
+<tr><td><b>State</b></td><td>{{.State}}</td></tr>
+<tr><td><b>Rule</b></td><td>{{.Rule}}</td></tr>
+<tr><td><b>Object</b></td><td>{{.Kind}} {{.Name}}</td></tr>
+<tr><td><b>Time</b></td><td>{{.Time.Format "2006-01-02 15:04:05 MST"}}</td></tr>
+</table>
+<p>{{.Message}}</p>
+{{end}}</body></html>
+`
+)
+
+// EmailNotifier sends notifications as emails with a plain text and an HTML part.
+type EmailNotifier struct {
+	settings SMTPSettings
+	from     string   // Envelope sender address
+	to       []string // Envelope recipient addresses
+	subject  *texttemplate.Template
+	text     *texttemplate.Template
+	html     *htmltemplate.Template
+}
+
+// NewEmailNotifier creates a notifier that emails notifications as configured by settings.
+// It returns an error if the settings are incomplete or a template does not parse.
+func NewEmailNotifier(settings SMTPSettings) (*EmailNotifier, error) {
+	if settings.Host == "" {
+		return nil, fmt.Errorf("SMTP host is required")
+	}
+	if settings.From == "" {
+		return nil, fmt.Errorf("sender address (from) is required")
+	}
+	if len(settings.To) == 0 {
+		return nil, fmt.Errorf("at least one recipient address (to) is required")
+	}
+	if settings.Port == 0 {
+		settings.Port = DefaultSMTPPort
+	}
+	if settings.PasswordEnv != "" && settings.Username == "" {
+		return nil, fmt.Errorf("passwordEnv requires a username")
+	}
+
+	e := &EmailNotifier{settings: settings}
+	from, err := mail.ParseAddress(settings.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address '%s': %w", settings.From, err)
+	}
+	e.from = from.Address
+	for _, recipient := range settings.To {
+		to, err := mail.ParseAddress(recipient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient address '%s': %w", recipient, err)
+		}
+		e.to = append(e.to, to.Address)
+	}
+	if e.subject, err = texttemplate.New("subject").Parse(common.DefaultIfEmpty(settings.SubjectTemplate, defaultSubjectTemplate)); err != nil {
+		return nil, fmt.Errorf("invalid subject template: %w", err)
+	}
+	if e.text, err = texttemplate.New("text").Parse(common.DefaultIfEmpty(settings.TextTemplate, defaultTextTemplate)); err != nil {
+		return nil, fmt.Errorf("invalid text template: %w", err)
+	}
+	if e.html, err = htmltemplate.New("html").Parse(common.DefaultIfEmpty(settings.HTMLTemplate, defaultHTMLTemplate)); err != nil {
+		return nil, fmt.Errorf("invalid HTML template: %w", err)
+	}
+	return e, nil
+}
+
+// Notify renders the notification and sends it to the configured recipients.
+func (e *EmailNotifier) Notify(ctx context.Context, n Notification) error {
+	message, err := e.render(n)
+	if err != nil {
+		return err
+	}
+	if err := e.send(ctx, message); err != nil {
+		return fmt.Errorf("failed to send email through %s: %w", e.settings.Host, err)
+	}
+	return nil
+}
+
+// render builds the MIME message of a notification.
+func (e *EmailNotifier) render(n Notification) ([]byte, error) {
+	data := EmailData{Notification: n, Title: Title(n)}
+	var subject, text, html bytes.Buffer
+	if err := e.subject.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("failed to render email subject: %w", err)
+	}
+	if err := e.text.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render email text: %w", err)
+	}
+	if err := e.html.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("failed to render email HTML: %w", err)
+	}
+
+	var msg bytes.Buffer
+	body := multipart.NewWriter(&msg)
+	headers := []string{
+		"From: " + e.settings.From,
+		"To: " + strings.Join(e.settings.To, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", strings.TrimSpace(strings.ReplaceAll(subject.String(), "\n", " "))),
+		"Date: " + n.Time.Format(time.RFC1123Z),
+		"Message-ID: " + messageID(e.from),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + body.Boundary(),
+	}
+	msg.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", text.String()},
+		{"text/html; charset=utf-8", html.String()},
+	} {
+		w, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+// send delivers a message to the configured recipients.
+func (e *EmailNotifier) send(ctx context.Context, message []byte) error {
+	address := net.JoinHostPort(e.settings.Host, strconv.Itoa(e.settings.Port))
+	tlsConfig := &tls.Config{ServerName: e.settings.Host}
+
+	var conn net.Conn
+	var err error
+	if e.settings.TLS {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	client, err := smtp.NewClient(conn, e.settings.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && !e.settings.TLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+	if e.settings.Username != "" {
+		auth := smtp.PlainAuth("", e.settings.Username, os.Getenv(e.settings.PasswordEnv), e.settings.Host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(e.from); err != nil {
+		return err
+	}
+	for _, to := range e.to {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// Title describes a notification in a few words, naming what went wrong and where.
+func Title(n Notification) string {
+	if n.Rule == DigestRule {
+		return fmt.Sprintf("%d notifications", len(n.Notifications))
+	}
+	switch n.Kind + "/" + n.Status {
+	case "Application/Error", "Application/InvalidManifests":
+		return "Sync failure: " + n.Name
+	case "Application/Suspended":
+		return "Application suspended: " + n.Name
+	case "Application/RolledBack":
+		return "Rolled back: " + n.Name
+	case "Cluster/Unreachable", "Cluster/Error":
+		return "Cluster outage: " + n.Name
+	case "Cluster/CredentialsExpired":
+		return "Cluster credentials expired: " + n.Name
+	}
+	if n.Status == "" {
+		return n.Kind + " " + n.Name
+	}
+	return n.Kind + " " + n.Name + " is " + n.Status
+}
+
+// messageID returns a unique Message-ID header value in the sender's domain.
+func messageID(from string) string {
+	domain := "gitopsctl"
+	if i := strings.LastIndex(from, "@"); i >= 0 {
+		domain = from[i+1:]
+	}
+	b := make([]byte, 12)
+	rand.Read(b)
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
@@ -0,0 +1,217 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+)
+
+const (
+	// DefaultPagerDutyURL is the PagerDuty Events API v2 endpoint.
+	DefaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+	// DefaultOpsgenieURL is the Opsgenie Alert API endpoint in the US region.
+	DefaultOpsgenieURL = "https://api.opsgenie.com"
+	// OpsgenieEUURL is the Opsgenie Alert API endpoint in the EU region.
+	OpsgenieEUURL = "https://api.eu.opsgenie.com"
+
+	// incidentSource is the source reported to incident management tools.
+	incidentSource = "gitopsctl"
+	// maxPagerDutySummary is the maximum length of a PagerDuty incident summary.
+	maxPagerDutySummary = 1024
+	// maxOpsgenieMessage is the maximum length of an Opsgenie alert message.
+	maxOpsgenieMessage = 130
+)
+
+// pagerDutySeverities are the severities PagerDuty accepts.
+var pagerDutySeverities = []string{"critical", "error", "warning", "info"}
+
+// opsgeniePriorities are the priorities Opsgenie accepts.
+var opsgeniePriorities = []string{"P1", "P2", "P3", "P4", "P5"}
+
+// IncidentKey returns the key identifying the incident of an alert, the same for every notification of the
+// rule about the same application or cluster, so a firing alert opens one incident and its resolution closes it.
+func IncidentKey(n Notification) string {
+	return incidentSource + "/" + n.Rule + "/" + n.Kind + "/" + n.Name
+}
+
+// PagerDutySettings configure a PagerDuty Events API v2 integration.
+type PagerDutySettings struct {
+	// RoutingKeyEnv names the environment variable holding the integration's routing key.
+	RoutingKeyEnv string `json:"routingKeyEnv"`
+	// Severity is the severity of triggered incidents: critical, error, warning or info. Defaults to error.
+	Severity string `json:"severity,omitempty"`
+	// URL overrides the Events API endpoint, e.g. to go through a proxy.
+	URL string `json:"url,omitempty"`
+}
+
+// OpsgenieSettings configure an Opsgenie Alert API integration.
+type OpsgenieSettings struct {
+	// APIKeyEnv names the environment variable holding the API integration's key.
+	APIKeyEnv string `json:"apiKeyEnv"`
+	// Priority is the priority of created alerts, P1 to P5. Defaults to P3.
+	Priority string `json:"priority,omitempty"`
+	// Region is "us" (default) or "eu".
+	Region string `json:"region,omitempty"`
+	// Tags are added to every created alert.
+	Tags []string `json:"tags,omitempty"`
+	// URL overrides the Alert API endpoint, taking precedence over Region.
+	URL string `json:"url,omitempty"`
+}
+
+// PagerDutyNotifier triggers a PagerDuty incident when an alert fires and resolves it when the alert resolves.
+type PagerDutyNotifier struct {
+	settings PagerDutySettings
+	client   *http.Client
+}
+
+// NewPagerDutyNotifier creates a notifier that opens and resolves PagerDuty incidents.
+func NewPagerDutyNotifier(settings PagerDutySettings) (*PagerDutyNotifier, error) {
+	if settings.RoutingKeyEnv == "" {
+		return nil, fmt.Errorf("routingKeyEnv is required")
+	}
+	settings.Severity = common.DefaultIfEmpty(strings.ToLower(settings.Severity), "error")
+	if !slices.Contains(pagerDutySeverities, settings.Severity) {
+		return nil, fmt.Errorf("invalid severity '%s': must be one of %s", settings.Severity, strings.Join(pagerDutySeverities, ", "))
+	}
+	settings.URL = common.DefaultIfEmpty(settings.URL, DefaultPagerDutyURL)
+	return &PagerDutyNotifier{settings: settings, client: &http.Client{Timeout: DefaultWebhookTimeout}}, nil
+}
+
+// Notify sends a trigger event for a firing alert and a resolve event for a resolved one. The
+// notifications of a digest are sent one by one, since incidents cannot be batched.
+func (p *PagerDutyNotifier) Notify(ctx context.Context, n Notification) error {
+	if n.Rule == DigestRule {
+		return notifyEach(ctx, p, n)
+	}
+	routingKey := os.Getenv(p.settings.RoutingKeyEnv)
+	if routingKey == "" {
+		return fmt.Errorf("PagerDuty routing key is not set: environment variable %s is empty", p.settings.RoutingKeyEnv)
+	}
+
+	event := map[string]any{
+		"routing_key":  routingKey,
+		"event_action": "resolve",
+		"dedup_key":    IncidentKey(n),
+	}
+	if n.State == StateFiring {
+		event["event_action"] = "trigger"
+		event["payload"] = map[string]any{
+			"summary":   common.TruncateString(Title(n)+": "+n.Message, maxPagerDutySummary),
+			"source":    n.Name,
+			"severity":  p.settings.Severity,
+			"timestamp": n.Time,
+			"component": n.Name,
+			"group":     n.Kind,
+			"class":     n.Rule,
+			"custom_details": map[string]string{
+				"rule": n.Rule, "kind": n.Kind, "name": n.Name, "status": n.Status, "message": n.Message,
+			},
+		}
+	}
+	return postIncidentEvent(ctx, p.client, p.settings.URL, nil, event)
+}
+
+// OpsgenieNotifier creates an Opsgenie alert when an alert fires and closes it when the alert resolves.
+type OpsgenieNotifier struct {
+	settings OpsgenieSettings
+	client   *http.Client
+}
+
+// NewOpsgenieNotifier creates a notifier that creates and closes Opsgenie alerts.
+func NewOpsgenieNotifier(settings OpsgenieSettings) (*OpsgenieNotifier, error) {
+	if settings.APIKeyEnv == "" {
+		return nil, fmt.Errorf("apiKeyEnv is required")
+	}
+	settings.Priority = common.DefaultIfEmpty(strings.ToUpper(settings.Priority), "P3")
+	if !slices.Contains(opsgeniePriorities, settings.Priority) {
+		return nil, fmt.Errorf("invalid priority '%s': must be one of %s", settings.Priority, strings.Join(opsgeniePriorities, ", "))
+	}
+	switch strings.ToLower(settings.Region) {
+	case "", "us":
+		settings.URL = common.DefaultIfEmpty(settings.URL, DefaultOpsgenieURL)
+	case "eu":
+		settings.URL = common.DefaultIfEmpty(settings.URL, OpsgenieEUURL)
+	default:
+		return nil, fmt.Errorf("invalid region '%s': must be us or eu", settings.Region)
+	}
+	settings.URL = strings.TrimSuffix(settings.URL, "/")
+	return &OpsgenieNotifier{settings: settings, client: &http.Client{Timeout: DefaultWebhookTimeout}}, nil
+}
+
+// Notify creates an alert for a firing alert and closes it for a resolved one. The notifications
+// of a digest are sent one by one, since alerts cannot be batched.
+func (o *OpsgenieNotifier) Notify(ctx context.Context, n Notification) error {
+	if n.Rule == DigestRule {
+		return notifyEach(ctx, o, n)
+	}
+	apiKey := os.Getenv(o.settings.APIKeyEnv)
+	if apiKey == "" {
+		return fmt.Errorf("Opsgenie API key is not set: environment variable %s is empty", o.settings.APIKeyEnv)
+	}
+	headers := map[string]string{"Authorization": "GenieKey " + apiKey}
+
+	alias := IncidentKey(n)
+	if n.State == StateResolved {
+		endpoint := o.settings.URL + "/v2/alerts/" + url.PathEscape(alias) + "/close?identifierType=alias"
+		return postIncidentEvent(ctx, o.client, endpoint, headers, map[string]any{"source": incidentSource, "note": n.Message})
+	}
+
+	alert := map[string]any{
+		"message":     common.TruncateString(Title(n), maxOpsgenieMessage),
+		"alias":       alias,
+		"description": n.Message,
+		"priority":    o.settings.Priority,
+		"source":      incidentSource,
+		"entity":      n.Kind + "/" + n.Name,
+		"tags":        append([]string{n.Rule}, o.settings.Tags...),
+		"details":     map[string]string{"rule": n.Rule, "kind": n.Kind, "name": n.Name, "status": n.Status},
+	}
+	return postIncidentEvent(ctx, o.client, o.settings.URL+"/v2/alerts", headers, alert)
+}
+
+// notifyEach delivers the notifications batched into a digest one by one, returning the combined errors.
+func notifyEach(ctx context.Context, notifier Notifier, digest Notification) error {
+	var errs []error
+	for _, n := range digest.Notifications {
+		if err := notifier.Notify(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// postIncidentEvent posts an event as JSON to an incident management API.
+// Any non-2xx response is reported as an error.
+func postIncidentEvent(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, event any) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal incident event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create incident request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send incident event to %s: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("incident API %s returned status %d", req.URL.Host, resp.StatusCode)
+	}
+	return nil
+}
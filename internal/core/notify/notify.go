@@ -0,0 +1,136 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultWebhookTimeout is the timeout for delivering a single webhook notification.
+const DefaultWebhookTimeout = 10 * time.Second
+
+// State is the state of the alert a notification reports on.
+type State string
+
+const (
+	// StateFiring indicates that an alerting rule has started firing.
+	StateFiring State = "firing"
+	// StateResolved indicates that a previously firing alerting rule has resolved.
+	StateResolved State = "resolved"
+)
+
+// Notification is a message sent when an alerting rule fires or resolves.
+type Notification struct {
+	// Rule is the name of the alerting rule.
+	Rule string `json:"rule"`
+	// State is whether the alert is firing or resolved.
+	State State `json:"state"`
+	// Kind is the kind of the object the alert is about (e.g., "Application", "Cluster").
+	Kind string `json:"kind"`
+	// Name is the name of the object the alert is about.
+	Name string `json:"name"`
+	// Status is the object status the alert is about (e.g., "Error", "Unreachable").
+	Status string `json:"status,omitempty"`
+	// Message is a human-readable description of the alert.
+	Message string `json:"message"`
+	// Time is when the alert changed state.
+	Time time.Time `json:"time"`
+	// Notifications are the notifications batched into a digest, whose Rule is DigestRule.
+	Notifications []Notification `json:"notifications,omitempty"`
+}
+
+// Notifier delivers notifications to a destination.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// LogNotifier writes notifications to the logger.
+type LogNotifier struct {
+	logger *zap.Logger
+}
+
+// NewLogNotifier creates a notifier that logs notifications.
+func NewLogNotifier(logger *zap.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// Notify logs the notification, as a warning while the alert is firing.
+func (l *LogNotifier) Notify(_ context.Context, n Notification) error {
+	fields := []zap.Field{
+		zap.String("rule", n.Rule),
+		zap.String("kind", n.Kind),
+		zap.String("name", n.Name),
+		zap.String("message", n.Message),
+	}
+	if n.State == StateFiring {
+		l.logger.Warn("Alert firing", fields...)
+	} else {
+		l.logger.Info("Alert resolved", fields...)
+	}
+	return nil
+}
+
+// WebhookNotifier posts notifications as JSON to an HTTP endpoint.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a notifier that posts notifications to the given URL.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: DefaultWebhookTimeout},
+	}
+}
+
+// Notify posts the notification to the webhook URL.
+// Any non-2xx response is reported as an error.
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	return w.post(ctx, n)
+}
+
+// post posts the payload as JSON to the webhook URL.
+func (w *WebhookNotifier) post(ctx context.Context, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook to %s: %w", w.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned status %d", w.url, resp.StatusCode)
+	}
+	return nil
+}
+
+// Multi delivers each notification to every notifier it holds.
+type Multi []Notifier
+
+// Notify delivers the notification to all notifiers, returning the combined errors.
+func (m Multi) Notify(ctx context.Context, n Notification) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
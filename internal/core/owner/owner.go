@@ -0,0 +1,117 @@
+package owner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// SourceLabels means the owner was read from the tracking labels and annotations on the live resource.
+	SourceLabels = "labels"
+	// SourceInventory means the owner was found in the managed resources recorded for an application,
+	// because the live resource carries no tracking labels (e.g., it was applied by an older version).
+	SourceInventory = "inventory"
+)
+
+// ErrNotFound is returned by Find when the resource does not exist in the cluster.
+var ErrNotFound = errors.New("resource not found")
+
+// Query identifies the live resource whose owner is looked up.
+type Query struct {
+	// Cluster is the name of the registered cluster the resource lives in.
+	Cluster string
+	// Kind is the resource type, in any form kubectl accepts (e.g., "Deployment" or "deployments.apps").
+	Kind string
+	// Namespace is the namespace of the resource; empty defaults to "default" for namespaced resources.
+	Namespace string
+	// Name is the name of the resource.
+	Name string
+}
+
+// Result describes the application that manages a live resource.
+type Result struct {
+	// Cluster is the name of the cluster the resource lives in.
+	Cluster string `json:"cluster"`
+	// Kind is the resource's kind as reported by the cluster.
+	Kind string `json:"kind"`
+	// Namespace is the resource's namespace, empty for cluster-scoped resources.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+	// Managed reports whether an application manages the resource.
+	Managed bool `json:"managed"`
+	// App is the name of the managing application.
+	App string `json:"app,omitempty"`
+	// Registered reports whether the managing application is still registered for the cluster.
+	// An unregistered owner means the resource was left behind and can be pruned.
+	Registered bool `json:"registered"`
+	// Revision is the Git commit the resource was last applied from, if recorded.
+	Revision string `json:"revision,omitempty"`
+	// Controller is the controller instance that last applied the resource, if recorded.
+	Controller string `json:"controller,omitempty"`
+	// Source is how the owner was found: SourceLabels or SourceInventory.
+	Source string `json:"source,omitempty"`
+}
+
+// Find resolves the application that manages a live resource.
+//
+// The resource is fetched from the cluster and its tracking labels (see k8s.TrackingFor) are used
+// when present. Otherwise the managed resources recorded for the applications deployed to the
+// cluster are searched. The caller must hold at least a read lock on apps.
+func Find(ctx context.Context, client *k8s.ClientSet, apps *app.Applications, q Query) (*Result, error) {
+	obj, err := client.GetResource(ctx, q.Kind, q.Namespace, q.Name)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %s '%s' in cluster '%s'", ErrNotFound, q.Kind, q.Name, q.Cluster)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Cluster:   q.Cluster,
+		Kind:      obj.GetKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+
+	if tracking, ok := k8s.TrackingFor(obj); ok {
+		result.Managed = true
+		result.App = tracking.App
+		result.Revision = tracking.Revision
+		result.Controller = tracking.Controller
+		result.Source = SourceLabels
+		if a, ok := apps.Get(tracking.App); ok && a.ClusterName == q.Cluster {
+			result.Registered = true
+		}
+		return result, nil
+	}
+
+	for _, a := range apps.List() {
+		if a.ClusterName != q.Cluster || !manages(a, result) {
+			continue
+		}
+		result.Managed = true
+		result.Registered = true
+		result.App = a.Name
+		result.Revision = a.LastSyncedGitHash
+		result.Source = SourceInventory
+		break
+	}
+	return result, nil
+}
+
+// manages reports whether the resource is among the application's managed resources.
+func manages(a *app.Application, r *Result) bool {
+	for _, m := range a.ManagedResources {
+		if strings.EqualFold(m.Kind, r.Kind) && m.Namespace == r.Namespace && m.Name == r.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package progressive
+
+import (
+	"fmt"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+	"aeswibon.com/github/gitopsctl/internal/core/k8s"
+)
+
+// Rollouts returns the Argo Rollouts Rollouts and Flagger Canaries among an application's managed resources.
+func Rollouts(a *app.Application) []k8s.ResourceRef {
+	var refs []k8s.ResourceRef
+	for _, m := range a.ManagedResources {
+		if k8s.RolloutProvider(m.Kind) != "" {
+			refs = append(refs, k8s.ResourceRef{Kind: m.Kind, Namespace: m.Namespace, Name: m.Name})
+		}
+	}
+	return refs
+}
+
+// Find returns the application's rollout named "namespace/name" or "name".
+// An empty name selects the application's only rollout.
+func Find(a *app.Application, name string) (k8s.ResourceRef, error) {
+	refs := Rollouts(a)
+	if len(refs) == 0 {
+		return k8s.ResourceRef{}, fmt.Errorf("application '%s' manages no Argo Rollouts Rollout or Flagger Canary", a.Name)
+	}
+	if name == "" {
+		if len(refs) > 1 {
+			return k8s.ResourceRef{}, fmt.Errorf("application '%s' manages %d rollouts; name one of: %s", a.Name, len(refs), names(refs))
+		}
+		return refs[0], nil
+	}
+
+	namespace, resourceName, qualified := strings.Cut(name, "/")
+	if !qualified {
+		resourceName = namespace
+	}
+	var matches []k8s.ResourceRef
+	for _, ref := range refs {
+		if ref.Name == resourceName && (!qualified || ref.Namespace == namespace) {
+			matches = append(matches, ref)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return k8s.ResourceRef{}, fmt.Errorf("application '%s' manages no rollout named '%s'; its rollouts are: %s", a.Name, name, names(refs))
+	case 1:
+		return matches[0], nil
+	}
+	return k8s.ResourceRef{}, fmt.Errorf("rollout name '%s' is ambiguous; name it as namespace/name: %s", name, names(matches))
+}
+
+// names lists rollouts as "namespace/name".
+func names(refs []k8s.ResourceRef) string {
+	list := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		list = append(list, ref.Namespace+"/"+ref.Name)
+	}
+	return strings.Join(list, ", ")
+}
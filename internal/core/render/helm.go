@@ -0,0 +1,286 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultHelmNamespace is the release namespace used when none is configured, as by helm template.
+const DefaultHelmNamespace = "default"
+
+// HelmOptions configures how the helm renderer renders a chart.
+type HelmOptions struct {
+	// ReleaseName is the release name the chart is rendered with.
+	ReleaseName string
+	// Namespace is the release namespace the chart is rendered with, empty for "default".
+	Namespace string
+	// ValuesFiles are values files, relative to the chart directory, applied in order.
+	// They may refer to files outside the chart, but not outside the repository, or to the files
+	// of one of the request's sources as "$name/<path>".
+	ValuesFiles []string
+	// Values are inline values applied after the values files, taking precedence over them.
+	Values map[string]any
+}
+
+// envReference matches ${NAME} and ${NAME:-default} references in values, and the $${ escape.
+var envReference = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// ValidateValuesFiles checks that values file paths are relative to the chart directory.
+func ValidateValuesFiles(files []string) error {
+	for _, file := range files {
+		if strings.TrimSpace(file) == "" {
+			return fmt.Errorf("values file path cannot be empty")
+		}
+		if filepath.IsAbs(file) {
+			return fmt.Errorf("values file '%s' must be relative to the chart directory", file)
+		}
+	}
+	return nil
+}
+
+// ValidateValuesFileSources checks that the values files referring to an additional source name
+// one of sources.
+func ValidateValuesFileSources(files, sources []string) error {
+	for _, file := range files {
+		if name, rel, ok := ValuesFileSource(file); ok {
+			if !slices.Contains(sources, name) {
+				return fmt.Errorf("values file '%s' refers to unknown source '%s'", file, name)
+			}
+			if !filepath.IsLocal(rel) {
+				return fmt.Errorf("values file '%s' must be a path inside source '%s'", file, name)
+			}
+		}
+	}
+	return nil
+}
+
+// ValuesFileSource splits a values file referring to an additional source, "$name/<path>", into the
+// source's name and the path within it. It reports false for values files relative to the chart.
+func ValuesFileSource(file string) (string, string, bool) {
+	if !strings.HasPrefix(file, "$") {
+		return "", "", false
+	}
+	name, rel, _ := strings.Cut(strings.TrimPrefix(file, "$"), "/")
+	return name, rel, true
+}
+
+// ParseHelmSet turns key=value assignments into nested inline values, like helm's --set.
+//
+// Dots in the key separate nested keys, so "image.tag=v2" sets {image: {tag: v2}}. Values are
+// parsed as YAML, so numbers and booleans keep their type; quote them to keep them as strings.
+func ParseHelmSet(assignments []string) (map[string]any, error) {
+	values := make(map[string]any)
+	for _, assignment := range assignments {
+		key, raw, ok := strings.Cut(assignment, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid value '%s', expected key=value", assignment)
+		}
+		var value any
+		if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, fmt.Errorf("invalid value for '%s': %w", key, err)
+		}
+
+		parts := strings.Split(key, ".")
+		node := values
+		for _, part := range parts[:len(parts)-1] {
+			if part == "" {
+				return nil, fmt.Errorf("invalid key '%s'", key)
+			}
+			child, ok := node[part].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				node[part] = child
+			}
+			node = child
+		}
+		if parts[len(parts)-1] == "" {
+			return nil, fmt.Errorf("invalid key '%s'", key)
+		}
+		node[parts[len(parts)-1]] = value
+	}
+	return values, nil
+}
+
+// ValuesDigest returns a digest of the values and release namespace the request's chart is rendered
+// with, after environment variable substitution, or an empty string if its renderer does not take values.
+//
+// The controller records the digest of each successful sync, so a change to the values, or to the
+// environment variables they refer to, triggers a sync even if the repository has not changed.
+func ValuesDigest(req Request) (string, error) {
+	if req.Renderer != RendererHelm {
+		return "", nil
+	}
+	documents, err := resolveValues(req)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	fmt.Fprintf(hash, "namespace=%s\n", req.Helm.Namespace)
+	for _, doc := range documents {
+		fmt.Fprintf(hash, "%d\n", len(doc))
+		hash.Write(doc)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// ChartMetadata returns the metadata of the chart in sourceDir, as read from its Chart.yaml.
+func ChartMetadata(sourceDir string) (map[string]any, error) {
+	data, err := os.ReadFile(filepath.Join(sourceDir, "Chart.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Chart.yaml: %w", err)
+	}
+	var metadata map[string]any
+	if err := yaml.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse Chart.yaml: %w", err)
+	}
+	return metadata, nil
+}
+
+// HelmValues returns the values the request's chart is rendered with, merged the way helm merges
+// its values files and inline values, after environment variable substitution.
+func HelmValues(req Request) (map[string]any, error) {
+	documents, err := resolveValues(req)
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[string]any)
+	for _, doc := range documents {
+		var values map[string]any
+		if err := yaml.Unmarshal(doc, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse values: %w", err)
+		}
+		MergeValues(merged, values)
+	}
+	return merged, nil
+}
+
+// MergeValues merges src into dst, recursing into maps present in both; other values in src
+// replace those in dst.
+func MergeValues(dst, src map[string]any) {
+	for key, value := range src {
+		if srcMap, ok := value.(map[string]any); ok {
+			if dstMap, ok := dst[key].(map[string]any); ok {
+				MergeValues(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[key] = value
+	}
+}
+
+// renderHelm renders the chart in the request's SourceDir with helm template, applying its values
+// files and inline values in order.
+func renderHelm(ctx context.Context, req Request) ([]byte, error) {
+	if _, err := os.Stat(filepath.Join(req.SourceDir, "Chart.yaml")); err != nil {
+		return nil, fmt.Errorf("no Chart.yaml found in %s", req.SourceDir)
+	}
+	documents, err := resolveValues(req)
+	if err != nil {
+		return nil, err
+	}
+
+	valuesDir, err := os.MkdirTemp("", "gitopsctl-helm-values-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create values directory: %w", err)
+	}
+	defer os.RemoveAll(valuesDir)
+
+	args := []string{"template", req.Helm.ReleaseName, req.SourceDir}
+	if req.Helm.Namespace != "" {
+		args = append(args, "--namespace", req.Helm.Namespace)
+	}
+	for i, doc := range documents {
+		file := filepath.Join(valuesDir, fmt.Sprintf("values-%d.yaml", i))
+		if err := os.WriteFile(file, doc, 0600); err != nil {
+			return nil, fmt.Errorf("failed to write values file: %w", err)
+		}
+		args = append(args, "--values", file)
+	}
+	return runTool(ctx, "helm", args...)
+}
+
+// resolveValues reads the request's values files and inline values, in the order they are
+// applied, and substitutes the environment variables they refer to.
+func resolveValues(req Request) ([][]byte, error) {
+	root := req.RepoDir
+	if root == "" {
+		root = req.SourceDir
+	}
+
+	var documents [][]byte
+	for _, file := range req.Helm.ValuesFiles {
+		path, base := filepath.Join(req.SourceDir, file), root
+		if name, rel, ok := ValuesFileSource(file); ok {
+			sourceDir, found := req.Sources[name]
+			if !found {
+				return nil, fmt.Errorf("values file '%s' refers to unknown source '%s'", file, name)
+			}
+			path, base = filepath.Join(sourceDir, rel), sourceDir
+		}
+		if rel, err := filepath.Rel(base, path); err != nil || !filepath.IsLocal(rel) {
+			return nil, fmt.Errorf("values file '%s' is outside the repository", file)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file '%s': %w", file, err)
+		}
+		if data, err = substituteEnv(data); err != nil {
+			return nil, fmt.Errorf("values file '%s': %w", file, err)
+		}
+		documents = append(documents, data)
+	}
+
+	if len(req.Helm.Values) > 0 {
+		data, err := yaml.Marshal(req.Helm.Values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode inline values: %w", err)
+		}
+		if data, err = substituteEnv(data); err != nil {
+			return nil, fmt.Errorf("inline values: %w", err)
+		}
+		documents = append(documents, data)
+	}
+	return documents, nil
+}
+
+// substituteEnv replaces ${NAME} and ${NAME:-default} with the value of the environment variable
+// NAME, or default when it is unset or empty. $${NAME} is kept as the literal ${NAME}.
+func substituteEnv(data []byte) ([]byte, error) {
+	result, missing := substituteReferences(data, os.Getenv)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("environment variable(s) not set: %s", strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
+// substituteReferences replaces ${NAME} and ${NAME:-default} with lookup(NAME), or default when it
+// is empty, and $${ with ${. It returns the names of the references that have neither a value nor
+// a default, which are left as they are.
+func substituteReferences(data []byte, lookup func(string) string) ([]byte, []string) {
+	var missing []string
+	result := envReference.ReplaceAllFunc(data, func(match []byte) []byte {
+		if bytes.Equal(match, []byte("$${")) {
+			return []byte("${")
+		}
+		groups := envReference.FindSubmatch(match)
+		if value := lookup(string(groups[1])); value != "" {
+			return []byte(value)
+		}
+		if groups[2] != nil {
+			return groups[3]
+		}
+		missing = append(missing, string(groups[1]))
+		return match
+	})
+	return result, missing
+}
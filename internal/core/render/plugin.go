@@ -0,0 +1,279 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultPluginConfigFile is the default path of the manifest generator plugin configuration
+	DefaultPluginConfigFile = "configs/plugins.json"
+	// DefaultPluginTimeout is how long a plugin may run when no timeout is configured.
+	DefaultPluginTimeout = 60 * time.Second
+	// DefaultPluginMaxOutputBytes is the maximum manifest output accepted from a plugin when no limit is configured.
+	DefaultPluginMaxOutputBytes = 10 << 20
+)
+
+// Plugin is an external manifest generator.
+//
+// The controller runs Command with Args in a working directory containing the application's
+// manifests path. A JSON-encoded PluginInput is written to the plugin's stdin, and the plugin
+// must write the generated Kubernetes manifests as a YAML stream to stdout.
+type Plugin struct {
+	// Name identifies the plugin and is used as the application's renderer.
+	Name string `json:"name"`
+	// Command is the executable to run, resolved via PATH if it is not a path.
+	Command string `json:"command"`
+	// Args are the arguments passed to Command.
+	Args []string `json:"args,omitempty"`
+	// Env holds additional environment variables set for the plugin.
+	Env map[string]string `json:"env,omitempty"`
+	// Timeout is how long the plugin may run (e.g., "2m"). Defaults to DefaultPluginTimeout.
+	Timeout string `json:"timeout,omitempty"`
+	// Sandbox restricts what the plugin can see and produce.
+	Sandbox Sandbox `json:"sandbox,omitempty"`
+
+	// timeout is the parsed duration of the Timeout field.
+	timeout time.Duration
+}
+
+// Sandbox holds the isolation options applied when running a plugin.
+type Sandbox struct {
+	// InheritEnv passes the controller's full environment to the plugin.
+	// By default only PATH, HOME and the plugin's Env are set.
+	InheritEnv bool `json:"inheritEnv,omitempty"`
+	// CopySource runs the plugin in a temporary copy of the manifests path so it cannot modify the checkout.
+	CopySource bool `json:"copySource,omitempty"`
+	// MaxOutputBytes limits the size of the plugin's output. Defaults to DefaultPluginMaxOutputBytes.
+	MaxOutputBytes int64 `json:"maxOutputBytes,omitempty"`
+}
+
+// PluginInput is the JSON document written to a plugin's stdin.
+type PluginInput struct {
+	// App is the name of the application being synced.
+	App string `json:"app"`
+	// RepoURL is the application's Git repository URL.
+	RepoURL string `json:"repoURL"`
+	// Branch is the application's Git branch.
+	Branch string `json:"branch"`
+	// Path is the manifests path within the repository.
+	Path string `json:"path"`
+	// Revision is the Git commit being synced.
+	Revision string `json:"revision"`
+	// Params are the application's plugin parameters.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// Plugins is the set of configured manifest generator plugins, keyed by name.
+type Plugins struct {
+	byName map[string]*Plugin
+}
+
+// pluginConfig is the on-disk format of the plugin configuration file.
+type pluginConfig struct {
+	Plugins []*Plugin `json:"plugins"`
+}
+
+// LoadPlugins loads and validates the plugin configuration from the specified file path.
+// If the file does not exist, it returns an empty set of plugins.
+func LoadPlugins(filePath string) (*Plugins, error) {
+	plugins := &Plugins{byName: make(map[string]*Plugin)}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return plugins, nil
+		}
+		return nil, fmt.Errorf("failed to read plugin config file %s: %w", filePath, err)
+	}
+
+	var config pluginConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plugin config: %w", err)
+	}
+
+	for i, plugin := range config.Plugins {
+		if strings.TrimSpace(plugin.Name) == "" {
+			return nil, fmt.Errorf("plugin %d: name is required", i+1)
+		}
+		if IsBuiltinRenderer(plugin.Name) {
+			return nil, fmt.Errorf("plugin '%s': name conflicts with a built-in renderer", plugin.Name)
+		}
+		if _, exists := plugins.byName[plugin.Name]; exists {
+			return nil, fmt.Errorf("plugin '%s': duplicate plugin name", plugin.Name)
+		}
+		if strings.TrimSpace(plugin.Command) == "" {
+			return nil, fmt.Errorf("plugin '%s': command is required", plugin.Name)
+		}
+		plugin.timeout = DefaultPluginTimeout
+		if plugin.Timeout != "" {
+			d, err := time.ParseDuration(plugin.Timeout)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("plugin '%s': invalid timeout '%s'", plugin.Name, plugin.Timeout)
+			}
+			plugin.timeout = d
+		}
+		if plugin.Sandbox.MaxOutputBytes <= 0 {
+			plugin.Sandbox.MaxOutputBytes = DefaultPluginMaxOutputBytes
+		}
+		plugins.byName[plugin.Name] = plugin
+	}
+	return plugins, nil
+}
+
+// Get returns the plugin with the given name.
+func (p *Plugins) Get(name string) (*Plugin, bool) {
+	if p == nil {
+		return nil, false
+	}
+	plugin, ok := p.byName[name]
+	return plugin, ok
+}
+
+// Names returns the names of all configured plugins.
+func (p *Plugins) Names() []string {
+	if p == nil {
+		return nil
+	}
+	names := make([]string, 0, len(p.byName))
+	for name := range p.byName {
+		names = append(names, name)
+	}
+	return names
+}
+
+// IsValidRenderer reports whether name is a built-in renderer or a configured plugin.
+func (p *Plugins) IsValidRenderer(name string) bool {
+	if IsBuiltinRenderer(name) {
+		return true
+	}
+	_, ok := p.Get(name)
+	return ok
+}
+
+// run executes the plugin for the given input and returns the manifests it generated.
+func (plugin *Plugin) run(ctx context.Context, logger *zap.Logger, sourceDir string, input PluginInput) ([]byte, error) {
+	workDir := sourceDir
+	if plugin.Sandbox.CopySource {
+		tmpDir, err := os.MkdirTemp("", "gitopsctl-plugin-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create plugin work directory: %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+		if err := copyDir(sourceDir, tmpDir); err != nil {
+			return nil, fmt.Errorf("failed to copy sources for plugin: %w", err)
+		}
+		workDir = tmpDir
+	}
+
+	stdin, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin input: %w", err)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, plugin.timeout)
+	defer cancel()
+
+	var stdout limitedBuffer
+	stdout.limit = plugin.Sandbox.MaxOutputBytes
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(runCtx, plugin.Command, plugin.Args...)
+	cmd.Dir = workDir
+	cmd.Env = plugin.environment()
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	logger.Debug("Running manifest generator plugin", zap.String("plugin", plugin.Name), zap.String("command", plugin.Command))
+	if err := cmd.Run(); err != nil {
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("plugin '%s' timed out after %s", plugin.Name, plugin.timeout)
+		}
+		if stdout.exceeded {
+			return nil, fmt.Errorf("plugin '%s' output exceeded %d bytes", plugin.Name, plugin.Sandbox.MaxOutputBytes)
+		}
+		return nil, fmt.Errorf("plugin '%s' failed: %w: %s", plugin.Name, err, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.exceeded {
+		return nil, fmt.Errorf("plugin '%s' output exceeded %d bytes", plugin.Name, plugin.Sandbox.MaxOutputBytes)
+	}
+	return stdout.Bytes(), nil
+}
+
+// environment builds the plugin's environment according to its sandbox options.
+func (plugin *Plugin) environment() []string {
+	var env []string
+	if plugin.Sandbox.InheritEnv {
+		env = os.Environ()
+	} else {
+		for _, key := range []string{"PATH", "HOME"} {
+			if value, ok := os.LookupEnv(key); ok {
+				env = append(env, key+"="+value)
+			}
+		}
+	}
+	for key, value := range plugin.Env {
+		env = append(env, key+"="+value)
+	}
+	return env
+}
+
+// limitedBuffer is a buffer that stops accepting writes beyond limit bytes.
+// The buffer is not embedded so that io.Copy cannot bypass Write through ReadFrom.
+type limitedBuffer struct {
+	buf      bytes.Buffer
+	limit    int64
+	exceeded bool
+}
+
+// Write appends p to the buffer, failing once the limit would be exceeded.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if int64(b.buf.Len()+len(p)) > b.limit {
+		b.exceeded = true
+		return 0, io.ErrShortWrite
+	}
+	return b.buf.Write(p)
+}
+
+// Bytes returns the buffered output.
+func (b *limitedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// copyDir recursively copies the regular files and directories under src into dst.
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+}
@@ -0,0 +1,206 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// RendererYAML applies plain YAML manifests as they are stored in the repository.
+	RendererYAML = "yaml"
+	// RendererJsonnet evaluates a Jsonnet entrypoint and applies the resulting objects.
+	RendererJsonnet = "jsonnet"
+	// RendererYtt renders the directory with Carvel ytt and applies the resulting YAML.
+	RendererYtt = "ytt"
+	// RendererHelm renders the directory as a Helm chart with helm template and applies the resulting YAML.
+	RendererHelm = "helm"
+
+	// DefaultJsonnetEntrypoint is the Jsonnet file evaluated when the path contains more than one.
+	DefaultJsonnetEntrypoint = "main.jsonnet"
+	// RenderedManifestFile is the name of the file in the output directory rendered manifests are written to.
+	RenderedManifestFile = "rendered.yaml"
+)
+
+// Renderers lists the built-in manifest renderers.
+var Renderers = []string{RendererYAML, RendererJsonnet, RendererYtt, RendererHelm}
+
+// Request describes the manifests to render for an application.
+type Request struct {
+	// Renderer is a built-in renderer or the name of a configured plugin.
+	Renderer string
+	// SourceDir is the directory holding the application's manifests path.
+	SourceDir string
+	// RepoDir is the root of the repository checkout SourceDir is in. Files the renderer reads,
+	// such as Helm values files, must be inside it.
+	RepoDir string
+	// Sources maps the names of the application's additional sources to the directories they are
+	// checked out in, for values files referring to them as "$name/<path>".
+	Sources map[string]string
+	// Helm configures the helm renderer.
+	Helm HelmOptions
+	// Input is passed to plugins on stdin.
+	Input PluginInput
+}
+
+// IsBuiltinRenderer reports whether name is a built-in renderer. An empty name selects plain YAML.
+func IsBuiltinRenderer(name string) bool {
+	if name == "" {
+		return true
+	}
+	for _, r := range Renderers {
+		if name == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Render produces plain YAML manifests for the request using its renderer.
+//
+// For plain YAML it returns the request's SourceDir unchanged. Otherwise the rendered
+// manifests are written to a single file in outputDir, which is returned. The jsonnet, ytt
+// and helm binaries must be available in PATH; any other renderer must be a configured plugin.
+func Render(ctx context.Context, logger *zap.Logger, plugins *Plugins, req Request, outputDir string) (string, error) {
+	var (
+		rendered []byte
+		err      error
+	)
+	switch req.Renderer {
+	case "", RendererYAML:
+		return req.SourceDir, nil
+	case RendererJsonnet:
+		rendered, err = renderJsonnet(ctx, req.SourceDir)
+	case RendererYtt:
+		rendered, err = renderYtt(ctx, req.SourceDir)
+	case RendererHelm:
+		rendered, err = renderHelm(ctx, req)
+	default:
+		plugin, ok := plugins.Get(req.Renderer)
+		if !ok {
+			return "", fmt.Errorf("unknown renderer '%s' (built-in: %s; plugins: %s)",
+				req.Renderer, strings.Join(Renderers, ", "), strings.Join(plugins.Names(), ", "))
+		}
+		rendered, err = plugin.run(ctx, logger, req.SourceDir, req.Input)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create render output directory %s: %w", outputDir, err)
+	}
+	outputFile := filepath.Join(outputDir, RenderedManifestFile)
+	if err := os.WriteFile(outputFile, rendered, 0644); err != nil {
+		return "", fmt.Errorf("failed to write rendered manifests: %w", err)
+	}
+	logger.Debug("Rendered manifests", zap.String("renderer", req.Renderer), zap.String("file", outputFile), zap.Int("bytes", len(rendered)))
+	return outputDir, nil
+}
+
+// renderJsonnet evaluates the Jsonnet entrypoint in sourceDir and converts the result to a YAML stream.
+//
+// The entrypoint may evaluate to a single object, an array of objects, or an object whose
+// values are objects (e.g., {deployment: {...}, service: {...}}).
+func renderJsonnet(ctx context.Context, sourceDir string) ([]byte, error) {
+	entrypoint, err := jsonnetEntrypoint(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := runTool(ctx, "jsonnet", "-J", sourceDir, entrypoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode jsonnet output: %w", err)
+	}
+
+	var objects []any
+	switch v := result.(type) {
+	case []any:
+		objects = v
+	case map[string]any:
+		if _, ok := v["kind"]; ok {
+			objects = []any{v}
+		} else {
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				objects = append(objects, v[key])
+			}
+		}
+	default:
+		return nil, fmt.Errorf("jsonnet output must be an object or an array of objects")
+	}
+
+	var buf bytes.Buffer
+	for i, obj := range objects {
+		doc, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert jsonnet output to YAML: %w", err)
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(doc)
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonnetEntrypoint returns the Jsonnet file to evaluate: the only .jsonnet file in sourceDir,
+// or DefaultJsonnetEntrypoint when there are several.
+func jsonnetEntrypoint(sourceDir string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(sourceDir, "*.jsonnet"))
+	if err != nil {
+		return "", fmt.Errorf("failed to list jsonnet files: %w", err)
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no .jsonnet files found in %s", sourceDir)
+	case 1:
+		return matches[0], nil
+	}
+
+	entrypoint := filepath.Join(sourceDir, DefaultJsonnetEntrypoint)
+	if _, err := os.Stat(entrypoint); err != nil {
+		return "", fmt.Errorf("multiple .jsonnet files found in %s but no %s entrypoint", sourceDir, DefaultJsonnetEntrypoint)
+	}
+	return entrypoint, nil
+}
+
+// renderYtt renders all templates in sourceDir with ytt.
+func renderYtt(ctx context.Context, sourceDir string) ([]byte, error) {
+	return runTool(ctx, "ytt", "-f", sourceDir)
+}
+
+// runTool runs an external rendering tool and returns its standard output.
+func runTool(ctx context.Context, name string, args ...string) ([]byte, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("renderer requires the '%s' binary in PATH: %w", name, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,89 @@
+package render
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+)
+
+// NormalizeSourcePaths normalizes the additional paths of an application's manifests like its main
+// path, and checks that each is a valid glob pattern in path.Match syntax.
+func NormalizeSourcePaths(paths []string) ([]string, error) {
+	normalized := make([]string, 0, len(paths))
+	for _, p := range paths {
+		cleaned, err := common.NormalizeRepoPath(p)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := path.Match(cleaned, ""); err != nil {
+			return nil, fmt.Errorf("invalid path pattern '%s': %w", p, err)
+		}
+		normalized = append(normalized, cleaned)
+	}
+	return normalized, nil
+}
+
+// CombineSources copies the files selected by paths, each a path or glob pattern relative to
+// repoDir, into outputDir so they can be applied as a single set. Every file keeps its path
+// relative to the repository, so manifests of different paths with the same name do not collide.
+// A path that matches nothing is an error, and the repository's .git directory is never copied.
+func CombineSources(repoDir string, paths []string, outputDir string) error {
+	for _, p := range paths {
+		matches, err := filepath.Glob(filepath.Join(repoDir, filepath.FromSlash(p)))
+		if err != nil {
+			return fmt.Errorf("invalid path pattern '%s': %w", p, err)
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("path '%s' matches nothing in the repository", p)
+		}
+		sort.Strings(matches)
+		for _, match := range matches {
+			rel, err := filepath.Rel(repoDir, match)
+			if err != nil {
+				return err
+			}
+			if err := copySource(match, filepath.Join(outputDir, rel)); err != nil {
+				return fmt.Errorf("failed to copy '%s': %w", filepath.ToSlash(rel), err)
+			}
+		}
+	}
+	return nil
+}
+
+// copySource copies a file, or a directory without its .git directories, to dst.
+func copySource(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dst, data, 0644)
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Name() == ".git" || (!entry.IsDir() && !entry.Type().IsRegular()) {
+			continue
+		}
+		if err := copySource(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package render
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	// VariableClusterName is substituted with the name of the cluster the application is deployed to.
+	VariableClusterName = "CLUSTER_NAME"
+	// VariableAppName is substituted with the name of the application.
+	VariableAppName = "APP_NAME"
+)
+
+// BuiltinVariables lists the variables that are always available for substitution and cannot be
+// redefined by clusters or applications.
+var BuiltinVariables = []string{VariableClusterName, VariableAppName}
+
+// variableName matches the names that can be referenced as ${NAME}.
+var variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateVariables checks that user-defined variables have names that can be referenced as
+// ${NAME} and do not redefine a built-in variable.
+func ValidateVariables(vars map[string]string) error {
+	for name := range vars {
+		if !variableName.MatchString(name) {
+			return fmt.Errorf("invalid variable name '%s': must start with a letter or underscore and contain only letters, digits and underscores", name)
+		}
+		if slices.Contains(BuiltinVariables, name) {
+			return fmt.Errorf("variable '%s' is built in and cannot be redefined", name)
+		}
+	}
+	return nil
+}
+
+// SubstituteVariables writes the YAML files under sourceDir to outputDir, keeping their relative
+// paths, with ${NAME} and ${NAME:-default} replaced by the value of the variable NAME, or default
+// when it is not set. $${NAME} is kept as the literal ${NAME}. References to variables that are
+// neither set nor have a default are reported as an error, naming the files they are in.
+func SubstituteVariables(sourceDir, outputDir string, vars map[string]string) error {
+	lookup := func(name string) string { return vars[name] }
+	missing := make(map[string][]string)
+	err := filepath.WalkDir(sourceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || (!strings.HasSuffix(d.Name(), ".yaml") && !strings.HasSuffix(d.Name(), ".yml")) {
+			return err
+		}
+		rel, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		data, names := substituteReferences(data, lookup)
+		for _, name := range names {
+			if !slices.Contains(missing[name], filepath.ToSlash(rel)) {
+				missing[name] = append(missing[name], filepath.ToSlash(rel))
+			}
+		}
+		target := filepath.Join(outputDir, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to substitute variables: %w", err)
+	}
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		details := make([]string, len(names))
+		for i, name := range names {
+			details[i] = fmt.Sprintf("%s (in %s)", name, strings.Join(missing[name], ", "))
+		}
+		return fmt.Errorf("variable(s) not set: %s", strings.Join(details, ", "))
+	}
+	return nil
+}
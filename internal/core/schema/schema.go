@@ -0,0 +1,413 @@
+// Package schema validates JSON state files against the Go types they are loaded into, so that
+// mistakes in hand-edited files are reported on load with their location instead of surfacing
+// later as opaque unmarshal or duration parsing errors.
+//
+// The schema of a type is derived from its json tags: unknown fields, values of the wrong type
+// and values rejected by a type's own UnmarshalJSON are errors. Fields can be further constrained
+// with a schema tag listing options separated by commas:
+//
+//	required  the field must be present and not empty
+//	duration  the field, a string, must be a duration such as "5m" if it is not empty
+package schema
+
+import (
+	"bytes"
+	"cmp"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error is a problem found in a JSON document.
+type Error struct {
+	// Path is the location of the offending value in the document, e.g. "[2].interval".
+	Path string
+	// Line is the line the offending value starts on, from 1.
+	Line int
+	// Column is the column the offending value starts at, from 1.
+	Column int
+	// Message describes the problem.
+	Message string
+}
+
+// Error implements the error interface.
+func (e Error) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("line %d, column %d: %s: %s", e.Line, e.Column, e.Path, e.Message)
+}
+
+// Errors lists the problems found in a JSON document, in the order they appear in it.
+type Errors []Error
+
+// Error implements the error interface, with one problem per line.
+func (e Errors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate checks data, a JSON document, against the type of v, such as *[]*app.Application.
+// It returns Errors listing every problem found, or nil if the document is valid.
+func Validate(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	p := &parser{data: data, dec: dec}
+	root, err := p.parse()
+	if err == nil {
+		if _, err = dec.Token(); err == nil {
+			err = errors.New("unexpected data after the top-level value")
+		} else if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if err != nil {
+		offset := int(dec.InputOffset())
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			offset = int(syntaxErr.Offset)
+		}
+		line, column := position(data, offset)
+		return Errors{{Line: line, Column: column, Message: "invalid JSON: " + strings.TrimPrefix(err.Error(), "json: ")}}
+	}
+
+	c := &checker{data: data}
+	c.check(root, reflect.TypeOf(v), "")
+	if len(c.errs) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(c.errs, func(a, b Error) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+	return c.errs
+}
+
+// kind is the kind of a JSON value.
+type kind int
+
+const (
+	kindNull kind = iota
+	kindBool
+	kindNumber
+	kindString
+	kindArray
+	kindObject
+)
+
+// String returns the name of the kind for error messages.
+func (k kind) String() string {
+	return [...]string{"null", "a boolean", "a number", "a string", "an array", "an object"}[k]
+}
+
+// node is a parsed JSON value with its location.
+type node struct {
+	kind  kind
+	start int
+	end   int
+	// value is the string, json.Number or bool of a scalar.
+	value any
+	// keys and fields are the members of an object, in order.
+	keys   []string
+	fields []*node
+	// items are the elements of an array.
+	items []*node
+}
+
+// parser parses a JSON document into nodes, recording where each value starts and ends.
+type parser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+// parse parses the next value.
+func (p *parser) parse() (*node, error) {
+	start := p.skip(int(p.dec.InputOffset()))
+	tok, err := p.dec.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("unexpected end of JSON input")
+		}
+		return nil, err
+	}
+
+	n := &node{start: start}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			n.kind = kindObject
+			for p.dec.More() {
+				keyTok, err := p.dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				child, err := p.parse()
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, keyTok.(string))
+				n.fields = append(n.fields, child)
+			}
+		} else {
+			n.kind = kindArray
+			for p.dec.More() {
+				child, err := p.parse()
+				if err != nil {
+					return nil, err
+				}
+				n.items = append(n.items, child)
+			}
+		}
+		if _, err := p.dec.Token(); err != nil { // Closing delimiter
+			return nil, err
+		}
+	case string:
+		n.kind, n.value = kindString, t
+	case json.Number:
+		n.kind, n.value = kindNumber, t
+	case bool:
+		n.kind, n.value = kindBool, t
+	case nil:
+		n.kind = kindNull
+	}
+	n.end = int(p.dec.InputOffset())
+	return n, nil
+}
+
+// skip returns the offset of the first byte from offset on that is not whitespace or a separator.
+func (p *parser) skip(offset int) int {
+	for offset < len(p.data) && strings.IndexByte(" \t\r\n,:", p.data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// position returns the line and column of a byte offset in data, both from 1.
+func position(data []byte, offset int) (int, int) {
+	offset = min(max(offset, 0), len(data))
+	line := bytes.Count(data[:offset], []byte("\n")) + 1
+	column := offset - bytes.LastIndexByte(data[:offset], '\n')
+	return line, column
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// checker checks nodes against types and collects the problems found.
+type checker struct {
+	data []byte
+	errs Errors
+}
+
+// fail records a problem with the value of n.
+func (c *checker) fail(n *node, path, format string, args ...any) {
+	line, column := position(c.data, n.start)
+	c.errs = append(c.errs, Error{Path: path, Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+// check checks the value of n, at path, against type t.
+func (c *checker) check(n *node, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if n.kind == kindNull {
+		return // Null leaves any value unset
+	}
+
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		target := reflect.New(t).Interface().(json.Unmarshaler)
+		if err := target.UnmarshalJSON(c.data[n.start:n.end]); err != nil {
+			c.fail(n, path, "%s", strings.TrimPrefix(err.Error(), "json: "))
+		}
+		return
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		if n.kind != kindString {
+			c.fail(n, path, "must be a string, not %s", n.kind)
+			return
+		}
+		target := reflect.New(t).Interface().(encoding.TextUnmarshaler)
+		if err := target.UnmarshalText([]byte(n.value.(string))); err != nil {
+			c.fail(n, path, "%v", err)
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		c.expect(n, kindString, path)
+	case reflect.Bool:
+		c.expect(n, kindBool, path)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if c.expect(n, kindNumber, path) {
+			if _, err := strconv.ParseInt(string(n.value.(json.Number)), 10, t.Bits()); err != nil {
+				c.fail(n, path, "must be an integer, not %s", n.value)
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if c.expect(n, kindNumber, path) {
+			if _, err := strconv.ParseUint(string(n.value.(json.Number)), 10, t.Bits()); err != nil {
+				c.fail(n, path, "must be a non-negative integer, not %s", n.value)
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		c.expect(n, kindNumber, path)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			c.expect(n, kindString, path) // Base64-encoded bytes
+			return
+		}
+		if c.expect(n, kindArray, path) {
+			for i, item := range n.items {
+				c.check(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case reflect.Map:
+		if c.expect(n, kindObject, path) {
+			for i, key := range n.keys {
+				c.check(n.fields[i], t.Elem(), join(path, key))
+			}
+		}
+	case reflect.Struct:
+		if c.expect(n, kindObject, path) {
+			c.checkStruct(n, t, path)
+		}
+	}
+}
+
+// expect reports whether n is of kind k, recording a problem if it is not.
+func (c *checker) expect(n *node, k kind, path string) bool {
+	if n.kind != k {
+		c.fail(n, path, "must be %s, not %s", k, n.kind)
+		return false
+	}
+	return true
+}
+
+// checkStruct checks the members of the object n against the fields of struct type t.
+func (c *checker) checkStruct(n *node, t reflect.Type, path string) {
+	fields := structFields(t)
+	present := make(map[string]bool, len(n.keys))
+	for i, key := range n.keys {
+		f, ok := fields[key]
+		if !ok {
+			// encoding/json matches field names case-insensitively
+			for name, candidate := range fields {
+				if strings.EqualFold(name, key) {
+					f, ok = candidate, true
+					break
+				}
+			}
+		}
+		if !ok {
+			c.fail(n.fields[i], join(path, key), "unknown field")
+			continue
+		}
+		present[f.name] = true
+		value := n.fields[i]
+		c.check(value, f.typ, join(path, key))
+		if f.duration && value.kind == kindString && value.value != "" {
+			if _, err := time.ParseDuration(value.value.(string)); err != nil {
+				c.fail(value, join(path, key), "must be a duration such as 30s, 5m or 1h, not %q", value.value)
+			}
+		}
+		if f.required && value.kind == kindString && value.value == "" {
+			c.fail(value, join(path, key), "must not be empty")
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		if fields[name].required && !present[name] {
+			c.fail(n, path, "missing required field %q", name)
+		}
+	}
+}
+
+// field is a field of a struct as seen in JSON.
+type field struct {
+	name     string
+	typ      reflect.Type
+	required bool
+	duration bool
+}
+
+// structFields returns the fields of struct type t by JSON name, including those promoted from
+// embedded structs.
+func structFields(t reflect.Type) map[string]field {
+	fields := make(map[string]field)
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for n, f := range structFields(embedded) {
+					if _, ok := fields[n]; !ok {
+						fields[n] = f
+					}
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		f := field{name: name, typ: sf.Type}
+		for _, option := range strings.Split(sf.Tag.Get("schema"), ",") {
+			switch option {
+			case "required":
+				f.required = true
+			case "duration":
+				f.duration = true
+			}
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// join appends a field name to a path.
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// Version returns the version of a versioned JSON document: 1 for a top-level array, the format
+// of state files written before they were versioned, and otherwise the number in the version
+// field of the top-level object, or 0 if there is none.
+func Version(data []byte) int {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return 1
+	}
+	var doc struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(trimmed, &doc); err != nil {
+		return 0
+	}
+	return doc.Version
+}
@@ -0,0 +1,148 @@
+package stats
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+)
+
+// DORA is the DORA metrics of an application, or of all applications, over a rolling window:
+// deployment frequency, lead time for changes and change failure rate.
+//
+// A change is a commit an application had not synced yet. It is deployed by the first sync of it
+// that succeeds, and failed if any sync of it failed, including a rollback after it was deployed.
+type DORA struct {
+	// App is the name of the application, empty for all applications.
+	App string
+	// Window is how far back the metrics go.
+	Window time.Duration
+	// Deploys is the number of changes deployed.
+	Deploys int
+	// DeploymentFrequency is the number of deploys per day.
+	DeploymentFrequency float64
+	// LeadTime is the median time from a commit to the sync that deployed it.
+	LeadTime time.Duration
+	// Changes is the number of changes synced, deployed or not.
+	Changes int
+	// FailedChanges is the number of changes with a failed sync.
+	FailedChanges int
+	// ChangeFailureRate is FailedChanges divided by Changes, or 0 when there were no changes.
+	ChangeFailureRate float64
+}
+
+// change is the outcome of the syncs of a commit an application had not synced yet.
+type change struct {
+	deployed bool
+	failed   bool
+}
+
+// computeDORA computes the DORA metrics of each application of the report, and over all of them,
+// from the syncs counted by Compute.
+func computeDORA(report *Report, syncs []Sync) {
+	changes := make(map[string]map[string]*change)
+	leadTimes := make(map[string][]time.Duration)
+	for _, s := range syncs {
+		if s.CommitTime.IsZero() || s.GitHash == "" {
+			continue
+		}
+		if changes[s.App] == nil {
+			changes[s.App] = make(map[string]*change)
+		}
+		c, ok := changes[s.App][s.GitHash]
+		if !ok {
+			c = &change{}
+			changes[s.App][s.GitHash] = c
+		}
+		switch {
+		case s.Status.IsFailed():
+			c.failed = true
+		case !c.deployed:
+			c.deployed = true
+			leadTimes[s.App] = append(leadTimes[s.App], max(s.Time.Sub(s.CommitTime), 0))
+		}
+	}
+
+	report.DORA = &DORA{Window: report.Window}
+	var allLeadTimes []time.Duration
+	for _, stats := range report.Apps {
+		stats.DORA = &DORA{App: stats.App, Window: report.Window}
+		for _, c := range changes[stats.App] {
+			stats.DORA.add(c)
+			report.DORA.add(c)
+		}
+		stats.DORA.finish(leadTimes[stats.App])
+		allLeadTimes = append(allLeadTimes, leadTimes[stats.App]...)
+	}
+	report.DORA.finish(allLeadTimes)
+}
+
+// add counts a change.
+func (d *DORA) add(c *change) {
+	d.Changes++
+	if c.deployed {
+		d.Deploys++
+	}
+	if c.failed {
+		d.FailedChanges++
+	}
+}
+
+// finish computes the rates and the median lead time from the counts and the lead times of the deploys.
+func (d *DORA) finish(leadTimes []time.Duration) {
+	if days := d.Window.Hours() / 24; days > 0 {
+		d.DeploymentFrequency = float64(d.Deploys) / days
+	}
+	if d.Changes > 0 {
+		d.ChangeFailureRate = float64(d.FailedChanges) / float64(d.Changes)
+	}
+	if len(leadTimes) > 0 {
+		sorted := slices.Clone(leadTimes)
+		slices.Sort(sorted)
+		middle := len(sorted) / 2
+		d.LeadTime = sorted[middle]
+		if len(sorted)%2 == 0 {
+			d.LeadTime = (sorted[middle-1] + sorted[middle]) / 2
+		}
+	}
+}
+
+// FormatLeadTime formats the median lead time, "-" when there were no deploys.
+func (d *DORA) FormatLeadTime() string {
+	if d.Deploys == 0 {
+		return "-"
+	}
+	return d.LeadTime.Round(time.Second).String()
+}
+
+// ToTableHeaders implements cliutils.Renderable for table output headers.
+func (d *DORA) ToTableHeaders(details bool) []string {
+	if details {
+		return []string{"APP", "DEPLOYS", "DEPLOYS/DAY", "LEAD TIME", "CHANGES", "FAILED CHANGES", "CHANGE FAILURE RATE"}
+	}
+	return []string{"APP", "DEPLOYS/DAY", "LEAD TIME", "CHANGE FAILURE RATE"}
+}
+
+// ToTableRow implements cliutils.Renderable for table output rows.
+func (d *DORA) ToTableRow(details bool) []string {
+	app := common.DefaultIfEmpty(d.App, "(all)")
+	frequency := strconv.FormatFloat(d.DeploymentFrequency, 'f', 2, 64)
+	if details {
+		return []string{app, strconv.Itoa(d.Deploys), frequency, d.FormatLeadTime(), strconv.Itoa(d.Changes), strconv.Itoa(d.FailedChanges), FormatRate(d.ChangeFailureRate)}
+	}
+	return []string{app, frequency, d.FormatLeadTime(), FormatRate(d.ChangeFailureRate)}
+}
+
+// ToJSONMap implements cliutils.Renderable for JSON output.
+func (d *DORA) ToJSONMap() map[string]any {
+	return map[string]any{
+		"app":                  d.App,
+		"deploys":              d.Deploys,
+		"deployment_frequency": d.DeploymentFrequency,
+		"lead_time":            d.LeadTime.Seconds(),
+		"changes":              d.Changes,
+		"failed_changes":       d.FailedChanges,
+		"change_failure_rate":  d.ChangeFailureRate,
+	}
+}
@@ -0,0 +1,319 @@
+// Package stats aggregates the outcomes of application syncs over a rolling window for SLO
+// reporting: how often syncs succeed, how long commits take to be deployed and how long
+// applications keep failing.
+//
+// Each finished sync is appended to a JSON Lines file, one object per line, so the controller
+// writes to it while a standalone API server and the CLI read it.
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"aeswibon.com/github/gitopsctl/internal/core/app"
+)
+
+const (
+	// DefaultSyncLogFile is the default path of the sync log statistics are computed from.
+	DefaultSyncLogFile = "configs/syncs.log"
+	// DefaultWindow is the rolling window statistics are computed over by default.
+	DefaultWindow = 7 * 24 * time.Hour
+)
+
+// Sync is a finished sync attempt of an application.
+type Sync struct {
+	// Time is when the sync attempt finished.
+	Time time.Time `json:"time"`
+	// App is the name of the application.
+	App string `json:"app"`
+	// Status is the resulting application status.
+	Status app.Status `json:"status"`
+	// GitHash is the commit that was being synced, if known.
+	GitHash string `json:"gitHash,omitempty"`
+	// CommitTime is when the commit was made, set only for syncs of a commit the application had not
+	// synced yet. Those syncs are deploys of a change, and their failures failed changes.
+	CommitTime time.Time `json:"commitTime,omitempty"`
+	// Message describes the outcome of the sync.
+	Message string `json:"message,omitempty"`
+	// Actor is who requested the sync, empty for syncs the controller started on its own.
+	Actor string `json:"actor,omitempty"`
+	// Reason is why the sync was requested, as given by the actor.
+	Reason string `json:"reason,omitempty"`
+	// RequestID is the ID of the API request that requested the sync, if any.
+	RequestID string `json:"requestID,omitempty"`
+}
+
+// mu serializes appends from the same process so syncs are never interleaved.
+var mu sync.Mutex
+
+// Record appends a sync to the sync log at filePath.
+func Record(filePath string, s Sync) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	f, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open sync log %s: %w", filePath, err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write sync log %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// Load reads the syncs that finished at or after since from the sync log at filePath, oldest first.
+// If the file does not exist, it returns no syncs.
+func Load(filePath string, since time.Time) ([]Sync, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read sync log %s: %w", filePath, err)
+	}
+	defer f.Close()
+
+	var syncs []Sync
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var s Sync
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			return nil, fmt.Errorf("failed to parse sync log %s line %d: %w", filePath, line, err)
+		}
+		if !s.Time.Before(since) {
+			syncs = append(syncs, s)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read sync log %s: %w", filePath, err)
+	}
+	slices.SortStableFunc(syncs, func(a, b Sync) int { return a.Time.Compare(b.Time) })
+	return syncs, nil
+}
+
+// ForApp returns the syncs of the named application.
+func ForApp(syncs []Sync, name string) []Sync {
+	var matched []Sync
+	for _, s := range syncs {
+		if s.App == name {
+			matched = append(matched, s)
+		}
+	}
+	return matched
+}
+
+// ParseWindow parses a rolling window such as "7d", "12h" or "90m". Days are 24 hours.
+func ParseWindow(window string) (time.Duration, error) {
+	window = strings.TrimSpace(window)
+	var d time.Duration
+	if days, ok := strings.CutSuffix(window, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window '%s': %w", window, err)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(window); err != nil {
+			return 0, fmt.Errorf("invalid window '%s': %w", window, err)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid window '%s': must be positive", window)
+	}
+	return d, nil
+}
+
+// Summary is the statistics of a set of syncs. Only syncs that succeeded or failed are counted;
+// syncs held for approval or interrupted are neither.
+type Summary struct {
+	// Syncs is the number of syncs that succeeded or failed.
+	Syncs int
+	// Succeeded is the number of syncs that left the application synced.
+	Succeeded int
+	// Failed is the number of syncs that failed (see app.Status.IsFailed).
+	Failed int
+	// SuccessRate is Succeeded divided by Syncs, or 1 when there were no syncs.
+	SuccessRate float64
+	// Deploys is the number of successful syncs that deployed a new commit.
+	Deploys int
+	// MeanTimeToDeploy is the mean time from a commit to the sync that deployed it.
+	MeanTimeToDeploy time.Duration
+	// LongestFailureStreak is the most consecutive failed syncs of an application.
+	LongestFailureStreak int
+}
+
+// AppStats is the statistics of an application's syncs.
+type AppStats struct {
+	Summary
+	// App is the name of the application.
+	App string
+	// FailureStreak is the number of consecutive failed syncs the window ends with.
+	FailureStreak int
+	// LastSync is when the application's last counted sync finished.
+	LastSync time.Time
+	// DORA is the DORA metrics of the application.
+	DORA *DORA
+}
+
+// Report is the statistics of the syncs in a rolling window, per application and over all of them.
+type Report struct {
+	// Window is how far back the report goes.
+	Window time.Duration
+	// From is when the window starts.
+	From time.Time
+	// To is when the window ends, the time the report was computed.
+	To time.Time
+	// Total is the statistics over the syncs of all applications.
+	Total Summary
+	// FailingApps is the number of applications whose last sync failed.
+	FailingApps int
+	// Apps is the statistics of each application with syncs in the window, by name.
+	Apps []*AppStats
+	// DORA is the DORA metrics over all applications.
+	DORA *DORA
+}
+
+// Compute computes the statistics and DORA metrics of syncs, ordered oldest first, over the window
+// ending at now.
+func Compute(syncs []Sync, window time.Duration, now time.Time) *Report {
+	report := &Report{Window: window, From: now.Add(-window), To: now, Apps: []*AppStats{}}
+	byApp := make(map[string]*AppStats)
+	var totalDeployTime time.Duration
+	deployTimes := make(map[string]time.Duration)
+	var counted []Sync
+
+	for _, s := range syncs {
+		if s.Time.Before(report.From) || s.Time.After(now) || (s.Status != app.StatusSynced && !s.Status.IsFailed()) {
+			continue
+		}
+		counted = append(counted, s)
+		stats, ok := byApp[s.App]
+		if !ok {
+			stats = &AppStats{App: s.App}
+			byApp[s.App] = stats
+			report.Apps = append(report.Apps, stats)
+		}
+		stats.Syncs++
+		stats.LastSync = s.Time
+		if s.Status.IsFailed() {
+			stats.Failed++
+			stats.FailureStreak++
+			stats.LongestFailureStreak = max(stats.LongestFailureStreak, stats.FailureStreak)
+			continue
+		}
+		stats.Succeeded++
+		stats.FailureStreak = 0
+		if !s.CommitTime.IsZero() {
+			deployTime := max(s.Time.Sub(s.CommitTime), 0)
+			stats.Deploys++
+			deployTimes[s.App] += deployTime
+			totalDeployTime += deployTime
+		}
+	}
+
+	slices.SortFunc(report.Apps, func(a, b *AppStats) int { return strings.Compare(a.App, b.App) })
+	for _, stats := range report.Apps {
+		stats.finish(deployTimes[stats.App])
+		report.Total.Syncs += stats.Syncs
+		report.Total.Succeeded += stats.Succeeded
+		report.Total.Failed += stats.Failed
+		report.Total.Deploys += stats.Deploys
+		report.Total.LongestFailureStreak = max(report.Total.LongestFailureStreak, stats.LongestFailureStreak)
+		if stats.FailureStreak > 0 {
+			report.FailingApps++
+		}
+	}
+	report.Total.finish(totalDeployTime)
+	computeDORA(report, counted)
+	return report
+}
+
+// finish computes the success rate and mean time to deploy from the counts and the total deploy time.
+func (s *Summary) finish(deployTime time.Duration) {
+	s.SuccessRate = 1
+	if s.Syncs > 0 {
+		s.SuccessRate = float64(s.Succeeded) / float64(s.Syncs)
+	}
+	if s.Deploys > 0 {
+		s.MeanTimeToDeploy = deployTime / time.Duration(s.Deploys)
+	}
+}
+
+// FormatRate formats a success rate as a percentage, e.g. "99.5%".
+func FormatRate(rate float64) string {
+	return strconv.FormatFloat(rate*100, 'f', 1, 64) + "%"
+}
+
+// FormatMeanTimeToDeploy formats a mean time to deploy, "-" when there were no deploys.
+func FormatMeanTimeToDeploy(s Summary) string {
+	if s.Deploys == 0 {
+		return "-"
+	}
+	return s.MeanTimeToDeploy.Round(time.Second).String()
+}
+
+// ToTableHeaders implements cliutils.Renderable for table output headers.
+func (a *AppStats) ToTableHeaders(details bool) []string {
+	if details {
+		return []string{"APP", "SYNCS", "SUCCEEDED", "FAILED", "SUCCESS RATE", "DEPLOYS", "MEAN TIME TO DEPLOY", "FAILURE STREAK", "LONGEST STREAK", "LAST SYNC"}
+	}
+	return []string{"APP", "SYNCS", "SUCCESS RATE", "MEAN TIME TO DEPLOY", "FAILURE STREAK"}
+}
+
+// ToTableRow implements cliutils.Renderable for table output rows.
+func (a *AppStats) ToTableRow(details bool) []string {
+	if details {
+		return []string{
+			a.App,
+			strconv.Itoa(a.Syncs),
+			strconv.Itoa(a.Succeeded),
+			strconv.Itoa(a.Failed),
+			FormatRate(a.SuccessRate),
+			strconv.Itoa(a.Deploys),
+			FormatMeanTimeToDeploy(a.Summary),
+			strconv.Itoa(a.FailureStreak),
+			strconv.Itoa(a.LongestFailureStreak),
+			common.GetRelativeTime(a.LastSync),
+		}
+	}
+	return []string{a.App, strconv.Itoa(a.Syncs), FormatRate(a.SuccessRate), FormatMeanTimeToDeploy(a.Summary), strconv.Itoa(a.FailureStreak)}
+}
+
+// ToJSONMap implements cliutils.Renderable for JSON output.
+func (a *AppStats) ToJSONMap() map[string]any {
+	return map[string]any{
+		"app":                    a.App,
+		"syncs":                  a.Syncs,
+		"succeeded":              a.Succeeded,
+		"failed":                 a.Failed,
+		"success_rate":           a.SuccessRate,
+		"deploys":                a.Deploys,
+		"mean_time_to_deploy":    a.MeanTimeToDeploy.Seconds(),
+		"failure_streak":         a.FailureStreak,
+		"longest_failure_streak": a.LongestFailureStreak,
+		"last_sync":              a.LastSync.Format(time.RFC3339),
+	}
+}
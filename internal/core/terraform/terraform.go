@@ -0,0 +1,210 @@
+// Package terraform plans and applies Terraform and OpenTofu configurations with their CLI.
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// BinaryTerraform is the Terraform CLI, used by default.
+	BinaryTerraform = "terraform"
+	// BinaryOpenTofu is the OpenTofu CLI.
+	BinaryOpenTofu = "tofu"
+
+	// maxOutput is how much of a failed command's output is kept in its error.
+	maxOutput = 2000
+)
+
+// Binaries lists the supported CLIs.
+var Binaries = []string{BinaryTerraform, BinaryOpenTofu}
+
+// Options configures how a configuration is planned.
+type Options struct {
+	// VarFiles are variable files, relative to the configuration directory, passed in order.
+	VarFiles []string
+	// Vars are input variables, taking precedence over VarFiles.
+	Vars map[string]string
+}
+
+// Plan is the outcome of planning a configuration.
+type Plan struct {
+	// HasChanges reports whether applying the plan would change infrastructure or outputs.
+	HasChanges bool
+	// ID is a digest of the saved plan file.
+	ID string
+	// Add, Change and Destroy count the resources the plan creates, updates and deletes.
+	// A replaced resource counts as both added and destroyed.
+	Add, Change, Destroy int
+}
+
+// Summary returns the plan's summary in the form the CLI prints it.
+func (p Plan) Summary() string {
+	if !p.HasChanges {
+		return "No changes. Your infrastructure matches the configuration."
+	}
+	return fmt.Sprintf("Plan: %d to add, %d to change, %d to destroy.", p.Add, p.Change, p.Destroy)
+}
+
+// Runner runs a Terraform or OpenTofu CLI in a configuration directory.
+type Runner struct {
+	// Binary is the CLI to run, BinaryTerraform if empty.
+	Binary string
+	// Dir is the configuration directory.
+	Dir string
+}
+
+// ValidateVarFiles checks that variable file paths are relative to the configuration directory.
+// They may refer to files outside it, which the controller checks are still inside the repository.
+func ValidateVarFiles(files []string) error {
+	for _, file := range files {
+		if strings.TrimSpace(file) == "" {
+			return fmt.Errorf("variable file path cannot be empty")
+		}
+		if filepath.IsAbs(file) {
+			return fmt.Errorf("variable file '%s' must be relative to the configuration directory", file)
+		}
+	}
+	return nil
+}
+
+// Init initializes the configuration's working directory, installing providers and modules and
+// configuring its backend.
+func (r Runner) Init(ctx context.Context) error {
+	_, err := r.run(ctx, "init", "-input=false", "-no-color")
+	return err
+}
+
+// Plan plans the configuration and saves the plan to planFile. The plan's changes are counted
+// from the saved plan, as shown by the CLI's JSON output.
+func (r Runner) Plan(ctx context.Context, planFile string, opts Options) (Plan, error) {
+	args := []string{"plan", "-input=false", "-no-color", "-detailed-exitcode", "-out=" + planFile}
+	for _, file := range opts.VarFiles {
+		args = append(args, "-var-file="+file)
+	}
+	names := make([]string, 0, len(opts.Vars))
+	for name := range opts.Vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, fmt.Sprintf("-var=%s=%s", name, opts.Vars[name]))
+	}
+
+	// With -detailed-exitcode, exit code 2 means the plan succeeded and has changes.
+	_, err := r.run(ctx, args...)
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return Plan{}, nil
+	case !errors.As(err, &exitErr) || exitErr.ExitCode() != 2:
+		return Plan{}, err
+	}
+
+	shown, err := r.run(ctx, "show", "-json", "-no-color", planFile)
+	if err != nil {
+		return Plan{}, err
+	}
+	plan, err := countChanges(shown)
+	if err != nil {
+		return Plan{}, err
+	}
+	data, err := os.ReadFile(planFile)
+	if err != nil {
+		return Plan{}, fmt.Errorf("failed to read plan: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	plan.ID = hex.EncodeToString(sum[:])[:12]
+	plan.HasChanges = true
+	return plan, nil
+}
+
+// Apply applies a plan saved by Plan. The CLI refuses plans made against a state that has changed since.
+func (r Runner) Apply(ctx context.Context, planFile string) error {
+	_, err := r.run(ctx, "apply", "-input=false", "-no-color", "-auto-approve", planFile)
+	return err
+}
+
+// run runs the CLI with the given arguments in the configuration directory and returns its output.
+func (r Runner) run(ctx context.Context, args ...string) ([]byte, error) {
+	binary := r.Binary
+	if binary == "" {
+		binary = BinaryTerraform
+	}
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("terraform applications require the '%s' binary in PATH: %w", binary, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Dir = r.Dir
+	cmd.Env = append(os.Environ(), "TF_IN_AUTOMATION=1", "TF_INPUT=0")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		output := strings.TrimSpace(stderr.String())
+		if output == "" {
+			output = strings.TrimSpace(stdout.String())
+		}
+		if len(output) > maxOutput {
+			output = "..." + output[len(output)-maxOutput:]
+		}
+		return stdout.Bytes(), &commandError{command: binary + " " + args[0], err: err, output: output}
+	}
+	return stdout.Bytes(), nil
+}
+
+// commandError is the error of a CLI command that failed, with the end of its output.
+type commandError struct {
+	command string
+	err     error
+	output  string
+}
+
+func (e *commandError) Error() string {
+	return fmt.Sprintf("%s failed: %v: %s", e.command, e.err, e.output)
+}
+
+func (e *commandError) Unwrap() error {
+	return e.err
+}
+
+// countChanges counts the resource changes in the JSON representation of a plan.
+func countChanges(data []byte) (Plan, error) {
+	var shown struct {
+		ResourceChanges []struct {
+			Change struct {
+				Actions []string `json:"actions"`
+			} `json:"change"`
+		} `json:"resource_changes"`
+	}
+	if err := json.Unmarshal(data, &shown); err != nil {
+		return Plan{}, fmt.Errorf("failed to parse plan: %w", err)
+	}
+
+	var plan Plan
+	for _, rc := range shown.ResourceChanges {
+		for _, action := range rc.Change.Actions {
+			switch action {
+			case "create":
+				plan.Add++
+			case "update":
+				plan.Change++
+			case "delete":
+				plan.Destroy++
+			}
+		}
+	}
+	return plan, nil
+}
@@ -1,30 +1,77 @@
 package utils
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"aeswibon.com/github/gitopsctl/internal/common"
+	"github.com/spf13/cobra"
+)
+
+// ListOutputFormats lists the output formats of list commands. "wide" is the table with all
+// columns, as with --details.
+var ListOutputFormats = []string{"table", "wide", "json", "yaml", "csv"}
 
 // ListOptions holds the options for listing resources.
-// It includes output format, header visibility, detail level, status filter, and sorting options.
+// It includes output format, header visibility, detail level, column selection, status filter,
+// free-text search, and sorting options.
 type ListOptions struct {
 	OutputFormat string
 	NoHeader     bool
 	ShowDetails  bool
+	// Columns selects the columns of table, wide and csv output by name, in order.
+	Columns      []string
 	StatusFilter string
-	SortBy       string
+	// Search keeps the Searchable items matching it, ignoring case.
+	Search string
+	SortBy string
+	// Statuses lists the values accepted by the status filter, besides "all".
+	Statuses []string
+}
+
+// ValidateStatusFilter returns an error if the status filter is not "all" or a comma-separated
+// list of accepted statuses, each optionally negated with '!'. The comparison ignores case.
+func (o ListOptions) ValidateStatusFilter() error {
+	_, err := common.ParseStatusFilter(o.StatusFilter, o.Statuses)
+	return err
+}
+
+// ValidateOutput returns an error if the output format is not one of ListOutputFormats, or columns
+// are selected for json or yaml output. The format is compared ignoring case.
+func (o ListOptions) ValidateOutput() error {
+	format := strings.ToLower(o.OutputFormat)
+	if !slices.Contains(ListOutputFormats, format) {
+		return fmt.Errorf("unsupported output format '%s' (must be %s)", o.OutputFormat, strings.Join(ListOutputFormats, ", "))
+	}
+	if len(o.Columns) > 0 && (format == "json" || format == "yaml") {
+		return fmt.Errorf("--columns cannot be used with %s output", format)
+	}
+	return nil
 }
 
 // AddListFlags adds common flags for listing commands to the provided Cobra command.
-// It includes flags for output format, header visibility, detail level, status filter, and sorting options.
-func AddListFlags(cmd *cobra.Command, opts *ListOptions, defaultSort string) {
-	cmd.Flags().StringVarP(&opts.OutputFormat, "output", "o", "table", "Output format: table, json, yaml")
+// It includes flags for output format, header visibility, detail level, column selection, status
+// filter, free-text search, and sorting options.
+// The statuses are offered for the status filter's help text and shell completion.
+func AddListFlags[S ~string](cmd *cobra.Command, opts *ListOptions, defaultSort string, statuses []S) {
+	opts.Statuses = make([]string, len(statuses))
+	for i, s := range statuses {
+		opts.Statuses[i] = string(s)
+	}
+
+	cmd.Flags().StringVarP(&opts.OutputFormat, "output", "o", "table", "Output format: "+strings.Join(ListOutputFormats, ", "))
 	cmd.Flags().BoolVar(&opts.NoHeader, "no-header", false, "Hide table headers")
 	cmd.Flags().BoolVar(&opts.ShowDetails, "details", false, "Show additional details")
-	cmd.Flags().StringVar(&opts.StatusFilter, "status", "all", "Filter by status: all, active, inactive, error, pending")
+	cmd.Flags().StringSliceVar(&opts.Columns, "columns", nil, "Comma-separated columns of table, wide and csv output, such as name,status (any column of the wide output)")
+	cmd.Flags().StringVar(&opts.StatusFilter, "status", "all", "Filter by status: all, "+strings.Join(opts.Statuses, ", ")+"; comma-separate several, prefix with '!' to exclude")
+	cmd.Flags().StringVar(&opts.Search, "search", "", "Only list applications whose name, repository URL or message contains this text, ignoring case")
 	cmd.Flags().StringVar(&opts.SortBy, "sort-by", defaultSort, "Sort by: name, status, registered")
 
 	cmd.RegisterFlagCompletionFunc("output", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"table", "json", "yaml"}, cobra.ShellCompDirectiveDefault
+		return ListOutputFormats, cobra.ShellCompDirectiveDefault
 	})
 	cmd.RegisterFlagCompletionFunc("status", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"all", "active", "inactive", "error", "pending"}, cobra.ShellCompDirectiveDefault
+		return append([]string{"all"}, opts.Statuses...), cobra.ShellCompDirectiveDefault
 	})
 }
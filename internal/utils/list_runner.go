@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -11,11 +12,23 @@ import (
 )
 
 // Renderable is an interface that defines methods for rendering items in different formats.
+// JSON and YAML output both encode ToJSONMap.
 type Renderable interface {
 	ToTableHeaders(details bool) []string
 	ToTableRow(details bool) []string
 	ToJSONMap() map[string]any
-	ToYAMLString() string
+}
+
+// Recorder is implemented by Renderables that provide full values for csv output.
+type Recorder interface {
+	// ToRecord returns the columns of ToTableRow(true) with values neither shortened nor decorated.
+	ToRecord() []string
+}
+
+// Searchable is implemented by Renderables that the --search flag of list commands can filter.
+type Searchable interface {
+	// MatchesSearch reports whether the item contains term, ignoring case.
+	MatchesSearch(term string) bool
 }
 
 // RunListCommand executes a list command with the provided options.
@@ -27,6 +40,13 @@ func RunListCommand(
 	sortFunc func([]Renderable, string),
 	emptyMessageFunc func(string) error,
 ) error {
+	if err := opts.ValidateStatusFilter(); err != nil {
+		return err
+	}
+	if err := opts.ValidateOutput(); err != nil {
+		return err
+	}
+
 	items, err := loadFunc()
 	if err != nil {
 		// If loadFunc returns an error and also indicates no items,
@@ -38,40 +58,158 @@ func RunListCommand(
 		return err
 	}
 
-	filteredItems := filterFunc(items, opts.StatusFilter)
+	filteredItems := searchItems(filterFunc(items, opts.StatusFilter), opts.Search)
+	if len(filteredItems) == 0 && strings.TrimSpace(opts.Search) != "" {
+		logger.Info("No items found matching the search.", zap.String("search", opts.Search))
+		fmt.Printf("📋 Nothing matches '%s'\n", strings.TrimSpace(opts.Search))
+		return nil
+	}
 	if len(filteredItems) == 0 {
 		logger.Info("No items found matching the specified criteria.")
 		return emptyMessageFunc(opts.StatusFilter)
 	}
 
 	sortFunc(filteredItems, opts.SortBy)
+	return RenderList(filteredItems, opts)
+}
 
-	switch strings.ToLower(opts.OutputFormat) {
+// searchItems returns the items matching term. Items that are not Searchable are kept.
+func searchItems(items []Renderable, term string) []Renderable {
+	if strings.TrimSpace(term) == "" {
+		return items
+	}
+	var matched []Renderable
+	for _, item := range items {
+		if searchable, ok := item.(Searchable); !ok || searchable.MatchesSearch(term) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+// RenderList renders items in the output format of opts, which must be valid.
+//
+// Wide and csv output include every column, as with details. Selected columns are picked from
+// every column, whatever the format, and csv output uses the full values of Recorders.
+func RenderList(items []Renderable, opts ListOptions) error {
+	format := strings.ToLower(opts.OutputFormat)
+	switch format {
 	case "json":
-		return RenderJSON(filteredItems)
+		return RenderJSON(items)
 	case "yaml":
-		return RenderYAML(filteredItems)
-	default:
-		return RenderTable(filteredItems, opts.NoHeader, opts.ShowDetails)
+		return RenderYAML(items)
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	details := opts.ShowDetails || format == "wide" || format == "csv" || len(opts.Columns) > 0
+	headers := items[0].ToTableHeaders(details)
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		if recorder, ok := item.(Recorder); ok && format == "csv" {
+			rows[i] = recorder.ToRecord()
+		} else {
+			rows[i] = item.ToTableRow(details)
+		}
 	}
+	if len(opts.Columns) > 0 {
+		var err error
+		if headers, rows, err = selectColumns(headers, rows, opts.Columns); err != nil {
+			return err
+		}
+	}
+
+	if format == "csv" {
+		return renderCSV(headers, rows, opts.NoHeader)
+	}
+	renderRows(headers, rows, opts.NoHeader)
+	return nil
 }
 
 // RenderTable renders items as a table.
 func RenderTable(items []Renderable, noHeader bool, showDetails bool) error {
+	if len(items) == 0 {
+		return nil
+	}
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = item.ToTableRow(showDetails)
+	}
+	renderRows(items[0].ToTableHeaders(showDetails), rows, noHeader)
+	return nil
+}
+
+// renderRows writes rows as an aligned table, under the headers unless noHeader is set.
+func renderRows(headers []string, rows [][]string, noHeader bool) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.TabIndent)
 	defer w.Flush()
 
-	if !noHeader && len(items) > 0 {
-		fmt.Fprintln(w, strings.Join(items[0].ToTableHeaders(showDetails), "\t"))
-		fmt.Fprintln(w, strings.Join(generateSeparator(items[0].ToTableHeaders(showDetails)), "\t"))
+	if !noHeader {
+		fmt.Fprintln(w, strings.Join(headers, "\t"))
+		fmt.Fprintln(w, strings.Join(generateSeparator(headers), "\t"))
 	}
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+}
 
-	for _, item := range items {
-		fmt.Fprintln(w, strings.Join(item.ToTableRow(showDetails), "\t"))
+// renderCSV writes rows as CSV, under the headers unless noHeader is set.
+func renderCSV(headers []string, rows [][]string, noHeader bool) error {
+	w := csv.NewWriter(os.Stdout)
+	if !noHeader {
+		if err := w.Write(headers); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
 	}
 	return nil
 }
 
+// columnName returns the name a table header is selected by with --columns: lowercase, with
+// hyphens for spaces, such as "repo-url" for "REPO URL".
+func columnName(header string) string {
+	return strings.ReplaceAll(strings.ToLower(header), " ", "-")
+}
+
+// selectColumns returns the headers and rows reduced to the named columns, in the given order.
+// Names are matched as returned by columnName, also accepting underscores for hyphens.
+func selectColumns(headers []string, rows [][]string, columns []string) ([]string, [][]string, error) {
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(column)), "_", "-")
+		indexes[i] = -1
+		for j, header := range headers {
+			if columnName(header) == name {
+				indexes[i] = j
+				break
+			}
+		}
+		if indexes[i] < 0 {
+			available := make([]string, len(headers))
+			for j, header := range headers {
+				available[j] = columnName(header)
+			}
+			return nil, nil, fmt.Errorf("unknown column '%s' (must be %s)", column, strings.Join(available, ", "))
+		}
+	}
+
+	selectedHeaders := make([]string, len(indexes))
+	for i, index := range indexes {
+		selectedHeaders[i] = headers[index]
+	}
+	selectedRows := make([][]string, len(rows))
+	for r, row := range rows {
+		selectedRows[r] = make([]string, len(indexes))
+		for i, index := range indexes {
+			selectedRows[r][i] = row[index]
+		}
+	}
+	return selectedHeaders, selectedRows, nil
+}
+
 func generateSeparator(headers []string) []string {
 	separators := make([]string, len(headers))
 	for i, header := range headers {
@@ -98,16 +236,20 @@ func RenderJSON(items []Renderable) error {
 	return nil
 }
 
-// RenderYAML renders items as YAML.
+// RenderYAML renders items as YAML, with the same fields as RenderJSON.
 func RenderYAML(items []Renderable) error {
-	fmt.Println("items:")
+	yamlItems := make([]map[string]any, 0, len(items))
 	for _, item := range items {
-		// Indent each line of the YAML string
-		lines := strings.Split(item.ToYAMLString(), "\n")
-		for _, line := range lines {
-			fmt.Printf("  %s\n", line)
-		}
+		yamlItems = append(yamlItems, item.ToJSONMap())
+	}
+	response := map[string]any{
+		"items": yamlItems,
+		"total": len(yamlItems),
+	}
+	yamlData, err := MarshalYAML(response)
+	if err != nil {
+		return err
 	}
-	fmt.Printf("total: %d\n", len(items))
+	fmt.Print(string(yamlData))
 	return nil
 }
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// OutputOptions holds the output format for commands that act on a single object.
+// An empty format selects the command's human-readable output.
+type OutputOptions struct {
+	OutputFormat string
+}
+
+// AddOutputFlags adds the --output flag for single-object commands to the provided Cobra command.
+func AddOutputFlags(cmd *cobra.Command, opts *OutputOptions) {
+	cmd.Flags().StringVarP(&opts.OutputFormat, "output", "o", "", "Output format: json, yaml, jsonpath=<template>")
+
+	cmd.RegisterFlagCompletionFunc("output", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json", "yaml", "jsonpath="}, cobra.ShellCompDirectiveNoSpace
+	})
+}
+
+// IsMachineOutput reports whether a machine-readable output format was requested.
+func (o OutputOptions) IsMachineOutput() bool {
+	return strings.TrimSpace(o.OutputFormat) != ""
+}
+
+// Validate checks that the requested output format is supported.
+func (o OutputOptions) Validate() error {
+	format := strings.TrimSpace(o.OutputFormat)
+	switch {
+	case format == "", format == "json", format == "yaml":
+		return nil
+	case strings.HasPrefix(format, "jsonpath="):
+		if strings.TrimPrefix(format, "jsonpath=") == "" {
+			return fmt.Errorf("jsonpath template must not be empty")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format '%s'\nSupported formats: json, yaml, jsonpath=<template>", format)
+	}
+}
+
+// RenderObject writes obj in the requested machine-readable format.
+// Field names follow the object's JSON tags, so a jsonpath template such as
+// '{.status}' selects the same field that appears in the JSON output.
+func RenderObject(opts OutputOptions, obj any) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	format := strings.TrimSpace(opts.OutputFormat)
+	switch {
+	case format == "json":
+		var out bytes.Buffer
+		if err := json.Indent(&out, data, "", "  "); err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Println(out.String())
+		return nil
+	case format == "yaml":
+		yamlData, err := MarshalYAML(json.RawMessage(data))
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(yamlData))
+		return nil
+	case strings.HasPrefix(format, "jsonpath="):
+		return renderJSONPath(strings.TrimPrefix(format, "jsonpath="), data)
+	default:
+		return fmt.Errorf("unsupported output format '%s'", format)
+	}
+}
+
+// renderJSONPath evaluates a kubectl-style jsonpath template against the JSON-encoded object.
+func renderJSONPath(template string, data []byte) error {
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	jp := jsonpath.New("output")
+	if err := jp.Parse(template); err != nil {
+		return fmt.Errorf("invalid jsonpath template '%s': %w", template, err)
+	}
+
+	var out bytes.Buffer
+	if err := jp.Execute(&out, generic); err != nil {
+		return fmt.Errorf("failed to evaluate jsonpath template: %w", err)
+	}
+	fmt.Println(out.String())
+	return nil
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// DefaultWatchInterval is the default refresh interval for watch mode.
+const DefaultWatchInterval = 2 * time.Second
+
+// WatchOptions holds the options for continuously refreshing command output.
+type WatchOptions struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+// AddWatchFlags adds the --watch and --watch-interval flags to the provided Cobra command.
+func AddWatchFlags(cmd *cobra.Command, opts *WatchOptions) {
+	cmd.Flags().BoolVarP(&opts.Enabled, "watch", "w", false, "Watch for changes and refresh the output periodically")
+	cmd.Flags().DurationVar(&opts.Interval, "watch-interval", DefaultWatchInterval, "Refresh interval used with --watch")
+}
+
+// Validate returns an error if watch mode is enabled with outputFormat, the command's output
+// format, and that format is machine-readable. Watch mode clears the screen and prints a header
+// before each refresh, which would corrupt JSON, YAML or CSV output.
+func (o WatchOptions) Validate(outputFormat string) error {
+	if !o.Enabled {
+		return nil
+	}
+	switch format := strings.ToLower(strings.TrimSpace(outputFormat)); format {
+	case "", "table", "wide":
+		return nil
+	default:
+		return fmt.Errorf("--watch cannot be combined with --output %s\nWatch the table output, or run the command repeatedly for machine-readable output", format)
+	}
+}
+
+// RunWatch runs the render function once, or repeatedly when watch mode is enabled.
+// In watch mode the screen is cleared before each refresh and the loop exits on SIGINT or SIGTERM.
+func RunWatch(opts WatchOptions, render func() error) error {
+	if !opts.Enabled {
+		return render()
+	}
+	if opts.Interval <= 0 {
+		return fmt.Errorf("watch interval must be greater than zero")
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		// Clear the screen and move the cursor to the top-left corner
+		fmt.Print("\033[H\033[2J")
+		fmt.Printf("Every %s: %s\n\n", opts.Interval, time.Now().Format("2006-01-02 15:04:05 MST"))
+		if err := render(); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-sigChan:
+			return nil
+		}
+	}
+}